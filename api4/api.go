@@ -124,6 +124,26 @@ type Routes struct {
 	Groups         *mux.Router // 'api/v4/groups'
 
 	Cloud *mux.Router // 'api/v4/cloud'
+
+	Polls *mux.Router // 'api/v4/polls'
+	Poll  *mux.Router // 'api/v4/polls/{poll_id:[A-Za-z0-9]+}'
+
+	Drafts *mux.Router // 'api/v4/drafts'
+
+	ChannelBookmarks *mux.Router // 'api/v4/channels/{channel_id:[A-Za-z0-9]+}/bookmarks'
+	ChannelBookmark  *mux.Router // 'api/v4/channels/{channel_id:[A-Za-z0-9]+}/bookmarks/{bookmark_id:[A-Za-z0-9]+}'
+
+	EmailQueue  *mux.Router // 'api/v4/email_queue'
+	QueuedEmail *mux.Router // 'api/v4/email_queue/{email_id:[A-Za-z0-9]+}'
+
+	KeywordWatches *mux.Router // 'api/v4/users/{user_id:[A-Za-z0-9]+}/keyword_watches'
+	KeywordWatch   *mux.Router // 'api/v4/users/{user_id:[A-Za-z0-9]+}/keyword_watches/{watch_id:[A-Za-z0-9]+}'
+
+	SavedSearches *mux.Router // 'api/v4/users/{user_id:[A-Za-z0-9]+}/saved_searches'
+	SavedSearch   *mux.Router // 'api/v4/users/{user_id:[A-Za-z0-9]+}/saved_searches/{saved_search_id:[A-Za-z0-9]+}'
+
+	PublicLinks *mux.Router // 'api/v4/users/{user_id:[A-Za-z0-9]+}/public_links'
+	PublicLink  *mux.Router // 'api/v4/users/{user_id:[A-Za-z0-9]+}/public_links/{public_link_id:[A-Za-z0-9]+}'
 }
 
 type API struct {
@@ -237,6 +257,26 @@ func Init(configservice configservice.ConfigService, globalOptionsFunc app.AppOp
 
 	api.BaseRoutes.Cloud = api.BaseRoutes.ApiRoot.PathPrefix("/cloud").Subrouter()
 
+	api.BaseRoutes.Polls = api.BaseRoutes.ApiRoot.PathPrefix("/polls").Subrouter()
+	api.BaseRoutes.Poll = api.BaseRoutes.Polls.PathPrefix("/{poll_id:[A-Za-z0-9]+}").Subrouter()
+
+	api.BaseRoutes.Drafts = api.BaseRoutes.ApiRoot.PathPrefix("/drafts").Subrouter()
+
+	api.BaseRoutes.ChannelBookmarks = api.BaseRoutes.Channel.PathPrefix("/bookmarks").Subrouter()
+	api.BaseRoutes.ChannelBookmark = api.BaseRoutes.ChannelBookmarks.PathPrefix("/{bookmark_id:[A-Za-z0-9]+}").Subrouter()
+
+	api.BaseRoutes.EmailQueue = api.BaseRoutes.ApiRoot.PathPrefix("/email_queue").Subrouter()
+	api.BaseRoutes.QueuedEmail = api.BaseRoutes.EmailQueue.PathPrefix("/{email_id:[A-Za-z0-9]+}").Subrouter()
+
+	api.BaseRoutes.KeywordWatches = api.BaseRoutes.User.PathPrefix("/keyword_watches").Subrouter()
+	api.BaseRoutes.KeywordWatch = api.BaseRoutes.KeywordWatches.PathPrefix("/{watch_id:[A-Za-z0-9]+}").Subrouter()
+
+	api.BaseRoutes.SavedSearches = api.BaseRoutes.User.PathPrefix("/saved_searches").Subrouter()
+	api.BaseRoutes.SavedSearch = api.BaseRoutes.SavedSearches.PathPrefix("/{saved_search_id:[A-Za-z0-9]+}").Subrouter()
+
+	api.BaseRoutes.PublicLinks = api.BaseRoutes.User.PathPrefix("/public_links").Subrouter()
+	api.BaseRoutes.PublicLink = api.BaseRoutes.PublicLinks.PathPrefix("/{public_link_id:[A-Za-z0-9]+}").Subrouter()
+
 	api.InitUser()
 	api.InitBot()
 	api.InitTeam()
@@ -273,6 +313,13 @@ func Init(configservice configservice.ConfigService, globalOptionsFunc app.AppOp
 	api.InitGroup()
 	api.InitAction()
 	api.InitCloud()
+	api.InitPoll()
+	api.InitDraft()
+	api.InitChannelBookmark()
+	api.InitEmailQueue()
+	api.InitKeywordWatch()
+	api.InitSavedSearch()
+	api.InitPublicLink()
 
 	root.Handle("/api/v4/{anything:.*}", http.HandlerFunc(api.Handle404))
 
@@ -0,0 +1,233 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/zacmm/zacmm-server/audit"
+	"github.com/zacmm/zacmm-server/model"
+)
+
+func (api *API) InitChannelBookmark() {
+	api.BaseRoutes.ChannelBookmarks.Handle("", api.ApiSessionRequired(getChannelBookmarks)).Methods("GET")
+	api.BaseRoutes.ChannelBookmarks.Handle("", api.ApiSessionRequired(createChannelBookmark)).Methods("POST")
+	api.BaseRoutes.ChannelBookmark.Handle("/patch", api.ApiSessionRequired(patchChannelBookmark)).Methods("PUT")
+	api.BaseRoutes.ChannelBookmark.Handle("/sort_order", api.ApiSessionRequired(updateChannelBookmarkSortOrder)).Methods("POST")
+	api.BaseRoutes.ChannelBookmark.Handle("", api.ApiSessionRequired(deleteChannelBookmark)).Methods("DELETE")
+}
+
+// channelBookmarkPermission picks the public or private channel variant of a
+// bookmark permission. Direct and group message channels use the private one.
+func channelBookmarkPermission(channel *model.Channel, public, private *model.Permission) *model.Permission {
+	if channel.Type == model.CHANNEL_OPEN {
+		return public
+	}
+	return private
+}
+
+// getBookmarkForChannel loads the bookmark from the URL, making sure it belongs
+// to the channel from the URL.
+func getBookmarkForChannel(c *Context) *model.ChannelBookmark {
+	bookmark, err := c.App.GetChannelBookmark(c.Params.BookmarkId)
+	if err != nil {
+		c.Err = err
+		return nil
+	}
+
+	if bookmark.ChannelId != c.Params.ChannelId {
+		c.SetInvalidUrlParam("bookmark_id")
+		return nil
+	}
+
+	return bookmark
+}
+
+func getChannelBookmarks(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToChannel(*c.App.Session(), c.Params.ChannelId, model.PERMISSION_READ_CHANNEL) {
+		c.SetPermissionError(model.PERMISSION_READ_CHANNEL)
+		return
+	}
+
+	bookmarks, err := c.App.GetChannelBookmarks(c.Params.ChannelId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(model.ChannelBookmarksToJson(bookmarks)))
+}
+
+func createChannelBookmark(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId()
+	if c.Err != nil {
+		return
+	}
+
+	bookmark := model.ChannelBookmarkFromJson(r.Body)
+	if bookmark == nil {
+		c.SetInvalidParam("bookmark")
+		return
+	}
+
+	bookmark.ChannelId = c.Params.ChannelId
+	bookmark.OwnerId = c.App.Session().UserId
+
+	auditRec := c.MakeAuditRecord("createChannelBookmark", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("bookmark", bookmark)
+
+	channel, err := c.App.GetChannel(c.Params.ChannelId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	permission := channelBookmarkPermission(channel, model.PERMISSION_ADD_BOOKMARK_PUBLIC_CHANNEL, model.PERMISSION_ADD_BOOKMARK_PRIVATE_CHANNEL)
+	if !c.App.SessionHasPermissionToChannel(*c.App.Session(), channel.Id, permission) {
+		c.SetPermissionError(permission)
+		return
+	}
+
+	rbookmark, err := c.App.CreateChannelBookmark(bookmark)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddMeta("bookmark", rbookmark) // overwrite meta
+
+	w.WriteHeader(http.StatusCreated)
+	w.Write([]byte(rbookmark.ToJson()))
+}
+
+func patchChannelBookmark(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId().RequireBookmarkId()
+	if c.Err != nil {
+		return
+	}
+
+	patch := model.ChannelBookmarkPatchFromJson(r.Body)
+	if patch == nil {
+		c.SetInvalidParam("bookmark")
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("patchChannelBookmark", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+
+	channel, err := c.App.GetChannel(c.Params.ChannelId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	permission := channelBookmarkPermission(channel, model.PERMISSION_EDIT_BOOKMARK_PUBLIC_CHANNEL, model.PERMISSION_EDIT_BOOKMARK_PRIVATE_CHANNEL)
+	if !c.App.SessionHasPermissionToChannel(*c.App.Session(), channel.Id, permission) {
+		c.SetPermissionError(permission)
+		return
+	}
+
+	bookmark := getBookmarkForChannel(c)
+	if c.Err != nil {
+		return
+	}
+	auditRec.AddMeta("bookmark", bookmark)
+
+	rbookmark, err := c.App.PatchChannelBookmark(bookmark, patch)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddMeta("patch", rbookmark)
+
+	w.Write([]byte(rbookmark.ToJson()))
+}
+
+func updateChannelBookmarkSortOrder(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId().RequireBookmarkId()
+	if c.Err != nil {
+		return
+	}
+
+	var newIndex int64
+	if jsonErr := json.NewDecoder(r.Body).Decode(&newIndex); jsonErr != nil {
+		c.SetInvalidParam("sort_order")
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("updateChannelBookmarkSortOrder", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("bookmark_id", c.Params.BookmarkId)
+	auditRec.AddMeta("sort_order", newIndex)
+
+	channel, err := c.App.GetChannel(c.Params.ChannelId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	permission := channelBookmarkPermission(channel, model.PERMISSION_ORDER_BOOKMARK_PUBLIC_CHANNEL, model.PERMISSION_ORDER_BOOKMARK_PRIVATE_CHANNEL)
+	if !c.App.SessionHasPermissionToChannel(*c.App.Session(), channel.Id, permission) {
+		c.SetPermissionError(permission)
+		return
+	}
+
+	bookmarks, err := c.App.UpdateChannelBookmarkSortOrder(c.Params.BookmarkId, channel.Id, newIndex)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+
+	w.Write([]byte(model.ChannelBookmarksToJson(bookmarks)))
+}
+
+func deleteChannelBookmark(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId().RequireBookmarkId()
+	if c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("deleteChannelBookmark", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("bookmark_id", c.Params.BookmarkId)
+
+	channel, err := c.App.GetChannel(c.Params.ChannelId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	permission := channelBookmarkPermission(channel, model.PERMISSION_DELETE_BOOKMARK_PUBLIC_CHANNEL, model.PERMISSION_DELETE_BOOKMARK_PRIVATE_CHANNEL)
+	if !c.App.SessionHasPermissionToChannel(*c.App.Session(), channel.Id, permission) {
+		c.SetPermissionError(permission)
+		return
+	}
+
+	bookmark := getBookmarkForChannel(c)
+	if c.Err != nil {
+		return
+	}
+	auditRec.AddMeta("bookmark", bookmark)
+
+	if err := c.App.DeleteChannelBookmark(bookmark); err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+
+	ReturnStatusOK(w)
+}
@@ -0,0 +1,311 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zacmm/zacmm-server/model"
+)
+
+func TestCreateChannelBookmark(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	bookmark, resp := Client.CreateChannelBookmark(&model.ChannelBookmark{
+		ChannelId:   th.BasicChannel.Id,
+		DisplayName: "docs",
+		Type:        model.CHANNEL_BOOKMARK_LINK,
+		LinkUrl:     "https://example.com/docs",
+	})
+	CheckNoError(t, resp)
+	CheckCreatedStatus(t, resp)
+	require.Equal(t, th.BasicUser.Id, bookmark.OwnerId)
+	require.Equal(t, int64(0), bookmark.SortOrder)
+
+	t.Run("file bookmark", func(t *testing.T) {
+		fileResp, resp := Client.UploadFile([]byte("data"), th.BasicChannel.Id, "test.txt")
+		CheckNoError(t, resp)
+
+		fileBookmark, resp := Client.CreateChannelBookmark(&model.ChannelBookmark{
+			ChannelId:   th.BasicChannel.Id,
+			DisplayName: "file",
+			Type:        model.CHANNEL_BOOKMARK_FILE,
+			FileId:      fileResp.FileInfos[0].Id,
+		})
+		CheckNoError(t, resp)
+		require.Equal(t, int64(1), fileBookmark.SortOrder)
+
+		th.LoginBasic2()
+		defer th.LoginBasic()
+
+		_, resp = Client.GetFileInfo(fileResp.FileInfos[0].Id)
+		CheckNoError(t, resp)
+	})
+
+	t.Run("file bookmarked in several channels", func(t *testing.T) {
+		fileResp, resp := Client.UploadFile([]byte("data"), th.BasicChannel.Id, "test.txt")
+		CheckNoError(t, resp)
+		fileId := fileResp.FileInfos[0].Id
+
+		// the oldest bookmark is in a channel the other user can't read
+		private := th.CreatePrivateChannel()
+		_, resp = Client.CreateChannelBookmark(&model.ChannelBookmark{
+			ChannelId:   private.Id,
+			DisplayName: "file",
+			Type:        model.CHANNEL_BOOKMARK_FILE,
+			FileId:      fileId,
+		})
+		CheckNoError(t, resp)
+
+		th.LoginBasic2()
+		_, resp = Client.GetFileInfo(fileId)
+		CheckForbiddenStatus(t, resp)
+		th.LoginBasic()
+
+		_, resp = Client.CreateChannelBookmark(&model.ChannelBookmark{
+			ChannelId:   th.BasicChannel.Id,
+			DisplayName: "file",
+			Type:        model.CHANNEL_BOOKMARK_FILE,
+			FileId:      fileId,
+		})
+		CheckNoError(t, resp)
+
+		th.LoginBasic2()
+		defer th.LoginBasic()
+
+		_, resp = Client.GetFileInfo(fileId)
+		CheckNoError(t, resp)
+	})
+
+	t.Run("file uploaded by someone else", func(t *testing.T) {
+		th.LoginBasic2()
+		fileResp, resp := Client.UploadFile([]byte("data"), th.BasicChannel.Id, "test.txt")
+		CheckNoError(t, resp)
+		th.LoginBasic()
+
+		_, resp = Client.CreateChannelBookmark(&model.ChannelBookmark{
+			ChannelId:   th.BasicChannel.Id,
+			DisplayName: "file",
+			Type:        model.CHANNEL_BOOKMARK_FILE,
+			FileId:      fileResp.FileInfos[0].Id,
+		})
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("invalid bookmark", func(t *testing.T) {
+		invalid := &model.ChannelBookmark{
+			ChannelId:   th.BasicChannel.Id,
+			DisplayName: "invalid",
+			Type:        model.CHANNEL_BOOKMARK_LINK,
+			LinkUrl:     "not a url",
+		}
+		_, resp := Client.CreateChannelBookmark(invalid)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("without permission", func(t *testing.T) {
+		th.RemovePermissionFromRole(model.PERMISSION_ADD_BOOKMARK_PUBLIC_CHANNEL.Id, model.CHANNEL_USER_ROLE_ID)
+		defer th.AddPermissionToRole(model.PERMISSION_ADD_BOOKMARK_PUBLIC_CHANNEL.Id, model.CHANNEL_USER_ROLE_ID)
+
+		_, resp := Client.CreateChannelBookmark(&model.ChannelBookmark{
+			ChannelId:   th.BasicChannel.Id,
+			DisplayName: "docs",
+			Type:        model.CHANNEL_BOOKMARK_LINK,
+			LinkUrl:     "https://example.com/docs",
+		})
+		CheckForbiddenStatus(t, resp)
+
+		_, resp = Client.CreateChannelBookmark(&model.ChannelBookmark{
+			ChannelId:   th.BasicPrivateChannel.Id,
+			DisplayName: "docs",
+			Type:        model.CHANNEL_BOOKMARK_LINK,
+			LinkUrl:     "https://example.com/docs",
+		})
+		CheckNoError(t, resp)
+	})
+
+	t.Run("not a member of the channel", func(t *testing.T) {
+		channel := th.CreatePrivateChannel()
+		th.LoginBasic2()
+		defer th.LoginBasic()
+
+		_, resp := Client.CreateChannelBookmark(&model.ChannelBookmark{
+			ChannelId:   channel.Id,
+			DisplayName: "docs",
+			Type:        model.CHANNEL_BOOKMARK_LINK,
+			LinkUrl:     "https://example.com/docs",
+		})
+		CheckForbiddenStatus(t, resp)
+	})
+}
+
+func TestGetChannelBookmarks(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	first, resp := Client.CreateChannelBookmark(&model.ChannelBookmark{
+		ChannelId:   th.BasicChannel.Id,
+		DisplayName: "first",
+		Type:        model.CHANNEL_BOOKMARK_LINK,
+		LinkUrl:     "https://example.com/first",
+	})
+	CheckNoError(t, resp)
+	second, resp := Client.CreateChannelBookmark(&model.ChannelBookmark{
+		ChannelId:   th.BasicChannel.Id,
+		DisplayName: "second",
+		Type:        model.CHANNEL_BOOKMARK_LINK,
+		LinkUrl:     "https://example.com/second",
+	})
+	CheckNoError(t, resp)
+
+	bookmarks, resp := Client.GetChannelBookmarks(th.BasicChannel.Id)
+	CheckNoError(t, resp)
+	require.Len(t, bookmarks, 2)
+	require.Equal(t, first.Id, bookmarks[0].Id)
+	require.Equal(t, second.Id, bookmarks[1].Id)
+
+	t.Run("not a member of the channel", func(t *testing.T) {
+		channel := th.CreatePrivateChannel()
+		th.LoginBasic2()
+		defer th.LoginBasic()
+
+		_, resp := Client.GetChannelBookmarks(channel.Id)
+		CheckForbiddenStatus(t, resp)
+	})
+}
+
+func TestPatchChannelBookmark(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	bookmark, resp := Client.CreateChannelBookmark(&model.ChannelBookmark{
+		ChannelId:   th.BasicChannel.Id,
+		DisplayName: "docs",
+		Type:        model.CHANNEL_BOOKMARK_LINK,
+		LinkUrl:     "https://example.com/docs",
+	})
+	CheckNoError(t, resp)
+
+	patched, resp := Client.PatchChannelBookmark(th.BasicChannel.Id, bookmark.Id, &model.ChannelBookmarkPatch{DisplayName: model.NewString("handbook"), Emoji: model.NewString("book")})
+	CheckNoError(t, resp)
+	require.Equal(t, "handbook", patched.DisplayName)
+	require.Equal(t, "book", patched.Emoji)
+	require.Equal(t, bookmark.LinkUrl, patched.LinkUrl)
+
+	t.Run("file cannot be changed", func(t *testing.T) {
+		fileResp, resp := Client.UploadFile([]byte("data"), th.BasicChannel.Id, "test.txt")
+		CheckNoError(t, resp)
+		fileId := fileResp.FileInfos[0].Id
+
+		fileBookmark, resp := Client.CreateChannelBookmark(&model.ChannelBookmark{
+			ChannelId:   th.BasicChannel.Id,
+			DisplayName: "file",
+			Type:        model.CHANNEL_BOOKMARK_FILE,
+			FileId:      fileId,
+		})
+		CheckNoError(t, resp)
+
+		r, err := Client.DoApiPut(Client.GetChannelBookmarkRoute(th.BasicChannel.Id, fileBookmark.Id)+"/patch", `{"file_id": "`+model.NewId()+`", "display_name": "renamed"}`)
+		require.Nil(t, err)
+		patched := model.ChannelBookmarkFromJson(r.Body)
+		r.Body.Close()
+		require.Equal(t, "renamed", patched.DisplayName)
+		require.Equal(t, fileId, patched.FileId)
+	})
+
+	t.Run("bookmark from another channel", func(t *testing.T) {
+		_, resp := Client.PatchChannelBookmark(th.BasicChannel2.Id, bookmark.Id, &model.ChannelBookmarkPatch{DisplayName: model.NewString("handbook")})
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("invalid patch", func(t *testing.T) {
+		_, resp := Client.PatchChannelBookmark(th.BasicChannel.Id, bookmark.Id, &model.ChannelBookmarkPatch{LinkUrl: model.NewString("")})
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("without permission", func(t *testing.T) {
+		th.RemovePermissionFromRole(model.PERMISSION_EDIT_BOOKMARK_PUBLIC_CHANNEL.Id, model.CHANNEL_USER_ROLE_ID)
+		defer th.AddPermissionToRole(model.PERMISSION_EDIT_BOOKMARK_PUBLIC_CHANNEL.Id, model.CHANNEL_USER_ROLE_ID)
+
+		_, resp := Client.PatchChannelBookmark(th.BasicChannel.Id, bookmark.Id, &model.ChannelBookmarkPatch{DisplayName: model.NewString("handbook")})
+		CheckForbiddenStatus(t, resp)
+	})
+}
+
+func TestUpdateChannelBookmarkSortOrder(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	var ids []string
+	for _, name := range []string{"a", "b", "c"} {
+		bookmark, resp := Client.CreateChannelBookmark(&model.ChannelBookmark{
+			ChannelId:   th.BasicChannel.Id,
+			DisplayName: name,
+			Type:        model.CHANNEL_BOOKMARK_LINK,
+			LinkUrl:     "https://example.com/" + name,
+		})
+		CheckNoError(t, resp)
+		ids = append(ids, bookmark.Id)
+	}
+
+	bookmarks, resp := Client.UpdateChannelBookmarkSortOrder(th.BasicChannel.Id, ids[2], 0)
+	CheckNoError(t, resp)
+	require.Len(t, bookmarks, 3)
+	require.Equal(t, ids[2], bookmarks[0].Id)
+	require.Equal(t, ids[0], bookmarks[1].Id)
+	require.Equal(t, ids[1], bookmarks[2].Id)
+
+	t.Run("out of range", func(t *testing.T) {
+		_, resp := Client.UpdateChannelBookmarkSortOrder(th.BasicChannel.Id, ids[2], 3)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("without permission", func(t *testing.T) {
+		th.RemovePermissionFromRole(model.PERMISSION_ORDER_BOOKMARK_PUBLIC_CHANNEL.Id, model.CHANNEL_USER_ROLE_ID)
+		defer th.AddPermissionToRole(model.PERMISSION_ORDER_BOOKMARK_PUBLIC_CHANNEL.Id, model.CHANNEL_USER_ROLE_ID)
+
+		_, resp := Client.UpdateChannelBookmarkSortOrder(th.BasicChannel.Id, ids[2], 1)
+		CheckForbiddenStatus(t, resp)
+	})
+}
+
+func TestDeleteChannelBookmark(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	bookmark, resp := Client.CreateChannelBookmark(&model.ChannelBookmark{
+		ChannelId:   th.BasicChannel.Id,
+		DisplayName: "docs",
+		Type:        model.CHANNEL_BOOKMARK_LINK,
+		LinkUrl:     "https://example.com/docs",
+	})
+	CheckNoError(t, resp)
+
+	t.Run("without permission", func(t *testing.T) {
+		th.RemovePermissionFromRole(model.PERMISSION_DELETE_BOOKMARK_PUBLIC_CHANNEL.Id, model.CHANNEL_USER_ROLE_ID)
+		defer th.AddPermissionToRole(model.PERMISSION_DELETE_BOOKMARK_PUBLIC_CHANNEL.Id, model.CHANNEL_USER_ROLE_ID)
+
+		_, resp := Client.DeleteChannelBookmark(th.BasicChannel.Id, bookmark.Id)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	ok, resp := Client.DeleteChannelBookmark(th.BasicChannel.Id, bookmark.Id)
+	CheckNoError(t, resp)
+	require.True(t, ok)
+
+	bookmarks, resp := Client.GetChannelBookmarks(th.BasicChannel.Id)
+	CheckNoError(t, resp)
+	require.Empty(t, bookmarks)
+
+	_, resp = Client.DeleteChannelBookmark(th.BasicChannel.Id, bookmark.Id)
+	CheckNotFoundStatus(t, resp)
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"net/http"
+
+	"github.com/zacmm/zacmm-server/model"
+)
+
+func (api *API) InitDraft() {
+	api.BaseRoutes.Drafts.Handle("", api.ApiSessionRequired(upsertDraft)).Methods("POST")
+	api.BaseRoutes.TeamForUser.Handle("/drafts", api.ApiSessionRequired(getDraftsForUser)).Methods("GET")
+	api.BaseRoutes.ChannelForUser.Handle("/drafts", api.ApiSessionRequired(deleteDraft)).Methods("DELETE")
+	api.BaseRoutes.ChannelForUser.Handle("/drafts/{thread_id:[A-Za-z0-9]+}", api.ApiSessionRequired(deleteDraft)).Methods("DELETE")
+}
+
+func upsertDraft(c *Context, w http.ResponseWriter, r *http.Request) {
+	draft := model.DraftFromJson(r.Body)
+	if draft == nil {
+		c.SetInvalidParam("draft")
+		return
+	}
+
+	draft.UserId = c.App.Session().UserId
+
+	if !c.App.SessionHasPermissionToChannel(*c.App.Session(), draft.ChannelId, model.PERMISSION_CREATE_POST) {
+		c.SetPermissionError(model.PERMISSION_CREATE_POST)
+		return
+	}
+
+	rdraft, err := c.App.UpsertDraft(draft)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(rdraft.ToJson()))
+}
+
+func getDraftsForUser(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId().RequireTeamId()
+	if c.Err != nil {
+		return
+	}
+
+	// Drafts are private to their author, so not even admins may read them.
+	if c.App.Session().UserId != c.Params.UserId {
+		c.SetPermissionError(model.PERMISSION_EDIT_OTHER_USERS)
+		return
+	}
+
+	if !c.App.SessionHasPermissionToTeam(*c.App.Session(), c.Params.TeamId, model.PERMISSION_VIEW_TEAM) {
+		c.SetPermissionError(model.PERMISSION_VIEW_TEAM)
+		return
+	}
+
+	drafts, err := c.App.GetDraftsForUser(c.Params.UserId, c.Params.TeamId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(model.DraftsToJson(drafts)))
+}
+
+func deleteDraft(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId().RequireChannelId()
+	if c.Err != nil {
+		return
+	}
+
+	if c.Params.ThreadId != "" {
+		c.RequireThreadId()
+		if c.Err != nil {
+			return
+		}
+	}
+
+	if c.App.Session().UserId != c.Params.UserId {
+		c.SetPermissionError(model.PERMISSION_EDIT_OTHER_USERS)
+		return
+	}
+
+	if err := c.App.DeleteDraft(c.Params.UserId, c.Params.ChannelId, c.Params.ThreadId); err != nil {
+		c.Err = err
+		return
+	}
+
+	ReturnStatusOK(w)
+}
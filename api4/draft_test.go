@@ -0,0 +1,115 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zacmm/zacmm-server/model"
+)
+
+func TestUpsertDraft(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	draft := &model.Draft{ChannelId: th.BasicChannel.Id, Message: "draft"}
+
+	rdraft, resp := Client.UpsertDraft(draft)
+	CheckNoError(t, resp)
+	require.Equal(t, th.BasicUser.Id, rdraft.UserId)
+	require.Equal(t, "draft", rdraft.Message)
+
+	t.Run("updating replaces the existing draft", func(t *testing.T) {
+		draft.Message = "updated draft"
+		updated, resp := Client.UpsertDraft(draft)
+		CheckNoError(t, resp)
+		require.Equal(t, rdraft.CreateAt, updated.CreateAt)
+
+		drafts, resp := Client.GetDrafts(th.BasicUser.Id, th.BasicTeam.Id)
+		CheckNoError(t, resp)
+		require.Len(t, drafts, 1)
+		require.Equal(t, "updated draft", drafts[0].Message)
+	})
+
+	t.Run("root post in another channel", func(t *testing.T) {
+		_, resp := Client.UpsertDraft(&model.Draft{ChannelId: th.BasicChannel2.Id, RootId: th.BasicPost.Id, Message: "draft"})
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("no permission to channel", func(t *testing.T) {
+		channel := th.CreatePrivateChannel()
+		th.LoginBasic2()
+		defer th.LoginBasic()
+
+		_, resp := Client.UpsertDraft(&model.Draft{ChannelId: channel.Id, Message: "draft"})
+		CheckForbiddenStatus(t, resp)
+	})
+}
+
+func TestGetDrafts(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	_, resp := Client.UpsertDraft(&model.Draft{ChannelId: th.BasicChannel.Id, Message: "channel draft"})
+	CheckNoError(t, resp)
+	_, resp = Client.UpsertDraft(&model.Draft{ChannelId: th.BasicChannel.Id, RootId: th.BasicPost.Id, Message: "thread draft"})
+	CheckNoError(t, resp)
+
+	drafts, resp := Client.GetDrafts(th.BasicUser.Id, th.BasicTeam.Id)
+	CheckNoError(t, resp)
+	require.Len(t, drafts, 2)
+
+	t.Run("other users cannot read drafts", func(t *testing.T) {
+		_, resp := Client.GetDrafts(th.BasicUser2.Id, th.BasicTeam.Id)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp = th.SystemAdminClient.GetDrafts(th.BasicUser.Id, th.BasicTeam.Id)
+		CheckForbiddenStatus(t, resp)
+	})
+}
+
+func TestDeleteDraft(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	_, resp := Client.UpsertDraft(&model.Draft{ChannelId: th.BasicChannel.Id, Message: "channel draft"})
+	CheckNoError(t, resp)
+	_, resp = Client.UpsertDraft(&model.Draft{ChannelId: th.BasicChannel.Id, RootId: th.BasicPost.Id, Message: "thread draft"})
+	CheckNoError(t, resp)
+
+	ok, resp := Client.DeleteDraft(th.BasicUser.Id, th.BasicChannel.Id, th.BasicPost.Id)
+	CheckNoError(t, resp)
+	require.True(t, ok)
+
+	drafts, resp := Client.GetDrafts(th.BasicUser.Id, th.BasicTeam.Id)
+	CheckNoError(t, resp)
+	require.Len(t, drafts, 1)
+	require.Equal(t, "channel draft", drafts[0].Message)
+
+	t.Run("non-existent draft", func(t *testing.T) {
+		_, resp := Client.DeleteDraft(th.BasicUser.Id, th.BasicChannel.Id, th.BasicPost.Id)
+		CheckNotFoundStatus(t, resp)
+	})
+
+	t.Run("other users cannot delete drafts", func(t *testing.T) {
+		_, resp := th.SystemAdminClient.DeleteDraft(th.BasicUser.Id, th.BasicChannel.Id, "")
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("creating a post deletes the draft", func(t *testing.T) {
+		_, resp := Client.CreatePost(&model.Post{ChannelId: th.BasicChannel.Id, Message: "channel draft"})
+		CheckNoError(t, resp)
+
+		require.Eventually(t, func() bool {
+			drafts, resp := Client.GetDrafts(th.BasicUser.Id, th.BasicTeam.Id)
+			return resp.Error == nil && len(drafts) == 0
+		}, 5*time.Second, 100*time.Millisecond)
+	})
+}
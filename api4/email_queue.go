@@ -0,0 +1,88 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"net/http"
+
+	"github.com/zacmm/zacmm-server/audit"
+	"github.com/zacmm/zacmm-server/model"
+)
+
+func (api *API) InitEmailQueue() {
+	api.BaseRoutes.EmailQueue.Handle("", api.ApiSessionRequired(getQueuedEmails)).Methods("GET")
+	api.BaseRoutes.QueuedEmail.Handle("", api.ApiSessionRequired(getQueuedEmail)).Methods("GET")
+	api.BaseRoutes.QueuedEmail.Handle("/requeue", api.ApiSessionRequired(requeueEmail)).Methods("POST")
+}
+
+func getQueuedEmails(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionTo(*c.App.Session(), model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	status := r.URL.Query().Get("status")
+	if status != "" && !model.IsValidQueuedEmailStatus(status) {
+		c.SetInvalidUrlParam("status")
+		return
+	}
+
+	emails, err := c.App.GetQueuedEmails(status, c.Params.Page, c.Params.PerPage)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	for _, email := range emails {
+		email.Sanitize()
+	}
+
+	w.Write([]byte(model.QueuedEmailListToJson(emails)))
+}
+
+func getQueuedEmail(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireEmailId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(*c.App.Session(), model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	email, err := c.App.GetQueuedEmail(c.Params.EmailId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(email.ToJson()))
+}
+
+func requeueEmail(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireEmailId()
+	if c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("requeueEmail", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("email_id", c.Params.EmailId)
+
+	if !c.App.SessionHasPermissionTo(*c.App.Session(), model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	email, err := c.App.RequeueEmail(c.Params.EmailId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+
+	w.Write([]byte(email.ToJson()))
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zacmm/zacmm-server/model"
+	"github.com/zacmm/zacmm-server/services/mailservice"
+)
+
+func TestEmailQueue(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.EmailSettings.EnableEmailQueue = true })
+
+	user := th.BasicUser
+	mailservice.DeleteMailBox(user.Email)
+	defer mailservice.DeleteMailBox(user.Email)
+
+	th.Client.Logout()
+	success, resp := th.Client.SendPasswordResetEmail(user.Email)
+	CheckNoError(t, resp)
+	require.True(t, success)
+
+	var queued *model.QueuedEmail
+	emails, resp := th.SystemAdminClient.GetQueuedEmails(model.QUEUED_EMAIL_STATUS_SENT, 0, 200)
+	CheckNoError(t, resp)
+	for _, email := range emails {
+		if email.Recipient == user.Email {
+			queued = email
+			break
+		}
+	}
+	require.NotNil(t, queued, "the password reset email should have been queued and sent")
+	require.Empty(t, queued.HtmlBody, "the list of queued emails should not include their body")
+
+	var mailbox mailservice.JSONMessageHeaderInbucket
+	err := mailservice.RetryInbucket(5, func() error {
+		var err error
+		mailbox, err = mailservice.GetMailBox(user.Email)
+		return err
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, mailbox)
+	require.Equal(t, queued.Subject, mailbox[0].Subject)
+
+	t.Run("get queued email", func(t *testing.T) {
+		email, resp := th.SystemAdminClient.GetQueuedEmail(queued.Id)
+		CheckNoError(t, resp)
+		require.Equal(t, queued.Id, email.Id)
+		require.Equal(t, model.QUEUED_EMAIL_STATUS_SENT, email.Status)
+		require.NotEmpty(t, email.HtmlBody)
+
+		_, resp = th.SystemAdminClient.GetQueuedEmail(model.NewId())
+		CheckNotFoundStatus(t, resp)
+
+		_, resp = th.SystemAdminClient.GetQueuedEmail("junk")
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("get queued emails with invalid status", func(t *testing.T) {
+		_, resp := th.SystemAdminClient.GetQueuedEmails("unknown", 0, 60)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("requeue email", func(t *testing.T) {
+		mailservice.DeleteMailBox(user.Email)
+
+		email, resp := th.SystemAdminClient.RequeueEmail(queued.Id)
+		CheckNoError(t, resp)
+		require.Equal(t, model.QUEUED_EMAIL_STATUS_PENDING, email.Status)
+		require.Equal(t, 0, email.Attempts)
+
+		sent, _, appErr := th.App.ProcessEmailQueue()
+		require.Nil(t, appErr)
+		require.GreaterOrEqual(t, sent, 1)
+
+		email, resp = th.SystemAdminClient.GetQueuedEmail(queued.Id)
+		CheckNoError(t, resp)
+		require.Equal(t, model.QUEUED_EMAIL_STATUS_SENT, email.Status)
+
+		err := mailservice.RetryInbucket(5, func() error {
+			var err error
+			mailbox, err = mailservice.GetMailBox(user.Email)
+			return err
+		})
+		require.NoError(t, err)
+		require.NotEmpty(t, mailbox)
+	})
+
+	t.Run("requires system admin", func(t *testing.T) {
+		th.LoginBasic()
+
+		_, resp := th.Client.GetQueuedEmails("", 0, 60)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp = th.Client.GetQueuedEmail(queued.Id)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp = th.Client.RequeueEmail(queued.Id)
+		CheckForbiddenStatus(t, resp)
+	})
+}
@@ -23,9 +23,6 @@ import (
 
 const (
 	FILE_TEAM_ID = "noteam"
-
-	PREVIEW_IMAGE_TYPE   = "image/jpeg"
-	THUMBNAIL_IMAGE_TYPE = "image/jpeg"
 )
 
 var UNSAFE_CONTENT_TYPES = [...]string{
@@ -43,6 +40,8 @@ var MEDIA_CONTENT_TYPES = [...]string{
 	"image/bmp",
 	"image/gif",
 	"image/tiff",
+	"image/webp",
+	"image/avif",
 	"video/avi",
 	"video/mpeg",
 	"video/mp4",
@@ -62,6 +61,8 @@ func (api *API) InitFile() {
 
 	api.BaseRoutes.PublicFile.Handle("", api.ApiHandler(getPublicFile)).Methods("GET")
 
+	api.BaseRoutes.Team.Handle("/files/search", api.ApiSessionRequiredDisableWhenBusy(searchFiles)).Methods("GET")
+
 }
 
 func parseMultipartRequestHeader(req *http.Request) (boundary string, err error) {
@@ -479,7 +480,7 @@ func getFile(c *Context, w http.ResponseWriter, r *http.Request) {
 	}
 	auditRec.AddMeta("file", info)
 
-	if info.CreatorId != c.App.Session().UserId && !c.App.SessionHasPermissionToChannelByPost(*c.App.Session(), info.PostId, model.PERMISSION_READ_CHANNEL) {
+	if !c.App.SessionHasPermissionToFile(*c.App.Session(), info, model.PERMISSION_READ_CHANNEL) {
 		c.SetPermissionError(model.PERMISSION_READ_CHANNEL)
 		return
 	}
@@ -514,7 +515,7 @@ func getFileThumbnail(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if info.CreatorId != c.App.Session().UserId && !c.App.SessionHasPermissionToChannelByPost(*c.App.Session(), info.PostId, model.PERMISSION_READ_CHANNEL) {
+	if !c.App.SessionHasPermissionToFile(*c.App.Session(), info, model.PERMISSION_READ_CHANNEL) {
 		c.SetPermissionError(model.PERMISSION_READ_CHANNEL)
 		return
 	}
@@ -524,7 +525,7 @@ func getFileThumbnail(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	fileReader, err := c.App.FileReader(info.ThumbnailPath)
+	fileReader, contentType, err := c.App.FilePreviewReader(info.ThumbnailPath, r.Header.Get("Accept"))
 	if err != nil {
 		c.Err = err
 		c.Err.StatusCode = http.StatusNotFound
@@ -532,7 +533,8 @@ func getFileThumbnail(c *Context, w http.ResponseWriter, r *http.Request) {
 	}
 	defer fileReader.Close()
 
-	err = writeFileResponse(info.Name, THUMBNAIL_IMAGE_TYPE, 0, time.Unix(0, info.UpdateAt*int64(1000*1000)), *c.App.Config().ServiceSettings.WebserverMode, fileReader, forceDownload, w, r)
+	w.Header().Set("Vary", "Accept")
+	err = writeFileResponse(info.Name, contentType, 0, time.Unix(0, info.UpdateAt*int64(1000*1000)), *c.App.Config().ServiceSettings.WebserverMode, fileReader, forceDownload, w, r)
 	if err != nil {
 		c.Err = err
 		return
@@ -560,7 +562,7 @@ func getFileLink(c *Context, w http.ResponseWriter, r *http.Request) {
 	}
 	auditRec.AddMeta("file", info)
 
-	if info.CreatorId != c.App.Session().UserId && !c.App.SessionHasPermissionToChannelByPost(*c.App.Session(), info.PostId, model.PERMISSION_READ_CHANNEL) {
+	if !c.App.SessionHasPermissionToFile(*c.App.Session(), info, model.PERMISSION_READ_CHANNEL) {
 		c.SetPermissionError(model.PERMISSION_READ_CHANNEL)
 		return
 	}
@@ -570,8 +572,13 @@ func getFileLink(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	link, err := c.App.GeneratePublicLink(c.GetSiteURLHeader(), info, c.App.Session().UserId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
 	resp := make(map[string]string)
-	link := c.App.GeneratePublicLink(c.GetSiteURLHeader(), info)
 	resp["link"] = link
 
 	auditRec.Success()
@@ -593,7 +600,7 @@ func getFilePreview(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if info.CreatorId != c.App.Session().UserId && !c.App.SessionHasPermissionToChannelByPost(*c.App.Session(), info.PostId, model.PERMISSION_READ_CHANNEL) {
+	if !c.App.SessionHasPermissionToFile(*c.App.Session(), info, model.PERMISSION_READ_CHANNEL) {
 		c.SetPermissionError(model.PERMISSION_READ_CHANNEL)
 		return
 	}
@@ -603,7 +610,7 @@ func getFilePreview(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	fileReader, err := c.App.FileReader(info.PreviewPath)
+	fileReader, contentType, err := c.App.FilePreviewReader(info.PreviewPath, r.Header.Get("Accept"))
 	if err != nil {
 		c.Err = err
 		c.Err.StatusCode = http.StatusNotFound
@@ -611,7 +618,8 @@ func getFilePreview(c *Context, w http.ResponseWriter, r *http.Request) {
 	}
 	defer fileReader.Close()
 
-	err = writeFileResponse(info.Name, PREVIEW_IMAGE_TYPE, 0, time.Unix(0, info.UpdateAt*int64(1000*1000)), *c.App.Config().ServiceSettings.WebserverMode, fileReader, forceDownload, w, r)
+	w.Header().Set("Vary", "Accept")
+	err = writeFileResponse(info.Name, contentType, 0, time.Unix(0, info.UpdateAt*int64(1000*1000)), *c.App.Config().ServiceSettings.WebserverMode, fileReader, forceDownload, w, r)
 	if err != nil {
 		c.Err = err
 		return
@@ -630,7 +638,7 @@ func getFileInfo(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if info.CreatorId != c.App.Session().UserId && !c.App.SessionHasPermissionToChannelByPost(*c.App.Session(), info.PostId, model.PERMISSION_READ_CHANNEL) {
+	if !c.App.SessionHasPermissionToFile(*c.App.Session(), info, model.PERMISSION_READ_CHANNEL) {
 		c.SetPermissionError(model.PERMISSION_READ_CHANNEL)
 		return
 	}
@@ -650,6 +658,10 @@ func getPublicFile(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	auditRec := c.MakeAuditRecord("getPublicFile", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("file_id", c.Params.FileId)
+
 	info, err := c.App.GetFileInfo(c.Params.FileId)
 	if err != nil {
 		c.Err = err
@@ -664,7 +676,22 @@ func getPublicFile(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if subtle.ConstantTimeCompare([]byte(hash), []byte(app.GeneratePublicLinkHash(info.Id, *c.App.Config().FileSettings.PublicLinkSalt))) != 1 {
+	// Links created before they were stored only carry a hash of the file id,
+	// so they can't be revoked one by one.
+	if linkId := r.URL.Query().Get("l"); linkId != "" {
+		link, err := c.App.UsePublicLink(info.Id, linkId, hash)
+		if err != nil {
+			c.Err = err
+			utils.RenderWebAppError(c.App.Config(), w, r, c.Err, c.App.AsymmetricSigningKey())
+			return
+		}
+		auditRec.AddMeta("public_link_id", link.Id)
+		auditRec.AddMeta("creator_id", link.CreatorId)
+	} else if !*c.App.Config().FileSettings.EnableLegacyPublicLinks {
+		c.Err = model.NewAppError("getPublicFile", "api.file.get_file.public_legacy_disabled.app_error", nil, "", http.StatusGone)
+		utils.RenderWebAppError(c.App.Config(), w, r, c.Err, c.App.AsymmetricSigningKey())
+		return
+	} else if subtle.ConstantTimeCompare([]byte(hash), []byte(app.GeneratePublicLinkHash(info.Id, *c.App.Config().FileSettings.PublicLinkSalt))) != 1 {
 		c.Err = model.NewAppError("getPublicFile", "api.file.get_file.public_invalid.app_error", nil, "", http.StatusBadRequest)
 		utils.RenderWebAppError(c.App.Config(), w, r, c.Err, c.App.AsymmetricSigningKey())
 		return
@@ -683,6 +710,8 @@ func getPublicFile(c *Context, w http.ResponseWriter, r *http.Request) {
 		c.Err = err
 		return
 	}
+
+	auditRec.Success()
 }
 
 func writeFileResponse(filename string, contentType string, contentSize int64, lastModification time.Time, webserverMode string, fileReader io.ReadSeeker, forceDownload bool, w http.ResponseWriter, r *http.Request) *model.AppError {
@@ -743,3 +772,45 @@ func writeFileResponse(filename string, contentType string, contentSize int64, l
 
 	return nil
 }
+
+func searchFiles(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireTeamId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToTeam(*c.App.Session(), c.Params.TeamId, model.PERMISSION_VIEW_TEAM) {
+		c.SetPermissionError(model.PERMISSION_VIEW_TEAM)
+		return
+	}
+
+	query := r.URL.Query()
+
+	terms := query.Get("terms")
+	if terms == "" {
+		c.SetInvalidParam("terms")
+		return
+	}
+
+	timeZoneOffset := 0
+	if val := query.Get("time_zone_offset"); val != "" {
+		offset, err := strconv.Atoi(val)
+		if err != nil {
+			c.SetInvalidUrlParam("time_zone_offset")
+			return
+		}
+		timeZoneOffset = offset
+	}
+
+	isOrSearch, _ := strconv.ParseBool(query.Get("is_or_search"))
+	includeDeletedChannels, _ := strconv.ParseBool(query.Get("include_deleted_channels"))
+
+	results, err := c.App.SearchFilesInTeamForUser(terms, c.App.Session().UserId, c.Params.TeamId, isOrSearch, includeDeletedChannels, timeZoneOffset, c.Params.Page, c.Params.PerPage)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
+	w.Write([]byte(results.ToJson()))
+}
@@ -821,6 +821,62 @@ func TestGetFileThumbnail(t *testing.T) {
 	CheckNoError(t, resp)
 }
 
+func TestGetFilePreviewFormats(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	if *th.App.Config().FileSettings.DriverName == "" {
+		t.Skip("skipping because no file driver is enabled")
+	}
+
+	dir, err := ioutil.TempDir("", "ffmpeg")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	script := filepath.Join(dir, "ffmpeg")
+	require.NoError(t, ioutil.WriteFile(script, []byte("#!/bin/sh\nfor last; do :; done; printf converted > \"$last\"\n"), 0700))
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.FileSettings.FFmpegPath = script
+		cfg.FileSettings.PreviewFormats = []string{model.PREVIEW_FORMAT_WEBP}
+	})
+
+	sent, err := testutils.ReadTestFile("test.png")
+	require.NoError(t, err)
+	fileResp, resp := Client.UploadFile(sent, th.BasicChannel.Id, "test.png")
+	CheckNoError(t, resp)
+	fileId := fileResp.FileInfos[0].Id
+
+	get := func(t *testing.T, route, accept string) (*http.Response, []byte) {
+		req, err := http.NewRequest("GET", Client.ApiUrl+Client.GetFileRoute(fileId)+route, nil)
+		require.NoError(t, err)
+		req.Header.Set(model.HEADER_AUTH, Client.AuthType+" "+Client.AuthToken)
+		req.Header.Set("Accept", accept)
+
+		httpResp, err := Client.HttpClient.Do(req)
+		require.NoError(t, err)
+		defer closeBody(httpResp)
+		require.Equal(t, http.StatusOK, httpResp.StatusCode)
+
+		data, err := ioutil.ReadAll(httpResp.Body)
+		require.NoError(t, err)
+		return httpResp, data
+	}
+
+	for _, route := range []string{"/thumbnail", "/preview"} {
+		t.Run(route, func(t *testing.T) {
+			httpResp, data := get(t, route, "image/avif,image/webp,*/*;q=0.8")
+			assert.Equal(t, "image/webp", httpResp.Header.Get("Content-Type"))
+			assert.Equal(t, "Accept", httpResp.Header.Get("Vary"))
+			assert.Equal(t, []byte("converted"), data)
+
+			httpResp, data = get(t, route, "image/*")
+			assert.Equal(t, "image/jpeg", httpResp.Header.Get("Content-Type"))
+			assert.NotEqual(t, []byte("converted"), data)
+		})
+	}
+}
+
 func TestGetFileLink(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
@@ -973,6 +1029,86 @@ func TestGetFileInfo(t *testing.T) {
 	CheckNoError(t, resp)
 }
 
+func TestSearchFiles(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	if *th.App.Config().FileSettings.DriverName == "" {
+		t.Skip("skipping because no file driver is enabled")
+	}
+
+	fileResp, resp := Client.UploadFile([]byte("quarterly numbers"), th.BasicChannel.Id, "quarterly report.txt")
+	CheckNoError(t, resp)
+	fileId := fileResp.FileInfos[0].Id
+
+	_, resp = Client.CreatePost(&model.Post{
+		ChannelId: th.BasicChannel.Id,
+		Message:   "see attached",
+		FileIds:   model.StringArray{fileId},
+	})
+	CheckNoError(t, resp)
+
+	t.Run("should find the file by name", func(t *testing.T) {
+		list, resp := Client.SearchFiles(th.BasicTeam.Id, "quarterly", false)
+		CheckNoError(t, resp)
+		require.Len(t, list.Order, 1)
+		require.Equal(t, fileId, list.Order[0])
+		require.Equal(t, "", list.FileInfos[fileId].Path, "file path shouldn't have been returned to client")
+	})
+
+	t.Run("should filter by extension", func(t *testing.T) {
+		list, resp := Client.SearchFiles(th.BasicTeam.Id, "quarterly ext:txt", false)
+		CheckNoError(t, resp)
+		require.Len(t, list.Order, 1)
+
+		list, resp = Client.SearchFiles(th.BasicTeam.Id, "quarterly ext:pdf", false)
+		CheckNoError(t, resp)
+		require.Empty(t, list.Order)
+	})
+
+	t.Run("should filter by channel and user", func(t *testing.T) {
+		list, resp := Client.SearchFiles(th.BasicTeam.Id, "quarterly in:"+th.BasicChannel.Name+" from:"+th.BasicUser.Username, false)
+		CheckNoError(t, resp)
+		require.Len(t, list.Order, 1)
+
+		list, resp = Client.SearchFiles(th.BasicTeam.Id, "quarterly in:"+th.BasicChannel2.Name, false)
+		CheckNoError(t, resp)
+		require.Empty(t, list.Order)
+	})
+
+	t.Run("should not find files in channels the user is not a member of", func(t *testing.T) {
+		appErr := th.App.RemoveUserFromChannel(th.BasicUser2.Id, "", th.BasicChannel)
+		require.Nil(t, appErr)
+
+		th.LoginBasic2()
+		defer th.LoginBasic()
+
+		list, resp := Client.SearchFiles(th.BasicTeam.Id, "quarterly", false)
+		CheckNoError(t, resp)
+		require.Empty(t, list.Order)
+	})
+
+	t.Run("should require terms", func(t *testing.T) {
+		_, resp := Client.SearchFiles(th.BasicTeam.Id, "", false)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("should require team membership", func(t *testing.T) {
+		otherTeam := th.CreateTeam()
+		_, resp := Client.SearchFiles(otherTeam.Id, "quarterly", false)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("should fail when file search is disabled", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableFileSearch = false })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableFileSearch = true })
+
+		_, resp := Client.SearchFiles(th.BasicTeam.Id, "quarterly", false)
+		CheckNotImplementedStatus(t, resp)
+	})
+}
+
 func TestGetPublicFile(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
@@ -996,16 +1132,50 @@ func TestGetPublicFile(t *testing.T) {
 
 	info, err := th.App.Srv().Store.FileInfo().Get(fileId)
 	require.Nil(t, err)
-	link := th.App.GeneratePublicLink(Client.Url, info)
+	link, appErr := th.App.GeneratePublicLink(Client.Url, info, th.BasicUser.Id)
+	require.Nil(t, appErr)
 
 	resp, err := http.Get(link)
 	require.NoError(t, err)
 	require.Equal(t, http.StatusOK, resp.StatusCode, "failed to get image with public link")
 
-	resp, err = http.Get(link[:strings.LastIndex(link, "?")])
+	resp, err = http.Get(link[:strings.LastIndex(link, "&")])
 	require.NoError(t, err)
 	require.Equal(t, http.StatusBadRequest, resp.StatusCode, "should've failed to get image with public link without hash", resp.Status)
 
+	legacyLink := Client.Url + "/files/" + fileId + "/public?h=" + app.GeneratePublicLinkHash(fileId, *th.App.Config().FileSettings.PublicLinkSalt)
+	resp, err = http.Get(legacyLink)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode, "failed to get image with legacy public link")
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.FileSettings.EnableLegacyPublicLinks = false })
+	resp, err = http.Get(legacyLink)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusGone, resp.StatusCode, "should've failed to get image with legacy public link when disabled")
+	resp, err = http.Get(link)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode, "failed to get image with public link when legacy links are disabled")
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.FileSettings.EnableLegacyPublicLinks = true })
+
+	expiredLink, appErr := th.App.CreatePublicLink(Client.Url, info, th.BasicUser.Id, model.GetMillis()+100)
+	require.Nil(t, appErr)
+	time.Sleep(200 * time.Millisecond)
+	resp, err = http.Get(expiredLink.Link)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusGone, resp.StatusCode, "should've failed to get image with expired public link")
+
+	revokedLink, appErr := th.App.CreatePublicLink(Client.Url, info, th.BasicUser.Id, 0)
+	require.Nil(t, appErr)
+	require.Nil(t, th.App.RevokePublicLink(revokedLink.Id))
+	resp, err = http.Get(revokedLink.Link)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusGone, resp.StatusCode, "should've failed to get image with revoked public link")
+
+	otherLink := strings.Replace(link, fileId, th.BasicPost.Id, 1)
+	resp, err = http.Get(otherLink)
+	require.NoError(t, err)
+	require.NotEqual(t, http.StatusOK, resp.StatusCode, "should've failed to get another file with the public link")
+
 	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.FileSettings.EnablePublicLink = false })
 
 	resp, err = http.Get(link)
@@ -1029,7 +1199,8 @@ func TestGetPublicFile(t *testing.T) {
 	require.Nil(t, th.cleanupTestFile(fileInfo))
 
 	th.cleanupTestFile(info)
-	link = th.App.GeneratePublicLink(Client.Url, info)
+	link, appErr = th.App.GeneratePublicLink(Client.Url, info, th.BasicUser.Id)
+	require.Nil(t, appErr)
 	resp, err = http.Get(link)
 	require.Nil(t, err)
 	require.Equal(t, http.StatusNotFound, resp.StatusCode, "should've failed to get file after it is deleted")
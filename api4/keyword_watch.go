@@ -0,0 +1,191 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"net/http"
+
+	"github.com/zacmm/zacmm-server/audit"
+	"github.com/zacmm/zacmm-server/model"
+)
+
+func (api *API) InitKeywordWatch() {
+	api.BaseRoutes.KeywordWatches.Handle("", api.ApiSessionRequired(getKeywordWatches)).Methods("GET")
+	api.BaseRoutes.KeywordWatches.Handle("", api.ApiSessionRequired(createKeywordWatch)).Methods("POST")
+	api.BaseRoutes.KeywordWatch.Handle("", api.ApiSessionRequired(updateKeywordWatch)).Methods("PUT")
+	api.BaseRoutes.KeywordWatch.Handle("", api.ApiSessionRequired(deleteKeywordWatch)).Methods("DELETE")
+}
+
+func requireKeywordWatchesEnabled(c *Context) {
+	if !*c.App.Config().ServiceSettings.EnableKeywordWatches {
+		c.Err = model.NewAppError("requireKeywordWatchesEnabled", "api.keyword_watch.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+}
+
+// getKeywordWatchForUser returns the watch of the URL, making sure that it
+// belongs to the user of the URL.
+func getKeywordWatchForUser(c *Context) *model.KeywordWatch {
+	watch, err := c.App.GetKeywordWatch(c.Params.WatchId)
+	if err != nil {
+		c.Err = err
+		return nil
+	}
+
+	if watch.UserId != c.Params.UserId {
+		c.Err = model.NewAppError("getKeywordWatchForUser", "app.keyword_watch.get.app_error", nil, "", http.StatusNotFound)
+		return nil
+	}
+
+	return watch
+}
+
+func getKeywordWatches(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	if requireKeywordWatchesEnabled(c); c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToUser(*c.App.Session(), c.Params.UserId) {
+		c.SetPermissionError(model.PERMISSION_EDIT_OTHER_USERS)
+		return
+	}
+
+	watches, err := c.App.GetKeywordWatchesForUser(c.Params.UserId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(model.KeywordWatchListToJson(watches)))
+}
+
+func createKeywordWatch(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	if requireKeywordWatchesEnabled(c); c.Err != nil {
+		return
+	}
+
+	watch := model.KeywordWatchFromJson(r.Body)
+	if watch == nil {
+		c.SetInvalidParam("keyword_watch")
+		return
+	}
+
+	if watch.UserId != "" && watch.UserId != c.Params.UserId {
+		c.SetInvalidParam("user_id")
+		return
+	}
+	watch.UserId = c.Params.UserId
+
+	auditRec := c.MakeAuditRecord("createKeywordWatch", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("user_id", c.Params.UserId)
+	auditRec.AddMeta("team_id", watch.TeamId)
+
+	if !c.App.SessionHasPermissionToUser(*c.App.Session(), c.Params.UserId) {
+		c.SetPermissionError(model.PERMISSION_EDIT_OTHER_USERS)
+		return
+	}
+
+	rwatch, err := c.App.CreateKeywordWatch(watch)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddMeta("watch_id", rwatch.Id)
+
+	w.WriteHeader(http.StatusCreated)
+	w.Write([]byte(rwatch.ToJson()))
+}
+
+func updateKeywordWatch(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId().RequireWatchId()
+	if c.Err != nil {
+		return
+	}
+
+	if requireKeywordWatchesEnabled(c); c.Err != nil {
+		return
+	}
+
+	watch := model.KeywordWatchFromJson(r.Body)
+	if watch == nil {
+		c.SetInvalidParam("keyword_watch")
+		return
+	}
+
+	if watch.Id != "" && watch.Id != c.Params.WatchId {
+		c.SetInvalidParam("id")
+		return
+	}
+	watch.Id = c.Params.WatchId
+
+	auditRec := c.MakeAuditRecord("updateKeywordWatch", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("user_id", c.Params.UserId)
+	auditRec.AddMeta("watch_id", c.Params.WatchId)
+
+	if !c.App.SessionHasPermissionToUser(*c.App.Session(), c.Params.UserId) {
+		c.SetPermissionError(model.PERMISSION_EDIT_OTHER_USERS)
+		return
+	}
+
+	if getKeywordWatchForUser(c); c.Err != nil {
+		return
+	}
+
+	rwatch, err := c.App.UpdateKeywordWatch(watch)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+
+	w.Write([]byte(rwatch.ToJson()))
+}
+
+func deleteKeywordWatch(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId().RequireWatchId()
+	if c.Err != nil {
+		return
+	}
+
+	if requireKeywordWatchesEnabled(c); c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("deleteKeywordWatch", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("user_id", c.Params.UserId)
+	auditRec.AddMeta("watch_id", c.Params.WatchId)
+
+	if !c.App.SessionHasPermissionToUser(*c.App.Session(), c.Params.UserId) {
+		c.SetPermissionError(model.PERMISSION_EDIT_OTHER_USERS)
+		return
+	}
+
+	if getKeywordWatchForUser(c); c.Err != nil {
+		return
+	}
+
+	if err := c.App.DeleteKeywordWatch(c.Params.WatchId); err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+
+	ReturnStatusOK(w)
+}
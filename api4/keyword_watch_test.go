@@ -0,0 +1,97 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zacmm/zacmm-server/model"
+)
+
+func TestKeywordWatches(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	t.Run("disabled", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableKeywordWatches = false })
+
+		_, resp := Client.GetKeywordWatches(th.BasicUser.Id)
+		CheckNotImplementedStatus(t, resp)
+	})
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableKeywordWatches = true })
+
+	watch, resp := Client.CreateKeywordWatch(th.BasicUser.Id, &model.KeywordWatch{TeamId: th.BasicTeam.Id, Pattern: "outage"})
+	CheckNoError(t, resp)
+	CheckCreatedStatus(t, resp)
+	assert.Equal(t, th.BasicUser.Id, watch.UserId)
+	assert.Equal(t, model.KEYWORD_WATCH_DELIVERY_NOTIFICATION, watch.Delivery)
+
+	t.Run("create", func(t *testing.T) {
+		_, resp := Client.CreateKeywordWatch(th.BasicUser.Id, &model.KeywordWatch{TeamId: th.BasicTeam.Id, Pattern: "CVE-(", IsRegex: true})
+		CheckBadRequestStatus(t, resp)
+
+		_, resp = Client.CreateKeywordWatch(th.BasicUser.Id, &model.KeywordWatch{TeamId: model.NewId(), Pattern: "outage"})
+		CheckBadRequestStatus(t, resp)
+
+		_, resp = Client.CreateKeywordWatch(th.BasicUser.Id, &model.KeywordWatch{UserId: th.BasicUser2.Id, TeamId: th.BasicTeam.Id, Pattern: "outage"})
+		CheckBadRequestStatus(t, resp)
+
+		_, resp = Client.CreateKeywordWatch(th.BasicUser2.Id, &model.KeywordWatch{TeamId: th.BasicTeam.Id, Pattern: "outage"})
+		CheckForbiddenStatus(t, resp)
+
+		_, resp = th.SystemAdminClient.CreateKeywordWatch(th.BasicUser2.Id, &model.KeywordWatch{TeamId: th.BasicTeam.Id, Pattern: "outage"})
+		CheckNoError(t, resp)
+	})
+
+	t.Run("limit", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.MaxKeywordWatchesPerUser = 1 })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.MaxKeywordWatchesPerUser = 25 })
+
+		_, resp := Client.CreateKeywordWatch(th.BasicUser.Id, &model.KeywordWatch{TeamId: th.BasicTeam.Id, Pattern: "incident"})
+		CheckBadRequestStatus(t, resp)
+		CheckErrorMessage(t, resp, "app.keyword_watch.create.limit.app_error")
+	})
+
+	t.Run("get", func(t *testing.T) {
+		watches, resp := Client.GetKeywordWatches(th.BasicUser.Id)
+		CheckNoError(t, resp)
+		require.Len(t, watches, 1)
+		assert.Equal(t, watch.Id, watches[0].Id)
+
+		_, resp = Client.GetKeywordWatches(th.BasicUser2.Id)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		updated, resp := Client.UpdateKeywordWatch(th.BasicUser.Id, &model.KeywordWatch{Id: watch.Id, Pattern: `CVE-\d+`, IsRegex: true, Delivery: model.KEYWORD_WATCH_DELIVERY_DIGEST})
+		CheckNoError(t, resp)
+		assert.Equal(t, `CVE-\d+`, updated.Pattern)
+		assert.True(t, updated.IsRegex)
+		assert.Equal(t, model.KEYWORD_WATCH_DELIVERY_DIGEST, updated.Delivery)
+		assert.Equal(t, th.BasicTeam.Id, updated.TeamId)
+
+		_, resp = Client.UpdateKeywordWatch(th.BasicUser.Id, &model.KeywordWatch{Id: watch.Id, Delivery: "pager", Pattern: "outage"})
+		CheckBadRequestStatus(t, resp)
+
+		_, resp = th.SystemAdminClient.UpdateKeywordWatch(th.BasicUser2.Id, &model.KeywordWatch{Id: watch.Id, Pattern: "outage"})
+		CheckNotFoundStatus(t, resp)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		_, resp := th.SystemAdminClient.DeleteKeywordWatch(th.BasicUser2.Id, watch.Id)
+		CheckNotFoundStatus(t, resp)
+
+		ok, resp := Client.DeleteKeywordWatch(th.BasicUser.Id, watch.Id)
+		CheckNoError(t, resp)
+		assert.True(t, ok)
+
+		_, resp = Client.DeleteKeywordWatch(th.BasicUser.Id, watch.Id)
+		CheckNotFoundStatus(t, resp)
+	})
+}
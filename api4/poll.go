@@ -0,0 +1,163 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"net/http"
+
+	"github.com/zacmm/zacmm-server/app"
+	"github.com/zacmm/zacmm-server/audit"
+	"github.com/zacmm/zacmm-server/model"
+)
+
+func (api *API) InitPoll() {
+	api.BaseRoutes.Polls.Handle("", api.ApiSessionRequired(createPoll)).Methods("POST")
+	api.BaseRoutes.Poll.Handle("", api.ApiSessionRequired(getPoll)).Methods("GET")
+	api.BaseRoutes.Poll.Handle("/results", api.ApiSessionRequired(getPollResults)).Methods("GET")
+	api.BaseRoutes.Poll.Handle("/votes", api.ApiSessionRequired(voteOnPoll)).Methods("POST")
+	api.BaseRoutes.Poll.Handle("/close", api.ApiSessionRequired(closePoll)).Methods("POST")
+}
+
+func createPoll(c *Context, w http.ResponseWriter, r *http.Request) {
+	poll := model.PollFromJson(r.Body)
+	if poll == nil {
+		c.SetInvalidParam("poll")
+		return
+	}
+
+	poll.UserId = c.App.Session().UserId
+
+	auditRec := c.MakeAuditRecord("createPoll", audit.Fail)
+	defer c.LogAuditRecWithLevel(auditRec, app.LevelContent)
+	auditRec.AddMeta("poll", poll)
+
+	if !c.App.SessionHasPermissionToChannel(*c.App.Session(), poll.ChannelId, model.PERMISSION_CREATE_POST) {
+		c.SetPermissionError(model.PERMISSION_CREATE_POST)
+		return
+	}
+
+	rpoll, err := c.App.CreatePoll(poll, c.App.Session().Id)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddMeta("poll", rpoll) // overwrite meta
+
+	w.WriteHeader(http.StatusCreated)
+	w.Write([]byte(rpoll.ToJson()))
+}
+
+func getPoll(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequirePollId()
+	if c.Err != nil {
+		return
+	}
+
+	poll, err := c.App.GetPoll(c.Params.PollId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	if !c.App.SessionHasPermissionToChannel(*c.App.Session(), poll.ChannelId, model.PERMISSION_READ_CHANNEL) {
+		c.SetPermissionError(model.PERMISSION_READ_CHANNEL)
+		return
+	}
+
+	w.Write([]byte(poll.ToJson()))
+}
+
+func getPollResults(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequirePollId()
+	if c.Err != nil {
+		return
+	}
+
+	poll, err := c.App.GetPoll(c.Params.PollId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	if !c.App.SessionHasPermissionToChannel(*c.App.Session(), poll.ChannelId, model.PERMISSION_READ_CHANNEL) {
+		c.SetPermissionError(model.PERMISSION_READ_CHANNEL)
+		return
+	}
+
+	results, err := c.App.GetPollResults(poll)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(results.ToJson()))
+}
+
+func voteOnPoll(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequirePollId()
+	if c.Err != nil {
+		return
+	}
+
+	vote := model.PollVoteRequestFromJson(r.Body)
+	if vote == nil {
+		c.SetInvalidParam("vote")
+		return
+	}
+
+	poll, err := c.App.GetPoll(c.Params.PollId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	// Voting is allowed wherever reacting is, so that polls keep working in
+	// read-only channels.
+	if !c.App.SessionHasPermissionToChannel(*c.App.Session(), poll.ChannelId, model.PERMISSION_ADD_REACTION) {
+		c.SetPermissionError(model.PERMISSION_ADD_REACTION)
+		return
+	}
+
+	results, err := c.App.VoteOnPoll(poll, c.App.Session().UserId, vote)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(results.ToJson()))
+}
+
+func closePoll(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequirePollId()
+	if c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("closePoll", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("poll_id", c.Params.PollId)
+
+	poll, err := c.App.GetPoll(c.Params.PollId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	if c.App.Session().UserId != poll.UserId && !c.App.SessionHasPermissionToChannel(*c.App.Session(), poll.ChannelId, model.PERMISSION_EDIT_OTHERS_POSTS) {
+		c.SetPermissionError(model.PERMISSION_EDIT_OTHERS_POSTS)
+		return
+	}
+
+	rpoll, err := c.App.ClosePoll(poll)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+
+	w.Write([]byte(rpoll.ToJson()))
+}
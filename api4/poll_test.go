@@ -0,0 +1,201 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zacmm/zacmm-server/model"
+)
+
+func TestCreatePoll(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	poll := &model.Poll{
+		ChannelId: th.BasicChannel.Id,
+		Question:  "Lunch?",
+		Options:   model.StringArray{"Pizza", "Sushi"},
+	}
+
+	rpoll, resp := Client.CreatePoll(poll)
+	CheckNoError(t, resp)
+	CheckCreatedStatus(t, resp)
+	require.Equal(t, th.BasicUser.Id, rpoll.UserId)
+	require.NotEmpty(t, rpoll.PostId)
+
+	post, resp := Client.GetPost(rpoll.PostId, "")
+	CheckNoError(t, resp)
+	require.Equal(t, model.POST_POLL, post.Type)
+	require.Equal(t, "Lunch?", post.Message)
+	require.Equal(t, rpoll.Id, post.GetProp(model.POST_PROPS_POLL_ID))
+
+	t.Run("invalid poll", func(t *testing.T) {
+		_, resp := Client.CreatePoll(&model.Poll{ChannelId: th.BasicChannel.Id, Question: "Lunch?", Options: model.StringArray{"Pizza"}})
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("no permission to channel", func(t *testing.T) {
+		channel := th.CreatePrivateChannel()
+		th.LoginBasic2()
+		defer th.LoginBasic()
+
+		_, resp := Client.CreatePoll(&model.Poll{ChannelId: channel.Id, Question: "Lunch?", Options: model.StringArray{"Pizza", "Sushi"}})
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("poll posts cannot be created directly", func(t *testing.T) {
+		_, resp := Client.CreatePost(&model.Post{ChannelId: th.BasicChannel.Id, Message: "Lunch?", Type: model.POST_POLL})
+		CheckBadRequestStatus(t, resp)
+	})
+}
+
+func TestVoteOnPoll(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	poll, resp := Client.CreatePoll(&model.Poll{
+		ChannelId: th.BasicChannel.Id,
+		Question:  "Lunch?",
+		Options:   model.StringArray{"Pizza", "Sushi", "Tacos"},
+	})
+	CheckNoError(t, resp)
+
+	results, resp := Client.VoteOnPoll(poll.Id, []int{1})
+	CheckNoError(t, resp)
+	require.Equal(t, []int{0, 1, 0}, results.Counts)
+	require.Equal(t, []string{th.BasicUser.Id}, results.Voters[1])
+
+	t.Run("changing vote replaces the previous vote", func(t *testing.T) {
+		results, resp := Client.VoteOnPoll(poll.Id, []int{2})
+		CheckNoError(t, resp)
+		require.Equal(t, []int{0, 0, 1}, results.Counts)
+		require.Equal(t, 1, results.TotalVoters)
+	})
+
+	t.Run("multiple options on single choice poll", func(t *testing.T) {
+		_, resp := Client.VoteOnPoll(poll.Id, []int{0, 1})
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("tally is stored in the post", func(t *testing.T) {
+		post, resp := Client.GetPost(poll.PostId, "")
+		CheckNoError(t, resp)
+		tally, ok := post.GetProp(model.POST_PROPS_POLL_RESULTS).(map[string]interface{})
+		require.True(t, ok)
+		require.Equal(t, []interface{}{float64(0), float64(0), float64(1)}, tally["counts"])
+		require.Nil(t, tally["voters"])
+	})
+
+	t.Run("no permission to channel", func(t *testing.T) {
+		appErr := th.App.RemoveUserFromChannel(th.BasicUser2.Id, "", th.BasicChannel)
+		require.Nil(t, appErr)
+		th.LoginBasic2()
+		defer th.LoginBasic()
+
+		_, resp := Client.VoteOnPoll(poll.Id, []int{0})
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("deleted post", func(t *testing.T) {
+		poll, resp := Client.CreatePoll(&model.Poll{
+			ChannelId: th.BasicChannel.Id,
+			Question:  "Dinner?",
+			Options:   model.StringArray{"Pasta", "Curry"},
+		})
+		CheckNoError(t, resp)
+
+		_, resp = Client.DeletePost(poll.PostId)
+		CheckNoError(t, resp)
+
+		_, resp = Client.VoteOnPoll(poll.Id, []int{0})
+		CheckNotFoundStatus(t, resp)
+	})
+
+	t.Run("archived channel", func(t *testing.T) {
+		channel := th.CreatePublicChannel()
+		poll, resp := Client.CreatePoll(&model.Poll{
+			ChannelId: channel.Id,
+			Question:  "Dinner?",
+			Options:   model.StringArray{"Pasta", "Curry"},
+		})
+		CheckNoError(t, resp)
+
+		_, resp = Client.DeleteChannel(channel.Id)
+		CheckNoError(t, resp)
+
+		_, resp = Client.VoteOnPoll(poll.Id, []int{0})
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("closed poll", func(t *testing.T) {
+		_, resp := Client.ClosePoll(poll.Id)
+		CheckNoError(t, resp)
+
+		_, resp = Client.VoteOnPoll(poll.Id, []int{0})
+		CheckBadRequestStatus(t, resp)
+
+		results, resp := Client.GetPollResults(poll.Id)
+		CheckNoError(t, resp)
+		require.Equal(t, []int{0, 0, 1}, results.Counts)
+		require.NotZero(t, results.ClosedAt)
+	})
+}
+
+func TestClosePoll(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	poll, resp := Client.CreatePoll(&model.Poll{
+		ChannelId: th.BasicChannel.Id,
+		Question:  "Lunch?",
+		Options:   model.StringArray{"Pizza", "Sushi"},
+	})
+	CheckNoError(t, resp)
+
+	t.Run("other user cannot close poll", func(t *testing.T) {
+		th.LoginBasic2()
+		defer th.LoginBasic()
+
+		_, resp := Client.ClosePoll(poll.Id)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("creator can close poll", func(t *testing.T) {
+		rpoll, resp := Client.ClosePoll(poll.Id)
+		CheckNoError(t, resp)
+		require.NotZero(t, rpoll.ClosedAt)
+	})
+
+	t.Run("non-existent poll", func(t *testing.T) {
+		_, resp := Client.ClosePoll(model.NewId())
+		CheckNotFoundStatus(t, resp)
+	})
+
+	t.Run("polls due are closed", func(t *testing.T) {
+		poll, resp := Client.CreatePoll(&model.Poll{
+			ChannelId: th.BasicChannel.Id,
+			Question:  "Dinner?",
+			Options:   model.StringArray{"Pizza", "Sushi"},
+			CloseAt:   model.GetMillis() + 1000,
+		})
+		CheckNoError(t, resp)
+
+		poll.CreateAt = model.GetMillis() - 2000
+		poll.CloseAt = model.GetMillis() - 1000
+		_, err := th.App.Srv().Store.Poll().Update(poll)
+		require.NoError(t, err)
+
+		require.Nil(t, th.App.ClosePollsDue())
+
+		rpoll, resp := Client.GetPoll(poll.Id)
+		CheckNoError(t, resp)
+		require.NotZero(t, rpoll.ClosedAt)
+	})
+}
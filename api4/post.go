@@ -22,6 +22,7 @@ func (api *API) InitPost() {
 	api.BaseRoutes.Posts.Handle("/ephemeral", api.ApiSessionRequired(createEphemeralPost)).Methods("POST")
 	api.BaseRoutes.Post.Handle("/thread", api.ApiSessionRequired(getPostThread)).Methods("GET")
 	api.BaseRoutes.Post.Handle("/files/info", api.ApiSessionRequired(getFileInfosForPost)).Methods("GET")
+	api.BaseRoutes.Post.Handle("/read_by", api.ApiSessionRequired(getPostReadReceipts)).Methods("GET")
 	api.BaseRoutes.PostsForChannel.Handle("", api.ApiSessionRequired(getPostsForChannel)).Methods("GET")
 	api.BaseRoutes.PostsForUser.Handle("/flagged", api.ApiSessionRequired(getFlaggedPostsForUser)).Methods("GET")
 	api.BaseRoutes.PostsAll.Handle("", api.ApiSessionRequired(getAllPosts)).Methods("POST")
@@ -44,6 +45,12 @@ func createPost(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Poll posts can only be created through the polls API.
+	if post.Type == model.POST_POLL {
+		c.SetInvalidParam("type")
+		return
+	}
+
 	post.UserId = c.App.Session().UserId
 
 	auditRec := c.MakeAuditRecord("createPost", audit.Fail)
@@ -779,6 +786,26 @@ func getFileInfosForPost(c *Context, w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte(model.FileInfosToJson(infos)))
 }
 
+func getPostReadReceipts(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequirePostId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToChannelByPost(*c.App.Session(), c.Params.PostId, model.PERMISSION_READ_CHANNEL) {
+		c.SetPermissionError(model.PERMISSION_READ_CHANNEL)
+		return
+	}
+
+	receipts, err := c.App.GetPostReadReceipts(c.Params.PostId, c.App.Session().UserId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(model.PostReadReceiptsToJson(receipts)))
+}
+
 func removePostsBetween(c *Context, w http.ResponseWriter, r *http.Request) {
 	if !c.IsSystemAdmin() {
 		c.SetPermissionError(model.PERMISSION_EDIT_OTHER_USERS)
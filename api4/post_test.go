@@ -2463,6 +2463,89 @@ func TestGetFileInfosForPost(t *testing.T) {
 	CheckNoError(t, resp)
 }
 
+func TestGetPostReadReceipts(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	dm := th.CreateDmChannel(th.BasicUser2)
+	post, resp := Client.CreatePost(&model.Post{ChannelId: dm.Id, Message: "zz" + model.NewId() + "a"})
+	CheckNoError(t, resp)
+
+	_, resp = Client.GetPostReadReceipts(post.Id)
+	CheckNotImplementedStatus(t, resp)
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableReadReceipts = true })
+
+	enableReadReceipts := func(userId string) {
+		err := th.App.UpdatePreferences(userId, model.Preferences{{
+			UserId:   userId,
+			Category: model.PREFERENCE_CATEGORY_DISPLAY_SETTINGS,
+			Name:     model.PREFERENCE_NAME_READ_RECEIPTS,
+			Value:    "true",
+		}})
+		require.Nil(t, err)
+	}
+
+	t.Run("should require the user to have opted in", func(t *testing.T) {
+		_, resp := Client.GetPostReadReceipts(post.Id)
+		CheckForbiddenStatus(t, resp)
+		require.Equal(t, "app.post.read_receipts.not_enabled.app_error", resp.Error.Id)
+	})
+
+	enableReadReceipts(th.BasicUser.Id)
+
+	t.Run("should not include users that have not opted in", func(t *testing.T) {
+		_, err := th.App.MarkChannelsAsViewed([]string{dm.Id}, th.BasicUser2.Id, "")
+		require.Nil(t, err)
+
+		receipts, resp := Client.GetPostReadReceipts(post.Id)
+		CheckNoError(t, resp)
+		require.Empty(t, receipts)
+	})
+
+	t.Run("should include users that have opted in and viewed the post", func(t *testing.T) {
+		enableReadReceipts(th.BasicUser2.Id)
+
+		receipts, resp := Client.GetPostReadReceipts(post.Id)
+		CheckNoError(t, resp)
+		require.Len(t, receipts, 1)
+		require.Equal(t, th.BasicUser2.Id, receipts[0].UserId)
+		require.Equal(t, post.Id, receipts[0].PostId)
+		require.GreaterOrEqual(t, receipts[0].ReadAt, post.CreateAt)
+	})
+
+	t.Run("should not include users that have not viewed the post", func(t *testing.T) {
+		post2, resp := Client.CreatePost(&model.Post{ChannelId: dm.Id, Message: "zz" + model.NewId() + "a"})
+		CheckNoError(t, resp)
+
+		receipts, resp := Client.GetPostReadReceipts(post2.Id)
+		CheckNoError(t, resp)
+		require.Empty(t, receipts)
+	})
+
+	t.Run("should reject posts outside of direct and group channels", func(t *testing.T) {
+		_, resp := Client.GetPostReadReceipts(th.BasicPost.Id)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("should require access to the channel", func(t *testing.T) {
+		enableReadReceipts(th.SystemAdminUser.Id)
+		_, resp := th.SystemAdminClient.GetPostReadReceipts(post.Id)
+		CheckNoError(t, resp)
+
+		otherUser := th.CreateUser()
+		th.LinkUserToTeam(otherUser, th.BasicTeam)
+		enableReadReceipts(otherUser.Id)
+		otherClient := th.CreateClient()
+		_, resp = otherClient.Login(otherUser.Email, otherUser.Password)
+		CheckNoError(t, resp)
+
+		_, resp = otherClient.GetPostReadReceipts(post.Id)
+		CheckForbiddenStatus(t, resp)
+	})
+}
+
 func TestSetChannelUnread(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
@@ -0,0 +1,137 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"net/http"
+
+	"github.com/zacmm/zacmm-server/audit"
+	"github.com/zacmm/zacmm-server/model"
+)
+
+func (api *API) InitPublicLink() {
+	api.BaseRoutes.PublicLinks.Handle("", api.ApiSessionRequired(getPublicLinks)).Methods("GET")
+	api.BaseRoutes.PublicLinks.Handle("", api.ApiSessionRequired(createPublicLink)).Methods("POST")
+	api.BaseRoutes.PublicLink.Handle("", api.ApiSessionRequired(revokePublicLink)).Methods("DELETE")
+}
+
+func getPublicLinks(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToUser(*c.App.Session(), c.Params.UserId) {
+		c.SetPermissionError(model.PERMISSION_EDIT_OTHER_USERS)
+		return
+	}
+
+	links, err := c.App.GetPublicLinksForUser(c.GetSiteURLHeader(), c.Params.UserId, c.Params.Page, c.Params.PerPage)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(model.PublicLinksToJson(links)))
+}
+
+func createPublicLink(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	link := model.PublicLinkFromJson(r.Body)
+	if link == nil {
+		c.SetInvalidParam("public_link")
+		return
+	}
+
+	if !model.IsValidId(link.FileId) {
+		c.SetInvalidParam("file_id")
+		return
+	}
+
+	if !*c.App.Config().FileSettings.EnablePublicLink {
+		c.Err = model.NewAppError("createPublicLink", "api.file.get_public_link.disabled.app_error", nil, "", http.StatusNotImplemented)
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("createPublicLink", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("user_id", c.Params.UserId)
+	auditRec.AddMeta("file_id", link.FileId)
+
+	if !c.App.SessionHasPermissionToUser(*c.App.Session(), c.Params.UserId) {
+		c.SetPermissionError(model.PERMISSION_EDIT_OTHER_USERS)
+		return
+	}
+
+	info, err := c.App.GetFileInfo(link.FileId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	if !c.App.SessionHasPermissionToFile(*c.App.Session(), info, model.PERMISSION_READ_CHANNEL) {
+		c.SetPermissionError(model.PERMISSION_READ_CHANNEL)
+		return
+	}
+
+	if len(info.PostId) == 0 {
+		c.Err = model.NewAppError("createPublicLink", "api.file.get_public_link.no_post.app_error", nil, "file_id="+info.Id, http.StatusBadRequest)
+		return
+	}
+
+	rlink, err := c.App.CreatePublicLink(c.GetSiteURLHeader(), info, c.Params.UserId, link.ExpireAt)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddMeta("public_link_id", rlink.Id)
+	auditRec.AddMeta("expire_at", rlink.ExpireAt)
+
+	w.WriteHeader(http.StatusCreated)
+	w.Write([]byte(rlink.ToJson()))
+}
+
+func revokePublicLink(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId().RequirePublicLinkId()
+	if c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("revokePublicLink", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("user_id", c.Params.UserId)
+	auditRec.AddMeta("public_link_id", c.Params.PublicLinkId)
+
+	if !c.App.SessionHasPermissionToUser(*c.App.Session(), c.Params.UserId) {
+		c.SetPermissionError(model.PERMISSION_EDIT_OTHER_USERS)
+		return
+	}
+
+	link, err := c.App.GetPublicLink(c.Params.PublicLinkId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	if link.CreatorId != c.Params.UserId {
+		c.Err = model.NewAppError("revokePublicLink", "app.public_link.get.not_found.app_error", nil, "", http.StatusNotFound)
+		return
+	}
+
+	if err := c.App.RevokePublicLink(link.Id); err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddMeta("file_id", link.FileId)
+
+	ReturnStatusOK(w)
+}
@@ -0,0 +1,134 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zacmm/zacmm-server/model"
+	"github.com/zacmm/zacmm-server/utils/testutils"
+)
+
+func TestPublicLinks(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.FileSettings.EnablePublicLink = true
+		*cfg.FileSettings.PublicLinkSalt = model.NewRandomString(32)
+	})
+
+	data, err := testutils.ReadTestFile("test.png")
+	require.NoError(t, err)
+
+	fileResp, resp := Client.UploadFile(data, th.BasicChannel.Id, "test.png")
+	CheckNoError(t, resp)
+	fileId := fileResp.FileInfos[0].Id
+	require.Nil(t, th.App.Srv().Store.FileInfo().AttachToPost(fileId, th.BasicPost.Id, th.BasicUser.Id))
+	defer func() {
+		info, err := th.App.Srv().Store.FileInfo().Get(fileId)
+		require.Nil(t, err)
+		th.cleanupTestFile(info)
+	}()
+
+	var link *model.PublicLink
+
+	t.Run("create", func(t *testing.T) {
+		expireAt := model.GetMillis() + time.Hour.Milliseconds()
+		link, resp = Client.CreatePublicLink(th.BasicUser.Id, fileId, expireAt)
+		CheckNoError(t, resp)
+		CheckCreatedStatus(t, resp)
+		assert.Equal(t, fileId, link.FileId)
+		assert.Equal(t, th.BasicUser.Id, link.CreatorId)
+		assert.Equal(t, expireAt, link.ExpireAt)
+		assert.Contains(t, link.Link, "l="+link.Id)
+
+		httpResp, err := http.Get(link.Link)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, httpResp.StatusCode)
+	})
+
+	t.Run("create with an expiry beyond the limit", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.FileSettings.PublicLinkExpiryHours = 1 })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.FileSettings.PublicLinkExpiryHours = 0 })
+
+		_, resp := Client.CreatePublicLink(th.BasicUser.Id, fileId, model.GetMillis()+2*time.Hour.Milliseconds())
+		CheckBadRequestStatus(t, resp)
+
+		limited, resp := Client.CreatePublicLink(th.BasicUser.Id, fileId, 0)
+		CheckNoError(t, resp)
+		assert.NotZero(t, limited.ExpireAt)
+	})
+
+	t.Run("create without access to the file", func(t *testing.T) {
+		_, resp := Client.CreatePublicLink(th.BasicUser.Id, model.NewId(), 0)
+		CheckNotFoundStatus(t, resp)
+
+		otherUser := th.CreateUser()
+		otherClient := th.CreateClient()
+		_, resp = otherClient.Login(otherUser.Email, otherUser.Password)
+		CheckNoError(t, resp)
+
+		_, resp = otherClient.CreatePublicLink(otherUser.Id, fileId, 0)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp = otherClient.CreatePublicLink(th.BasicUser.Id, fileId, 0)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("get link reuses the active link", func(t *testing.T) {
+		url, resp := Client.GetFileLink(fileId)
+		CheckNoError(t, resp)
+
+		again, resp := Client.GetFileLink(fileId)
+		CheckNoError(t, resp)
+		assert.Equal(t, url, again)
+	})
+
+	t.Run("list", func(t *testing.T) {
+		links, resp := Client.GetPublicLinks(th.BasicUser.Id, 0, 100)
+		CheckNoError(t, resp)
+		require.NotEmpty(t, links)
+		for _, l := range links {
+			assert.Equal(t, th.BasicUser.Id, l.CreatorId)
+			assert.NotEmpty(t, l.Link)
+		}
+
+		_, resp = Client.GetPublicLinks(th.BasicUser2.Id, 0, 100)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp = th.SystemAdminClient.GetPublicLinks(th.BasicUser.Id, 0, 100)
+		CheckNoError(t, resp)
+	})
+
+	t.Run("revoke", func(t *testing.T) {
+		_, resp := Client.RevokePublicLink(th.BasicUser2.Id, link.Id)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp = th.SystemAdminClient.RevokePublicLink(th.BasicUser2.Id, link.Id)
+		CheckNotFoundStatus(t, resp)
+
+		ok, resp := Client.RevokePublicLink(th.BasicUser.Id, link.Id)
+		CheckNoError(t, resp)
+		assert.True(t, ok)
+
+		_, resp = Client.RevokePublicLink(th.BasicUser.Id, link.Id)
+		CheckNotFoundStatus(t, resp)
+
+		httpResp, err := http.Get(link.Link)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusGone, httpResp.StatusCode)
+
+		revoked, appErr := th.App.GetPublicLink(link.Id)
+		require.Nil(t, appErr)
+		assert.NotZero(t, revoked.RevokeAt)
+		assert.Equal(t, int64(1), revoked.DownloadCount)
+	})
+}
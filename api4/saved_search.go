@@ -0,0 +1,189 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"net/http"
+
+	"github.com/zacmm/zacmm-server/audit"
+	"github.com/zacmm/zacmm-server/model"
+)
+
+func (api *API) InitSavedSearch() {
+	api.BaseRoutes.SavedSearches.Handle("", api.ApiSessionRequired(getSavedSearches)).Methods("GET")
+	api.BaseRoutes.SavedSearches.Handle("", api.ApiSessionRequired(createSavedSearch)).Methods("POST")
+	api.BaseRoutes.SavedSearch.Handle("", api.ApiSessionRequired(getSavedSearch)).Methods("GET")
+	api.BaseRoutes.SavedSearch.Handle("", api.ApiSessionRequired(updateSavedSearch)).Methods("PUT")
+	api.BaseRoutes.SavedSearch.Handle("", api.ApiSessionRequired(deleteSavedSearch)).Methods("DELETE")
+}
+
+// getSavedSearchForUser returns the saved search of the URL, making sure that
+// it belongs to the user of the URL.
+func getSavedSearchForUser(c *Context) *model.SavedSearch {
+	search, err := c.App.GetSavedSearch(c.Params.SavedSearchId)
+	if err != nil {
+		c.Err = err
+		return nil
+	}
+
+	if search.UserId != c.Params.UserId {
+		c.Err = model.NewAppError("getSavedSearchForUser", "app.saved_search.get.app_error", nil, "", http.StatusNotFound)
+		return nil
+	}
+
+	return search
+}
+
+func getSavedSearches(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToUser(*c.App.Session(), c.Params.UserId) {
+		c.SetPermissionError(model.PERMISSION_EDIT_OTHER_USERS)
+		return
+	}
+
+	searches, err := c.App.GetSavedSearchesForUser(c.Params.UserId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(model.SavedSearchListToJson(searches)))
+}
+
+func getSavedSearch(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId().RequireSavedSearchId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToUser(*c.App.Session(), c.Params.UserId) {
+		c.SetPermissionError(model.PERMISSION_EDIT_OTHER_USERS)
+		return
+	}
+
+	search := getSavedSearchForUser(c)
+	if c.Err != nil {
+		return
+	}
+
+	w.Write([]byte(search.ToJson()))
+}
+
+func createSavedSearch(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	search := model.SavedSearchFromJson(r.Body)
+	if search == nil {
+		c.SetInvalidParam("saved_search")
+		return
+	}
+
+	if search.UserId != "" && search.UserId != c.Params.UserId {
+		c.SetInvalidParam("user_id")
+		return
+	}
+	search.UserId = c.Params.UserId
+
+	auditRec := c.MakeAuditRecord("createSavedSearch", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("user_id", c.Params.UserId)
+	auditRec.AddMeta("team_id", search.TeamId)
+
+	if !c.App.SessionHasPermissionToUser(*c.App.Session(), c.Params.UserId) {
+		c.SetPermissionError(model.PERMISSION_EDIT_OTHER_USERS)
+		return
+	}
+
+	rsearch, err := c.App.CreateSavedSearch(search)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddMeta("saved_search_id", rsearch.Id)
+
+	w.WriteHeader(http.StatusCreated)
+	w.Write([]byte(rsearch.ToJson()))
+}
+
+func updateSavedSearch(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId().RequireSavedSearchId()
+	if c.Err != nil {
+		return
+	}
+
+	search := model.SavedSearchFromJson(r.Body)
+	if search == nil {
+		c.SetInvalidParam("saved_search")
+		return
+	}
+
+	if search.Id != "" && search.Id != c.Params.SavedSearchId {
+		c.SetInvalidParam("id")
+		return
+	}
+	search.Id = c.Params.SavedSearchId
+
+	auditRec := c.MakeAuditRecord("updateSavedSearch", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("user_id", c.Params.UserId)
+	auditRec.AddMeta("saved_search_id", c.Params.SavedSearchId)
+
+	if !c.App.SessionHasPermissionToUser(*c.App.Session(), c.Params.UserId) {
+		c.SetPermissionError(model.PERMISSION_EDIT_OTHER_USERS)
+		return
+	}
+
+	if getSavedSearchForUser(c); c.Err != nil {
+		return
+	}
+
+	rsearch, err := c.App.UpdateSavedSearch(search)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+
+	w.Write([]byte(rsearch.ToJson()))
+}
+
+func deleteSavedSearch(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId().RequireSavedSearchId()
+	if c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("deleteSavedSearch", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("user_id", c.Params.UserId)
+	auditRec.AddMeta("saved_search_id", c.Params.SavedSearchId)
+
+	if !c.App.SessionHasPermissionToUser(*c.App.Session(), c.Params.UserId) {
+		c.SetPermissionError(model.PERMISSION_EDIT_OTHER_USERS)
+		return
+	}
+
+	if getSavedSearchForUser(c); c.Err != nil {
+		return
+	}
+
+	if err := c.App.DeleteSavedSearch(c.Params.SavedSearchId); err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+
+	ReturnStatusOK(w)
+}
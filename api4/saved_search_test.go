@@ -0,0 +1,87 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zacmm/zacmm-server/model"
+)
+
+func TestSavedSearches(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	search, resp := Client.CreateSavedSearch(th.BasicUser.Id, &model.SavedSearch{TeamId: th.BasicTeam.Id, Name: "Incidents", Terms: "from:alertbot in:prod-incidents error"})
+	CheckNoError(t, resp)
+	CheckCreatedStatus(t, resp)
+	assert.Equal(t, th.BasicUser.Id, search.UserId)
+	assert.False(t, search.NotifyOnMatch)
+
+	t.Run("create", func(t *testing.T) {
+		_, resp := Client.CreateSavedSearch(th.BasicUser.Id, &model.SavedSearch{TeamId: th.BasicTeam.Id, Name: "Incidents"})
+		CheckBadRequestStatus(t, resp)
+
+		_, resp = Client.CreateSavedSearch(th.BasicUser.Id, &model.SavedSearch{TeamId: model.NewId(), Name: "Incidents", Terms: "error"})
+		CheckBadRequestStatus(t, resp)
+
+		_, resp = Client.CreateSavedSearch(th.BasicUser.Id, &model.SavedSearch{UserId: th.BasicUser2.Id, TeamId: th.BasicTeam.Id, Name: "Incidents", Terms: "error"})
+		CheckBadRequestStatus(t, resp)
+
+		_, resp = Client.CreateSavedSearch(th.BasicUser2.Id, &model.SavedSearch{TeamId: th.BasicTeam.Id, Name: "Incidents", Terms: "error"})
+		CheckForbiddenStatus(t, resp)
+
+		_, resp = th.SystemAdminClient.CreateSavedSearch(th.BasicUser2.Id, &model.SavedSearch{TeamId: th.BasicTeam.Id, Name: "Incidents", Terms: "error"})
+		CheckNoError(t, resp)
+	})
+
+	t.Run("get", func(t *testing.T) {
+		searches, resp := Client.GetSavedSearches(th.BasicUser.Id)
+		CheckNoError(t, resp)
+		require.Len(t, searches, 1)
+		assert.Equal(t, search.Id, searches[0].Id)
+
+		received, resp := Client.GetSavedSearch(th.BasicUser.Id, search.Id)
+		CheckNoError(t, resp)
+		assert.Equal(t, search.Terms, received.Terms)
+
+		_, resp = Client.GetSavedSearches(th.BasicUser2.Id)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp = th.SystemAdminClient.GetSavedSearch(th.BasicUser2.Id, search.Id)
+		CheckNotFoundStatus(t, resp)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		updated, resp := Client.UpdateSavedSearch(th.BasicUser.Id, &model.SavedSearch{Id: search.Id, Name: "Errors", Terms: "error", IsOrSearch: true, NotifyOnMatch: true})
+		CheckNoError(t, resp)
+		assert.Equal(t, "Errors", updated.Name)
+		assert.Equal(t, "error", updated.Terms)
+		assert.True(t, updated.IsOrSearch)
+		assert.True(t, updated.NotifyOnMatch)
+		assert.Equal(t, th.BasicTeam.Id, updated.TeamId)
+
+		_, resp = Client.UpdateSavedSearch(th.BasicUser.Id, &model.SavedSearch{Id: search.Id, Name: "Errors"})
+		CheckBadRequestStatus(t, resp)
+
+		_, resp = th.SystemAdminClient.UpdateSavedSearch(th.BasicUser2.Id, &model.SavedSearch{Id: search.Id, Name: "Errors", Terms: "error"})
+		CheckNotFoundStatus(t, resp)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		_, resp := th.SystemAdminClient.DeleteSavedSearch(th.BasicUser2.Id, search.Id)
+		CheckNotFoundStatus(t, resp)
+
+		ok, resp := Client.DeleteSavedSearch(th.BasicUser.Id, search.Id)
+		CheckNoError(t, resp)
+		assert.True(t, ok)
+
+		_, resp = Client.GetSavedSearch(th.BasicUser.Id, search.Id)
+		CheckNotFoundStatus(t, resp)
+	})
+}
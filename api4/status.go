@@ -6,6 +6,7 @@ package api4
 import (
 	"net/http"
 
+	"github.com/zacmm/zacmm-server/audit"
 	"github.com/zacmm/zacmm-server/model"
 )
 
@@ -13,6 +14,12 @@ func (api *API) InitStatus() {
 	api.BaseRoutes.User.Handle("/status", api.ApiSessionRequired(getUserStatus)).Methods("GET")
 	api.BaseRoutes.Users.Handle("/status/ids", api.ApiSessionRequired(getUserStatusesByIds)).Methods("POST")
 	api.BaseRoutes.User.Handle("/status", api.ApiSessionRequired(updateUserStatus)).Methods("PUT")
+	api.BaseRoutes.User.Handle("/status/custom", api.ApiSessionRequired(getUserCustomStatus)).Methods("GET")
+	api.BaseRoutes.User.Handle("/status/custom", api.ApiSessionRequired(updateUserCustomStatus)).Methods("PUT")
+	api.BaseRoutes.User.Handle("/status/custom", api.ApiSessionRequired(removeUserCustomStatus)).Methods("DELETE")
+	api.BaseRoutes.User.Handle("/status/dnd_schedule", api.ApiSessionRequired(getUserDndSchedule)).Methods("GET")
+	api.BaseRoutes.User.Handle("/status/dnd_schedule", api.ApiSessionRequired(updateUserDndSchedule)).Methods("PUT")
+	api.BaseRoutes.User.Handle("/status/dnd_schedule", api.ApiSessionRequired(removeUserDndSchedule)).Methods("DELETE")
 }
 
 func getUserStatus(c *Context, w http.ResponseWriter, r *http.Request) {
@@ -99,7 +106,14 @@ func updateUserStatus(c *Context, w http.ResponseWriter, r *http.Request) {
 	case "away":
 		c.App.SetStatusAwayIfNeeded(c.Params.UserId, true)
 	case "dnd":
-		c.App.SetStatusDoNotDisturb(c.Params.UserId)
+		if status.DNDEndTime > 0 {
+			if err := c.App.SetStatusDoNotDisturbTimed(c.Params.UserId, status.DNDEndTime); err != nil {
+				c.Err = err
+				return
+			}
+		} else {
+			c.App.SetStatusDoNotDisturb(c.Params.UserId)
+		}
 	default:
 		c.SetInvalidParam("status")
 		return
@@ -107,3 +121,164 @@ func updateUserStatus(c *Context, w http.ResponseWriter, r *http.Request) {
 
 	getUserStatus(c, w, r)
 }
+
+func getUserCustomStatus(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	// No permission check required
+
+	customStatus, err := c.App.GetCustomStatus(c.Params.UserId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(customStatus.ToJson()))
+}
+
+func updateUserCustomStatus(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	customStatus := model.CustomStatusFromJson(r.Body)
+	if customStatus == nil {
+		c.SetInvalidParam("custom_status")
+		return
+	}
+
+	if customStatus.UserId != "" && customStatus.UserId != c.Params.UserId {
+		c.SetInvalidParam("user_id")
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("updateUserCustomStatus", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("user_id", c.Params.UserId)
+
+	if !c.App.SessionHasPermissionToUser(*c.App.Session(), c.Params.UserId) {
+		c.SetPermissionError(model.PERMISSION_EDIT_OTHER_USERS)
+		return
+	}
+
+	rcustomStatus, err := c.App.SetCustomStatus(c.Params.UserId, customStatus)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+
+	w.Write([]byte(rcustomStatus.ToJson()))
+}
+
+func removeUserCustomStatus(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("removeUserCustomStatus", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("user_id", c.Params.UserId)
+
+	if !c.App.SessionHasPermissionToUser(*c.App.Session(), c.Params.UserId) {
+		c.SetPermissionError(model.PERMISSION_EDIT_OTHER_USERS)
+		return
+	}
+
+	if err := c.App.RemoveCustomStatus(c.Params.UserId); err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+
+	ReturnStatusOK(w)
+}
+
+func getUserDndSchedule(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToUser(*c.App.Session(), c.Params.UserId) {
+		c.SetPermissionError(model.PERMISSION_EDIT_OTHER_USERS)
+		return
+	}
+
+	schedule, err := c.App.GetDndSchedule(c.Params.UserId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(schedule.ToJson()))
+}
+
+func updateUserDndSchedule(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	schedule := model.DndScheduleFromJson(r.Body)
+	if schedule == nil {
+		c.SetInvalidParam("dnd_schedule")
+		return
+	}
+
+	if schedule.UserId != "" && schedule.UserId != c.Params.UserId {
+		c.SetInvalidParam("user_id")
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("updateUserDndSchedule", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("user_id", c.Params.UserId)
+
+	if !c.App.SessionHasPermissionToUser(*c.App.Session(), c.Params.UserId) {
+		c.SetPermissionError(model.PERMISSION_EDIT_OTHER_USERS)
+		return
+	}
+
+	rschedule, err := c.App.SetDndSchedule(c.Params.UserId, schedule)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+
+	w.Write([]byte(rschedule.ToJson()))
+}
+
+func removeUserDndSchedule(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("removeUserDndSchedule", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("user_id", c.Params.UserId)
+
+	if !c.App.SessionHasPermissionToUser(*c.App.Session(), c.Params.UserId) {
+		c.SetPermissionError(model.PERMISSION_EDIT_OTHER_USERS)
+		return
+	}
+
+	if err := c.App.DeleteDndSchedule(c.Params.UserId); err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+
+	ReturnStatusOK(w)
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/zacmm/zacmm-server/model"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGetUserStatus(t *testing.T) {
@@ -164,6 +165,20 @@ func TestUpdateUserStatus(t *testing.T) {
 		assert.Equal(t, "dnd", updateUserStatus.Status)
 	})
 
+	t.Run("set dnd status until a time", func(t *testing.T) {
+		endTime := model.GetMillis() + 60*60*1000
+		toUpdateUserStatus := &model.Status{Status: "dnd", UserId: th.BasicUser.Id, DNDEndTime: endTime}
+		updateUserStatus, resp := Client.UpdateUserStatus(th.BasicUser.Id, toUpdateUserStatus)
+		CheckNoError(t, resp)
+		assert.Equal(t, "dnd", updateUserStatus.Status)
+		assert.Equal(t, endTime, updateUserStatus.DNDEndTime)
+		assert.Empty(t, updateUserStatus.PrevStatus)
+
+		toUpdateUserStatus.DNDEndTime = model.GetMillis() - 1000
+		_, resp = Client.UpdateUserStatus(th.BasicUser.Id, toUpdateUserStatus)
+		CheckBadRequestStatus(t, resp)
+	})
+
 	t.Run("set offline status", func(t *testing.T) {
 		toUpdateUserStatus := &model.Status{Status: "offline", UserId: th.BasicUser.Id}
 		updateUserStatus, resp := Client.UpdateUserStatus(th.BasicUser.Id, toUpdateUserStatus)
@@ -197,3 +212,128 @@ func TestUpdateUserStatus(t *testing.T) {
 		CheckUnauthorizedStatus(t, resp)
 	})
 }
+
+func TestUpdateUserCustomStatus(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	customStatus, resp := Client.UpdateUserCustomStatus(th.BasicUser.Id, &model.CustomStatus{Emoji: ":calendar:", Text: "In a meeting"})
+	CheckNoError(t, resp)
+	require.Equal(t, th.BasicUser.Id, customStatus.UserId)
+	require.Equal(t, "calendar", customStatus.Emoji)
+
+	received, resp := Client.GetUserCustomStatus(th.BasicUser.Id, "")
+	CheckNoError(t, resp)
+	require.Equal(t, "In a meeting", received.Text)
+
+	t.Run("empty custom status", func(t *testing.T) {
+		_, resp := Client.UpdateUserCustomStatus(th.BasicUser.Id, &model.CustomStatus{})
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("already expired", func(t *testing.T) {
+		_, resp := Client.UpdateUserCustomStatus(th.BasicUser.Id, &model.CustomStatus{Text: "Gone", ExpiresAt: model.GetMillis() - 1000})
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("mismatched user id", func(t *testing.T) {
+		_, resp := Client.UpdateUserCustomStatus(th.BasicUser.Id, &model.CustomStatus{UserId: th.BasicUser2.Id, Text: "Busy"})
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("other user", func(t *testing.T) {
+		_, resp := Client.UpdateUserCustomStatus(th.BasicUser2.Id, &model.CustomStatus{Text: "Busy"})
+		CheckForbiddenStatus(t, resp)
+
+		_, resp = th.SystemAdminClient.UpdateUserCustomStatus(th.BasicUser2.Id, &model.CustomStatus{Text: "Busy"})
+		CheckNoError(t, resp)
+	})
+
+	t.Run("included in autocomplete", func(t *testing.T) {
+		autocomplete, resp := Client.AutocompleteUsersInChannel(th.BasicTeam.Id, th.BasicChannel.Id, th.BasicUser.Username, model.USER_SEARCH_DEFAULT_LIMIT, "")
+		CheckNoError(t, resp)
+		require.Contains(t, autocomplete.CustomStatuses, th.BasicUser.Id)
+		require.Equal(t, "In a meeting", autocomplete.CustomStatuses[th.BasicUser.Id].Text)
+	})
+}
+
+func TestRemoveUserCustomStatus(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	_, resp := Client.UpdateUserCustomStatus(th.BasicUser.Id, &model.CustomStatus{Text: "In a meeting"})
+	CheckNoError(t, resp)
+
+	_, resp = Client.RemoveUserCustomStatus(th.BasicUser2.Id)
+	CheckForbiddenStatus(t, resp)
+
+	ok, resp := Client.RemoveUserCustomStatus(th.BasicUser.Id)
+	CheckNoError(t, resp)
+	require.True(t, ok)
+
+	_, resp = Client.GetUserCustomStatus(th.BasicUser.Id, "")
+	CheckNotFoundStatus(t, resp)
+
+	_, resp = Client.RemoveUserCustomStatus(th.BasicUser.Id)
+	CheckNotFoundStatus(t, resp)
+}
+
+func TestUserDndSchedule(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	_, resp := Client.GetUserDndSchedule(th.BasicUser.Id, "")
+	CheckNotFoundStatus(t, resp)
+
+	schedule, resp := Client.UpdateUserDndSchedule(th.BasicUser.Id, &model.DndSchedule{
+		Days:             model.StringArray{"monday", "tuesday", "wednesday", "thursday", "friday"},
+		StartTime:        "19:00",
+		EndTime:          "08:00",
+		ExemptChannelIds: model.StringArray{th.BasicChannel.Id},
+		AllowUrgent:      true,
+	})
+	CheckNoError(t, resp)
+	require.Equal(t, th.BasicUser.Id, schedule.UserId)
+
+	received, resp := Client.GetUserDndSchedule(th.BasicUser.Id, "")
+	CheckNoError(t, resp)
+	require.Equal(t, "19:00", received.StartTime)
+	require.Equal(t, model.StringArray{th.BasicChannel.Id}, received.ExemptChannelIds)
+
+	t.Run("invalid schedule", func(t *testing.T) {
+		_, resp := Client.UpdateUserDndSchedule(th.BasicUser.Id, &model.DndSchedule{Days: model.StringArray{"monday"}, StartTime: "19:00", EndTime: "19:00"})
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("mismatched user id", func(t *testing.T) {
+		_, resp := Client.UpdateUserDndSchedule(th.BasicUser.Id, &model.DndSchedule{UserId: th.BasicUser2.Id, Days: model.StringArray{"monday"}, StartTime: "19:00", EndTime: "08:00"})
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("other user", func(t *testing.T) {
+		_, resp := Client.GetUserDndSchedule(th.BasicUser2.Id, "")
+		CheckForbiddenStatus(t, resp)
+
+		_, resp = Client.UpdateUserDndSchedule(th.BasicUser2.Id, &model.DndSchedule{Days: model.StringArray{"monday"}, StartTime: "19:00", EndTime: "08:00"})
+		CheckForbiddenStatus(t, resp)
+
+		_, resp = Client.RemoveUserDndSchedule(th.BasicUser2.Id)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp = th.SystemAdminClient.UpdateUserDndSchedule(th.BasicUser2.Id, &model.DndSchedule{Days: model.StringArray{"monday"}, StartTime: "19:00", EndTime: "08:00"})
+		CheckNoError(t, resp)
+	})
+
+	ok, resp := Client.RemoveUserDndSchedule(th.BasicUser.Id)
+	CheckNoError(t, resp)
+	require.True(t, ok)
+
+	_, resp = Client.GetUserDndSchedule(th.BasicUser.Id, "")
+	CheckNotFoundStatus(t, resp)
+
+	_, resp = Client.RemoveUserDndSchedule(th.BasicUser.Id)
+	CheckNotFoundStatus(t, resp)
+}
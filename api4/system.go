@@ -49,6 +49,7 @@ func (api *API) InitSystem() {
 	api.BaseRoutes.ApiRoot.Handle("/logs", api.ApiHandler(postLog)).Methods("POST")
 
 	api.BaseRoutes.ApiRoot.Handle("/analytics/old", api.ApiSessionRequired(getAnalytics)).Methods("GET")
+	api.BaseRoutes.ApiRoot.Handle("/analytics/storage_usage", api.ApiSessionRequired(getStorageUsageReport)).Methods("GET")
 
 	api.BaseRoutes.ApiRoot.Handle("/redirect_location", api.ApiSessionRequiredTrustRequester(getRedirectLocation)).Methods("GET")
 
@@ -362,6 +363,30 @@ func getAnalytics(c *Context, w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte(rows.ToJson()))
 }
 
+func getStorageUsageReport(c *Context, w http.ResponseWriter, r *http.Request) {
+	targetType := r.URL.Query().Get("target_type")
+	if targetType == "" {
+		targetType = model.STORAGE_USAGE_TARGET_USER
+	}
+	if !model.IsValidStorageUsageTarget(targetType) {
+		c.SetInvalidParam("target_type")
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(*c.App.Session(), model.PERMISSION_SYSCONSOLE_READ_REPORTING) {
+		c.SetPermissionError(model.PERMISSION_SYSCONSOLE_READ_REPORTING)
+		return
+	}
+
+	usages, err := c.App.GetStorageUsageReport(targetType, c.Params.Page, c.Params.PerPage)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(model.StorageUsageListToJson(usages)))
+}
+
 func getSupportedTimezones(c *Context, w http.ResponseWriter, r *http.Request) {
 	supportedTimezones := c.App.Timezones().GetSupported()
 	if supportedTimezones == nil {
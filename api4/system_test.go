@@ -425,6 +425,33 @@ func TestGetAnalyticsOld(t *testing.T) {
 	CheckUnauthorizedStatus(t, resp)
 }
 
+func TestGetStorageUsageReport(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	info, appErr := th.App.DoUploadFile(time.Now(), th.BasicTeam.Id, th.BasicChannel.Id, th.BasicUser.Id, "file.txt", []byte("storage usage"))
+	require.Nil(t, appErr)
+	defer th.App.Srv().Store.FileInfo().PermanentDelete(info.Id)
+
+	_, resp := th.Client.GetStorageUsageReport(model.STORAGE_USAGE_TARGET_USER, 0, 10)
+	CheckForbiddenStatus(t, resp)
+
+	_, resp = th.SystemAdminClient.GetStorageUsageReport("channel", 0, 10)
+	CheckBadRequestStatus(t, resp)
+
+	usages, resp := th.SystemAdminClient.GetStorageUsageReport(model.STORAGE_USAGE_TARGET_TEAM, 0, 200)
+	CheckNoError(t, resp)
+	found := false
+	for _, usage := range usages {
+		if usage.TargetId == th.BasicTeam.Id {
+			found = true
+			assert.Equal(t, model.STORAGE_USAGE_TARGET_TEAM, usage.TargetType)
+			assert.True(t, usage.UsedBytes >= info.Size)
+		}
+	}
+	assert.True(t, found, "should report the usage of the team")
+}
+
 func TestS3TestConnection(t *testing.T) {
 	th := Setup(t)
 	defer th.TearDown()
@@ -8,6 +8,7 @@ import (
 	"io"
 	"mime/multipart"
 	"net/http"
+	"strconv"
 
 	"github.com/zacmm/zacmm-server/audit"
 	"github.com/zacmm/zacmm-server/model"
@@ -20,13 +21,6 @@ func (api *API) InitUpload() {
 }
 
 func createUpload(c *Context, w http.ResponseWriter, r *http.Request) {
-	if !*c.App.Config().FileSettings.EnableFileAttachments {
-		c.Err = model.NewAppError("createUpload",
-			"api.file.attachments.disabled.app_error",
-			nil, "", http.StatusNotImplemented)
-		return
-	}
-
 	us := model.UploadSessionFromJson(r.Body)
 	if us == nil {
 		c.SetInvalidParam("upload")
@@ -37,13 +31,36 @@ func createUpload(c *Context, w http.ResponseWriter, r *http.Request) {
 	defer c.LogAuditRec(auditRec)
 	auditRec.AddMeta("upload", us)
 
-	if !c.App.SessionHasPermissionToChannel(*c.App.Session(), us.ChannelId, model.PERMISSION_UPLOAD_FILE) {
-		c.SetPermissionError(model.PERMISSION_UPLOAD_FILE)
+	switch us.Type {
+	case "", model.UploadTypeAttachment:
+		if !*c.App.Config().FileSettings.EnableFileAttachments {
+			c.Err = model.NewAppError("createUpload",
+				"api.file.attachments.disabled.app_error",
+				nil, "", http.StatusNotImplemented)
+			return
+		}
+
+		if !c.App.SessionHasPermissionToChannel(*c.App.Session(), us.ChannelId, model.PERMISSION_UPLOAD_FILE) {
+			c.SetPermissionError(model.PERMISSION_UPLOAD_FILE)
+			return
+		}
+		us.Type = model.UploadTypeAttachment
+	case model.UploadTypePluginBundle:
+		if !checkPluginBundleUpload(c, "createUpload") {
+			return
+		}
+
+		if us.FileSize > MAXIMUM_PLUGIN_FILE_SIZE {
+			c.Err = model.NewAppError("createUpload", "api.upload.create.plugin_bundle_too_large.app_error",
+				map[string]interface{}{"MaxSize": MAXIMUM_PLUGIN_FILE_SIZE}, "", http.StatusRequestEntityTooLarge)
+			return
+		}
+	default:
+		c.SetInvalidParam("type")
 		return
 	}
 
 	us.Id = model.NewId()
-	us.Type = model.UploadTypeAttachment
 	us.UserId = c.App.Session().UserId
 	us, err := c.App.CreateUploadSession(us)
 	if err != nil {
@@ -56,6 +73,23 @@ func createUpload(c *Context, w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte(us.ToJson()))
 }
 
+// checkPluginBundleUpload checks that plugin bundles can be uploaded and that
+// the session is allowed to do so, setting c.Err otherwise.
+func checkPluginBundleUpload(c *Context, where string) bool {
+	config := c.App.Config()
+	if !*config.PluginSettings.Enable || !*config.PluginSettings.EnableUploads || *config.PluginSettings.RequirePluginSignature {
+		c.Err = model.NewAppError(where, "app.plugin.upload_disabled.app_error", nil, "", http.StatusNotImplemented)
+		return false
+	}
+
+	if !c.App.SessionHasPermissionTo(*c.App.Session(), model.PERMISSION_SYSCONSOLE_WRITE_PLUGINS) {
+		c.SetPermissionError(model.PERMISSION_SYSCONSOLE_WRITE_PLUGINS)
+		return false
+	}
+
+	return true
+}
+
 func getUpload(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequireUploadId()
 	if c.Err != nil {
@@ -77,12 +111,6 @@ func getUpload(c *Context, w http.ResponseWriter, r *http.Request) {
 }
 
 func uploadData(c *Context, w http.ResponseWriter, r *http.Request) {
-	if !*c.App.Config().FileSettings.EnableFileAttachments {
-		c.Err = model.NewAppError("uploadData", "api.file.attachments.disabled.app_error",
-			nil, "", http.StatusNotImplemented)
-		return
-	}
-
 	c.RequireUploadId()
 	if c.Err != nil {
 		return
@@ -93,14 +121,30 @@ func uploadData(c *Context, w http.ResponseWriter, r *http.Request) {
 	auditRec.AddMeta("upload_id", c.Params.UploadId)
 
 	us, err := c.App.GetUploadSession(c.Params.UploadId)
+	// plugin bundles can be uploaded even when file attachments are disabled.
+	if !*c.App.Config().FileSettings.EnableFileAttachments && (err != nil || us.Type != model.UploadTypePluginBundle) {
+		c.Err = model.NewAppError("uploadData", "api.file.attachments.disabled.app_error",
+			nil, "", http.StatusNotImplemented)
+		return
+	}
 	if err != nil {
 		c.Err = err
 		return
 	}
 
-	if us.UserId != c.App.Session().UserId || !c.App.SessionHasPermissionToChannel(*c.App.Session(), us.ChannelId, model.PERMISSION_UPLOAD_FILE) {
-		c.SetPermissionError(model.PERMISSION_UPLOAD_FILE)
-		return
+	if us.Type == model.UploadTypePluginBundle {
+		if !checkPluginBundleUpload(c, "uploadData") {
+			return
+		}
+		if us.UserId != c.App.Session().UserId {
+			c.SetPermissionError(model.PERMISSION_SYSCONSOLE_WRITE_PLUGINS)
+			return
+		}
+	} else {
+		if us.UserId != c.App.Session().UserId || !c.App.SessionHasPermissionToChannel(*c.App.Session(), us.ChannelId, model.PERMISSION_UPLOAD_FILE) {
+			c.SetPermissionError(model.PERMISSION_UPLOAD_FILE)
+			return
+		}
 	}
 
 	boundary, parseErr := parseMultipartRequestHeader(r)
@@ -129,7 +173,31 @@ func uploadData(c *Context, w http.ResponseWriter, r *http.Request) {
 		rd = r.Body
 	}
 
-	info, err := c.App.UploadData(us, rd)
+	checksum := r.Header.Get(model.HEADER_CHUNK_CHECKSUM)
+	auditRec.AddMeta("checksum", checksum)
+
+	if us.Type == model.UploadTypePluginBundle {
+		force, _ := strconv.ParseBool(r.URL.Query().Get("force"))
+		manifest, err := c.App.UploadPluginBundleData(us, rd, checksum, force)
+		if err != nil {
+			c.Err = err
+			return
+		}
+
+		auditRec.Success()
+
+		if manifest == nil {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		auditRec.AddMeta("plugin", manifest)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(manifest.ToJson()))
+		return
+	}
+
+	info, err := c.App.UploadData(us, rd, checksum)
 	if err != nil {
 		c.Err = err
 		return
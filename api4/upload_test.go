@@ -6,11 +6,15 @@ package api4
 import (
 	"bytes"
 	"io"
+	"io/ioutil"
 	"mime/multipart"
 	"net/http"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/zacmm/zacmm-server/model"
+	"github.com/zacmm/zacmm-server/utils/fileutils"
 
 	"github.com/stretchr/testify/require"
 )
@@ -245,6 +249,103 @@ func TestUploadData(t *testing.T) {
 		require.Nil(t, resp.Error)
 		require.Equal(t, file, data)
 	})
+
+	t.Run("resume success with checksums", func(t *testing.T) {
+		u, resp := th.Client.CreateUpload(us)
+		require.Nil(t, resp.Error)
+		require.NotEmpty(t, u)
+
+		info, resp := th.Client.UploadDataWithChecksum(u.Id, data[:5*1024*1024])
+		require.Nil(t, resp.Error)
+		require.Nil(t, info)
+		require.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+		info, resp = th.Client.UploadDataWithChecksum(u.Id, data[5*1024*1024:])
+		require.Nil(t, resp.Error)
+		require.NotEmpty(t, info)
+
+		file, resp := th.Client.GetFile(info.Id)
+		require.Nil(t, resp.Error)
+		require.Equal(t, file, data)
+	})
+}
+
+func TestUploadPluginBundle(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	if *th.App.Config().FileSettings.DriverName == "" {
+		t.Skip("skipping because no file driver is enabled")
+	}
+
+	path, _ := fileutils.FindDir("tests")
+	tarData, err := ioutil.ReadFile(filepath.Join(path, "testplugin.tar.gz"))
+	require.NoError(t, err)
+
+	us := &model.UploadSession{
+		Type:     model.UploadTypePluginBundle,
+		Filename: "testplugin.tar.gz",
+		FileSize: int64(len(tarData)),
+	}
+
+	t.Run("plugin uploads disabled", func(t *testing.T) {
+		u, resp := th.SystemAdminClient.CreateUpload(us)
+		require.Nil(t, u)
+		require.Error(t, resp.Error)
+		require.Equal(t, "app.plugin.upload_disabled.app_error", resp.Error.Id)
+		require.Equal(t, http.StatusNotImplemented, resp.StatusCode)
+	})
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.PluginSettings.Enable = true
+		*cfg.PluginSettings.EnableUploads = true
+	})
+
+	t.Run("no permissions", func(t *testing.T) {
+		u, resp := th.Client.CreateUpload(us)
+		require.Nil(t, u)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("invalid type", func(t *testing.T) {
+		u, resp := th.SystemAdminClient.CreateUpload(&model.UploadSession{
+			Type:     "unknown",
+			Filename: "testplugin.tar.gz",
+			FileSize: int64(len(tarData)),
+		})
+		require.Nil(t, u)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("too large", func(t *testing.T) {
+		u, resp := th.SystemAdminClient.CreateUpload(&model.UploadSession{
+			Type:     model.UploadTypePluginBundle,
+			Filename: "testplugin.tar.gz",
+			FileSize: MAXIMUM_PLUGIN_FILE_SIZE + 1,
+		})
+		require.Nil(t, u)
+		require.Error(t, resp.Error)
+		require.Equal(t, "api.upload.create.plugin_bundle_too_large.app_error", resp.Error.Id)
+		require.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode)
+	})
+
+	t.Run("success with file attachments disabled", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.FileSettings.EnableFileAttachments = false })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.FileSettings.EnableFileAttachments = true })
+
+		u, resp := th.SystemAdminClient.CreateUpload(us)
+		require.Nil(t, resp.Error)
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+		require.Equal(t, model.UploadTypePluginBundle, u.Type)
+
+		manifest, resp := th.SystemAdminClient.UploadPluginBundleData(u.Id, tarData, true)
+		defer os.RemoveAll("plugins/testplugin")
+		require.Nil(t, resp.Error)
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+		require.Equal(t, "testplugin", manifest.Id)
+
+		_, resp = th.SystemAdminClient.GetUpload(u.Id)
+		CheckNotFoundStatus(t, resp)
+	})
 }
 
 func TestUploadDataMultipart(t *testing.T) {
@@ -80,6 +80,8 @@ func (api *API) InitUser() {
 	api.BaseRoutes.Users.Handle("/sessions/revoke/all", api.ApiSessionRequired(revokeAllSessionsAllUsers)).Methods("POST")
 	api.BaseRoutes.Users.Handle("/sessions/device", api.ApiSessionRequired(attachDeviceId)).Methods("PUT")
 	api.BaseRoutes.User.Handle("/audits", api.ApiSessionRequired(getUserAudits)).Methods("GET")
+	api.BaseRoutes.User.Handle("/email_digest/unsubscribe", api.ApiHandlerTrustRequester(getEmailDigestUnsubscribePage)).Methods("GET")
+	api.BaseRoutes.User.Handle("/email_digest/unsubscribe", api.ApiHandlerTrustRequester(unsubscribeFromEmailDigests)).Methods("POST")
 
 	api.BaseRoutes.User.Handle("/tokens", api.ApiSessionRequired(createUserAccessToken)).Methods("POST")
 	api.BaseRoutes.User.Handle("/tokens", api.ApiSessionRequired(getUserAccessTokensForUser)).Methods("GET")
@@ -1126,6 +1128,22 @@ func autocompleteUsers(c *Context, w http.ResponseWriter, r *http.Request) {
 		autocomplete.Users = result
 	}
 
+	var userIds []string
+	for _, user := range append(autocomplete.Users, autocomplete.OutOfChannel...) {
+		userIds = append(userIds, user.Id)
+	}
+
+	if len(userIds) > 0 {
+		// Custom statuses are only decoration, so still return the users
+		// when they cannot be loaded.
+		customStatuses, err := c.App.GetCustomStatusesByUserIds(userIds)
+		if err != nil {
+			mlog.Warn("Failed to get custom statuses for autocomplete", mlog.Err(err))
+		} else {
+			autocomplete.CustomStatuses = customStatuses
+		}
+	}
+
 	w.Write([]byte((autocomplete.ToJson())))
 }
 
@@ -3060,3 +3078,56 @@ func updateReadStateAllThreadsByUser(c *Context, w http.ResponseWriter, r *http.
 	ReturnStatusOK(w)
 	auditRec.Success()
 }
+
+// unsubscribeFromEmailDigests is linked from email digests, so that it can be
+// opened from a browser without a session as well as used for one-click
+// unsubscribes.
+// getEmailDigestUnsubscribePage asks the user to confirm that they want to
+// unsubscribe, since mail scanners follow the links of the emails they check.
+func getEmailDigestUnsubscribePage(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	if err := c.App.CheckEmailDigestUnsubscribeToken(c.Params.UserId, r.URL.Query().Get("token")); err != nil {
+		utils.RenderWebAppError(c.App.Config(), w, r, err, c.App.AsymmetricSigningKey())
+		return
+	}
+
+	page := utils.NewHTMLTemplate(c.App.Srv().HTMLTemplates(), "email_digest_unsubscribe")
+	page.Props["Title"] = c.App.T("api.email_digest.unsubscribe.title")
+	page.Props["Message"] = c.App.T("api.email_digest.unsubscribe.message")
+	page.Props["Button"] = c.App.T("api.email_digest.unsubscribe.button")
+	page.Props["Action"] = r.URL.RequestURI()
+
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	page.RenderToWriter(w)
+}
+
+func unsubscribeFromEmailDigests(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("unsubscribeFromEmailDigests", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("user_id", c.Params.UserId)
+
+	if err := c.App.UnsubscribeFromEmailDigests(c.Params.UserId, r.URL.Query().Get("token")); err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+
+	// the confirmation page posts a form, so send the user on to the site
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		http.Redirect(w, r, c.GetSiteURLHeader(), http.StatusSeeOther)
+		return
+	}
+
+	ReturnStatusOK(w)
+}
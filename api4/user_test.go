@@ -4,7 +4,11 @@
 package api4
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"regexp"
 	"strings"
@@ -5652,3 +5656,53 @@ func TestReadThreads(t *testing.T) {
 		require.Equal(t, uss3.Threads[1].LastViewedAt, timestamp)
 	})
 }
+
+func TestUnsubscribeFromEmailDigests(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.Client.Logout()
+
+	_, resp := th.Client.UnsubscribeFromEmailDigests(th.BasicUser.Id, "")
+	CheckBadRequestStatus(t, resp)
+
+	_, resp = th.Client.UnsubscribeFromEmailDigests(th.BasicUser.Id, model.NewId())
+	CheckBadRequestStatus(t, resp)
+
+	_, resp = th.Client.UnsubscribeFromEmailDigests("junk", model.NewId())
+	CheckBadRequestStatus(t, resp)
+
+	mac := hmac.New(sha256.New, th.App.Srv().PostActionCookieSecret())
+	mac.Write([]byte("email_digest_unsubscribe:" + th.BasicUser.Id))
+	token := hex.EncodeToString(mac.Sum(nil))
+
+	getDigestPreference := func() (*model.Preference, *model.AppError) {
+		return th.App.GetPreferenceByCategoryAndNameForUser(th.BasicUser.Id, model.PREFERENCE_CATEGORY_NOTIFICATIONS, model.PREFERENCE_NAME_EMAIL_DIGEST)
+	}
+
+	t.Run("opening the link should only ask for confirmation", func(t *testing.T) {
+		r, err := th.Client.DoApiGet(th.Client.GetUserRoute(th.BasicUser.Id)+"/email_digest/unsubscribe?token="+token, "")
+		require.Nil(t, err)
+		defer r.Body.Close()
+		require.Equal(t, http.StatusOK, r.StatusCode)
+
+		body, _ := ioutil.ReadAll(r.Body)
+		assert.Contains(t, string(body), "<form method='post'")
+
+		_, appErr := getDigestPreference()
+		require.NotNil(t, appErr)
+	})
+
+	t.Run("confirming should stop email digests", func(t *testing.T) {
+		_, resp := th.Client.UnsubscribeFromEmailDigests(th.BasicUser.Id, token)
+		CheckNoError(t, resp)
+
+		preference, appErr := getDigestPreference()
+		require.Nil(t, appErr)
+		assert.Equal(t, (&model.EmailDigestSchedule{Frequency: model.EMAIL_DIGEST_FREQUENCY_NEVER}).ToJson(), preference.Value)
+	})
+
+	user, err := th.App.GetUser(th.BasicUser.Id)
+	require.Nil(t, err)
+	require.NotEqual(t, "false", user.NotifyProps[model.EMAIL_NOTIFY_PROP])
+}
@@ -124,6 +124,58 @@ func (a *App) initJobs() {
 		a.srv.Jobs.Cloud = jobsCloudInterface(a.srv)
 	}
 
+	if jobsPollClosingInterface != nil {
+		a.srv.Jobs.PollClosing = jobsPollClosingInterface(a)
+	}
+
+	if jobsCustomStatusExpiryInterface != nil {
+		a.srv.Jobs.CustomStatusExpiry = jobsCustomStatusExpiryInterface(a)
+	}
+
+	if jobsExtractContentInterface != nil {
+		a.srv.Jobs.ExtractContent = jobsExtractContentInterface(a)
+	}
+
+	if jobsEmailQueueInterface != nil {
+		a.srv.Jobs.EmailQueue = jobsEmailQueueInterface(a)
+	}
+
+	if jobsEmailDigestInterface != nil {
+		a.srv.Jobs.EmailDigest = jobsEmailDigestInterface(a)
+	}
+
+	if jobsDndScheduleInterface != nil {
+		a.srv.Jobs.DndSchedule = jobsDndScheduleInterface(a)
+	}
+
+	if jobsSavedSearchAlertsInterface != nil {
+		a.srv.Jobs.SavedSearchAlerts = jobsSavedSearchAlertsInterface(a)
+	}
+
+	if jobsReencryptFilesInterface != nil {
+		a.srv.Jobs.ReencryptFiles = jobsReencryptFilesInterface(a)
+	}
+
+	if jobsDeduplicateFilesInterface != nil {
+		a.srv.Jobs.DeduplicateFiles = jobsDeduplicateFilesInterface(a)
+	}
+
+	if jobsScanFilesInterface != nil {
+		a.srv.Jobs.ScanFiles = jobsScanFilesInterface(a)
+	}
+
+	if jobsGeneratePreviewsInterface != nil {
+		a.srv.Jobs.GeneratePreviews = jobsGeneratePreviewsInterface(a)
+	}
+
+	if jobsMigrateFileStoreInterface != nil {
+		a.srv.Jobs.MigrateFileStore = jobsMigrateFileStoreInterface(a)
+	}
+
+	if jobsCleanupUploadSessionsInterface != nil {
+		a.srv.Jobs.CleanupUploadSessions = jobsCleanupUploadSessionsInterface(a)
+	}
+
 	a.srv.Jobs.Workers = a.srv.Jobs.InitWorkers()
 	a.srv.Jobs.Schedulers = a.srv.Jobs.InitSchedulers()
 }
@@ -356,7 +408,7 @@ func (a *App) notifyAdminsOfWarnMetricStatus(warnMetricId string, isE0Edition bo
 		OwnerId:     sysAdmins[0].Id,
 	}
 
-	bot, err := a.getOrCreateWarnMetricsBot(warnMetricsBot)
+	bot, err := a.getOrCreateBot(warnMetricsBot)
 	if err != nil {
 		return err
 	}
@@ -60,14 +60,29 @@ type AppIface interface {
 	// The result can be used, for example, to determine the set of users who would be removed from a channel if the
 	// channel were group-constrained with the given groups.
 	ChannelMembersMinusGroupMembers(channelID string, groupIDs []string, page, perPage int) ([]*model.UserWithGroups, int64, *model.AppError)
+	// CheckEmailDigestUnsubscribeToken checks that the token of an email digest
+	// unsubscribe link was generated for the given user.
+	CheckEmailDigestUnsubscribeToken(userId, token string) *model.AppError
+	// CleanupUploadSessions deletes the upload sessions that received no data
+	// since the given time along with their files, unless data is being uploaded
+	// to them. The files of completed uploads are kept when a FileInfo references
+	// them. It returns the number of deleted sessions.
+	CleanupUploadSessions(updatedBefore int64) (int, *model.AppError)
 	// ClientConfigWithComputed gets the configuration in a format suitable for sending to the client.
 	ClientConfigWithComputed() map[string]string
+	// ClosePoll stops the poll from accepting further votes.
+	ClosePoll(poll *model.Poll) (*model.Poll, *model.AppError)
+	// ClosePollsDue closes every poll whose closing time has passed.
+	ClosePollsDue() *model.AppError
 	// ConvertBotToUser converts a bot to user.
 	ConvertBotToUser(bot *model.Bot, userPatch *model.UserPatch, sysadmin bool) (*model.User, *model.AppError)
 	// ConvertUserToBot converts a user to bot.
 	ConvertUserToBot(user *model.User) (*model.Bot, *model.AppError)
 	// CreateBot creates the given bot and corresponding user.
 	CreateBot(bot *model.Bot) (*model.Bot, *model.AppError)
+	// CreateChannelBookmark adds the bookmark after the channel's existing
+	// bookmarks and notifies the channel.
+	CreateChannelBookmark(bookmark *model.ChannelBookmark) (*model.ChannelBookmark, *model.AppError)
 	// CreateChannelScheme creates a new Scheme of scope channel and assigns it to the channel.
 	CreateChannelScheme(channel *model.Channel) (*model.Scheme, *model.AppError)
 	// CreateDefaultChannels creates channels in the given team for each channel returned by (*App).DefaultChannelNames.
@@ -79,12 +94,31 @@ type AppIface interface {
 	// CreateGuest creates a guest and sets several fields of the returned User struct to
 	// their zero values.
 	CreateGuest(user *model.User) (*model.User, *model.AppError)
+	// CreateKeywordWatch saves a new keyword watch for a user, who must be a
+	// member of the team that the watch covers.
+	CreateKeywordWatch(watch *model.KeywordWatch) (*model.KeywordWatch, *model.AppError)
+	// CreatePoll creates a post of type POST_POLL for the poll and saves the
+	// poll against it. The post carries the current tally in its props so that
+	// clients, as well as compliance exports, can render the results.
+	CreatePoll(poll *model.Poll, currentSessionId string) (*model.Poll, *model.AppError)
+	// CreatePublicLink creates a public link to the file for the user. The link
+	// expires at the given time, or never when it is 0, but no later than allowed
+	// by FileSettings.PublicLinkExpiryHours.
+	CreatePublicLink(siteURL string, info *model.FileInfo, userId string, expireAt int64) (*model.PublicLink, *model.AppError)
+	// CreateSavedSearch saves a search for a user, who must be a member of the
+	// team that the search runs on.
+	CreateSavedSearch(search *model.SavedSearch) (*model.SavedSearch, *model.AppError)
 	// CreateUser creates a user and sets several fields of the returned User struct to
 	// their zero values.
 	CreateUser(user *model.User) (*model.User, *model.AppError)
 	CheckIfTeamAdmin(userId string) (bool, *model.AppError)
 	// Creates and stores FileInfos for a post created before the FileInfos table existed.
 	MigrateFilenamesToFileInfos(post *model.Post) []*model.FileInfo
+	// DeduplicateFiles moves the existing files created in the given time range
+	// to the content-addressed location of their content, so that a single copy
+	// is kept of the files with the same content. It then removes the copies that
+	// are no longer used.
+	DeduplicateFiles(startTime, endTime int64) *model.AppError
 	// DefaultChannelNames returns the list of system-wide default channel names.
 	//
 	// By default the list will be (not necessarily in this order):
@@ -96,8 +130,16 @@ type AppIface interface {
 	DefaultChannelNames() []string
 	// DeleteBotIconImage deletes LHS icon for a bot.
 	DeleteBotIconImage(botUserId string) *model.AppError
+	// DeleteChannelBookmark removes the bookmark and notifies the channel.
+	DeleteChannelBookmark(bookmark *model.ChannelBookmark) *model.AppError
 	// DeleteChannelScheme deletes a channels scheme and sets its SchemeId to nil.
 	DeleteChannelScheme(channel *model.Channel) (*model.Channel, *model.AppError)
+	// DeleteDndSchedule removes the user's Do Not Disturb schedule, ending the
+	// quiet period that it started if that is still running.
+	DeleteDndSchedule(userId string) *model.AppError
+	// DeleteDraft removes the user's draft for a channel or thread and notifies
+	// the user's other sessions.
+	DeleteDraft(userId, channelId, rootId string) *model.AppError
 	// DeleteGroupConstrainedMemberships deletes team and channel memberships of users who aren't members of the allowed
 	// groups of all group-constrained teams and channels.
 	DeleteGroupConstrainedMemberships() error
@@ -124,6 +166,18 @@ type AppIface interface {
 	// A new ExpiresAt is only written if enough time has elapsed since last update.
 	// Returns true only if the session was extended.
 	ExtendSessionExpiryIfNeeded(session *model.Session) bool
+	// ExtractContentForFiles extracts the content of the existing files created
+	// in the given time range that don't have any content extracted yet.
+	ExtractContentForFiles(startTime, endTime int64) *model.AppError
+	// ExtractContentFromFileInfo extracts the text content of the given file
+	// using the document extractor and stores it so the file can be searched.
+	ExtractContentFromFileInfo(fileInfo *model.FileInfo) error
+	// FilePreviewReader returns a reader of the thumbnail or preview stored at
+	// path, as a JPEG, in the best format accepted by the client according to
+	// its Accept header, along with the MIME type of that format. Thumbnails and
+	// previews are converted to other formats when first requested, falling back
+	// to the JPEG when that fails.
+	FilePreviewReader(path, accept string) (filesstore.ReadCloseSeeker, string, *model.AppError)
 	// FillInPostProps should be invoked before saving posts to fill in properties such as
 	// channel_mentions.
 	//
@@ -135,6 +189,13 @@ type AppIface interface {
 	// FilterNonGroupTeamMembers returns the subset of the given user IDs of the users who are not members of groups
 	// associated to the team excluding bots.
 	FilterNonGroupTeamMembers(userIds []string, team *model.Team) ([]string, error)
+	// GenerateFilePreviews generates the missing thumbnails and previews of the
+	// files, not images, uploaded in the given time range. It returns how many
+	// previews were generated.
+	GenerateFilePreviews(startTime, endTime int64) (int, *model.AppError)
+	// GeneratePublicLink returns the most recent active public link of the user
+	// to the file, creating one when there is none.
+	GeneratePublicLink(siteURL string, info *model.FileInfo, userId string) (string, *model.AppError)
 	// GetAllLdapGroupsPage retrieves all LDAP groups under the configured base DN using the default or configured group
 	// filter.
 	GetAllLdapGroupsPage(page int, perPage int, opts model.LdapGroupSearchOpts) ([]*model.Group, int, *model.AppError)
@@ -144,6 +205,8 @@ type AppIface interface {
 	GetBotIconImage(botUserId string) ([]byte, *model.AppError)
 	// GetBots returns the requested page of bots.
 	GetBots(options *model.BotGetOptions) (model.BotList, *model.AppError)
+	// GetChannelBookmarks returns the channel's bookmarks in display order.
+	GetChannelBookmarks(channelId string) ([]*model.ChannelBookmark, *model.AppError)
 	// GetChannelGroupUsers returns the users who are associated to the channel via GroupChannels and GroupMembers.
 	GetChannelGroupUsers(channelID string) ([]*model.User, *model.AppError)
 	// GetChannelModerationsForChannel Gets a channels ChannelModerations from either the higherScoped roles or from the channel scheme roles.
@@ -152,6 +215,13 @@ type AppIface interface {
 	GetClusterPluginStatuses() (model.PluginStatuses, *model.AppError)
 	// GetConfigFile proxies access to the given configuration file to the underlying config store.
 	GetConfigFile(name string) ([]byte, error)
+	// GetCustomStatusesByUserIds returns the custom statuses of the given users
+	// keyed by user id. Users without a custom status, or whose custom status has
+	// expired, are left out.
+	GetCustomStatusesByUserIds(userIds []string) (map[string]*model.CustomStatus, *model.AppError)
+	// GetDraftsForUser returns the user's drafts in the given team, including
+	// those in direct and group message channels.
+	GetDraftsForUser(userId, teamId string) ([]*model.Draft, *model.AppError)
 	// GetEmojiStaticUrl returns a relative static URL for system default emojis,
 	// and the API route for custom ones. Errors if not found or if custom and deleted.
 	GetEmojiStaticUrl(emojiName string) (string, *model.AppError)
@@ -182,10 +252,21 @@ type AppIface interface {
 	// To get the plugins environment when the plugins are disabled, manually acquire the plugins
 	// lock instead.
 	GetPluginsEnvironment() *plugin.Environment
+	// GetPollResults returns the current tally of the poll, including the voters
+	// for each option unless the poll is anonymous.
+	GetPollResults(poll *model.Poll) (*model.PollResults, *model.AppError)
+	// GetPostReadReceipts returns the members of the post's direct or group
+	// channel, other than its author, that have viewed the channel since the post
+	// was created. Members that have not opted in to read receipts are omitted,
+	// and only users that share their own read state may see others'.
+	GetPostReadReceipts(postId, userId string) ([]*model.PostReadReceipt, *model.AppError)
 	// GetProductNotices is called from the frontend to fetch the product notices that are relevant to the caller
 	GetProductNotices(userId, teamId string, client model.NoticeClientType, clientVersion string, locale string) (model.NoticeMessages, *model.AppError)
 	// GetPublicKey will return the actual public key saved in the `name` file.
 	GetPublicKey(name string) ([]byte, *model.AppError)
+	// GetPublicLinksForUser returns the public links created by the user, the
+	// most recent first, with their URLs.
+	GetPublicLinksForUser(siteURL, userId string, page, perPage int) ([]*model.PublicLink, *model.AppError)
 	// GetSanitizedConfig gets the configuration for a system admin without any secrets.
 	GetSanitizedConfig() *model.Config
 	// GetSchemeRolesForChannel Checks if a channel or its team has an override scheme for channel roles and returns the scheme roles or default channel roles.
@@ -193,6 +274,12 @@ type AppIface interface {
 	// GetSessionLengthInMillis returns the session length, in milliseconds,
 	// based on the type of session (Mobile, SSO, Web/LDAP).
 	GetSessionLengthInMillis(session *model.Session) int64
+	// GetStorageUsage returns the storage used by a user or a team, which is
+	// zero until they upload their first file.
+	GetStorageUsage(targetType, targetId string) (*model.StorageUsage, *model.AppError)
+	// GetStorageUsageReport returns a page of the storage usage of the users or
+	// the teams, the largest first.
+	GetStorageUsageReport(targetType string, page, perPage int) ([]*model.StorageUsage, *model.AppError)
 	// GetSuggestions returns suggestions for user input.
 	GetSuggestions(commandArgs *model.CommandArgs, commands []*model.Command, roleID string) []model.AutocompleteSuggestion
 	// GetTeamGroupUsers returns the users who are associated to the team via GroupTeams and GroupMembers.
@@ -201,6 +288,15 @@ type AppIface interface {
 	GetTeamSchemeChannelRoles(teamId string) (guestRoleName string, userRoleName string, adminRoleName string, err *model.AppError)
 	// GetTotalUsersStats is used for the DM list total
 	GetTotalUsersStats(viewRestrictions *model.ViewUsersRestrictions) (*model.UsersStats, *model.AppError)
+	// HandleInboundEmail posts a reply received by email in the thread that the
+	// notification email was about. The recipient address has to carry a valid
+	// signature for the thread and the sender, which prevents anyone else from
+	// posting as the user.
+	HandleInboundEmail(to []string, data []byte) *model.AppError
+	// HasReadReceiptsEnabled reports whether the given user has opted in to
+	// sharing and seeing read receipts. Read receipts are always off when the
+	// feature is disabled server-wide.
+	HasReadReceiptsEnabled(userId string) bool
 	// HubRegister registers a connection to a hub.
 	HubRegister(webConn *WebConn)
 	// HubStart starts all the hubs.
@@ -232,6 +328,16 @@ type AppIface interface {
 	// MentionsToTeamMembers returns all the @ mentions found in message that
 	// belong to users in the specified team, linking them to their users
 	MentionsToTeamMembers(message, teamId string) model.UserMentionMap
+	// MigrateFileStore copies the files of the current file backend to the one
+	// with the given settings and, once they are all copied, switches the
+	// configuration to the new backend. The files that are already in the new
+	// backend with the same content are skipped, so that a migration that failed
+	// can be resumed by running it again. While the files are copied, and until
+	// the configuration is switched, the changes made to the files by every node
+	// of the cluster are mirrored to the new backend, and the switch is refused
+	// if any of them failed on this node. The progress function, if any, is
+	// called after each batch of files.
+	MigrateFileStore(settings *model.FileSettings, progress func(*model.FileStoreMigrationReport)) (*model.FileStoreMigrationReport, *model.AppError)
 	// MoveChannel method is prone to data races if someone joins to channel during the move process. However this
 	// function is only exposed to sysadmins and the possibility of this edge case is realtively small.
 	MoveChannel(team *model.Team, channel *model.Channel, user *model.User) *model.AppError
@@ -246,6 +352,9 @@ type AppIface interface {
 	OverrideIconURLIfEmoji(post *model.Post)
 	// PatchBot applies the given patch to the bot and corresponding user.
 	PatchBot(botUserId string, botPatch *model.BotPatch) (*model.Bot, *model.AppError)
+	// PatchChannelBookmark applies the patch to the bookmark and notifies the
+	// channel.
+	PatchChannelBookmark(bookmark *model.ChannelBookmark, patch *model.ChannelBookmarkPatch) (*model.ChannelBookmark, *model.AppError)
 	// PatchChannelModerationsForChannel Updates a channels scheme roles based on a given ChannelModerationPatch, if the permissions match the higher scoped role the scheme is deleted.
 	PatchChannelModerationsForChannel(channel *model.Channel, channelModerationsPatch []*model.ChannelModerationPatch) ([]*model.ChannelModeration, *model.AppError)
 	// Perform an HTTP POST request to an integration's action endpoint.
@@ -254,27 +363,72 @@ type AppIface interface {
 	DoActionRequest(rawURL string, body []byte) (*http.Response, *model.AppError)
 	// PermanentDeleteBot permanently deletes a bot and its corresponding user.
 	PermanentDeleteBot(botUserId string) *model.AppError
+	// ProcessEmailQueue sends the queued emails whose next attempt is due and
+	// removes the emails that were sent a while ago. It returns how many emails
+	// were sent and how many failed; failed emails are retried by a later run
+	// unless they were dead-lettered.
+	ProcessEmailQueue() (int, int, *model.AppError)
 	// PromoteGuestToUser Convert user's roles and all his mermbership's roles from
 	// guest roles to regular user roles.
 	PromoteGuestToUser(user *model.User, requestorId string) *model.AppError
+	// RecomputeStorageUsage rebuilds the storage usage of all the users and teams
+	// from the stored files.
+	RecomputeStorageUsage() *model.AppError
+	// ReencryptFiles encrypts all the stored files with the current encryption
+	// key, when they were written before the encryption was enabled or before the
+	// key was rotated. Besides the uploaded files and their previews, this covers
+	// the emoji, the profile images, the team and bot icons, the branding and the
+	// plugins. It returns the id of the key.
+	ReencryptFiles() (string, *model.AppError)
+	// RemoveCustomStatus clears the user's custom status and broadcasts an empty
+	// one in its place.
+	RemoveCustomStatus(userId string) *model.AppError
+	// RemoveExpiredCustomStatuses clears every custom status whose expiry has
+	// passed, broadcasting each change.
+	RemoveExpiredCustomStatuses() *model.AppError
+	// RemoveExpiredDNDStatuses ends every timed Do Not Disturb whose end time has
+	// passed, putting back the status that each user had before.
+	RemoveExpiredDNDStatuses() *model.AppError
 	// RenameChannel is used to rename the channel Name and the DisplayName fields
 	RenameChannel(channel *model.Channel, newChannelName string, newDisplayName string) (*model.Channel, *model.AppError)
 	// RenameTeam is used to rename the team Name and the DisplayName fields
 	RenameTeam(team *model.Team, newTeamName string, newDisplayName string) (*model.Team, *model.AppError)
+	// RequeueEmail schedules a queued email, typically a dead-lettered one, to be
+	// sent again by the next run of the email queue job.
+	RequeueEmail(id string) (*model.QueuedEmail, *model.AppError)
 	// RevokeSessionsFromAllUsers will go through all the sessions active
 	// in the server and revoke them
 	RevokeSessionsFromAllUsers() *model.AppError
 	// SaveConfig replaces the active configuration, optionally notifying cluster peers.
 	SaveConfig(newCfg *model.Config, sendConfigChangeClusterMessage bool) *model.AppError
+	// ScanFiles scans the existing files created in the given time range for
+	// malware, with the latest signatures of the scanner, and quarantines the
+	// infected ones. It returns the number of files quarantined.
+	ScanFiles(startTime, endTime int64) (int, *model.AppError)
 	// SearchAllChannels returns a list of channels, the total count of the results of the search (if the paginate search option is true), and an error.
 	SearchAllChannels(term string, opts model.ChannelSearchOpts) (*model.ChannelListWithTeamData, int64, *model.AppError)
 	// SearchAllTeams returns a team list and the total count of the results
 	SearchAllTeams(searchOpts *model.TeamSearch) ([]*model.Team, int64, *model.AppError)
+	// SendAckToPushProxy hands the acknowledgement that a device received a push
+	// notification to the transport of the device's platform.
+	SendAckToPushProxy(ack *model.PushNotificationAck) error
+	// SendPendingEmailDigests sends an email digest to the users whose digest is
+	// due and drops the notifications that they have read in the meantime.
+	SendPendingEmailDigests() *model.AppError
+	// SendSavedSearchAlerts runs every saved search that notifies its user about
+	// new matches, and tells the user about the posts made since the last run.
+	SendSavedSearchAlerts() *model.AppError
 	// ServePluginPublicRequest serves public plugin files
 	// at the URL http(s)://$SITE_URL/plugins/$PLUGIN_ID/public/{anything}
 	ServePluginPublicRequest(w http.ResponseWriter, r *http.Request)
 	// ServerBusyStateChanged is called when a CLUSTER_EVENT_BUSY_STATE_CHANGED is received.
 	ServerBusyStateChanged(sbs *model.ServerBusyState)
+	// SessionHasPermissionToFile checks whether the session may access the file,
+	// either as its creator or through the channel of the post it is attached to.
+	// Files that are not attached to a post can also be shared through channel
+	// bookmarks, in which case any bookmarked channel the session can access grants
+	// access.
+	SessionHasPermissionToFile(session model.Session, info *model.FileInfo, permission *model.Permission) bool
 	// SessionHasPermissionToManageBot returns nil if the session has access to manage the given bot.
 	// This function deviates from other authorization checks in returning an error instead of just
 	// a boolean, allowing the permission failure to be exposed with more granularity.
@@ -285,10 +439,21 @@ type AppIface interface {
 	SetBotIconImage(botUserId string, file io.ReadSeeker) *model.AppError
 	// SetBotIconImageFromMultiPartFile sets LHS icon for a bot.
 	SetBotIconImageFromMultiPartFile(botUserId string, imageData *multipart.FileHeader) *model.AppError
+	// SetCustomStatus replaces the user's custom status and broadcasts it along
+	// with their current status.
+	SetCustomStatus(userId string, customStatus *model.CustomStatus) (*model.CustomStatus, *model.AppError)
+	// SetDndSchedule replaces the user's Do Not Disturb schedule. If a quiet
+	// period of the new schedule is already running, Do Not Disturb starts right
+	// away instead of on the next run of the job.
+	SetDndSchedule(userId string, schedule *model.DndSchedule) (*model.DndSchedule, *model.AppError)
 	// SetSessionExpireInDays sets the session's expiry the specified number of days
 	// relative to either the session creation date or the current time, depending
 	// on the `ExtendSessionOnActivity` config setting.
 	SetSessionExpireInDays(session *model.Session, days int)
+	// SetStatusDoNotDisturbTimed sets the user's status to Do Not Disturb until
+	// endTime, after which the DND expiry job puts back the status they had
+	// before.
+	SetStatusDoNotDisturbTimed(userId string, endTime int64) *model.AppError
 	// SetStatusLastActivityAt sets the last activity at for a user on the local app server and updates
 	// status to away if needed. Used by the WS to set status to away if an 'online' device disconnects
 	// while an 'away' device is still connected
@@ -313,16 +478,33 @@ type AppIface interface {
 	DoAdvancedPermissionsMigration()
 	// This to be used for places we check the users password when they are already logged in
 	DoubleCheckPassword(user *model.User, password string) *model.AppError
+	// UnsubscribeFromEmailDigests stops the email digests of the user that the
+	// token was generated for and drops their pending notifications. Their other
+	// email notifications are left as they are.
+	UnsubscribeFromEmailDigests(userId, token string) *model.AppError
 	// UpdateBotActive marks a bot as active or inactive, along with its corresponding user.
 	UpdateBotActive(botUserId string, active bool) (*model.Bot, *model.AppError)
 	// UpdateBotOwner changes a bot's owner to the given value.
 	UpdateBotOwner(botUserId, newOwnerId string) (*model.Bot, *model.AppError)
 	// UpdateChannel updates a given channel by its Id. It also publishes the CHANNEL_UPDATED event.
 	UpdateChannel(channel *model.Channel) (*model.Channel, *model.AppError)
+	// UpdateChannelBookmarkSortOrder moves the bookmark to newIndex among the
+	// channel's bookmarks and notifies the channel of the new order.
+	UpdateChannelBookmarkSortOrder(bookmarkId, channelId string, newIndex int64) ([]*model.ChannelBookmark, *model.AppError)
 	// UpdateChannelScheme saves the new SchemeId of the channel passed.
 	UpdateChannelScheme(channel *model.Channel) (*model.Channel, *model.AppError)
+	// UpdateKeywordWatch changes the pattern and the delivery of an existing
+	// keyword watch. Its owner and team can't be changed.
+	UpdateKeywordWatch(watch *model.KeywordWatch) (*model.KeywordWatch, *model.AppError)
 	// UpdateProductNotices is called periodically from a scheduled worker to fetch new notices and update the cache
 	UpdateProductNotices() *model.AppError
+	// UpdateSavedSearch changes the name, the terms, the options and the
+	// notifications of an existing saved search. Its owner and team can't be
+	// changed.
+	UpdateSavedSearch(search *model.SavedSearch) (*model.SavedSearch, *model.AppError)
+	// UpdateScheduledDndStatuses ends every timed Do Not Disturb that has expired
+	// and starts it for the users whose scheduled quiet period has begun.
+	UpdateScheduledDndStatuses() *model.AppError
 	// UpdateViewedProductNotices is called from the frontend to mark a set of notices as 'viewed' by user
 	UpdateViewedProductNotices(userId string, noticeIds []string) *model.AppError
 	// UpdateViewedProductNoticesForNewUser is called when new user is created to mark all current notices for this
@@ -330,6 +512,10 @@ type AppIface interface {
 	UpdateViewedProductNoticesForNewUser(userId string)
 	// UpdateWebConnUserActivity sets the LastUserActivityAt of the hub for the given session.
 	UpdateWebConnUserActivity(session model.Session, activityAt int64)
+	// UploadData writes a chunk of data to an attachment upload session. If a
+	// checksum is given, the chunk is rejected when it doesn't match. Once the
+	// upload is complete it creates and returns the FileInfo.
+	UploadData(us *model.UploadSession, rd io.Reader, checksum string) (*model.FileInfo, *model.AppError)
 	// UploadFile uploads a single file in form of a completely constructed byte array for a channel.
 	UploadFile(data []byte, channelId string, filename string) (*model.FileInfo, *model.AppError)
 	// UploadFileX uploads a single file as specified in t. It applies the upload
@@ -338,15 +524,30 @@ type AppIface interface {
 	// upload, returning a rejection error. In this case FileInfo would have
 	// contained the last "good" FileInfo before the execution of that plugin.
 	UploadFileX(channelId, name string, input io.Reader, opts ...func(*UploadFileTask)) (*model.FileInfo, *model.AppError)
+	// UploadPluginBundleData writes a chunk of data to a plugin bundle upload
+	// session. Once the bundle is complete it is installed, replacing an existing
+	// plugin with the same id if replace is set, and its manifest is returned.
+	// The bundle is kept if the installation fails, so that it can be retried
+	// without uploading it again.
+	UploadPluginBundleData(us *model.UploadSession, rd io.Reader, checksum string, replace bool) (*model.Manifest, *model.AppError)
 	// Uploads some files to the given team and channel as the given user. files and filenames should have
 	// the same length. clientIds should either not be provided or have the same length as files and filenames.
 	// The provided files should be closed by the caller so that they are not leaked.
 	UploadFiles(teamId string, channelId string, userId string, files []io.ReadCloser, filenames []string, clientIds []string, now time.Time) (*model.FileUploadResponse, *model.AppError)
+	// UpsertDraft saves the user's draft for a channel or thread, replacing any
+	// existing one, and notifies the user's other sessions.
+	UpsertDraft(draft *model.Draft) (*model.Draft, *model.AppError)
+	// UsePublicLink checks that the public link with the given id and hash gives
+	// access to the file, and records the download.
+	UsePublicLink(fileId, linkId, hash string) (*model.PublicLink, *model.AppError)
 	// UserIsInAdminRoleGroup returns true at least one of the user's groups are configured to set the members as
 	// admins in the given syncable.
 	UserIsInAdminRoleGroup(userID, syncableID string, syncableType model.GroupSyncableType) (bool, *model.AppError)
 	// VerifyPlugin checks that the given signature corresponds to the given plugin and matches a trusted certificate.
 	VerifyPlugin(plugin, signature io.ReadSeeker) *model.AppError
+	// VoteOnPoll replaces the user's votes on the poll with the requested options
+	// and broadcasts the updated tally.
+	VoteOnPoll(poll *model.Poll, userId string, vote *model.PollVoteRequest) (*model.PollResults, *model.AppError)
 	//GetUserStatusesByIds used by apiV4
 	GetUserStatusesByIds(userIds []string) ([]*model.Status, *model.AppError)
 	AcceptLanguage() string
@@ -419,6 +620,7 @@ type AppIface interface {
 	Compliance() einterfaces.ComplianceInterface
 	Config() *model.Config
 	Context() context.Context
+	CopyFile(oldPath, newPath string) *model.AppError
 	CopyFileInfos(userId string, fileIds []string) ([]string, *model.AppError)
 	CreateChannel(channel *model.Channel, addMember bool) (*model.Channel, *model.AppError)
 	CreateChannelWithUser(channel *model.Channel, userId string) (*model.Channel, *model.AppError)
@@ -469,6 +671,7 @@ type AppIface interface {
 	DeleteGroupMember(groupID string, userID string) (*model.GroupMember, *model.AppError)
 	DeleteGroupSyncable(groupID string, syncableID string, syncableType model.GroupSyncableType) (*model.GroupSyncable, *model.AppError)
 	DeleteIncomingWebhook(hookId string) *model.AppError
+	DeleteKeywordWatch(watchId string) *model.AppError
 	DeleteOAuthApp(appId string) *model.AppError
 	DeleteOutgoingWebhook(hookId string) *model.AppError
 	DeletePluginKey(pluginId string, key string) *model.AppError
@@ -476,6 +679,7 @@ type AppIface interface {
 	DeletePostFiles(post *model.Post)
 	DeletePreferences(userId string, preferences model.Preferences) *model.AppError
 	DeleteReactionForPost(reaction *model.Reaction) *model.AppError
+	DeleteSavedSearch(savedSearchId string) *model.AppError
 	DeleteScheme(schemeId string) (*model.Scheme, *model.AppError)
 	DeleteSidebarCategory(userId, teamId, categoryId string) *model.AppError
 	DeleteToken(token *model.Token) *model.AppError
@@ -504,7 +708,6 @@ type AppIface interface {
 	FilterUsersByVisible(viewer *model.User, otherUsers []*model.User) ([]*model.User, *model.AppError)
 	FindTeamByName(name string) bool
 	GenerateMfaSecret(userId string) (*model.MfaSecret, *model.AppError)
-	GeneratePublicLink(siteURL string, info *model.FileInfo) string
 	GetActivePluginManifests() ([]*model.Manifest, *model.AppError)
 	GetAllChannels(page, perPage int, opts model.ChannelSearchOpts) (*model.ChannelListWithTeamData, *model.AppError)
 	GetAllChannelsCount(opts model.ChannelSearchOpts) (int64, *model.AppError)
@@ -527,6 +730,7 @@ type AppIface interface {
 	GetBrandImage() ([]byte, *model.AppError)
 	GetBulkReactionsForPosts(postIds []string) (map[string][]*model.Reaction, *model.AppError)
 	GetChannel(channelId string) (*model.Channel, *model.AppError)
+	GetChannelBookmark(bookmarkId string) (*model.ChannelBookmark, *model.AppError)
 	GetChannelByName(channelName, teamId string, includeDeleted bool) (*model.Channel, *model.AppError)
 	GetChannelByNameForTeamName(channelName, teamName string, includeDeleted bool) (*model.Channel, *model.AppError)
 	GetChannelCounts(teamId string, userId string) (*model.ChannelCounts, *model.AppError)
@@ -552,9 +756,11 @@ type AppIface interface {
 	GetComplianceReport(reportId string) (*model.Compliance, *model.AppError)
 	GetComplianceReports(page, perPage int) (model.Compliances, *model.AppError)
 	GetCookieDomain() string
+	GetCustomStatus(userId string) (*model.CustomStatus, *model.AppError)
 	GetDataRetentionPolicy() (*model.DataRetentionPolicy, *model.AppError)
 	GetDefaultProfileImage(user *model.User) ([]byte, *model.AppError)
 	GetDeletedChannels(teamId string, offset int, limit int, userId string) (*model.ChannelList, *model.AppError)
+	GetDndSchedule(userId string) (*model.DndSchedule, *model.AppError)
 	GetEmoji(emojiId string) (*model.Emoji, *model.AppError)
 	GetEmojiByName(emojiName string) (*model.Emoji, *model.AppError)
 	GetEmojiImage(emojiId string) ([]byte, string, *model.AppError)
@@ -594,6 +800,8 @@ type AppIface interface {
 	GetJobsByType(jobType string, offset int, limit int) ([]*model.Job, *model.AppError)
 	GetJobsByTypePage(jobType string, page int, perPage int) ([]*model.Job, *model.AppError)
 	GetJobsPage(page int, perPage int) ([]*model.Job, *model.AppError)
+	GetKeywordWatch(watchId string) (*model.KeywordWatch, *model.AppError)
+	GetKeywordWatchesForUser(userId string) ([]*model.KeywordWatch, *model.AppError)
 	GetLatestTermsOfService() (*model.TermsOfService, *model.AppError)
 	GetLogs(page, perPage int) ([]string, *model.AppError)
 	GetLogsSkipSend(page, perPage int) ([]string, *model.AppError)
@@ -627,6 +835,7 @@ type AppIface interface {
 	GetPinnedPosts(channelId string) (*model.PostList, *model.AppError)
 	GetPluginKey(pluginId string, key string) ([]byte, *model.AppError)
 	GetPlugins() (*model.PluginsResponse, *model.AppError)
+	GetPoll(pollId string) (*model.Poll, *model.AppError)
 	GetPostAfterTime(channelId string, time int64) (*model.Post, *model.AppError)
 	GetPostIdAfterTime(channelId string, time int64) (string, *model.AppError)
 	GetPostIdBeforeTime(channelId string, time int64) (string, *model.AppError)
@@ -649,6 +858,9 @@ type AppIface interface {
 	GetProfileImage(user *model.User) ([]byte, bool, *model.AppError)
 	GetPublicChannelsByIdsForTeam(teamId string, channelIds []string) (*model.ChannelList, *model.AppError)
 	GetPublicChannelsForTeam(teamId string, offset int, limit int) (*model.ChannelList, *model.AppError)
+	GetPublicLink(linkId string) (*model.PublicLink, *model.AppError)
+	GetQueuedEmail(id string) (*model.QueuedEmail, *model.AppError)
+	GetQueuedEmails(status string, page, perPage int) ([]*model.QueuedEmail, *model.AppError)
 	GetReactionsForPost(postId string) ([]*model.Reaction, *model.AppError)
 	GetRecentlyActiveUsersForTeam(teamId string) (map[string]*model.User, *model.AppError)
 	GetRecentlyActiveUsersForTeamPage(teamId string, page, perPage int, asAdmin bool, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, *model.AppError)
@@ -659,6 +871,8 @@ type AppIface interface {
 	GetSamlMetadata() (string, *model.AppError)
 	GetSamlMetadataFromIdp(idpMetadataUrl string) (*model.SamlMetadataResponse, *model.AppError)
 	GetSanitizeOptions(asAdmin bool) map[string]bool
+	GetSavedSearch(savedSearchId string) (*model.SavedSearch, *model.AppError)
+	GetSavedSearchesForUser(userId string) ([]*model.SavedSearch, *model.AppError)
 	GetScheme(id string) (*model.Scheme, *model.AppError)
 	GetSchemeByName(name string) (*model.Scheme, *model.AppError)
 	GetSchemeRolesForTeam(teamId string) (string, string, string, *model.AppError)
@@ -858,6 +1072,7 @@ type AppIface interface {
 	RestrictUsersSearchByPermissions(userId string, options *model.UserSearchOptions) (*model.UserSearchOptions, *model.AppError)
 	RevokeAccessToken(token string) *model.AppError
 	RevokeAllSessions(userId string) *model.AppError
+	RevokePublicLink(linkId string) *model.AppError
 	RevokeSession(session *model.Session) *model.AppError
 	RevokeSessionById(sessionId string) *model.AppError
 	RevokeSessionsForDeviceId(userId string, deviceId string, currentSessionId string) *model.AppError
@@ -879,6 +1094,7 @@ type AppIface interface {
 	SearchChannelsUserNotIn(teamId string, userId string, term string) (*model.ChannelList, *model.AppError)
 	SearchEmoji(name string, prefixOnly bool, limit int) ([]*model.Emoji, *model.AppError)
 	SearchEngine() *searchengine.Broker
+	SearchFilesInTeamForUser(terms string, userId string, teamId string, isOrSearch bool, includeDeletedChannels bool, timeZoneOffset int, page, perPage int) (*model.FileInfoList, *model.AppError)
 	SearchGroupChannels(userId, term string) (*model.ChannelList, *model.AppError)
 	SearchPostsInTeam(teamId string, paramsList []*model.SearchParams) (*model.PostList, *model.AppError)
 	SearchPostsInTeamForUser(terms string, userId string, teamId string, isOrSearch bool, includeDeletedChannels bool, timeZoneOffset int, page, perPage int) (*model.PostSearchResults, *model.AppError)
@@ -892,7 +1108,6 @@ type AppIface interface {
 	SearchUsersNotInChannel(teamId string, channelId string, term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError)
 	SearchUsersNotInTeam(notInTeamId string, term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError)
 	SearchUsersWithoutTeam(term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError)
-	SendAckToPushProxy(ack *model.PushNotificationAck) error
 	SendAutoResponse(channel *model.Channel, receiver *model.User, post *model.Post) (bool, *model.AppError)
 	SendAutoResponseIfNecessary(channel *model.Channel, sender *model.User, post *model.Post) (bool, *model.AppError)
 	SendEmailVerification(user *model.User, newEmail, redirect string) *model.AppError
@@ -1010,7 +1225,6 @@ type AppIface interface {
 	UpdateUserAuth(userId string, userAuth *model.UserAuth) (*model.UserAuth, *model.AppError)
 	UpdateUserNotifyProps(userId string, props map[string]string) (*model.User, *model.AppError)
 	UpdateUserRoles(userId string, newRoles string, sendWebSocketEvent bool) (*model.User, *model.AppError)
-	UploadData(us *model.UploadSession, rd io.Reader) (*model.FileInfo, *model.AppError)
 	UploadEmojiImage(id string, imageData *multipart.FileHeader) *model.AppError
 	UploadMultipartFiles(teamId string, channelId string, userId string, fileHeaders []*multipart.FileHeader, clientIds []string, now time.Time) (*model.FileUploadResponse, *model.AppError)
 	UpsertGroupMember(groupID string, userID string) (*model.GroupMember, *model.AppError)
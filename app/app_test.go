@@ -117,6 +117,14 @@ func TestDoAdvancedPermissionsMigration(t *testing.T) {
 			model.PERMISSION_MANAGE_PRIVATE_CHANNEL_MEMBERS.Id,
 			model.PERMISSION_DELETE_POST.Id,
 			model.PERMISSION_EDIT_POST.Id,
+			model.PERMISSION_ADD_BOOKMARK_PUBLIC_CHANNEL.Id,
+			model.PERMISSION_EDIT_BOOKMARK_PUBLIC_CHANNEL.Id,
+			model.PERMISSION_DELETE_BOOKMARK_PUBLIC_CHANNEL.Id,
+			model.PERMISSION_ORDER_BOOKMARK_PUBLIC_CHANNEL.Id,
+			model.PERMISSION_ADD_BOOKMARK_PRIVATE_CHANNEL.Id,
+			model.PERMISSION_EDIT_BOOKMARK_PRIVATE_CHANNEL.Id,
+			model.PERMISSION_DELETE_BOOKMARK_PRIVATE_CHANNEL.Id,
+			model.PERMISSION_ORDER_BOOKMARK_PRIVATE_CHANNEL.Id,
 		},
 		"channel_admin": {
 			model.PERMISSION_MANAGE_CHANNEL_ROLES.Id,
@@ -246,6 +254,14 @@ func TestDoAdvancedPermissionsMigration(t *testing.T) {
 			model.PERMISSION_MANAGE_PRIVATE_CHANNEL_MEMBERS.Id,
 			model.PERMISSION_DELETE_POST.Id,
 			model.PERMISSION_EDIT_POST.Id,
+			model.PERMISSION_ADD_BOOKMARK_PUBLIC_CHANNEL.Id,
+			model.PERMISSION_EDIT_BOOKMARK_PUBLIC_CHANNEL.Id,
+			model.PERMISSION_DELETE_BOOKMARK_PUBLIC_CHANNEL.Id,
+			model.PERMISSION_ORDER_BOOKMARK_PUBLIC_CHANNEL.Id,
+			model.PERMISSION_ADD_BOOKMARK_PRIVATE_CHANNEL.Id,
+			model.PERMISSION_EDIT_BOOKMARK_PRIVATE_CHANNEL.Id,
+			model.PERMISSION_DELETE_BOOKMARK_PRIVATE_CHANNEL.Id,
+			model.PERMISSION_ORDER_BOOKMARK_PRIVATE_CHANNEL.Id,
 		},
 		"channel_admin": {
 			model.PERMISSION_MANAGE_CHANNEL_ROLES.Id,
@@ -104,6 +104,30 @@ func (a *App) SessionHasPermissionToChannelByPost(session model.Session, postId
 	return a.SessionHasPermissionTo(session, permission)
 }
 
+// SessionHasPermissionToFile checks whether the session may access the file,
+// either as its creator or through the channel of the post it is attached to.
+// Files that are not attached to a post can also be shared through channel
+// bookmarks, in which case any bookmarked channel the session can access grants
+// access.
+func (a *App) SessionHasPermissionToFile(session model.Session, info *model.FileInfo, permission *model.Permission) bool {
+	if info.CreatorId == session.UserId {
+		return true
+	}
+
+	if info.PostId == "" {
+		if bookmarks, err := a.Srv().Store.ChannelBookmark().GetBookmarksForFile(info.Id); err == nil && len(bookmarks) > 0 {
+			for _, bookmark := range bookmarks {
+				if a.SessionHasPermissionToChannel(session, bookmark.ChannelId, permission) {
+					return true
+				}
+			}
+			return false
+		}
+	}
+
+	return a.SessionHasPermissionToChannelByPost(session, info.PostId, permission)
+}
+
 func (a *App) SessionHasPermissionToCategory(session model.Session, userId, teamId, categoryId string) bool {
 	if a.SessionHasPermissionTo(session, model.PERMISSION_EDIT_OTHER_USERS) {
 		return true
@@ -84,7 +84,7 @@ func (a *App) CreateBot(bot *model.Bot) (*model.Bot, *model.AppError) {
 	return savedBot, nil
 }
 
-func (a *App) getOrCreateWarnMetricsBot(botDef *model.Bot) (*model.Bot, *model.AppError) {
+func (a *App) getOrCreateBot(botDef *model.Bot) (*model.Bot, *model.AppError) {
 	botUser, appErr := a.GetUserByUsername(botDef.Username)
 	if appErr != nil {
 		if appErr.StatusCode != http.StatusNotFound {
@@ -111,9 +111,9 @@ func (a *App) getOrCreateWarnMetricsBot(botDef *model.Bot) (*model.Bot, *model.A
 				default:
 					code = "app.user.save.existing.app_error"
 				}
-				return nil, model.NewAppError("getOrCreateWarnMetricsBot", code, nil, invErr.Error(), http.StatusBadRequest)
+				return nil, model.NewAppError("getOrCreateBot", code, nil, invErr.Error(), http.StatusBadRequest)
 			default:
-				return nil, model.NewAppError("getOrCreateWarnMetricsBot", "app.user.save.app_error", nil, nErr.Error(), http.StatusInternalServerError)
+				return nil, model.NewAppError("getOrCreateBot", "app.user.save.app_error", nil, nErr.Error(), http.StatusInternalServerError)
 			}
 		}
 		botDef.UserId = user.Id
@@ -127,14 +127,14 @@ func (a *App) getOrCreateWarnMetricsBot(botDef *model.Bot) (*model.Bot, *model.A
 			case errors.As(nErr, &nAppErr): // in case we haven't converted to plain error.
 				return nil, nAppErr
 			default: // last fallback in case it doesn't map to an existing app error.
-				return nil, model.NewAppError("getOrCreateWarnMetricsBot", "app.bot.createbot.internal_error", nil, nErr.Error(), http.StatusInternalServerError)
+				return nil, model.NewAppError("getOrCreateBot", "app.bot.createbot.internal_error", nil, nErr.Error(), http.StatusInternalServerError)
 			}
 		}
 		return savedBot, nil
 	}
 
 	if botUser == nil {
-		return nil, model.NewAppError("getOrCreateWarnMetricsBot", "app.bot.createbot.internal_error", nil, "", http.StatusInternalServerError)
+		return nil, model.NewAppError("getOrCreateBot", "app.bot.createbot.internal_error", nil, "", http.StatusInternalServerError)
 	}
 
 	//return the bot for this user
@@ -147,6 +147,44 @@ func (a *App) getOrCreateWarnMetricsBot(botDef *model.Bot) (*model.Bot, *model.A
 	return savedBot, nil
 }
 
+// The number of suffixed usernames that getOrCreateBotByOwner tries when the
+// username of a bot is already taken.
+const botUsernameAttempts = 10
+
+// getOrCreateBotByOwner returns the bot owned by the owner of botDef, creating
+// it when there is none. The server's own bots are owned by a fixed marker
+// rather than a user, so they are found by their owner even when a user took
+// their username first, in which case the bot is created with a suffixed one.
+func (a *App) getOrCreateBotByOwner(botDef *model.Bot) (*model.Bot, *model.AppError) {
+	bots, err := a.Srv().Store.Bot().GetAll(&model.BotGetOptions{OwnerId: botDef.OwnerId, PerPage: 1})
+	if err != nil {
+		return nil, model.NewAppError("getOrCreateBotByOwner", "app.bot.getbots.internal_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	if len(bots) > 0 {
+		return bots[0], nil
+	}
+
+	username := botDef.Username
+	for i := 2; ; i++ {
+		_, appErr := a.GetUserByUsername(username)
+		if appErr != nil {
+			if appErr.StatusCode != http.StatusNotFound {
+				return nil, appErr
+			}
+			break
+		}
+
+		if i > botUsernameAttempts {
+			return nil, model.NewAppError("getOrCreateBotByOwner", "app.user.save.username_exists.app_error", nil, "username="+botDef.Username, http.StatusBadRequest)
+		}
+		username = fmt.Sprintf("%s-%d", botDef.Username, i)
+	}
+
+	bot := botDef.Clone()
+	bot.Username = username
+	return a.getOrCreateBot(bot)
+}
+
 // PatchBot applies the given patch to the bot and corresponding user.
 func (a *App) PatchBot(botUserId string, botPatch *model.BotPatch) (*model.Bot, *model.AppError) {
 	bot, err := a.GetBot(botUserId, true)
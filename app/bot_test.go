@@ -266,6 +266,33 @@ func TestGetBot(t *testing.T) {
 	})
 }
 
+func TestGetOrCreateBotByOwner(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	ownerId := model.NewId()
+
+	t.Run("username taken by a user", func(t *testing.T) {
+		user := th.CreateUser()
+
+		bot, err := th.App.getOrCreateBotByOwner(&model.Bot{
+			Username: user.Username,
+			OwnerId:  ownerId,
+		})
+		require.Nil(t, err)
+		defer th.App.PermanentDeleteBot(bot.UserId)
+		assert.Equal(t, user.Username+"-2", bot.Username)
+		assert.NotEqual(t, user.Id, bot.UserId)
+
+		again, err := th.App.getOrCreateBotByOwner(&model.Bot{
+			Username: user.Username,
+			OwnerId:  ownerId,
+		})
+		require.Nil(t, err)
+		assert.Equal(t, bot.UserId, again.UserId)
+	})
+}
+
 func TestGetBots(t *testing.T) {
 	th := Setup(t)
 	defer th.TearDown()
@@ -2526,6 +2526,11 @@ func (a *App) MarkChannelsAsViewed(channelIds []string, userId string, currentSe
 			a.Publish(message)
 		}
 	}
+
+	a.Srv().Go(func() {
+		a.publishReadReceipts(times, userId)
+	})
+
 	for _, channelId := range channelsToClearPushNotifications {
 		a.clearPushNotification(currentSessionId, userId, channelId)
 	}
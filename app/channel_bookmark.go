@@ -0,0 +1,190 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/zacmm/zacmm-server/model"
+	"github.com/zacmm/zacmm-server/store"
+)
+
+func (a *App) GetChannelBookmark(bookmarkId string) (*model.ChannelBookmark, *model.AppError) {
+	bookmark, err := a.Srv().Store.ChannelBookmark().Get(bookmarkId, false)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("GetChannelBookmark", "app.channel_bookmark.get.app_error", nil, nfErr.Error(), http.StatusNotFound)
+		default:
+			return nil, model.NewAppError("GetChannelBookmark", "app.channel_bookmark.get.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return bookmark, nil
+}
+
+// GetChannelBookmarks returns the channel's bookmarks in display order.
+func (a *App) GetChannelBookmarks(channelId string) ([]*model.ChannelBookmark, *model.AppError) {
+	bookmarks, err := a.Srv().Store.ChannelBookmark().GetBookmarksForChannel(channelId)
+	if err != nil {
+		return nil, model.NewAppError("GetChannelBookmarks", "app.channel_bookmark.get_for_channel.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return bookmarks, nil
+}
+
+// CreateChannelBookmark adds the bookmark after the channel's existing
+// bookmarks and notifies the channel.
+func (a *App) CreateChannelBookmark(bookmark *model.ChannelBookmark) (*model.ChannelBookmark, *model.AppError) {
+	if err := a.checkChannelBookmark(bookmark); err != nil {
+		return nil, err
+	}
+
+	bookmarks, err := a.GetChannelBookmarks(bookmark.ChannelId)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(bookmarks) >= model.CHANNEL_BOOKMARKS_PER_CHANNEL_MAX {
+		return nil, model.NewAppError("CreateChannelBookmark", "app.channel_bookmark.save.limit.app_error", map[string]interface{}{"Max": model.CHANNEL_BOOKMARKS_PER_CHANNEL_MAX}, "channel_id="+bookmark.ChannelId, http.StatusBadRequest)
+	}
+
+	bookmark.Id = ""
+	bookmark.CreateAt = 0
+	savedBookmark, nErr := a.Srv().Store.ChannelBookmark().Save(bookmark)
+	if nErr != nil {
+		var appErr *model.AppError
+		switch {
+		case errors.As(nErr, &appErr):
+			return nil, appErr
+		default:
+			return nil, model.NewAppError("CreateChannelBookmark", "app.channel_bookmark.save.app_error", nil, nErr.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	message := model.NewWebSocketEvent(model.WEBSOCKET_EVENT_CHANNEL_BOOKMARK_CREATED, "", savedBookmark.ChannelId, "", nil)
+	message.Add("bookmark", savedBookmark.ToJson())
+	a.Publish(message)
+
+	return savedBookmark, nil
+}
+
+// PatchChannelBookmark applies the patch to the bookmark and notifies the
+// channel.
+func (a *App) PatchChannelBookmark(bookmark *model.ChannelBookmark, patch *model.ChannelBookmarkPatch) (*model.ChannelBookmark, *model.AppError) {
+	bookmark.Patch(patch)
+	if err := a.checkChannelBookmark(bookmark); err != nil {
+		return nil, err
+	}
+
+	if err := a.Srv().Store.ChannelBookmark().Update(bookmark); err != nil {
+		var appErr *model.AppError
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &appErr):
+			return nil, appErr
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("PatchChannelBookmark", "app.channel_bookmark.update.app_error", nil, nfErr.Error(), http.StatusNotFound)
+		default:
+			return nil, model.NewAppError("PatchChannelBookmark", "app.channel_bookmark.update.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	message := model.NewWebSocketEvent(model.WEBSOCKET_EVENT_CHANNEL_BOOKMARK_UPDATED, "", bookmark.ChannelId, "", nil)
+	message.Add("bookmark", bookmark.ToJson())
+	a.Publish(message)
+
+	return bookmark, nil
+}
+
+// UpdateChannelBookmarkSortOrder moves the bookmark to newIndex among the
+// channel's bookmarks and notifies the channel of the new order.
+func (a *App) UpdateChannelBookmarkSortOrder(bookmarkId, channelId string, newIndex int64) ([]*model.ChannelBookmark, *model.AppError) {
+	bookmarks, err := a.Srv().Store.ChannelBookmark().UpdateSortOrder(bookmarkId, channelId, newIndex)
+	if err != nil {
+		var invErr *store.ErrInvalidInput
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &invErr):
+			return nil, model.NewAppError("UpdateChannelBookmarkSortOrder", "app.channel_bookmark.update_sort_order.invalid_input.app_error", nil, invErr.Error(), http.StatusBadRequest)
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("UpdateChannelBookmarkSortOrder", "app.channel_bookmark.update_sort_order.app_error", nil, nfErr.Error(), http.StatusNotFound)
+		default:
+			return nil, model.NewAppError("UpdateChannelBookmarkSortOrder", "app.channel_bookmark.update_sort_order.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	message := model.NewWebSocketEvent(model.WEBSOCKET_EVENT_CHANNEL_BOOKMARK_SORTED, "", channelId, "", nil)
+	message.Add("bookmarks", model.ChannelBookmarksToJson(bookmarks))
+	a.Publish(message)
+
+	return bookmarks, nil
+}
+
+// DeleteChannelBookmark removes the bookmark and notifies the channel.
+func (a *App) DeleteChannelBookmark(bookmark *model.ChannelBookmark) *model.AppError {
+	if err := a.Srv().Store.ChannelBookmark().Delete(bookmark.Id); err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return model.NewAppError("DeleteChannelBookmark", "app.channel_bookmark.delete.app_error", nil, nfErr.Error(), http.StatusNotFound)
+		default:
+			return model.NewAppError("DeleteChannelBookmark", "app.channel_bookmark.delete.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	message := model.NewWebSocketEvent(model.WEBSOCKET_EVENT_CHANNEL_BOOKMARK_DELETED, "", bookmark.ChannelId, "", nil)
+	message.Add("bookmark_id", bookmark.Id)
+	a.Publish(message)
+
+	return nil
+}
+
+// checkChannelBookmark verifies that the bookmark's channel can take bookmarks
+// and that a bookmarked file is visible to the members of that channel: it must
+// either belong to a post in the channel or not be attached to any post yet and
+// have been uploaded by the bookmark's owner.
+func (a *App) checkChannelBookmark(bookmark *model.ChannelBookmark) *model.AppError {
+	channel, err := a.GetChannel(bookmark.ChannelId)
+	if err != nil {
+		return err
+	}
+
+	if channel.DeleteAt != 0 {
+		return model.NewAppError("checkChannelBookmark", "app.channel_bookmark.archived_channel.app_error", nil, "channel_id="+channel.Id, http.StatusBadRequest)
+	}
+
+	if bookmark.Type != model.CHANNEL_BOOKMARK_FILE {
+		return nil
+	}
+
+	info, err := a.GetFileInfo(bookmark.FileId)
+	if err != nil {
+		return err
+	}
+
+	if info.DeleteAt != 0 {
+		return model.NewAppError("checkChannelBookmark", "app.channel_bookmark.file.app_error", nil, "file_id="+info.Id, http.StatusBadRequest)
+	}
+
+	if info.PostId == "" {
+		if info.CreatorId != bookmark.OwnerId {
+			return model.NewAppError("checkChannelBookmark", "app.channel_bookmark.file.app_error", nil, "file_id="+info.Id, http.StatusBadRequest)
+		}
+		return nil
+	}
+
+	post, err := a.GetSinglePost(info.PostId)
+	if err != nil {
+		return err
+	}
+
+	if post.ChannelId != bookmark.ChannelId {
+		return model.NewAppError("checkChannelBookmark", "app.channel_bookmark.file.app_error", nil, "file_id="+info.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
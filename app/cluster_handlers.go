@@ -27,6 +27,7 @@ func (a *App) registerAllClusterMessageHandlers() {
 	a.Cluster().RegisterClusterMessageHandler(model.CLUSTER_EVENT_INSTALL_PLUGIN, a.clusterInstallPluginHandler)
 	a.Cluster().RegisterClusterMessageHandler(model.CLUSTER_EVENT_REMOVE_PLUGIN, a.clusterRemovePluginHandler)
 	a.Cluster().RegisterClusterMessageHandler(model.CLUSTER_EVENT_BUSY_STATE_CHANGED, a.clusterBusyStateChgHandler)
+	a.Cluster().RegisterClusterMessageHandler(model.CLUSTER_EVENT_FILE_STORE_MIGRATION, a.clusterFileStoreMigrationHandler)
 }
 
 func (a *App) clusterPublishHandler(msg *model.ClusterMessage) {
@@ -22,6 +22,7 @@ import (
 	"github.com/zacmm/zacmm-server/config"
 	"github.com/zacmm/zacmm-server/mlog"
 	"github.com/zacmm/zacmm-server/model"
+	"github.com/zacmm/zacmm-server/services/webpush"
 	"github.com/zacmm/zacmm-server/utils"
 )
 
@@ -312,6 +313,10 @@ func (s *Server) regenerateClientConfig() {
 		der, _ := x509.MarshalPKIXPublicKey(&key.PublicKey)
 		clientConfig["AsymmetricSigningPublicKey"] = base64.StdEncoding.EncodeToString(der)
 		limitedClientConfig["AsymmetricSigningPublicKey"] = base64.StdEncoding.EncodeToString(der)
+
+		if *s.Config().EmailSettings.EnableWebPushNotifications {
+			clientConfig["WebPushVAPIDPublicKey"] = webpush.PublicKey(key)
+		}
 	}
 
 	clientConfigJSON, _ := json.Marshal(clientConfig)
@@ -0,0 +1,135 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/zacmm/zacmm-server/mlog"
+	"github.com/zacmm/zacmm-server/model"
+	"github.com/zacmm/zacmm-server/store"
+)
+
+const customStatusExpiryBatchSize = 100
+
+func (a *App) GetCustomStatus(userId string) (*model.CustomStatus, *model.AppError) {
+	customStatus, err := a.Srv().Store.CustomStatus().Get(userId)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("GetCustomStatus", "app.custom_status.get.app_error", nil, nfErr.Error(), http.StatusNotFound)
+		default:
+			return nil, model.NewAppError("GetCustomStatus", "app.custom_status.get.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	if customStatus.IsExpired() {
+		return nil, model.NewAppError("GetCustomStatus", "app.custom_status.get.app_error", nil, "user_id="+userId, http.StatusNotFound)
+	}
+
+	return customStatus, nil
+}
+
+// GetCustomStatusesByUserIds returns the custom statuses of the given users
+// keyed by user id. Users without a custom status, or whose custom status has
+// expired, are left out.
+func (a *App) GetCustomStatusesByUserIds(userIds []string) (map[string]*model.CustomStatus, *model.AppError) {
+	customStatuses, err := a.Srv().Store.CustomStatus().GetByUserIds(userIds)
+	if err != nil {
+		return nil, model.NewAppError("GetCustomStatusesByUserIds", "app.custom_status.get_by_user_ids.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	customStatusMap := make(map[string]*model.CustomStatus, len(customStatuses))
+	for _, customStatus := range customStatuses {
+		if customStatus.IsExpired() {
+			continue
+		}
+		customStatusMap[customStatus.UserId] = customStatus
+	}
+
+	return customStatusMap, nil
+}
+
+// SetCustomStatus replaces the user's custom status and broadcasts it along
+// with their current status.
+func (a *App) SetCustomStatus(userId string, customStatus *model.CustomStatus) (*model.CustomStatus, *model.AppError) {
+	customStatus.UserId = userId
+	if customStatus.IsExpired() {
+		return nil, model.NewAppError("SetCustomStatus", "app.custom_status.set.expired.app_error", nil, "user_id="+userId, http.StatusBadRequest)
+	}
+
+	savedCustomStatus, err := a.Srv().Store.CustomStatus().Save(customStatus)
+	if err != nil {
+		var appErr *model.AppError
+		switch {
+		case errors.As(err, &appErr):
+			return nil, appErr
+		default:
+			return nil, model.NewAppError("SetCustomStatus", "app.custom_status.set.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	a.broadcastCustomStatus(savedCustomStatus)
+
+	return savedCustomStatus, nil
+}
+
+// RemoveCustomStatus clears the user's custom status and broadcasts an empty
+// one in its place.
+func (a *App) RemoveCustomStatus(userId string) *model.AppError {
+	if err := a.Srv().Store.CustomStatus().Delete(userId); err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return model.NewAppError("RemoveCustomStatus", "app.custom_status.remove.app_error", nil, nfErr.Error(), http.StatusNotFound)
+		default:
+			return model.NewAppError("RemoveCustomStatus", "app.custom_status.remove.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	a.broadcastCustomStatus(&model.CustomStatus{UserId: userId})
+
+	return nil
+}
+
+// RemoveExpiredCustomStatuses clears every custom status whose expiry has
+// passed, broadcasting each change.
+func (a *App) RemoveExpiredCustomStatuses() *model.AppError {
+	for {
+		customStatuses, err := a.Srv().Store.CustomStatus().GetExpired(model.GetMillis(), customStatusExpiryBatchSize)
+		if err != nil {
+			return model.NewAppError("RemoveExpiredCustomStatuses", "app.custom_status.get_expired.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+
+		for _, customStatus := range customStatuses {
+			if appErr := a.RemoveCustomStatus(customStatus.UserId); appErr != nil && appErr.StatusCode != http.StatusNotFound {
+				return appErr
+			}
+		}
+
+		if len(customStatuses) < customStatusExpiryBatchSize {
+			return nil
+		}
+	}
+}
+
+// broadcastCustomStatus sends the user's current status together with the
+// custom status over the status change event. Unlike BroadcastStatus this is
+// not skipped when the server is busy, since it follows a change the user made
+// rather than their activity.
+func (a *App) broadcastCustomStatus(customStatus *model.CustomStatus) {
+	status, appErr := a.GetStatus(customStatus.UserId)
+	if appErr != nil || status.UserId == "" {
+		if appErr != nil && appErr.StatusCode != http.StatusNotFound {
+			mlog.Warn("Failed to get status for custom status broadcast", mlog.String("user_id", customStatus.UserId), mlog.Err(appErr))
+		}
+		status = &model.Status{UserId: customStatus.UserId, Status: model.STATUS_OFFLINE}
+	}
+
+	event := newStatusChangeEvent(status)
+	event.Add("custom_status", customStatus.ToJson())
+	a.Publish(event)
+}
@@ -0,0 +1,191 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/zacmm/zacmm-server/mlog"
+	"github.com/zacmm/zacmm-server/model"
+	"github.com/zacmm/zacmm-server/store"
+)
+
+const (
+	dndExpiryBatchSize   = 100
+	dndScheduleBatchSize = 100
+)
+
+func (a *App) GetDndSchedule(userId string) (*model.DndSchedule, *model.AppError) {
+	schedule, err := a.Srv().Store.DndSchedule().Get(userId)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("GetDndSchedule", "app.dnd_schedule.get.app_error", nil, nfErr.Error(), http.StatusNotFound)
+		default:
+			return nil, model.NewAppError("GetDndSchedule", "app.dnd_schedule.get.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return schedule, nil
+}
+
+// SetDndSchedule replaces the user's Do Not Disturb schedule. If a quiet
+// period of the new schedule is already running, Do Not Disturb starts right
+// away instead of on the next run of the job.
+func (a *App) SetDndSchedule(userId string, schedule *model.DndSchedule) (*model.DndSchedule, *model.AppError) {
+	schedule.UserId = userId
+	schedule.ActiveUntil = 0
+	if existing, err := a.Srv().Store.DndSchedule().Get(userId); err == nil {
+		schedule.ActiveUntil = existing.ActiveUntil
+	}
+
+	savedSchedule, err := a.Srv().Store.DndSchedule().Save(schedule)
+	if err != nil {
+		var appErr *model.AppError
+		switch {
+		case errors.As(err, &appErr):
+			return nil, appErr
+		default:
+			return nil, model.NewAppError("SetDndSchedule", "app.dnd_schedule.save.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	if appErr := a.applyDndSchedule(savedSchedule, time.Now()); appErr != nil {
+		mlog.Warn("Failed to apply Do Not Disturb schedule", mlog.String("user_id", userId), mlog.Err(appErr))
+	}
+
+	return savedSchedule, nil
+}
+
+// DeleteDndSchedule removes the user's Do Not Disturb schedule, ending the
+// quiet period that it started if that is still running.
+func (a *App) DeleteDndSchedule(userId string) *model.AppError {
+	schedule, appErr := a.GetDndSchedule(userId)
+	if appErr != nil {
+		return appErr
+	}
+
+	if err := a.Srv().Store.DndSchedule().Delete(userId); err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return model.NewAppError("DeleteDndSchedule", "app.dnd_schedule.delete.app_error", nil, nfErr.Error(), http.StatusNotFound)
+		default:
+			return model.NewAppError("DeleteDndSchedule", "app.dnd_schedule.delete.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	if schedule.ActiveUntil > model.GetMillis() {
+		status, appErr := a.GetStatus(userId)
+		if appErr == nil && status.Status == model.STATUS_DND && status.DNDEndTime == schedule.ActiveUntil {
+			return a.endDoNotDisturb(status)
+		}
+	}
+
+	return nil
+}
+
+// UpdateScheduledDndStatuses ends every timed Do Not Disturb that has expired
+// and starts it for the users whose scheduled quiet period has begun.
+func (a *App) UpdateScheduledDndStatuses() *model.AppError {
+	if !*a.Config().ServiceSettings.EnableUserStatuses {
+		return nil
+	}
+
+	if appErr := a.RemoveExpiredDNDStatuses(); appErr != nil {
+		return appErr
+	}
+
+	now := time.Now()
+	afterUserId := ""
+	for {
+		schedules, err := a.Srv().Store.DndSchedule().GetAll(afterUserId, dndScheduleBatchSize)
+		if err != nil {
+			return model.NewAppError("UpdateScheduledDndStatuses", "app.dnd_schedule.get_all.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+
+		for _, schedule := range schedules {
+			if appErr := a.applyDndSchedule(schedule, now); appErr != nil {
+				mlog.Warn("Failed to apply Do Not Disturb schedule", mlog.String("user_id", schedule.UserId), mlog.Err(appErr))
+			}
+		}
+
+		if len(schedules) < dndScheduleBatchSize {
+			return nil
+		}
+		afterUserId = schedules[len(schedules)-1].UserId
+	}
+}
+
+// applyDndSchedule sets the user to Do Not Disturb until the end of the quiet
+// period that includes now, unless the schedule already did so for that
+// period. Users who are out of office or who turned Do Not Disturb on until
+// further notice are left alone.
+func (a *App) applyDndSchedule(schedule *model.DndSchedule, now time.Time) *model.AppError {
+	user, appErr := a.GetUser(schedule.UserId)
+	if appErr != nil {
+		return appErr
+	}
+
+	if user.DeleteAt != 0 {
+		return nil
+	}
+
+	location, err := time.LoadLocation(user.GetPreferredTimezone())
+	if err != nil {
+		location = time.UTC
+	}
+
+	_, end, ok := schedule.CurrentPeriod(now, location)
+	if !ok {
+		return nil
+	}
+
+	endTime := model.GetMillisForTime(end)
+	if schedule.ActiveUntil >= endTime {
+		return nil
+	}
+
+	startDnd := true
+	if status, appErr := a.GetStatus(user.Id); appErr == nil {
+		switch status.Status {
+		case model.STATUS_OUT_OF_OFFICE:
+			startDnd = false
+		case model.STATUS_DND:
+			// Only extend a timed Do Not Disturb that ends before the quiet period.
+			startDnd = status.DNDEndTime != 0 && status.DNDEndTime < endTime
+		}
+	}
+
+	if startDnd {
+		if appErr = a.SetStatusDoNotDisturbTimed(user.Id, endTime); appErr != nil {
+			return appErr
+		}
+	}
+
+	schedule.ActiveUntil = endTime
+	if _, err := a.Srv().Store.DndSchedule().Save(schedule); err != nil {
+		return model.NewAppError("applyDndSchedule", "app.dnd_schedule.save.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// isPostExemptFromDnd reports whether the post should still notify a user in
+// Do Not Disturb because their schedule exempts its channel or its priority.
+func (a *App) isPostExemptFromDnd(status *model.Status, post *model.Post) bool {
+	if status.Status != model.STATUS_DND {
+		return false
+	}
+
+	schedule, err := a.Srv().Store.DndSchedule().Get(status.UserId)
+	if err != nil {
+		return false
+	}
+
+	return schedule.AllowsPost(post)
+}
@@ -0,0 +1,186 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zacmm/zacmm-server/model"
+)
+
+func TestSetStatusDoNotDisturbTimed(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	t.Run("should reject an end time in the past", func(t *testing.T) {
+		appErr := th.App.SetStatusDoNotDisturbTimed(th.BasicUser.Id, model.GetMillis()-1000)
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+	})
+
+	t.Run("should go back to the previous status once expired", func(t *testing.T) {
+		th.App.SetStatusAwayIfNeeded(th.BasicUser.Id, true)
+
+		require.Nil(t, th.App.SetStatusDoNotDisturbTimed(th.BasicUser.Id, model.GetMillis()+60000))
+
+		status, appErr := th.App.GetStatus(th.BasicUser.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, model.STATUS_DND, status.Status)
+		assert.Equal(t, model.STATUS_AWAY, status.PrevStatus)
+
+		require.Nil(t, th.App.RemoveExpiredDNDStatuses())
+		status, appErr = th.App.GetStatus(th.BasicUser.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, model.STATUS_DND, status.Status, "should stay in DND until the end time")
+
+		status.DNDEndTime = model.GetMillis() - 1000
+		th.App.SaveAndBroadcastStatus(status)
+
+		require.Nil(t, th.App.RemoveExpiredDNDStatuses())
+		status, appErr = th.App.GetStatus(th.BasicUser.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, model.STATUS_AWAY, status.Status)
+		assert.Zero(t, status.DNDEndTime)
+	})
+
+	t.Run("should not expire after the user changed their status", func(t *testing.T) {
+		require.Nil(t, th.App.SetStatusDoNotDisturbTimed(th.BasicUser2.Id, model.GetMillis()+60000))
+		th.App.SetStatusDoNotDisturb(th.BasicUser2.Id)
+
+		status, appErr := th.App.GetStatus(th.BasicUser2.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, model.STATUS_DND, status.Status)
+		assert.Zero(t, status.DNDEndTime, "DND until further notice should not expire")
+	})
+}
+
+func TestApplyDndSchedule(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	// Monday evening in UTC, which is the timezone of users who have not set one.
+	monday := time.Date(2021, time.January, 4, 20, 0, 0, 0, time.UTC)
+	periodEnd := model.GetMillisForTime(time.Date(2021, time.January, 5, 8, 0, 0, 0, time.UTC))
+
+	newSchedule := func(userId string) *model.DndSchedule {
+		schedule, err := th.App.Srv().Store.DndSchedule().Save(&model.DndSchedule{
+			UserId:    userId,
+			Days:      model.StringArray{"monday", "tuesday", "wednesday", "thursday", "friday"},
+			StartTime: "19:00",
+			EndTime:   "08:00",
+		})
+		require.NoError(t, err)
+		return schedule
+	}
+
+	t.Run("should start Do Not Disturb until the end of the period", func(t *testing.T) {
+		user := th.CreateUser()
+		th.App.SetStatusOnline(user.Id, true)
+		schedule := newSchedule(user.Id)
+
+		require.Nil(t, th.App.applyDndSchedule(schedule, monday))
+
+		status, appErr := th.App.GetStatus(user.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, model.STATUS_DND, status.Status)
+		assert.Equal(t, periodEnd, status.DNDEndTime)
+		assert.Equal(t, model.STATUS_ONLINE, status.PrevStatus)
+
+		saved, err := th.App.Srv().Store.DndSchedule().Get(user.Id)
+		require.NoError(t, err)
+		assert.Equal(t, periodEnd, saved.ActiveUntil)
+	})
+
+	t.Run("should not start Do Not Disturb again during the same period", func(t *testing.T) {
+		user := th.CreateUser()
+		schedule := newSchedule(user.Id)
+
+		require.Nil(t, th.App.applyDndSchedule(schedule, monday))
+		th.App.SetStatusOnline(user.Id, true)
+		require.Nil(t, th.App.applyDndSchedule(schedule, monday.Add(time.Hour)))
+
+		status, appErr := th.App.GetStatus(user.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, model.STATUS_ONLINE, status.Status)
+	})
+
+	t.Run("should do nothing outside of a period", func(t *testing.T) {
+		user := th.CreateUser()
+		th.App.SetStatusOnline(user.Id, true)
+		schedule := newSchedule(user.Id)
+
+		require.Nil(t, th.App.applyDndSchedule(schedule, monday.Add(-2*time.Hour)))
+
+		status, appErr := th.App.GetStatus(user.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, model.STATUS_ONLINE, status.Status)
+	})
+
+	t.Run("should leave users who are out of office alone", func(t *testing.T) {
+		user := th.CreateUser()
+		th.App.SetStatusOutOfOffice(user.Id)
+		schedule := newSchedule(user.Id)
+
+		require.Nil(t, th.App.applyDndSchedule(schedule, monday))
+
+		status, appErr := th.App.GetStatus(user.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, model.STATUS_OUT_OF_OFFICE, status.Status)
+	})
+
+	t.Run("should end the period it started when the schedule is removed", func(t *testing.T) {
+		user := th.CreateUser()
+		th.App.SetStatusOnline(user.Id, true)
+		schedule := newSchedule(user.Id)
+
+		schedule.ActiveUntil = model.GetMillis() + 60000
+		_, err := th.App.Srv().Store.DndSchedule().Save(schedule)
+		require.NoError(t, err)
+		require.Nil(t, th.App.SetStatusDoNotDisturbTimed(user.Id, schedule.ActiveUntil))
+
+		require.Nil(t, th.App.DeleteDndSchedule(user.Id))
+
+		status, appErr := th.App.GetStatus(user.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, model.STATUS_ONLINE, status.Status)
+	})
+}
+
+func TestDndScheduleExemptsPost(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	channelMemberNotificationProps := model.StringMap{
+		model.EMAIL_NOTIFY_PROP:       model.CHANNEL_NOTIFY_DEFAULT,
+		model.MARK_UNREAD_NOTIFY_PROP: model.CHANNEL_MARK_UNREAD_ALL,
+	}
+
+	user := th.CreateUser()
+	th.App.SetStatusDoNotDisturb(user.Id)
+
+	onCallChannelId := model.NewId()
+	_, appErr := th.App.SetDndSchedule(user.Id, &model.DndSchedule{
+		Days:             model.StringArray{"monday"},
+		StartTime:        "19:00",
+		EndTime:          "08:00",
+		ExemptChannelIds: model.StringArray{onCallChannelId},
+		AllowUrgent:      true,
+	})
+	require.Nil(t, appErr)
+
+	assert.False(t, th.App.userAllowsEmail(user, channelMemberNotificationProps, &model.Post{ChannelId: model.NewId()}))
+	assert.True(t, th.App.userAllowsEmail(user, channelMemberNotificationProps, &model.Post{ChannelId: onCallChannelId}))
+
+	urgent := &model.Post{ChannelId: model.NewId()}
+	urgent.AddProp(model.POST_PROPS_PRIORITY, model.POST_PRIORITY_URGENT)
+	assert.True(t, th.App.userAllowsEmail(user, channelMemberNotificationProps, urgent))
+
+	th.App.SetStatusOnline(user.Id, true)
+	assert.False(t, th.App.userAllowsEmail(user, channelMemberNotificationProps, &model.Post{ChannelId: onCallChannelId}), "exemptions only apply in Do Not Disturb")
+}
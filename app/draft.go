@@ -0,0 +1,108 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/zacmm/zacmm-server/mlog"
+	"github.com/zacmm/zacmm-server/model"
+	"github.com/zacmm/zacmm-server/store"
+)
+
+// UpsertDraft saves the user's draft for a channel or thread, replacing any
+// existing one, and notifies the user's other sessions.
+func (a *App) UpsertDraft(draft *model.Draft) (*model.Draft, *model.AppError) {
+	channel, err := a.GetChannel(draft.ChannelId)
+	if err != nil {
+		return nil, err
+	}
+
+	if channel.DeleteAt != 0 {
+		return nil, model.NewAppError("UpsertDraft", "app.draft.save.archived_channel.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if draft.RootId != "" {
+		rootPost, err := a.GetSinglePost(draft.RootId)
+		if err != nil {
+			return nil, err
+		}
+
+		if rootPost.ChannelId != draft.ChannelId {
+			return nil, model.NewAppError("UpsertDraft", "app.draft.save.root_id.app_error", nil, "root_id="+draft.RootId, http.StatusBadRequest)
+		}
+	}
+
+	draft.CreateAt = 0
+	draft.PreSave()
+	if err := draft.IsValid(a.MaxPostSize()); err != nil {
+		return nil, err
+	}
+
+	savedDraft, nErr := a.Srv().Store.Draft().Save(draft)
+	if nErr != nil {
+		var appErr *model.AppError
+		switch {
+		case errors.As(nErr, &appErr):
+			return nil, appErr
+		default:
+			return nil, model.NewAppError("UpsertDraft", "app.draft.save.app_error", nil, nErr.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	message := model.NewWebSocketEvent(model.WEBSOCKET_EVENT_DRAFT_CREATED, "", "", savedDraft.UserId, nil)
+	message.Add("draft", savedDraft.ToJson())
+	a.Publish(message)
+
+	return savedDraft, nil
+}
+
+// GetDraftsForUser returns the user's drafts in the given team, including
+// those in direct and group message channels.
+func (a *App) GetDraftsForUser(userId, teamId string) ([]*model.Draft, *model.AppError) {
+	drafts, err := a.Srv().Store.Draft().GetDraftsForUser(userId, teamId)
+	if err != nil {
+		return nil, model.NewAppError("GetDraftsForUser", "app.draft.get_for_user.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return drafts, nil
+}
+
+// DeleteDraft removes the user's draft for a channel or thread and notifies
+// the user's other sessions.
+func (a *App) DeleteDraft(userId, channelId, rootId string) *model.AppError {
+	if err := a.Srv().Store.Draft().Delete(userId, channelId, rootId); err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return model.NewAppError("DeleteDraft", "app.draft.delete.app_error", nil, nfErr.Error(), http.StatusNotFound)
+		default:
+			return model.NewAppError("DeleteDraft", "app.draft.delete.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	message := model.NewWebSocketEvent(model.WEBSOCKET_EVENT_DRAFT_DELETED, "", "", userId, nil)
+	message.Add("channel_id", channelId)
+	message.Add("root_id", rootId)
+	a.Publish(message)
+
+	return nil
+}
+
+// deleteDraftForPost removes the author's draft for the channel or thread the
+// post was made in, since its content has now been sent. Posts made through
+// webhooks and system messages never have a draft. The delete runs in the
+// background so that it does not hold up creating the post.
+func (a *App) deleteDraftForPost(post *model.Post) {
+	if post.IsSystemMessage() || post.GetProp("from_webhook") == "true" {
+		return
+	}
+
+	a.Srv().Go(func() {
+		if err := a.DeleteDraft(post.UserId, post.ChannelId, post.RootId); err != nil && err.StatusCode != http.StatusNotFound {
+			mlog.Warn("Failed to delete draft for post", mlog.String("post_id", post.Id), mlog.Err(err))
+		}
+	})
+}
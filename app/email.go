@@ -44,7 +44,6 @@ func condenseSiteURL(siteURL string) string {
 type EmailService struct {
 	srv              *Server
 	EmailRateLimiter *throttled.GCRARateLimiter
-	EmailBatching    *EmailBatchingJob
 }
 
 func NewEmailService(srv *Server) (*EmailService, error) {
@@ -52,7 +51,6 @@ func NewEmailService(srv *Server) (*EmailService, error) {
 	if err := service.setupInviteEmailRateLimiting(); err != nil {
 		return nil, err
 	}
-	service.InitEmailBatching()
 	return service, nil
 }
 
@@ -550,8 +548,31 @@ func (es *EmailService) sendMail(to, subject, htmlBody string) *model.AppError {
 	return es.sendMailWithCC(to, subject, htmlBody, "")
 }
 
+// sendNotificationMailWithReplyTo sends a notification email whose replies go
+// to the given address instead of the configured reply-to address.
+func (es *EmailService) sendNotificationMailWithReplyTo(to, subject, htmlBody, replyTo string) *model.AppError {
+	if !*es.srv.Config().EmailSettings.SendEmailNotifications {
+		return nil
+	}
+	return es.sendMailAdvanced(to, subject, htmlBody, "", replyTo)
+}
+
 func (es *EmailService) sendMailWithCC(to, subject, htmlBody string, ccMail string) *model.AppError {
+	return es.sendMailAdvanced(to, subject, htmlBody, ccMail, "")
+}
+
+func (es *EmailService) sendMailAdvanced(to, subject, htmlBody, ccMail, replyTo string) *model.AppError {
+	if *es.srv.Config().EmailSettings.EnableEmailQueue {
+		return es.queueMail(to, subject, htmlBody, ccMail, replyTo)
+	}
+	return es.deliverMail(to, subject, htmlBody, ccMail, replyTo)
+}
+
+func (es *EmailService) deliverMail(to, subject, htmlBody, ccMail, replyTo string) *model.AppError {
 	license := es.srv.License()
+	if replyTo != "" {
+		return mailservice.SendMailWithReplyToUsingConfig(to, subject, htmlBody, replyTo, es.srv.Config(), license != nil && *license.Features.Compliance, ccMail)
+	}
 	return mailservice.SendMailUsingConfig(to, subject, htmlBody, es.srv.Config(), license != nil && *license.Features.Compliance, ccMail)
 }
 
@@ -4,231 +4,358 @@
 package app
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"html/template"
+	"math"
+	"net/http"
 	"strconv"
-	"sync"
+	"strings"
 	"time"
 
+	"github.com/mattermost/go-i18n/i18n"
+
 	"github.com/zacmm/zacmm-server/mlog"
 	"github.com/zacmm/zacmm-server/model"
+	"github.com/zacmm/zacmm-server/store"
 	"github.com/zacmm/zacmm-server/utils"
-
-	"net/http"
-
-	"github.com/mattermost/go-i18n/i18n"
 )
 
 const (
-	EMAIL_BATCHING_TASK_NAME = "Email Batching"
+	emailDigestUserBatchSize = 100
 )
 
-func (es *EmailService) InitEmailBatching() {
-	if *es.srv.Config().EmailSettings.EnableEmailBatching {
-		if es.EmailBatching == nil {
-			es.EmailBatching = NewEmailBatchingJob(es, *es.srv.Config().EmailSettings.EmailBatchingBufferSize)
-		}
-
-		// note that we don't support changing EmailBatchingBufferSize without restarting the server
-
-		es.EmailBatching.Start()
-	}
-}
-
 func (es *EmailService) AddNotificationEmailToBatch(user *model.User, post *model.Post, team *model.Team) *model.AppError {
 	if !*es.srv.Config().EmailSettings.EnableEmailBatching {
 		return model.NewAppError("AddNotificationEmailToBatch", "api.email_batching.add_notification_email_to_batch.disabled.app_error", nil, "", http.StatusNotImplemented)
 	}
 
-	if !es.EmailBatching.Add(user, post, team) {
-		mlog.Error("Email batching job's receiving channel was full. Please increase the EmailBatchingBufferSize.")
-		return model.NewAppError("AddNotificationEmailToBatch", "api.email_batching.add_notification_email_to_batch.channel_full.app_error", nil, "", http.StatusInternalServerError)
+	if schedule := es.getEmailDigestSchedule(user.Id); schedule != nil && schedule.Frequency == model.EMAIL_DIGEST_FREQUENCY_NEVER {
+		// the user unsubscribed from their email digest
+		return nil
+	}
+
+	notification := &model.PendingEmailNotification{
+		UserId:    user.Id,
+		PostId:    post.Id,
+		ChannelId: post.ChannelId,
+		TeamId:    team.Id,
+	}
+
+	if _, err := es.srv.Store.EmailDigest().Save(notification); err != nil {
+		var appErr *model.AppError
+		switch {
+		case errors.As(err, &appErr):
+			return appErr
+		default:
+			return model.NewAppError("AddNotificationEmailToBatch", "app.email_digest.save.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
 	}
 
 	return nil
 }
 
 type batchedNotification struct {
+	id       string
 	userId   string
 	post     *model.Post
 	teamName string
 }
 
-type EmailBatchingJob struct {
-	server               *Server
-	newNotifications     chan *batchedNotification
-	pendingNotifications map[string][]*batchedNotification
-	task                 *model.ScheduledTask
-	taskMutex            sync.Mutex
-}
+// SendPendingEmailDigests sends an email digest to the users whose digest is
+// due and drops the notifications that they have read in the meantime.
+func (a *App) SendPendingEmailDigests() *model.AppError {
+	es := a.Srv().EmailService
 
-func NewEmailBatchingJob(es *EmailService, bufferSize int) *EmailBatchingJob {
-	return &EmailBatchingJob{
-		server:               es.srv,
-		newNotifications:     make(chan *batchedNotification, bufferSize),
-		pendingNotifications: make(map[string][]*batchedNotification),
-	}
+	// it's a bit weird to pass the send email function through here, but it makes it so that we can test
+	// without actually sending emails
+	return es.checkPendingEmailDigests(time.Now(), es.sendBatchedEmailNotification)
 }
 
-func (job *EmailBatchingJob) Start() {
-	mlog.Debug("Email batching job starting. Checking for pending emails periodically.", mlog.Int("interval_in_seconds", *job.server.Config().EmailSettings.EmailBatchingInterval))
-	newTask := model.CreateRecurringTask(EMAIL_BATCHING_TASK_NAME, job.CheckPendingEmails, time.Duration(*job.server.Config().EmailSettings.EmailBatchingInterval)*time.Second)
+func (es *EmailService) checkPendingEmailDigests(now time.Time, handler func(*model.User, []*batchedNotification) *model.AppError) *model.AppError {
+	afterUserId := ""
+	for {
+		userIds, err := es.srv.Store.EmailDigest().GetUserIdsWithPending(afterUserId, emailDigestUserBatchSize)
+		if err != nil {
+			return model.NewAppError("checkPendingEmailDigests", "app.email_digest.get_users.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
 
-	job.taskMutex.Lock()
-	oldTask := job.task
-	job.task = newTask
-	job.taskMutex.Unlock()
+		for _, userId := range userIds {
+			if err := es.checkPendingEmailDigest(userId, now, handler); err != nil {
+				mlog.Warn("Unable to send email digest", mlog.String("user_id", userId), mlog.Err(err))
+			}
+		}
 
-	if oldTask != nil {
-		oldTask.Cancel()
+		if len(userIds) < emailDigestUserBatchSize {
+			return nil
+		}
+		afterUserId = userIds[len(userIds)-1]
 	}
 }
 
-func (job *EmailBatchingJob) Add(user *model.User, post *model.Post, team *model.Team) bool {
-	notification := &batchedNotification{
-		userId:   user.Id,
-		post:     post,
-		teamName: team.Name,
+func (es *EmailService) checkPendingEmailDigest(userId string, now time.Time, handler func(*model.User, []*batchedNotification) *model.AppError) error {
+	pending, err := es.srv.Store.EmailDigest().GetForUser(userId)
+	if err != nil {
+		return err
 	}
 
-	select {
-	case job.newNotifications <- notification:
-		return true
-	default:
-		// return false if we couldn't queue the email notification so that we can send an immediate email
-		return false
+	user, err := es.srv.Store.User().Get(userId)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		if errors.As(err, &nfErr) {
+			return es.srv.Store.EmailDigest().DeleteForUser(userId)
+		}
+		return err
 	}
-}
 
-func (job *EmailBatchingJob) CheckPendingEmails() {
-	job.handleNewNotifications()
+	// drop the notifications for channels that the user has viewed since they were queued
+	var unread []*model.PendingEmailNotification
+	var read []string
+	lastViewedAt := make(map[string]int64)
+	for _, notification := range pending {
+		if _, ok := lastViewedAt[notification.ChannelId]; !ok {
+			member, err := es.srv.Store.Channel().GetMember(notification.ChannelId, userId)
+			if err != nil {
+				// the user left the channel
+				lastViewedAt[notification.ChannelId] = math.MaxInt64
+			} else {
+				lastViewedAt[notification.ChannelId] = member.LastViewedAt
+			}
+		}
+
+		if lastViewedAt[notification.ChannelId] >= notification.CreateAt {
+			read = append(read, notification.Id)
+		} else {
+			unread = append(unread, notification)
+		}
+	}
 
-	// it's a bit weird to pass the send email function through here, but it makes it so that we can test
-	// without actually sending emails
-	job.checkPendingNotifications(time.Now(), job.server.EmailService.sendBatchedEmailNotification)
+	if len(read) > 0 {
+		mlog.Debug("Deleted notifications for user", mlog.String("user_id", userId), mlog.Int("count", len(read)))
+		if err := es.srv.Store.EmailDigest().Delete(read); err != nil {
+			return err
+		}
+	}
 
-	mlog.Debug("Email batching job ran. Some users still have notifications pending.", mlog.Int("number_of_users", len(job.pendingNotifications)))
-}
+	if len(unread) == 0 || now.Before(es.getEmailDigestSendTime(user, unread[0].CreateAt)) {
+		return nil
+	}
 
-func (job *EmailBatchingJob) handleNewNotifications() {
-	receiving := true
+	teamNames := make(map[string]string)
+	notifications := make([]*batchedNotification, 0, len(unread))
+	ids := make([]string, 0, len(unread))
+	for _, notification := range unread {
+		ids = append(ids, notification.Id)
 
-	// read in new notifications to send
-	for receiving {
-		select {
-		case notification := <-job.newNotifications:
-			userId := notification.userId
+		post, err := es.srv.Store.Post().GetSingle(notification.PostId)
+		if err != nil {
+			// the post was deleted
+			continue
+		}
 
-			if _, ok := job.pendingNotifications[userId]; !ok {
-				job.pendingNotifications[userId] = []*batchedNotification{notification}
-			} else {
-				job.pendingNotifications[userId] = append(job.pendingNotifications[userId], notification)
+		if _, ok := teamNames[notification.TeamId]; !ok {
+			// in case the user hasn't joined any teams we send them to the select_team page
+			teamNames[notification.TeamId] = "select_team"
+			if notification.TeamId != "" {
+				if team, err := es.srv.Store.Team().Get(notification.TeamId); err == nil {
+					teamNames[notification.TeamId] = team.Name
+				}
 			}
-		default:
-			receiving = false
 		}
+
+		notifications = append(notifications, &batchedNotification{
+			id:       notification.Id,
+			userId:   userId,
+			post:     post,
+			teamName: teamNames[notification.TeamId],
+		})
 	}
+
+	if len(notifications) > 0 {
+		if appErr := handler(user, notifications); appErr != nil {
+			// keep the notifications so that the digest is sent again on the next run
+			return appErr
+		}
+	}
+
+	return es.srv.Store.EmailDigest().Delete(ids)
 }
 
-func (job *EmailBatchingJob) checkPendingNotifications(now time.Time, handler func(string, []*batchedNotification)) {
-	for userId, notifications := range job.pendingNotifications {
-		batchStartTime := notifications[0].post.CreateAt
-		inspectedTeamNames := make(map[string]string)
-		for _, notification := range notifications {
-			// at most, we'll do one check for each team that notifications were sent for
-			if inspectedTeamNames[notification.teamName] != "" {
-				continue
-			}
+// getEmailDigestSchedule returns the email digest schedule chosen by the user,
+// or nil if they haven't chosen a valid one.
+func (es *EmailService) getEmailDigestSchedule(userId string) *model.EmailDigestSchedule {
+	preference, err := es.srv.Store.Preference().Get(userId, model.PREFERENCE_CATEGORY_NOTIFICATIONS, model.PREFERENCE_NAME_EMAIL_DIGEST)
+	if err != nil {
+		return nil
+	}
 
-			team, nErr := job.server.Store.Team().GetByName(notifications[0].teamName)
-			if nErr != nil {
-				mlog.Error("Unable to find Team id for notification", mlog.Err(nErr))
-				continue
-			}
+	schedule := model.EmailDigestScheduleFromJson(strings.NewReader(preference.Value))
+	if schedule == nil || schedule.IsValid() != nil {
+		return nil
+	}
 
-			if team != nil {
-				inspectedTeamNames[notification.teamName] = team.Id
-			}
+	return schedule
+}
 
-			// if the user has viewed any channels in this team since the notification was queued, delete
-			// all queued notifications
-			channelMembers, err := job.server.Store.Channel().GetMembersForUser(inspectedTeamNames[notification.teamName], userId)
-			if err != nil {
-				mlog.Error("Unable to find ChannelMembers for user", mlog.Err(err))
-				continue
-			}
+// getEmailDigestSendTime returns when the digest of a user whose oldest
+// pending notification was queued at the given time should be sent.
+func (es *EmailService) getEmailDigestSendTime(user *model.User, since int64) time.Time {
+	sinceTime := time.Unix(0, since*int64(time.Millisecond))
 
-			for _, channelMember := range *channelMembers {
-				if channelMember.LastViewedAt >= batchStartTime {
-					mlog.Debug("Deleted notifications for user", mlog.String("user_id", userId))
-					delete(job.pendingNotifications, userId)
-					break
-				}
-			}
+	if schedule := es.getEmailDigestSchedule(user.Id); schedule != nil && schedule.Frequency != model.EMAIL_DIGEST_FREQUENCY_NEVER {
+		location, err := time.LoadLocation(user.GetPreferredTimezone())
+		if err != nil {
+			location = time.UTC
 		}
+		return schedule.NextSendTime(sinceTime, location)
+	}
 
-		// get how long we need to wait to send notifications to the user
-		var interval int64
-		preference, err := job.server.Store.Preference().Get(userId, model.PREFERENCE_CATEGORY_NOTIFICATIONS, model.PREFERENCE_NAME_EMAIL_INTERVAL)
-		if err != nil {
-			// use the default batching interval if an error ocurrs while fetching user preferences
+	// get how long we need to wait to send notifications to the user
+	var interval int64
+	preference, err := es.srv.Store.Preference().Get(user.Id, model.PREFERENCE_CATEGORY_NOTIFICATIONS, model.PREFERENCE_NAME_EMAIL_INTERVAL)
+	if err != nil {
+		// use the default batching interval if an error ocurrs while fetching user preferences
+		interval, _ = strconv.ParseInt(model.PREFERENCE_EMAIL_INTERVAL_BATCHING_SECONDS, 10, 64)
+	} else {
+		if value, err := strconv.ParseInt(preference.Value, 10, 64); err != nil {
+			// // use the default batching interval if an error ocurrs while deserializing user preferences
 			interval, _ = strconv.ParseInt(model.PREFERENCE_EMAIL_INTERVAL_BATCHING_SECONDS, 10, 64)
 		} else {
-			if value, err := strconv.ParseInt(preference.Value, 10, 64); err != nil {
-				// // use the default batching interval if an error ocurrs while deserializing user preferences
-				interval, _ = strconv.ParseInt(model.PREFERENCE_EMAIL_INTERVAL_BATCHING_SECONDS, 10, 64)
-			} else {
-				interval = value
-			}
-		}
-
-		// send the email notification if there are notifications to send AND it's been long enough
-		if len(job.pendingNotifications[userId]) > 0 && now.Sub(time.Unix(batchStartTime/1000, 0)) > time.Duration(interval)*time.Second {
-			job.server.Go(func(userId string, notifications []*batchedNotification) func() {
-				return func() {
-					handler(userId, notifications)
-				}
-			}(userId, job.pendingNotifications[userId]))
-			delete(job.pendingNotifications, userId)
+			interval = value
 		}
 	}
+
+	return sinceTime.Add(time.Duration(interval) * time.Second)
 }
 
-func (es *EmailService) sendBatchedEmailNotification(userId string, notifications []*batchedNotification) {
-	user, err := es.srv.Store.User().Get(userId)
-	if err != nil {
-		mlog.Warn("Unable to find recipient for batched email notification")
-		return
+type emailDigestChannel struct {
+	channel       *model.Channel
+	notifications []*batchedNotification
+}
+
+type emailDigestTeam struct {
+	teamId   string
+	channels []*emailDigestChannel
+}
+
+// groupEmailDigest groups notifications by team, and then by channel, in the
+// order in which they were first notified. Direct and group messages are
+// grouped under an empty team id.
+func groupEmailDigest(notifications []*batchedNotification, channels map[string]*model.Channel) []*emailDigestTeam {
+	var teams []*emailDigestTeam
+	teamsById := make(map[string]*emailDigestTeam)
+	channelsById := make(map[string]*emailDigestChannel)
+
+	for _, notification := range notifications {
+		channel, ok := channels[notification.post.ChannelId]
+		if !ok {
+			continue
+		}
+
+		digestChannel, ok := channelsById[channel.Id]
+		if !ok {
+			digestTeam, ok := teamsById[channel.TeamId]
+			if !ok {
+				digestTeam = &emailDigestTeam{teamId: channel.TeamId}
+				teamsById[channel.TeamId] = digestTeam
+				teams = append(teams, digestTeam)
+			}
+
+			digestChannel = &emailDigestChannel{channel: channel}
+			channelsById[channel.Id] = digestChannel
+			digestTeam.channels = append(digestTeam.channels, digestChannel)
+		}
+
+		digestChannel.notifications = append(digestChannel.notifications, notification)
 	}
 
+	return teams
+}
+
+func (es *EmailService) sendBatchedEmailNotification(user *model.User, notifications []*batchedNotification) *model.AppError {
 	translateFunc := utils.GetUserTranslations(user.Locale)
 	displayNameFormat := *es.srv.Config().TeamSettings.TeammateNameDisplay
+	siteURL := *es.srv.Config().ServiceSettings.SiteURL
 
-	var contents string
+	emailNotificationContentsType := model.EMAIL_NOTIFICATION_CONTENTS_FULL
+	if license := es.srv.License(); license != nil && *license.Features.EmailNotificationContents {
+		emailNotificationContentsType = *es.srv.Config().EmailSettings.EmailNotificationContentsType
+	}
+
+	channels := make(map[string]*model.Channel)
+	senders := make(map[string]*model.User)
 	for _, notification := range notifications {
-		sender, err := es.srv.Store.User().Get(notification.post.UserId)
-		if err != nil {
-			mlog.Warn("Unable to find sender of post for batched email notification")
-			continue
+		if _, ok := channels[notification.post.ChannelId]; !ok {
+			channel, errCh := es.srv.Store.Channel().Get(notification.post.ChannelId, true)
+			if errCh != nil {
+				mlog.Warn("Unable to find channel of post for batched email notification")
+				continue
+			}
+			channels[channel.Id] = channel
+		}
+
+		if _, ok := senders[notification.post.UserId]; !ok {
+			sender, err := es.srv.Store.User().Get(notification.post.UserId)
+			if err != nil {
+				mlog.Warn("Unable to find sender of post for batched email notification")
+				continue
+			}
+			senders[sender.Id] = sender
 		}
+	}
+
+	var unreadThreads map[string]int64
+	if *es.srv.Config().ServiceSettings.CollapsedThreads != model.COLLAPSED_THREADS_DISABLED {
+		var err error
+		if unreadThreads, err = es.srv.Store.Thread().GetUnreadThreadCountsByTeam(user.Id); err != nil {
+			mlog.Warn("Unable to count unread threads for batched email notification", mlog.Err(err))
+		}
+	}
 
-		channel, errCh := es.srv.Store.Channel().Get(notification.post.ChannelId, true)
-		if errCh != nil {
-			mlog.Warn("Unable to find channel of post for batched email notification")
+	var contents string
+	count := 0
+	for _, digestTeam := range groupEmailDigest(notifications, channels) {
+		var posts string
+		for _, digestChannel := range digestTeam.channels {
+			for _, notification := range digestChannel.notifications {
+				sender, ok := senders[notification.post.UserId]
+				if !ok {
+					continue
+				}
+
+				posts += es.renderBatchedPost(notification, digestChannel.channel, sender, siteURL, displayNameFormat, translateFunc, user.Locale, emailNotificationContentsType)
+				count++
+			}
+		}
+
+		if posts == "" {
 			continue
 		}
 
-		emailNotificationContentsType := model.EMAIL_NOTIFICATION_CONTENTS_FULL
-		if license := es.srv.License(); license != nil && *license.Features.EmailNotificationContents {
-			emailNotificationContentsType = *es.srv.Config().EmailSettings.EmailNotificationContentsType
+		teamTemplate := es.newEmailTemplate("post_batched_team", user.Locale)
+		teamTemplate.Props["Posts"] = template.HTML(posts)
+		if digestTeam.teamId == "" {
+			teamTemplate.Props["TeamName"] = translateFunc("api.email_batching.send_batched_email_notification.direct_messages")
+		} else if team, err := es.srv.Store.Team().Get(digestTeam.teamId); err == nil {
+			teamTemplate.Props["TeamName"] = team.DisplayName
+		}
+		if unread := unreadThreads[digestTeam.teamId]; unread > 0 {
+			teamTemplate.Props["UnreadThreads"] = translateFunc("api.email_batching.send_batched_email_notification.unread_threads", int(unread))
 		}
 
-		contents += es.renderBatchedPost(notification, channel, sender, *es.srv.Config().ServiceSettings.SiteURL, displayNameFormat, translateFunc, user.Locale, emailNotificationContentsType)
+		contents += teamTemplate.Render()
+	}
+
+	if count == 0 {
+		return nil
 	}
 
 	tm := time.Unix(notifications[0].post.CreateAt/1000, 0)
 
-	subject := translateFunc("api.email_batching.send_batched_email_notification.subject", len(notifications), map[string]interface{}{
+	subject := translateFunc("api.email_batching.send_batched_email_notification.subject", count, map[string]interface{}{
 		"SiteName": es.srv.Config().TeamSettings.SiteName,
 		"Year":     tm.Year(),
 		"Month":    translateFunc(tm.Month().String()),
@@ -236,13 +363,70 @@ func (es *EmailService) sendBatchedEmailNotification(userId string, notification
 	})
 
 	body := es.newEmailTemplate("post_batched_body", user.Locale)
-	body.Props["SiteURL"] = *es.srv.Config().ServiceSettings.SiteURL
+	body.Props["SiteURL"] = siteURL
 	body.Props["Posts"] = template.HTML(contents)
-	body.Props["BodyText"] = translateFunc("api.email_batching.send_batched_email_notification.body_text", len(notifications))
+	body.Props["BodyText"] = translateFunc("api.email_batching.send_batched_email_notification.body_text", count)
+	body.Props["UnsubscribeLink"] = es.getEmailDigestUnsubscribeLink(user.Id)
+	body.Props["UnsubscribeText"] = translateFunc("api.email_batching.send_batched_email_notification.unsubscribe")
 
 	if err := es.sendNotificationMail(user.Email, subject, body.Render()); err != nil {
 		mlog.Warn("Unable to send batched email notification", mlog.String("email", user.Email), mlog.Err(err))
+		return err
 	}
+
+	return nil
+}
+
+// emailDigestUnsubscribeToken signs a user id so that the unsubscribe link of
+// their email digest can't be used to unsubscribe someone else.
+func (es *EmailService) emailDigestUnsubscribeToken(userId string) string {
+	mac := hmac.New(sha256.New, es.srv.PostActionCookieSecret())
+	mac.Write([]byte("email_digest_unsubscribe:" + userId))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (es *EmailService) getEmailDigestUnsubscribeLink(userId string) string {
+	return *es.srv.Config().ServiceSettings.SiteURL + model.API_URL_SUFFIX + "/users/" + userId + "/email_digest/unsubscribe?token=" + es.emailDigestUnsubscribeToken(userId)
+}
+
+// CheckEmailDigestUnsubscribeToken checks that the token of an email digest
+// unsubscribe link was generated for the given user.
+func (a *App) CheckEmailDigestUnsubscribeToken(userId, token string) *model.AppError {
+	expected := a.Srv().EmailService.emailDigestUnsubscribeToken(userId)
+	if !hmac.Equal([]byte(token), []byte(expected)) {
+		return model.NewAppError("CheckEmailDigestUnsubscribeToken", "app.email_digest.unsubscribe.invalid_token.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// UnsubscribeFromEmailDigests stops the email digests of the user that the
+// token was generated for and drops their pending notifications. Their other
+// email notifications are left as they are.
+func (a *App) UnsubscribeFromEmailDigests(userId, token string) *model.AppError {
+	if appErr := a.CheckEmailDigestUnsubscribeToken(userId, token); appErr != nil {
+		return appErr
+	}
+
+	if _, appErr := a.GetUser(userId); appErr != nil {
+		return appErr
+	}
+
+	preference := model.Preference{
+		UserId:   userId,
+		Category: model.PREFERENCE_CATEGORY_NOTIFICATIONS,
+		Name:     model.PREFERENCE_NAME_EMAIL_DIGEST,
+		Value:    (&model.EmailDigestSchedule{Frequency: model.EMAIL_DIGEST_FREQUENCY_NEVER}).ToJson(),
+	}
+	if appErr := a.UpdatePreferences(userId, model.Preferences{preference}); appErr != nil {
+		return appErr
+	}
+
+	if err := a.Srv().Store.EmailDigest().DeleteForUser(userId); err != nil {
+		return model.NewAppError("UnsubscribeFromEmailDigests", "app.email_digest.delete.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
 }
 
 func (es *EmailService) renderBatchedPost(notification *batchedNotification, channel *model.Channel, sender *model.User, siteURL string, displayNameFormat string, translateFunc i18n.TranslateFunc, userLocale string, emailNotificationContentsType string) string {
@@ -4,283 +4,314 @@
 package app
 
 import (
-	"sync/atomic"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/zacmm/zacmm-server/model"
-	"github.com/stretchr/testify/require"
 )
 
-func TestHandleNewNotifications(t *testing.T) {
-	th := SetupWithStoreMock(t)
-	defer th.TearDown()
-
-	id1 := model.NewId()
-	id2 := model.NewId()
-	id3 := model.NewId()
-
-	// test queueing of received posts by user
-	job := NewEmailBatchingJob(th.Server.EmailService, 128)
-
-	job.handleNewNotifications()
-
-	require.Empty(t, job.pendingNotifications, "shouldn't have added any pending notifications")
-
-	job.Add(&model.User{Id: id1}, &model.Post{UserId: id1, Message: "test"}, &model.Team{Name: "team"})
-	require.Empty(t, job.pendingNotifications, "shouldn't have added any pending notifications")
-
-	job.handleNewNotifications()
-	require.Len(t, job.pendingNotifications, 1, "should have received posts for 1 user")
-	require.Len(t, job.pendingNotifications[id1], 1, "should have received 1 post for user")
-
-	job.Add(&model.User{Id: id1}, &model.Post{UserId: id1, Message: "test"}, &model.Team{Name: "team"})
-	job.handleNewNotifications()
-	require.Len(t, job.pendingNotifications, 1, "should have received posts for 1 user")
-	require.Len(t, job.pendingNotifications[id1], 2, "should have received 2 posts for user1")
-
-	job.Add(&model.User{Id: id2}, &model.Post{UserId: id1, Message: "test"}, &model.Team{Name: "team"})
-	job.handleNewNotifications()
-	require.Len(t, job.pendingNotifications, 2, "should have received posts for 2 users")
-	require.Len(t, job.pendingNotifications[id1], 2, "should have received 2 posts for user1")
-	require.Len(t, job.pendingNotifications[id2], 1, "should have received 1 post for user2")
-
-	job.Add(&model.User{Id: id2}, &model.Post{UserId: id2, Message: "test"}, &model.Team{Name: "team"})
-	job.Add(&model.User{Id: id1}, &model.Post{UserId: id3, Message: "test"}, &model.Team{Name: "team"})
-	job.Add(&model.User{Id: id3}, &model.Post{UserId: id3, Message: "test"}, &model.Team{Name: "team"})
-	job.Add(&model.User{Id: id2}, &model.Post{UserId: id2, Message: "test"}, &model.Team{Name: "team"})
-	job.handleNewNotifications()
-	require.Len(t, job.pendingNotifications, 3, "should have received posts for 3 users")
-	require.Len(t, job.pendingNotifications[id1], 3, "should have received 3 posts for user1")
-	require.Len(t, job.pendingNotifications[id2], 3, "should have received 3 posts for user2")
-	require.Len(t, job.pendingNotifications[id3], 1, "should have received 1 post for user3")
-
-	// test ordering of received posts
-	job = NewEmailBatchingJob(th.Server.EmailService, 128)
-
-	job.Add(&model.User{Id: id1}, &model.Post{UserId: id1, Message: "test1"}, &model.Team{Name: "team"})
-	job.Add(&model.User{Id: id1}, &model.Post{UserId: id1, Message: "test2"}, &model.Team{Name: "team"})
-	job.Add(&model.User{Id: id2}, &model.Post{UserId: id1, Message: "test3"}, &model.Team{Name: "team"})
-	job.Add(&model.User{Id: id1}, &model.Post{UserId: id1, Message: "test4"}, &model.Team{Name: "team"})
-	job.Add(&model.User{Id: id2}, &model.Post{UserId: id1, Message: "test5"}, &model.Team{Name: "team"})
-	job.handleNewNotifications()
-	assert.Equal(t, job.pendingNotifications[id1][0].post.Message, "test1", "incorrect order of received posts for user1")
-	assert.Equal(t, job.pendingNotifications[id1][1].post.Message, "test2", "incorrect order of received posts for user1")
-	assert.Equal(t, job.pendingNotifications[id1][2].post.Message, "test4", "incorrect order of received posts for user1")
-	assert.Equal(t, job.pendingNotifications[id2][0].post.Message, "test3", "incorrect order of received posts for user2")
-	assert.Equal(t, job.pendingNotifications[id2][1].post.Message, "test5", "incorrect order of received posts for user2")
+func savePendingEmailNotification(t *testing.T, th *TestHelper, post *model.Post, createAt int64) *model.PendingEmailNotification {
+	notification, err := th.App.Srv().Store.EmailDigest().Save(&model.PendingEmailNotification{
+		UserId:    th.BasicUser.Id,
+		PostId:    post.Id,
+		ChannelId: post.ChannelId,
+		TeamId:    th.BasicTeam.Id,
+		CreateAt:  createAt,
+	})
+	require.NoError(t, err)
+	return notification
 }
 
-func TestCheckPendingNotifications(t *testing.T) {
-	th := Setup(t).InitBasic()
-	defer th.TearDown()
-
-	job := NewEmailBatchingJob(th.Server.EmailService, 128)
-	job.pendingNotifications[th.BasicUser.Id] = []*batchedNotification{
-		{
-			post: &model.Post{
-				UserId:    th.BasicUser.Id,
-				ChannelId: th.BasicChannel.Id,
-				CreateAt:  10000000,
-			},
-			teamName: th.BasicTeam.Name,
-		},
-	}
-
+func setChannelLastViewedAt(t *testing.T, th *TestHelper, lastViewedAt int64) {
 	channelMember, err := th.App.Srv().Store.Channel().GetMember(th.BasicChannel.Id, th.BasicUser.Id)
-	require.Nil(t, err)
-	channelMember.LastViewedAt = 9999999
+	require.NoError(t, err)
+	channelMember.LastViewedAt = lastViewedAt
 	_, err = th.App.Srv().Store.Channel().UpdateMember(channelMember)
-	require.Nil(t, err)
+	require.NoError(t, err)
+}
 
-	nErr := th.App.Srv().Store.Preference().Save(&model.Preferences{{
+func setEmailPreference(t *testing.T, th *TestHelper, name, value string) {
+	err := th.App.Srv().Store.Preference().Save(&model.Preferences{{
 		UserId:   th.BasicUser.Id,
 		Category: model.PREFERENCE_CATEGORY_NOTIFICATIONS,
-		Name:     model.PREFERENCE_NAME_EMAIL_INTERVAL,
-		Value:    "60",
+		Name:     name,
+		Value:    value,
 	}})
-	require.Nil(t, nErr)
+	require.NoError(t, err)
+}
 
-	// test that notifications aren't sent before interval
-	job.checkPendingNotifications(time.Unix(10001, 0), func(string, []*batchedNotification) {})
+func getPendingEmailNotifications(t *testing.T, th *TestHelper) []*model.PendingEmailNotification {
+	notifications, err := th.App.Srv().Store.EmailDigest().GetForUser(th.BasicUser.Id)
+	require.NoError(t, err)
+	return notifications
+}
+
+func TestAddNotificationEmailToBatch(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
 
-	require.NotNil(t, job.pendingNotifications[th.BasicUser.Id])
-	require.Len(t, job.pendingNotifications[th.BasicUser.Id], 1, "shouldn't have sent queued post")
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.EmailSettings.EnableEmailBatching = false })
 
-	// test that notifications are cleared if the user has acted
-	channelMember, err = th.App.Srv().Store.Channel().GetMember(th.BasicChannel.Id, th.BasicUser.Id)
+	err := th.App.Srv().EmailService.AddNotificationEmailToBatch(th.BasicUser, th.BasicPost, th.BasicTeam)
+	require.NotNil(t, err, "should fail when email batching is disabled")
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.EmailSettings.EnableEmailBatching = true })
+
+	err = th.App.Srv().EmailService.AddNotificationEmailToBatch(th.BasicUser, th.BasicPost, th.BasicTeam)
 	require.Nil(t, err)
-	channelMember.LastViewedAt = 10001000
-	_, err = th.App.Srv().Store.Channel().UpdateMember(channelMember)
+
+	// direct messages of users that aren't on a team aren't linked to a team
+	err = th.App.Srv().EmailService.AddNotificationEmailToBatch(th.BasicUser, th.BasicPost, &model.Team{Name: "select_team"})
 	require.Nil(t, err)
 
-	// We reset the interval to something shorter
-	nErr = th.App.Srv().Store.Preference().Save(&model.Preferences{{
-		UserId:   th.BasicUser.Id,
-		Category: model.PREFERENCE_CATEGORY_NOTIFICATIONS,
-		Name:     model.PREFERENCE_NAME_EMAIL_INTERVAL,
-		Value:    "10",
-	}})
-	require.Nil(t, nErr)
+	notifications := getPendingEmailNotifications(t, th)
+	require.Len(t, notifications, 2)
+	assert.Equal(t, th.BasicPost.Id, notifications[0].PostId)
+	assert.Equal(t, th.BasicPost.ChannelId, notifications[0].ChannelId)
+	assert.Equal(t, th.BasicTeam.Id, notifications[0].TeamId)
+	assert.Empty(t, notifications[1].TeamId)
+}
 
-	var wasCalled int32
-	job.checkPendingNotifications(time.Unix(10050, 0), func(string, []*batchedNotification) {
-		atomic.StoreInt32(&wasCalled, int32(1))
-	})
+func TestCheckPendingEmailDigests(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
 
-	// A hack to check whether the handler was called.
-	// It's not straightforward to just wait for it using a channel because the test should
-	// NOT call the handler, and it will be called only if the test fails.
-	time.Sleep(1 * time.Second)
-	// We do a check outside the email handler, because otherwise, failing from
-	// inside the handler doesn't let the .Go() function exit cleanly, and it gets
-	// stuck during server shutdown, trying to wait for the goroutine to exit
-	require.Equal(t, atomic.LoadInt32(&wasCalled), int32(0), "email handler should not have been called")
+	var received []*batchedNotification
+	handler := func(user *model.User, notifications []*batchedNotification) *model.AppError {
+		require.Equal(t, th.BasicUser.Id, user.Id)
+		received = append(received, notifications...)
+		return nil
+	}
 
-	require.Nil(t, job.pendingNotifications[th.BasicUser.Id])
-	require.Empty(t, job.pendingNotifications[th.BasicUser.Id], "should've remove queued post since user acted")
+	// creating posts updates when the user last viewed the channel
+	post := th.CreatePost(th.BasicChannel)
+	post1 := th.CreateMessagePost(th.BasicChannel, "post1")
+	post2 := th.CreateMessagePost(th.BasicChannel, "post2")
 
-	// test that notifications are sent if enough time passes since the first message
-	job.pendingNotifications[th.BasicUser.Id] = []*batchedNotification{
-		{
-			post: &model.Post{
-				UserId:    th.BasicUser.Id,
-				ChannelId: th.BasicChannel.Id,
-				CreateAt:  10060000,
-				Message:   "post1",
-			},
-			teamName: th.BasicTeam.Name,
-		},
-		{
-			post: &model.Post{
-				UserId:    th.BasicUser.Id,
-				ChannelId: th.BasicChannel.Id,
-				CreateAt:  10090000,
-				Message:   "post2",
-			},
-			teamName: th.BasicTeam.Name,
-		},
-	}
+	setChannelLastViewedAt(t, th, 9999999)
+	setEmailPreference(t, th, model.PREFERENCE_NAME_EMAIL_INTERVAL, "60")
 
-	received := make(chan *model.Post, 2)
-	timeout := make(chan bool)
+	savePendingEmailNotification(t, th, post, 10000000)
 
-	job.checkPendingNotifications(time.Unix(10130, 0), func(s string, notifications []*batchedNotification) {
-		for _, notification := range notifications {
-			received <- notification.post
-		}
-	})
+	// test that notifications aren't sent before interval
+	require.Nil(t, th.App.Srv().EmailService.checkPendingEmailDigests(time.Unix(10001, 0), handler))
+	require.Empty(t, received)
+	require.Len(t, getPendingEmailNotifications(t, th), 1, "shouldn't have sent queued post")
 
-	go func() {
-		// start a timeout to make sure that we don't get stuck here on a failed test
-		time.Sleep(5 * time.Second)
-		timeout <- true
-	}()
+	// test that notifications are cleared if the user has acted
+	setChannelLastViewedAt(t, th, 10001000)
+	setEmailPreference(t, th, model.PREFERENCE_NAME_EMAIL_INTERVAL, "10")
 
-	require.Nil(t, job.pendingNotifications[th.BasicUser.Id], "shouldn't have sent queued post")
+	require.Nil(t, th.App.Srv().EmailService.checkPendingEmailDigests(time.Unix(10050, 0), handler))
+	require.Empty(t, received, "email handler should not have been called")
+	require.Empty(t, getPendingEmailNotifications(t, th), "should've removed queued post since user acted")
 
-	select {
-	case post := <-received:
-		require.Equal(t, post.Message, "post1", "should've received post1 first")
+	// test that notifications are sent if enough time passes since the first message
+	savePendingEmailNotification(t, th, post1, 10060000)
+	savePendingEmailNotification(t, th, post2, 10090000)
+
+	require.Nil(t, th.App.Srv().EmailService.checkPendingEmailDigests(time.Unix(10130, 0), handler))
+	require.Len(t, received, 2)
+	assert.Equal(t, "post1", received[0].post.Message, "should've received post1 first")
+	assert.Equal(t, "post2", received[1].post.Message, "should've received post2 second")
+	assert.Equal(t, th.BasicTeam.Name, received[0].teamName)
+	require.Empty(t, getPendingEmailNotifications(t, th), "should've removed sent posts")
+}
 
-	case <-timeout:
-		require.Fail(t, "timed out waiting for first post notification")
-	}
+func TestCheckPendingEmailDigestsSendFailure(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
 
-	select {
-	case post := <-received:
-		require.Equal(t, post.Message, "post2", "should've received post2 second")
+	post := th.CreatePost(th.BasicChannel)
+	setChannelLastViewedAt(t, th, 9999000)
+	savePendingEmailNotification(t, th, post, 10000000)
 
-	case <-timeout:
-		require.Fail(t, "timed out waiting for second post notification")
+	handler := func(*model.User, []*batchedNotification) *model.AppError {
+		return model.NewAppError("test", "test", nil, "", 500)
 	}
+
+	require.Nil(t, th.App.Srv().EmailService.checkPendingEmailDigests(time.Unix(20000, 0), handler))
+	require.Len(t, getPendingEmailNotifications(t, th), 1, "should keep the notifications to retry sending them")
 }
 
 /**
  * Ensures that email batch interval defaults to 15 minutes for users that haven't explicitly set this preference
  */
-func TestCheckPendingNotificationsDefaultInterval(t *testing.T) {
+func TestCheckPendingEmailDigestsDefaultInterval(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
 
-	job := NewEmailBatchingJob(th.Server.EmailService, 128)
+	var called bool
+	handler := func(*model.User, []*batchedNotification) *model.AppError {
+		called = true
+		return nil
+	}
 
 	// bypasses recent user activity check
-	channelMember, err := th.App.Srv().Store.Channel().GetMember(th.BasicChannel.Id, th.BasicUser.Id)
-	require.Nil(t, err)
-	channelMember.LastViewedAt = 9999000
-	_, err = th.App.Srv().Store.Channel().UpdateMember(channelMember)
-	require.Nil(t, err)
-
-	job.pendingNotifications[th.BasicUser.Id] = []*batchedNotification{
-		{
-			post: &model.Post{
-				UserId:    th.BasicUser.Id,
-				ChannelId: th.BasicChannel.Id,
-				CreateAt:  10000000,
-			},
-			teamName: th.BasicTeam.Name,
-		},
-	}
+	post := th.CreatePost(th.BasicChannel)
+	setChannelLastViewedAt(t, th, 9999000)
+	savePendingEmailNotification(t, th, post, 10000000)
 
 	// notifications should not be sent 1s after post was created, because default batch interval is 15mins
-	job.checkPendingNotifications(time.Unix(10001, 0), func(string, []*batchedNotification) {})
-	require.NotNil(t, job.pendingNotifications[th.BasicUser.Id])
-	require.Len(t, job.pendingNotifications[th.BasicUser.Id], 1, "shouldn't have sent queued post")
+	require.Nil(t, th.App.Srv().EmailService.checkPendingEmailDigests(time.Unix(10001, 0), handler))
+	require.False(t, called)
+	require.Len(t, getPendingEmailNotifications(t, th), 1, "shouldn't have sent queued post")
 
 	// notifications should be sent 901s after post was created, because default batch interval is 15mins
-	job.checkPendingNotifications(time.Unix(10901, 0), func(string, []*batchedNotification) {})
-	require.Nil(t, job.pendingNotifications[th.BasicUser.Id])
-	require.Empty(t, job.pendingNotifications[th.BasicUser.Id], "should have sent queued post")
+	require.Nil(t, th.App.Srv().EmailService.checkPendingEmailDigests(time.Unix(10901, 0), handler))
+	require.True(t, called)
+	require.Empty(t, getPendingEmailNotifications(t, th), "should have sent queued post")
 }
 
 /**
  * Ensures that email batch interval defaults to 15 minutes if user preference is invalid
  */
-func TestCheckPendingNotificationsCantParseInterval(t *testing.T) {
+func TestCheckPendingEmailDigestsCantParseInterval(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
 
-	job := NewEmailBatchingJob(th.Server.EmailService, 128)
+	var called bool
+	handler := func(*model.User, []*batchedNotification) *model.AppError {
+		called = true
+		return nil
+	}
 
 	// bypasses recent user activity check
-	channelMember, err := th.App.Srv().Store.Channel().GetMember(th.BasicChannel.Id, th.BasicUser.Id)
-	require.Nil(t, err)
-	channelMember.LastViewedAt = 9999000
-	_, err = th.App.Srv().Store.Channel().UpdateMember(channelMember)
-	require.Nil(t, err)
+	post := th.CreatePost(th.BasicChannel)
+	setChannelLastViewedAt(t, th, 9999000)
 
 	// preference value is not an integer, so we'll fall back to the default 15min value
-	nErr := th.App.Srv().Store.Preference().Save(&model.Preferences{{
-		UserId:   th.BasicUser.Id,
-		Category: model.PREFERENCE_CATEGORY_NOTIFICATIONS,
-		Name:     model.PREFERENCE_NAME_EMAIL_INTERVAL,
-		Value:    "notAnIntegerValue",
-	}})
-	require.Nil(t, nErr)
-
-	job.pendingNotifications[th.BasicUser.Id] = []*batchedNotification{
-		{
-			post: &model.Post{
-				UserId:    th.BasicUser.Id,
-				ChannelId: th.BasicChannel.Id,
-				CreateAt:  10000000,
-			},
-			teamName: th.BasicTeam.Name,
-		},
+	setEmailPreference(t, th, model.PREFERENCE_NAME_EMAIL_INTERVAL, "notAnIntegerValue")
+	savePendingEmailNotification(t, th, post, 10000000)
+
+	require.Nil(t, th.App.Srv().EmailService.checkPendingEmailDigests(time.Unix(10001, 0), handler))
+	require.False(t, called)
+
+	require.Nil(t, th.App.Srv().EmailService.checkPendingEmailDigests(time.Unix(10901, 0), handler))
+	require.True(t, called)
+	require.Empty(t, getPendingEmailNotifications(t, th), "should have sent queued post")
+}
+
+func TestCheckPendingEmailDigestsSchedule(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	var called bool
+	handler := func(*model.User, []*batchedNotification) *model.AppError {
+		called = true
+		return nil
 	}
 
-	// notifications should not be sent 1s after post was created, because default batch interval is 15mins
-	job.checkPendingNotifications(time.Unix(10001, 0), func(string, []*batchedNotification) {})
-	require.NotNil(t, job.pendingNotifications[th.BasicUser.Id])
-	require.Len(t, job.pendingNotifications[th.BasicUser.Id], 1, "shouldn't have sent queued post")
+	post := th.CreatePost(th.BasicChannel)
+	setChannelLastViewedAt(t, th, 0)
 
-	// notifications should be sent 901s after post was created, because default batch interval is 15mins
-	job.checkPendingNotifications(time.Unix(10901, 0), func(string, []*batchedNotification) {})
+	th.BasicUser.Timezone = model.StringMap{"useAutomaticTimezone": "false", "manualTimezone": "America/New_York"}
+	_, err := th.App.Srv().Store.User().Update(th.BasicUser, true)
+	require.NoError(t, err)
+
+	schedule := &model.EmailDigestSchedule{Frequency: model.EMAIL_DIGEST_FREQUENCY_DAILY, Hour: 9}
+	setEmailPreference(t, th, model.PREFERENCE_NAME_EMAIL_DIGEST, schedule.ToJson())
+
+	location, nErr := time.LoadLocation("America/New_York")
+	require.NoError(t, nErr)
+	queuedAt := time.Date(2021, time.March, 1, 17, 0, 0, 0, location)
+	savePendingEmailNotification(t, th, post, model.GetMillisForTime(queuedAt))
+
+	// the interval preference doesn't apply when the user picked a schedule
+	require.Nil(t, th.App.Srv().EmailService.checkPendingEmailDigests(queuedAt.Add(2*time.Hour), handler))
+	require.False(t, called)
 
-	require.Nil(t, job.pendingNotifications[th.BasicUser.Id], "should have sent queued post")
+	require.Nil(t, th.App.Srv().EmailService.checkPendingEmailDigests(time.Date(2021, time.March, 2, 8, 59, 0, 0, location), handler))
+	require.False(t, called, "the digest should only be sent at 9:00 in the user's timezone")
+
+	require.Nil(t, th.App.Srv().EmailService.checkPendingEmailDigests(time.Date(2021, time.March, 2, 9, 0, 0, 0, location), handler))
+	require.True(t, called)
+	require.Empty(t, getPendingEmailNotifications(t, th))
+}
+
+func TestGroupEmailDigest(t *testing.T) {
+	teamId := model.NewId()
+	channels := map[string]*model.Channel{
+		"town":  {Id: "town", TeamId: teamId},
+		"off":   {Id: "off", TeamId: teamId},
+		"dm":    {Id: "dm", Type: model.CHANNEL_DIRECT},
+		"other": {Id: "other", TeamId: model.NewId()},
+	}
+
+	notification := func(channelId, message string) *batchedNotification {
+		return &batchedNotification{post: &model.Post{ChannelId: channelId, Message: message}}
+	}
+
+	teams := groupEmailDigest([]*batchedNotification{
+		notification("town", "1"),
+		notification("dm", "2"),
+		notification("off", "3"),
+		notification("town", "4"),
+		notification("other", "5"),
+		notification("missing", "6"),
+	}, channels)
+
+	require.Len(t, teams, 3)
+
+	assert.Equal(t, teamId, teams[0].teamId)
+	require.Len(t, teams[0].channels, 2)
+	assert.Equal(t, "town", teams[0].channels[0].channel.Id)
+	require.Len(t, teams[0].channels[0].notifications, 2)
+	assert.Equal(t, "1", teams[0].channels[0].notifications[0].post.Message)
+	assert.Equal(t, "4", teams[0].channels[0].notifications[1].post.Message)
+	assert.Equal(t, "off", teams[0].channels[1].channel.Id)
+
+	assert.Empty(t, teams[1].teamId, "direct messages should be grouped without a team")
+	assert.Equal(t, "dm", teams[1].channels[0].channel.Id)
+
+	assert.Equal(t, "other", teams[2].channels[0].channel.Id)
+}
+
+func TestUnsubscribeFromEmailDigests(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	savePendingEmailNotification(t, th, th.BasicPost, 0)
+
+	link := th.App.Srv().EmailService.getEmailDigestUnsubscribeLink(th.BasicUser.Id)
+	require.True(t, strings.HasPrefix(link, *th.App.Config().ServiceSettings.SiteURL+model.API_URL_SUFFIX+"/users/"+th.BasicUser.Id+"/email_digest/unsubscribe?token="))
+	token := link[strings.Index(link, "token=")+len("token="):]
+
+	t.Run("should reject a token for another user", func(t *testing.T) {
+		err := th.App.UnsubscribeFromEmailDigests(th.BasicUser2.Id, token)
+		require.NotNil(t, err)
+		assert.Equal(t, "app.email_digest.unsubscribe.invalid_token.app_error", err.Id)
+
+		user, err := th.App.GetUser(th.BasicUser2.Id)
+		require.Nil(t, err)
+		assert.NotEqual(t, "false", user.NotifyProps[model.EMAIL_NOTIFY_PROP])
+	})
+
+	t.Run("should stop email digests", func(t *testing.T) {
+		require.Nil(t, th.App.UnsubscribeFromEmailDigests(th.BasicUser.Id, token))
+
+		preference, err := th.App.GetPreferenceByCategoryAndNameForUser(th.BasicUser.Id, model.PREFERENCE_CATEGORY_NOTIFICATIONS, model.PREFERENCE_NAME_EMAIL_DIGEST)
+		require.Nil(t, err)
+		assert.Equal(t, (&model.EmailDigestSchedule{Frequency: model.EMAIL_DIGEST_FREQUENCY_NEVER}).ToJson(), preference.Value)
+		assert.Empty(t, getPendingEmailNotifications(t, th))
+
+		user, err := th.App.GetUser(th.BasicUser.Id)
+		require.Nil(t, err)
+		assert.NotEqual(t, "false", user.NotifyProps[model.EMAIL_NOTIFY_PROP], "other email notifications should be left as they are")
+	})
+
+	t.Run("should not batch notifications after unsubscribing", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.EmailSettings.EnableEmailBatching = true
+		})
+
+		require.Nil(t, th.App.Srv().EmailService.AddNotificationEmailToBatch(th.BasicUser, th.BasicPost, th.BasicTeam))
+		assert.Empty(t, getPendingEmailNotifications(t, th))
+	})
 }
 
 /*
@@ -0,0 +1,170 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/zacmm/zacmm-server/mlog"
+	"github.com/zacmm/zacmm-server/model"
+	"github.com/zacmm/zacmm-server/services/mailservice"
+	"github.com/zacmm/zacmm-server/store"
+)
+
+const (
+	emailQueueBatchSize  = 100
+	emailQueueMaxBatches = 10
+
+	emailQueueSentRetention = 7 * 24 * time.Hour
+)
+
+// queueMail persists an email before trying to send it right away, so that it
+// is retried by the email queue job if the SMTP server can't be reached. It
+// only returns an error if the email could not be sent and won't be retried.
+func (es *EmailService) queueMail(to, subject, htmlBody, ccMail, replyTo string) *model.AppError {
+	if *es.srv.Config().EmailSettings.SMTPServer == "" {
+		return nil
+	}
+
+	email := &model.QueuedEmail{
+		Recipient: to,
+		Cc:        ccMail,
+		ReplyTo:   replyTo,
+		Subject:   subject,
+		HtmlBody:  htmlBody,
+		// Give this attempt some time before the email queue job picks it up,
+		// so that it isn't sent twice.
+		NextAttemptAt: model.GetMillis() + int64(model.QUEUED_EMAIL_RETRY_BASE_DELAY/time.Millisecond),
+	}
+
+	if _, err := es.srv.Store.EmailQueue().Save(email); err != nil {
+		mlog.Warn("Failed to queue email, sending it without retries", mlog.Err(err))
+		return es.deliverMail(to, subject, htmlBody, ccMail, replyTo)
+	}
+
+	sendErr, err := es.sendQueuedEmail(email)
+	if err != nil {
+		mlog.Error("Failed to record the outcome of sending a queued email", mlog.String("email_id", email.Id), mlog.Err(err))
+	}
+
+	if sendErr != nil {
+		if email.Status == model.QUEUED_EMAIL_STATUS_DEAD {
+			return sendErr
+		}
+		mlog.Warn("Failed to send email, it will be retried", mlog.String("email_id", email.Id), mlog.Err(sendErr))
+	}
+
+	return nil
+}
+
+// sendQueuedEmail makes a delivery attempt for a queued email and records its
+// outcome. It returns the error of the delivery attempt, and separately any
+// error recording its outcome.
+func (es *EmailService) sendQueuedEmail(email *model.QueuedEmail) (*model.AppError, *model.AppError) {
+	sendErr := es.deliverMail(email.Recipient, email.Subject, email.HtmlBody, email.Cc, email.ReplyTo)
+	if sendErr == nil {
+		email.MarkSent()
+	} else {
+		email.MarkFailed(sendErr.Error(), mailservice.IsPermanentError(sendErr), *es.srv.Config().EmailSettings.EmailQueueMaxAttempts)
+	}
+
+	if _, err := es.srv.Store.EmailQueue().Update(email); err != nil {
+		return sendErr, model.NewAppError("sendQueuedEmail", "app.email_queue.update.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return sendErr, nil
+}
+
+// ProcessEmailQueue sends the queued emails whose next attempt is due and
+// removes the emails that were sent a while ago. It returns how many emails
+// were sent and how many failed; failed emails are retried by a later run
+// unless they were dead-lettered.
+func (a *App) ProcessEmailQueue() (int, int, *model.AppError) {
+	es := a.Srv().EmailService
+
+	sent, failed := 0, 0
+	for i := 0; i < emailQueueMaxBatches; i++ {
+		emails, err := a.Srv().Store.EmailQueue().GetReadyToSend(model.GetMillis(), emailQueueBatchSize)
+		if err != nil {
+			return sent, failed, model.NewAppError("ProcessEmailQueue", "app.email_queue.get_ready_to_send.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+
+		for _, email := range emails {
+			sendErr, appErr := es.sendQueuedEmail(email)
+			if sendErr == nil {
+				sent++
+			} else {
+				failed++
+				mlog.Warn("Failed to send queued email", mlog.String("email_id", email.Id), mlog.Int("attempts", email.Attempts), mlog.String("status", email.Status), mlog.Err(sendErr))
+			}
+
+			// Without its outcome recorded, the email would be sent again or
+			// stay pending without anyone noticing.
+			if appErr != nil {
+				return sent, failed, appErr
+			}
+		}
+
+		if len(emails) < emailQueueBatchSize {
+			break
+		}
+	}
+
+	before := model.GetMillis() - int64(emailQueueSentRetention/time.Millisecond)
+	if _, err := a.Srv().Store.EmailQueue().PermanentDeleteSentBefore(before, emailQueueBatchSize*emailQueueMaxBatches); err != nil {
+		return sent, failed, model.NewAppError("ProcessEmailQueue", "app.email_queue.permanent_delete_sent_before.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return sent, failed, nil
+}
+
+func (a *App) GetQueuedEmails(status string, page, perPage int) ([]*model.QueuedEmail, *model.AppError) {
+	emails, err := a.Srv().Store.EmailQueue().GetAll(status, page*perPage, perPage)
+	if err != nil {
+		return nil, model.NewAppError("GetQueuedEmails", "app.email_queue.get_all.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return emails, nil
+}
+
+func (a *App) GetQueuedEmail(id string) (*model.QueuedEmail, *model.AppError) {
+	email, err := a.Srv().Store.EmailQueue().Get(id)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("GetQueuedEmail", "app.email_queue.get.app_error", nil, nfErr.Error(), http.StatusNotFound)
+		default:
+			return nil, model.NewAppError("GetQueuedEmail", "app.email_queue.get.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return email, nil
+}
+
+// RequeueEmail schedules a queued email, typically a dead-lettered one, to be
+// sent again by the next run of the email queue job.
+func (a *App) RequeueEmail(id string) (*model.QueuedEmail, *model.AppError) {
+	email, appErr := a.GetQueuedEmail(id)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	email.Requeue()
+
+	updated, err := a.Srv().Store.EmailQueue().Update(email)
+	if err != nil {
+		var appErr *model.AppError
+		switch {
+		case errors.As(err, &appErr):
+			return nil, appErr
+		default:
+			return nil, model.NewAppError("RequeueEmail", "app.email_queue.update.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return updated, nil
+}
@@ -0,0 +1,151 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zacmm/zacmm-server/model"
+	"github.com/zacmm/zacmm-server/services/mailservice"
+)
+
+func getQueuedEmailForRecipient(t *testing.T, th *TestHelper, recipient string) *model.QueuedEmail {
+	emails, err := th.App.GetQueuedEmails("", 0, 1000)
+	require.Nil(t, err)
+	for _, email := range emails {
+		if email.Recipient == recipient {
+			return email
+		}
+	}
+	require.Fail(t, "email not found in the queue", recipient)
+	return nil
+}
+
+func checkInbucketReceived(t *testing.T, recipient, subject string) {
+	var mailbox mailservice.JSONMessageHeaderInbucket
+	err := mailservice.RetryInbucket(5, func() error {
+		var err error
+		mailbox, err = mailservice.GetMailBox(recipient)
+		return err
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, mailbox)
+	require.Equal(t, subject, mailbox[0].Subject)
+}
+
+func TestQueueMail(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.EmailSettings.EnableEmailQueue = true })
+
+	t.Run("should send the email right away", func(t *testing.T) {
+		recipient := "success" + model.NewId() + "@example.com"
+		defer mailservice.DeleteMailBox(recipient)
+
+		err := th.App.Srv().EmailService.sendMail(recipient, "Queued email", "<p>Body</p>")
+		require.Nil(t, err)
+
+		email := getQueuedEmailForRecipient(t, th, recipient)
+		require.Equal(t, model.QUEUED_EMAIL_STATUS_SENT, email.Status)
+		require.Equal(t, 1, email.Attempts)
+		require.NotZero(t, email.SentAt)
+
+		checkInbucketReceived(t, recipient, "Queued email")
+	})
+
+	t.Run("should retry the email when the SMTP server is unavailable", func(t *testing.T) {
+		recipient := "retry" + model.NewId() + "@example.com"
+		defer mailservice.DeleteMailBox(recipient)
+
+		smtpPort := *th.App.Config().EmailSettings.SMTPPort
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.EmailSettings.SMTPPort = "1" })
+
+		err := th.App.Srv().EmailService.sendMail(recipient, "Retried email", "<p>Body</p>")
+		require.Nil(t, err, "transient failures should not be reported")
+
+		email := getQueuedEmailForRecipient(t, th, recipient)
+		require.Equal(t, model.QUEUED_EMAIL_STATUS_PENDING, email.Status)
+		require.Equal(t, 1, email.Attempts)
+		require.NotEmpty(t, email.LastError)
+		require.True(t, email.NextAttemptAt > model.GetMillis())
+
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.EmailSettings.SMTPPort = smtpPort })
+
+		// Make the retry due now instead of waiting for the backoff.
+		email.NextAttemptAt = model.GetMillis()
+		_, nErr := th.App.Srv().Store.EmailQueue().Update(email)
+		require.NoError(t, nErr)
+
+		sent, _, appErr := th.App.ProcessEmailQueue()
+		require.Nil(t, appErr)
+		require.GreaterOrEqual(t, sent, 1)
+
+		email, err = th.App.GetQueuedEmail(email.Id)
+		require.Nil(t, err)
+		require.Equal(t, model.QUEUED_EMAIL_STATUS_SENT, email.Status)
+		require.Equal(t, 2, email.Attempts)
+
+		checkInbucketReceived(t, recipient, "Retried email")
+	})
+
+	t.Run("should dead-letter the email after the last attempt", func(t *testing.T) {
+		recipient := "dead" + model.NewId() + "@example.com"
+		defer mailservice.DeleteMailBox(recipient)
+
+		smtpPort := *th.App.Config().EmailSettings.SMTPPort
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.EmailSettings.SMTPPort = "1"
+			*cfg.EmailSettings.EmailQueueMaxAttempts = 1
+		})
+		defer th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.EmailSettings.SMTPPort = smtpPort
+			*cfg.EmailSettings.EmailQueueMaxAttempts = model.EMAIL_QUEUE_MAX_ATTEMPTS
+		})
+
+		err := th.App.Srv().EmailService.sendMail(recipient, "Dead email", "<p>Body</p>")
+		require.NotNil(t, err)
+
+		email := getQueuedEmailForRecipient(t, th, recipient)
+		require.Equal(t, model.QUEUED_EMAIL_STATUS_DEAD, email.Status)
+
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.EmailSettings.SMTPPort = smtpPort })
+
+		email, err = th.App.RequeueEmail(email.Id)
+		require.Nil(t, err)
+		require.Equal(t, model.QUEUED_EMAIL_STATUS_PENDING, email.Status)
+		require.Equal(t, 0, email.Attempts)
+
+		sent, _, appErr := th.App.ProcessEmailQueue()
+		require.Nil(t, appErr)
+		require.GreaterOrEqual(t, sent, 1)
+
+		email, err = th.App.GetQueuedEmail(email.Id)
+		require.Nil(t, err)
+		require.Equal(t, model.QUEUED_EMAIL_STATUS_SENT, email.Status)
+
+		checkInbucketReceived(t, recipient, "Dead email")
+	})
+
+	t.Run("should send directly when the queue is disabled", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.EmailSettings.EnableEmailQueue = false })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.EmailSettings.EnableEmailQueue = true })
+
+		recipient := "direct" + model.NewId() + "@example.com"
+		defer mailservice.DeleteMailBox(recipient)
+
+		err := th.App.Srv().EmailService.sendMail(recipient, "Direct email", "<p>Body</p>")
+		require.Nil(t, err)
+
+		emails, err := th.App.GetQueuedEmails("", 0, 1000)
+		require.Nil(t, err)
+		for _, email := range emails {
+			require.NotEqual(t, recipient, email.Recipient)
+		}
+
+		checkInbucketReceived(t, recipient, "Direct email")
+	})
+}
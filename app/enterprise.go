@@ -114,6 +114,84 @@ func RegisterProductNoticesJobInterface(f func(*App) tjobs.ProductNoticesJobInte
 	productNoticesJobInterface = f
 }
 
+var jobsPollClosingInterface func(*App) tjobs.PollClosingJobInterface
+
+func RegisterJobsPollClosingInterface(f func(*App) tjobs.PollClosingJobInterface) {
+	jobsPollClosingInterface = f
+}
+
+var jobsCustomStatusExpiryInterface func(*App) tjobs.CustomStatusExpiryJobInterface
+
+func RegisterJobsCustomStatusExpiryInterface(f func(*App) tjobs.CustomStatusExpiryJobInterface) {
+	jobsCustomStatusExpiryInterface = f
+}
+
+var jobsExtractContentInterface func(*App) tjobs.ExtractContentJobInterface
+
+func RegisterJobsExtractContentInterface(f func(*App) tjobs.ExtractContentJobInterface) {
+	jobsExtractContentInterface = f
+}
+
+var jobsEmailQueueInterface func(*App) tjobs.EmailQueueJobInterface
+
+func RegisterJobsEmailQueueInterface(f func(*App) tjobs.EmailQueueJobInterface) {
+	jobsEmailQueueInterface = f
+}
+
+var jobsEmailDigestInterface func(*App) tjobs.EmailDigestJobInterface
+
+func RegisterJobsEmailDigestInterface(f func(*App) tjobs.EmailDigestJobInterface) {
+	jobsEmailDigestInterface = f
+}
+
+var jobsDndScheduleInterface func(*App) tjobs.DndScheduleJobInterface
+
+func RegisterJobsDndScheduleInterface(f func(*App) tjobs.DndScheduleJobInterface) {
+	jobsDndScheduleInterface = f
+}
+
+var jobsSavedSearchAlertsInterface func(*App) tjobs.SavedSearchAlertsJobInterface
+
+func RegisterJobsSavedSearchAlertsInterface(f func(*App) tjobs.SavedSearchAlertsJobInterface) {
+	jobsSavedSearchAlertsInterface = f
+}
+
+var jobsReencryptFilesInterface func(*App) tjobs.ReencryptFilesJobInterface
+
+func RegisterJobsReencryptFilesInterface(f func(*App) tjobs.ReencryptFilesJobInterface) {
+	jobsReencryptFilesInterface = f
+}
+
+var jobsDeduplicateFilesInterface func(*App) tjobs.DeduplicateFilesJobInterface
+
+func RegisterJobsDeduplicateFilesInterface(f func(*App) tjobs.DeduplicateFilesJobInterface) {
+	jobsDeduplicateFilesInterface = f
+}
+
+var jobsScanFilesInterface func(*App) tjobs.ScanFilesJobInterface
+
+func RegisterJobsScanFilesInterface(f func(*App) tjobs.ScanFilesJobInterface) {
+	jobsScanFilesInterface = f
+}
+
+var jobsGeneratePreviewsInterface func(*App) tjobs.GeneratePreviewsJobInterface
+
+func RegisterJobsGeneratePreviewsInterface(f func(*App) tjobs.GeneratePreviewsJobInterface) {
+	jobsGeneratePreviewsInterface = f
+}
+
+var jobsMigrateFileStoreInterface func(*App) tjobs.MigrateFileStoreJobInterface
+
+func RegisterJobsMigrateFileStoreInterface(f func(*App) tjobs.MigrateFileStoreJobInterface) {
+	jobsMigrateFileStoreInterface = f
+}
+
+var jobsCleanupUploadSessionsInterface func(*App) tjobs.CleanupUploadSessionsJobInterface
+
+func RegisterJobsCleanupUploadSessionsInterface(f func(*App) tjobs.CleanupUploadSessionsJobInterface) {
+	jobsCleanupUploadSessionsInterface = f
+}
+
 var ldapInterface func(*App) einterfaces.LdapInterface
 
 func RegisterLdapInterface(f func(*App) einterfaces.LdapInterface) {
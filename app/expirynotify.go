@@ -42,7 +42,7 @@ func (a *App) NotifySessionsExpired() *model.AppError {
 		tmpMessage.AckId = model.NewId()
 		tmpMessage.Message = a.getSessionExpiredPushMessage(session)
 
-		errPush := a.sendToPushProxy(tmpMessage, session)
+		errPush := a.sendToPushTransport(tmpMessage, session)
 		if errPush != nil {
 			a.NotificationsLog().Error("Notification error",
 				mlog.String("ackId", tmpMessage.AckId),
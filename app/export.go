@@ -6,6 +6,7 @@ package app
 import (
 	"encoding/json"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -18,6 +19,9 @@ import (
 	"github.com/pkg/errors"
 )
 
+// Directory next to the export file that the files of file bookmarks are copied to.
+const exportedBookmarkFilesDir = "exported_bookmark_files"
+
 // We use this map to identify the exportable preferences.
 // Here we link the preference category and name, to the name of the relevant field in the import struct.
 var exportablePreferences = map[ComparablePreference]string{{
@@ -79,6 +83,11 @@ func (a *App) BulkExport(writer io.Writer, file string, pathToEmojiDir string, d
 		return err
 	}
 
+	mlog.Info("Bulk export: exporting channel bookmarks")
+	if err := a.exportAllChannelBookmarks(writer, file); err != nil {
+		return err
+	}
+
 	mlog.Info("Bulk export: exporting emoji")
 	if err := a.exportCustomEmoji(writer, file, pathToEmojiDir, dirNameToExportEmoji); err != nil {
 		return err
@@ -347,6 +356,18 @@ func (a *App) exportAllPosts(writer io.Writer) *model.AppError {
 			return nil
 		}
 
+		var pollPostIds []string
+		for _, post := range posts {
+			if post.Type == model.POST_POLL && post.DeleteAt == 0 {
+				pollPostIds = append(pollPostIds, post.Id)
+			}
+		}
+
+		polls, err := a.buildPostPolls(pollPostIds)
+		if err != nil {
+			return err
+		}
+
 		for _, post := range posts {
 			afterId = post.Id
 
@@ -356,6 +377,7 @@ func (a *App) exportAllPosts(writer io.Writer) *model.AppError {
 			}
 
 			postLine := ImportLineForPost(post)
+			postLine.Post.Poll = polls[post.Id]
 
 			var err *model.AppError
 			postLine.Post.Replies, err = a.buildPostReplies(post.Id)
@@ -426,6 +448,50 @@ func (a *App) BuildPostReactions(postId string) (*[]ReactionImportData, *model.A
 
 }
 
+// buildPostPolls returns the polls attached to the given posts, keyed by post
+// id. Poll results are also part of the post props, which is what compliance
+// exports include.
+func (a *App) buildPostPolls(postIds []string) (map[string]*PollImportData, *model.AppError) {
+	pollsByPost := map[string]*PollImportData{}
+	if len(postIds) == 0 {
+		return pollsByPost, nil
+	}
+
+	polls, nErr := a.Srv().Store.Poll().GetForPosts(postIds)
+	if nErr != nil {
+		return nil, model.NewAppError("buildPostPolls", "app.poll.get_for_posts.app_error", nil, nErr.Error(), http.StatusInternalServerError)
+	}
+
+	for _, poll := range polls {
+		votes, nErr := a.Srv().Store.Poll().GetVotes(poll.Id)
+		if nErr != nil {
+			return nil, model.NewAppError("buildPostPolls", "app.poll.get_votes.app_error", nil, nErr.Error(), http.StatusInternalServerError)
+		}
+
+		pollData := ImportPollFromPoll(poll, poll.Results(votes, false))
+		if !poll.Anonymous {
+			pollVotes := []PollVoteImportData{}
+			for _, vote := range votes {
+				user, err := a.Srv().Store.User().Get(vote.UserId)
+				if err != nil {
+					var nfErr *store.ErrNotFound
+					if errors.As(err, &nfErr) { // the user that voted might've been deleted by now
+						mlog.Info("Skipping poll votes by user since the entity doesn't exist anymore", mlog.String("user_id", vote.UserId))
+						continue
+					}
+					return nil, model.NewAppError("buildPostPolls", "app.user.get.app_error", nil, err.Error(), http.StatusInternalServerError)
+				}
+				pollVotes = append(pollVotes, *ImportPollVoteFromPollVote(user, vote))
+			}
+			pollData.Votes = &pollVotes
+		}
+
+		pollsByPost[poll.PostId] = pollData
+	}
+
+	return pollsByPost, nil
+}
+
 func (a *App) exportCustomEmoji(writer io.Writer, file string, pathToEmojiDir string, dirNameToExportEmoji string) *model.AppError {
 	pageNumber := 0
 	for {
@@ -513,6 +579,67 @@ func (a *App) copyEmojiImages(emojiId string, emojiImagePath string, pathToDir s
 	return nil
 }
 
+// exportAllChannelBookmarks writes the bookmarks of all team channels. The
+// files of file bookmarks are copied to a directory next to the export file.
+func (a *App) exportAllChannelBookmarks(writer io.Writer, file string) *model.AppError {
+	afterId := strings.Repeat("0", 26)
+	for {
+		bookmarks, err := a.Srv().Store.ChannelBookmark().GetAllForExport(1000, afterId)
+		if err != nil {
+			return model.NewAppError("exportAllChannelBookmarks", "app.channel_bookmark.get_all_for_export.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+
+		if len(bookmarks) == 0 {
+			break
+		}
+
+		for _, bookmark := range bookmarks {
+			afterId = bookmark.Id
+
+			filePath := ""
+			if bookmark.Type == model.CHANNEL_BOOKMARK_FILE {
+				var appErr *model.AppError
+				if filePath, appErr = a.exportChannelBookmarkFile(bookmark.FileId, file); appErr != nil {
+					return appErr
+				}
+			}
+
+			bookmarkLine := ImportLineFromChannelBookmark(bookmark, filePath)
+			if err := a.exportWriteLine(writer, bookmarkLine); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// exportChannelBookmarkFile copies a bookmarked file into the
+// 'exported_bookmark_files' directory and returns its path relative to the
+// export file.
+func (a *App) exportChannelBookmarkFile(fileId string, file string) (string, *model.AppError) {
+	info, appErr := a.GetFileInfo(fileId)
+	if appErr != nil {
+		return "", appErr
+	}
+
+	data, appErr := a.ReadFile(info.Path)
+	if appErr != nil {
+		return "", appErr
+	}
+
+	fileDir := a.createDirForEmoji(file, exportedBookmarkFilesDir) + "/" + info.Id
+	if err := os.MkdirAll(fileDir, os.ModePerm); err != nil {
+		return "", model.NewAppError("BulkExport", "app.export.export_channel_bookmark_file.error", nil, "err="+err.Error(), http.StatusInternalServerError)
+	}
+
+	if err := ioutil.WriteFile(fileDir+"/"+info.Name, data, 0666); err != nil {
+		return "", model.NewAppError("BulkExport", "app.export.export_channel_bookmark_file.error", nil, "err="+err.Error(), http.StatusInternalServerError)
+	}
+
+	return exportedBookmarkFilesDir + "/" + info.Id + "/" + info.Name, nil
+}
+
 func (a *App) exportAllDirectChannels(writer io.Writer) *model.AppError {
 	afterId := strings.Repeat("0", 26)
 	for {
@@ -555,6 +682,18 @@ func (a *App) exportAllDirectPosts(writer io.Writer) *model.AppError {
 			break
 		}
 
+		var pollPostIds []string
+		for _, post := range posts {
+			if post.Type == model.POST_POLL && post.DeleteAt == 0 {
+				pollPostIds = append(pollPostIds, post.Id)
+			}
+		}
+
+		polls, appErr := a.buildPostPolls(pollPostIds)
+		if appErr != nil {
+			return appErr
+		}
+
 		for _, post := range posts {
 			afterId = post.Id
 
@@ -571,6 +710,7 @@ func (a *App) exportAllDirectPosts(writer io.Writer) *model.AppError {
 
 			postLine := ImportLineForDirectPost(post)
 			postLine.DirectPost.Replies = replies
+			postLine.DirectPost.Poll = polls[post.Id]
 			if err := a.exportWriteLine(writer, postLine); err != nil {
 				return err
 			}
@@ -195,6 +195,62 @@ func ImportReactionFromPost(user *model.User, reaction *model.Reaction) *Reactio
 	}
 }
 
+func ImportPollFromPoll(poll *model.Poll, results *model.PollResults) *PollImportData {
+	options := []string(poll.Options)
+	return &PollImportData{
+		Question:       &poll.Question,
+		Options:        &options,
+		Anonymous:      &poll.Anonymous,
+		MultipleChoice: &poll.MultipleChoice,
+		CloseAt:        &poll.CloseAt,
+		ClosedAt:       &poll.ClosedAt,
+		Counts:         &results.Counts,
+	}
+}
+
+func ImportPollVoteFromPollVote(user *model.User, vote *model.PollVote) *PollVoteImportData {
+	return &PollVoteImportData{
+		User:     &user.Username,
+		Option:   &vote.OptionIndex,
+		CreateAt: &vote.CreateAt,
+	}
+}
+
+func ImportLineFromChannelBookmark(bookmark *model.ChannelBookmarkForExport, filePath string) *LineImportData {
+	bookmarkData := &ChannelBookmarkImportData{
+		Team:        &bookmark.TeamName,
+		Channel:     &bookmark.ChannelName,
+		Owner:       &bookmark.Username,
+		DisplayName: &bookmark.DisplayName,
+		Type:        &bookmark.Type,
+		SortOrder:   &bookmark.SortOrder,
+		CreateAt:    &bookmark.CreateAt,
+	}
+
+	if bookmark.LinkUrl != "" {
+		bookmarkData.LinkUrl = &bookmark.LinkUrl
+	}
+
+	if bookmark.ImageUrl != "" {
+		bookmarkData.ImageUrl = &bookmark.ImageUrl
+	}
+
+	if bookmark.Emoji != "" {
+		bookmarkData.Emoji = &bookmark.Emoji
+	}
+
+	if filePath != "" {
+		bookmarkData.File = &AttachmentImportData{
+			Path: &filePath,
+		}
+	}
+
+	return &LineImportData{
+		Type:     "channel_bookmark",
+		Bookmark: bookmarkData,
+	}
+}
+
 func ImportLineFromEmoji(emoji *model.Emoji, filePath string) *LineImportData {
 	return &LineImportData{
 		Type: "emoji",
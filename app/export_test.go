@@ -521,6 +521,55 @@ func TestExportPostWithProps(t *testing.T) {
 	assert.Contains(t, posts[1].Props["attachments"].([]interface{})[0], "footer")
 }
 
+func TestExportDMPostWithPoll(t *testing.T) {
+	th1 := Setup(t).InitBasic()
+
+	dmChannel := th1.CreateDmChannel(th1.BasicUser2)
+
+	poll, appErr := th1.App.CreatePoll(&model.Poll{
+		ChannelId: dmChannel.Id,
+		UserId:    th1.BasicUser.Id,
+		Question:  "Lunch?",
+		Options:   model.StringArray{"Pizza", "Sushi"},
+	}, "")
+	require.Nil(t, appErr)
+
+	_, appErr = th1.App.VoteOnPoll(poll, th1.BasicUser2.Id, &model.PollVoteRequest{Options: []int{1}})
+	require.Nil(t, appErr)
+
+	var b bytes.Buffer
+	appErr = th1.App.BulkExport(&b, "somefile", "somePath", "someDir")
+	require.Nil(t, appErr)
+
+	th1.TearDown()
+
+	th2 := Setup(t)
+	defer th2.TearDown()
+
+	appErr, i := th2.App.BulkImport(&b, false, 5)
+	require.Nil(t, appErr)
+	require.Equal(t, 0, i)
+
+	posts, err := th2.App.Srv().Store.Post().GetDirectPostParentsForExportAfter(1000, "0000000")
+	require.Nil(t, err)
+	require.Len(t, posts, 1)
+	require.Equal(t, model.POST_POLL, posts[0].Type)
+
+	polls, err := th2.App.Srv().Store.Poll().GetForPosts([]string{posts[0].Id})
+	require.NoError(t, err)
+	require.Len(t, polls, 1)
+	assert.Equal(t, "Lunch?", polls[0].Question)
+	assert.Equal(t, polls[0].Id, posts[0].GetProp(model.POST_PROPS_POLL_ID))
+
+	voter, err := th2.App.Srv().Store.User().GetByUsername(th1.BasicUser2.Username)
+	require.NoError(t, err)
+
+	results, appErr := th2.App.GetPollResults(polls[0])
+	require.Nil(t, appErr)
+	assert.Equal(t, []int{0, 1}, results.Counts)
+	assert.Equal(t, [][]string{{}, {voter.Id}}, results.Voters)
+}
+
 func TestExportDMPostWithSelf(t *testing.T) {
 	th1 := Setup(t).InitBasic()
 
@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"image"
@@ -14,15 +15,20 @@ import (
 	"image/draw"
 	"image/gif"
 	"image/jpeg"
+	"image/png"
 	"io"
+	"io/ioutil"
 	"mime/multipart"
 	"net/http"
 	"net/url"
 	"path/filepath"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
+	"unicode/utf8"
 
 	"github.com/disintegration/imaging"
 	_ "github.com/oov/psd"
@@ -33,9 +39,13 @@ import (
 	"github.com/zacmm/zacmm-server/mlog"
 	"github.com/zacmm/zacmm-server/model"
 	"github.com/zacmm/zacmm-server/plugin"
+	"github.com/zacmm/zacmm-server/services/docextractor"
 	"github.com/zacmm/zacmm-server/services/filesstore"
+	"github.com/zacmm/zacmm-server/services/previewer"
+	"github.com/zacmm/zacmm-server/services/virusscan"
 	"github.com/zacmm/zacmm-server/store"
 	"github.com/zacmm/zacmm-server/utils"
+	"github.com/zacmm/zacmm-server/utils/imgutils"
 )
 
 const (
@@ -66,6 +76,12 @@ const (
 
 	maxUploadInitialBufferSize = 1024 * 1024 // 1Mb
 
+	maxContentExtractionSize = 1024*64 - 1 // Fits in a TEXT column
+
+	fileBlobsDirectory     = "blobs/"
+	fileBlobRemovingSuffix = ".removing"
+	fileBlobGracePeriod    = time.Hour
+
 	// Deprecated
 	IMAGE_THUMBNAIL_PIXEL_WIDTH  = 120
 	IMAGE_THUMBNAIL_PIXEL_HEIGHT = 100
@@ -127,6 +143,14 @@ func (a *App) AppendFile(fr io.Reader, path string) (int64, *model.AppError) {
 	return backend.AppendFile(fr, path)
 }
 
+func (a *App) CopyFile(oldPath, newPath string) *model.AppError {
+	backend, err := a.FileBackend()
+	if err != nil {
+		return err
+	}
+	return backend.CopyFile(oldPath, newPath)
+}
+
 func (a *App) RemoveFile(path string) *model.AppError {
 	backend, err := a.FileBackend()
 	if err != nil {
@@ -361,11 +385,6 @@ func (a *App) MigrateFilenamesToFileInfos(post *model.Post) []*model.FileInfo {
 	return savedInfos
 }
 
-func (a *App) GeneratePublicLink(siteURL string, info *model.FileInfo) string {
-	hash := GeneratePublicLinkHash(info.Id, *a.Config().FileSettings.PublicLinkSalt)
-	return fmt.Sprintf("%s/files/%v/public?h=%s", siteURL, info.Id, hash)
-}
-
 func GeneratePublicLinkHash(fileId, salt string) string {
 	hash := sha256.New()
 	hash.Write([]byte(salt))
@@ -441,6 +460,10 @@ func (a *App) UploadFiles(teamId string, channelId string, userId string, files
 
 	a.HandleImages(previewPathList, thumbnailPathList, imageDataList)
 
+	for _, info := range resStruct.FileInfos {
+		a.generatePreviewVariantsInBackground(info)
+	}
+
 	return resStruct, nil
 }
 
@@ -615,6 +638,13 @@ func (a *App) UploadFileX(channelId, name string, input io.Reader,
 
 	t.init(a)
 
+	t.fileinfo.TeamId = a.getStorageUsageTeamId(t.ChannelId, t.TeamId)
+	if t.ContentLength > 0 {
+		if aerr := a.checkStorageQuota(t.UserId, t.fileinfo.TeamId, t.ContentLength); aerr != nil {
+			return nil, aerr
+		}
+	}
+
 	var aerr *model.AppError
 	if !t.Raw && t.fileinfo.IsImage() {
 		aerr = t.preprocessImage()
@@ -623,6 +653,28 @@ func (a *App) UploadFileX(channelId, name string, input io.Reader,
 		}
 	}
 
+	if !t.Raw && a.shouldStripImageMetadata(t.fileinfo) {
+		// Metadata can only be stripped once the whole image has been read,
+		// which the limited input caps at the maximum file size.
+		if _, err := io.Copy(ioutil.Discard, t.teeInput); err != nil {
+			return nil, t.newAppError("api.file.upload_file.read_request.app_error",
+				err.Error(), http.StatusBadRequest)
+		}
+		if int64(t.buf.Len()) > t.limit {
+			return nil, t.newAppError("api.file.upload_file.too_large_detailed.app_error",
+				"", http.StatusRequestEntityTooLarge, "Length", t.ContentLength, "Limit", t.maxFileSize)
+		}
+
+		stripped, upright, aerr := a.stripImageMetadata(t.fileinfo, t.buf.Bytes())
+		if aerr != nil {
+			return nil, aerr
+		}
+		if upright {
+			t.imageOrientation = Upright
+		}
+		t.buf = bytes.NewBuffer(stripped)
+	}
+
 	written, aerr := t.writeFile(io.MultiReader(t.buf, t.limitedInput), t.fileinfo.Path)
 	if aerr != nil {
 		return nil, aerr
@@ -638,6 +690,17 @@ func (a *App) UploadFileX(channelId, name string, input io.Reader,
 
 	t.fileinfo.Size = written
 
+	if aerr = a.checkStorageQuota(t.UserId, t.fileinfo.TeamId, written); aerr != nil {
+		if fileErr := a.RemoveFile(t.fileinfo.Path); fileErr != nil {
+			mlog.Error("Failed to remove file", mlog.Err(fileErr))
+		}
+		return nil, aerr
+	}
+
+	if aerr = a.scanStoredFile(t.fileinfo.Path, t.Name); aerr != nil {
+		return nil, aerr
+	}
+
 	file, aerr := a.FileReader(t.fileinfo.Path)
 	if aerr != nil {
 		return nil, aerr
@@ -656,9 +719,14 @@ func (a *App) UploadFileX(channelId, name string, input io.Reader,
 		}
 		defer file.Close()
 		t.postprocessImage(file)
+	} else if !t.Raw {
+		a.generateFilePreview(t.fileinfo)
 	}
 
-	if _, err := t.saveToDatabase(t.fileinfo); err != nil {
+	finishDeduplication := a.deduplicateFile(t.fileinfo)
+	_, err := t.saveToDatabase(t.fileinfo)
+	finishDeduplication(err == nil)
+	if err != nil {
 		var appErr *model.AppError
 		switch {
 		case errors.As(err, &appErr):
@@ -668,6 +736,10 @@ func (a *App) UploadFileX(channelId, name string, input io.Reader,
 		}
 	}
 
+	a.warnStorageQuota(t.UserId, t.fileinfo.TeamId, t.fileinfo.Size)
+	a.extractContentInBackground(t.fileinfo)
+	a.generatePreviewVariantsInBackground(t.fileinfo)
+
 	return t.fileinfo, nil
 }
 
@@ -859,6 +931,7 @@ func (a *App) DoUploadFileExpectModification(now time.Time, rawTeamId string, ra
 	info.Id = model.NewId()
 	info.CreatorId = userId
 	info.CreateAt = now.UnixNano() / int64(time.Millisecond)
+	info.TeamId = a.getStorageUsageTeamId(channelId, teamId)
 
 	pathPrefix := now.Format("20060102") + "/teams/" + teamId + "/channels/" + channelId + "/users/" + userId + "/" + info.Id + "/"
 	info.Path = pathPrefix + filename
@@ -878,6 +951,19 @@ func (a *App) DoUploadFileExpectModification(now time.Time, rawTeamId string, ra
 		info.ThumbnailPath = pathPrefix + nameWithoutExtension + "_thumb.jpg"
 	}
 
+	if a.shouldStripImageMetadata(info) {
+		stripped, _, err := a.stripImageMetadata(info, data)
+		if err != nil {
+			return nil, data, err
+		}
+		data = stripped
+		info.Size = int64(len(data))
+	}
+
+	if err := a.scanFile(filename, bytes.NewReader(data)); err != nil {
+		return nil, data, err
+	}
+
 	if pluginsEnvironment := a.GetPluginsEnvironment(); pluginsEnvironment != nil {
 		var rejectionError *model.AppError
 		pluginContext := a.PluginContext()
@@ -903,20 +989,32 @@ func (a *App) DoUploadFileExpectModification(now time.Time, rawTeamId string, ra
 		}
 	}
 
+	if err := a.checkStorageQuota(userId, info.TeamId, int64(len(data))); err != nil {
+		return nil, data, err
+	}
+
 	if _, err := a.WriteFile(bytes.NewReader(data), info.Path); err != nil {
 		return nil, data, err
 	}
 
-	if _, err := a.Srv().Store.FileInfo().Save(info); err != nil {
+	a.generateFilePreview(info)
+
+	finishDeduplication := a.deduplicateFile(info)
+	_, storeErr := a.Srv().Store.FileInfo().Save(info)
+	finishDeduplication(storeErr == nil)
+	if storeErr != nil {
 		var appErr *model.AppError
 		switch {
-		case errors.As(err, &appErr):
+		case errors.As(storeErr, &appErr):
 			return nil, data, appErr
 		default:
-			return nil, data, model.NewAppError("DoUploadFileExpectModification", "app.file_info.save.app_error", nil, err.Error(), http.StatusInternalServerError)
+			return nil, data, model.NewAppError("DoUploadFileExpectModification", "app.file_info.save.app_error", nil, storeErr.Error(), http.StatusInternalServerError)
 		}
 	}
 
+	a.warnStorageQuota(userId, info.TeamId, info.Size)
+	a.extractContentInBackground(info)
+
 	return info, data, nil
 }
 
@@ -1007,6 +1105,79 @@ func getImageOrientation(input io.Reader) (int, error) {
 	return orientation, nil
 }
 
+// shouldStripImageMetadata returns whether the metadata of the file should be
+// stripped before it is stored, as enabled by FileSettings.StripImageMetadata.
+func (a *App) shouldStripImageMetadata(info *model.FileInfo) bool {
+	if !*a.Config().FileSettings.StripImageMetadata {
+		return false
+	}
+
+	switch info.Extension {
+	case "jpg", "jpeg", "png", "webp", "heic", "heif", "avif":
+		return true
+	default:
+		return false
+	}
+}
+
+// stripImageMetadata returns the image data without its EXIF, XMP and GPS
+// metadata, recording what was removed in the file info. When its metadata
+// can't be stripped, the upload is rejected unless
+// FileSettings.StripMetadataFailure allows encoding the image again; it then
+// returns the image encoded again upright, and true.
+func (a *App) stripImageMetadata(info *model.FileInfo, data []byte) ([]byte, bool, *model.AppError) {
+	stripped, removed, err := imgutils.StripMetadata(data)
+	if err == nil {
+		info.StrippedMetadata = removed
+		return stripped, false, nil
+	}
+
+	mlog.Warn("Failed to strip image metadata", mlog.String("file_id", info.Id), mlog.String("name", info.Name), mlog.Err(err))
+	if *a.Config().FileSettings.StripMetadataFailure == model.STRIP_METADATA_FAILURE_REENCODE {
+		reencoded, reencodeErr := reencodeImage(data)
+		if reencodeErr == nil {
+			info.StrippedMetadata = []string{imgutils.MetadataAll}
+			return reencoded, true, nil
+		}
+		err = reencodeErr
+	}
+
+	return nil, false, model.NewAppError("stripImageMetadata", "app.file.strip_image_metadata.app_error", map[string]interface{}{"Filename": info.Name}, err.Error(), http.StatusBadRequest)
+}
+
+// reencodeImage decodes a JPEG or PNG image and encodes it again upright,
+// dropping all of its metadata.
+func reencodeImage(data []byte) ([]byte, error) {
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	if format != "jpeg" && format != "png" {
+		return nil, fmt.Errorf("can't encode %s images", format)
+	}
+	if int64(config.Width)*int64(config.Height) > MaxImageSize {
+		return nil, errors.New("image is too large to be encoded again")
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	orientation, _ := getImageOrientation(bytes.NewReader(data))
+	img = makeImageUpright(img, orientation)
+
+	buf := new(bytes.Buffer)
+	if format == "png" {
+		err = png.Encode(buf, img)
+	} else {
+		err = jpeg.Encode(buf, img, &jpeg.Options{Quality: 90})
+	}
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
 func (a *App) generateThumbnailImage(img image.Image, thumbnailPath string, width int, height int) {
 	buf := new(bytes.Buffer)
 	if err := jpeg.Encode(buf, genThumbnail(img), &jpeg.Options{Quality: 90}); err != nil {
@@ -1036,6 +1207,324 @@ func (a *App) generatePreviewImage(img image.Image, previewPath string, width in
 	}
 }
 
+// filePreviewer returns the previewer generating the thumbnails and the
+// previews of the files that aren't images.
+func (a *App) filePreviewer() previewer.Previewer {
+	settings := a.Config().FileSettings
+	return previewer.New(previewer.Settings{
+		FFmpegPath:   *settings.FFmpegPath,
+		PdftoppmPath: *settings.PdftoppmPath,
+		Timeout:      time.Duration(*settings.PreviewerTimeoutSeconds) * time.Second,
+		MaxImageSize: MaxImageSize,
+	})
+}
+
+// previewFormat is a format other than JPEG that thumbnails and previews can
+// be served in.
+type previewFormat struct {
+	format   string
+	mimeType string
+}
+
+// previewFormats are the formats thumbnails and previews can be served in,
+// best first.
+var previewFormats = []previewFormat{
+	{model.PREVIEW_FORMAT_AVIF, "image/avif"},
+	{model.PREVIEW_FORMAT_WEBP, "image/webp"},
+}
+
+// previewVariantPath returns the path of the thumbnail or preview stored at
+// path once converted to the format.
+func previewVariantPath(path, format string) string {
+	return strings.TrimSuffix(path, filepath.Ext(path)) + "." + format
+}
+
+// previewPaths returns the paths of the thumbnail and the preview of a file,
+// along with those of their conversions to the other formats, which may not
+// exist as they are only made when first requested.
+func previewPaths(info *model.FileInfo) []string {
+	var paths []string
+	for _, path := range []string{info.ThumbnailPath, info.PreviewPath} {
+		if path == "" {
+			continue
+		}
+		paths = append(paths, path)
+		for _, f := range previewFormats {
+			paths = append(paths, previewVariantPath(path, f.format))
+		}
+	}
+	return paths
+}
+
+// removeFilePreviews removes the thumbnail and the preview of a file in all
+// their formats.
+func (a *App) removeFilePreviews(info *model.FileInfo) {
+	for _, path := range previewPaths(info) {
+		exists, appErr := a.FileExists(path)
+		if appErr == nil && exists {
+			appErr = a.RemoveFile(path)
+		}
+		if appErr != nil {
+			mlog.Warn("Unable to remove the preview of a file", mlog.String("path", path), mlog.Err(appErr))
+		}
+	}
+}
+
+// acceptedPreviewFormats returns the enabled preview formats that the client
+// accepts according to its Accept header, preferred ones first.
+func (a *App) acceptedPreviewFormats(accept string) []previewFormat {
+	enabled := a.Config().FileSettings.PreviewFormats
+	if accept == "" || len(enabled) == 0 {
+		return nil
+	}
+
+	qualities := map[string]float64{}
+	for _, mediaRange := range strings.Split(accept, ",") {
+		params := strings.Split(mediaRange, ";")
+		quality := 1.0
+		for _, param := range params[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if q, err := strconv.ParseFloat(param[2:], 64); err == nil {
+					quality = q
+				}
+			}
+		}
+		qualities[strings.ToLower(strings.TrimSpace(params[0]))] = quality
+	}
+
+	var formats []previewFormat
+	for _, f := range previewFormats {
+		if qualities[f.mimeType] > 0 && utils.StringInSlice(f.format, enabled) {
+			formats = append(formats, f)
+		}
+	}
+	sort.SliceStable(formats, func(i, j int) bool {
+		return qualities[formats[i].mimeType] > qualities[formats[j].mimeType]
+	})
+	return formats
+}
+
+// FilePreviewReader returns a reader of the thumbnail or preview stored at
+// path, as a JPEG, in the best format accepted by the client according to
+// its Accept header, along with the MIME type of that format. Thumbnails and
+// previews are converted to other formats when first requested, falling back
+// to the JPEG when that fails.
+func (a *App) FilePreviewReader(path, accept string) (filesstore.ReadCloseSeeker, string, *model.AppError) {
+	for _, f := range a.acceptedPreviewFormats(accept) {
+		variantPath := previewVariantPath(path, f.format)
+		exists, appErr := a.FileExists(variantPath)
+		if appErr == nil && !exists {
+			appErr = a.generatePreviewVariant(path, f.format)
+		}
+		if appErr != nil {
+			mlog.Warn("Unable to get converted preview", mlog.String("path", variantPath), mlog.Err(appErr))
+			continue
+		}
+
+		reader, appErr := a.FileReader(variantPath)
+		if appErr != nil {
+			mlog.Warn("Unable to read converted preview", mlog.String("path", variantPath), mlog.Err(appErr))
+			continue
+		}
+		return reader, f.mimeType, nil
+	}
+
+	reader, appErr := a.FileReader(path)
+	if appErr != nil {
+		return nil, "", appErr
+	}
+	return reader, "image/jpeg", nil
+}
+
+// previewVariantCall is a conversion of a thumbnail or preview in progress,
+// whose result is shared with the requests made for it meanwhile.
+type previewVariantCall struct {
+	done   chan struct{}
+	appErr *model.AppError
+}
+
+// generatePreviewVariant converts the thumbnail or preview stored at path to
+// the format, and stores it next to it. Concurrent conversions of the same
+// thumbnail or preview to the same format are run once.
+func (a *App) generatePreviewVariant(path, format string) *model.AppError {
+	variantPath := previewVariantPath(path, format)
+
+	s := a.Srv()
+	s.previewVariantCallsMut.Lock()
+	if call, ok := s.previewVariantCalls[variantPath]; ok {
+		s.previewVariantCallsMut.Unlock()
+		<-call.done
+		return call.appErr
+	}
+	call := &previewVariantCall{done: make(chan struct{})}
+	s.previewVariantCalls[variantPath] = call
+	s.previewVariantCallsMut.Unlock()
+
+	// Release the call and the slot even if the conversion panics, so that
+	// waiting and later requests aren't blocked.
+	defer func() {
+		s.previewVariantCallsMut.Lock()
+		delete(s.previewVariantCalls, variantPath)
+		s.previewVariantCallsMut.Unlock()
+		close(call.done)
+	}()
+
+	s.previewVariantSema <- struct{}{}
+	defer func() { <-s.previewVariantSema }()
+
+	call.appErr = a.convertPreview(path, format)
+	return call.appErr
+}
+
+func (a *App) convertPreview(path, format string) *model.AppError {
+	reader, appErr := a.FileReader(path)
+	if appErr != nil {
+		return appErr
+	}
+	defer reader.Close()
+
+	settings := a.Config().FileSettings
+	converted, err := previewer.Convert(previewer.Settings{
+		FFmpegPath: *settings.FFmpegPath,
+		Timeout:    time.Duration(*settings.PreviewerTimeoutSeconds) * time.Second,
+	}, reader, format)
+	if err != nil {
+		return model.NewAppError("generatePreviewVariant", "app.file.convert_preview.app_error", map[string]interface{}{"Format": format}, err.Error(), http.StatusInternalServerError)
+	}
+
+	if _, appErr := a.WriteFile(bytes.NewReader(converted), previewVariantPath(path, format)); appErr != nil {
+		return appErr
+	}
+	return nil
+}
+
+// generatePreviewVariantsInBackground converts the thumbnail and the preview
+// of a new file to the enabled formats, so that they are ready when first
+// requested.
+func (a *App) generatePreviewVariantsInBackground(info *model.FileInfo) {
+	formats := a.Config().FileSettings.PreviewFormats
+	if len(formats) == 0 || !info.HasPreviewImage {
+		return
+	}
+
+	paths := []string{info.ThumbnailPath, info.PreviewPath}
+	a.Srv().Go(func() {
+		for _, path := range paths {
+			if path == "" {
+				continue
+			}
+			for _, format := range formats {
+				if appErr := a.generatePreviewVariant(path, format); appErr != nil {
+					mlog.Warn("Unable to convert preview", mlog.String("path", path), mlog.String("format", format), mlog.Err(appErr))
+				}
+			}
+		}
+	})
+}
+
+// generateFilePreview generates the thumbnail and the preview of a file that
+// isn't an image, such as the first page of a PDF or a frame of a video, and
+// sets their paths. It returns false when there is no preview for the file.
+func (a *App) generateFilePreview(info *model.FileInfo) bool {
+	if !*a.Config().FileSettings.EnableFilePreviews || info.IsImage() {
+		return false
+	}
+
+	filePreviewer := a.filePreviewer()
+	if !filePreviewer.Match(info.Name) {
+		return false
+	}
+
+	file, appErr := a.FileReader(info.Path)
+	if appErr != nil {
+		mlog.Warn("Unable to read file to generate its preview", mlog.String("path", info.Path), mlog.Err(appErr))
+		return false
+	}
+	defer file.Close()
+
+	img, err := filePreviewer.Preview(info.Name, file)
+	if err != nil {
+		if err != previewer.ErrNoPreview {
+			mlog.Warn("Unable to generate file preview", mlog.String("path", info.Path), mlog.Err(err))
+		}
+		return false
+	}
+
+	width := img.Bounds().Dx()
+	height := img.Bounds().Dy()
+
+	dir := filepath.Dir(info.Path)
+	if info.ContentHash != "" {
+		// Deduplicated files share their directory with other files.
+		dir = "previews/" + info.Id
+	}
+	nameWithoutExtension := strings.TrimSuffix(info.Name, filepath.Ext(info.Name))
+	info.ThumbnailPath = dir + "/" + nameWithoutExtension + "_thumb.jpg"
+	info.PreviewPath = dir + "/" + nameWithoutExtension + "_preview.jpg"
+	info.HasPreviewImage = true
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		a.generateThumbnailImage(img, info.ThumbnailPath, width, height)
+	}()
+	go func() {
+		defer wg.Done()
+		a.generatePreviewImage(img, info.PreviewPath, width)
+	}()
+	wg.Wait()
+
+	return true
+}
+
+// GenerateFilePreviews generates the missing thumbnails and previews of the
+// files, not images, uploaded in the given time range. It returns how many
+// previews were generated.
+func (a *App) GenerateFilePreviews(startTime, endTime int64) (int, *model.AppError) {
+	if !*a.Config().FileSettings.EnableFilePreviews {
+		return 0, model.NewAppError("GenerateFilePreviews", "app.file.generate_previews.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	const batchSize = 100
+	generated := 0
+	filePreviewer := a.filePreviewer()
+
+	for startTime < endTime {
+		files, err := a.Srv().Store.FileInfo().GetFilesBatchForIndexing(startTime, endTime, batchSize)
+		if err != nil {
+			return generated, model.NewAppError("GenerateFilePreviews", "app.file_info.get_files_batch_for_indexing.get.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+		if len(files) == 0 {
+			break
+		}
+
+		for _, file := range files {
+			info := &file.FileInfo
+			if info.DeleteAt != 0 || info.IsImage() || info.ThumbnailPath != "" || !filePreviewer.Match(info.Name) {
+				continue
+			}
+			if !a.generateFilePreview(info) {
+				continue
+			}
+
+			if _, err := a.Srv().Store.FileInfo().Upsert(info); err != nil {
+				return generated, model.NewAppError("GenerateFilePreviews", "app.file_info.save.app_error", nil, err.Error(), http.StatusInternalServerError)
+			}
+			a.Srv().Store.FileInfo().InvalidateFileInfosForPostCache(info.PostId, false)
+			generated++
+		}
+
+		if len(files) < batchSize {
+			break
+		}
+		startTime = files[len(files)-1].CreateAt + 1
+	}
+
+	return generated, nil
+}
+
 // generateMiniPreview updates mini preview if needed
 // will save fileinfo with the preview added
 func (a *App) generateMiniPreview(fi *model.FileInfo) {
@@ -1159,3 +1648,698 @@ func (a *App) CopyFileInfos(userId string, fileIds []string) ([]string, *model.A
 
 	return newFileIds, nil
 }
+
+// ExtractContentFromFileInfo extracts the text content of the given file
+// using the document extractor and stores it so the file can be searched.
+func (a *App) ExtractContentFromFileInfo(fileInfo *model.FileInfo) error {
+	file, aerr := a.FileReader(fileInfo.Path)
+	if aerr != nil {
+		return fmt.Errorf("failed to open file for extract file content: %w", aerr)
+	}
+	defer file.Close()
+
+	text, err := docextractor.Extract(fileInfo.Name, file, docextractor.ExtractSettings{
+		ArchiveRecursion: *a.Config().FileSettings.ArchiveRecursion,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to extract file content: %w", err)
+	}
+	if text == "" {
+		return nil
+	}
+
+	if len(text) > maxContentExtractionSize {
+		text = text[:maxContentExtractionSize]
+		// Avoid leaving a partial multi-byte character at the end.
+		for !utf8.ValidString(text) {
+			text = text[:len(text)-1]
+		}
+	}
+
+	fileInfo.Content = text
+	if err := a.Srv().Store.FileInfo().SetContent(fileInfo.Id, text); err != nil {
+		return fmt.Errorf("failed to save the extracted file content: %w", err)
+	}
+	return nil
+}
+
+// extractContentInBackground schedules the content extraction of a newly
+// uploaded file when content extraction is enabled.
+func (a *App) extractContentInBackground(fileInfo *model.FileInfo) {
+	if !*a.Config().FileSettings.ExtractContent || fileInfo.IsImage() {
+		return
+	}
+
+	infoCopy := *fileInfo
+	a.Srv().Go(func() {
+		if err := a.ExtractContentFromFileInfo(&infoCopy); err != nil {
+			mlog.Error("Failed to extract file content", mlog.String("file_id", infoCopy.Id), mlog.Err(err))
+		}
+	})
+}
+
+func (a *App) SearchFilesInTeamForUser(terms string, userId string, teamId string, isOrSearch bool, includeDeletedChannels bool, timeZoneOffset int, page, perPage int) (*model.FileInfoList, *model.AppError) {
+	paramsList := model.ParseSearchParams(strings.TrimSpace(terms), timeZoneOffset)
+	includeDeleted := includeDeletedChannels && *a.Config().TeamSettings.ExperimentalViewArchivedChannels
+
+	if !*a.Config().ServiceSettings.EnableFileSearch {
+		return nil, model.NewAppError("SearchFilesInTeamForUser", "store.sql_file_info.search.disabled", nil, fmt.Sprintf("teamId=%v userId=%v", teamId, userId), http.StatusNotImplemented)
+	}
+
+	finalParamsList := []*model.SearchParams{}
+
+	for _, params := range paramsList {
+		params.OrTerms = isOrSearch
+		params.IncludeDeletedChannels = includeDeleted
+		// Don't allow users to search for "*"
+		if params.Terms != "*" {
+			// Convert channel names to channel IDs
+			params.InChannels = a.convertChannelNamesToChannelIds(params.InChannels, userId, teamId, includeDeletedChannels)
+			params.ExcludedChannels = a.convertChannelNamesToChannelIds(params.ExcludedChannels, userId, teamId, includeDeletedChannels)
+
+			// Convert usernames to user IDs
+			params.FromUsers = a.convertUserNameToUserIds(params.FromUsers)
+			params.ExcludedUsers = a.convertUserNameToUserIds(params.ExcludedUsers)
+
+			finalParamsList = append(finalParamsList, params)
+		}
+	}
+
+	// If the processed search params are empty, return empty search results.
+	if len(finalParamsList) == 0 {
+		return model.NewFileInfoList(), nil
+	}
+
+	fileInfoSearchResults, nErr := a.Srv().Store.FileInfo().Search(finalParamsList, userId, teamId, page, perPage)
+	if nErr != nil {
+		var appErr *model.AppError
+		switch {
+		case errors.As(nErr, &appErr):
+			return nil, appErr
+		default:
+			return nil, model.NewAppError("SearchFilesInTeamForUser", "app.file_info.search.app_error", nil, nErr.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return fileInfoSearchResults, nil
+}
+
+// ExtractContentForFiles extracts the content of the existing files created
+// in the given time range that don't have any content extracted yet.
+func (a *App) ExtractContentForFiles(startTime, endTime int64) *model.AppError {
+	const batchSize = 100
+
+	for startTime < endTime {
+		files, err := a.Srv().Store.FileInfo().GetFilesBatchForIndexing(startTime, endTime, batchSize)
+		if err != nil {
+			return model.NewAppError("ExtractContentForFiles", "app.file_info.get_files_batch_for_indexing.get.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+		if len(files) == 0 {
+			break
+		}
+
+		for _, file := range files {
+			if file.Content != "" || file.DeleteAt != 0 || file.IsImage() {
+				continue
+			}
+			if err := a.ExtractContentFromFileInfo(&file.FileInfo); err != nil {
+				mlog.Warn("Failed to extract file content", mlog.String("file_id", file.Id), mlog.Err(err))
+			}
+		}
+
+		if len(files) < batchSize {
+			break
+		}
+		startTime = files[len(files)-1].CreateAt + 1
+	}
+
+	return nil
+}
+
+// ReencryptFiles encrypts all the stored files with the current encryption
+// key, when they were written before the encryption was enabled or before the
+// key was rotated. Besides the uploaded files and their previews, this covers
+// the emoji, the profile images, the team and bot icons, the branding and the
+// plugins. It returns the id of the key.
+func (a *App) ReencryptFiles() (string, *model.AppError) {
+	backend, appErr := a.FileBackend()
+	if appErr != nil {
+		return "", appErr
+	}
+
+	encryptedBackend, ok := backend.(*filesstore.EncryptedFileBackend)
+	if !ok {
+		return "", model.NewAppError("ReencryptFiles", "app.file.reencrypt_files.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	paths, appErr := encryptedBackend.ListDirectoryRecursively("")
+	if appErr != nil {
+		return "", appErr
+	}
+
+	// The files of the upload sessions are still being appended to, which
+	// encrypts them with the current key anyway, so they are left alone
+	// rather than rewritten under the uploads.
+	uploadPaths, appErr := a.getUploadSessionPaths()
+	if appErr != nil {
+		return "", appErr
+	}
+
+	failed := 0
+	for _, path := range *paths {
+		if uploadPaths[path] {
+			continue
+		}
+
+		if _, appErr := encryptedBackend.ReencryptFile(path); appErr != nil {
+			mlog.Warn("Failed to re-encrypt file", mlog.String("path", path), mlog.Err(appErr))
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return "", model.NewAppError("ReencryptFiles", "app.file.reencrypt_files.failed.app_error", map[string]interface{}{"Count": failed}, "", http.StatusInternalServerError)
+	}
+
+	return encryptedBackend.KeyId(), nil
+}
+
+// getUploadSessionPaths returns the paths of the files of all the upload
+// sessions, along with those of the chunks being verified for them.
+func (a *App) getUploadSessionPaths() (map[string]bool, *model.AppError) {
+	const batchSize = 1000
+
+	paths := map[string]bool{}
+	for offset := 0; ; offset += batchSize {
+		sessions, err := a.Srv().Store.UploadSession().GetAllPage(offset, batchSize)
+		if err != nil {
+			return nil, model.NewAppError("getUploadSessionPaths", "app.upload.get_all_page.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+		for _, us := range sessions {
+			paths[us.Path] = true
+			paths[uploadChunkPath(us)] = true
+		}
+		if len(sessions) < batchSize {
+			return paths, nil
+		}
+	}
+}
+
+// getFileBlobPath returns the path of the single copy kept of the
+// deduplicated files with the given content hash.
+func getFileBlobPath(contentHash string) string {
+	return fileBlobsDirectory + contentHash[0:2] + "/" + contentHash[2:4] + "/" + contentHash
+}
+
+// hashFile returns the hex encoded SHA-256 of the content of the file stored
+// at the given path.
+func (a *App) hashFile(path string) (string, *model.AppError) {
+	file, appErr := a.FileReader(path)
+	if appErr != nil {
+		return "", appErr
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", model.NewAppError("hashFile", "app.file.hash_file.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// lockFileBlob serializes the changes to the references to the deduplicated
+// copy of the given content with its removal once it is unused. The returned
+// function releases the lock.
+func (s *Server) lockFileBlob(contentHash string) func() {
+	index, _ := strconv.ParseUint(contentHash[0:2], 16, 8)
+	s.fileBlobLocks[index].Lock()
+	return s.fileBlobLocks[index].Unlock
+}
+
+// deduplicateFile points a newly uploaded file, before its FileInfo is saved,
+// to the content-addressed copy of its content when deduplication is enabled,
+// storing that copy first when the content is new. The returned function must
+// be called once saving the FileInfo succeeded or failed: the uploaded copy is
+// only removed once the saved FileInfo references the shared one, and a shared
+// copy stored for a FileInfo that couldn't be saved is removed again.
+func (a *App) deduplicateFile(info *model.FileInfo) func(saved bool) {
+	if !*a.Config().FileSettings.EnableDeduplication {
+		return func(bool) {}
+	}
+
+	contentHash, appErr := a.hashFile(info.Path)
+	if appErr != nil {
+		mlog.Warn("Failed to deduplicate file", mlog.String("path", info.Path), mlog.Err(appErr))
+		return func(bool) {}
+	}
+
+	// The shared copy must not be removed as unused before the FileInfo
+	// referencing it is saved.
+	unlock := a.Srv().lockFileBlob(contentHash)
+
+	blobPath := getFileBlobPath(contentHash)
+	exists, appErr := a.FileExists(blobPath)
+	if appErr == nil && !exists {
+		appErr = a.CopyFile(info.Path, blobPath)
+	}
+	if appErr != nil {
+		unlock()
+		mlog.Warn("Failed to deduplicate file", mlog.String("path", info.Path), mlog.Err(appErr))
+		return func(bool) {}
+	}
+
+	uploadPath := info.Path
+	info.Path = blobPath
+	info.ContentHash = contentHash
+
+	return func(saved bool) {
+		defer unlock()
+
+		if !saved {
+			info.Path = uploadPath
+			info.ContentHash = ""
+			// Another server may have referenced the copy in the meantime.
+			if !exists {
+				a.removeUnreferencedFileBlob(contentHash)
+			}
+			return
+		}
+
+		if appErr := a.restoreFileBlob(uploadPath, blobPath); appErr != nil {
+			mlog.Error("Failed to restore deduplicated file", mlog.String("content_hash", contentHash), mlog.Err(appErr))
+			return
+		}
+
+		if appErr := a.RemoveFile(uploadPath); appErr != nil {
+			mlog.Warn("Unable to remove the uploaded copy of a deduplicated file", mlog.String("path", uploadPath), mlog.Err(appErr))
+		}
+	}
+}
+
+// restoreFileBlob copies a file to the content-addressed location of its
+// content when the copy stored there was removed. It must be called once the
+// FileInfos referencing the copy are saved, and before the file is removed: a
+// server removing the copy as unused concurrently either counts the saved
+// FileInfos and keeps it, or has already removed it by then.
+func (a *App) restoreFileBlob(path, blobPath string) *model.AppError {
+	exists, appErr := a.FileExists(blobPath)
+	if appErr != nil {
+		return appErr
+	}
+	if exists {
+		return nil
+	}
+	return a.CopyFile(path, blobPath)
+}
+
+// permanentDeleteFileInfo permanently deletes a FileInfo, and the
+// deduplicated copy of its content once no other file uses it.
+func (a *App) permanentDeleteFileInfo(fileId string) {
+	info, err := a.Srv().Store.FileInfo().Get(fileId)
+	if err != nil {
+		mlog.Warn("Failed to get the FileInfo to delete", mlog.String("file_id", fileId), mlog.Err(err))
+		return
+	}
+
+	if err := a.Srv().Store.FileInfo().PermanentDelete(fileId); err != nil {
+		mlog.Warn("Failed to delete FileInfo", mlog.String("file_id", fileId), mlog.Err(err))
+		return
+	}
+
+	if info.ContentHash != "" {
+		a.removeUnusedFileBlobs([]string{info.ContentHash})
+	}
+}
+
+// removeUnusedFileBlobs removes the stored copies of the given deduplicated
+// contents that are no longer referenced by any file. It must be called once
+// the files referencing them are permanently deleted.
+func (a *App) removeUnusedFileBlobs(contentHashes []string) {
+	for _, contentHash := range contentHashes {
+		a.removeFileBlobIfUnused(contentHash)
+	}
+}
+
+func (a *App) removeFileBlobIfUnused(contentHash string) {
+	unlock := a.Srv().lockFileBlob(contentHash)
+	defer unlock()
+
+	a.removeUnreferencedFileBlob(contentHash)
+}
+
+// removeUnreferencedFileBlob removes the deduplicated copy of the given
+// content when no FileInfo references it. The lock of the content must be
+// held, which only serializes the servers' own changes: another server may be
+// saving a FileInfo referencing the copy. The copy is therefore moved aside
+// first and only removed when the FileInfos still don't reference it once
+// counted again, as the servers saving them restore a missing copy afterwards.
+func (a *App) removeUnreferencedFileBlob(contentHash string) {
+	count, err := a.Srv().Store.FileInfo().CountByContentHash(contentHash)
+	if err != nil {
+		mlog.Warn("Failed to count the references to a deduplicated file", mlog.String("content_hash", contentHash), mlog.Err(err))
+		return
+	}
+	if count > 0 {
+		return
+	}
+
+	blobPath := getFileBlobPath(contentHash)
+	if appErr := a.MoveFile(blobPath, blobPath+fileBlobRemovingSuffix); appErr != nil {
+		mlog.Warn("Unable to remove deduplicated file", mlog.String("content_hash", contentHash), mlog.Err(appErr))
+		return
+	}
+
+	a.finishFileBlobRemoval(contentHash)
+}
+
+// finishFileBlobRemoval removes the deduplicated copy of the given content
+// that was moved aside to be removed, or moves it back when a FileInfo
+// references it after all.
+func (a *App) finishFileBlobRemoval(contentHash string) {
+	blobPath := getFileBlobPath(contentHash)
+	removingPath := blobPath + fileBlobRemovingSuffix
+
+	count, err := a.Srv().Store.FileInfo().CountByContentHash(contentHash)
+	if err != nil {
+		mlog.Warn("Failed to count the references to a deduplicated file", mlog.String("content_hash", contentHash), mlog.Err(err))
+	}
+
+	if err == nil && count == 0 {
+		if appErr := a.RemoveFile(removingPath); appErr != nil {
+			mlog.Warn("Unable to remove deduplicated file", mlog.String("content_hash", contentHash), mlog.Err(appErr))
+		}
+		return
+	}
+
+	// The server that saved the reference may have restored the copy already.
+	if appErr := a.restoreFileBlob(removingPath, blobPath); appErr != nil {
+		mlog.Error("Failed to restore deduplicated file", mlog.String("content_hash", contentHash), mlog.Err(appErr))
+		return
+	}
+	if appErr := a.RemoveFile(removingPath); appErr != nil {
+		mlog.Warn("Unable to remove deduplicated file", mlog.String("content_hash", contentHash), mlog.Err(appErr))
+	}
+}
+
+// removeOrphanedFileBlobs removes the stored deduplicated copies that are no
+// longer referenced by any file, such as the ones whose files were removed by
+// the data retention, and finishes the removals that were interrupted. Copies
+// stored within the grace period are kept, as the FileInfo referencing them
+// may still be about to be saved by another server.
+func (a *App) removeOrphanedFileBlobs() *model.AppError {
+	backend, appErr := a.FileBackend()
+	if appErr != nil {
+		return appErr
+	}
+
+	paths, appErr := backend.ListDirectoryRecursively(fileBlobsDirectory)
+	if appErr != nil {
+		return appErr
+	}
+
+	for _, path := range *paths {
+		removing := strings.HasSuffix(path, fileBlobRemovingSuffix)
+		contentHash := strings.TrimSuffix(filepath.Base(path), fileBlobRemovingSuffix)
+		if strings.TrimSuffix(path, fileBlobRemovingSuffix) != getFileBlobPath(contentHash) {
+			continue
+		}
+
+		modTime, appErr := backend.FileModTime(path)
+		if appErr != nil {
+			mlog.Warn("Failed to get the age of a deduplicated file", mlog.String("path", path), mlog.Err(appErr))
+			continue
+		}
+		if time.Since(modTime) < fileBlobGracePeriod {
+			continue
+		}
+
+		// The removal of a copy moved aside was interrupted.
+		if removing {
+			unlock := a.Srv().lockFileBlob(contentHash)
+			a.finishFileBlobRemoval(contentHash)
+			unlock()
+			continue
+		}
+
+		a.removeFileBlobIfUnused(contentHash)
+	}
+
+	return nil
+}
+
+// DeduplicateFiles moves the existing files created in the given time range
+// to the content-addressed location of their content, so that a single copy
+// is kept of the files with the same content. It then removes the copies that
+// are no longer used.
+func (a *App) DeduplicateFiles(startTime, endTime int64) *model.AppError {
+	if !*a.Config().FileSettings.EnableDeduplication {
+		return model.NewAppError("DeduplicateFiles", "app.file.deduplicate_files.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	backend, appErr := a.FileBackend()
+	if appErr != nil {
+		return appErr
+	}
+
+	const batchSize = 100
+	failed := 0
+
+	for startTime < endTime {
+		files, err := a.Srv().Store.FileInfo().GetFilesBatchForIndexing(startTime, endTime, batchSize)
+		if err != nil {
+			return model.NewAppError("DeduplicateFiles", "app.file_info.get_files_batch_for_indexing.get.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+		if len(files) == 0 {
+			break
+		}
+
+		for _, file := range files {
+			if file.ContentHash != "" {
+				continue
+			}
+			if appErr := a.deduplicateStoredFile(backend, &file.FileInfo); appErr != nil {
+				mlog.Warn("Failed to deduplicate file", mlog.String("file_id", file.Id), mlog.Err(appErr))
+				failed++
+			}
+		}
+
+		if len(files) < batchSize {
+			break
+		}
+		startTime = files[len(files)-1].CreateAt + 1
+	}
+
+	if appErr := a.removeOrphanedFileBlobs(); appErr != nil {
+		return appErr
+	}
+
+	if failed > 0 {
+		return model.NewAppError("DeduplicateFiles", "app.file.deduplicate_files.failed.app_error", map[string]interface{}{"Count": failed}, "", http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// deduplicateStoredFile moves a file whose FileInfo is already saved to the
+// content-addressed location of its content. The FileInfos are updated
+// before the original copy is removed so the file stays readable if the move
+// is interrupted.
+func (a *App) deduplicateStoredFile(backend filesstore.FileBackend, info *model.FileInfo) *model.AppError {
+	if exists, appErr := backend.FileExists(info.Path); appErr != nil {
+		return appErr
+	} else if !exists {
+		return nil
+	}
+
+	contentHash, appErr := a.hashFile(info.Path)
+	if appErr != nil {
+		return appErr
+	}
+
+	unlock := a.Srv().lockFileBlob(contentHash)
+	defer unlock()
+
+	blobPath := getFileBlobPath(contentHash)
+	exists, appErr := backend.FileExists(blobPath)
+	if appErr != nil {
+		return appErr
+	}
+	if !exists {
+		if appErr = backend.CopyFile(info.Path, blobPath); appErr != nil {
+			return appErr
+		}
+	}
+
+	// Copies of a file made for another post share its path, so all of them
+	// are moved at once.
+	if err := a.Srv().Store.FileInfo().SetContentHash(info.Path, contentHash, blobPath); err != nil {
+		return model.NewAppError("deduplicateStoredFile", "app.file_info.set_content_hash.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	if info.PostId != "" {
+		a.Srv().Store.FileInfo().InvalidateFileInfosForPostCache(info.PostId, false)
+		a.Srv().Store.FileInfo().InvalidateFileInfosForPostCache(info.PostId, true)
+	}
+
+	if appErr = a.restoreFileBlob(info.Path, blobPath); appErr != nil {
+		return appErr
+	}
+
+	if appErr = backend.RemoveFile(info.Path); appErr != nil {
+		mlog.Warn("Unable to remove the original copy of a deduplicated file", mlog.String("path", info.Path), mlog.Err(appErr))
+	}
+	return nil
+}
+
+// scanFile scans the content of an upload for malware when malware scanning
+// is enabled. It fails when the content is infected, but also when it can't
+// be scanned, so that no file gets through unscanned.
+func (a *App) scanFile(name string, r io.Reader) *model.AppError {
+	settings := a.Config().FileSettings
+	if !*settings.EnableVirusScan {
+		return nil
+	}
+
+	timeout := time.Duration(*settings.VirusScanTimeoutSeconds) * time.Second
+	scanner, err := virusscan.NewScanner(*settings.VirusScanDriver, *settings.VirusScanAddress, timeout)
+	if err != nil {
+		return model.NewAppError("scanFile", "app.file.virus_scan.failed.app_error", map[string]interface{}{"Filename": name}, err.Error(), http.StatusInternalServerError)
+	}
+
+	result, err := scanner.Scan(r)
+	if err != nil {
+		return model.NewAppError("scanFile", "app.file.virus_scan.failed.app_error", map[string]interface{}{"Filename": name}, err.Error(), http.StatusInternalServerError)
+	}
+
+	if result.Infected {
+		mlog.Warn("Rejected a file containing malware", mlog.String("filename", name), mlog.String("signature", result.Signature))
+		return model.NewAppError("scanFile", "app.file.virus_scan.infected.app_error", map[string]interface{}{"Filename": name, "Signature": result.Signature}, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// scanStoredFile scans the uploaded file stored at the given path for
+// malware, and removes it when it is rejected.
+func (a *App) scanStoredFile(path, name string) *model.AppError {
+	if !*a.Config().FileSettings.EnableVirusScan {
+		return nil
+	}
+
+	file, appErr := a.FileReader(path)
+	if appErr != nil {
+		return appErr
+	}
+	appErr = a.scanFile(name, file)
+	file.Close()
+
+	if appErr != nil {
+		if fileErr := a.RemoveFile(path); fileErr != nil {
+			mlog.Error("Failed to remove file", mlog.Err(fileErr))
+		}
+		return appErr
+	}
+	return nil
+}
+
+// ScanFiles scans the existing files created in the given time range for
+// malware, with the latest signatures of the scanner, and quarantines the
+// infected ones. It returns the number of files quarantined.
+func (a *App) ScanFiles(startTime, endTime int64) (int, *model.AppError) {
+	if !*a.Config().FileSettings.EnableVirusScan {
+		return 0, model.NewAppError("ScanFiles", "app.file.scan_files.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	const batchSize = 100
+	quarantined := 0
+	failed := 0
+
+	for startTime < endTime {
+		files, err := a.Srv().Store.FileInfo().GetFilesBatchForIndexing(startTime, endTime, batchSize)
+		if err != nil {
+			return quarantined, model.NewAppError("ScanFiles", "app.file_info.get_files_batch_for_indexing.get.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+		if len(files) == 0 {
+			break
+		}
+
+		// Deduplicated files share their path, so each is only scanned once.
+		scanned := map[string]bool{}
+		for _, file := range files {
+			if file.DeleteAt != 0 || scanned[file.Path] {
+				continue
+			}
+			scanned[file.Path] = true
+
+			infected, appErr := a.scanExistingFile(&file.FileInfo)
+			if appErr != nil {
+				mlog.Warn("Failed to scan file", mlog.String("file_id", file.Id), mlog.Err(appErr))
+				failed++
+			} else if infected {
+				quarantined++
+			}
+		}
+
+		if len(files) < batchSize {
+			break
+		}
+		startTime = files[len(files)-1].CreateAt + 1
+	}
+
+	if failed > 0 {
+		return quarantined, model.NewAppError("ScanFiles", "app.file.scan_files.failed.app_error", map[string]interface{}{"Count": failed}, "", http.StatusInternalServerError)
+	}
+
+	return quarantined, nil
+}
+
+// scanExistingFile scans a saved file for malware and quarantines it when it
+// is infected. It returns whether the file was quarantined.
+func (a *App) scanExistingFile(info *model.FileInfo) (bool, *model.AppError) {
+	if exists, appErr := a.FileExists(info.Path); appErr != nil {
+		return false, appErr
+	} else if !exists {
+		return false, nil
+	}
+
+	file, appErr := a.FileReader(info.Path)
+	if appErr != nil {
+		return false, appErr
+	}
+	appErr = a.scanFile(info.Name, file)
+	file.Close()
+
+	if appErr == nil {
+		return false, nil
+	} else if appErr.Id != "app.file.virus_scan.infected.app_error" {
+		return false, appErr
+	}
+
+	return true, a.quarantineFile(info)
+}
+
+// quarantineFile deletes the files stored at the path of the given file and
+// moves the stored file under the quarantine directory, where it is kept for
+// the admins to inspect. The files are deleted first so that they can't be
+// downloaded anymore even if the move fails.
+func (a *App) quarantineFile(info *model.FileInfo) *model.AppError {
+	// The deduplicated copy is shared by all the files with its content, which
+	// are all quarantined with it.
+	if info.ContentHash != "" {
+		unlock := a.Srv().lockFileBlob(info.ContentHash)
+		defer unlock()
+	}
+
+	quarantinePath := "quarantine/" + info.Path
+	if err := a.Srv().Store.FileInfo().Quarantine(info.Path, quarantinePath); err != nil {
+		return model.NewAppError("quarantineFile", "app.file_info.quarantine.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	if info.PostId != "" {
+		a.Srv().Store.FileInfo().InvalidateFileInfosForPostCache(info.PostId, false)
+		a.Srv().Store.FileInfo().InvalidateFileInfosForPostCache(info.PostId, true)
+	}
+
+	a.removeFilePreviews(info)
+
+	mlog.Warn("Quarantined a file containing malware", mlog.String("file_id", info.Id), mlog.String("path", quarantinePath))
+	return a.MoveFile(info.Path, quarantinePath)
+}
@@ -0,0 +1,416 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/zacmm/zacmm-server/mlog"
+	"github.com/zacmm/zacmm-server/model"
+	"github.com/zacmm/zacmm-server/services/filesstore"
+)
+
+const fileStoreMigrationBatchSize = 100
+
+type fileStoreMigration struct {
+	source      filesstore.FileBackend
+	destination filesstore.FileBackend
+	report      *model.FileStoreMigrationReport
+	progress    func(*model.FileStoreMigrationReport)
+}
+
+// MigrateFileStore copies the files of the current file backend to the one
+// with the given settings and, once they are all copied, switches the
+// configuration to the new backend. The files that are already in the new
+// backend with the same content are skipped, so that a migration that failed
+// can be resumed by running it again. While the files are copied, and until
+// the configuration is switched, the changes made to the files by every node
+// of the cluster are mirrored to the new backend, and the switch is refused
+// if any of them failed on any node. The progress function, if any, is called
+// after each batch of files.
+func (a *App) MigrateFileStore(settings *model.FileSettings, progress func(*model.FileStoreMigrationReport)) (*model.FileStoreMigrationReport, *model.AppError) {
+	source, appErr := a.FileBackend()
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	license := a.Srv().License()
+	destination, appErr := filesstore.NewFileBackend(settings, license != nil && *license.Features.Compliance)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if appErr = destination.TestConnection(); appErr != nil {
+		return nil, appErr
+	}
+
+	m := &fileStoreMigration{
+		source:      source,
+		destination: destination,
+		report:      &model.FileStoreMigrationReport{},
+		progress:    progress,
+	}
+
+	migrationId := model.NewId()
+	if appErr := a.startFileStoreMirror(settings, migrationId); appErr != nil {
+		return nil, appErr
+	}
+	defer a.stopFileStoreMirror(migrationId)
+
+	endTime := model.GetMillis()
+	if appErr := a.migrateFileInfos(m, 0, endTime); appErr != nil {
+		return m.report, appErr
+	}
+	if appErr := a.migrateEmojis(m); appErr != nil {
+		return m.report, appErr
+	}
+	if appErr := a.migrateProfileImages(m); appErr != nil {
+		return m.report, appErr
+	}
+	if appErr := a.migrateTeamIcons(m); appErr != nil {
+		return m.report, appErr
+	}
+	if appErr := a.migrateBotIcons(m); appErr != nil {
+		return m.report, appErr
+	}
+	if appErr := m.migrateDirectory(fileStorePluginFolder); appErr != nil {
+		return m.report, appErr
+	}
+	if appErr := m.migrateDirectory(BRAND_FILE_PATH); appErr != nil {
+		return m.report, appErr
+	}
+	if appErr := a.migrateUploadSessions(m); appErr != nil {
+		return m.report, appErr
+	}
+	if appErr := a.migrateFileInfos(m, endTime, model.GetMillis()); appErr != nil {
+		return m.report, appErr
+	}
+
+	if m.report.Failed > 0 {
+		return m.report, model.NewAppError("MigrateFileStore", "app.file_store_migration.failed.app_error", map[string]interface{}{"Count": m.report.Failed}, "", http.StatusInternalServerError)
+	}
+	// The failures of every node are recorded in the database.
+	mirrorFailed, err := a.Srv().Store.System().GetByName(model.SYSTEM_FILE_STORE_MIRROR_FAILED_PREFIX + migrationId)
+	if err != nil {
+		return m.report, model.NewAppError("MigrateFileStore", "app.system.get_by_name.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	if mirrorFailed.Value == "true" {
+		return m.report, model.NewAppError("MigrateFileStore", "app.file_store_migration.mirror_failed.app_error", nil, "", http.StatusInternalServerError)
+	}
+
+	// The credentials are those of the current configuration.
+	cfg := a.Config().Clone()
+	cfg.FileSettings.DriverName = model.NewString(*settings.DriverName)
+	cfg.FileSettings.Directory = model.NewString(*settings.Directory)
+	cfg.FileSettings.AmazonS3Bucket = model.NewString(*settings.AmazonS3Bucket)
+	cfg.FileSettings.AmazonS3PathPrefix = model.NewString(*settings.AmazonS3PathPrefix)
+	cfg.FileSettings.AmazonS3Region = model.NewString(*settings.AmazonS3Region)
+	cfg.FileSettings.AmazonS3Endpoint = model.NewString(*settings.AmazonS3Endpoint)
+	cfg.FileSettings.AmazonS3SSL = model.NewBool(*settings.AmazonS3SSL)
+	cfg.FileSettings.AmazonS3SignV2 = model.NewBool(*settings.AmazonS3SignV2)
+	cfg.FileSettings.AmazonS3SSE = model.NewBool(*settings.AmazonS3SSE)
+	if appErr := a.SaveConfig(cfg, true); appErr != nil {
+		return m.report, appErr
+	}
+
+	return m.report, nil
+}
+
+// startFileStoreMirror mirrors the changes made to the files on every node to
+// the backend with the given settings. The nodes record the changes that fail
+// under the id of the migration.
+func (a *App) startFileStoreMirror(settings *model.FileSettings, migrationId string) *model.AppError {
+	if err := a.Srv().Store.System().SaveOrUpdate(&model.System{Name: model.SYSTEM_FILE_STORE_MIRROR_FAILED_PREFIX + migrationId, Value: "false"}); err != nil {
+		return model.NewAppError("MigrateFileStore", "app.system.save.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	a.Srv().setFileStoreMirror(settings, migrationId)
+
+	if a.Cluster() != nil {
+		data := model.FileStoreMigrationData(settings)
+		data[model.FILE_STORE_MIGRATION_ID] = migrationId
+		a.Cluster().SendClusterMessage(&model.ClusterMessage{
+			Event:            model.CLUSTER_EVENT_FILE_STORE_MIGRATION,
+			SendType:         model.CLUSTER_SEND_RELIABLE,
+			WaitForAllToSend: true,
+			Data:             model.MapToJson(data),
+		})
+	}
+	return nil
+}
+
+func (a *App) stopFileStoreMirror(migrationId string) {
+	a.Srv().setFileStoreMirror(nil, "")
+
+	if a.Cluster() != nil {
+		a.Cluster().SendClusterMessage(&model.ClusterMessage{
+			Event:            model.CLUSTER_EVENT_FILE_STORE_MIGRATION,
+			SendType:         model.CLUSTER_SEND_RELIABLE,
+			WaitForAllToSend: true,
+		})
+	}
+
+	if _, err := a.Srv().Store.System().PermanentDeleteByName(model.SYSTEM_FILE_STORE_MIRROR_FAILED_PREFIX + migrationId); err != nil {
+		mlog.Warn("Failed to remove the record of the file changes that couldn't be mirrored", mlog.String("migration_id", migrationId), mlog.Err(err))
+	}
+}
+
+// clusterFileStoreMigrationHandler starts mirroring the changes made to the
+// files to the backend a migration copies them to, or stops it when the
+// message has no data. The credentials are those of the local configuration.
+func (a *App) clusterFileStoreMigrationHandler(msg *model.ClusterMessage) {
+	if msg.Data == "" {
+		a.Srv().setFileStoreMirror(nil, "")
+		return
+	}
+
+	data := model.MapFromJson(strings.NewReader(msg.Data))
+	settings, appErr := model.FileStoreMigrationDestination(&a.Config().FileSettings, data)
+	if appErr != nil {
+		mlog.Error("Failed to mirror file changes to the file store being migrated to", mlog.Err(appErr))
+		a.Srv().recordFileStoreMirrorFailure(data[model.FILE_STORE_MIGRATION_ID])
+		return
+	}
+	a.Srv().setFileStoreMirror(settings, data[model.FILE_STORE_MIGRATION_ID])
+}
+
+func (a *App) migrateFileInfos(m *fileStoreMigration, startTime, endTime int64) *model.AppError {
+	for startTime < endTime {
+		files, err := a.Srv().Store.FileInfo().GetFilesBatchForIndexing(startTime, endTime, fileStoreMigrationBatchSize)
+		if err != nil {
+			return model.NewAppError("MigrateFileStore", "app.file_info.get_files_batch_for_indexing.get.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+		if len(files) == 0 {
+			break
+		}
+
+		for _, file := range files {
+			m.migrate(file.Path)
+			m.migrate(file.ThumbnailPath)
+			m.migrate(file.PreviewPath)
+			for _, f := range previewFormats {
+				if file.ThumbnailPath != "" {
+					m.migrateIfExists(previewVariantPath(file.ThumbnailPath, f.format))
+				}
+				if file.PreviewPath != "" {
+					m.migrateIfExists(previewVariantPath(file.PreviewPath, f.format))
+				}
+			}
+		}
+		m.done()
+
+		if len(files) < fileStoreMigrationBatchSize {
+			break
+		}
+		startTime = files[len(files)-1].CreateAt + 1
+	}
+	return nil
+}
+
+// migrateUploadSessions copies the partial files of the uploads in progress,
+// which are then appended to on the new backend.
+func (a *App) migrateUploadSessions(m *fileStoreMigration) *model.AppError {
+	for offset := 0; ; offset += fileStoreMigrationBatchSize {
+		sessions, err := a.Srv().Store.UploadSession().GetAllPage(offset, fileStoreMigrationBatchSize)
+		if err != nil {
+			return model.NewAppError("MigrateFileStore", "app.upload.get_all_page.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+		for _, us := range sessions {
+			m.migrate(us.Path)
+		}
+		m.done()
+		if len(sessions) < fileStoreMigrationBatchSize {
+			return nil
+		}
+	}
+}
+
+func (a *App) migrateEmojis(m *fileStoreMigration) *model.AppError {
+	for offset := 0; ; offset += fileStoreMigrationBatchSize {
+		emojis, err := a.Srv().Store.Emoji().GetList(offset, fileStoreMigrationBatchSize, "")
+		if err != nil {
+			return model.NewAppError("MigrateFileStore", "app.emoji.get_list.internal_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+		for _, emoji := range emojis {
+			m.migrate(getEmojiImagePath(emoji.Id))
+		}
+		m.done()
+		if len(emojis) < fileStoreMigrationBatchSize {
+			return nil
+		}
+	}
+}
+
+func (a *App) migrateProfileImages(m *fileStoreMigration) *model.AppError {
+	for page := 0; ; page++ {
+		users, err := a.Srv().Store.User().GetAllProfiles(&model.UserGetOptions{Page: page, PerPage: fileStoreMigrationBatchSize})
+		if err != nil {
+			return model.NewAppError("MigrateFileStore", "app.user.get_profiles.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+		for _, user := range users {
+			// The default profile images are generated again when missing.
+			if user.LastPictureUpdate > 0 {
+				m.migrate("users/" + user.Id + "/profile.png")
+			}
+		}
+		m.done()
+		if len(users) < fileStoreMigrationBatchSize {
+			return nil
+		}
+	}
+}
+
+func (a *App) migrateTeamIcons(m *fileStoreMigration) *model.AppError {
+	for offset := 0; ; offset += fileStoreMigrationBatchSize {
+		teams, err := a.Srv().Store.Team().GetAllPage(offset, fileStoreMigrationBatchSize)
+		if err != nil {
+			return model.NewAppError("MigrateFileStore", "app.team.get_all.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+		for _, team := range teams {
+			if team.LastTeamIconUpdate > 0 {
+				m.migrate("teams/" + team.Id + "/teamIcon.png")
+			}
+		}
+		m.done()
+		if len(teams) < fileStoreMigrationBatchSize {
+			return nil
+		}
+	}
+}
+
+func (a *App) migrateBotIcons(m *fileStoreMigration) *model.AppError {
+	for page := 0; ; page++ {
+		bots, err := a.Srv().Store.Bot().GetAll(&model.BotGetOptions{IncludeDeleted: true, Page: page, PerPage: fileStoreMigrationBatchSize})
+		if err != nil {
+			return model.NewAppError("MigrateFileStore", "app.bot.getbots.internal_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+		for _, bot := range bots {
+			if bot.LastIconUpdate > 0 {
+				m.migrate(getBotIconPath(bot.UserId))
+			}
+		}
+		m.done()
+		if len(bots) < fileStoreMigrationBatchSize {
+			return nil
+		}
+	}
+}
+
+func (m *fileStoreMigration) done() {
+	if m.progress != nil {
+		m.progress(m.report)
+	}
+}
+
+// migrateDirectory copies the files of a directory that isn't referenced by
+// the database, such as the plugin bundles.
+func (m *fileStoreMigration) migrateDirectory(dir string) *model.AppError {
+	paths, appErr := m.source.ListDirectory(dir)
+	if appErr != nil {
+		return appErr
+	}
+	for _, path := range *paths {
+		m.migrate(path)
+	}
+	m.done()
+	return nil
+}
+
+// migrateIfExists copies a file that may not exist, such as the conversions
+// of the thumbnails and previews to other formats, without reporting it as
+// missing.
+func (m *fileStoreMigration) migrateIfExists(path string) {
+	if exists, appErr := m.source.FileExists(path); appErr == nil && !exists {
+		return
+	}
+	m.migrate(path)
+}
+
+// migrate copies a file to the destination backend, recording the outcome in
+// the report of the migration.
+func (m *fileStoreMigration) migrate(path string) {
+	if path == "" {
+		return
+	}
+
+	if exists, appErr := m.source.FileExists(path); appErr != nil {
+		mlog.Warn("Failed to check file before migrating it", mlog.String("path", path), mlog.Err(appErr))
+		m.report.Failed++
+		return
+	} else if !exists {
+		mlog.Warn("File to migrate is missing", mlog.String("path", path))
+		m.report.Missing++
+		return
+	}
+
+	copied, err := migrateFile(m.source, m.destination, path)
+	if err != nil {
+		mlog.Warn("Failed to migrate file", mlog.String("path", path), mlog.Err(err))
+		m.report.Failed++
+		return
+	}
+	if copied {
+		m.report.Copied++
+	} else {
+		m.report.Skipped++
+	}
+}
+
+// migrateFile copies the file at the given path from a backend to the other,
+// unless it is already there, and checks that the copy has the checksum of
+// the original. It returns whether the file was copied.
+func migrateFile(source, destination filesstore.FileBackend, path string) (bool, error) {
+	checksum, err := fileChecksum(source, path)
+	if err != nil {
+		return false, err
+	}
+
+	exists, appErr := destination.FileExists(path)
+	if appErr != nil {
+		return false, appErr
+	}
+	if exists {
+		if existing, err := fileChecksum(destination, path); err == nil && bytes.Equal(existing, checksum) {
+			return false, nil
+		}
+	}
+
+	reader, appErr := source.Reader(path)
+	if appErr != nil {
+		return false, appErr
+	}
+	defer reader.Close()
+
+	if _, appErr = destination.WriteFile(reader, path); appErr != nil {
+		return false, appErr
+	}
+
+	copied, err := fileChecksum(destination, path)
+	if err != nil {
+		return false, err
+	}
+	if !bytes.Equal(copied, checksum) {
+		return false, errors.New("checksum of the copy doesn't match the original")
+	}
+	return true, nil
+}
+
+func fileChecksum(backend filesstore.FileBackend, path string) ([]byte, error) {
+	reader, appErr := backend.Reader(path)
+	if appErr != nil {
+		return nil, appErr
+	}
+	defer reader.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, reader); err != nil {
+		return nil, errors.Wrapf(err, "failed to read %s", path)
+	}
+	return hash.Sum(nil), nil
+}
@@ -0,0 +1,162 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zacmm/zacmm-server/model"
+	"github.com/zacmm/zacmm-server/services/filesstore"
+)
+
+func newTestLocalFileSettings(t *testing.T) *model.FileSettings {
+	dir, err := ioutil.TempDir("", "filestoremigration")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	settings := &model.FileSettings{}
+	settings.SetDefaults(false)
+	settings.Directory = model.NewString(dir)
+	return settings
+}
+
+func newTestLocalFileBackend(t *testing.T) filesstore.FileBackend {
+	backend, appErr := filesstore.NewFileBackend(newTestLocalFileSettings(t), false)
+	require.Nil(t, appErr)
+	return backend
+}
+
+func TestMigrateFile(t *testing.T) {
+	source := newTestLocalFileBackend(t)
+	destination := newTestLocalFileBackend(t)
+
+	_, appErr := source.WriteFile(bytes.NewReader([]byte("content")), "data/file.txt")
+	require.Nil(t, appErr)
+
+	t.Run("should copy the file", func(t *testing.T) {
+		copied, err := migrateFile(source, destination, "data/file.txt")
+		require.NoError(t, err)
+		assert.True(t, copied)
+
+		data, appErr := destination.ReadFile("data/file.txt")
+		require.Nil(t, appErr)
+		assert.Equal(t, "content", string(data))
+	})
+
+	t.Run("should skip the file already copied", func(t *testing.T) {
+		copied, err := migrateFile(source, destination, "data/file.txt")
+		require.NoError(t, err)
+		assert.False(t, copied)
+	})
+
+	t.Run("should copy the file again when the copy differs", func(t *testing.T) {
+		_, appErr := destination.WriteFile(bytes.NewReader([]byte("cont")), "data/file.txt")
+		require.Nil(t, appErr)
+
+		copied, err := migrateFile(source, destination, "data/file.txt")
+		require.NoError(t, err)
+		assert.True(t, copied)
+
+		data, appErr := destination.ReadFile("data/file.txt")
+		require.Nil(t, appErr)
+		assert.Equal(t, "content", string(data))
+	})
+
+	t.Run("should fail when the file is missing", func(t *testing.T) {
+		_, err := migrateFile(source, destination, "data/missing.txt")
+		require.Error(t, err)
+	})
+}
+
+func TestMigrateFileStore(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	sourceSettings := newTestLocalFileSettings(t)
+	th.App.UpdateConfig(func(cfg *model.Config) { cfg.FileSettings.Directory = sourceSettings.Directory })
+
+	info, appErr := th.App.DoUploadFile(time.Now(), th.BasicTeam.Id, th.BasicChannel.Id, th.BasicUser.Id, "file.txt", []byte("content"))
+	require.Nil(t, appErr)
+	defer th.App.Srv().Store.FileInfo().PermanentDelete(info.Id)
+
+	_, appErr = th.App.WriteFile(bytes.NewReader([]byte("plugin")), "plugins/testplugin.tar.gz")
+	require.Nil(t, appErr)
+	_, appErr = th.App.WriteFile(bytes.NewReader([]byte("brand")), BRAND_FILE_PATH+BRAND_FILE_NAME)
+	require.Nil(t, appErr)
+
+	destinationSettings := newTestLocalFileSettings(t)
+	settings, appErr := model.FileStoreMigrationDestination(&th.App.Config().FileSettings, map[string]string{
+		model.FILE_STORE_MIGRATION_DIRECTORY: *destinationSettings.Directory,
+	})
+	require.Nil(t, appErr)
+
+	t.Run("should fail without switching when a file can't be copied", func(t *testing.T) {
+		// A file in place of the destination directory of the brand image.
+		require.NoError(t, ioutil.WriteFile(*destinationSettings.Directory+"/brand", nil, 0600))
+		defer os.Remove(*destinationSettings.Directory + "/brand")
+
+		report, appErr := th.App.MigrateFileStore(settings, nil)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.file_store_migration.failed.app_error", appErr.Id)
+		assert.Equal(t, 1, report.Failed)
+		assert.Equal(t, *sourceSettings.Directory, *th.App.Config().FileSettings.Directory)
+	})
+
+	t.Run("should fail without switching when another node couldn't mirror a change", func(t *testing.T) {
+		_, appErr := th.App.MigrateFileStore(settings, func(report *model.FileStoreMigrationReport) {
+			th.App.Srv().fileStoreMirrorMut.RLock()
+			migrationId := th.App.Srv().fileStoreMirrorMigrationId
+			th.App.Srv().fileStoreMirrorMut.RUnlock()
+			th.App.Srv().recordFileStoreMirrorFailure(migrationId)
+		})
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.file_store_migration.mirror_failed.app_error", appErr.Id)
+		assert.Equal(t, *sourceSettings.Directory, *th.App.Config().FileSettings.Directory)
+	})
+
+	us, err := th.App.Srv().Store.UploadSession().Save(&model.UploadSession{
+		Id:        model.NewId(),
+		Type:      model.UploadTypeAttachment,
+		UserId:    th.BasicUser.Id,
+		ChannelId: th.BasicChannel.Id,
+		Filename:  "upload.txt",
+		Path:      "data/upload.txt",
+		FileSize:  100,
+	})
+	require.NoError(t, err)
+	defer th.App.Srv().Store.UploadSession().Delete(us.Id)
+	_, appErr = th.App.WriteFile(bytes.NewReader([]byte("partial")), us.Path)
+	require.Nil(t, appErr)
+
+	t.Run("should resume the migration and switch the file store", func(t *testing.T) {
+		var progress []model.FileStoreMigrationReport
+		report, appErr := th.App.MigrateFileStore(settings, func(report *model.FileStoreMigrationReport) {
+			if len(progress) == 0 {
+				// The changes made during the migration are mirrored.
+				_, appErr := th.App.WriteFile(bytes.NewReader([]byte("during")), "data/during.txt")
+				require.Nil(t, appErr)
+			}
+			progress = append(progress, *report)
+		})
+		require.Nil(t, appErr)
+		assert.Equal(t, 2, report.Copied)
+		assert.GreaterOrEqual(t, report.Skipped, 2)
+		assert.Zero(t, report.Failed)
+		assert.NotEmpty(t, progress)
+		assert.Equal(t, *destinationSettings.Directory, *th.App.Config().FileSettings.Directory)
+
+		for _, path := range []string{info.Path, us.Path, "data/during.txt", "plugins/testplugin.tar.gz", BRAND_FILE_PATH + BRAND_FILE_NAME} {
+			exists, appErr := th.App.FileExists(path)
+			require.Nil(t, appErr)
+			assert.True(t, exists, path)
+		}
+	})
+}
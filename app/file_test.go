@@ -4,17 +4,28 @@
 package app
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
 	"fmt"
+	"image/jpeg"
+	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/rwcarlsen/goexif/exif"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/zacmm/zacmm-server/model"
+	"github.com/zacmm/zacmm-server/services/virusscan/virusscantest"
 	"github.com/zacmm/zacmm-server/utils/fileutils"
+	"github.com/zacmm/zacmm-server/utils/imgutils"
+	"github.com/zacmm/zacmm-server/utils/testutils"
 )
 
 func TestGeneratePublicLinkHash(t *testing.T) {
@@ -299,3 +310,692 @@ func TestCopyFileInfos(t *testing.T) {
 	assert.NotEqual(t, info1.Id, info2.Id, "should not be equal")
 	assert.Equal(t, info2.PostId, "", "should be empty string")
 }
+
+func TestDoUploadFileDeduplication(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.FileSettings.EnableDeduplication = true })
+
+	teamId := model.NewId()
+	channelId := model.NewId()
+	userId := model.NewId()
+	data := []byte(model.NewId())
+	contentHash := fmt.Sprintf("%x", sha256.Sum256(data))
+
+	info1, err := th.App.DoUploadFile(time.Now(), teamId, channelId, userId, "file1.txt", data)
+	require.Nil(t, err)
+	defer th.App.Srv().Store.FileInfo().PermanentDelete(info1.Id)
+
+	info2, err := th.App.DoUploadFile(time.Now(), teamId, channelId, userId, "file2.txt", data)
+	require.Nil(t, err)
+	defer th.App.Srv().Store.FileInfo().PermanentDelete(info2.Id)
+	defer th.App.RemoveFile(info2.Path)
+
+	assert.Equal(t, contentHash, info1.ContentHash)
+	assert.Equal(t, getFileBlobPath(contentHash), info1.Path)
+	assert.Equal(t, info1.Path, info2.Path, "files with the same content should share the stored copy")
+
+	stored, err := th.App.ReadFile(info2.Path)
+	require.Nil(t, err)
+	assert.Equal(t, data, stored)
+
+	count, nErr := th.App.Srv().Store.FileInfo().CountByContentHash(contentHash)
+	require.Nil(t, nErr)
+	assert.Equal(t, int64(2), count)
+}
+
+func TestDeduplicateFile(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.FileSettings.EnableDeduplication = true })
+
+	newUpload := func(t *testing.T) (*model.FileInfo, string) {
+		data := []byte(model.NewId())
+		info := &model.FileInfo{Path: "tests/" + model.NewId()}
+		_, err := th.App.WriteFile(bytes.NewReader(data), info.Path)
+		require.Nil(t, err)
+		return info, fmt.Sprintf("%x", sha256.Sum256(data))
+	}
+
+	t.Run("should remove the uploaded copy once the FileInfo is saved", func(t *testing.T) {
+		info, contentHash := newUpload(t)
+		uploadPath := info.Path
+
+		finish := th.App.deduplicateFile(info)
+		assert.Equal(t, getFileBlobPath(contentHash), info.Path)
+		exists, err := th.App.FileExists(uploadPath)
+		require.Nil(t, err)
+		assert.True(t, exists, "the uploaded copy should be kept until the FileInfo is saved")
+
+		finish(true)
+		defer th.App.RemoveFile(info.Path)
+
+		exists, err = th.App.FileExists(uploadPath)
+		require.Nil(t, err)
+		assert.False(t, exists)
+		exists, err = th.App.FileExists(info.Path)
+		require.Nil(t, err)
+		assert.True(t, exists)
+	})
+
+	t.Run("should restore the shared copy removed before the FileInfo is saved", func(t *testing.T) {
+		info, contentHash := newUpload(t)
+		uploadPath := info.Path
+
+		finish := th.App.deduplicateFile(info)
+		// another server removed the copy as unused
+		require.Nil(t, th.App.RemoveFile(getFileBlobPath(contentHash)))
+
+		finish(true)
+		defer th.App.RemoveFile(info.Path)
+
+		exists, err := th.App.FileExists(info.Path)
+		require.Nil(t, err)
+		assert.True(t, exists)
+		exists, err = th.App.FileExists(uploadPath)
+		require.Nil(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("should remove the new shared copy when the FileInfo isn't saved", func(t *testing.T) {
+		info, contentHash := newUpload(t)
+		uploadPath := info.Path
+		defer th.App.RemoveFile(uploadPath)
+
+		finish := th.App.deduplicateFile(info)
+		finish(false)
+
+		assert.Equal(t, uploadPath, info.Path)
+		assert.Empty(t, info.ContentHash)
+		exists, err := th.App.FileExists(uploadPath)
+		require.Nil(t, err)
+		assert.True(t, exists)
+		exists, err = th.App.FileExists(getFileBlobPath(contentHash))
+		require.Nil(t, err)
+		assert.False(t, exists)
+	})
+}
+
+func TestRemoveOrphanedFileBlobs(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	oldHash := fmt.Sprintf("%x", sha256.Sum256([]byte(model.NewId())))
+	newHash := fmt.Sprintf("%x", sha256.Sum256([]byte(model.NewId())))
+	for _, contentHash := range []string{oldHash, newHash} {
+		_, err := th.App.WriteFile(bytes.NewReader([]byte("blob")), getFileBlobPath(contentHash))
+		require.Nil(t, err)
+		defer th.App.RemoveFile(getFileBlobPath(contentHash))
+	}
+
+	modTime := time.Now().Add(-2 * fileBlobGracePeriod)
+	require.NoError(t, os.Chtimes(filepath.Join(*th.App.Config().FileSettings.Directory, getFileBlobPath(oldHash)), modTime, modTime))
+
+	require.Nil(t, th.App.removeOrphanedFileBlobs())
+
+	exists, err := th.App.FileExists(getFileBlobPath(oldHash))
+	require.Nil(t, err)
+	assert.False(t, exists, "unused copies should be removed")
+
+	exists, err = th.App.FileExists(getFileBlobPath(newHash))
+	require.Nil(t, err)
+	assert.True(t, exists, "copies stored within the grace period should be kept")
+
+	t.Run("should finish interrupted removals", func(t *testing.T) {
+		usedHash := fmt.Sprintf("%x", sha256.Sum256([]byte(model.NewId())))
+		unusedHash := fmt.Sprintf("%x", sha256.Sum256([]byte(model.NewId())))
+		for _, contentHash := range []string{usedHash, unusedHash} {
+			removingPath := getFileBlobPath(contentHash) + fileBlobRemovingSuffix
+			_, err := th.App.WriteFile(bytes.NewReader([]byte("blob")), removingPath)
+			require.Nil(t, err)
+			defer th.App.RemoveFile(removingPath)
+			defer th.App.RemoveFile(getFileBlobPath(contentHash))
+			require.NoError(t, os.Chtimes(filepath.Join(*th.App.Config().FileSettings.Directory, removingPath), modTime, modTime))
+		}
+
+		info, err := th.App.Srv().Store.FileInfo().Save(&model.FileInfo{
+			CreatorId:   model.NewId(),
+			Path:        getFileBlobPath(usedHash),
+			ContentHash: usedHash,
+		})
+		require.NoError(t, err)
+		defer th.App.Srv().Store.FileInfo().PermanentDelete(info.Id)
+
+		require.Nil(t, th.App.removeOrphanedFileBlobs())
+
+		exists, appErr := th.App.FileExists(getFileBlobPath(usedHash))
+		require.Nil(t, appErr)
+		assert.True(t, exists, "referenced copies should be moved back")
+
+		for _, contentHash := range []string{usedHash, unusedHash} {
+			exists, appErr = th.App.FileExists(getFileBlobPath(contentHash) + fileBlobRemovingSuffix)
+			require.Nil(t, appErr)
+			assert.False(t, exists)
+		}
+		exists, appErr = th.App.FileExists(getFileBlobPath(unusedHash))
+		require.Nil(t, appErr)
+		assert.False(t, exists, "unused copies should be removed")
+	})
+}
+
+func TestDeduplicateFiles(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	teamId := model.NewId()
+	channelId := model.NewId()
+	userId := model.NewId()
+	data := []byte(model.NewId())
+	contentHash := fmt.Sprintf("%x", sha256.Sum256(data))
+
+	startTime := model.GetMillis()
+
+	info1, err := th.App.DoUploadFile(time.Now(), teamId, channelId, userId, "file1.txt", data)
+	require.Nil(t, err)
+	defer th.App.Srv().Store.FileInfo().PermanentDelete(info1.Id)
+
+	info2, err := th.App.DoUploadFile(time.Now(), teamId, channelId, userId, "file2.txt", data)
+	require.Nil(t, err)
+	defer th.App.Srv().Store.FileInfo().PermanentDelete(info2.Id)
+	require.NotEqual(t, info1.Path, info2.Path)
+
+	t.Run("should fail when deduplication is disabled", func(t *testing.T) {
+		err := th.App.DeduplicateFiles(startTime, model.GetMillis()+1)
+		require.NotNil(t, err)
+		assert.Equal(t, "app.file.deduplicate_files.disabled.app_error", err.Id)
+	})
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.FileSettings.EnableDeduplication = true })
+
+	t.Run("should move the files to a single stored copy", func(t *testing.T) {
+		err := th.App.DeduplicateFiles(startTime, model.GetMillis()+1)
+		require.Nil(t, err)
+		defer th.App.RemoveFile(getFileBlobPath(contentHash))
+
+		for _, info := range []*model.FileInfo{info1, info2} {
+			updated, err := th.App.GetFileInfo(info.Id)
+			require.Nil(t, err)
+			assert.Equal(t, contentHash, updated.ContentHash)
+			assert.Equal(t, getFileBlobPath(contentHash), updated.Path)
+
+			exists, err := th.App.FileExists(info.Path)
+			require.Nil(t, err)
+			assert.False(t, exists, "the original copy should be removed")
+		}
+
+		stored, err := th.App.ReadFile(getFileBlobPath(contentHash))
+		require.Nil(t, err)
+		assert.Equal(t, data, stored)
+	})
+}
+
+func TestDoUploadFileVirusScan(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	clamd, err := virusscantest.NewClamd()
+	require.NoError(t, err)
+	defer clamd.Close()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.FileSettings.EnableVirusScan = true
+		*cfg.FileSettings.VirusScanDriver = model.VIRUS_SCAN_DRIVER_CLAMD
+		*cfg.FileSettings.VirusScanAddress = clamd.Address
+	})
+
+	teamId := model.NewId()
+	channelId := model.NewId()
+	userId := model.NewId()
+
+	t.Run("should accept clean files", func(t *testing.T) {
+		info, appErr := th.App.DoUploadFile(time.Now(), teamId, channelId, userId, "clean.txt", []byte("clean"))
+		require.Nil(t, appErr)
+		defer func() {
+			th.App.Srv().Store.FileInfo().PermanentDelete(info.Id)
+			th.App.RemoveFile(info.Path)
+		}()
+	})
+
+	t.Run("should reject infected files", func(t *testing.T) {
+		_, appErr := th.App.DoUploadFile(time.Now(), teamId, channelId, userId, "infected.txt", []byte(virusscantest.Malware))
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.file.virus_scan.infected.app_error", appErr.Id)
+	})
+
+	t.Run("should reject files when the scanner is unavailable", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.FileSettings.VirusScanAddress = "127.0.0.1:1" })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.FileSettings.VirusScanAddress = clamd.Address })
+
+		_, appErr := th.App.DoUploadFile(time.Now(), teamId, channelId, userId, "clean.txt", []byte("clean"))
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.file.virus_scan.failed.app_error", appErr.Id)
+	})
+}
+
+func TestScanFiles(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	icap, err := virusscantest.NewICAP()
+	require.NoError(t, err)
+	defer icap.Close()
+
+	teamId := model.NewId()
+	channelId := model.NewId()
+	userId := model.NewId()
+
+	startTime := model.GetMillis()
+
+	clean, appErr := th.App.DoUploadFile(time.Now(), teamId, channelId, userId, "clean.txt", []byte("clean"))
+	require.Nil(t, appErr)
+	defer func() {
+		th.App.Srv().Store.FileInfo().PermanentDelete(clean.Id)
+		th.App.RemoveFile(clean.Path)
+	}()
+
+	// Uploaded before the scanning was enabled.
+	infected, appErr := th.App.DoUploadFile(time.Now(), teamId, channelId, userId, "infected.txt", []byte(virusscantest.Malware))
+	require.Nil(t, appErr)
+	defer func() {
+		th.App.Srv().Store.FileInfo().PermanentDelete(infected.Id)
+		th.App.RemoveFile("quarantine/" + infected.Path)
+	}()
+
+	t.Run("should fail when scanning is disabled", func(t *testing.T) {
+		_, appErr := th.App.ScanFiles(startTime, model.GetMillis()+1)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.file.scan_files.disabled.app_error", appErr.Id)
+	})
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.FileSettings.EnableVirusScan = true
+		*cfg.FileSettings.VirusScanDriver = model.VIRUS_SCAN_DRIVER_ICAP
+		*cfg.FileSettings.VirusScanAddress = icap.Address
+	})
+
+	t.Run("should quarantine the infected files", func(t *testing.T) {
+		quarantined, appErr := th.App.ScanFiles(startTime, model.GetMillis()+1)
+		require.Nil(t, appErr)
+		assert.Equal(t, 1, quarantined)
+
+		_, appErr = th.App.GetFileInfo(clean.Id)
+		require.Nil(t, appErr)
+
+		_, appErr = th.App.GetFileInfo(infected.Id)
+		require.NotNil(t, appErr, "quarantined files should not be available")
+
+		exists, appErr := th.App.FileExists(infected.Path)
+		require.Nil(t, appErr)
+		assert.False(t, exists)
+
+		exists, appErr = th.App.FileExists("quarantine/" + infected.Path)
+		require.Nil(t, appErr)
+		assert.True(t, exists)
+	})
+}
+
+// setupFakePdftoppm configures a fake pdftoppm that renders every page as the
+// test image.
+func setupFakePdftoppm(t *testing.T, th *TestHelper) {
+	dir, err := ioutil.TempDir("", "pdftoppm")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	testDir, found := fileutils.FindDir("tests")
+	require.True(t, found)
+	script := filepath.Join(dir, "pdftoppm")
+	require.NoError(t, ioutil.WriteFile(script, []byte("#!/bin/sh\nfor last; do :; done; cp "+filepath.Join(testDir, "test.png")+" \"$last.png\"\n"), 0700))
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.FileSettings.PdftoppmPath = script })
+}
+
+func TestDoUploadFilePreviews(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	setupFakePdftoppm(t, th)
+
+	pdf, err := testutils.ReadTestFile("sample-doc.pdf")
+	require.NoError(t, err)
+
+	teamId := model.NewId()
+	channelId := model.NewId()
+	userId := model.NewId()
+
+	t.Run("should not generate previews when disabled", func(t *testing.T) {
+		info, appErr := th.App.DoUploadFile(time.Now(), teamId, channelId, userId, "document.pdf", pdf)
+		require.Nil(t, appErr)
+		defer th.App.Srv().Store.FileInfo().PermanentDelete(info.Id)
+
+		assert.False(t, info.HasPreviewImage)
+		assert.Empty(t, info.ThumbnailPath)
+	})
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.FileSettings.EnableFilePreviews = true })
+
+	t.Run("should generate the previews of documents", func(t *testing.T) {
+		info, appErr := th.App.DoUploadFile(time.Now(), teamId, channelId, userId, "document.pdf", pdf)
+		require.Nil(t, appErr)
+		defer th.App.Srv().Store.FileInfo().PermanentDelete(info.Id)
+
+		assert.True(t, info.HasPreviewImage)
+		assert.Equal(t, filepath.Dir(info.Path)+"/document_thumb.jpg", info.ThumbnailPath)
+		assert.Equal(t, filepath.Dir(info.Path)+"/document_preview.jpg", info.PreviewPath)
+
+		for _, path := range []string{info.ThumbnailPath, info.PreviewPath} {
+			exists, appErr := th.App.FileExists(path)
+			require.Nil(t, appErr)
+			assert.True(t, exists, path)
+		}
+	})
+
+	t.Run("should not generate previews of other files", func(t *testing.T) {
+		info, appErr := th.App.DoUploadFile(time.Now(), teamId, channelId, userId, "notes.txt", []byte("notes"))
+		require.Nil(t, appErr)
+		defer th.App.Srv().Store.FileInfo().PermanentDelete(info.Id)
+
+		assert.False(t, info.HasPreviewImage)
+		assert.Empty(t, info.ThumbnailPath)
+	})
+}
+
+func TestUploadFileStripImageMetadata(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	data, err := testutils.ReadTestFile("orientation_test_6.jpeg")
+	require.NoError(t, err)
+	orientation, err := getImageOrientation(bytes.NewReader(data))
+	require.NoError(t, err)
+
+	teamId := model.NewId()
+	channelId := model.NewId()
+	userId := model.NewId()
+
+	t.Run("should keep the metadata when disabled", func(t *testing.T) {
+		info, appErr := th.App.DoUploadFile(time.Now(), teamId, channelId, userId, "photo.jpeg", data)
+		require.Nil(t, appErr)
+		defer th.App.Srv().Store.FileInfo().PermanentDelete(info.Id)
+
+		assert.Empty(t, info.StrippedMetadata)
+		stored, appErr := th.App.ReadFile(info.Path)
+		require.Nil(t, appErr)
+		assert.Equal(t, data, stored)
+	})
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.FileSettings.StripImageMetadata = true })
+
+	check := func(t *testing.T, info *model.FileInfo) {
+		assert.Contains(t, info.StrippedMetadata, imgutils.MetadataExif)
+
+		stored, appErr := th.App.ReadFile(info.Path)
+		require.Nil(t, appErr)
+		assert.Equal(t, int64(len(stored)), info.Size)
+		assert.Less(t, len(stored), len(data))
+
+		storedOrientation, err := getImageOrientation(bytes.NewReader(stored))
+		require.NoError(t, err)
+		assert.Equal(t, orientation, storedOrientation)
+
+		saved, appErr := th.App.GetFileInfo(info.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, info.StrippedMetadata, saved.StrippedMetadata)
+	}
+
+	t.Run("should strip the metadata of uploaded images", func(t *testing.T) {
+		info, appErr := th.App.DoUploadFile(time.Now(), teamId, channelId, userId, "photo.jpeg", data)
+		require.Nil(t, appErr)
+		defer th.App.Srv().Store.FileInfo().PermanentDelete(info.Id)
+
+		check(t, info)
+	})
+
+	t.Run("should strip the metadata of streamed images", func(t *testing.T) {
+		info, appErr := th.App.UploadFileX(channelId, "photo.jpeg", bytes.NewReader(data),
+			UploadFileSetTeamId(teamId), UploadFileSetUserId(userId), UploadFileSetTimestamp(time.Now()))
+		require.Nil(t, appErr)
+		defer th.App.Srv().Store.FileInfo().PermanentDelete(info.Id)
+
+		check(t, info)
+	})
+
+	t.Run("should not change other files", func(t *testing.T) {
+		info, appErr := th.App.DoUploadFile(time.Now(), teamId, channelId, userId, "notes.txt", []byte("notes"))
+		require.Nil(t, appErr)
+		defer th.App.Srv().Store.FileInfo().PermanentDelete(info.Id)
+
+		assert.Empty(t, info.StrippedMetadata)
+	})
+
+	// A stray byte after the first segment, which decoders skip but which
+	// stops the metadata from being stripped.
+	firstSegmentEnd := 4 + int(binary.BigEndian.Uint16(data[4:6]))
+	malformed := append(append(append([]byte{}, data[:firstSegmentEnd]...), 0), data[firstSegmentEnd:]...)
+
+	t.Run("should reject images whose metadata can't be stripped", func(t *testing.T) {
+		_, appErr := th.App.DoUploadFile(time.Now(), teamId, channelId, userId, "photo.jpeg", malformed)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.file.strip_image_metadata.app_error", appErr.Id)
+
+		_, appErr = th.App.UploadFileX(channelId, "photo.jpeg", bytes.NewReader(malformed),
+			UploadFileSetTeamId(teamId), UploadFileSetUserId(userId), UploadFileSetTimestamp(time.Now()))
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.file.strip_image_metadata.app_error", appErr.Id)
+	})
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.FileSettings.StripMetadataFailure = model.STRIP_METADATA_FAILURE_REENCODE
+	})
+
+	t.Run("should encode again images whose metadata can't be stripped", func(t *testing.T) {
+		info, appErr := th.App.UploadFileX(channelId, "photo.jpeg", bytes.NewReader(malformed),
+			UploadFileSetTeamId(teamId), UploadFileSetUserId(userId), UploadFileSetTimestamp(time.Now()))
+		require.Nil(t, appErr)
+		defer th.App.Srv().Store.FileInfo().PermanentDelete(info.Id)
+
+		assert.Equal(t, []string{imgutils.MetadataAll}, []string(info.StrippedMetadata))
+
+		stored, appErr := th.App.ReadFile(info.Path)
+		require.Nil(t, appErr)
+		_, err := exif.Decode(bytes.NewReader(stored))
+		assert.Error(t, err, "no metadata should be left")
+
+		config, err := jpeg.DecodeConfig(bytes.NewReader(stored))
+		require.NoError(t, err)
+		assert.Equal(t, info.Width, config.Width, "the image should be upright")
+		assert.Equal(t, info.Height, config.Height, "the image should be upright")
+	})
+}
+
+// setupFakeFFmpeg configures a fake ffmpeg that converts images to WebP by
+// writing "webp" to its output file, and fails to convert them to any other
+// format.
+func setupFakeFFmpeg(t *testing.T, th *TestHelper) {
+	dir, err := ioutil.TempDir("", "ffmpeg")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	script := filepath.Join(dir, "ffmpeg")
+	require.NoError(t, ioutil.WriteFile(script, []byte("#!/bin/sh\nfor last; do :; done; case \"$last\" in *.webp) printf webp > \"$last\";; *) exit 1;; esac\n"), 0700))
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.FileSettings.FFmpegPath = script })
+}
+
+func TestFilePreviewReader(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	setupFakeFFmpeg(t, th)
+
+	preview, err := testutils.ReadTestFile("test.png")
+	require.NoError(t, err)
+	path := "previewreadertest/" + model.NewId() + "/test_preview.jpg"
+	_, appErr := th.App.WriteFile(bytes.NewReader(preview), path)
+	require.Nil(t, appErr)
+	defer th.App.RemoveFile(path)
+	defer th.App.RemoveFile(previewVariantPath(path, model.PREVIEW_FORMAT_WEBP))
+
+	read := func(t *testing.T, accept string) (string, []byte) {
+		reader, mimeType, appErr := th.App.FilePreviewReader(path, accept)
+		require.Nil(t, appErr)
+		defer reader.Close()
+		data, err := ioutil.ReadAll(reader)
+		require.NoError(t, err)
+		return mimeType, data
+	}
+
+	t.Run("should serve the JPEG when no other format is enabled", func(t *testing.T) {
+		mimeType, data := read(t, "image/webp,*/*")
+		assert.Equal(t, "image/jpeg", mimeType)
+		assert.Equal(t, preview, data)
+	})
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		cfg.FileSettings.PreviewFormats = []string{model.PREVIEW_FORMAT_WEBP, model.PREVIEW_FORMAT_AVIF}
+	})
+
+	t.Run("should serve the JPEG when the client accepts no other format", func(t *testing.T) {
+		for _, accept := range []string{"", "image/*,*/*;q=0.8", "image/webp;q=0"} {
+			mimeType, data := read(t, accept)
+			assert.Equal(t, "image/jpeg", mimeType, accept)
+			assert.Equal(t, preview, data, accept)
+		}
+	})
+
+	t.Run("should convert and cache the preview", func(t *testing.T) {
+		mimeType, data := read(t, "image/webp,image/*,*/*;q=0.8")
+		assert.Equal(t, "image/webp", mimeType)
+		assert.Equal(t, []byte("webp"), data)
+
+		cached, appErr := th.App.ReadFile(previewVariantPath(path, model.PREVIEW_FORMAT_WEBP))
+		require.Nil(t, appErr)
+		assert.Equal(t, []byte("webp"), cached)
+	})
+
+	t.Run("should fall back when conversion fails", func(t *testing.T) {
+		mimeType, data := read(t, "image/avif,image/webp")
+		assert.Equal(t, "image/webp", mimeType)
+		assert.Equal(t, []byte("webp"), data)
+
+		mimeType, data = read(t, "image/avif")
+		assert.Equal(t, "image/jpeg", mimeType)
+		assert.Equal(t, preview, data)
+	})
+
+	t.Run("should order formats by quality, then by preference", func(t *testing.T) {
+		formats := th.App.acceptedPreviewFormats("image/avif;q=0.5, image/webp;q=0.9")
+		require.Len(t, formats, 2)
+		assert.Equal(t, model.PREVIEW_FORMAT_WEBP, formats[0].format)
+		assert.Equal(t, model.PREVIEW_FORMAT_AVIF, formats[1].format)
+
+		formats = th.App.acceptedPreviewFormats("image/webp,image/avif")
+		require.Len(t, formats, 2)
+		assert.Equal(t, model.PREVIEW_FORMAT_AVIF, formats[0].format)
+	})
+}
+
+func TestGeneratePreviewVariantConcurrently(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	// A fake ffmpeg that records each conversion and takes long enough for
+	// the requests to overlap.
+	dir, err := ioutil.TempDir("", "ffmpeg")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	calls := filepath.Join(dir, "calls")
+	script := filepath.Join(dir, "ffmpeg")
+	require.NoError(t, ioutil.WriteFile(script, []byte("#!/bin/sh\necho >> "+calls+"\nsleep 0.5\nfor last; do :; done; printf webp > \"$last\"\n"), 0700))
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.FileSettings.FFmpegPath = script })
+
+	preview, err := testutils.ReadTestFile("test.png")
+	require.NoError(t, err)
+	path := "previewvarianttest/" + model.NewId() + "/test_preview.jpg"
+	_, appErr := th.App.WriteFile(bytes.NewReader(preview), path)
+	require.Nil(t, appErr)
+	defer th.App.RemoveFile(path)
+	defer th.App.RemoveFile(previewVariantPath(path, model.PREVIEW_FORMAT_WEBP))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.Nil(t, th.App.generatePreviewVariant(path, model.PREVIEW_FORMAT_WEBP))
+		}()
+	}
+	wg.Wait()
+
+	recorded, err := ioutil.ReadFile(calls)
+	require.NoError(t, err)
+	assert.Equal(t, 1, strings.Count(string(recorded), "\n"), "the preview should be converted once")
+
+	converted, appErr := th.App.ReadFile(previewVariantPath(path, model.PREVIEW_FORMAT_WEBP))
+	require.Nil(t, appErr)
+	assert.Equal(t, []byte("webp"), converted)
+}
+
+func TestPreviewPaths(t *testing.T) {
+	assert.Empty(t, previewPaths(&model.FileInfo{Path: "data/file.txt"}))
+	assert.Equal(t, []string{
+		"data/photo_thumb.jpg",
+		"data/photo_thumb.avif",
+		"data/photo_thumb.webp",
+		"data/photo_preview.jpg",
+		"data/photo_preview.avif",
+		"data/photo_preview.webp",
+	}, previewPaths(&model.FileInfo{
+		Path:          "data/photo.png",
+		ThumbnailPath: "data/photo_thumb.jpg",
+		PreviewPath:   "data/photo_preview.jpg",
+	}))
+}
+
+func TestGenerateFilePreviews(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	setupFakePdftoppm(t, th)
+
+	pdf, err := testutils.ReadTestFile("sample-doc.pdf")
+	require.NoError(t, err)
+
+	startTime := model.GetMillis()
+
+	// Uploaded before the previews were enabled.
+	info, appErr := th.App.DoUploadFile(time.Now(), model.NewId(), model.NewId(), model.NewId(), "document.pdf", pdf)
+	require.Nil(t, appErr)
+	defer th.App.Srv().Store.FileInfo().PermanentDelete(info.Id)
+	require.Empty(t, info.ThumbnailPath)
+
+	t.Run("should fail when previews are disabled", func(t *testing.T) {
+		_, appErr := th.App.GenerateFilePreviews(startTime, model.GetMillis()+1)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.file.generate_previews.disabled.app_error", appErr.Id)
+	})
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.FileSettings.EnableFilePreviews = true })
+
+	t.Run("should generate the missing previews", func(t *testing.T) {
+		generated, appErr := th.App.GenerateFilePreviews(startTime, model.GetMillis()+1)
+		require.Nil(t, appErr)
+		assert.Equal(t, 1, generated)
+
+		info, appErr := th.App.GetFileInfo(info.Id)
+		require.Nil(t, appErr)
+		assert.True(t, info.HasPreviewImage)
+		assert.NotEmpty(t, info.ThumbnailPath)
+
+		exists, appErr := th.App.FileExists(info.PreviewPath)
+		require.Nil(t, appErr)
+		assert.True(t, exists)
+	})
+
+	t.Run("should skip the files that have previews", func(t *testing.T) {
+		generated, appErr := th.App.GenerateFilePreviews(startTime, model.GetMillis()+1)
+		require.Nil(t, appErr)
+		assert.Equal(t, 0, generated)
+	})
+}
@@ -210,6 +210,11 @@ func (a *App) importLine(line LineImportData, dryRun bool) *model.AppError {
 			return model.NewAppError("BulkImport", "app.import.import_line.null_emoji.error", nil, "", http.StatusBadRequest)
 		}
 		return a.importEmoji(line.Emoji, dryRun)
+	case line.Type == "channel_bookmark":
+		if line.Bookmark == nil {
+			return model.NewAppError("BulkImport", "app.import.import_line.null_channel_bookmark.error", nil, "", http.StatusBadRequest)
+		}
+		return a.importChannelBookmark(line.Bookmark, dryRun)
 	default:
 		return model.NewAppError("BulkImport", "app.import.import_line.unknown_line_type.error", map[string]interface{}{"Type": line.Type}, "", http.StatusBadRequest)
 	}
@@ -1013,6 +1013,93 @@ func (a *App) importReaction(data *ReactionImportData, post *model.Post, dryRun
 	return nil
 }
 
+// importPoll creates the poll attached to an imported post, or updates it when
+// the post already has one, and replaces the votes of every imported voter.
+// Anonymous polls are exported without voters, so their tally is taken from
+// the exported counts instead.
+func (a *App) importPoll(data *PollImportData, post *model.Post) *model.AppError {
+	polls, nErr := a.Srv().Store.Poll().GetForPosts([]string{post.Id})
+	if nErr != nil {
+		return model.NewAppError("importPoll", "app.poll.get_for_posts.app_error", nil, nErr.Error(), http.StatusInternalServerError)
+	}
+
+	poll := &model.Poll{CreateAt: post.CreateAt}
+	if len(polls) > 0 {
+		poll = polls[0]
+	}
+
+	poll.PostId = post.Id
+	poll.ChannelId = post.ChannelId
+	poll.UserId = post.UserId
+	poll.Question = *data.Question
+	poll.Options = model.StringArray(*data.Options)
+	if data.Anonymous != nil {
+		poll.Anonymous = *data.Anonymous
+	}
+	if data.MultipleChoice != nil {
+		poll.MultipleChoice = *data.MultipleChoice
+	}
+	if data.CloseAt != nil {
+		poll.CloseAt = *data.CloseAt
+	}
+	if data.ClosedAt != nil {
+		poll.ClosedAt = *data.ClosedAt
+	}
+
+	if len(poll.Id) == 0 {
+		poll, nErr = a.Srv().Store.Poll().Save(poll)
+	} else {
+		poll, nErr = a.Srv().Store.Poll().Update(poll)
+	}
+	if nErr != nil {
+		var appErr *model.AppError
+		switch {
+		case errors.As(nErr, &appErr):
+			return appErr
+		default:
+			return model.NewAppError("importPoll", "app.poll.save.app_error", nil, nErr.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	results := poll.Results(nil, false)
+	if data.Counts != nil && len(*data.Counts) == len(poll.Options) {
+		results.Counts = *data.Counts
+	}
+
+	post.Type = model.POST_POLL
+	post.AddProp(model.POST_PROPS_POLL_ID, poll.Id)
+	post.AddProp(model.POST_PROPS_POLL_RESULTS, results)
+	if _, nErr = a.Srv().Store.Post().Overwrite(post); nErr != nil {
+		return model.NewAppError("importPoll", "app.post.overwrite.app_error", nil, nErr.Error(), http.StatusInternalServerError)
+	}
+
+	if data.Votes == nil {
+		return nil
+	}
+
+	var usernames []string
+	votesByUsername := map[string][]*model.PollVote{}
+	for _, vote := range *data.Votes {
+		if _, ok := votesByUsername[*vote.User]; !ok {
+			usernames = append(usernames, *vote.User)
+		}
+		votesByUsername[*vote.User] = append(votesByUsername[*vote.User], &model.PollVote{OptionIndex: *vote.Option})
+	}
+
+	for _, username := range usernames {
+		user, nErr := a.Srv().Store.User().GetByUsername(username)
+		if nErr != nil {
+			return model.NewAppError("BulkImport", "app.import.import_poll.user_not_found.error", map[string]interface{}{"Username": username}, nErr.Error(), http.StatusBadRequest)
+		}
+
+		if _, nErr := a.Srv().Store.Poll().SaveVotes(poll.Id, user.Id, votesByUsername[username]); nErr != nil {
+			return model.NewAppError("importPoll", "app.poll.save_votes.app_error", nil, nErr.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return nil
+}
+
 func (a *App) importReplies(data []ReplyImportData, post *model.Post, teamId string, dryRun bool) *model.AppError {
 	var err *model.AppError
 	usernames := []string{}
@@ -1067,7 +1154,7 @@ func (a *App) importReplies(data []ReplyImportData, post *model.Post, teamId str
 		}
 		for _, fileID := range reply.FileIds {
 			if _, ok := fileIds[fileID]; !ok {
-				a.Srv().Store.FileInfo().PermanentDelete(fileID)
+				a.permanentDeleteFileInfo(fileID)
 			}
 		}
 		reply.FileIds = make([]string, 0)
@@ -1318,7 +1405,7 @@ func (a *App) importMultiplePostLines(lines []LineImportWorkerData, dryRun bool)
 		}
 		for _, fileID := range post.FileIds {
 			if _, ok := fileIds[fileID]; !ok {
-				a.Srv().Store.FileInfo().PermanentDelete(fileID)
+				a.permanentDeleteFileInfo(fileID)
 			}
 		}
 		post.FileIds = make([]string, 0)
@@ -1408,6 +1495,12 @@ func (a *App) importMultiplePostLines(lines []LineImportWorkerData, dryRun bool)
 				return postWithData.lineNumber, err
 			}
 		}
+
+		if postWithData.postData.Poll != nil {
+			if err := a.importPoll(postWithData.postData.Poll, postWithData.post); err != nil {
+				return postWithData.lineNumber, err
+			}
+		}
 		a.updateFileInfoWithPostId(postWithData.post)
 	}
 	return 0, nil
@@ -1614,7 +1707,7 @@ func (a *App) importMultipleDirectPostLines(lines []LineImportWorkerData, dryRun
 		}
 		for _, fileID := range post.FileIds {
 			if _, ok := fileIds[fileID]; !ok {
-				a.Srv().Store.FileInfo().PermanentDelete(fileID)
+				a.permanentDeleteFileInfo(fileID)
 			}
 		}
 		post.FileIds = make([]string, 0)
@@ -1702,6 +1795,12 @@ func (a *App) importMultipleDirectPostLines(lines []LineImportWorkerData, dryRun
 			}
 		}
 
+		if postWithData.directPostData.Poll != nil {
+			if err := a.importPoll(postWithData.directPostData.Poll, postWithData.post); err != nil {
+				return postWithData.lineNumber, err
+			}
+		}
+
 		a.updateFileInfoWithPostId(postWithData.post)
 	}
 	return 0, nil
@@ -1753,3 +1852,118 @@ func (a *App) importEmoji(data *EmojiImportData, dryRun bool) *model.AppError {
 
 	return nil
 }
+
+func (a *App) importChannelBookmark(data *ChannelBookmarkImportData, dryRun bool) *model.AppError {
+	if err := validateChannelBookmarkImportData(data); err != nil {
+		return err
+	}
+
+	// If this is a Dry Run, do not continue any further.
+	if dryRun {
+		return nil
+	}
+
+	team, err := a.Srv().Store.Team().GetByName(*data.Team)
+	if err != nil {
+		return model.NewAppError("BulkImport", "app.import.import_channel_bookmark.team_not_found.error", map[string]interface{}{"TeamName": *data.Team}, err.Error(), http.StatusBadRequest)
+	}
+
+	channel, err := a.Srv().Store.Channel().GetByName(team.Id, *data.Channel, false)
+	if err != nil {
+		return model.NewAppError("BulkImport", "app.import.import_channel_bookmark.channel_not_found.error", map[string]interface{}{"ChannelName": *data.Channel}, err.Error(), http.StatusBadRequest)
+	}
+
+	owner, err := a.Srv().Store.User().GetByUsername(*data.Owner)
+	if err != nil {
+		return model.NewAppError("BulkImport", "app.import.import_channel_bookmark.owner_not_found.error", map[string]interface{}{"Username": *data.Owner}, err.Error(), http.StatusBadRequest)
+	}
+
+	existingBookmarks, err := a.Srv().Store.ChannelBookmark().GetBookmarksForChannel(channel.Id)
+	if err != nil {
+		return model.NewAppError("BulkImport", "app.channel_bookmark.get_for_channel.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	// A bookmark with the same name and creation time is taken to be one that
+	// was imported before.
+	var bookmark *model.ChannelBookmark
+	for _, existingBookmark := range existingBookmarks {
+		if existingBookmark.DisplayName == *data.DisplayName && data.CreateAt != nil && existingBookmark.CreateAt == *data.CreateAt {
+			bookmark = existingBookmark
+			break
+		}
+	}
+
+	alreadyExists := bookmark != nil
+	if !alreadyExists {
+		bookmark = &model.ChannelBookmark{
+			ChannelId:   channel.Id,
+			DisplayName: *data.DisplayName,
+		}
+		if data.CreateAt != nil {
+			bookmark.CreateAt = *data.CreateAt
+		}
+	}
+
+	bookmark.OwnerId = owner.Id
+	bookmark.Type = *data.Type
+	bookmark.LinkUrl = ""
+	if data.LinkUrl != nil {
+		bookmark.LinkUrl = *data.LinkUrl
+	}
+	bookmark.ImageUrl = ""
+	if data.ImageUrl != nil {
+		bookmark.ImageUrl = *data.ImageUrl
+	}
+	bookmark.Emoji = ""
+	if data.Emoji != nil {
+		bookmark.Emoji = *data.Emoji
+	}
+
+	if bookmark.Type == model.CHANNEL_BOOKMARK_FILE && (!alreadyExists || bookmark.FileId == "") {
+		file, err := os.Open(*data.File.Path)
+		if err != nil {
+			return model.NewAppError("BulkImport", "app.import.attachment.bad_file.error", map[string]interface{}{"FilePath": *data.File.Path}, "", http.StatusBadRequest)
+		}
+		defer file.Close()
+
+		buf := bytes.NewBuffer(nil)
+		_, _ = io.Copy(buf, file)
+
+		createAt := bookmark.CreateAt
+		if createAt == 0 {
+			createAt = model.GetMillis()
+		}
+
+		fileInfo, appErr := a.DoUploadFile(utils.TimeFromMillis(createAt), team.Id, channel.Id, owner.Id, file.Name(), buf.Bytes())
+		if appErr != nil {
+			return appErr
+		}
+		a.HandleImages([]string{fileInfo.PreviewPath}, []string{fileInfo.ThumbnailPath}, [][]byte{buf.Bytes()})
+
+		bookmark.FileId = fileInfo.Id
+	} else if bookmark.Type == model.CHANNEL_BOOKMARK_LINK {
+		bookmark.FileId = ""
+	}
+
+	if !alreadyExists {
+		if bookmark, err = a.Srv().Store.ChannelBookmark().Save(bookmark); err != nil {
+			return model.NewAppError("BulkImport", "app.channel_bookmark.save.app_error", nil, err.Error(), http.StatusBadRequest)
+		}
+
+		if data.SortOrder == nil || bookmark.SortOrder == *data.SortOrder {
+			return nil
+		}
+	}
+
+	// Bookmark lines may be imported out of order, so the exported sort order
+	// is kept as is rather than recomputed.
+	if data.SortOrder != nil {
+		bookmark.SortOrder = *data.SortOrder
+	}
+
+	if err := a.Srv().Store.ChannelBookmark().Update(bookmark); err != nil {
+		return model.NewAppError("BulkImport", "app.channel_bookmark.update.app_error", nil, err.Error(), http.StatusBadRequest)
+	}
+
+	return nil
+}
@@ -8,16 +8,17 @@ import "github.com/zacmm/zacmm-server/model"
 // Import Data Models
 
 type LineImportData struct {
-	Type          string                   `json:"type"`
-	Scheme        *SchemeImportData        `json:"scheme,omitempty"`
-	Team          *TeamImportData          `json:"team,omitempty"`
-	Channel       *ChannelImportData       `json:"channel,omitempty"`
-	User          *UserImportData          `json:"user,omitempty"`
-	Post          *PostImportData          `json:"post,omitempty"`
-	DirectChannel *DirectChannelImportData `json:"direct_channel,omitempty"`
-	DirectPost    *DirectPostImportData    `json:"direct_post,omitempty"`
-	Emoji         *EmojiImportData         `json:"emoji,omitempty"`
-	Version       *int                     `json:"version,omitempty"`
+	Type          string                     `json:"type"`
+	Scheme        *SchemeImportData          `json:"scheme,omitempty"`
+	Team          *TeamImportData            `json:"team,omitempty"`
+	Channel       *ChannelImportData         `json:"channel,omitempty"`
+	User          *UserImportData            `json:"user,omitempty"`
+	Post          *PostImportData            `json:"post,omitempty"`
+	DirectChannel *DirectChannelImportData   `json:"direct_channel,omitempty"`
+	DirectPost    *DirectPostImportData      `json:"direct_post,omitempty"`
+	Emoji         *EmojiImportData           `json:"emoji,omitempty"`
+	Bookmark      *ChannelBookmarkImportData `json:"channel_bookmark,omitempty"`
+	Version       *int                       `json:"version,omitempty"`
 }
 
 type TeamImportData struct {
@@ -139,6 +140,26 @@ type PostImportData struct {
 	Reactions   *[]ReactionImportData   `json:"reactions,omitempty"`
 	Replies     *[]ReplyImportData      `json:"replies,omitempty"`
 	Attachments *[]AttachmentImportData `json:"attachments,omitempty"`
+	Poll        *PollImportData         `json:"poll,omitempty"`
+}
+
+// PollImportData holds the poll attached to a post. Voters are left out for
+// anonymous polls, in which case the imported tally comes from Counts.
+type PollImportData struct {
+	Question       *string               `json:"question"`
+	Options        *[]string             `json:"options"`
+	Anonymous      *bool                 `json:"anonymous"`
+	MultipleChoice *bool                 `json:"multiple_choice"`
+	CloseAt        *int64                `json:"close_at"`
+	ClosedAt       *int64                `json:"closed_at"`
+	Counts         *[]int                `json:"counts"`
+	Votes          *[]PollVoteImportData `json:"votes,omitempty"`
+}
+
+type PollVoteImportData struct {
+	User     *string `json:"user"`
+	Option   *int    `json:"option"`
+	CreateAt *int64  `json:"create_at"`
 }
 
 type DirectChannelImportData struct {
@@ -160,6 +181,7 @@ type DirectPostImportData struct {
 	Reactions   *[]ReactionImportData   `json:"reactions"`
 	Replies     *[]ReplyImportData      `json:"replies"`
 	Attachments *[]AttachmentImportData `json:"attachments"`
+	Poll        *PollImportData         `json:"poll,omitempty"`
 }
 
 type SchemeImportData struct {
@@ -192,6 +214,21 @@ type LineImportWorkerError struct {
 	LineNumber int
 }
 
+type ChannelBookmarkImportData struct {
+	Team    *string `json:"team"`
+	Channel *string `json:"channel"`
+	Owner   *string `json:"owner"`
+
+	DisplayName *string               `json:"display_name"`
+	Type        *string               `json:"type"`
+	LinkUrl     *string               `json:"link_url,omitempty"`
+	ImageUrl    *string               `json:"image_url,omitempty"`
+	Emoji       *string               `json:"emoji,omitempty"`
+	SortOrder   *int64                `json:"sort_order,omitempty"`
+	CreateAt    *int64                `json:"create_at"`
+	File        *AttachmentImportData `json:"file,omitempty"`
+}
+
 type AttachmentImportData struct {
 	Path *string `json:"path"`
 }
@@ -461,6 +461,36 @@ func validatePostImportData(data *PostImportData, maxPostSize int) *model.AppErr
 		return model.NewAppError("BulkImport", "app.import.validate_post_import_data.props_too_large.error", nil, "", http.StatusBadRequest)
 	}
 
+	if data.Poll != nil {
+		if err := validatePollImportData(data.Poll); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validatePollImportData(data *PollImportData) *model.AppError {
+	if data.Question == nil {
+		return model.NewAppError("BulkImport", "app.import.validate_poll_import_data.question_missing.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.Options == nil {
+		return model.NewAppError("BulkImport", "app.import.validate_poll_import_data.options_missing.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.Votes != nil {
+		for _, vote := range *data.Votes {
+			if vote.User == nil {
+				return model.NewAppError("BulkImport", "app.import.validate_poll_import_data.vote_user_missing.error", nil, "", http.StatusBadRequest)
+			}
+
+			if vote.Option == nil || *vote.Option < 0 || *vote.Option >= len(*data.Options) {
+				return model.NewAppError("BulkImport", "app.import.validate_poll_import_data.vote_option_invalid.error", nil, "", http.StatusBadRequest)
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -557,6 +587,12 @@ func validateDirectPostImportData(data *DirectPostImportData, maxPostSize int) *
 		}
 	}
 
+	if data.Poll != nil {
+		if err := validatePollImportData(data.Poll); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -579,3 +615,46 @@ func validateEmojiImportData(data *EmojiImportData) *model.AppError {
 
 	return nil
 }
+
+func validateChannelBookmarkImportData(data *ChannelBookmarkImportData) *model.AppError {
+	if data.Team == nil {
+		return model.NewAppError("BulkImport", "app.import.validate_channel_bookmark_import_data.team_missing.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.Channel == nil {
+		return model.NewAppError("BulkImport", "app.import.validate_channel_bookmark_import_data.channel_missing.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.Owner == nil {
+		return model.NewAppError("BulkImport", "app.import.validate_channel_bookmark_import_data.owner_missing.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.DisplayName == nil || *data.DisplayName == "" {
+		return model.NewAppError("BulkImport", "app.import.validate_channel_bookmark_import_data.display_name_missing.error", nil, "", http.StatusBadRequest)
+	} else if utf8.RuneCountInString(*data.DisplayName) > model.CHANNEL_BOOKMARK_DISPLAY_NAME_MAX_RUNES {
+		return model.NewAppError("BulkImport", "app.import.validate_channel_bookmark_import_data.display_name_length.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.Type == nil {
+		return model.NewAppError("BulkImport", "app.import.validate_channel_bookmark_import_data.type_missing.error", nil, "", http.StatusBadRequest)
+	}
+
+	switch *data.Type {
+	case model.CHANNEL_BOOKMARK_LINK:
+		if data.LinkUrl == nil || !model.IsValidHttpUrl(*data.LinkUrl) {
+			return model.NewAppError("BulkImport", "app.import.validate_channel_bookmark_import_data.link_url_invalid.error", nil, "", http.StatusBadRequest)
+		}
+	case model.CHANNEL_BOOKMARK_FILE:
+		if data.File == nil || data.File.Path == nil || *data.File.Path == "" {
+			return model.NewAppError("BulkImport", "app.import.validate_channel_bookmark_import_data.file_missing.error", nil, "", http.StatusBadRequest)
+		}
+	default:
+		return model.NewAppError("BulkImport", "app.import.validate_channel_bookmark_import_data.type_invalid.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.CreateAt != nil && *data.CreateAt == 0 {
+		return model.NewAppError("BulkImport", "app.import.validate_channel_bookmark_import_data.create_at_zero.error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
@@ -892,6 +892,53 @@ func TestImportValidateReplyImportData(t *testing.T) {
 	require.NotNil(t, err, "Should have failed due parent with newer create-at value.")
 }
 
+func TestImportValidatePollImportData(t *testing.T) {
+	// Test with minimum required valid properties.
+	data := PollImportData{
+		Question: ptrStr("Lunch?"),
+		Options:  &[]string{"Pizza", "Sushi"},
+	}
+	err := validatePollImportData(&data)
+	require.Nil(t, err, "Validation failed but should have been valid.")
+
+	// Test with missing required properties.
+	data = PollImportData{
+		Options: &[]string{"Pizza", "Sushi"},
+	}
+	err = validatePollImportData(&data)
+	require.NotNil(t, err, "Should have failed due to missing required property.")
+
+	data = PollImportData{
+		Question: ptrStr("Lunch?"),
+	}
+	err = validatePollImportData(&data)
+	require.NotNil(t, err, "Should have failed due to missing required property.")
+
+	// Test with valid votes.
+	data = PollImportData{
+		Question: ptrStr("Lunch?"),
+		Options:  &[]string{"Pizza", "Sushi"},
+		Votes: &[]PollVoteImportData{
+			{User: ptrStr("username"), Option: ptrInt(1), CreateAt: ptrInt64(model.GetMillis())},
+		},
+	}
+	err = validatePollImportData(&data)
+	require.Nil(t, err, "Validation failed but should have been valid.")
+
+	// Test with invalid votes.
+	data.Votes = &[]PollVoteImportData{{Option: ptrInt(1)}}
+	err = validatePollImportData(&data)
+	require.NotNil(t, err, "Should have failed due to missing vote user.")
+
+	data.Votes = &[]PollVoteImportData{{User: ptrStr("username")}}
+	err = validatePollImportData(&data)
+	require.NotNil(t, err, "Should have failed due to missing vote option.")
+
+	data.Votes = &[]PollVoteImportData{{User: ptrStr("username"), Option: ptrInt(2)}}
+	err = validatePollImportData(&data)
+	require.NotNil(t, err, "Should have failed due to out of range vote option.")
+}
+
 func TestImportValidatePostImportData(t *testing.T) {
 	maxPostSize := 10000
 
@@ -1371,3 +1418,54 @@ func TestImportValidateEmojiImportData(t *testing.T) {
 	err = validateEmojiImportData(&data)
 	assert.NotNil(t, err)
 }
+
+func TestImportValidateChannelBookmarkImportData(t *testing.T) {
+	data := ChannelBookmarkImportData{
+		Team:        ptrStr("teamname"),
+		Channel:     ptrStr("channelname"),
+		Owner:       ptrStr("username"),
+		DisplayName: ptrStr("Docs"),
+		Type:        ptrStr(model.CHANNEL_BOOKMARK_LINK),
+		LinkUrl:     ptrStr("https://example.com"),
+		CreateAt:    ptrInt64(model.GetMillis()),
+	}
+
+	err := validateChannelBookmarkImportData(&data)
+	assert.Nil(t, err, "Validation should succeed")
+
+	data.Team = nil
+	err = validateChannelBookmarkImportData(&data)
+	assert.NotNil(t, err)
+	data.Team = ptrStr("teamname")
+
+	data.Owner = nil
+	err = validateChannelBookmarkImportData(&data)
+	assert.NotNil(t, err)
+	data.Owner = ptrStr("username")
+
+	*data.DisplayName = strings.Repeat("a", model.CHANNEL_BOOKMARK_DISPLAY_NAME_MAX_RUNES+1)
+	err = validateChannelBookmarkImportData(&data)
+	assert.NotNil(t, err)
+	*data.DisplayName = "Docs"
+
+	*data.LinkUrl = "not a url"
+	err = validateChannelBookmarkImportData(&data)
+	assert.NotNil(t, err)
+
+	*data.Type = model.CHANNEL_BOOKMARK_FILE
+	err = validateChannelBookmarkImportData(&data)
+	assert.NotNil(t, err, "File bookmarks require a file")
+
+	data.File = &AttachmentImportData{Path: ptrStr("/path/to/file")}
+	err = validateChannelBookmarkImportData(&data)
+	assert.Nil(t, err, "Validation should succeed")
+
+	*data.Type = "unknown"
+	err = validateChannelBookmarkImportData(&data)
+	assert.NotNil(t, err)
+	*data.Type = model.CHANNEL_BOOKMARK_FILE
+
+	*data.CreateAt = 0
+	err = validateChannelBookmarkImportData(&data)
+	assert.NotNil(t, err)
+}
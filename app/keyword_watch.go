@@ -0,0 +1,280 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"errors"
+	"net/http"
+	"regexp"
+	"sync"
+
+	"github.com/zacmm/zacmm-server/mlog"
+	"github.com/zacmm/zacmm-server/model"
+	"github.com/zacmm/zacmm-server/store"
+	"github.com/zacmm/zacmm-server/utils"
+)
+
+// keywordWatchMatcherCacheSize bounds the number of compiled keyword watch
+// patterns kept in memory.
+const keywordWatchMatcherCacheSize = 10000
+
+// keywordWatchMatcherCache keeps the compiled matchers of keyword watches,
+// since every post in a public channel is checked against all the watches of
+// its team. Matchers are keyed by pattern, so that updated watches don't get
+// stale matchers.
+type keywordWatchMatcherCache struct {
+	mutex    sync.Mutex
+	matchers map[string]*regexp.Regexp
+}
+
+func newKeywordWatchMatcherCache() *keywordWatchMatcherCache {
+	return &keywordWatchMatcherCache{
+		matchers: make(map[string]*regexp.Regexp),
+	}
+}
+
+func (c *keywordWatchMatcherCache) get(watch *model.KeywordWatch) (*regexp.Regexp, error) {
+	key := "keyword:" + watch.Pattern
+	if watch.IsRegex {
+		key = "regex:" + watch.Pattern
+	}
+
+	c.mutex.Lock()
+	matcher, ok := c.matchers[key]
+	c.mutex.Unlock()
+	if ok {
+		return matcher, nil
+	}
+
+	matcher, err := watch.Matcher()
+	if err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if len(c.matchers) >= keywordWatchMatcherCacheSize {
+		c.matchers = make(map[string]*regexp.Regexp)
+	}
+	c.matchers[key] = matcher
+
+	return matcher, nil
+}
+
+func (a *App) GetKeywordWatch(watchId string) (*model.KeywordWatch, *model.AppError) {
+	watch, err := a.Srv().Store.KeywordWatch().Get(watchId)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("GetKeywordWatch", "app.keyword_watch.get.app_error", nil, nfErr.Error(), http.StatusNotFound)
+		default:
+			return nil, model.NewAppError("GetKeywordWatch", "app.keyword_watch.get.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return watch, nil
+}
+
+func (a *App) GetKeywordWatchesForUser(userId string) ([]*model.KeywordWatch, *model.AppError) {
+	watches, err := a.Srv().Store.KeywordWatch().GetForUser(userId)
+	if err != nil {
+		return nil, model.NewAppError("GetKeywordWatchesForUser", "app.keyword_watch.get_for_user.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return watches, nil
+}
+
+// CreateKeywordWatch saves a new keyword watch for a user, who must be a
+// member of the team that the watch covers.
+func (a *App) CreateKeywordWatch(watch *model.KeywordWatch) (*model.KeywordWatch, *model.AppError) {
+	if member, appErr := a.GetTeamMember(watch.TeamId, watch.UserId); appErr != nil || member.DeleteAt != 0 {
+		return nil, model.NewAppError("CreateKeywordWatch", "app.keyword_watch.create.not_team_member.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	watches, appErr := a.GetKeywordWatchesForUser(watch.UserId)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if len(watches) >= *a.Config().ServiceSettings.MaxKeywordWatchesPerUser {
+		return nil, model.NewAppError("CreateKeywordWatch", "app.keyword_watch.create.limit.app_error", map[string]interface{}{"Max": *a.Config().ServiceSettings.MaxKeywordWatchesPerUser}, "", http.StatusBadRequest)
+	}
+
+	watch.LastAlertAt = 0
+	savedWatch, err := a.Srv().Store.KeywordWatch().Save(watch)
+	if err != nil {
+		var appErr *model.AppError
+		var invErr *store.ErrInvalidInput
+		switch {
+		case errors.As(err, &appErr):
+			return nil, appErr
+		case errors.As(err, &invErr):
+			return nil, model.NewAppError("CreateKeywordWatch", "app.keyword_watch.save.existing.app_error", nil, invErr.Error(), http.StatusBadRequest)
+		default:
+			return nil, model.NewAppError("CreateKeywordWatch", "app.keyword_watch.save.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return savedWatch, nil
+}
+
+// UpdateKeywordWatch changes the pattern and the delivery of an existing
+// keyword watch. Its owner and team can't be changed.
+func (a *App) UpdateKeywordWatch(watch *model.KeywordWatch) (*model.KeywordWatch, *model.AppError) {
+	oldWatch, appErr := a.GetKeywordWatch(watch.Id)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	oldWatch.Pattern = watch.Pattern
+	oldWatch.IsRegex = watch.IsRegex
+	oldWatch.Delivery = watch.Delivery
+
+	updatedWatch, err := a.Srv().Store.KeywordWatch().Update(oldWatch)
+	if err != nil {
+		var appErr *model.AppError
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &appErr):
+			return nil, appErr
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("UpdateKeywordWatch", "app.keyword_watch.update.app_error", nil, nfErr.Error(), http.StatusNotFound)
+		default:
+			return nil, model.NewAppError("UpdateKeywordWatch", "app.keyword_watch.update.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return updatedWatch, nil
+}
+
+func (a *App) DeleteKeywordWatch(watchId string) *model.AppError {
+	if err := a.Srv().Store.KeywordWatch().Delete(watchId); err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return model.NewAppError("DeleteKeywordWatch", "app.keyword_watch.delete.app_error", nil, nfErr.Error(), http.StatusNotFound)
+		default:
+			return model.NewAppError("DeleteKeywordWatch", "app.keyword_watch.delete.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return nil
+}
+
+// checkKeywordWatches alerts the users whose keyword watches on the team
+// match a post in one of its public channels. Users who were mentioned by the
+// post already get a notification for it, and each user gets at most one
+// alert per post.
+func (a *App) checkKeywordWatches(post *model.Post, channel *model.Channel, team *model.Team, mentionedUserIds map[string]MentionType) {
+	watches, err := a.Srv().Store.KeywordWatch().GetForTeam(team.Id)
+	if err != nil {
+		mlog.Warn("Unable to get keyword watches", mlog.String("team_id", team.Id), mlog.Err(err))
+		return
+	}
+
+	alertedUserIds := make(map[string]bool)
+	for _, watch := range watches {
+		if watch.UserId == post.UserId || alertedUserIds[watch.UserId] {
+			continue
+		}
+
+		if _, ok := mentionedUserIds[watch.UserId]; ok {
+			continue
+		}
+
+		matcher, err := a.Srv().keywordWatchMatchers.get(watch)
+		if err != nil || !matcher.MatchString(post.Message) {
+			continue
+		}
+
+		alerted, appErr := a.sendKeywordWatchAlert(watch, post, channel, team)
+		if appErr != nil {
+			mlog.Warn("Unable to send keyword watch alert", mlog.String("watch_id", watch.Id), mlog.String("post_id", post.Id), mlog.Err(appErr))
+			continue
+		}
+		alertedUserIds[watch.UserId] = alerted
+	}
+}
+
+// sendKeywordWatchAlert tells the owner of the watch about the post, either
+// right away or in their next email digest, and reports whether it did. The
+// alerts of a watch are sent at most once per cooldown so that a busy
+// channel can't flood its owner.
+func (a *App) sendKeywordWatchAlert(watch *model.KeywordWatch, post *model.Post, channel *model.Channel, team *model.Team) (bool, *model.AppError) {
+	user, appErr := a.GetUser(watch.UserId)
+	if appErr != nil {
+		return false, appErr
+	}
+
+	if user.DeleteAt != 0 {
+		return false, nil
+	}
+
+	// The user can only read the public channels of the teams they belong to.
+	if member, appErr := a.GetTeamMember(team.Id, user.Id); appErr != nil || member.DeleteAt != 0 {
+		return false, nil
+	}
+
+	now := model.GetMillis()
+	cooldown := int64(*a.Config().ServiceSettings.KeywordWatchAlertCooldownSeconds) * 1000
+	updated, err := a.Srv().Store.KeywordWatch().UpdateLastAlertAt(watch.Id, now, now-cooldown)
+	if err != nil {
+		return false, model.NewAppError("sendKeywordWatchAlert", "app.keyword_watch.update.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	if !updated {
+		return false, nil
+	}
+
+	// Email digests only include the posts of channels that the user is a
+	// member of, so the alerts about other channels are sent as messages.
+	if watch.Delivery == model.KEYWORD_WATCH_DELIVERY_DIGEST {
+		if _, err := a.Srv().Store.Channel().GetMember(channel.Id, user.Id); err == nil {
+			if appErr := a.Srv().EmailService.AddNotificationEmailToBatch(user, post, team); appErr != nil {
+				return false, appErr
+			}
+			return true, nil
+		}
+	}
+
+	return true, a.postKeywordWatchAlert(watch, user, post, channel, team)
+}
+
+// postKeywordWatchAlert sends the alert as a direct message from the keyword
+// watch bot, which also notifies the user through their usual channels.
+func (a *App) postKeywordWatchAlert(watch *model.KeywordWatch, user *model.User, post *model.Post, channel *model.Channel, team *model.Team) *model.AppError {
+	T := utils.GetUserTranslations(user.Locale)
+
+	bot, appErr := a.getOrCreateBotByOwner(&model.Bot{
+		Username:    model.BOT_KEYWORD_WATCH_USERNAME,
+		DisplayName: T("app.keyword_watch.bot_displayname"),
+		Description: T("app.keyword_watch.bot_description"),
+		OwnerId:     model.BOT_KEYWORD_WATCH_USERNAME,
+	})
+	if appErr != nil {
+		return appErr
+	}
+
+	dm, appErr := a.GetOrCreateDirectChannel(bot.UserId, user.Id)
+	if appErr != nil {
+		return appErr
+	}
+
+	alert := &model.Post{
+		UserId:    bot.UserId,
+		ChannelId: dm.Id,
+		Message: T("app.keyword_watch.alert", map[string]interface{}{
+			"Pattern":     watch.Pattern,
+			"ChannelName": channel.DisplayName,
+			"Link":        a.GetSiteURL() + "/" + team.Name + "/pl/" + post.Id,
+		}),
+	}
+
+	if _, appErr := a.CreatePost(alert, dm, false, false); appErr != nil {
+		return appErr
+	}
+
+	return nil
+}
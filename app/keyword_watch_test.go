@@ -0,0 +1,151 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zacmm/zacmm-server/model"
+)
+
+func TestCreateKeywordWatch(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	watch, err := th.App.CreateKeywordWatch(&model.KeywordWatch{UserId: th.BasicUser.Id, TeamId: th.BasicTeam.Id, Pattern: "outage"})
+	require.Nil(t, err)
+	assert.NotEmpty(t, watch.Id)
+
+	t.Run("not a team member", func(t *testing.T) {
+		_, err := th.App.CreateKeywordWatch(&model.KeywordWatch{UserId: th.BasicUser.Id, TeamId: th.CreateTeam().Id, Pattern: "outage"})
+		require.NotNil(t, err)
+		assert.Equal(t, "app.keyword_watch.create.not_team_member.app_error", err.Id)
+	})
+
+	t.Run("limit reached", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.MaxKeywordWatchesPerUser = 1 })
+
+		_, err := th.App.CreateKeywordWatch(&model.KeywordWatch{UserId: th.BasicUser.Id, TeamId: th.BasicTeam.Id, Pattern: "incident"})
+		require.NotNil(t, err)
+		assert.Equal(t, "app.keyword_watch.create.limit.app_error", err.Id)
+	})
+}
+
+func TestCheckKeywordWatches(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	// The watches are checked directly below, rather than when the posts
+	// are created, so EnableKeywordWatches stays off.
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.KeywordWatchAlertCooldownSeconds = 300 })
+
+	_, err := th.App.CreateKeywordWatch(&model.KeywordWatch{UserId: th.BasicUser2.Id, TeamId: th.BasicTeam.Id, Pattern: "outage"})
+	require.Nil(t, err)
+
+	getAlerts := func(t *testing.T) []*model.Post {
+		bot, err := th.App.GetUserByUsername(model.BOT_KEYWORD_WATCH_USERNAME)
+		if err != nil {
+			return nil
+		}
+
+		dm, err := th.App.GetOrCreateDirectChannel(bot.Id, th.BasicUser2.Id)
+		require.Nil(t, err)
+
+		posts, err := th.App.GetPosts(dm.Id, 0, 10)
+		require.Nil(t, err)
+		return posts.ToSlice()
+	}
+
+	post := th.CreateMessagePost(th.BasicChannel, "No outages today")
+	th.App.checkKeywordWatches(post, th.BasicChannel, th.BasicTeam, nil)
+	assert.Empty(t, getAlerts(t))
+
+	post = th.CreateMessagePost(th.BasicChannel, "We have an outage")
+	th.App.checkKeywordWatches(post, th.BasicChannel, th.BasicTeam, map[string]MentionType{th.BasicUser2.Id: KeywordMention})
+	assert.Empty(t, getAlerts(t), "mentioned users are notified already")
+
+	th.App.checkKeywordWatches(post, th.BasicChannel, th.BasicTeam, nil)
+	alerts := getAlerts(t)
+	require.Len(t, alerts, 1)
+	assert.Contains(t, alerts[0].Message, post.Id)
+
+	post = th.CreateMessagePost(th.BasicChannel, "The outage is getting worse")
+	th.App.checkKeywordWatches(post, th.BasicChannel, th.BasicTeam, nil)
+	assert.Len(t, getAlerts(t), 1, "alerts within the cooldown are dropped")
+}
+
+func TestCheckKeywordWatchesDigest(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.KeywordWatchAlertCooldownSeconds = 300
+		*cfg.EmailSettings.EnableEmailBatching = true
+	})
+
+	watch, err := th.App.CreateKeywordWatch(&model.KeywordWatch{UserId: th.BasicUser2.Id, TeamId: th.BasicTeam.Id, Pattern: "outage", Delivery: model.KEYWORD_WATCH_DELIVERY_DIGEST})
+	require.Nil(t, err)
+
+	getPending := func(t *testing.T) []*model.PendingEmailNotification {
+		notifications, err := th.App.Srv().Store.EmailDigest().GetForUser(th.BasicUser2.Id)
+		require.NoError(t, err)
+		return notifications
+	}
+
+	t.Run("should add posts of joined channels to the digest once per cooldown", func(t *testing.T) {
+		th.AddUserToChannel(th.BasicUser2, th.BasicChannel)
+
+		post := th.CreateMessagePost(th.BasicChannel, "We have an outage")
+		th.App.checkKeywordWatches(post, th.BasicChannel, th.BasicTeam, nil)
+		pending := getPending(t)
+		require.Len(t, pending, 1)
+		assert.Equal(t, post.Id, pending[0].PostId)
+
+		post = th.CreateMessagePost(th.BasicChannel, "The outage is getting worse")
+		th.App.checkKeywordWatches(post, th.BasicChannel, th.BasicTeam, nil)
+		assert.Len(t, getPending(t), 1, "alerts within the cooldown are dropped")
+	})
+
+	t.Run("should send a message about posts of other channels", func(t *testing.T) {
+		_, err := th.App.Srv().Store.KeywordWatch().UpdateLastAlertAt(watch.Id, 0, model.GetMillis())
+		require.NoError(t, err)
+
+		channel := th.CreateChannel(th.BasicTeam)
+		post := th.CreateMessagePost(channel, "Another outage")
+		th.App.checkKeywordWatches(post, channel, th.BasicTeam, nil)
+		assert.Len(t, getPending(t), 1)
+
+		bot, appErr := th.App.GetUserByUsername(model.BOT_KEYWORD_WATCH_USERNAME)
+		require.Nil(t, appErr)
+		dm, appErr := th.App.GetOrCreateDirectChannel(bot.Id, th.BasicUser2.Id)
+		require.Nil(t, appErr)
+		posts, appErr := th.App.GetPosts(dm.Id, 0, 10)
+		require.Nil(t, appErr)
+		require.Len(t, posts.ToSlice(), 1)
+		assert.Contains(t, posts.ToSlice()[0].Message, post.Id)
+	})
+}
+
+func TestKeywordWatchMatcherCache(t *testing.T) {
+	cache := newKeywordWatchMatcherCache()
+
+	keyword, err := cache.get(&model.KeywordWatch{Pattern: "v1.2"})
+	require.NoError(t, err)
+	assert.True(t, keyword.MatchString("Released V1.2 today"))
+	assert.False(t, keyword.MatchString("Released v102 today"))
+
+	cached, err := cache.get(&model.KeywordWatch{Pattern: "v1.2"})
+	require.NoError(t, err)
+	assert.Same(t, keyword, cached)
+
+	regex, err := cache.get(&model.KeywordWatch{Pattern: "v1.2", IsRegex: true})
+	require.NoError(t, err)
+	assert.True(t, regex.MatchString("Released v102 today"), "keywords and regular expressions are cached apart")
+
+	_, err = cache.get(&model.KeywordWatch{Pattern: "(", IsRegex: true})
+	require.Error(t, err)
+}
@@ -290,7 +290,9 @@ func (a *App) SendNotifications(post *model.Post, team *model.Team, channel *mod
 		}
 	}
 
-	if sendPushNotifications {
+	sendNotificationEndpoints := *a.Config().EmailSettings.EnableNotificationEndpoints
+
+	if sendPushNotifications || sendNotificationEndpoints {
 		for _, id := range mentionedUsersList {
 			if profileMap[id] == nil {
 				continue
@@ -302,7 +304,8 @@ func (a *App) SendNotifications(post *model.Post, team *model.Team, channel *mod
 				status = &model.Status{UserId: id, Status: model.STATUS_OFFLINE, Manual: false, LastActivityAt: 0, ActiveChannel: ""}
 			}
 
-			if ShouldSendPushNotification(profileMap[id], channelMemberNotifyPropsMap[id], true, status, post) {
+			if ShouldSendPushNotification(profileMap[id], channelMemberNotifyPropsMap[id], true, status, post) ||
+				(a.isPostExemptFromDnd(status, post) && DoesNotifyPropsAllowPushNotification(profileMap[id], channelMemberNotifyPropsMap[id], post, true)) {
 				mentionType := mentions.Mentions[id]
 
 				replyToThreadType := ""
@@ -312,13 +315,22 @@ func (a *App) SendNotifications(post *model.Post, team *model.Team, channel *mod
 					replyToThreadType = model.COMMENTS_NOTIFY_ROOT
 				}
 
-				a.sendPushNotification(
-					notification,
-					profileMap[id],
-					mentionType == KeywordMention || mentionType == ChannelMention || mentionType == DMMention,
-					mentionType == ChannelMention,
-					replyToThreadType,
-				)
+				explicitMention := mentionType == KeywordMention || mentionType == ChannelMention || mentionType == DMMention
+				channelWideMention := mentionType == ChannelMention
+
+				if sendPushNotifications {
+					a.sendPushNotification(
+						notification,
+						profileMap[id],
+						explicitMention,
+						channelWideMention,
+						replyToThreadType,
+					)
+				}
+
+				if sendNotificationEndpoints {
+					a.sendNotificationToEndpoint(notification, profileMap[id], explicitMention, channelWideMention, replyToThreadType)
+				}
 			} else {
 				// register that a notification was not sent
 				a.NotificationsLog().Warn("Notification not sent",
@@ -343,14 +355,21 @@ func (a *App) SendNotifications(post *model.Post, team *model.Team, channel *mod
 					status = &model.Status{UserId: id, Status: model.STATUS_OFFLINE, Manual: false, LastActivityAt: 0, ActiveChannel: ""}
 				}
 
-				if ShouldSendPushNotification(profileMap[id], channelMemberNotifyPropsMap[id], false, status, post) {
-					a.sendPushNotification(
-						notification,
-						profileMap[id],
-						false,
-						false,
-						"",
-					)
+				if ShouldSendPushNotification(profileMap[id], channelMemberNotifyPropsMap[id], false, status, post) ||
+					(a.isPostExemptFromDnd(status, post) && DoesNotifyPropsAllowPushNotification(profileMap[id], channelMemberNotifyPropsMap[id], post, false)) {
+					if sendPushNotifications {
+						a.sendPushNotification(
+							notification,
+							profileMap[id],
+							false,
+							false,
+							"",
+						)
+					}
+
+					if sendNotificationEndpoints {
+						a.sendNotificationToEndpoint(notification, profileMap[id], false, false, "")
+					}
 				} else {
 					// register that a notification was not sent
 					a.NotificationsLog().Warn("Notification not sent",
@@ -365,6 +384,14 @@ func (a *App) SendNotifications(post *model.Post, team *model.Team, channel *mod
 		}
 	}
 
+	if *a.Config().ServiceSettings.EnableKeywordWatches && channel.Type == model.CHANNEL_OPEN && !post.IsSystemMessage() {
+		// Watches are checked in the background since a team can have many of them.
+		watchedPost := post.Clone()
+		a.Srv().Go(func() {
+			a.checkKeywordWatches(watchedPost, channel, team, mentions.Mentions)
+		})
+	}
+
 	message := model.NewWebSocketEvent(model.WEBSOCKET_EVENT_POSTED, "", post.ChannelId, "", nil)
 
 	// Note that PreparePostForClient should've already been called by this point
@@ -480,7 +507,7 @@ func (a *App) userAllowsEmail(user *model.User, channelMemberNotificationProps m
 	}
 
 	autoResponderRelated := status.Status == model.STATUS_OUT_OF_OFFICE || post.Type == model.POST_AUTO_RESPONDER
-	emailNotificationsAllowedForStatus := status.Status != model.STATUS_ONLINE && status.Status != model.STATUS_DND
+	emailNotificationsAllowedForStatus := status.Status != model.STATUS_ONLINE && (status.Status != model.STATUS_DND || a.isPostExemptFromDnd(status, post))
 
 	return userAllowsEmails && emailNotificationsAllowedForStatus && user.DeleteAt == 0 && !autoResponderRelated
 }
@@ -99,8 +99,16 @@ func (a *App) sendNotificationEmail(notification *PostNotification, user *model.
 	landingURL := a.GetSiteURL() + "/landing#/" + team.Name
 	var bodyText = a.getNotificationEmailBody(user, post, channel, channelName, senderName, team.Name, landingURL, emailNotificationContentsType, useMilitaryTime, translateFunc)
 
+	replyTo := a.getReplyByEmailAddress(post, user.Id)
+
 	a.Srv().Go(func() {
-		if err := a.Srv().EmailService.sendNotificationMail(user.Email, html.UnescapeString(subjectText), bodyText); err != nil {
+		var err *model.AppError
+		if replyTo != "" {
+			err = a.Srv().EmailService.sendNotificationMailWithReplyTo(user.Email, html.UnescapeString(subjectText), bodyText, replyTo)
+		} else {
+			err = a.Srv().EmailService.sendNotificationMail(user.Email, html.UnescapeString(subjectText), bodyText)
+		}
+		if err != nil {
 			mlog.Error("Error while sending the email", mlog.String("user_email", user.Email), mlog.Err(err))
 		}
 	})
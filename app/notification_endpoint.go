@@ -0,0 +1,130 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/zacmm/zacmm-server/mlog"
+	"github.com/zacmm/zacmm-server/model"
+)
+
+// getNotificationEndpoint returns the endpoint that the user wants their push
+// notifications to be POSTed to, or nil if they haven't set a valid one.
+func (a *App) getNotificationEndpoint(userId string) *model.NotificationEndpoint {
+	preference, err := a.Srv().Store.Preference().Get(userId, model.PREFERENCE_CATEGORY_NOTIFICATIONS, model.PREFERENCE_NAME_NOTIFICATION_ENDPOINT)
+	if err != nil {
+		return nil
+	}
+
+	endpoint := model.NotificationEndpointFromJson(strings.NewReader(preference.Value))
+	if endpoint == nil || endpoint.IsValid() != nil {
+		return nil
+	}
+
+	return endpoint
+}
+
+// sendNotificationToEndpoint POSTs the notification in the background to the
+// endpoint that the user set up, if any.
+func (a *App) sendNotificationToEndpoint(notification *PostNotification, user *model.User, explicitMention, channelWideMention bool, replyToThreadType string) {
+	endpoint := a.getNotificationEndpoint(user.Id)
+	if endpoint == nil {
+		return
+	}
+
+	payload := a.buildNotificationEndpointPayload(notification, user, explicitMention, channelWideMention, replyToThreadType)
+
+	a.Srv().Go(func() {
+		if err := a.postToNotificationEndpoint(endpoint, payload); err != nil {
+			mlog.Warn(
+				"Failed to send notification to endpoint",
+				mlog.String("user_id", user.Id),
+				mlog.String("post_id", payload.PostId),
+				mlog.Err(err),
+			)
+		}
+	})
+}
+
+func (a *App) buildNotificationEndpointPayload(notification *PostNotification, user *model.User, explicitMention, channelWideMention bool, replyToThreadType string) *model.NotificationEndpointPayload {
+	cfg := a.Config()
+	channel := notification.Channel
+
+	nameFormat := a.GetNotificationNameFormat(user)
+	channelName := notification.GetChannelName(nameFormat, user.Id)
+	senderName := notification.GetSenderName(nameFormat, *cfg.ServiceSettings.EnablePostUsernameOverride)
+
+	// Endpoints can't load the post afterwards like the mobile app does, so
+	// they get a generic message instead of the id loaded one.
+	contentsConfig := *cfg.EmailSettings.PushNotificationContents
+	if contentsConfig == model.ID_LOADED_NOTIFICATION {
+		contentsConfig = model.GENERIC_NOTIFICATION
+	}
+
+	// Building the message appends the attachments to the post, which is
+	// shared with the other notifications.
+	post := notification.Post.Clone()
+	msg := a.buildFullPushNotificationMessage(contentsConfig, post, user, channel, channelName, senderName, explicitMention, channelWideMention, replyToThreadType)
+
+	payload := &model.NotificationEndpointPayload{
+		Title:       msg.ChannelName,
+		Message:     msg.Message,
+		UserId:      user.Id,
+		TeamId:      msg.TeamId,
+		ChannelId:   msg.ChannelId,
+		ChannelName: msg.ChannelName,
+		PostId:      msg.PostId,
+		RootId:      msg.RootId,
+		SenderId:    msg.SenderId,
+		SenderName:  msg.SenderName,
+		CreateAt:    post.CreateAt,
+	}
+
+	if payload.Title == "" {
+		payload.Title = *cfg.TeamSettings.SiteName
+	}
+
+	if channel.TeamId != "" {
+		if team, err := a.GetTeam(channel.TeamId); err == nil {
+			payload.Url = a.GetSiteURL() + "/" + team.Name + "/pl/" + post.Id
+		}
+	}
+
+	return payload
+}
+
+// postToNotificationEndpoint POSTs the payload to the endpoint, signed with
+// the endpoint's secret.
+func (a *App) postToNotificationEndpoint(endpoint *model.NotificationEndpoint, payload *model.NotificationEndpointPayload) error {
+	body := []byte(payload.ToJson())
+	timestamp := model.GetMillis()
+
+	request, err := http.NewRequest("POST", endpoint.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(model.NOTIFICATION_ENDPOINT_TIMESTAMP_HEADER, strconv.FormatInt(timestamp, 10))
+	request.Header.Set(model.NOTIFICATION_ENDPOINT_SIGNATURE_HEADER, endpoint.Sign(timestamp, body))
+
+	resp, err := a.HTTPService().MakeClient(false).Do(request)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("notification endpoint responded with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
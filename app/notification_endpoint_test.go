@@ -0,0 +1,129 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zacmm/zacmm-server/model"
+	"github.com/zacmm/zacmm-server/store"
+	"github.com/zacmm/zacmm-server/store/storetest/mocks"
+)
+
+func setupNotificationEndpointStoreMock(th *TestHelper) *mocks.PreferenceStore {
+	mockStore := th.App.Srv().Store.(*mocks.Store)
+	mockUserStore := mocks.UserStore{}
+	mockUserStore.On("Count", mock.Anything).Return(int64(10), nil)
+	mockPostStore := mocks.PostStore{}
+	mockPostStore.On("GetMaxPostSize").Return(65535, nil)
+	mockSystemStore := mocks.SystemStore{}
+	mockSystemStore.On("GetByName", "UpgradedFromTE").Return(&model.System{Name: "UpgradedFromTE", Value: "false"}, nil)
+	mockSystemStore.On("GetByName", "InstallationDate").Return(&model.System{Name: "InstallationDate", Value: "10"}, nil)
+	mockSystemStore.On("GetByName", "FirstServerRunTimestamp").Return(&model.System{Name: "FirstServerRunTimestamp", Value: "10"}, nil)
+	mockPreferenceStore := mocks.PreferenceStore{}
+
+	mockStore.On("User").Return(&mockUserStore)
+	mockStore.On("Post").Return(&mockPostStore)
+	mockStore.On("System").Return(&mockSystemStore)
+	mockStore.On("Preference").Return(&mockPreferenceStore)
+
+	return &mockPreferenceStore
+}
+
+func TestGetNotificationEndpoint(t *testing.T) {
+	th := SetupWithStoreMock(t)
+	defer th.TearDown()
+
+	mockPreferenceStore := setupNotificationEndpointStoreMock(th)
+
+	endpoint := &model.NotificationEndpoint{URL: "https://ntfy.example.com/alerts", Secret: "secret"}
+	mockPreferenceStore.On("Get", "user1", model.PREFERENCE_CATEGORY_NOTIFICATIONS, model.PREFERENCE_NAME_NOTIFICATION_ENDPOINT).Return(&model.Preference{Value: endpoint.ToJson()}, nil)
+	mockPreferenceStore.On("Get", "user2", model.PREFERENCE_CATEGORY_NOTIFICATIONS, model.PREFERENCE_NAME_NOTIFICATION_ENDPOINT).Return(nil, store.NewErrNotFound("Preference", "user2"))
+	mockPreferenceStore.On("Get", "user3", model.PREFERENCE_CATEGORY_NOTIFICATIONS, model.PREFERENCE_NAME_NOTIFICATION_ENDPOINT).Return(&model.Preference{Value: `{"url":"https://ntfy.example.com/alerts"}`}, nil)
+
+	assert.Equal(t, endpoint, th.App.getNotificationEndpoint("user1"))
+	assert.Nil(t, th.App.getNotificationEndpoint("user2"))
+	assert.Nil(t, th.App.getNotificationEndpoint("user3"))
+}
+
+func TestBuildNotificationEndpointPayload(t *testing.T) {
+	th := SetupWithStoreMock(t)
+	defer th.TearDown()
+
+	mockPreferenceStore := setupNotificationEndpointStoreMock(th)
+	mockPreferenceStore.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("not found"))
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.EmailSettings.PushNotificationContents = model.ID_LOADED_NOTIFICATION
+		*cfg.PrivacySettings.ShowFullName = false
+	})
+
+	sender := &model.User{Id: model.NewId(), Username: "sender"}
+	receiver := &model.User{Id: model.NewId(), Username: "receiver", Locale: "en"}
+	channel := &model.Channel{Id: model.NewId(), Type: model.CHANNEL_DIRECT}
+	post := &model.Post{Id: model.NewId(), ChannelId: channel.Id, UserId: sender.Id, Message: "secret message", CreateAt: 1600000000000}
+
+	payload := th.App.buildNotificationEndpointPayload(&PostNotification{Post: post, Channel: channel, Sender: sender}, receiver, true, false, "")
+
+	assert.Equal(t, receiver.Id, payload.UserId)
+	assert.Equal(t, post.Id, payload.PostId)
+	assert.Equal(t, channel.Id, payload.ChannelId)
+	assert.Equal(t, sender.Id, payload.SenderId)
+	assert.Equal(t, "@sender", payload.Title)
+	assert.Equal(t, post.CreateAt, payload.CreateAt)
+	assert.Empty(t, payload.Url)
+	assert.NotContains(t, payload.Message, "secret message")
+}
+
+func TestPostToNotificationEndpoint(t *testing.T) {
+	th := SetupWithStoreMock(t)
+	defer th.TearDown()
+
+	setupNotificationEndpointStoreMock(th)
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.AllowedUntrustedInternalConnections = "localhost,127.0.0.1"
+	})
+
+	var request *http.Request
+	var body []byte
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request = r
+		body, _ = ioutil.ReadAll(r.Body)
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	endpoint := &model.NotificationEndpoint{URL: server.URL + "/alerts", Secret: "secret"}
+	payload := &model.NotificationEndpointPayload{Title: "Town Square", Message: "@sender: hello", PostId: model.NewId()}
+
+	t.Run("should send a signed payload", func(t *testing.T) {
+		require.NoError(t, th.App.postToNotificationEndpoint(endpoint, payload))
+
+		require.NotNil(t, request)
+		assert.Equal(t, "/alerts", request.URL.Path)
+		assert.Equal(t, "application/json", request.Header.Get("Content-Type"))
+
+		timestamp, err := strconv.ParseInt(request.Header.Get(model.NOTIFICATION_ENDPOINT_TIMESTAMP_HEADER), 10, 64)
+		require.NoError(t, err)
+		assert.Equal(t, endpoint.Sign(timestamp, body), request.Header.Get(model.NOTIFICATION_ENDPOINT_SIGNATURE_HEADER))
+		assert.Equal(t, payload, model.NotificationEndpointPayloadFromJson(bytes.NewReader(body)))
+	})
+
+	t.Run("should fail when the endpoint rejects the payload", func(t *testing.T) {
+		status = http.StatusUnauthorized
+		assert.Error(t, th.App.postToNotificationEndpoint(endpoint, payload))
+	})
+}
@@ -4,15 +4,11 @@
 package app
 
 import (
-	"io"
-	"io/ioutil"
 	"net/http"
 	"runtime"
 	"strings"
 	"sync"
 
-	"github.com/pkg/errors"
-
 	"github.com/mattermost/go-i18n/i18n"
 	"github.com/zacmm/zacmm-server/mlog"
 	"github.com/zacmm/zacmm-server/model"
@@ -101,7 +97,7 @@ func (a *App) sendPushNotificationToAllSessions(msg *model.PushNotification, use
 		tmpMessage.SetDeviceIdAndPlatform(session.DeviceId)
 		tmpMessage.AckId = model.NewId()
 
-		err := a.sendToPushProxy(tmpMessage, session)
+		err := a.sendToPushTransport(tmpMessage, session)
 		if err != nil {
 			a.NotificationsLog().Error("Notification error",
 				mlog.String("ackId", tmpMessage.AckId),
@@ -356,7 +352,10 @@ func (s *Server) StopPushNotificationsHubWorkers() {
 	s.PushNotificationsHub.stop()
 }
 
-func (a *App) sendToPushProxy(msg *model.PushNotification, session *model.Session) error {
+// sendToPushTransport sends the message to the device of the session using
+// the transport of its platform, detaching the device from the session when
+// it no longer accepts push notifications.
+func (a *App) sendToPushTransport(msg *model.PushNotification, session *model.Session) error {
 	msg.ServerId = a.TelemetryId()
 
 	a.NotificationsLog().Info("Notification will be sent",
@@ -367,31 +366,21 @@ func (a *App) sendToPushProxy(msg *model.PushNotification, session *model.Sessio
 		mlog.String("status", model.PUSH_SEND_PREPARE),
 	)
 
-	url := strings.TrimRight(*a.Config().EmailSettings.PushNotificationServer, "/") + model.API_URL_SUFFIX_V1 + "/send_push"
-	request, err := http.NewRequest("POST", url, strings.NewReader(msg.ToJson()))
-	if err != nil {
-		return err
-	}
-
-	resp, err := a.Srv().pushNotificationClient.Do(request)
+	transport, err := a.getPushNotificationTransport(msg.Platform)
 	if err != nil {
 		return err
 	}
-	defer resp.Body.Close()
 
-	pushResponse := model.PushResponseFromJson(resp.Body)
-
-	switch pushResponse[model.PUSH_STATUS] {
-	case model.PUSH_STATUS_REMOVE:
+	err = transport.SendPushNotification(msg)
+	if err == errPushDeviceRemoved {
 		a.AttachDeviceId(session.Id, "", session.ExpiresAt)
 		a.ClearSessionCacheForUser(session.UserId)
-		return errors.New("Device was reported as removed")
-	case model.PUSH_STATUS_FAIL:
-		return errors.New(pushResponse[model.PUSH_STATUS_ERROR_MSG])
 	}
-	return nil
+	return err
 }
 
+// SendAckToPushProxy hands the acknowledgement that a device received a push
+// notification to the transport of the device's platform.
 func (a *App) SendAckToPushProxy(ack *model.PushNotificationAck) error {
 	if ack == nil {
 		return nil
@@ -405,28 +394,12 @@ func (a *App) SendAckToPushProxy(ack *model.PushNotificationAck) error {
 		mlog.String("status", model.PUSH_RECEIVED),
 	)
 
-	request, err := http.NewRequest(
-		"POST",
-		strings.TrimRight(*a.Config().EmailSettings.PushNotificationServer, "/")+model.API_URL_SUFFIX_V1+"/ack",
-		strings.NewReader(ack.ToJson()),
-	)
-
+	transport, err := a.getPushNotificationTransport(ack.ClientPlatform)
 	if err != nil {
 		return err
 	}
 
-	resp, err := a.Srv().pushNotificationClient.Do(request)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-	// Reading the body to completion.
-	_, err = io.Copy(ioutil.Discard, resp.Body)
-	if err != nil {
-		return err
-	}
-
-	return nil
+	return transport.SendAck(ack)
 }
 
 func (a *App) getMobileAppSessions(userId string) ([]*model.Session, *model.AppError) {
@@ -56,6 +56,7 @@ const (
 	PERMISSION_DEMOTE_TO_GUEST                   = "demote_to_guest"
 	PERMISSION_USE_CHANNEL_MENTIONS              = "use_channel_mentions"
 	PERMISSION_CREATE_POST                       = "create_post"
+	PERMISSION_READ_CHANNEL                      = "read_channel"
 	PERMISSION_CREATE_POST_PUBLIC                = "create_post_public"
 	PERMISSION_USE_GROUP_MENTIONS                = "use_group_mentions"
 	PERMISSION_ADD_REACTION                      = "add_reaction"
@@ -71,6 +72,14 @@ const (
 	PERMISSION_EDIT_BRAND                        = "edit_brand"
 	PERMISSION_MANAGE_SHARED_CHANNELS            = "manage_shared_channels"
 	PERMISSION_MANAGE_REMOTE_CLUSTERS            = "manage_remote_clusters"
+	PERMISSION_ADD_BOOKMARK_PUBLIC_CHANNEL       = "add_bookmark_public_channel"
+	PERMISSION_EDIT_BOOKMARK_PUBLIC_CHANNEL      = "edit_bookmark_public_channel"
+	PERMISSION_DELETE_BOOKMARK_PUBLIC_CHANNEL    = "delete_bookmark_public_channel"
+	PERMISSION_ORDER_BOOKMARK_PUBLIC_CHANNEL     = "order_bookmark_public_channel"
+	PERMISSION_ADD_BOOKMARK_PRIVATE_CHANNEL      = "add_bookmark_private_channel"
+	PERMISSION_EDIT_BOOKMARK_PRIVATE_CHANNEL     = "edit_bookmark_private_channel"
+	PERMISSION_DELETE_BOOKMARK_PRIVATE_CHANNEL   = "delete_bookmark_private_channel"
+	PERMISSION_ORDER_BOOKMARK_PRIVATE_CHANNEL    = "order_bookmark_private_channel"
 )
 
 func isRole(roleName string) func(*model.Role, map[string]map[string]bool) bool {
@@ -525,6 +534,32 @@ func (a *App) getAddManageRemoteClustersPermissionsMigration() (permissionsMap,
 	}, nil
 }
 
+func (a *App) getAddChannelBookmarksPermissionsMigration() (permissionsMap, error) {
+	return permissionsMap{
+		permissionTransformation{
+			On: permissionOr(
+				isRole(model.SYSTEM_ADMIN_ROLE_ID),
+				permissionAnd(
+					isNotRole(model.CHANNEL_GUEST_ROLE_ID),
+					isNotSchemeRole("Channel Guest Role for Scheme"),
+					permissionExists(PERMISSION_READ_CHANNEL),
+					permissionExists(PERMISSION_CREATE_POST),
+				),
+			),
+			Add: []string{
+				PERMISSION_ADD_BOOKMARK_PUBLIC_CHANNEL,
+				PERMISSION_EDIT_BOOKMARK_PUBLIC_CHANNEL,
+				PERMISSION_DELETE_BOOKMARK_PUBLIC_CHANNEL,
+				PERMISSION_ORDER_BOOKMARK_PUBLIC_CHANNEL,
+				PERMISSION_ADD_BOOKMARK_PRIVATE_CHANNEL,
+				PERMISSION_EDIT_BOOKMARK_PRIVATE_CHANNEL,
+				PERMISSION_DELETE_BOOKMARK_PRIVATE_CHANNEL,
+				PERMISSION_ORDER_BOOKMARK_PRIVATE_CHANNEL,
+			},
+		},
+	}, nil
+}
+
 // DoPermissionsMigrations execute all the permissions migrations need by the current version.
 func (a *App) DoPermissionsMigrations() error {
 	PermissionsMigrations := []struct {
@@ -547,6 +582,7 @@ func (a *App) DoPermissionsMigrations() error {
 		{Key: model.MIGRATION_KEY_ADD_MANAGE_SHARED_CHANNEL_PERMISSIONS, Migration: a.getAddManageSharedChannelsPermissionsMigration},
 		{Key: model.MIGRATION_KEY_ADD_MANAGE_REMOTE_CLUSTERS_PERMISSIONS, Migration: a.getAddManageRemoteClustersPermissionsMigration},
 		{Key: model.MIGRATION_KEY_ADD_SYSTEM_ROLES_PERMISSIONS, Migration: a.getSystemRolesPermissionsMigration},
+		{Key: model.MIGRATION_KEY_ADD_CHANNEL_BOOKMARKS_PERMISSIONS, Migration: a.getAddChannelBookmarksPermissionsMigration},
 	}
 
 	roles, err := a.GetAllRoles()
@@ -658,7 +658,7 @@ func (api *PluginAPI) GetFileLink(fileId string) (string, *model.AppError) {
 		return "", model.NewAppError("GetFileLink", "plugin_api.get_file_link.no_post.app_error", nil, "file_id="+info.Id, http.StatusBadRequest)
 	}
 
-	return api.app.GeneratePublicLink(api.app.GetSiteURL(), info), nil
+	return api.app.GeneratePublicLink(api.app.GetSiteURL(), info, info.CreatorId)
 }
 
 func (api *PluginAPI) ReadFile(path string) ([]byte, *model.AppError) {
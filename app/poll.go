@@ -0,0 +1,205 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/zacmm/zacmm-server/mlog"
+	"github.com/zacmm/zacmm-server/model"
+	"github.com/zacmm/zacmm-server/store"
+)
+
+const pollsToCloseBatchSize = 100
+
+// CreatePoll creates a post of type POST_POLL for the poll and saves the
+// poll against it. The post carries the current tally in its props so that
+// clients, as well as compliance exports, can render the results.
+func (a *App) CreatePoll(poll *model.Poll, currentSessionId string) (*model.Poll, *model.AppError) {
+	poll.Id = ""
+	poll.CreateAt = 0
+	poll.ClosedAt = 0
+	poll.DeleteAt = 0
+
+	// Validate before creating the post, using a placeholder post id.
+	poll.PostId = model.NewId()
+	poll.PreSave()
+	if err := poll.IsValid(); err != nil {
+		return nil, err
+	}
+
+	channel, err := a.GetChannel(poll.ChannelId)
+	if err != nil {
+		return nil, err
+	}
+
+	if channel.DeleteAt > 0 {
+		return nil, model.NewAppError("CreatePoll", "api.poll.create.archived_channel.app_error", nil, "", http.StatusForbidden)
+	}
+
+	post := &model.Post{
+		ChannelId: poll.ChannelId,
+		UserId:    poll.UserId,
+		Message:   poll.Question,
+		Type:      model.POST_POLL,
+	}
+	post.AddProp(model.POST_PROPS_POLL_ID, poll.Id)
+	post.AddProp(model.POST_PROPS_POLL_RESULTS, poll.Results(nil, false))
+
+	rpost, err := a.CreatePostAsUser(post, currentSessionId, true)
+	if err != nil {
+		return nil, err
+	}
+
+	poll.PostId = rpost.Id
+	savedPoll, nErr := a.Srv().Store.Poll().Save(poll)
+	if nErr != nil {
+		if _, err := a.DeletePost(rpost.Id, poll.UserId); err != nil {
+			mlog.Warn("Failed to delete post for unsaved poll", mlog.String("post_id", rpost.Id), mlog.Err(err))
+		}
+
+		var appErr *model.AppError
+		switch {
+		case errors.As(nErr, &appErr):
+			return nil, appErr
+		default:
+			return nil, model.NewAppError("CreatePoll", "app.poll.save.app_error", nil, nErr.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return savedPoll, nil
+}
+
+func (a *App) GetPoll(pollId string) (*model.Poll, *model.AppError) {
+	poll, err := a.Srv().Store.Poll().Get(pollId)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("GetPoll", "app.poll.get.app_error", nil, nfErr.Error(), http.StatusNotFound)
+		default:
+			return nil, model.NewAppError("GetPoll", "app.poll.get.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return poll, nil
+}
+
+// GetPollResults returns the current tally of the poll, including the voters
+// for each option unless the poll is anonymous.
+func (a *App) GetPollResults(poll *model.Poll) (*model.PollResults, *model.AppError) {
+	votes, err := a.Srv().Store.Poll().GetVotes(poll.Id)
+	if err != nil {
+		return nil, model.NewAppError("GetPollResults", "app.poll.get_votes.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return poll.Results(votes, true), nil
+}
+
+// VoteOnPoll replaces the user's votes on the poll with the requested options
+// and broadcasts the updated tally.
+func (a *App) VoteOnPoll(poll *model.Poll, userId string, vote *model.PollVoteRequest) (*model.PollResults, *model.AppError) {
+	if poll.IsClosed(model.GetMillis()) {
+		return nil, model.NewAppError("VoteOnPoll", "app.poll.vote.closed.app_error", nil, "id="+poll.Id, http.StatusBadRequest)
+	}
+
+	if err := poll.IsValidVote(vote); err != nil {
+		return nil, err
+	}
+
+	post, err := a.GetSinglePost(poll.PostId)
+	if err != nil {
+		return nil, err
+	}
+
+	channel, err := a.GetChannel(post.ChannelId)
+	if err != nil {
+		return nil, err
+	}
+
+	if channel.DeleteAt > 0 {
+		return nil, model.NewAppError("VoteOnPoll", "api.poll.vote.archived_channel.app_error", nil, "", http.StatusForbidden)
+	}
+
+	votes := make([]*model.PollVote, 0, len(vote.Options))
+	for _, index := range vote.Options {
+		votes = append(votes, &model.PollVote{OptionIndex: index})
+	}
+
+	post, nErr := a.Srv().Store.Poll().SaveVotes(poll.Id, userId, votes)
+	if nErr != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(nErr, &nfErr):
+			return nil, model.NewAppError("VoteOnPoll", "app.poll.get.app_error", nil, nfErr.Error(), http.StatusNotFound)
+		default:
+			return nil, model.NewAppError("VoteOnPoll", "app.poll.save_votes.app_error", nil, nErr.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	a.publishPollPost(post)
+
+	return a.GetPollResults(poll)
+}
+
+// ClosePoll stops the poll from accepting further votes.
+func (a *App) ClosePoll(poll *model.Poll) (*model.Poll, *model.AppError) {
+	if poll.ClosedAt != 0 {
+		return poll, nil
+	}
+
+	poll.ClosedAt = model.GetMillis()
+	updatedPoll, err := a.Srv().Store.Poll().Update(poll)
+	if err != nil {
+		var appErr *model.AppError
+		switch {
+		case errors.As(err, &appErr):
+			return nil, appErr
+		default:
+			return nil, model.NewAppError("ClosePoll", "app.poll.update.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	post, err := a.Srv().Store.Poll().UpdateTally(updatedPoll.Id)
+	if err != nil {
+		return nil, model.NewAppError("ClosePoll", "app.poll.update.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	a.publishPollPost(post)
+
+	return updatedPoll, nil
+}
+
+// ClosePollsDue closes every poll whose closing time has passed.
+func (a *App) ClosePollsDue() *model.AppError {
+	for {
+		polls, err := a.Srv().Store.Poll().GetPollsToClose(model.GetMillis(), pollsToCloseBatchSize)
+		if err != nil {
+			return model.NewAppError("ClosePollsDue", "app.poll.get_polls_to_close.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+
+		for _, poll := range polls {
+			if _, appErr := a.ClosePoll(poll); appErr != nil {
+				return appErr
+			}
+		}
+
+		if len(polls) < pollsToCloseBatchSize {
+			return nil
+		}
+	}
+}
+
+// publishPollPost broadcasts a change to the tally kept in the poll's post as
+// a post edit.
+func (a *App) publishPollPost(post *model.Post) {
+	rpost := a.PreparePostForClient(post, false, true)
+
+	message := model.NewWebSocketEvent(model.WEBSOCKET_EVENT_POST_EDITED, "", rpost.ChannelId, "", nil)
+	message.Add("post", rpost.ToJson())
+	a.Publish(message)
+
+	a.invalidateCacheForChannelPosts(rpost.ChannelId)
+}
@@ -347,6 +347,8 @@ func (a *App) CreatePost(post *model.Post, channel *model.Channel, triggerWebhoo
 		}
 	}
 
+	a.deleteDraftForPost(rpost)
+
 	// Normally, we would let the API layer call PreparePostForClient, but we do it here since it also needs
 	// to be done when we send the post over the websocket in handlePostEvents
 	rpost = a.PreparePostForClient(rpost, true, false)
@@ -574,6 +576,11 @@ func (a *App) UpdatePost(post *model.Post, safeUpdate bool) (*model.Post, *model
 		return nil, err
 	}
 
+	if oldPost.Type == model.POST_POLL && post.Message != oldPost.Message {
+		err = model.NewAppError("UpdatePost", "api.post.update_post.poll.app_error", nil, "id="+post.Id, http.StatusBadRequest)
+		return nil, err
+	}
+
 	if a.Srv().License() != nil {
 		if *a.Config().ServiceSettings.PostEditTimeLimit != -1 && model.GetMillis() > oldPost.CreateAt+int64(*a.Config().ServiceSettings.PostEditTimeLimit*1000) && post.Message != oldPost.Message {
 			err = model.NewAppError("UpdatePost", "api.post.update_post.permissions_time_limit.app_error", map[string]interface{}{"timeLimit": *a.Config().ServiceSettings.PostEditTimeLimit}, "", http.StatusBadRequest)
@@ -603,7 +610,10 @@ func (a *App) UpdatePost(post *model.Post, safeUpdate bool) (*model.Post, *model
 		newPost.IsPinned = post.IsPinned
 		newPost.HasReactions = post.HasReactions
 		newPost.FileIds = post.FileIds
-		newPost.SetProps(post.GetProps())
+		// The tally of a poll is only ever updated through its votes.
+		if oldPost.Type != model.POST_POLL {
+			newPost.SetProps(post.GetProps())
+		}
 	}
 
 	// Avoid deep-equal checks if EditAt was already modified through message change
@@ -1253,6 +1263,8 @@ func (a *App) SearchPostsInTeamForUser(terms string, userId string, teamId strin
 			// Convert usernames to user IDs
 			params.FromUsers = a.convertUserNameToUserIds(params.FromUsers)
 			params.ExcludedUsers = a.convertUserNameToUserIds(params.ExcludedUsers)
+			params.ReactedUsers = a.convertUserNameToUserIds(params.ReactedUsers)
+			params.ExcludedReactedUsers = a.convertUserNameToUserIds(params.ExcludedReactedUsers)
 
 			finalParamsList = append(finalParamsList, params)
 		}
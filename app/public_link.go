@@ -0,0 +1,146 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/zacmm/zacmm-server/mlog"
+	"github.com/zacmm/zacmm-server/model"
+	"github.com/zacmm/zacmm-server/store"
+)
+
+func (a *App) publicLinkURL(siteURL string, link *model.PublicLink) string {
+	hash := GeneratePublicLinkHash(link.Id, *a.Config().FileSettings.PublicLinkSalt)
+	return fmt.Sprintf("%s/files/%v/public?l=%s&h=%s", siteURL, link.FileId, link.Id, hash)
+}
+
+// CreatePublicLink creates a public link to the file for the user. The link
+// expires at the given time, or never when it is 0, but no later than allowed
+// by FileSettings.PublicLinkExpiryHours.
+func (a *App) CreatePublicLink(siteURL string, info *model.FileInfo, userId string, expireAt int64) (*model.PublicLink, *model.AppError) {
+	now := model.GetMillis()
+	if expiryHours := *a.Config().FileSettings.PublicLinkExpiryHours; expiryHours > 0 {
+		maxExpireAt := now + int64(time.Duration(expiryHours)*time.Hour/time.Millisecond)
+		if expireAt == 0 {
+			expireAt = maxExpireAt
+		} else if expireAt > maxExpireAt {
+			return nil, model.NewAppError("CreatePublicLink", "app.public_link.expire_at_too_late.app_error", map[string]interface{}{"Hours": expiryHours}, "", http.StatusBadRequest)
+		}
+	}
+
+	link, err := a.Srv().Store.PublicLink().Save(&model.PublicLink{
+		FileId:    info.Id,
+		CreatorId: userId,
+		CreateAt:  now,
+		ExpireAt:  expireAt,
+	})
+	if err != nil {
+		var appErr *model.AppError
+		switch {
+		case errors.As(err, &appErr):
+			return nil, appErr
+		default:
+			return nil, model.NewAppError("CreatePublicLink", "app.public_link.save.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	link.Link = a.publicLinkURL(siteURL, link)
+	return link, nil
+}
+
+// GeneratePublicLink returns the most recent active public link of the user
+// to the file, creating one when there is none.
+func (a *App) GeneratePublicLink(siteURL string, info *model.FileInfo, userId string) (string, *model.AppError) {
+	link, err := a.Srv().Store.PublicLink().GetActive(info.Id, userId, model.GetMillis())
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			link, appErr := a.CreatePublicLink(siteURL, info, userId, 0)
+			if appErr != nil {
+				return "", appErr
+			}
+			return link.Link, nil
+		default:
+			return "", model.NewAppError("GeneratePublicLink", "app.public_link.get.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return a.publicLinkURL(siteURL, link), nil
+}
+
+func (a *App) GetPublicLink(linkId string) (*model.PublicLink, *model.AppError) {
+	link, err := a.Srv().Store.PublicLink().Get(linkId)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("GetPublicLink", "app.public_link.get.not_found.app_error", nil, nfErr.Error(), http.StatusNotFound)
+		default:
+			return nil, model.NewAppError("GetPublicLink", "app.public_link.get.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+	return link, nil
+}
+
+// GetPublicLinksForUser returns the public links created by the user, the
+// most recent first, with their URLs.
+func (a *App) GetPublicLinksForUser(siteURL, userId string, page, perPage int) ([]*model.PublicLink, *model.AppError) {
+	links, err := a.Srv().Store.PublicLink().GetForUser(userId, page*perPage, perPage)
+	if err != nil {
+		return nil, model.NewAppError("GetPublicLinksForUser", "app.public_link.get_for_user.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	for _, link := range links {
+		link.Link = a.publicLinkURL(siteURL, link)
+	}
+	return links, nil
+}
+
+func (a *App) RevokePublicLink(linkId string) *model.AppError {
+	if err := a.Srv().Store.PublicLink().Revoke(linkId, model.GetMillis()); err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return model.NewAppError("RevokePublicLink", "app.public_link.revoke.not_found.app_error", nil, nfErr.Error(), http.StatusNotFound)
+		default:
+			return model.NewAppError("RevokePublicLink", "app.public_link.revoke.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+	return nil
+}
+
+// UsePublicLink checks that the public link with the given id and hash gives
+// access to the file, and records the download.
+func (a *App) UsePublicLink(fileId, linkId, hash string) (*model.PublicLink, *model.AppError) {
+	link, err := a.Srv().Store.PublicLink().Get(linkId)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("UsePublicLink", "api.file.get_file.public_invalid.app_error", nil, "", http.StatusBadRequest)
+		default:
+			return nil, model.NewAppError("UsePublicLink", "app.public_link.get.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	if link.FileId != fileId || subtle.ConstantTimeCompare([]byte(hash), []byte(GeneratePublicLinkHash(link.Id, *a.Config().FileSettings.PublicLinkSalt))) != 1 {
+		return nil, model.NewAppError("UsePublicLink", "api.file.get_file.public_invalid.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	now := model.GetMillis()
+	if !link.IsActive(now) {
+		return nil, model.NewAppError("UsePublicLink", "app.public_link.expired.app_error", nil, "link_id="+link.Id, http.StatusGone)
+	}
+
+	if err := a.Srv().Store.PublicLink().RecordDownload(link.Id, now); err != nil {
+		mlog.Warn("Failed to record download of public link", mlog.String("link_id", link.Id), mlog.Err(err))
+	}
+	return link, nil
+}
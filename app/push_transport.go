@@ -0,0 +1,168 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/zacmm/zacmm-server/model"
+	"github.com/zacmm/zacmm-server/services/webpush"
+)
+
+// webPushTTL is how long a push service keeps a web push notification for a
+// client that is offline.
+const webPushTTL = 24 * time.Hour
+
+// errPushDeviceRemoved is returned by a PushNotificationTransport when the
+// device no longer accepts push notifications, so that it gets detached from
+// its session.
+var errPushDeviceRemoved = errors.New("Device was reported as removed")
+
+// PushNotificationTransport delivers push notifications to the devices of one
+// kind of platform and handles the acknowledgements that they send back.
+type PushNotificationTransport interface {
+	SendPushNotification(msg *model.PushNotification) error
+	SendAck(ack *model.PushNotificationAck) error
+}
+
+// pushProxyTransport sends push notifications through a push proxy, which
+// forwards them to APNS or FCM.
+type pushProxyTransport struct {
+	client    *http.Client
+	serverURL string
+}
+
+func (t *pushProxyTransport) SendPushNotification(msg *model.PushNotification) error {
+	request, err := http.NewRequest("POST", t.serverURL+model.API_URL_SUFFIX_V1+"/send_push", strings.NewReader(msg.ToJson()))
+	if err != nil {
+		return err
+	}
+
+	resp, err := t.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	pushResponse := model.PushResponseFromJson(resp.Body)
+
+	switch pushResponse[model.PUSH_STATUS] {
+	case model.PUSH_STATUS_REMOVE:
+		return errPushDeviceRemoved
+	case model.PUSH_STATUS_FAIL:
+		return errors.New(pushResponse[model.PUSH_STATUS_ERROR_MSG])
+	}
+	return nil
+}
+
+func (t *pushProxyTransport) SendAck(ack *model.PushNotificationAck) error {
+	request, err := http.NewRequest("POST", t.serverURL+model.API_URL_SUFFIX_V1+"/ack", strings.NewReader(ack.ToJson()))
+	if err != nil {
+		return err
+	}
+
+	resp, err := t.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	// Reading the body to completion.
+	_, err = io.Copy(ioutil.Discard, resp.Body)
+	return err
+}
+
+// webPushTransport sends push notifications straight to the Web Push endpoint
+// that a browser or a UnifiedPush distributor subscribed with, so that no push
+// proxy is needed.
+type webPushTransport struct {
+	client  *http.Client
+	key     *ecdsa.PrivateKey
+	subject string
+}
+
+func (t *webPushTransport) SendPushNotification(msg *model.PushNotification) error {
+	subscription, err := webpush.ParseSubscription(msg.DeviceId)
+	if err != nil {
+		return err
+	}
+
+	// The subscription is only needed to deliver the message, not by the client.
+	payload := msg.DeepCopy()
+	payload.DeviceId = ""
+
+	encrypted, err := webpush.Encrypt(subscription, []byte(payload.ToJson()))
+	if err != nil {
+		return err
+	}
+
+	request, err := webpush.NewRequest(subscription, encrypted, t.key, t.subject, webPushTTL)
+	if err != nil {
+		return err
+	}
+
+	resp, err := t.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, resp.Body)
+
+	if webpush.IsSubscriptionGone(resp) {
+		return errPushDeviceRemoved
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("push service responded with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// SendAck does nothing since push services do not take acknowledgements back.
+func (t *webPushTransport) SendAck(ack *model.PushNotificationAck) error {
+	return nil
+}
+
+// getPushNotificationTransport returns the transport that delivers push
+// notifications to devices of the given platform.
+func (a *App) getPushNotificationTransport(platform string) (PushNotificationTransport, error) {
+	settings := a.Config().EmailSettings
+
+	if platform == model.PUSH_NOTIFY_WEBPUSH {
+		if !*settings.EnableWebPushNotifications {
+			return nil, errors.New("Web push notifications are disabled")
+		}
+
+		key := a.AsymmetricSigningKey()
+		if key == nil {
+			return nil, errors.New("Missing the key that signs web push notifications")
+		}
+
+		subject := *settings.WebPushSubject
+		if subject == "" {
+			subject = *a.Config().ServiceSettings.SiteURL
+		}
+
+		// The endpoints are given by clients, so they must not reach the
+		// internal network.
+		return &webPushTransport{
+			client:  a.HTTPService().MakeClient(false),
+			key:     key,
+			subject: subject,
+		}, nil
+	}
+
+	return &pushProxyTransport{
+		client:    a.Srv().pushNotificationClient,
+		serverURL: strings.TrimRight(*settings.PushNotificationServer, "/"),
+	}, nil
+}
@@ -0,0 +1,195 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zacmm/zacmm-server/model"
+	"github.com/zacmm/zacmm-server/services/webpush"
+	"github.com/zacmm/zacmm-server/store/storetest/mocks"
+)
+
+func newTestWebPushSubscription(t *testing.T, endpoint string) *webpush.Subscription {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	authSecret := make([]byte, 16)
+	_, err = rand.Read(authSecret)
+	require.NoError(t, err)
+
+	subscription := &webpush.Subscription{Endpoint: endpoint}
+	subscription.Keys.P256dh = base64.RawURLEncoding.EncodeToString(elliptic.Marshal(elliptic.P256(), key.X, key.Y))
+	subscription.Keys.Auth = base64.RawURLEncoding.EncodeToString(authSecret)
+	return subscription
+}
+
+func TestPushProxyTransport(t *testing.T) {
+	t.Run("should send the notification to the proxy", func(t *testing.T) {
+		handler := &testPushNotificationHandler{t: t, behavior: "simple"}
+		pushServer := httptest.NewServer(http.HandlerFunc(handler.handleReq))
+		defer pushServer.Close()
+
+		transport := &pushProxyTransport{client: http.DefaultClient, serverURL: pushServer.URL}
+		msg := &model.PushNotification{AckId: "testid", Platform: model.PUSH_NOTIFY_APPLE, DeviceId: "device"}
+		require.NoError(t, transport.SendPushNotification(msg))
+
+		require.Equal(t, 1, handler.numReqs())
+		assert.Equal(t, msg.AckId, handler.notifications()[0].AckId)
+	})
+
+	t.Run("should report a removed device", func(t *testing.T) {
+		pushServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			resp := model.NewRemovePushResponse()
+			fmt.Fprintln(w, (&resp).ToJson())
+		}))
+		defer pushServer.Close()
+
+		transport := &pushProxyTransport{client: http.DefaultClient, serverURL: pushServer.URL}
+		err := transport.SendPushNotification(&model.PushNotification{Platform: model.PUSH_NOTIFY_APPLE, DeviceId: "device"})
+		assert.Equal(t, errPushDeviceRemoved, err)
+	})
+
+	t.Run("should send acks to the proxy", func(t *testing.T) {
+		handler := &testPushNotificationHandler{t: t}
+		pushServer := httptest.NewServer(http.HandlerFunc(handler.handleReq))
+		defer pushServer.Close()
+
+		transport := &pushProxyTransport{client: http.DefaultClient, serverURL: pushServer.URL}
+		ack := &model.PushNotificationAck{Id: "testid", NotificationType: model.PUSH_TYPE_MESSAGE}
+		require.NoError(t, transport.SendAck(ack))
+
+		require.Equal(t, 1, handler.numReqs())
+		assert.Equal(t, ack.Id, handler.notificationAcks()[0].Id)
+	})
+}
+
+func TestWebPushTransport(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	t.Run("should send the encrypted notification to the endpoint", func(t *testing.T) {
+		var request *http.Request
+		var body []byte
+		pushService := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			request = r
+			body, _ = ioutil.ReadAll(r.Body)
+			w.WriteHeader(http.StatusCreated)
+		}))
+		defer pushService.Close()
+
+		subscription := newTestWebPushSubscription(t, pushService.URL+"/push/abc")
+		transport := &webPushTransport{client: pushService.Client(), key: key, subject: "mailto:admin@example.com"}
+		msg := &model.PushNotification{AckId: "testid", Message: "hello", Platform: model.PUSH_NOTIFY_WEBPUSH, DeviceId: subscription.DeviceId()}
+		require.NoError(t, transport.SendPushNotification(msg))
+
+		require.NotNil(t, request)
+		assert.Equal(t, "/push/abc", request.URL.Path)
+		assert.Equal(t, "aes128gcm", request.Header.Get("Content-Encoding"))
+		assert.True(t, strings.HasPrefix(request.Header.Get("Authorization"), "vapid t="))
+		assert.NotContains(t, string(body), "hello")
+		assert.NotEmpty(t, body)
+	})
+
+	t.Run("should report an expired subscription as a removed device", func(t *testing.T) {
+		pushService := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusGone)
+		}))
+		defer pushService.Close()
+
+		subscription := newTestWebPushSubscription(t, pushService.URL+"/push/abc")
+		transport := &webPushTransport{client: pushService.Client(), key: key, subject: "mailto:admin@example.com"}
+		err := transport.SendPushNotification(&model.PushNotification{Platform: model.PUSH_NOTIFY_WEBPUSH, DeviceId: subscription.DeviceId()})
+		assert.Equal(t, errPushDeviceRemoved, err)
+	})
+
+	t.Run("should reject an invalid subscription", func(t *testing.T) {
+		transport := &webPushTransport{client: http.DefaultClient, key: key, subject: "mailto:admin@example.com"}
+		err := transport.SendPushNotification(&model.PushNotification{Platform: model.PUSH_NOTIFY_WEBPUSH, DeviceId: "invalid"})
+		assert.Equal(t, webpush.ErrInvalidSubscription, err)
+	})
+
+	t.Run("should not send to a plain http endpoint", func(t *testing.T) {
+		var requests int32
+		pushService := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&requests, 1)
+			w.WriteHeader(http.StatusCreated)
+		}))
+		defer pushService.Close()
+
+		subscription := newTestWebPushSubscription(t, pushService.URL+"/push/abc")
+		transport := &webPushTransport{client: pushService.Client(), key: key, subject: "mailto:admin@example.com"}
+		err := transport.SendPushNotification(&model.PushNotification{Platform: model.PUSH_NOTIFY_WEBPUSH, DeviceId: subscription.DeviceId()})
+		assert.Equal(t, webpush.ErrInvalidSubscription, err)
+		assert.Zero(t, atomic.LoadInt32(&requests))
+	})
+}
+
+func TestGetPushNotificationTransport(t *testing.T) {
+	th := SetupWithStoreMock(t)
+	defer th.TearDown()
+
+	mockStore := th.App.Srv().Store.(*mocks.Store)
+	mockUserStore := mocks.UserStore{}
+	mockUserStore.On("Count", mock.Anything).Return(int64(10), nil)
+	mockPostStore := mocks.PostStore{}
+	mockPostStore.On("GetMaxPostSize").Return(65535, nil)
+	mockSystemStore := mocks.SystemStore{}
+	mockSystemStore.On("GetByName", "UpgradedFromTE").Return(&model.System{Name: "UpgradedFromTE", Value: "false"}, nil)
+	mockSystemStore.On("GetByName", "InstallationDate").Return(&model.System{Name: "InstallationDate", Value: "10"}, nil)
+	mockSystemStore.On("GetByName", "FirstServerRunTimestamp").Return(&model.System{Name: "FirstServerRunTimestamp", Value: "10"}, nil)
+
+	mockStore.On("User").Return(&mockUserStore)
+	mockStore.On("Post").Return(&mockPostStore)
+	mockStore.On("System").Return(&mockSystemStore)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	th.App.Srv().asymmetricSigningKey = key
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.EmailSettings.PushNotificationServer = "https://push.example.com/"
+		*cfg.EmailSettings.EnableWebPushNotifications = false
+		*cfg.EmailSettings.WebPushSubject = ""
+		*cfg.ServiceSettings.SiteURL = "https://chat.example.com"
+	})
+
+	t.Run("should use the push proxy for mobile devices", func(t *testing.T) {
+		transport, err := th.App.getPushNotificationTransport(model.PUSH_NOTIFY_ANDROID)
+		require.NoError(t, err)
+		require.IsType(t, &pushProxyTransport{}, transport)
+		assert.Equal(t, "https://push.example.com", transport.(*pushProxyTransport).serverURL)
+	})
+
+	t.Run("should not use web push when it is disabled", func(t *testing.T) {
+		_, err := th.App.getPushNotificationTransport(model.PUSH_NOTIFY_WEBPUSH)
+		assert.Error(t, err)
+	})
+
+	t.Run("should use web push with the site URL as subject", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.EmailSettings.EnableWebPushNotifications = true
+		})
+
+		transport, err := th.App.getPushNotificationTransport(model.PUSH_NOTIFY_WEBPUSH)
+		require.NoError(t, err)
+		require.IsType(t, &webPushTransport{}, transport)
+		assert.Equal(t, "https://chat.example.com", transport.(*webPushTransport).subject)
+		assert.Equal(t, key, transport.(*webPushTransport).key)
+	})
+}
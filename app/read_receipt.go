@@ -0,0 +1,174 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/zacmm/zacmm-server/mlog"
+	"github.com/zacmm/zacmm-server/model"
+)
+
+// HasReadReceiptsEnabled reports whether the given user has opted in to
+// sharing and seeing read receipts. Read receipts are always off when the
+// feature is disabled server-wide.
+func (a *App) HasReadReceiptsEnabled(userId string) bool {
+	if !*a.Config().ServiceSettings.EnableReadReceipts {
+		return false
+	}
+
+	preference, err := a.Srv().Store.Preference().Get(userId, model.PREFERENCE_CATEGORY_DISPLAY_SETTINGS, model.PREFERENCE_NAME_READ_RECEIPTS)
+	if err != nil {
+		return false
+	}
+
+	enabled, _ := strconv.ParseBool(preference.Value)
+	return enabled
+}
+
+// GetPostReadReceipts returns the members of the post's direct or group
+// channel, other than its author, that have viewed the channel since the post
+// was created. Members that have not opted in to read receipts are omitted,
+// and only users that share their own read state may see others'.
+func (a *App) GetPostReadReceipts(postId, userId string) ([]*model.PostReadReceipt, *model.AppError) {
+	if !*a.Config().ServiceSettings.EnableReadReceipts {
+		return nil, model.NewAppError("GetPostReadReceipts", "app.post.read_receipts.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	if !a.HasReadReceiptsEnabled(userId) {
+		return nil, model.NewAppError("GetPostReadReceipts", "app.post.read_receipts.not_enabled.app_error", nil, "user_id="+userId, http.StatusForbidden)
+	}
+
+	post, err := a.GetSinglePost(postId)
+	if err != nil {
+		return nil, err
+	}
+
+	channel, err := a.GetChannel(post.ChannelId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !channel.IsGroupOrDirect() {
+		return nil, model.NewAppError("GetPostReadReceipts", "app.post.read_receipts.invalid_channel.app_error", nil, "channel_id="+channel.Id, http.StatusBadRequest)
+	}
+
+	members, nErr := a.Srv().Store.Channel().GetMembers(channel.Id, 0, model.CHANNEL_GROUP_MAX_USERS)
+	if nErr != nil {
+		return nil, model.NewAppError("GetPostReadReceipts", "app.channel.get_members.app_error", nil, nErr.Error(), http.StatusInternalServerError)
+	}
+
+	userIds := make([]string, 0, len(*members))
+	for _, member := range *members {
+		if member.UserId != post.UserId && member.LastViewedAt >= post.CreateAt {
+			userIds = append(userIds, member.UserId)
+		}
+	}
+
+	enabled, nErr := a.readReceiptsEnabledUsers(userIds)
+	if nErr != nil {
+		return nil, model.NewAppError("GetPostReadReceipts", "app.preference.get.app_error", nil, nErr.Error(), http.StatusInternalServerError)
+	}
+
+	receipts := []*model.PostReadReceipt{}
+	for _, member := range *members {
+		if !enabled[member.UserId] {
+			continue
+		}
+
+		receipts = append(receipts, &model.PostReadReceipt{
+			PostId: post.Id,
+			UserId: member.UserId,
+			ReadAt: member.LastViewedAt,
+		})
+	}
+
+	return receipts, nil
+}
+
+// readReceiptsEnabledUsers returns the subset of the given users that have
+// opted in to read receipts, looked up with a single preference query.
+func (a *App) readReceiptsEnabledUsers(userIds []string) (map[string]bool, error) {
+	enabled := make(map[string]bool, len(userIds))
+
+	preferences, err := a.Srv().Store.Preference().GetForUsers(userIds, model.PREFERENCE_CATEGORY_DISPLAY_SETTINGS, model.PREFERENCE_NAME_READ_RECEIPTS)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, preference := range preferences {
+		if value, _ := strconv.ParseBool(preference.Value); value {
+			enabled[preference.UserId] = true
+		}
+	}
+
+	return enabled, nil
+}
+
+// publishReadReceipts notifies the members of any direct or group channels in
+// lastViewedAtTimes that the given user has read the channel up to the
+// recorded time. Only members that have opted in to read receipts are notified.
+// The channels and the members' preferences are each fetched with a single
+// query.
+func (a *App) publishReadReceipts(lastViewedAtTimes map[string]int64, userId string) {
+	if len(lastViewedAtTimes) == 0 || !a.HasReadReceiptsEnabled(userId) {
+		return
+	}
+
+	channelIds := make([]string, 0, len(lastViewedAtTimes))
+	for channelId := range lastViewedAtTimes {
+		channelIds = append(channelIds, channelId)
+	}
+
+	channels, err := a.Srv().Store.Channel().GetChannelsByIds(channelIds, false)
+	if err != nil {
+		mlog.Warn("Failed to get channels for read receipts", mlog.Err(err))
+		return
+	}
+
+	membersByChannel := make(map[string]*model.ChannelMembers)
+	var userIds []string
+	for _, channel := range channels {
+		if !channel.IsGroupOrDirect() {
+			continue
+		}
+
+		members, err := a.Srv().Store.Channel().GetMembers(channel.Id, 0, model.CHANNEL_GROUP_MAX_USERS)
+		if err != nil {
+			mlog.Warn("Failed to get channel members for read receipts", mlog.String("channel_id", channel.Id), mlog.Err(err))
+			continue
+		}
+
+		membersByChannel[channel.Id] = members
+		for _, member := range *members {
+			if member.UserId != userId {
+				userIds = append(userIds, member.UserId)
+			}
+		}
+	}
+
+	if len(userIds) == 0 {
+		return
+	}
+
+	enabled, err := a.readReceiptsEnabledUsers(userIds)
+	if err != nil {
+		mlog.Warn("Failed to get read receipt preferences", mlog.Err(err))
+		return
+	}
+
+	for channelId, members := range membersByChannel {
+		for _, member := range *members {
+			if member.UserId == userId || !enabled[member.UserId] {
+				continue
+			}
+
+			message := model.NewWebSocketEvent(model.WEBSOCKET_EVENT_POST_READ, "", channelId, member.UserId, nil)
+			message.Add("user_id", userId)
+			message.Add("last_viewed_at", lastViewedAtTimes[channelId])
+			a.Publish(message)
+		}
+	}
+}
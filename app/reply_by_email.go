@@ -0,0 +1,190 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base32"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/zacmm/zacmm-server/mlog"
+	"github.com/zacmm/zacmm-server/model"
+	"github.com/zacmm/zacmm-server/services/mailservice"
+)
+
+const (
+	replyByEmailRootIdLength   = 26
+	replyByEmailSignatureSize  = 10
+	replyByEmailMaxMessageSize = 10 * 1024 * 1024
+)
+
+var replyByEmailSignatureEncoding = base32.NewEncoding("abcdefghijklmnopqrstuvwxyz234567").WithPadding(base32.NoPadding)
+
+var replyByEmailSignatureLength = replyByEmailSignatureEncoding.EncodedLen(replyByEmailSignatureSize)
+
+var (
+	quotedReplyHeaderRegexp = regexp.MustCompile(`^On\s.+\swrote:$`)
+	quotedReplySeparators   = []string{"-----Original Message-----", "________________________________"}
+)
+
+// replyByEmailSignature signs a thread and a user so that a reply sent to the
+// resulting address can only be posted in that thread as that user.
+func (a *App) replyByEmailSignature(rootId, userId string) string {
+	mac := hmac.New(sha256.New, a.PostActionCookieSecret())
+	mac.Write([]byte("reply_by_email:" + rootId + ":" + userId))
+	return replyByEmailSignatureEncoding.EncodeToString(mac.Sum(nil)[:replyByEmailSignatureSize])
+}
+
+// getReplyByEmailAddress returns the address that the given user can reply to
+// in order to answer the thread of the given post, or an empty string if reply
+// by email is disabled.
+func (a *App) getReplyByEmailAddress(post *model.Post, userId string) string {
+	settings := a.Config().EmailSettings
+	if !*settings.EnableReplyByEmail {
+		return ""
+	}
+
+	at := strings.LastIndex(*settings.ReplyByEmailAddress, "@")
+	if at < 0 {
+		return ""
+	}
+
+	rootId := post.RootId
+	if rootId == "" {
+		rootId = post.Id
+	}
+
+	address := *settings.ReplyByEmailAddress
+	return address[:at] + "+" + rootId + a.replyByEmailSignature(rootId, userId) + address[at:]
+}
+
+// parseReplyByEmailAddress extracts the thread and signature from a recipient
+// generated by getReplyByEmailAddress for the given reply address.
+func parseReplyByEmailAddress(recipient, replyAddress string) (rootId, signature string, ok bool) {
+	at := strings.LastIndex(recipient, "@")
+	replyAt := strings.LastIndex(replyAddress, "@")
+	if at < 0 || replyAt < 0 || !strings.EqualFold(recipient[at:], replyAddress[replyAt:]) {
+		return "", "", false
+	}
+
+	local := strings.ToLower(recipient[:at])
+	prefix := strings.ToLower(replyAddress[:replyAt]) + "+"
+	if !strings.HasPrefix(local, prefix) {
+		return "", "", false
+	}
+
+	token := local[len(prefix):]
+	if len(token) != replyByEmailRootIdLength+replyByEmailSignatureLength {
+		return "", "", false
+	}
+
+	rootId, signature = token[:replyByEmailRootIdLength], token[replyByEmailRootIdLength:]
+	if !model.IsValidId(rootId) {
+		return "", "", false
+	}
+
+	return rootId, signature, true
+}
+
+// stripQuotedReply removes the quoted message and the signature that email
+// clients add below a reply.
+func stripQuotedReply(text string) string {
+	lines := strings.Split(strings.Replace(text, "\r\n", "\n", -1), "\n")
+
+	end := len(lines)
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, ">") || line == "-- " || strings.HasPrefix(trimmed, "Sent from my ") {
+			end = i
+		} else if quotedReplyHeaderRegexp.MatchString(trimmed) {
+			end = i
+		} else if strings.HasPrefix(trimmed, "On ") && i+1 < len(lines) && quotedReplyHeaderRegexp.MatchString(trimmed+" "+strings.TrimSpace(lines[i+1])) {
+			// Some clients wrap the attribution line.
+			end = i
+		} else if strings.HasPrefix(trimmed, "From:") && i+1 < len(lines) && (strings.HasPrefix(lines[i+1], "Sent:") || strings.HasPrefix(lines[i+1], "Date:")) {
+			end = i
+		} else {
+			for _, separator := range quotedReplySeparators {
+				if strings.HasPrefix(trimmed, separator) {
+					end = i
+					break
+				}
+			}
+		}
+
+		if end != len(lines) {
+			break
+		}
+	}
+
+	return strings.TrimSpace(strings.Join(lines[:end], "\n"))
+}
+
+// HandleInboundEmail posts a reply received by email in the thread that the
+// notification email was about. The recipient address has to carry a valid
+// signature for the thread and the sender, which prevents anyone else from
+// posting as the user.
+func (a *App) HandleInboundEmail(to []string, data []byte) *model.AppError {
+	var rootId, signature string
+	found := false
+	for _, recipient := range to {
+		if rootId, signature, found = parseReplyByEmailAddress(recipient, *a.Config().EmailSettings.ReplyByEmailAddress); found {
+			break
+		}
+	}
+	if !found {
+		return model.NewAppError("HandleInboundEmail", "app.reply_by_email.invalid_recipient.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	from, text, err := mailservice.ReadMessageText(data)
+	if err != nil {
+		return model.NewAppError("HandleInboundEmail", "app.reply_by_email.parse_message.app_error", nil, err.Error(), http.StatusBadRequest)
+	}
+
+	user, err := a.Srv().Store.User().GetByEmail(from)
+	if err != nil {
+		return model.NewAppError("HandleInboundEmail", "app.reply_by_email.invalid_signature.app_error", nil, "unknown sender", http.StatusForbidden)
+	}
+
+	if !hmac.Equal([]byte(signature), []byte(a.replyByEmailSignature(rootId, user.Id))) {
+		return model.NewAppError("HandleInboundEmail", "app.reply_by_email.invalid_signature.app_error", nil, "user_id="+user.Id, http.StatusForbidden)
+	}
+
+	if user.DeleteAt != 0 {
+		return model.NewAppError("HandleInboundEmail", "app.reply_by_email.invalid_signature.app_error", nil, "deactivated user_id="+user.Id, http.StatusForbidden)
+	}
+
+	root, appErr := a.GetSinglePost(rootId)
+	if appErr != nil {
+		return appErr
+	}
+
+	if !a.HasPermissionToChannel(user.Id, root.ChannelId, model.PERMISSION_CREATE_POST) {
+		return model.NewAppError("HandleInboundEmail", "api.context.permissions.app_error", nil, "user_id="+user.Id, http.StatusForbidden)
+	}
+
+	message := stripQuotedReply(text)
+	if message == "" {
+		return model.NewAppError("HandleInboundEmail", "app.reply_by_email.empty_message.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	post := &model.Post{
+		ChannelId: root.ChannelId,
+		RootId:    root.Id,
+		UserId:    user.Id,
+		Message:   message,
+	}
+
+	if _, appErr := a.CreatePostAsUser(post, "", false); appErr != nil {
+		return appErr
+	}
+
+	mlog.Debug("Posted a reply received by email", mlog.String("user_id", user.Id), mlog.String("root_id", root.Id))
+
+	return nil
+}
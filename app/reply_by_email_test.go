@@ -0,0 +1,155 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zacmm/zacmm-server/model"
+)
+
+func TestStripQuotedReply(t *testing.T) {
+	for name, tc := range map[string]struct {
+		text     string
+		expected string
+	}{
+		"no quote":            {"Sounds good\r\n", "Sounds good"},
+		"quoted lines":        {"Sounds good\n\n> original message\n> more", "Sounds good"},
+		"attribution line":    {"Sounds good\n\nOn Mon, Jan 4, 2021 at 10:00 AM Someone <a@example.com> wrote:\n> original", "Sounds good"},
+		"wrapped attribution": {"Sounds good\n\nOn Mon, Jan 4, 2021 at 10:00 AM Someone <\na@example.com> wrote:\n\nsomething", "Sounds good"},
+		"outlook":             {"Sounds good\n\n-----Original Message-----\nFrom: someone", "Sounds good"},
+		"outlook separator":   {"Sounds good\n________________________________\nFrom: someone", "Sounds good"},
+		"outlook headers":     {"Sounds good\n\nFrom: Someone\nSent: Monday\nTo: me", "Sounds good"},
+		"signature":           {"Sounds good\n-- \nSome User\nSome Company", "Sounds good"},
+		"mobile signature":    {"Sounds good\n\nSent from my iPhone", "Sounds good"},
+		"multiple lines":      {"First line\nSecond line\n\n> quote", "First line\nSecond line"},
+		"only a quote":        {"> quote\n> more", ""},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, stripQuotedReply(tc.text))
+		})
+	}
+}
+
+func TestParseReplyByEmailAddress(t *testing.T) {
+	rootId := model.NewId()
+	signature := "abcdefghijklmnop"
+
+	for name, tc := range map[string]struct {
+		recipient string
+		ok        bool
+	}{
+		"valid":               {"reply+" + rootId + signature + "@example.com", true},
+		"different case":      {"Reply+" + rootId + signature + "@EXAMPLE.com", true},
+		"wrong domain":        {"reply+" + rootId + signature + "@example.org", false},
+		"wrong local part":    {"other+" + rootId + signature + "@example.com", false},
+		"missing token":       {"reply@example.com", false},
+		"truncated signature": {"reply+" + rootId + signature[1:] + "@example.com", false},
+		"not an address":      {"reply+" + rootId + signature, false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			parsedRootId, parsedSignature, ok := parseReplyByEmailAddress(tc.recipient, "reply@example.com")
+			require.Equal(t, tc.ok, ok)
+			if tc.ok {
+				assert.Equal(t, rootId, parsedRootId)
+				assert.Equal(t, signature, parsedSignature)
+			}
+		})
+	}
+}
+
+func TestHandleInboundEmail(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.EmailSettings.EnableReplyByEmail = true
+		*cfg.EmailSettings.ReplyByEmailAddress = "reply@example.com"
+	})
+
+	message := func(from, text string) []byte {
+		return []byte("From: " + from + "\r\nSubject: Re: Notification\r\n\r\n" + text + "\r\n")
+	}
+
+	t.Run("should generate a reply address for the thread", func(t *testing.T) {
+		address := th.App.getReplyByEmailAddress(th.BasicPost, th.BasicUser.Id)
+		assert.True(t, model.IsValidEmail(address))
+		assert.Len(t, address, len("reply+@example.com")+replyByEmailRootIdLength+replyByEmailSignatureLength)
+
+		reply := &model.Post{Id: model.NewId(), RootId: th.BasicPost.Id}
+		assert.Equal(t, address, th.App.getReplyByEmailAddress(reply, th.BasicUser.Id))
+		assert.NotEqual(t, address, th.App.getReplyByEmailAddress(th.BasicPost, th.BasicUser2.Id))
+
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.EmailSettings.EnableReplyByEmail = false })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.EmailSettings.EnableReplyByEmail = true })
+		assert.Empty(t, th.App.getReplyByEmailAddress(th.BasicPost, th.BasicUser.Id))
+	})
+
+	t.Run("should post the reply in the thread", func(t *testing.T) {
+		address := th.App.getReplyByEmailAddress(th.BasicPost, th.BasicUser.Id)
+
+		err := th.App.HandleInboundEmail([]string{address}, message(th.BasicUser.Email, "Replying by email\r\n\r\n> quoted notification"))
+		require.Nil(t, err)
+
+		thread, err := th.App.GetPostThread(th.BasicPost.Id, false)
+		require.Nil(t, err)
+
+		var found bool
+		for _, post := range thread.Posts {
+			if post.Message == "Replying by email" {
+				found = true
+				assert.Equal(t, th.BasicUser.Id, post.UserId)
+				assert.Equal(t, th.BasicPost.Id, post.RootId)
+				assert.Equal(t, th.BasicPost.ChannelId, post.ChannelId)
+			}
+		}
+		assert.True(t, found, "reply should be posted in the thread")
+	})
+
+	t.Run("should reject a reply from another user", func(t *testing.T) {
+		address := th.App.getReplyByEmailAddress(th.BasicPost, th.BasicUser.Id)
+
+		err := th.App.HandleInboundEmail([]string{address}, message(th.BasicUser2.Email, "Spoofed reply"))
+		require.NotNil(t, err)
+		assert.Equal(t, "app.reply_by_email.invalid_signature.app_error", err.Id)
+		assert.Equal(t, http.StatusForbidden, err.StatusCode)
+	})
+
+	t.Run("should reject a reply to another thread", func(t *testing.T) {
+		address := th.App.getReplyByEmailAddress(th.BasicPost, th.BasicUser.Id)
+		otherPost := th.CreatePost(th.BasicChannel)
+		forged := "reply+" + otherPost.Id + address[len("reply+")+replyByEmailRootIdLength:]
+
+		err := th.App.HandleInboundEmail([]string{forged}, message(th.BasicUser.Email, "Forged reply"))
+		require.NotNil(t, err)
+		assert.Equal(t, "app.reply_by_email.invalid_signature.app_error", err.Id)
+	})
+
+	t.Run("should reject an unknown recipient", func(t *testing.T) {
+		err := th.App.HandleInboundEmail([]string{"someone@example.com"}, message(th.BasicUser.Email, "Hello"))
+		require.NotNil(t, err)
+		assert.Equal(t, "app.reply_by_email.invalid_recipient.app_error", err.Id)
+	})
+
+	t.Run("should reject an empty reply", func(t *testing.T) {
+		address := th.App.getReplyByEmailAddress(th.BasicPost, th.BasicUser.Id)
+
+		err := th.App.HandleInboundEmail([]string{address}, message(th.BasicUser.Email, "> only the quote"))
+		require.NotNil(t, err)
+		assert.Equal(t, "app.reply_by_email.empty_message.app_error", err.Id)
+	})
+
+	t.Run("should reject a reply without permission to post", func(t *testing.T) {
+		privatePost := th.CreatePost(th.CreatePrivateChannel(th.BasicTeam))
+		address := th.App.getReplyByEmailAddress(privatePost, th.BasicUser2.Id)
+
+		err := th.App.HandleInboundEmail([]string{address}, message(th.BasicUser2.Email, "Not allowed"))
+		require.NotNil(t, err)
+		assert.Equal(t, http.StatusForbidden, err.StatusCode)
+	})
+}
@@ -0,0 +1,286 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/zacmm/zacmm-server/mlog"
+	"github.com/zacmm/zacmm-server/model"
+	"github.com/zacmm/zacmm-server/store"
+	"github.com/zacmm/zacmm-server/utils"
+)
+
+const (
+	savedSearchNotificationBatchSize = 100
+	// savedSearchAlertSearchSize is the page size of the searches that look
+	// for the new posts of a saved search.
+	savedSearchAlertSearchSize = 100
+	savedSearchAlertMaxLinks   = 10
+	// savedSearchIndexingDelay gives the search engine time to index new
+	// posts before saved searches look for them.
+	savedSearchIndexingDelay = time.Minute
+)
+
+func (a *App) GetSavedSearch(savedSearchId string) (*model.SavedSearch, *model.AppError) {
+	search, err := a.Srv().Store.SavedSearch().Get(savedSearchId)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("GetSavedSearch", "app.saved_search.get.app_error", nil, nfErr.Error(), http.StatusNotFound)
+		default:
+			return nil, model.NewAppError("GetSavedSearch", "app.saved_search.get.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return search, nil
+}
+
+func (a *App) GetSavedSearchesForUser(userId string) ([]*model.SavedSearch, *model.AppError) {
+	searches, err := a.Srv().Store.SavedSearch().GetForUser(userId)
+	if err != nil {
+		return nil, model.NewAppError("GetSavedSearchesForUser", "app.saved_search.get_for_user.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return searches, nil
+}
+
+// CreateSavedSearch saves a search for a user, who must be a member of the
+// team that the search runs on.
+func (a *App) CreateSavedSearch(search *model.SavedSearch) (*model.SavedSearch, *model.AppError) {
+	if member, appErr := a.GetTeamMember(search.TeamId, search.UserId); appErr != nil || member.DeleteAt != 0 {
+		return nil, model.NewAppError("CreateSavedSearch", "app.saved_search.create.not_team_member.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	searches, appErr := a.GetSavedSearchesForUser(search.UserId)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if len(searches) >= *a.Config().ServiceSettings.MaxSavedSearchesPerUser {
+		return nil, model.NewAppError("CreateSavedSearch", "app.saved_search.create.limit.app_error", map[string]interface{}{"Max": *a.Config().ServiceSettings.MaxSavedSearchesPerUser}, "", http.StatusBadRequest)
+	}
+
+	search.LastCheckedAt = 0
+	savedSearch, err := a.Srv().Store.SavedSearch().Save(search)
+	if err != nil {
+		var appErr *model.AppError
+		var invErr *store.ErrInvalidInput
+		switch {
+		case errors.As(err, &appErr):
+			return nil, appErr
+		case errors.As(err, &invErr):
+			return nil, model.NewAppError("CreateSavedSearch", "app.saved_search.save.existing.app_error", nil, invErr.Error(), http.StatusBadRequest)
+		default:
+			return nil, model.NewAppError("CreateSavedSearch", "app.saved_search.save.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return savedSearch, nil
+}
+
+// UpdateSavedSearch changes the name, the terms, the options and the
+// notifications of an existing saved search. Its owner and team can't be
+// changed.
+func (a *App) UpdateSavedSearch(search *model.SavedSearch) (*model.SavedSearch, *model.AppError) {
+	oldSearch, appErr := a.GetSavedSearch(search.Id)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	// Only notify about the posts made after the notifications were turned
+	// on, rather than about every post since the search was created.
+	if search.NotifyOnMatch && !oldSearch.NotifyOnMatch {
+		oldSearch.LastCheckedAt = model.GetMillis()
+	}
+
+	oldSearch.Name = search.Name
+	oldSearch.Terms = search.Terms
+	oldSearch.IsOrSearch = search.IsOrSearch
+	oldSearch.TimeZoneOffset = search.TimeZoneOffset
+	oldSearch.IncludeDeletedChannels = search.IncludeDeletedChannels
+	oldSearch.NotifyOnMatch = search.NotifyOnMatch
+
+	updatedSearch, err := a.Srv().Store.SavedSearch().Update(oldSearch)
+	if err != nil {
+		var appErr *model.AppError
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &appErr):
+			return nil, appErr
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("UpdateSavedSearch", "app.saved_search.update.app_error", nil, nfErr.Error(), http.StatusNotFound)
+		default:
+			return nil, model.NewAppError("UpdateSavedSearch", "app.saved_search.update.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return updatedSearch, nil
+}
+
+func (a *App) DeleteSavedSearch(savedSearchId string) *model.AppError {
+	if err := a.Srv().Store.SavedSearch().Delete(savedSearchId); err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return model.NewAppError("DeleteSavedSearch", "app.saved_search.delete.app_error", nil, nfErr.Error(), http.StatusNotFound)
+		default:
+			return model.NewAppError("DeleteSavedSearch", "app.saved_search.delete.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return nil
+}
+
+// SendSavedSearchAlerts runs every saved search that notifies its user about
+// new matches, and tells the user about the posts made since the last run.
+func (a *App) SendSavedSearchAlerts() *model.AppError {
+	if !*a.Config().ServiceSettings.EnablePostSearch {
+		return model.NewAppError("SendSavedSearchAlerts", "store.sql_post.search.disabled", nil, "", http.StatusNotImplemented)
+	}
+
+	checkedAt := model.GetMillis() - int64(savedSearchIndexingDelay/time.Millisecond)
+
+	afterId := ""
+	for {
+		searches, err := a.Srv().Store.SavedSearch().GetForNotification(afterId, savedSearchNotificationBatchSize)
+		if err != nil {
+			return model.NewAppError("SendSavedSearchAlerts", "app.saved_search.get_for_notification.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+
+		for _, search := range searches {
+			if appErr := a.checkSavedSearch(search, checkedAt); appErr != nil {
+				mlog.Warn("Unable to check saved search", mlog.String("saved_search_id", search.Id), mlog.Err(appErr))
+			}
+		}
+
+		if len(searches) < savedSearchNotificationBatchSize {
+			return nil
+		}
+		afterId = searches[len(searches)-1].Id
+	}
+}
+
+// checkSavedSearch alerts the owner of the saved search about the posts that
+// match it and were made between its last check and checkedAt.
+func (a *App) checkSavedSearch(search *model.SavedSearch, checkedAt int64) *model.AppError {
+	if search.LastCheckedAt >= checkedAt {
+		return nil
+	}
+
+	user, appErr := a.GetUser(search.UserId)
+	if appErr != nil {
+		return appErr
+	}
+
+	var posts []*model.Post
+	if user.DeleteAt == 0 {
+		terms := savedSearchTerms(search)
+		seen := make(map[string]bool)
+		for page := 0; ; page++ {
+			results, appErr := a.SearchPostsInTeamForUser(terms, search.UserId, search.TeamId, search.IsOrSearch, search.IncludeDeletedChannels, search.TimeZoneOffset, page, savedSearchAlertSearchSize)
+			if appErr != nil {
+				return appErr
+			}
+
+			for _, postId := range results.Order {
+				// posts made while paging shift the later pages
+				if seen[postId] {
+					continue
+				}
+				seen[postId] = true
+
+				post := results.Posts[postId]
+				if post.UserId != search.UserId && post.CreateAt > search.LastCheckedAt && post.CreateAt <= checkedAt {
+					posts = append(posts, post)
+				}
+			}
+
+			if len(results.Order) < savedSearchAlertSearchSize {
+				break
+			}
+		}
+	}
+
+	if len(posts) > 0 {
+		if appErr := a.postSavedSearchAlert(search, user, posts); appErr != nil {
+			return appErr
+		}
+	}
+
+	// The posts are checked again next time if the alert couldn't be sent.
+	if err := a.Srv().Store.SavedSearch().UpdateLastCheckedAt(search.Id, checkedAt); err != nil {
+		return model.NewAppError("checkSavedSearch", "app.saved_search.update.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// savedSearchTerms bounds the terms of the search to the posts made since it
+// was last checked, unless the terms are already bound to a later time.
+func savedSearchTerms(search *model.SavedSearch) string {
+	since := time.Unix(search.LastCheckedAt/1000-1, 0).In(time.FixedZone("", search.TimeZoneOffset))
+	bound := &model.SearchParams{
+		AfterDate:      since.Format("2006-01-02T15:04:05"),
+		TimeZoneOffset: search.TimeZoneOffset,
+	}
+
+	for _, params := range model.ParseSearchParams(search.Terms, search.TimeZoneOffset) {
+		if params.AfterDate != "" && params.GetAfterDateMillis() >= bound.GetAfterDateMillis() {
+			return search.Terms
+		}
+	}
+
+	return search.Terms + " after:" + bound.AfterDate
+}
+
+// postSavedSearchAlert sends the new matches of a saved search to its owner
+// as a direct message from the saved search bot.
+func (a *App) postSavedSearchAlert(search *model.SavedSearch, user *model.User, posts []*model.Post) *model.AppError {
+	team, appErr := a.GetTeam(search.TeamId)
+	if appErr != nil {
+		return appErr
+	}
+
+	T := utils.GetUserTranslations(user.Locale)
+
+	bot, appErr := a.getOrCreateBotByOwner(&model.Bot{
+		Username:    model.BOT_SAVED_SEARCH_USERNAME,
+		DisplayName: T("app.saved_search.bot_displayname"),
+		Description: T("app.saved_search.bot_description"),
+		OwnerId:     model.BOT_SAVED_SEARCH_USERNAME,
+	})
+	if appErr != nil {
+		return appErr
+	}
+
+	dm, appErr := a.GetOrCreateDirectChannel(bot.UserId, user.Id)
+	if appErr != nil {
+		return appErr
+	}
+
+	message := T("app.saved_search.alert", map[string]interface{}{"Name": search.Name, "Count": len(posts)})
+	for i, post := range posts {
+		if i == savedSearchAlertMaxLinks {
+			message += "\n" + T("app.saved_search.alert.more", map[string]interface{}{"Count": len(posts) - i})
+			break
+		}
+		message += "\n- " + a.GetSiteURL() + "/" + team.Name + "/pl/" + post.Id
+	}
+
+	alert := &model.Post{
+		UserId:    bot.UserId,
+		ChannelId: dm.Id,
+		Message:   message,
+	}
+
+	if _, appErr := a.CreatePost(alert, dm, false, false); appErr != nil {
+		return appErr
+	}
+
+	return nil
+}
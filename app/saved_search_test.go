@@ -0,0 +1,138 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zacmm/zacmm-server/model"
+)
+
+func TestCreateSavedSearch(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	search, err := th.App.CreateSavedSearch(&model.SavedSearch{UserId: th.BasicUser.Id, TeamId: th.BasicTeam.Id, Name: "Errors", Terms: "error"})
+	require.Nil(t, err)
+	assert.NotEmpty(t, search.Id)
+
+	t.Run("not a team member", func(t *testing.T) {
+		_, err := th.App.CreateSavedSearch(&model.SavedSearch{UserId: th.BasicUser.Id, TeamId: th.CreateTeam().Id, Name: "Errors", Terms: "error"})
+		require.NotNil(t, err)
+		assert.Equal(t, "app.saved_search.create.not_team_member.app_error", err.Id)
+	})
+
+	t.Run("limit reached", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.MaxSavedSearchesPerUser = 1 })
+
+		_, err := th.App.CreateSavedSearch(&model.SavedSearch{UserId: th.BasicUser.Id, TeamId: th.BasicTeam.Id, Name: "Warnings", Terms: "warning"})
+		require.NotNil(t, err)
+		assert.Equal(t, "app.saved_search.create.limit.app_error", err.Id)
+	})
+}
+
+func TestUpdateSavedSearch(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	search, err := th.App.CreateSavedSearch(&model.SavedSearch{UserId: th.BasicUser.Id, TeamId: th.BasicTeam.Id, Name: "Errors", Terms: "error"})
+	require.Nil(t, err)
+
+	updated, err := th.App.UpdateSavedSearch(&model.SavedSearch{Id: search.Id, UserId: th.BasicUser2.Id, TeamId: model.NewId(), Name: "Warnings", Terms: "warning", NotifyOnMatch: true})
+	require.Nil(t, err)
+	assert.Equal(t, "Warnings", updated.Name)
+	assert.Equal(t, "warning", updated.Terms)
+	assert.True(t, updated.NotifyOnMatch)
+	assert.Equal(t, th.BasicUser.Id, updated.UserId)
+	assert.Equal(t, th.BasicTeam.Id, updated.TeamId)
+	assert.Greater(t, updated.LastCheckedAt, search.LastCheckedAt, "only posts made after turning on the notifications match")
+}
+
+func TestCheckSavedSearch(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.AddUserToChannel(th.BasicUser2, th.BasicChannel)
+
+	search, err := th.App.CreateSavedSearch(&model.SavedSearch{UserId: th.BasicUser2.Id, TeamId: th.BasicTeam.Id, Name: "Outages", Terms: "outage", NotifyOnMatch: true})
+	require.Nil(t, err)
+
+	// The posts of the test helper are made in the past.
+	search.LastCheckedAt -= 60000
+	require.NoError(t, th.App.Srv().Store.SavedSearch().UpdateLastCheckedAt(search.Id, search.LastCheckedAt))
+
+	getAlerts := func(t *testing.T) []*model.Post {
+		bot, err := th.App.GetUserByUsername(model.BOT_SAVED_SEARCH_USERNAME)
+		if err != nil {
+			return nil
+		}
+
+		dm, err := th.App.GetOrCreateDirectChannel(bot.Id, th.BasicUser2.Id)
+		require.Nil(t, err)
+
+		posts, err := th.App.GetPosts(dm.Id, 0, 10)
+		require.Nil(t, err)
+		return posts.ToSlice()
+	}
+
+	th.CreateMessagePost(th.BasicChannel, "Nothing to see here")
+	checkedAt := model.GetMillis()
+	require.Nil(t, th.App.checkSavedSearch(search, checkedAt))
+	assert.Empty(t, getAlerts(t))
+
+	search, err = th.App.GetSavedSearch(search.Id)
+	require.Nil(t, err)
+	assert.Equal(t, checkedAt, search.LastCheckedAt)
+
+	search.LastCheckedAt -= 60000
+	post := th.CreateMessagePost(th.BasicChannel, "We have an outage")
+	require.Nil(t, th.App.checkSavedSearch(search, model.GetMillis()))
+	alerts := getAlerts(t)
+	require.Len(t, alerts, 1)
+	assert.Contains(t, alerts[0].Message, post.Id)
+
+	search, err = th.App.GetSavedSearch(search.Id)
+	require.Nil(t, err)
+	require.Nil(t, th.App.checkSavedSearch(search, model.GetMillis()))
+	assert.Len(t, getAlerts(t), 1, "posts are only alerted about once")
+}
+
+func TestSavedSearchTerms(t *testing.T) {
+	lastCheckedAt := time.Date(2021, time.March, 4, 10, 30, 15, 500*int(time.Millisecond), time.UTC)
+	search := &model.SavedSearch{
+		Terms:         "outage",
+		LastCheckedAt: model.GetMillisForTime(lastCheckedAt),
+	}
+
+	t.Run("should only search posts made since the last check", func(t *testing.T) {
+		terms := savedSearchTerms(search)
+		assert.Equal(t, "outage after:2021-03-04T10:30:14", terms)
+
+		params := model.ParseSearchParams(terms, 0)
+		require.Len(t, params, 1)
+		assert.Equal(t, model.GetMillisForTime(lastCheckedAt.Truncate(time.Second)), params[0].GetAfterDateMillis())
+	})
+
+	t.Run("should use the time zone of the search", func(t *testing.T) {
+		search := *search
+		search.TimeZoneOffset = 2 * 60 * 60
+		assert.Equal(t, "outage after:2021-03-04T12:30:14", savedSearchTerms(&search))
+	})
+
+	t.Run("should keep a later bound of the terms", func(t *testing.T) {
+		search := *search
+		search.Terms = "outage after:2021-03-05"
+		assert.Equal(t, "outage after:2021-03-05", savedSearchTerms(&search))
+	})
+
+	t.Run("should replace an earlier bound of the terms", func(t *testing.T) {
+		search := *search
+		search.Terms = "outage after:2021-03-01"
+		assert.Equal(t, "outage after:2021-03-01 after:2021-03-04T10:30:14", savedSearchTerms(&search))
+	})
+}
@@ -16,6 +16,7 @@ import (
 	"os/exec"
 	"path"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -87,6 +88,10 @@ type Server struct {
 
 	localModeServer *http.Server
 
+	replyByEmailServer      *mailservice.InboundServer
+	replyByEmailServerMutex sync.Mutex
+	replyByEmailListenerId  string
+
 	didFinishListen chan struct{}
 
 	goroutineCount      int32
@@ -121,6 +126,7 @@ type Server struct {
 	sessionCache            cache.Cache
 	seenPendingPostIdsCache cache.Cache
 	statusCache             cache.Cache
+	keywordWatchMatchers    *keywordWatchMatcherCache
 	configListenerId        string
 	licenseListenerId       string
 	logListenerId           string
@@ -180,6 +186,22 @@ type Server struct {
 	uploadLockMapMut sync.Mutex
 	uploadLockMap    map[string]bool
 
+	// The changes to the files are mirrored to the backend the files are
+	// migrated to, if any, so that none is missed by the migration.
+	fileStoreMirrorMut         sync.RWMutex
+	fileStoreMirrorSettings    *model.FileSettings
+	fileStoreMirrorMigrationId string
+
+	// These serialize the deduplication of uploaded files with the removal of
+	// the deduplicated copies that are no longer used, by content hash.
+	fileBlobLocks [256]sync.Mutex
+
+	// These make concurrent requests for a converted thumbnail or preview
+	// wait for a single conversion, and bound the conversions run at once.
+	previewVariantCallsMut sync.Mutex
+	previewVariantCalls    map[string]*previewVariantCall
+	previewVariantSema     chan struct{}
+
 	featureFlagSynchronizer      *config.FeatureFlagSynchronizer
 	featureFlagStop              chan struct{}
 	featureFlagStopped           chan struct{}
@@ -197,6 +219,8 @@ func NewServer(options ...Option) (*Server, error) {
 		licenseListeners:    map[string]func(*model.License, *model.License){},
 		hashSeed:            maphash.MakeSeed(),
 		uploadLockMap:       map[string]bool{},
+		previewVariantCalls: map[string]*previewVariantCall{},
+		previewVariantSema:  make(chan struct{}, runtime.NumCPU()),
 	}
 
 	for _, option := range options {
@@ -295,6 +319,7 @@ func NewServer(options ...Option) (*Server, error) {
 	s.statusCache = s.CacheProvider.NewCache(&cache.CacheOptions{
 		Size: model.STATUS_CACHE_SIZE,
 	})
+	s.keywordWatchMatchers = newKeywordWatchMatcherCache()
 
 	s.createPushNotificationsHub()
 
@@ -454,11 +479,6 @@ func NewServer(options ...Option) (*Server, error) {
 	model.AppErrorInit(utils.T)
 
 	s.timezones = timezones.New()
-	// Start email batching because it's not like the other jobs
-	s.AddConfigListener(func(_, _ *model.Config) {
-		s.EmailService.InitEmailBatching()
-	})
-
 	// Start plugin health check job
 	pluginsEnvironment := s.PluginsEnvironment
 	if pluginsEnvironment != nil {
@@ -742,6 +762,8 @@ func (s *Server) Shutdown() error {
 
 	s.StopHTTPServer()
 	s.stopLocalModeServer()
+	s.RemoveConfigListener(s.replyByEmailListenerId)
+	s.stopReplyByEmailServer()
 	// Push notification hub needs to be shutdown after HTTP server
 	// to prevent stray requests from generating a push notification after it's shut down.
 	s.StopPushNotificationsHubWorkers()
@@ -1106,6 +1128,27 @@ func (s *Server) Start() error {
 		}
 	}
 
+	if *s.Config().EmailSettings.EnableReplyByEmail {
+		if err := s.startReplyByEmailServer(); err != nil {
+			mlog.Error("Unable to start the reply by email server", mlog.Err(err))
+		}
+	}
+	s.replyByEmailListenerId = s.AddConfigListener(func(oldCfg, newCfg *model.Config) {
+		oldSettings, newSettings := oldCfg.EmailSettings, newCfg.EmailSettings
+		if *oldSettings.EnableReplyByEmail == *newSettings.EnableReplyByEmail &&
+			*oldSettings.ReplyByEmailAddress == *newSettings.ReplyByEmailAddress &&
+			*oldSettings.ReplyByEmailListenAddress == *newSettings.ReplyByEmailListenAddress {
+			return
+		}
+
+		s.stopReplyByEmailServer()
+		if *newSettings.EnableReplyByEmail {
+			if err := s.startReplyByEmailServer(); err != nil {
+				mlog.Error("Unable to start the reply by email server", mlog.Err(err))
+			}
+		}
+	})
+
 	return nil
 }
 
@@ -1138,6 +1181,40 @@ func (s *Server) stopLocalModeServer() {
 	}
 }
 
+func (s *Server) startReplyByEmailServer() error {
+	s.replyByEmailServerMutex.Lock()
+	defer s.replyByEmailServerMutex.Unlock()
+
+	settings := s.Config().EmailSettings
+	address := *settings.ReplyByEmailAddress
+	hostname := address[strings.LastIndex(address, "@")+1:]
+
+	a := New(ServerConnector(s))
+	server := mailservice.NewInboundServer(hostname, replyByEmailMaxMessageSize, func(_ string, to []string, data []byte) error {
+		if appErr := a.HandleInboundEmail(to, data); appErr != nil {
+			return appErr
+		}
+		return nil
+	})
+	if err := server.Start(*settings.ReplyByEmailListenAddress); err != nil {
+		return err
+	}
+
+	s.replyByEmailServer = server
+	mlog.Info("Reply by email server is listening", mlog.String("address", server.Addr().String()))
+	return nil
+}
+
+func (s *Server) stopReplyByEmailServer() {
+	s.replyByEmailServerMutex.Lock()
+	defer s.replyByEmailServerMutex.Unlock()
+
+	if s.replyByEmailServer != nil {
+		s.replyByEmailServer.Close()
+		s.replyByEmailServer = nil
+	}
+}
+
 func (a *App) OriginChecker() func(*http.Request) bool {
 	if allowed := *a.Config().ServiceSettings.AllowCorsFrom; allowed != "" {
 		if allowed != "*" {
@@ -1501,9 +1578,48 @@ func (s *Server) stopSearchEngine() {
 	}
 }
 
+// setFileStoreMirror sets the settings of the backend the changes made to the
+// files are mirrored to by the given migration, or stops mirroring them when
+// nil.
+func (s *Server) setFileStoreMirror(settings *model.FileSettings, migrationId string) {
+	s.fileStoreMirrorMut.Lock()
+	defer s.fileStoreMirrorMut.Unlock()
+	s.fileStoreMirrorSettings = settings
+	s.fileStoreMirrorMigrationId = migrationId
+}
+
+// recordFileStoreMirrorFailure records that a change to the files couldn't be
+// mirrored during the given migration, so that the node running it refuses to
+// switch to the new backend whichever node the change was made on.
+func (s *Server) recordFileStoreMirrorFailure(migrationId string) {
+	if err := s.Store.System().SaveOrUpdate(&model.System{Name: model.SYSTEM_FILE_STORE_MIRROR_FAILED_PREFIX + migrationId, Value: "true"}); err != nil {
+		mlog.Error("Failed to record the failure to mirror a file change", mlog.String("migration_id", migrationId), mlog.Err(err))
+	}
+}
+
 func (s *Server) FileBackend() (filesstore.FileBackend, *model.AppError) {
 	license := s.License()
-	return filesstore.NewFileBackend(&s.Config().FileSettings, license != nil && *license.Features.Compliance)
+	settings := &s.Config().FileSettings
+	backend, appErr := filesstore.NewFileBackend(settings, license != nil && *license.Features.Compliance)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	s.fileStoreMirrorMut.RLock()
+	mirrorSettings := s.fileStoreMirrorSettings
+	migrationId := s.fileStoreMirrorMigrationId
+	s.fileStoreMirrorMut.RUnlock()
+	// The configuration may already point to the backend the files were
+	// migrated to.
+	if mirrorSettings == nil || mirrorSettings.SameBackend(settings) {
+		return backend, nil
+	}
+
+	mirror, appErr := filesstore.NewFileBackend(mirrorSettings, license != nil && *license.Features.Compliance)
+	if appErr != nil {
+		return nil, appErr
+	}
+	return filesstore.NewMirroredFileBackend(backend, mirror, func() { s.recordFileStoreMirrorFailure(migrationId) }), nil
 }
 
 func (s *Server) TotalWebsocketConnections() int {
@@ -0,0 +1,92 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package slashcommands
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	goi18n "github.com/mattermost/go-i18n/i18n"
+	"github.com/zacmm/zacmm-server/app"
+	"github.com/zacmm/zacmm-server/model"
+)
+
+type StatusProvider struct {
+}
+
+const (
+	CMD_STATUS = "status"
+)
+
+func init() {
+	app.RegisterCommandProvider(&StatusProvider{})
+}
+
+func (me *StatusProvider) GetTrigger() string {
+	return CMD_STATUS
+}
+
+func (me *StatusProvider) GetCommand(a *app.App, T goi18n.TranslateFunc) *model.Command {
+	return &model.Command{
+		Trigger:          CMD_STATUS,
+		AutoComplete:     true,
+		AutoCompleteDesc: T("api.command_status.desc"),
+		AutoCompleteHint: T("api.command_status.hint"),
+		DisplayName:      T("api.command_status.name"),
+	}
+}
+
+func (me *StatusProvider) DoCommand(a *app.App, args *model.CommandArgs, message string) *model.CommandResponse {
+	message = strings.TrimSpace(message)
+	if message == "" {
+		return &model.CommandResponse{ResponseType: model.COMMAND_RESPONSE_TYPE_EPHEMERAL, Text: args.T("api.command_status.empty.app_error")}
+	}
+
+	if message == "clear" {
+		if err := a.RemoveCustomStatus(args.UserId); err != nil && err.StatusCode != http.StatusNotFound {
+			return &model.CommandResponse{ResponseType: model.COMMAND_RESPONSE_TYPE_EPHEMERAL, Text: args.T("api.command_status.clear.app_error")}
+		}
+		return &model.CommandResponse{ResponseType: model.COMMAND_RESPONSE_TYPE_EPHEMERAL, Text: args.T("api.command_status.clear.success")}
+	}
+
+	customStatus := parseCustomStatus(message)
+	if _, err := a.SetCustomStatus(args.UserId, customStatus); err != nil {
+		return &model.CommandResponse{ResponseType: model.COMMAND_RESPONSE_TYPE_EPHEMERAL, Text: args.T("api.command_status.set.app_error", map[string]interface{}{"Error": err.Message})}
+	}
+
+	return &model.CommandResponse{ResponseType: model.COMMAND_RESPONSE_TYPE_EPHEMERAL, Text: args.T("api.command_status.set.success")}
+}
+
+// parseCustomStatus reads "[:emoji:] text [for <duration>]". The trailing "for"
+// is only taken as an expiry when it is followed by a valid duration such as
+// "2h" or "30m", so "/status out for lunch" keeps its text intact.
+func parseCustomStatus(message string) *model.CustomStatus {
+	customStatus := &model.CustomStatus{}
+
+	if strings.HasPrefix(message, ":") {
+		fields := strings.SplitN(message, " ", 2)
+		if len(fields[0]) > 2 && strings.HasSuffix(fields[0], ":") {
+			customStatus.Emoji = strings.Trim(fields[0], ":")
+			message = ""
+			if len(fields) == 2 {
+				message = strings.TrimSpace(fields[1])
+			}
+		}
+	}
+
+	// Padding the message lets a leading "for" match as well, and the match's
+	// index in the padded message is where "for" starts in the original one.
+	if index := strings.LastIndex(" "+message, " for "); index != -1 {
+		duration, err := time.ParseDuration(strings.TrimSpace(message[index+len("for "):]))
+		if err == nil && duration > 0 {
+			customStatus.ExpiresAt = model.GetMillis() + duration.Milliseconds()
+			message = message[:index]
+		}
+	}
+
+	customStatus.Text = strings.TrimSpace(message)
+
+	return customStatus
+}
@@ -0,0 +1,60 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package slashcommands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zacmm/zacmm-server/model"
+)
+
+func TestParseCustomStatus(t *testing.T) {
+	for name, tc := range map[string]struct {
+		Message       string
+		Emoji         string
+		Text          string
+		ExpectsExpiry bool
+	}{
+		"text only":               {"In a meeting", "", "In a meeting", false},
+		"emoji and text":          {":calendar: In a meeting", "calendar", "In a meeting", false},
+		"emoji only":              {":palm_tree:", "palm_tree", "", false},
+		"text with expiry":        {"In a meeting for 1h", "", "In a meeting", true},
+		"emoji with expiry":       {":coffee: for 15m", "coffee", "", true},
+		"for without duration":    {"Out for lunch", "", "Out for lunch", false},
+		"colon not used as emoji": {": hello", "", ": hello", false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			customStatus := parseCustomStatus(tc.Message)
+			assert.Equal(t, tc.Emoji, customStatus.Emoji)
+			assert.Equal(t, tc.Text, customStatus.Text)
+			assert.Equal(t, tc.ExpectsExpiry, customStatus.ExpiresAt != 0)
+		})
+	}
+}
+
+func TestStatusCommand(t *testing.T) {
+	th := setup(t).initBasic()
+	defer th.tearDown()
+
+	cmd := &StatusProvider{}
+	args := &model.CommandArgs{T: func(s string, args ...interface{}) string { return s }, UserId: th.BasicUser.Id}
+
+	resp := cmd.DoCommand(th.App, args, ":calendar: In a meeting for 1h")
+	assert.Equal(t, "api.command_status.set.success", resp.Text)
+
+	customStatus, err := th.App.GetCustomStatus(th.BasicUser.Id)
+	require.Nil(t, err)
+	assert.Equal(t, "calendar", customStatus.Emoji)
+	assert.Equal(t, "In a meeting", customStatus.Text)
+	assert.NotZero(t, customStatus.ExpiresAt)
+
+	resp = cmd.DoCommand(th.App, args, "clear")
+	assert.Equal(t, "api.command_status.clear.success", resp.Text)
+
+	_, err = th.App.GetCustomStatus(th.BasicUser.Id)
+	require.NotNil(t, err)
+}
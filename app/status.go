@@ -200,6 +200,8 @@ func (a *App) SetStatusOnline(userId string, manual bool) {
 		status.Status = model.STATUS_ONLINE
 		status.Manual = false // for "online" there's no manual setting
 		status.LastActivityAt = model.GetMillis()
+		status.DNDEndTime = 0
+		status.PrevStatus = ""
 	}
 
 	a.AddStatusCache(status)
@@ -228,10 +230,14 @@ func (a *App) BroadcastStatus(status *model.Status) {
 		// this is considered a non-critical service and will be disabled when server busy.
 		return
 	}
+	a.Publish(newStatusChangeEvent(status))
+}
+
+func newStatusChangeEvent(status *model.Status) *model.WebSocketEvent {
 	event := model.NewWebSocketEvent(model.WEBSOCKET_EVENT_STATUS_CHANGE, "", "", status.UserId, nil)
 	event.Add("status", status.Status)
 	event.Add("user_id", status.UserId)
-	a.Publish(event)
+	return event
 }
 
 func (a *App) SetStatusOffline(userId string, manual bool) {
@@ -294,11 +300,89 @@ func (a *App) SetStatusDoNotDisturb(userId string) {
 
 	status.Status = model.STATUS_DND
 	status.Manual = true
+	status.DNDEndTime = 0
 
 	a.SaveAndBroadcastStatus(status)
 }
 
+// SetStatusDoNotDisturbTimed sets the user's status to Do Not Disturb until
+// endTime, after which the DND expiry job puts back the status they had
+// before.
+func (a *App) SetStatusDoNotDisturbTimed(userId string, endTime int64) *model.AppError {
+	if !*a.Config().ServiceSettings.EnableUserStatuses {
+		return nil
+	}
+
+	if endTime <= model.GetMillis() {
+		return model.NewAppError("SetStatusDoNotDisturbTimed", "app.status.dnd_end_time.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	status, err := a.GetStatus(userId)
+
+	if err != nil {
+		status = &model.Status{UserId: userId, Status: model.STATUS_OFFLINE, Manual: false, LastActivityAt: 0, ActiveChannel: ""}
+	}
+
+	if status.Status != model.STATUS_DND {
+		status.PrevStatus = status.Status
+	}
+	status.Status = model.STATUS_DND
+	status.Manual = true
+	status.DNDEndTime = endTime
+
+	a.SaveAndBroadcastStatus(status)
+
+	return nil
+}
+
+// RemoveExpiredDNDStatuses ends every timed Do Not Disturb whose end time has
+// passed, putting back the status that each user had before.
+func (a *App) RemoveExpiredDNDStatuses() *model.AppError {
+	for {
+		statuses, err := a.Srv().Store.Status().GetExpiredDND(model.GetMillis(), dndExpiryBatchSize)
+		if err != nil {
+			return model.NewAppError("RemoveExpiredDNDStatuses", "app.status.get_expired_dnd.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+
+		for _, status := range statuses {
+			if appErr := a.endDoNotDisturb(status); appErr != nil {
+				return appErr
+			}
+		}
+
+		if len(statuses) < dndExpiryBatchSize {
+			return nil
+		}
+	}
+}
+
+// endDoNotDisturb puts back the status that the user had before their timed
+// Do Not Disturb started.
+func (a *App) endDoNotDisturb(status *model.Status) *model.AppError {
+	status.Status = status.PrevStatus
+	if status.Status == "" || status.Status == model.STATUS_DND {
+		status.Status = model.STATUS_ONLINE
+	}
+	status.Manual = status.Status == model.STATUS_OUT_OF_OFFICE
+	status.DNDEndTime = 0
+	status.PrevStatus = ""
+
+	if err := a.Srv().Store.Status().SaveOrUpdate(status); err != nil {
+		return model.NewAppError("endDoNotDisturb", "app.status.save.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	a.AddStatusCache(status)
+	a.BroadcastStatus(status)
+
+	return nil
+}
+
 func (a *App) SaveAndBroadcastStatus(status *model.Status) {
+	if status.Status != model.STATUS_DND {
+		status.DNDEndTime = 0
+		status.PrevStatus = ""
+	}
+
 	a.AddStatusCache(status)
 
 	if err := a.Srv().Store.Status().SaveOrUpdate(status); err != nil {
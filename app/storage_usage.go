@@ -0,0 +1,127 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/zacmm/zacmm-server/mlog"
+	"github.com/zacmm/zacmm-server/model"
+	"github.com/zacmm/zacmm-server/store"
+)
+
+// storageQuota returns the quota of the given type of target, or 0 when their
+// storage is unlimited.
+func (a *App) storageQuota(targetType string) int64 {
+	if targetType == model.STORAGE_USAGE_TARGET_TEAM {
+		return *a.Config().FileSettings.TeamStorageQuota
+	}
+	return *a.Config().FileSettings.UserStorageQuota
+}
+
+// GetStorageUsage returns the storage used by a user or a team, which is
+// zero until they upload their first file.
+func (a *App) GetStorageUsage(targetType, targetId string) (*model.StorageUsage, *model.AppError) {
+	usage, err := a.Srv().Store.StorageUsage().Get(targetType, targetId)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			usage = &model.StorageUsage{TargetType: targetType, TargetId: targetId}
+		default:
+			return nil, model.NewAppError("GetStorageUsage", "app.storage_usage.get.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	usage.QuotaBytes = a.storageQuota(targetType)
+	return usage, nil
+}
+
+// GetStorageUsageReport returns a page of the storage usage of the users or
+// the teams, the largest first.
+func (a *App) GetStorageUsageReport(targetType string, page, perPage int) ([]*model.StorageUsage, *model.AppError) {
+	usages, err := a.Srv().Store.StorageUsage().GetAll(targetType, page*perPage, perPage)
+	if err != nil {
+		return nil, model.NewAppError("GetStorageUsageReport", "app.storage_usage.get_all.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	quota := a.storageQuota(targetType)
+	for _, usage := range usages {
+		usage.QuotaBytes = quota
+	}
+	return usages, nil
+}
+
+// RecomputeStorageUsage rebuilds the storage usage of all the users and teams
+// from the stored files.
+func (a *App) RecomputeStorageUsage() *model.AppError {
+	if err := a.Srv().Store.StorageUsage().Recompute(); err != nil {
+		return model.NewAppError("RecomputeStorageUsage", "app.storage_usage.recompute.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	return nil
+}
+
+// getStorageUsageTeamId returns the team that a file uploaded to the channel
+// counts against. Files uploaded to direct and group messages count against
+// the team they were uploaded from, if any.
+func (a *App) getStorageUsageTeamId(channelId, teamId string) string {
+	if channel, err := a.GetChannel(channelId); err == nil && channel.TeamId != "" {
+		return channel.TeamId
+	}
+	if model.IsValidId(teamId) {
+		return teamId
+	}
+	return ""
+}
+
+// storageQuotaTargets returns the user and the team, when there is one, that
+// have a quota, as a file uploaded by the user to the team counts against both.
+func (a *App) storageQuotaTargets(userId, teamId string) []*model.StorageUsage {
+	var targets []*model.StorageUsage
+	if a.storageQuota(model.STORAGE_USAGE_TARGET_USER) > 0 {
+		targets = append(targets, &model.StorageUsage{TargetType: model.STORAGE_USAGE_TARGET_USER, TargetId: userId})
+	}
+	if teamId != "" && a.storageQuota(model.STORAGE_USAGE_TARGET_TEAM) > 0 {
+		targets = append(targets, &model.StorageUsage{TargetType: model.STORAGE_USAGE_TARGET_TEAM, TargetId: teamId})
+	}
+	return targets
+}
+
+// checkStorageQuota returns an error when storing size more bytes would
+// exceed the quota of the user or of the team.
+func (a *App) checkStorageQuota(userId, teamId string, size int64) *model.AppError {
+	for _, target := range a.storageQuotaTargets(userId, teamId) {
+		usage, appErr := a.GetStorageUsage(target.TargetType, target.TargetId)
+		if appErr != nil {
+			return appErr
+		}
+		if usage.UsedBytes+size > usage.QuotaBytes {
+			return model.NewAppError("checkStorageQuota", "app.file.storage_quota."+usage.TargetType+"_exceeded.app_error",
+				map[string]interface{}{"Quota": usage.QuotaBytes}, "", http.StatusRequestEntityTooLarge)
+		}
+	}
+	return nil
+}
+
+// warnStorageQuota notifies the user when the file they just stored made
+// their usage, or the usage of their team, cross the warning threshold.
+func (a *App) warnStorageQuota(userId, teamId string, size int64) {
+	for _, target := range a.storageQuotaTargets(userId, teamId) {
+		usage, appErr := a.GetStorageUsage(target.TargetType, target.TargetId)
+		if appErr != nil {
+			mlog.Warn("Failed to get storage usage", mlog.String("target_type", target.TargetType), mlog.String("target_id", target.TargetId), mlog.Err(appErr))
+			continue
+		}
+
+		threshold := usage.QuotaBytes * int64(*a.Config().FileSettings.StorageWarningPercent) / 100
+		if usage.UsedBytes < threshold || usage.UsedBytes-size >= threshold {
+			continue
+		}
+
+		message := model.NewWebSocketEvent(model.WEBSOCKET_EVENT_STORAGE_QUOTA_WARNING, "", "", userId, nil)
+		message.Add("storage_usage", usage.ToJson())
+		a.Publish(message)
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zacmm/zacmm-server/model"
+)
+
+func TestStorageQuota(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	uploadFile := func(t *testing.T, userId string, size int) (*model.FileInfo, *model.AppError) {
+		info, appErr := th.App.DoUploadFile(time.Now(), "noteam", th.BasicChannel.Id, userId, "file.txt", bytes.Repeat([]byte("a"), size))
+		if appErr == nil {
+			t.Cleanup(func() {
+				th.App.Srv().Store.FileInfo().PermanentDelete(info.Id)
+				th.App.RemoveFile(info.Path)
+			})
+		}
+		return info, appErr
+	}
+
+	t.Run("should track the usage of the user and of the team of the channel", func(t *testing.T) {
+		userId := model.NewId()
+		info, appErr := uploadFile(t, userId, 10)
+		require.Nil(t, appErr)
+		assert.Equal(t, th.BasicTeam.Id, info.TeamId)
+
+		usage, appErr := th.App.GetStorageUsage(model.STORAGE_USAGE_TARGET_USER, userId)
+		require.Nil(t, appErr)
+		assert.Equal(t, int64(10), usage.UsedBytes)
+		assert.Equal(t, int64(1), usage.FileCount)
+		assert.Equal(t, int64(0), usage.QuotaBytes)
+
+		usage, appErr = th.App.GetStorageUsage(model.STORAGE_USAGE_TARGET_USER, model.NewId())
+		require.Nil(t, appErr)
+		assert.Equal(t, int64(0), usage.UsedBytes)
+	})
+
+	t.Run("should block uploads over the user quota", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.FileSettings.UserStorageQuota = 100 })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.FileSettings.UserStorageQuota = 0 })
+
+		userId := model.NewId()
+		_, appErr := uploadFile(t, userId, 60)
+		require.Nil(t, appErr)
+		_, appErr = uploadFile(t, userId, 40)
+		require.Nil(t, appErr)
+
+		_, appErr = uploadFile(t, userId, 1)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.file.storage_quota.user_exceeded.app_error", appErr.Id)
+
+		_, appErr = uploadFile(t, model.NewId(), 100)
+		require.Nil(t, appErr)
+	})
+
+	t.Run("should block uploads over the team quota", func(t *testing.T) {
+		usage, appErr := th.App.GetStorageUsage(model.STORAGE_USAGE_TARGET_TEAM, th.BasicTeam.Id)
+		require.Nil(t, appErr)
+
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.FileSettings.TeamStorageQuota = usage.UsedBytes + 50 })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.FileSettings.TeamStorageQuota = 0 })
+
+		_, appErr = uploadFile(t, model.NewId(), 30)
+		require.Nil(t, appErr)
+
+		_, appErr = uploadFile(t, model.NewId(), 30)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.file.storage_quota.team_exceeded.app_error", appErr.Id)
+	})
+
+	t.Run("should block upload sessions over the quota", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.FileSettings.UserStorageQuota = 100 })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.FileSettings.UserStorageQuota = 0 })
+
+		_, appErr := th.App.CreateUploadSession(&model.UploadSession{
+			Id:        model.NewId(),
+			Type:      model.UploadTypeAttachment,
+			UserId:    th.BasicUser.Id,
+			ChannelId: th.BasicChannel.Id,
+			Filename:  "upload",
+			FileSize:  101,
+		})
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.file.storage_quota.user_exceeded.app_error", appErr.Id)
+	})
+
+	t.Run("should report the largest users first", func(t *testing.T) {
+		userId := model.NewId()
+		_, appErr := uploadFile(t, userId, 1000)
+		require.Nil(t, appErr)
+
+		usages, appErr := th.App.GetStorageUsageReport(model.STORAGE_USAGE_TARGET_USER, 0, 1)
+		require.Nil(t, appErr)
+		require.Len(t, usages, 1)
+		assert.Equal(t, userId, usages[0].TargetId)
+	})
+
+	t.Run("should recompute the usage", func(t *testing.T) {
+		userId := model.NewId()
+		_, appErr := uploadFile(t, userId, 10)
+		require.Nil(t, appErr)
+
+		require.Nil(t, th.App.RecomputeStorageUsage())
+
+		usage, appErr := th.App.GetStorageUsage(model.STORAGE_USAGE_TARGET_USER, userId)
+		require.Nil(t, appErr)
+		assert.Equal(t, int64(10), usage.UsedBytes)
+	})
+}
@@ -4,6 +4,9 @@
 package app
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"io"
 	"net/http"
@@ -18,7 +21,10 @@ import (
 	"github.com/zacmm/zacmm-server/store"
 )
 
-const minFirstPartSize = 5 * 1024 * 1024 // 5MB
+const (
+	minFirstPartSize              = 5 * 1024 * 1024 // 5MB
+	uploadSessionCleanupBatchSize = 1000
+)
 
 func (a *App) runPluginsHook(info *model.FileInfo, file io.Reader) *model.AppError {
 	pluginsEnvironment := a.GetPluginsEnvironment()
@@ -100,6 +106,10 @@ func (a *App) runPluginsHook(info *model.FileInfo, file io.Reader) *model.AppErr
 }
 
 func (a *App) CreateUploadSession(us *model.UploadSession) (*model.UploadSession, *model.AppError) {
+	if us.Type == model.UploadTypePluginBundle {
+		return a.createPluginBundleUploadSession(us)
+	}
+
 	if us.FileSize > *a.Config().FileSettings.MaxFileSize {
 		return nil, model.NewAppError("CreateUploadSession", "app.upload.create.upload_too_large.app_error",
 			map[string]interface{}{"channelId": us.ChannelId}, "", http.StatusRequestEntityTooLarge)
@@ -123,6 +133,30 @@ func (a *App) CreateUploadSession(us *model.UploadSession) (*model.UploadSession
 			map[string]interface{}{"channelId": us.ChannelId}, "", http.StatusBadRequest)
 	}
 
+	if err := a.checkStorageQuota(us.UserId, a.getStorageUsageTeamId(us.ChannelId, ""), us.FileSize); err != nil {
+		return nil, err
+	}
+
+	us, storeErr := a.Srv().Store.UploadSession().Save(us)
+	if storeErr != nil {
+		return nil, model.NewAppError("CreateUploadSession", "app.upload.create.save.app_error", nil, storeErr.Error(), http.StatusInternalServerError)
+	}
+
+	return us, nil
+}
+
+// createPluginBundleUploadSession creates an upload session for a plugin
+// bundle. Plugin bundles don't belong to a channel and are removed once
+// installed, so they aren't subject to the storage quota.
+func (a *App) createPluginBundleUploadSession(us *model.UploadSession) (*model.UploadSession, *model.AppError) {
+	us.FileOffset = 0
+	us.ChannelId = ""
+	us.CreateAt = model.GetMillis()
+	us.Path = "plugin_uploads/" + us.Id + "/" + filepath.Base(us.Filename)
+	if err := us.IsValid(); err != nil {
+		return nil, err
+	}
+
 	us, storeErr := a.Srv().Store.UploadSession().Save(us)
 	if storeErr != nil {
 		return nil, model.NewAppError("CreateUploadSession", "app.upload.create.save.app_error", nil, storeErr.Error(), http.StatusInternalServerError)
@@ -156,48 +190,61 @@ func (a *App) GetUploadSessionsForUser(userId string) ([]*model.UploadSession, *
 	return uss, nil
 }
 
-func (a *App) UploadData(us *model.UploadSession, rd io.Reader) (*model.FileInfo, *model.AppError) {
-	// prevent more than one caller to upload data at the same time for a given upload session.
-	// This is to avoid possible inconsistencies.
+// lockUploadSession prevents more than one caller to upload data at the same
+// time for a given upload session, to avoid possible inconsistencies. The
+// returned function releases the lock.
+func (a *App) lockUploadSession(us *model.UploadSession) (func(), *model.AppError) {
 	a.Srv().uploadLockMapMut.Lock()
-	locked := a.Srv().uploadLockMap[us.Id]
-	if locked {
+	defer a.Srv().uploadLockMapMut.Unlock()
+	if a.Srv().uploadLockMap[us.Id] {
 		// session lock is already taken, return error.
-		a.Srv().uploadLockMapMut.Unlock()
 		return nil, model.NewAppError("UploadData", "app.upload.upload_data.concurrent.app_error",
 			nil, "", http.StatusBadRequest)
 	}
-	// grab the session lock.
 	a.Srv().uploadLockMap[us.Id] = true
-	a.Srv().uploadLockMapMut.Unlock()
 
-	// reset the session lock on exit.
-	defer func() {
+	return func() {
 		a.Srv().uploadLockMapMut.Lock()
 		delete(a.Srv().uploadLockMap, us.Id)
 		a.Srv().uploadLockMapMut.Unlock()
-	}()
+	}, nil
+}
 
+// writeUploadData writes a chunk of data to the file of the upload session and
+// updates its offset. If a checksum is given, the chunk is first written to a
+// temporary file and rejected without changing the offset when it doesn't match.
+func (a *App) writeUploadData(us *model.UploadSession, rd io.Reader, checksum string) *model.AppError {
 	// fetch the session from store to check for inconsistencies.
 	if storedSession, err := a.GetUploadSession(us.Id); err != nil {
-		return nil, err
+		return err
 	} else if us.FileOffset != storedSession.FileOffset {
-		return nil, model.NewAppError("UploadData", "app.upload.upload_data.concurrent.app_error",
+		return model.NewAppError("UploadData", "app.upload.upload_data.concurrent.app_error",
 			nil, "FileOffset mismatch", http.StatusBadRequest)
 	}
 
+	// nothing left to write, e.g. when retrying the completion of an upload.
+	if us.FileOffset >= us.FileSize {
+		return nil
+	}
+
 	// make sure it's not possible to upload more data than what is expected.
 	lr := &io.LimitedReader{
 		R: rd,
 		N: us.FileSize - us.FileOffset,
 	}
+
 	var err *model.AppError
 	var written int64
-	if us.FileOffset == 0 {
+	if checksum != "" {
+		written, err = a.writeVerifiedChunk(us, lr, checksum)
+		if err != nil {
+			return err
+		}
+	} else if us.FileOffset == 0 {
 		// new upload
 		written, err = a.WriteFile(lr, us.Path)
 		if err != nil && written == 0 {
-			return nil, err
+			return err
 		}
 		if written < minFirstPartSize && written != us.FileSize {
 			a.RemoveFile(us.Path)
@@ -205,20 +252,88 @@ func (a *App) UploadData(us *model.UploadSession, rd io.Reader) (*model.FileInfo
 			if err != nil {
 				errStr = err.Error()
 			}
-			return nil, model.NewAppError("UploadData", "app.upload.upload_data.first_part_too_small.app_error",
+			return model.NewAppError("UploadData", "app.upload.upload_data.first_part_too_small.app_error",
 				map[string]interface{}{"Size": minFirstPartSize}, errStr, http.StatusBadRequest)
 		}
-	} else if us.FileOffset < us.FileSize {
+	} else {
 		// resume upload
 		written, err = a.AppendFile(lr, us.Path)
 	}
 	if written > 0 {
 		us.FileOffset += written
+		us.UpdateAt = model.GetMillis()
 		if storeErr := a.Srv().Store.UploadSession().Update(us); storeErr != nil {
-			return nil, model.NewAppError("UploadData", "app.upload.upload_data.update.app_error", nil, storeErr.Error(), http.StatusInternalServerError)
+			return model.NewAppError("UploadData", "app.upload.upload_data.update.app_error", nil, storeErr.Error(), http.StatusInternalServerError)
 		}
 	}
+	return err
+}
+
+// writeVerifiedChunk stores a chunk next to the file of the upload session,
+// verifies it against the checksum and only then adds it to the file.
+func (a *App) writeVerifiedChunk(us *model.UploadSession, rd io.Reader, checksum string) (int64, *model.AppError) {
+	chunkPath := uploadChunkPath(us)
+	defer func() {
+		if exists, _ := a.FileExists(chunkPath); exists {
+			if fileErr := a.RemoveFile(chunkPath); fileErr != nil {
+				mlog.Error("Failed to remove file", mlog.Err(fileErr))
+			}
+		}
+	}()
+
+	hash := sha256.New()
+	written, err := a.WriteFile(io.TeeReader(rd, hash), chunkPath)
 	if err != nil {
+		return 0, err
+	}
+
+	if sum := hex.EncodeToString(hash.Sum(nil)); !strings.EqualFold(sum, checksum) {
+		return 0, model.NewAppError("UploadData", "app.upload.upload_data.checksum_mismatch.app_error",
+			nil, "expected="+checksum+", actual="+sum, http.StatusBadRequest)
+	}
+
+	if us.FileOffset == 0 {
+		if written < minFirstPartSize && written != us.FileSize {
+			return 0, model.NewAppError("UploadData", "app.upload.upload_data.first_part_too_small.app_error",
+				map[string]interface{}{"Size": minFirstPartSize}, "", http.StatusBadRequest)
+		}
+		if err := a.MoveFile(chunkPath, us.Path); err != nil {
+			return 0, err
+		}
+		return written, nil
+	}
+
+	chunk, err := a.FileReader(chunkPath)
+	if err != nil {
+		return 0, err
+	}
+	defer chunk.Close()
+
+	return a.AppendFile(chunk, us.Path)
+}
+
+// uploadChunkPath returns the path of the temporary file chunks of the upload
+// session are verified in.
+func uploadChunkPath(us *model.UploadSession) string {
+	return us.Path + ".chunk"
+}
+
+// UploadData writes a chunk of data to an attachment upload session. If a
+// checksum is given, the chunk is rejected when it doesn't match. Once the
+// upload is complete it creates and returns the FileInfo.
+func (a *App) UploadData(us *model.UploadSession, rd io.Reader, checksum string) (*model.FileInfo, *model.AppError) {
+	if us.Type == model.UploadTypePluginBundle {
+		return nil, model.NewAppError("UploadData", "app.upload.upload_data.type.app_error",
+			map[string]interface{}{"Type": us.Type}, "", http.StatusBadRequest)
+	}
+
+	unlock, err := a.lockUploadSession(us)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	if err := a.writeUploadData(us, rd, checksum); err != nil {
 		return nil, err
 	}
 
@@ -227,20 +342,56 @@ func (a *App) UploadData(us *model.UploadSession, rd io.Reader) (*model.FileInfo
 		return nil, nil
 	}
 
+	// upload is done, check the quota again as other files may have been
+	// uploaded in the meantime
+	teamId := a.getStorageUsageTeamId(us.ChannelId, "")
+	if err := a.checkStorageQuota(us.UserId, teamId, us.FileSize); err != nil {
+		if fileErr := a.RemoveFile(us.Path); fileErr != nil {
+			mlog.Error("Failed to remove file", mlog.Err(fileErr))
+		}
+		if storeErr := a.Srv().Store.UploadSession().Delete(us.Id); storeErr != nil {
+			mlog.Error("Failed to delete UploadSession", mlog.Err(storeErr))
+		}
+		return nil, err
+	}
+
+	// scan it before creating the FileInfo
+	if err := a.scanStoredFile(us.Path, us.Filename); err != nil {
+		if storeErr := a.Srv().Store.UploadSession().Delete(us.Id); storeErr != nil {
+			mlog.Error("Failed to delete UploadSession", mlog.Err(storeErr))
+		}
+		return nil, err
+	}
+
+	// strip the image metadata before creating the FileInfo, so that it
+	// describes the stored file
+	size, strippedMetadata, err := a.stripUploadMetadata(us)
+	if err != nil {
+		if fileErr := a.RemoveFile(us.Path); fileErr != nil {
+			mlog.Error("Failed to remove file", mlog.Err(fileErr))
+		}
+		if storeErr := a.Srv().Store.UploadSession().Delete(us.Id); storeErr != nil {
+			mlog.Error("Failed to delete UploadSession", mlog.Err(storeErr))
+		}
+		return nil, err
+	}
+
 	// upload is done, create FileInfo
 	file, err := a.FileReader(us.Path)
 	if err != nil {
 		return nil, model.NewAppError("UploadData", "app.upload.upload_data.read_file.app_error", nil, err.Error(), http.StatusInternalServerError)
 	}
 
-	info, err := model.GetInfoForBytes(us.Filename, file, int(us.FileSize))
+	info, err := model.GetInfoForBytes(us.Filename, file, int(size))
 	file.Close()
 	if err != nil {
 		return nil, err
 	}
 
+	info.StrippedMetadata = strippedMetadata
 	info.CreatorId = us.UserId
 	info.Path = us.Path
+	info.TeamId = teamId
 
 	// run plugins upload hook
 	if err := a.runPluginsHook(info, file); err != nil {
@@ -265,10 +416,15 @@ func (a *App) UploadData(us *model.UploadSession, rd io.Reader) (*model.FileInfo
 			return nil, fileErr
 		}
 		a.HandleImages([]string{info.PreviewPath}, []string{info.ThumbnailPath}, [][]byte{imgData})
+	} else {
+		a.generateFilePreview(info)
 	}
 
+	finishDeduplication := a.deduplicateFile(info)
 	var storeErr error
-	if info, storeErr = a.Srv().Store.FileInfo().Save(info); storeErr != nil {
+	info, storeErr = a.Srv().Store.FileInfo().Save(info)
+	finishDeduplication(storeErr == nil)
+	if storeErr != nil {
 		var appErr *model.AppError
 		switch {
 		case errors.As(storeErr, &appErr):
@@ -278,6 +434,9 @@ func (a *App) UploadData(us *model.UploadSession, rd io.Reader) (*model.FileInfo
 		}
 	}
 
+	a.warnStorageQuota(us.UserId, info.TeamId, info.Size)
+	a.extractContentInBackground(info)
+
 	// delete upload session
 	if storeErr := a.Srv().Store.UploadSession().Delete(us.Id); storeErr != nil {
 		mlog.Error("Failed to delete UploadSession", mlog.Err(storeErr))
@@ -285,3 +444,183 @@ func (a *App) UploadData(us *model.UploadSession, rd io.Reader) (*model.FileInfo
 
 	return info, nil
 }
+
+// stripUploadMetadata strips the metadata of a completed image upload,
+// writing the stripped image back in place. It returns the size of the stored
+// file and the kinds of metadata removed.
+func (a *App) stripUploadMetadata(us *model.UploadSession) (int64, model.StringArray, *model.AppError) {
+	info := &model.FileInfo{
+		Name:      us.Filename,
+		Extension: strings.TrimPrefix(strings.ToLower(filepath.Ext(us.Filename)), "."),
+	}
+	if !a.shouldStripImageMetadata(info) {
+		return us.FileSize, nil, nil
+	}
+
+	// The whole image is read into memory, so check its size against the
+	// current limit first.
+	if us.FileSize > *a.Config().FileSettings.MaxFileSize {
+		return 0, nil, model.NewAppError("stripUploadMetadata", "app.upload.create.upload_too_large.app_error",
+			map[string]interface{}{"channelId": us.ChannelId}, "", http.StatusRequestEntityTooLarge)
+	}
+
+	data, err := a.ReadFile(us.Path)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	stripped, _, err := a.stripImageMetadata(info, data)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if _, err := a.WriteFile(bytes.NewReader(stripped), us.Path); err != nil {
+		return 0, nil, err
+	}
+
+	return int64(len(stripped)), info.StrippedMetadata, nil
+}
+
+// UploadPluginBundleData writes a chunk of data to a plugin bundle upload
+// session. Once the bundle is complete it is installed, replacing an existing
+// plugin with the same id if replace is set, and its manifest is returned.
+// The bundle is kept if the installation fails, so that it can be retried
+// without uploading it again.
+func (a *App) UploadPluginBundleData(us *model.UploadSession, rd io.Reader, checksum string, replace bool) (*model.Manifest, *model.AppError) {
+	if us.Type != model.UploadTypePluginBundle {
+		return nil, model.NewAppError("UploadPluginBundleData", "app.upload.upload_data.type.app_error",
+			map[string]interface{}{"Type": us.Type}, "", http.StatusBadRequest)
+	}
+
+	unlock, err := a.lockUploadSession(us)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	if err := a.writeUploadData(us, rd, checksum); err != nil {
+		return nil, err
+	}
+
+	// upload is incomplete
+	if us.FileOffset != us.FileSize {
+		return nil, nil
+	}
+
+	file, err := a.FileReader(us.Path)
+	if err != nil {
+		return nil, model.NewAppError("UploadPluginBundleData", "app.upload.upload_data.read_file.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	manifest, err := a.InstallPlugin(file, replace)
+	file.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	if fileErr := a.RemoveFile(us.Path); fileErr != nil {
+		mlog.Error("Failed to remove file", mlog.Err(fileErr))
+	}
+	if storeErr := a.Srv().Store.UploadSession().Delete(us.Id); storeErr != nil {
+		mlog.Error("Failed to delete UploadSession", mlog.Err(storeErr))
+	}
+
+	return manifest, nil
+}
+
+// CleanupUploadSessions deletes the upload sessions that received no data
+// since the given time along with their files, unless data is being uploaded
+// to them. The files of completed uploads are kept when a FileInfo references
+// them. It returns the number of deleted sessions.
+//
+// Uploads are only locked on this node, so this assumes that a single node
+// serves the uploads of a session meanwhile. To narrow the window left for
+// uploads served by other nodes, each session is fetched again before it is
+// removed, and kept if it received data within the same timeout measured
+// from then.
+func (a *App) CleanupUploadSessions(updatedBefore int64) (int, *model.AppError) {
+	timeout := model.GetMillis() - updatedBefore
+	deleted := 0
+	for {
+		sessions, err := a.Srv().Store.UploadSession().GetUpdatedBefore(updatedBefore, uploadSessionCleanupBatchSize)
+		if err != nil {
+			return deleted, model.NewAppError("CleanupUploadSessions", "app.upload.get_updated_before.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+
+		batchDeleted := 0
+		for _, us := range sessions {
+			unlock, appErr := a.lockUploadSession(us)
+			if appErr != nil {
+				continue
+			}
+			removed, err := a.cleanupUploadSession(us.Id, model.GetMillis()-timeout)
+			unlock()
+			if err != nil {
+				return deleted, err
+			}
+			if removed {
+				deleted++
+				batchDeleted++
+			}
+		}
+
+		// The sessions skipped are returned again, so the cleanup stops once
+		// only those are left.
+		if len(sessions) < uploadSessionCleanupBatchSize || batchDeleted == 0 {
+			return deleted, nil
+		}
+	}
+}
+
+// cleanupUploadSession deletes the upload session and its files unless it
+// received data since the given time. It returns whether it was deleted.
+func (a *App) cleanupUploadSession(id string, updatedBefore int64) (bool, *model.AppError) {
+	us, err := a.Srv().Store.UploadSession().Get(id)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		if errors.As(err, &nfErr) {
+			return false, nil
+		}
+		return false, model.NewAppError("CleanupUploadSessions", "app.upload.get.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	if us.UpdateAt >= updatedBefore {
+		return false, nil
+	}
+
+	paths := []string{uploadChunkPath(us)}
+	if a.isUploadFileUnused(us) {
+		paths = append(paths, us.Path)
+	}
+	for _, path := range paths {
+		if exists, _ := a.FileExists(path); !exists {
+			continue
+		}
+		if fileErr := a.RemoveFile(path); fileErr != nil {
+			mlog.Warn("Failed to remove file of stale upload session", mlog.String("upload_id", us.Id), mlog.String("path", path), mlog.Err(fileErr))
+		}
+	}
+
+	if err := a.Srv().Store.UploadSession().Delete(us.Id); err != nil {
+		return false, model.NewAppError("CleanupUploadSessions", "app.upload.delete.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	return true, nil
+}
+
+// isUploadFileUnused returns whether the file of a stale upload session can
+// be removed: it is incomplete, a plugin bundle, or no FileInfo was saved for
+// it. It is kept when that can't be checked.
+func (a *App) isUploadFileUnused(us *model.UploadSession) bool {
+	if us.FileOffset < us.FileSize || us.Type == model.UploadTypePluginBundle {
+		return true
+	}
+
+	_, err := a.Srv().Store.FileInfo().GetByPath(us.Path)
+	if err == nil {
+		return false
+	}
+	var nfErr *store.ErrNotFound
+	if !errors.As(err, &nfErr) {
+		mlog.Warn("Failed to check whether the file of a stale upload session is used", mlog.String("upload_id", us.Id), mlog.Err(err))
+		return false
+	}
+	return true
+}
@@ -5,17 +5,23 @@ package app
 
 import (
 	"bytes"
+	"encoding/binary"
 	"io"
 	"io/ioutil"
 	"math/rand"
+	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
 
 	"github.com/zacmm/zacmm-server/model"
 	"github.com/zacmm/zacmm-server/utils/fileutils"
+	"github.com/zacmm/zacmm-server/utils/imgutils"
 
+	"github.com/rwcarlsen/goexif/exif"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -126,7 +132,7 @@ func TestUploadData(t *testing.T) {
 
 		u := *us
 		u.Path = ""
-		info, err := th.App.UploadData(&u, rd)
+		info, err := th.App.UploadData(&u, rd, "")
 		require.Nil(t, info)
 		require.NotNil(t, err)
 		require.NotEqual(t, "app.upload.upload_data.first_part_too_small.app_error", err.Id)
@@ -142,7 +148,7 @@ func TestUploadData(t *testing.T) {
 		require.False(t, ok)
 		require.Nil(t, err)
 
-		info, err := th.App.UploadData(us, rd)
+		info, err := th.App.UploadData(us, rd, "")
 		require.Nil(t, info)
 		require.NotNil(t, err)
 		require.Equal(t, "app.upload.upload_data.first_part_too_small.app_error", err.Id)
@@ -157,7 +163,7 @@ func TestUploadData(t *testing.T) {
 			R: bytes.NewReader(data),
 			N: 5 * 1024 * 1024,
 		}
-		info, err := th.App.UploadData(us, rd)
+		info, err := th.App.UploadData(us, rd, "")
 		require.Nil(t, info)
 		require.Nil(t, err)
 
@@ -165,7 +171,7 @@ func TestUploadData(t *testing.T) {
 			R: bytes.NewReader(data[5*1024*1024:]),
 			N: 3 * 1024 * 1024,
 		}
-		info, err = th.App.UploadData(us, rd)
+		info, err = th.App.UploadData(us, rd, "")
 		require.Nil(t, err)
 		require.NotEmpty(t, info)
 
@@ -180,7 +186,7 @@ func TestUploadData(t *testing.T) {
 		require.Nil(t, err)
 		require.NotEmpty(t, us)
 
-		info, err := th.App.UploadData(us, bytes.NewReader(data))
+		info, err := th.App.UploadData(us, bytes.NewReader(data), "")
 		require.Nil(t, err)
 		require.NotEmpty(t, info)
 
@@ -200,7 +206,7 @@ func TestUploadData(t *testing.T) {
 			R: bytes.NewReader(data),
 			N: 1024 * 1024,
 		}
-		info, err := th.App.UploadData(us, rd)
+		info, err := th.App.UploadData(us, rd, "")
 		require.Nil(t, err)
 		require.NotEmpty(t, info)
 
@@ -222,7 +228,7 @@ func TestUploadData(t *testing.T) {
 		require.Nil(t, err)
 		require.NotEmpty(t, us)
 
-		info, err := th.App.UploadData(us, bytes.NewReader(data))
+		info, err := th.App.UploadData(us, bytes.NewReader(data), "")
 		require.Nil(t, err)
 		require.NotEmpty(t, info)
 		require.NotZero(t, info.Width)
@@ -232,6 +238,90 @@ func TestUploadData(t *testing.T) {
 	})
 }
 
+func TestUploadDataStripImageMetadata(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.FileSettings.StripImageMetadata = true })
+
+	testDir, _ := fileutils.FindDir("tests")
+	data, err := ioutil.ReadFile(filepath.Join(testDir, "gps_test.jpeg"))
+	require.NoError(t, err)
+
+	newSession := func(t *testing.T, size int64) *model.UploadSession {
+		us, appErr := th.App.CreateUploadSession(&model.UploadSession{
+			Id:        model.NewId(),
+			Type:      model.UploadTypeAttachment,
+			UserId:    th.BasicUser.Id,
+			ChannelId: th.BasicChannel.Id,
+			Filename:  "photo.jpeg",
+			FileSize:  size,
+		})
+		require.Nil(t, appErr)
+		return us
+	}
+
+	t.Run("should strip the metadata of uploaded images", func(t *testing.T) {
+		us := newSession(t, int64(len(data)))
+
+		info, appErr := th.App.UploadData(us, bytes.NewReader(data), "")
+		require.Nil(t, appErr)
+		require.NotNil(t, info)
+		defer th.App.Srv().Store.FileInfo().PermanentDelete(info.Id)
+
+		assert.Equal(t, []string{imgutils.MetadataExif, imgutils.MetadataGPS}, []string(info.StrippedMetadata))
+
+		stored, appErr := th.App.ReadFile(info.Path)
+		require.Nil(t, appErr)
+		assert.Equal(t, int64(len(stored)), info.Size)
+		assert.Less(t, len(stored), len(data))
+		if x, err := exif.Decode(bytes.NewReader(stored)); err == nil {
+			_, _, err = x.LatLong()
+			assert.Error(t, err, "no location should be left")
+		}
+
+		saved, appErr := th.App.GetFileInfo(info.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, info.StrippedMetadata, saved.StrippedMetadata)
+		assert.Equal(t, info.Size, saved.Size)
+	})
+
+	t.Run("should reject images larger than the maximum file size", func(t *testing.T) {
+		us := newSession(t, int64(len(data)))
+		maxFileSize := *th.App.Config().FileSettings.MaxFileSize
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.FileSettings.MaxFileSize = int64(len(data)) - 1 })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.FileSettings.MaxFileSize = maxFileSize })
+
+		info, appErr := th.App.UploadData(us, bytes.NewReader(data), "")
+		require.NotNil(t, appErr)
+		assert.Nil(t, info)
+		assert.Equal(t, "app.upload.create.upload_too_large.app_error", appErr.Id)
+
+		ok, appErr := th.App.FileExists(us.Path)
+		require.Nil(t, appErr)
+		assert.False(t, ok)
+	})
+
+	t.Run("should reject images whose metadata can't be stripped", func(t *testing.T) {
+		// A stray byte after the first segment, which decoders skip but which
+		// stops the metadata from being stripped.
+		firstSegmentEnd := 4 + int(binary.BigEndian.Uint16(data[4:6]))
+		malformed := append(append(append([]byte{}, data[:firstSegmentEnd]...), 0), data[firstSegmentEnd:]...)
+		us := newSession(t, int64(len(malformed)))
+
+		info, appErr := th.App.UploadData(us, bytes.NewReader(malformed), "")
+		require.NotNil(t, appErr)
+		assert.Nil(t, info)
+		assert.Equal(t, "app.file.strip_image_metadata.app_error", appErr.Id)
+
+		ok, appErr := th.App.FileExists(us.Path)
+		require.Nil(t, appErr)
+		assert.False(t, ok)
+		_, storeErr := th.App.Srv().Store.UploadSession().Get(us.Id)
+		assert.Error(t, storeErr)
+	})
+}
+
 func TestUploadDataConcurrent(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
@@ -267,7 +357,7 @@ func TestUploadDataConcurrent(t *testing.T) {
 				N: 5 * 1024 * 1024,
 			}
 			u := *us
-			_, err := th.App.UploadData(&u, rd)
+			_, err := th.App.UploadData(&u, rd, "")
 			if err != nil && err.Id == "app.upload.upload_data.concurrent.app_error" {
 				atomic.AddInt32(&nErrs, 1)
 			}
@@ -291,7 +381,7 @@ func TestUploadDataConcurrent(t *testing.T) {
 			}
 			u := *us
 			u.FileOffset = 5 * 1024 * 1024
-			_, err := th.App.UploadData(&u, rd)
+			_, err := th.App.UploadData(&u, rd, "")
 			if err != nil && err.Id == "app.upload.upload_data.concurrent.app_error" {
 				atomic.AddInt32(&nErrs, 1)
 			}
@@ -307,3 +397,234 @@ func TestUploadDataConcurrent(t *testing.T) {
 	require.Nil(t, err)
 	require.Equal(t, data, d)
 }
+
+func TestUploadDataChecksum(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	us := &model.UploadSession{
+		Id:        model.NewId(),
+		Type:      model.UploadTypeAttachment,
+		UserId:    th.BasicUser.Id,
+		ChannelId: th.BasicChannel.Id,
+		Filename:  "upload",
+		FileSize:  8 * 1024 * 1024,
+	}
+
+	var err error
+	us, err = th.App.CreateUploadSession(us)
+	require.Nil(t, err)
+	require.NotEmpty(t, us)
+
+	data := make([]byte, us.FileSize)
+	_, err2 := rand.Read(data)
+	require.NoError(t, err2)
+	first, second := data[:5*1024*1024], data[5*1024*1024:]
+
+	t.Run("checksum mismatch", func(t *testing.T) {
+		u := *us
+		info, err := th.App.UploadData(&u, bytes.NewReader(first), model.ChunkChecksum(second))
+		require.Nil(t, info)
+		require.NotNil(t, err)
+		require.Equal(t, "app.upload.upload_data.checksum_mismatch.app_error", err.Id)
+
+		stored, err := th.App.GetUploadSession(us.Id)
+		require.Nil(t, err)
+		require.Zero(t, stored.FileOffset)
+
+		ok, err := th.App.FileExists(us.Path)
+		require.False(t, ok)
+		require.Nil(t, err)
+		ok, err = th.App.FileExists(us.Path + ".chunk")
+		require.False(t, ok)
+		require.Nil(t, err)
+	})
+
+	t.Run("resume with checksums", func(t *testing.T) {
+		info, err := th.App.UploadData(us, bytes.NewReader(first), model.ChunkChecksum(first))
+		require.Nil(t, err)
+		require.Nil(t, info)
+		require.Equal(t, int64(len(first)), us.FileOffset)
+
+		info, err = th.App.UploadData(us, bytes.NewReader(second), strings.ToUpper(model.ChunkChecksum(second)))
+		require.Nil(t, err)
+		require.NotEmpty(t, info)
+
+		d, err := th.App.ReadFile(us.Path)
+		require.Nil(t, err)
+		require.Equal(t, data, d)
+	})
+}
+
+func TestUploadPluginBundleData(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.PluginSettings.Enable = true
+		*cfg.PluginSettings.EnableUploads = true
+	})
+
+	path, _ := fileutils.FindDir("tests")
+	tarData, err := ioutil.ReadFile(filepath.Join(path, "testplugin.tar.gz"))
+	require.NoError(t, err)
+
+	us, appErr := th.App.CreateUploadSession(&model.UploadSession{
+		Id:       model.NewId(),
+		Type:     model.UploadTypePluginBundle,
+		UserId:   model.NewId(),
+		Filename: "testplugin.tar.gz",
+		FileSize: int64(len(tarData)),
+	})
+	require.Nil(t, appErr)
+	require.Empty(t, us.ChannelId)
+
+	t.Run("attachment upload not allowed", func(t *testing.T) {
+		u := *us
+		info, appErr := th.App.UploadData(&u, bytes.NewReader(tarData), "")
+		require.Nil(t, info)
+		require.NotNil(t, appErr)
+		require.Equal(t, "app.upload.upload_data.type.app_error", appErr.Id)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		manifest, appErr := th.App.UploadPluginBundleData(us, bytes.NewReader(tarData), model.ChunkChecksum(tarData), true)
+		defer os.RemoveAll("plugins/testplugin")
+		require.Nil(t, appErr)
+		require.NotNil(t, manifest)
+		require.Equal(t, "testplugin", manifest.Id)
+
+		_, appErr = th.App.GetUploadSession(us.Id)
+		require.NotNil(t, appErr)
+		ok, appErr := th.App.FileExists(us.Path)
+		require.Nil(t, appErr)
+		require.False(t, ok)
+	})
+}
+
+func TestCleanupUploadSessions(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	us := &model.UploadSession{
+		Id:        model.NewId(),
+		Type:      model.UploadTypeAttachment,
+		UserId:    th.BasicUser.Id,
+		ChannelId: th.BasicChannel.Id,
+		Filename:  "upload",
+		FileSize:  8 * 1024 * 1024,
+	}
+
+	var err error
+	us, err = th.App.CreateUploadSession(us)
+	require.Nil(t, err)
+
+	data := make([]byte, 5*1024*1024)
+	_, err2 := rand.Read(data)
+	require.NoError(t, err2)
+
+	info, err := th.App.UploadData(us, bytes.NewReader(data), "")
+	require.Nil(t, err)
+	require.Nil(t, info)
+
+	us, err = th.App.GetUploadSession(us.Id)
+	require.Nil(t, err)
+	require.GreaterOrEqual(t, us.UpdateAt, us.CreateAt)
+
+	t.Run("recently updated sessions are kept", func(t *testing.T) {
+		_, err := th.App.CleanupUploadSessions(us.UpdateAt)
+		require.Nil(t, err)
+
+		_, err = th.App.GetUploadSession(us.Id)
+		require.Nil(t, err)
+	})
+
+	t.Run("sessions being uploaded to are kept", func(t *testing.T) {
+		unlock, err := th.App.lockUploadSession(us)
+		require.Nil(t, err)
+		defer unlock()
+
+		_, err = th.App.CleanupUploadSessions(us.UpdateAt + 1)
+		require.Nil(t, err)
+
+		_, err = th.App.GetUploadSession(us.Id)
+		require.Nil(t, err)
+	})
+
+	t.Run("sessions updated since they were fetched are kept", func(t *testing.T) {
+		removed, err := th.App.cleanupUploadSession(us.Id, us.UpdateAt)
+		require.Nil(t, err)
+		require.False(t, removed)
+
+		_, err = th.App.GetUploadSession(us.Id)
+		require.Nil(t, err)
+		ok, err := th.App.FileExists(us.Path)
+		require.Nil(t, err)
+		require.True(t, ok)
+	})
+
+	t.Run("stale sessions are deleted with their files", func(t *testing.T) {
+		deleted, err := th.App.CleanupUploadSessions(us.UpdateAt + 1)
+		require.Nil(t, err)
+		require.GreaterOrEqual(t, deleted, 1)
+
+		_, err = th.App.GetUploadSession(us.Id)
+		require.NotNil(t, err)
+		ok, err := th.App.FileExists(us.Path)
+		require.Nil(t, err)
+		require.False(t, ok)
+	})
+
+	// createCompleted creates a session whose upload completed, but whose
+	// FileInfo may not have been saved.
+	createCompleted := func(t *testing.T) *model.UploadSession {
+		us, err := th.App.CreateUploadSession(&model.UploadSession{
+			Id:        model.NewId(),
+			Type:      model.UploadTypeAttachment,
+			UserId:    th.BasicUser.Id,
+			ChannelId: th.BasicChannel.Id,
+			Filename:  "upload",
+			FileSize:  1024,
+		})
+		require.Nil(t, err)
+		_, err = th.App.WriteFile(bytes.NewReader(make([]byte, 1024)), us.Path)
+		require.Nil(t, err)
+		us.FileOffset = us.FileSize
+		require.NoError(t, th.App.Srv().Store.UploadSession().Update(us))
+		return us
+	}
+
+	t.Run("the files of completed sessions are kept when referenced", func(t *testing.T) {
+		completed := createCompleted(t)
+		fileInfo, storeErr := th.App.Srv().Store.FileInfo().Save(&model.FileInfo{
+			CreatorId: th.BasicUser.Id,
+			Path:      completed.Path,
+			Name:      completed.Filename,
+		})
+		require.NoError(t, storeErr)
+		defer th.App.Srv().Store.FileInfo().PermanentDelete(fileInfo.Id)
+		defer th.App.RemoveFile(completed.Path)
+
+		_, err := th.App.CleanupUploadSessions(completed.UpdateAt + 1)
+		require.Nil(t, err)
+
+		_, err = th.App.GetUploadSession(completed.Id)
+		require.NotNil(t, err)
+		ok, err := th.App.FileExists(completed.Path)
+		require.Nil(t, err)
+		require.True(t, ok)
+	})
+
+	t.Run("the files of completed sessions are deleted when unreferenced", func(t *testing.T) {
+		completed := createCompleted(t)
+
+		_, err := th.App.CleanupUploadSessions(completed.UpdateAt + 1)
+		require.Nil(t, err)
+
+		_, err = th.App.GetUploadSession(completed.Id)
+		require.NotNil(t, err)
+		ok, err := th.App.FileExists(completed.Path)
+		require.Nil(t, err)
+		require.False(t, ok)
+	})
+}
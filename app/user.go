@@ -1723,7 +1723,17 @@ func (a *App) PermanentDeleteUser(user *model.User) *model.AppError {
 		mlog.Warn("Error getting file list for user from FileInfoStore", mlog.Err(err))
 	}
 
+	var contentHashes []string
 	for _, info := range infos {
+		a.removeFilePreviews(info)
+
+		// Deduplicated files may be shared with other users, so they are only
+		// removed once no file references them anymore.
+		if info.ContentHash != "" {
+			contentHashes = append(contentHashes, info.ContentHash)
+			continue
+		}
+
 		res, err := a.FileExists(info.Path)
 		if err != nil {
 			mlog.Warn(
@@ -1754,6 +1764,8 @@ func (a *App) PermanentDeleteUser(user *model.User) *model.AppError {
 		return model.NewAppError("PermanentDeleteUser", "app.file_info.permanent_delete_by_user.app_error", nil, ""+err.Error(), http.StatusInternalServerError)
 	}
 
+	a.removeUnusedFileBlobs(utils.RemoveDuplicatesFromStringArray(contentHashes))
+
 	if err := a.Srv().Store.User().PermanentDelete(user.Id); err != nil {
 		return model.NewAppError("PermanentDeleteUser", "app.user.permanent_delete.app_error", nil, err.Error(), http.StatusInternalServerError)
 	}
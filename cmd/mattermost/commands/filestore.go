@@ -0,0 +1,93 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/zacmm/zacmm-server/audit"
+	"github.com/zacmm/zacmm-server/model"
+)
+
+var FileStoreCmd = &cobra.Command{
+	Use:   "filestore",
+	Short: "Management of the file store",
+}
+
+var FileStoreMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Migrate the files to another file store",
+	Long: `Copy the files, custom emoji, profile images, team and bot icons, plugins and branding images to another file store, checking the checksum of each copy, then switch the configuration to it.
+The settings not given, including the S3 credentials, are those of the current configuration. Files already copied are skipped, so an interrupted migration can be resumed by running the command again.
+The changes made to the files by a running server aren't mirrored to the new file store by this command: stop the server before running it, or run the MigrateFileStore job instead.`,
+	Example: `  filestore migrate --driver amazons3 --s3-bucket mattermost --s3-endpoint minio.example.com:9000
+  filestore migrate --driver local --directory /var/mattermost/data`,
+	RunE: fileStoreMigrateCmdF,
+}
+
+// fileStoreMigrateFlags maps the flags of the migrate command to the keys of
+// the data of a file store migration job.
+var fileStoreMigrateFlags = map[string]string{
+	"driver":         model.FILE_STORE_MIGRATION_DRIVER_NAME,
+	"directory":      model.FILE_STORE_MIGRATION_DIRECTORY,
+	"s3-bucket":      model.FILE_STORE_MIGRATION_AMAZON_S3_BUCKET,
+	"s3-path-prefix": model.FILE_STORE_MIGRATION_AMAZON_S3_PATH_PREFIX,
+	"s3-region":      model.FILE_STORE_MIGRATION_AMAZON_S3_REGION,
+	"s3-endpoint":    model.FILE_STORE_MIGRATION_AMAZON_S3_ENDPOINT,
+	"s3-ssl":         model.FILE_STORE_MIGRATION_AMAZON_S3_SSL,
+	"s3-signv2":      model.FILE_STORE_MIGRATION_AMAZON_S3_SIGN_V2,
+	"s3-sse":         model.FILE_STORE_MIGRATION_AMAZON_S3_SSE,
+}
+
+func init() {
+	FileStoreMigrateCmd.Flags().String("driver", "", "Driver of the destination file store, local or amazons3.")
+	FileStoreMigrateCmd.Flags().String("directory", "", "Directory of the destination local file store.")
+	FileStoreMigrateCmd.Flags().String("s3-bucket", "", "Name of the destination S3 bucket.")
+	FileStoreMigrateCmd.Flags().String("s3-path-prefix", "", "Path prefix of the files in the destination S3 bucket.")
+	FileStoreMigrateCmd.Flags().String("s3-region", "", "Region of the destination S3 bucket.")
+	FileStoreMigrateCmd.Flags().String("s3-endpoint", "", "Endpoint of the destination S3 storage.")
+	FileStoreMigrateCmd.Flags().String("s3-ssl", "", "Whether to connect to the destination S3 storage with TLS, true or false.")
+	FileStoreMigrateCmd.Flags().String("s3-signv2", "", "Whether to sign the requests to the destination S3 storage with Signature Version 2, true or false.")
+	FileStoreMigrateCmd.Flags().String("s3-sse", "", "Whether to enable server-side encryption in the destination S3 bucket, true or false.")
+
+	FileStoreCmd.AddCommand(FileStoreMigrateCmd)
+	RootCmd.AddCommand(FileStoreCmd)
+}
+
+func fileStoreMigrateCmdF(command *cobra.Command, args []string) error {
+	a, err := InitDBCommandContextCobra(command)
+	if err != nil {
+		return err
+	}
+	defer a.Srv().Shutdown()
+
+	data := map[string]string{}
+	for flag, key := range fileStoreMigrateFlags {
+		if command.Flags().Changed(flag) {
+			data[key], _ = command.Flags().GetString(flag)
+		}
+	}
+
+	settings, appErr := model.FileStoreMigrationDestination(&a.Config().FileSettings, data)
+	if appErr != nil {
+		return appErr
+	}
+
+	report, appErr := a.MigrateFileStore(settings, func(report *model.FileStoreMigrationReport) {
+		CommandPrettyPrintln(fmt.Sprintf("Copied %d files, skipped %d already copied, %d missing, %d failed", report.Copied, report.Skipped, report.Missing, report.Failed))
+	})
+	if appErr != nil {
+		return appErr
+	}
+
+	CommandPrettyPrintln(fmt.Sprintf("Migrated %d files to the %s file store", report.Copied+report.Skipped, *settings.DriverName))
+
+	auditRec := a.MakeAuditRecord("migrateFileStore", audit.Success)
+	auditRec.AddMeta("driver", *settings.DriverName)
+	a.LogAuditRec(auditRec, nil)
+
+	return nil
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"fmt"
+
+	"github.com/zacmm/zacmm-server/audit"
+	"github.com/zacmm/zacmm-server/model"
+	"github.com/spf13/cobra"
+)
+
+var StorageCmd = &cobra.Command{
+	Use:   "storage",
+	Short: "Management of file storage",
+}
+
+var StorageRecomputeCmd = &cobra.Command{
+	Use:     "recompute",
+	Short:   "Recompute storage usage",
+	Long:    "Recompute the storage usage of all the users and teams from the stored files, and print the largest ones.",
+	Example: "  storage recompute --top 20",
+	RunE:    storageRecomputeCmdF,
+}
+
+func init() {
+	StorageRecomputeCmd.Flags().Int("top", 10, "Number of users and teams using the most storage to print.")
+
+	StorageCmd.AddCommand(StorageRecomputeCmd)
+	RootCmd.AddCommand(StorageCmd)
+}
+
+func storageRecomputeCmdF(command *cobra.Command, args []string) error {
+	a, err := InitDBCommandContextCobra(command)
+	if err != nil {
+		return err
+	}
+	defer a.Srv().Shutdown()
+
+	top, _ := command.Flags().GetInt("top")
+
+	if appErr := a.RecomputeStorageUsage(); appErr != nil {
+		return appErr
+	}
+
+	CommandPrettyPrintln("Recomputed storage usage")
+
+	for _, targetType := range []string{model.STORAGE_USAGE_TARGET_USER, model.STORAGE_USAGE_TARGET_TEAM} {
+		if top <= 0 {
+			break
+		}
+		usages, appErr := a.GetStorageUsageReport(targetType, 0, top)
+		if appErr != nil {
+			return appErr
+		}
+		for _, usage := range usages {
+			CommandPrettyPrintln(fmt.Sprintf("%s %s: %d bytes in %d files", usage.TargetType, usage.TargetId, usage.UsedBytes, usage.FileCount))
+		}
+	}
+
+	auditRec := a.MakeAuditRecord("recomputeStorageUsage", audit.Success)
+	a.LogAuditRec(auditRec, nil)
+
+	return nil
+}
@@ -48,6 +48,8 @@ func GenerateClientConfig(c *model.Config, telemetryID string, license *model.Li
 	props["EnableLatex"] = strconv.FormatBool(*c.ServiceSettings.EnableLatex)
 	props["ExtendSessionLengthWithActivity"] = strconv.FormatBool(*c.ServiceSettings.ExtendSessionLengthWithActivity)
 	props["ManagedResourcePaths"] = *c.ServiceSettings.ManagedResourcePaths
+	props["EnableKeywordWatches"] = strconv.FormatBool(*c.ServiceSettings.EnableKeywordWatches)
+	props["EnableSavedSearchAlerts"] = strconv.FormatBool(*c.ServiceSettings.EnableSavedSearchAlerts)
 
 	// This setting is only temporary, so keep using the old setting name for the mobile and web apps
 	props["ExperimentalEnablePostMetadata"] = "true"
@@ -70,6 +72,8 @@ func GenerateClientConfig(c *model.Config, telemetryID string, license *model.Li
 
 	props["SendEmailNotifications"] = strconv.FormatBool(*c.EmailSettings.SendEmailNotifications)
 	props["SendPushNotifications"] = strconv.FormatBool(*c.EmailSettings.SendPushNotifications)
+	props["EnableWebPushNotifications"] = strconv.FormatBool(*c.EmailSettings.EnableWebPushNotifications)
+	props["EnableNotificationEndpoints"] = strconv.FormatBool(*c.EmailSettings.EnableNotificationEndpoints)
 	props["RequireEmailVerification"] = strconv.FormatBool(*c.EmailSettings.RequireEmailVerification)
 	props["EnableEmailBatching"] = strconv.FormatBool(*c.EmailSettings.EnableEmailBatching)
 	props["EnablePreviewModeBanner"] = strconv.FormatBool(*c.EmailSettings.EnablePreviewModeBanner)
@@ -95,6 +99,7 @@ func GenerateClientConfig(c *model.Config, telemetryID string, license *model.Li
 	props["TimeBetweenUserTypingUpdatesMilliseconds"] = strconv.FormatInt(*c.ServiceSettings.TimeBetweenUserTypingUpdatesMilliseconds, 10)
 	props["EnableUserTypingMessages"] = strconv.FormatBool(*c.ServiceSettings.EnableUserTypingMessages)
 	props["EnableChannelViewedMessages"] = strconv.FormatBool(*c.ServiceSettings.EnableChannelViewedMessages)
+	props["EnableReadReceipts"] = strconv.FormatBool(*c.ServiceSettings.EnableReadReceipts)
 
 	props["RunJobs"] = strconv.FormatBool(*c.JobSettings.RunJobs)
 
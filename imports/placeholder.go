@@ -21,4 +21,43 @@ import (
 
 	// This is a placeholder so this package can be imported in Team Edition when it will be otherwise empty.
 	_ "github.com/zacmm/zacmm-server/jobs/product_notices"
+
+	// This is a placeholder so this package can be imported in Team Edition when it will be otherwise empty.
+	_ "github.com/zacmm/zacmm-server/jobs/pollclosing"
+
+	// This is a placeholder so this package can be imported in Team Edition when it will be otherwise empty.
+	_ "github.com/zacmm/zacmm-server/jobs/customstatusexpiry"
+
+	// This is a placeholder so this package can be imported in Team Edition when it will be otherwise empty.
+	_ "github.com/zacmm/zacmm-server/jobs/extractcontent"
+
+	// This is a placeholder so this package can be imported in Team Edition when it will be otherwise empty.
+	_ "github.com/zacmm/zacmm-server/jobs/emailqueue"
+
+	// This is a placeholder so this package can be imported in Team Edition when it will be otherwise empty.
+	_ "github.com/zacmm/zacmm-server/jobs/emaildigest"
+
+	// This is a placeholder so this package can be imported in Team Edition when it will be otherwise empty.
+	_ "github.com/zacmm/zacmm-server/jobs/dndschedule"
+
+	// This is a placeholder so this package can be imported in Team Edition when it will be otherwise empty.
+	_ "github.com/zacmm/zacmm-server/jobs/savedsearchalerts"
+
+	// This is a placeholder so this package can be imported in Team Edition when it will be otherwise empty.
+	_ "github.com/zacmm/zacmm-server/jobs/reencryptfiles"
+
+	// This is a placeholder so this package can be imported in Team Edition when it will be otherwise empty.
+	_ "github.com/zacmm/zacmm-server/jobs/deduplicatefiles"
+
+	// This is a placeholder so this package can be imported in Team Edition when it will be otherwise empty.
+	_ "github.com/zacmm/zacmm-server/jobs/scanfiles"
+
+	// This is a placeholder so this package can be imported in Team Edition when it will be otherwise empty.
+	_ "github.com/zacmm/zacmm-server/jobs/generatepreviews"
+
+	// This is a placeholder so this package can be imported in Team Edition when it will be otherwise empty.
+	_ "github.com/zacmm/zacmm-server/jobs/migratefilestore"
+
+	// This is a placeholder so this package can be imported in Team Edition when it will be otherwise empty.
+	_ "github.com/zacmm/zacmm-server/jobs/cleanupuploadsessions"
 )
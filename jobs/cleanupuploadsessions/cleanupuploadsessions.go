@@ -0,0 +1,19 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package cleanupuploadsessions
+
+import (
+	"github.com/zacmm/zacmm-server/app"
+	tjobs "github.com/zacmm/zacmm-server/jobs/interfaces"
+)
+
+type CleanupUploadSessionsJobInterfaceImpl struct {
+	App *app.App
+}
+
+func init() {
+	app.RegisterJobsCleanupUploadSessionsInterface(func(a *app.App) tjobs.CleanupUploadSessionsJobInterface {
+		return &CleanupUploadSessionsJobInterfaceImpl{a}
+	})
+}
@@ -0,0 +1,51 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package cleanupuploadsessions
+
+import (
+	"time"
+
+	"github.com/zacmm/zacmm-server/app"
+	"github.com/zacmm/zacmm-server/model"
+)
+
+const (
+	SchedFreqMinutes = 60
+)
+
+type Scheduler struct {
+	App *app.App
+}
+
+func (m *CleanupUploadSessionsJobInterfaceImpl) MakeScheduler() model.Scheduler {
+	return &Scheduler{m.App}
+}
+
+func (scheduler *Scheduler) Name() string {
+	return JobName + "Scheduler"
+}
+
+func (scheduler *Scheduler) JobType() string {
+	return model.JOB_TYPE_CLEANUP_UPLOAD_SESSIONS
+}
+
+func (scheduler *Scheduler) Enabled(cfg *model.Config) bool {
+	// Stale upload sessions are kept when UploadExpiryHours is 0.
+	return *cfg.FileSettings.UploadExpiryHours > 0
+}
+
+func (scheduler *Scheduler) NextScheduleTime(cfg *model.Config, now time.Time, pendingJobs bool, lastSuccessfulJob *model.Job) *time.Time {
+	nextTime := time.Now().Add(SchedFreqMinutes * time.Minute)
+	return &nextTime
+}
+
+func (scheduler *Scheduler) ScheduleJob(cfg *model.Config, pendingJobs bool, lastSuccessfulJob *model.Job) (*model.Job, *model.AppError) {
+	data := map[string]string{}
+
+	job, err := scheduler.App.Srv().Jobs.CreateJob(model.JOB_TYPE_CLEANUP_UPLOAD_SESSIONS, data)
+	if err != nil {
+		return nil, err
+	}
+	return job, nil
+}
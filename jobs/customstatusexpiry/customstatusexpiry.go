@@ -0,0 +1,19 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package customstatusexpiry
+
+import (
+	"github.com/zacmm/zacmm-server/app"
+	tjobs "github.com/zacmm/zacmm-server/jobs/interfaces"
+)
+
+type CustomStatusExpiryJobInterfaceImpl struct {
+	App *app.App
+}
+
+func init() {
+	app.RegisterJobsCustomStatusExpiryInterface(func(a *app.App) tjobs.CustomStatusExpiryJobInterface {
+		return &CustomStatusExpiryJobInterfaceImpl{a}
+	})
+}
@@ -0,0 +1,19 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package deduplicatefiles
+
+import (
+	"github.com/zacmm/zacmm-server/app"
+	tjobs "github.com/zacmm/zacmm-server/jobs/interfaces"
+)
+
+type DeduplicateFilesJobInterfaceImpl struct {
+	App *app.App
+}
+
+func init() {
+	app.RegisterJobsDeduplicateFilesInterface(func(a *app.App) tjobs.DeduplicateFilesJobInterface {
+		return &DeduplicateFilesJobInterfaceImpl{a}
+	})
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package deduplicatefiles
+
+import (
+	"time"
+
+	"github.com/zacmm/zacmm-server/app"
+	"github.com/zacmm/zacmm-server/model"
+)
+
+const (
+	SchedFreqMinutes = 10
+	SweepFreqHours   = 24
+)
+
+type Scheduler struct {
+	App *app.App
+}
+
+func (m *DeduplicateFilesJobInterfaceImpl) MakeScheduler() model.Scheduler {
+	return &Scheduler{m.App}
+}
+
+func (scheduler *Scheduler) Name() string {
+	return JobName + "Scheduler"
+}
+
+func (scheduler *Scheduler) JobType() string {
+	return model.JOB_TYPE_DEDUPLICATE_FILES
+}
+
+func (scheduler *Scheduler) Enabled(cfg *model.Config) bool {
+	return *cfg.FileSettings.EnableDeduplication
+}
+
+// NextScheduleTime schedules the migration of the existing files once, then
+// runs the job daily to remove the deduplicated copies left unused by the data
+// retention. Files uploaded in between are deduplicated at upload time.
+func (scheduler *Scheduler) NextScheduleTime(cfg *model.Config, now time.Time, pendingJobs bool, lastSuccessfulJob *model.Job) *time.Time {
+	if pendingJobs || lastSuccessfulJob != nil {
+		nextTime := time.Now().Add(SweepFreqHours * time.Hour)
+		return &nextTime
+	}
+
+	nextTime := time.Now().Add(SchedFreqMinutes * time.Minute)
+	return &nextTime
+}
+
+func (scheduler *Scheduler) ScheduleJob(cfg *model.Config, pendingJobs bool, lastSuccessfulJob *model.Job) (*model.Job, *model.AppError) {
+	// A run that is still pending will remove the unused copies.
+	if pendingJobs {
+		return nil, nil
+	}
+
+	data := map[string]string{}
+	// The files uploaded since the last run were deduplicated at upload time.
+	if lastSuccessfulJob != nil && lastSuccessfulJob.Data["end_time"] != "" {
+		data["start_time"] = lastSuccessfulJob.Data["end_time"]
+	}
+
+	job, err := scheduler.App.Srv().Jobs.CreateJob(model.JOB_TYPE_DEDUPLICATE_FILES, data)
+	if err != nil {
+		return nil, err
+	}
+	return job, nil
+}
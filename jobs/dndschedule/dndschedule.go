@@ -0,0 +1,19 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package dndschedule
+
+import (
+	"github.com/zacmm/zacmm-server/app"
+	tjobs "github.com/zacmm/zacmm-server/jobs/interfaces"
+)
+
+type DndScheduleJobInterfaceImpl struct {
+	App *app.App
+}
+
+func init() {
+	app.RegisterJobsDndScheduleInterface(func(a *app.App) tjobs.DndScheduleJobInterface {
+		return &DndScheduleJobInterfaceImpl{a}
+	})
+}
@@ -0,0 +1,19 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package emaildigest
+
+import (
+	"github.com/zacmm/zacmm-server/app"
+	tjobs "github.com/zacmm/zacmm-server/jobs/interfaces"
+)
+
+type EmailDigestJobInterfaceImpl struct {
+	App *app.App
+}
+
+func init() {
+	app.RegisterJobsEmailDigestInterface(func(a *app.App) tjobs.EmailDigestJobInterface {
+		return &EmailDigestJobInterfaceImpl{a}
+	})
+}
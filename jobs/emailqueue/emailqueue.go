@@ -0,0 +1,19 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package emailqueue
+
+import (
+	"github.com/zacmm/zacmm-server/app"
+	tjobs "github.com/zacmm/zacmm-server/jobs/interfaces"
+)
+
+type EmailQueueJobInterfaceImpl struct {
+	App *app.App
+}
+
+func init() {
+	app.RegisterJobsEmailQueueInterface(func(a *app.App) tjobs.EmailQueueJobInterface {
+		return &EmailQueueJobInterfaceImpl{a}
+	})
+}
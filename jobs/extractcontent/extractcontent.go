@@ -0,0 +1,19 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package extractcontent
+
+import (
+	"github.com/zacmm/zacmm-server/app"
+	tjobs "github.com/zacmm/zacmm-server/jobs/interfaces"
+)
+
+type ExtractContentJobInterfaceImpl struct {
+	App *app.App
+}
+
+func init() {
+	app.RegisterJobsExtractContentInterface(func(a *app.App) tjobs.ExtractContentJobInterface {
+		return &ExtractContentJobInterfaceImpl{a}
+	})
+}
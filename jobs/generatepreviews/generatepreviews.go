@@ -0,0 +1,19 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package generatepreviews
+
+import (
+	"github.com/zacmm/zacmm-server/app"
+	tjobs "github.com/zacmm/zacmm-server/jobs/interfaces"
+)
+
+type GeneratePreviewsJobInterfaceImpl struct {
+	App *app.App
+}
+
+func init() {
+	app.RegisterJobsGeneratePreviewsInterface(func(a *app.App) tjobs.GeneratePreviewsJobInterface {
+		return &GeneratePreviewsJobInterfaceImpl{a}
+	})
+}
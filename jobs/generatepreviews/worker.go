@@ -0,0 +1,130 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package generatepreviews
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/zacmm/zacmm-server/app"
+	"github.com/zacmm/zacmm-server/jobs"
+	"github.com/zacmm/zacmm-server/mlog"
+	"github.com/zacmm/zacmm-server/model"
+)
+
+const (
+	JobName = "GeneratePreviews"
+)
+
+type Worker struct {
+	name      string
+	stop      chan bool
+	stopped   chan bool
+	jobs      chan model.Job
+	jobServer *jobs.JobServer
+	app       *app.App
+}
+
+func (m *GeneratePreviewsJobInterfaceImpl) MakeWorker() model.Worker {
+	worker := Worker{
+		name:      JobName,
+		stop:      make(chan bool, 1),
+		stopped:   make(chan bool, 1),
+		jobs:      make(chan model.Job),
+		jobServer: m.App.Srv().Jobs,
+		app:       m.App,
+	}
+	return &worker
+}
+
+func (worker *Worker) Run() {
+	mlog.Debug("Worker started", mlog.String("worker", worker.name))
+
+	defer func() {
+		mlog.Debug("Worker finished", mlog.String("worker", worker.name))
+		worker.stopped <- true
+	}()
+
+	for {
+		select {
+		case <-worker.stop:
+			mlog.Debug("Worker received stop signal", mlog.String("worker", worker.name))
+			return
+		case job := <-worker.jobs:
+			mlog.Debug("Worker received a new candidate job.", mlog.String("worker", worker.name))
+			worker.DoJob(&job)
+		}
+	}
+}
+
+func (worker *Worker) Stop() {
+	mlog.Debug("Worker stopping", mlog.String("worker", worker.name))
+	worker.stop <- true
+	<-worker.stopped
+}
+
+func (worker *Worker) JobChannel() chan<- model.Job {
+	return worker.jobs
+}
+
+func (worker *Worker) DoJob(job *model.Job) {
+	if claimed, err := worker.jobServer.ClaimJob(job); err != nil {
+		mlog.Warn("Worker experienced an error while trying to claim job",
+			mlog.String("worker", worker.name),
+			mlog.String("job_id", job.Id),
+			mlog.String("error", err.Error()))
+		return
+	} else if !claimed {
+		return
+	}
+
+	var startTime int64
+	if job.Data["start_time"] != "" {
+		var parseErr error
+		if startTime, parseErr = strconv.ParseInt(job.Data["start_time"], 10, 64); parseErr != nil {
+			worker.setJobError(job, model.NewAppError("GeneratePreviewsWorker", "generatepreviews.worker.do_job.invalid_input.start_time", nil, parseErr.Error(), http.StatusBadRequest))
+			return
+		}
+	}
+
+	endTime := model.GetMillis()
+	if job.Data["end_time"] != "" {
+		var parseErr error
+		if endTime, parseErr = strconv.ParseInt(job.Data["end_time"], 10, 64); parseErr != nil {
+			worker.setJobError(job, model.NewAppError("GeneratePreviewsWorker", "generatepreviews.worker.do_job.invalid_input.end_time", nil, parseErr.Error(), http.StatusBadRequest))
+			return
+		}
+	}
+
+	generated, err := worker.app.GenerateFilePreviews(startTime, endTime)
+	if job.Data == nil {
+		job.Data = make(map[string]string)
+	}
+	job.Data["generated"] = strconv.Itoa(generated)
+	if updateErr := worker.jobServer.UpdateInProgressJobData(job); updateErr != nil {
+		mlog.Warn("Worker: Failed to update the number of generated previews", mlog.String("worker", worker.name), mlog.String("job_id", job.Id), mlog.String("error", updateErr.Error()))
+	}
+
+	if err != nil {
+		mlog.Error("Worker: Failed to generate file previews", mlog.String("worker", worker.name), mlog.String("job_id", job.Id), mlog.String("error", err.Error()))
+		worker.setJobError(job, err)
+		return
+	}
+
+	mlog.Info("Worker: Job is complete", mlog.String("worker", worker.name), mlog.String("job_id", job.Id))
+	worker.setJobSuccess(job)
+}
+
+func (worker *Worker) setJobSuccess(job *model.Job) {
+	if err := worker.app.Srv().Jobs.SetJobSuccess(job); err != nil {
+		mlog.Error("Worker: Failed to set success for job", mlog.String("worker", worker.name), mlog.String("job_id", job.Id), mlog.String("error", err.Error()))
+		worker.setJobError(job, err)
+	}
+}
+
+func (worker *Worker) setJobError(job *model.Job, appError *model.AppError) {
+	if err := worker.app.Srv().Jobs.SetJobError(job, appError); err != nil {
+		mlog.Error("Worker: Failed to set job error", mlog.String("worker", worker.name), mlog.String("job_id", job.Id), mlog.String("error", err.Error()))
+	}
+}
@@ -0,0 +1,12 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package interfaces
+
+import "github.com/zacmm/zacmm-server/model"
+
+// MigrateFileStoreJobInterface has no scheduler, as the file store migration
+// jobs are only created by the system admins.
+type MigrateFileStoreJobInterface interface {
+	MakeWorker() model.Worker
+}
@@ -156,6 +156,97 @@ func (watcher *Watcher) PollAndNotify() {
 				default:
 				}
 			}
+		} else if job.Type == model.JOB_TYPE_POLL_CLOSING {
+			if watcher.workers.PollClosing != nil {
+				select {
+				case watcher.workers.PollClosing.JobChannel() <- *job:
+				default:
+				}
+			}
+		} else if job.Type == model.JOB_TYPE_CUSTOM_STATUS_EXPIRY {
+			if watcher.workers.CustomStatusExpiry != nil {
+				select {
+				case watcher.workers.CustomStatusExpiry.JobChannel() <- *job:
+				default:
+				}
+			}
+		} else if job.Type == model.JOB_TYPE_EXTRACT_CONTENT {
+			if watcher.workers.ExtractContent != nil {
+				select {
+				case watcher.workers.ExtractContent.JobChannel() <- *job:
+				default:
+				}
+			}
+		} else if job.Type == model.JOB_TYPE_EMAIL_QUEUE {
+			if watcher.workers.EmailQueue != nil {
+				select {
+				case watcher.workers.EmailQueue.JobChannel() <- *job:
+				default:
+				}
+			}
+		} else if job.Type == model.JOB_TYPE_EMAIL_DIGEST {
+			if watcher.workers.EmailDigest != nil {
+				select {
+				case watcher.workers.EmailDigest.JobChannel() <- *job:
+				default:
+				}
+			}
+		} else if job.Type == model.JOB_TYPE_DND_SCHEDULE {
+			if watcher.workers.DndSchedule != nil {
+				select {
+				case watcher.workers.DndSchedule.JobChannel() <- *job:
+				default:
+				}
+			}
+		} else if job.Type == model.JOB_TYPE_SAVED_SEARCH_ALERTS {
+			if watcher.workers.SavedSearchAlerts != nil {
+				select {
+				case watcher.workers.SavedSearchAlerts.JobChannel() <- *job:
+				default:
+				}
+			}
+		} else if job.Type == model.JOB_TYPE_REENCRYPT_FILES {
+			if watcher.workers.ReencryptFiles != nil {
+				select {
+				case watcher.workers.ReencryptFiles.JobChannel() <- *job:
+				default:
+				}
+			}
+		} else if job.Type == model.JOB_TYPE_DEDUPLICATE_FILES {
+			if watcher.workers.DeduplicateFiles != nil {
+				select {
+				case watcher.workers.DeduplicateFiles.JobChannel() <- *job:
+				default:
+				}
+			}
+		} else if job.Type == model.JOB_TYPE_SCAN_FILES {
+			if watcher.workers.ScanFiles != nil {
+				select {
+				case watcher.workers.ScanFiles.JobChannel() <- *job:
+				default:
+				}
+			}
+		} else if job.Type == model.JOB_TYPE_GENERATE_PREVIEWS {
+			if watcher.workers.GeneratePreviews != nil {
+				select {
+				case watcher.workers.GeneratePreviews.JobChannel() <- *job:
+				default:
+				}
+			}
+		} else if job.Type == model.JOB_TYPE_MIGRATE_FILE_STORE {
+			if watcher.workers.MigrateFileStore != nil {
+				select {
+				case watcher.workers.MigrateFileStore.JobChannel() <- *job:
+				default:
+				}
+			}
+		} else if job.Type == model.JOB_TYPE_CLEANUP_UPLOAD_SESSIONS {
+			if watcher.workers.CleanupUploadSessions != nil {
+				select {
+				case watcher.workers.CleanupUploadSessions.JobChannel() <- *job:
+				default:
+				}
+			}
 		}
 	}
 }
@@ -0,0 +1,19 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package migratefilestore
+
+import (
+	"github.com/zacmm/zacmm-server/app"
+	tjobs "github.com/zacmm/zacmm-server/jobs/interfaces"
+)
+
+type MigrateFileStoreJobInterfaceImpl struct {
+	App *app.App
+}
+
+func init() {
+	app.RegisterJobsMigrateFileStoreInterface(func(a *app.App) tjobs.MigrateFileStoreJobInterface {
+		return &MigrateFileStoreJobInterfaceImpl{a}
+	})
+}
@@ -0,0 +1,19 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package pollclosing
+
+import (
+	"github.com/zacmm/zacmm-server/app"
+	tjobs "github.com/zacmm/zacmm-server/jobs/interfaces"
+)
+
+type PollClosingJobInterfaceImpl struct {
+	App *app.App
+}
+
+func init() {
+	app.RegisterJobsPollClosingInterface(func(a *app.App) tjobs.PollClosingJobInterface {
+		return &PollClosingJobInterfaceImpl{a}
+	})
+}
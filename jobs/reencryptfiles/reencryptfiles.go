@@ -0,0 +1,19 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package reencryptfiles
+
+import (
+	"github.com/zacmm/zacmm-server/app"
+	tjobs "github.com/zacmm/zacmm-server/jobs/interfaces"
+)
+
+type ReencryptFilesJobInterfaceImpl struct {
+	App *app.App
+}
+
+func init() {
+	app.RegisterJobsReencryptFilesInterface(func(a *app.App) tjobs.ReencryptFilesJobInterface {
+		return &ReencryptFilesJobInterfaceImpl{a}
+	})
+}
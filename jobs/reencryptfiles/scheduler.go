@@ -0,0 +1,65 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package reencryptfiles
+
+import (
+	"time"
+
+	"github.com/zacmm/zacmm-server/app"
+	"github.com/zacmm/zacmm-server/model"
+	"github.com/zacmm/zacmm-server/services/filesstore"
+)
+
+const (
+	SchedFreqMinutes = 10
+)
+
+type Scheduler struct {
+	App *app.App
+}
+
+func (m *ReencryptFilesJobInterfaceImpl) MakeScheduler() model.Scheduler {
+	return &Scheduler{m.App}
+}
+
+func (scheduler *Scheduler) Name() string {
+	return JobName + "Scheduler"
+}
+
+func (scheduler *Scheduler) JobType() string {
+	return model.JOB_TYPE_REENCRYPT_FILES
+}
+
+func (scheduler *Scheduler) Enabled(cfg *model.Config) bool {
+	return *cfg.FileSettings.EnableEncryptionAtRest
+}
+
+// NextScheduleTime schedules the job when the encryption gets enabled, and
+// again whenever the key is rotated, so that all the files end up encrypted
+// with the current key.
+func (scheduler *Scheduler) NextScheduleTime(cfg *model.Config, now time.Time, pendingJobs bool, lastSuccessfulJob *model.Job) *time.Time {
+	if pendingJobs {
+		return nil
+	}
+
+	if lastSuccessfulJob != nil {
+		key, err := filesstore.ParseEncryptionKey(*cfg.FileSettings.EncryptionKey)
+		if err != nil || lastSuccessfulJob.Data["key_id"] == filesstore.EncryptionKeyId(key) {
+			return nil
+		}
+	}
+
+	nextTime := time.Now().Add(SchedFreqMinutes * time.Minute)
+	return &nextTime
+}
+
+func (scheduler *Scheduler) ScheduleJob(cfg *model.Config, pendingJobs bool, lastSuccessfulJob *model.Job) (*model.Job, *model.AppError) {
+	data := map[string]string{}
+
+	job, err := scheduler.App.Srv().Jobs.CreateJob(model.JOB_TYPE_REENCRYPT_FILES, data)
+	if err != nil {
+		return nil, err
+	}
+	return job, nil
+}
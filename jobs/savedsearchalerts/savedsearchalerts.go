@@ -0,0 +1,19 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package savedsearchalerts
+
+import (
+	"github.com/zacmm/zacmm-server/app"
+	tjobs "github.com/zacmm/zacmm-server/jobs/interfaces"
+)
+
+type SavedSearchAlertsJobInterfaceImpl struct {
+	App *app.App
+}
+
+func init() {
+	app.RegisterJobsSavedSearchAlertsInterface(func(a *app.App) tjobs.SavedSearchAlertsJobInterface {
+		return &SavedSearchAlertsJobInterfaceImpl{a}
+	})
+}
@@ -0,0 +1,19 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package scanfiles
+
+import (
+	"github.com/zacmm/zacmm-server/app"
+	tjobs "github.com/zacmm/zacmm-server/jobs/interfaces"
+)
+
+type ScanFilesJobInterfaceImpl struct {
+	App *app.App
+}
+
+func init() {
+	app.RegisterJobsScanFilesInterface(func(a *app.App) tjobs.ScanFilesJobInterface {
+		return &ScanFilesJobInterfaceImpl{a}
+	})
+}
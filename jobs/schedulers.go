@@ -77,6 +77,54 @@ func (srv *JobServer) InitSchedulers() *Schedulers {
 		schedulers.schedulers = append(schedulers.schedulers, cloudInterface.MakeScheduler())
 	}
 
+	if pollClosingInterface := srv.PollClosing; pollClosingInterface != nil {
+		schedulers.schedulers = append(schedulers.schedulers, pollClosingInterface.MakeScheduler())
+	}
+
+	if customStatusExpiryInterface := srv.CustomStatusExpiry; customStatusExpiryInterface != nil {
+		schedulers.schedulers = append(schedulers.schedulers, customStatusExpiryInterface.MakeScheduler())
+	}
+
+	if extractContentInterface := srv.ExtractContent; extractContentInterface != nil {
+		schedulers.schedulers = append(schedulers.schedulers, extractContentInterface.MakeScheduler())
+	}
+
+	if emailQueueInterface := srv.EmailQueue; emailQueueInterface != nil {
+		schedulers.schedulers = append(schedulers.schedulers, emailQueueInterface.MakeScheduler())
+	}
+
+	if emailDigestInterface := srv.EmailDigest; emailDigestInterface != nil {
+		schedulers.schedulers = append(schedulers.schedulers, emailDigestInterface.MakeScheduler())
+	}
+
+	if dndScheduleInterface := srv.DndSchedule; dndScheduleInterface != nil {
+		schedulers.schedulers = append(schedulers.schedulers, dndScheduleInterface.MakeScheduler())
+	}
+
+	if savedSearchAlertsInterface := srv.SavedSearchAlerts; savedSearchAlertsInterface != nil {
+		schedulers.schedulers = append(schedulers.schedulers, savedSearchAlertsInterface.MakeScheduler())
+	}
+
+	if reencryptFilesInterface := srv.ReencryptFiles; reencryptFilesInterface != nil {
+		schedulers.schedulers = append(schedulers.schedulers, reencryptFilesInterface.MakeScheduler())
+	}
+
+	if deduplicateFilesInterface := srv.DeduplicateFiles; deduplicateFilesInterface != nil {
+		schedulers.schedulers = append(schedulers.schedulers, deduplicateFilesInterface.MakeScheduler())
+	}
+
+	if scanFilesInterface := srv.ScanFiles; scanFilesInterface != nil {
+		schedulers.schedulers = append(schedulers.schedulers, scanFilesInterface.MakeScheduler())
+	}
+
+	if generatePreviewsInterface := srv.GeneratePreviews; generatePreviewsInterface != nil {
+		schedulers.schedulers = append(schedulers.schedulers, generatePreviewsInterface.MakeScheduler())
+	}
+
+	if cleanupUploadSessionsInterface := srv.CleanupUploadSessions; cleanupUploadSessionsInterface != nil {
+		schedulers.schedulers = append(schedulers.schedulers, cleanupUploadSessionsInterface.MakeScheduler())
+	}
+
 	schedulers.nextRunTimes = make([]*time.Time, len(schedulers.schedulers))
 	return schedulers
 }
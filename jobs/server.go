@@ -29,6 +29,19 @@ type JobServer struct {
 	ProductNotices          tjobs.ProductNoticesJobInterface
 	ActiveUsers             tjobs.ActiveUsersJobInterface
 	Cloud                   ejobs.CloudJobInterface
+	PollClosing             tjobs.PollClosingJobInterface
+	CustomStatusExpiry      tjobs.CustomStatusExpiryJobInterface
+	ExtractContent          tjobs.ExtractContentJobInterface
+	EmailQueue              tjobs.EmailQueueJobInterface
+	EmailDigest             tjobs.EmailDigestJobInterface
+	DndSchedule             tjobs.DndScheduleJobInterface
+	SavedSearchAlerts       tjobs.SavedSearchAlertsJobInterface
+	ReencryptFiles          tjobs.ReencryptFilesJobInterface
+	DeduplicateFiles        tjobs.DeduplicateFilesJobInterface
+	ScanFiles               tjobs.ScanFilesJobInterface
+	GeneratePreviews        tjobs.GeneratePreviewsJobInterface
+	MigrateFileStore        tjobs.MigrateFileStoreJobInterface
+	CleanupUploadSessions   tjobs.CleanupUploadSessionsJobInterface
 }
 
 func NewJobServer(configService configservice.ConfigService, store store.Store) *JobServer {
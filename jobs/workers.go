@@ -28,6 +28,19 @@ type Workers struct {
 	ProductNotices           model.Worker
 	ActiveUsers              model.Worker
 	Cloud                    model.Worker
+	PollClosing              model.Worker
+	CustomStatusExpiry       model.Worker
+	ExtractContent           model.Worker
+	EmailQueue               model.Worker
+	EmailDigest              model.Worker
+	DndSchedule              model.Worker
+	SavedSearchAlerts        model.Worker
+	ReencryptFiles           model.Worker
+	DeduplicateFiles         model.Worker
+	ScanFiles                model.Worker
+	GeneratePreviews         model.Worker
+	MigrateFileStore         model.Worker
+	CleanupUploadSessions    model.Worker
 
 	listenerId string
 }
@@ -86,6 +99,58 @@ func (srv *JobServer) InitWorkers() *Workers {
 		workers.Cloud = cloudInterface.MakeWorker()
 	}
 
+	if pollClosingInterface := srv.PollClosing; pollClosingInterface != nil {
+		workers.PollClosing = pollClosingInterface.MakeWorker()
+	}
+
+	if customStatusExpiryInterface := srv.CustomStatusExpiry; customStatusExpiryInterface != nil {
+		workers.CustomStatusExpiry = customStatusExpiryInterface.MakeWorker()
+	}
+
+	if extractContentInterface := srv.ExtractContent; extractContentInterface != nil {
+		workers.ExtractContent = extractContentInterface.MakeWorker()
+	}
+
+	if emailQueueInterface := srv.EmailQueue; emailQueueInterface != nil {
+		workers.EmailQueue = emailQueueInterface.MakeWorker()
+	}
+
+	if emailDigestInterface := srv.EmailDigest; emailDigestInterface != nil {
+		workers.EmailDigest = emailDigestInterface.MakeWorker()
+	}
+
+	if dndScheduleInterface := srv.DndSchedule; dndScheduleInterface != nil {
+		workers.DndSchedule = dndScheduleInterface.MakeWorker()
+	}
+
+	if savedSearchAlertsInterface := srv.SavedSearchAlerts; savedSearchAlertsInterface != nil {
+		workers.SavedSearchAlerts = savedSearchAlertsInterface.MakeWorker()
+	}
+
+	if reencryptFilesInterface := srv.ReencryptFiles; reencryptFilesInterface != nil {
+		workers.ReencryptFiles = reencryptFilesInterface.MakeWorker()
+	}
+
+	if deduplicateFilesInterface := srv.DeduplicateFiles; deduplicateFilesInterface != nil {
+		workers.DeduplicateFiles = deduplicateFilesInterface.MakeWorker()
+	}
+
+	if scanFilesInterface := srv.ScanFiles; scanFilesInterface != nil {
+		workers.ScanFiles = scanFilesInterface.MakeWorker()
+	}
+
+	if generatePreviewsInterface := srv.GeneratePreviews; generatePreviewsInterface != nil {
+		workers.GeneratePreviews = generatePreviewsInterface.MakeWorker()
+	}
+
+	if migrateFileStoreInterface := srv.MigrateFileStore; migrateFileStoreInterface != nil {
+		workers.MigrateFileStore = migrateFileStoreInterface.MakeWorker()
+	}
+
+	if cleanupUploadSessionsInterface := srv.CleanupUploadSessions; cleanupUploadSessionsInterface != nil {
+		workers.CleanupUploadSessions = cleanupUploadSessionsInterface.MakeWorker()
+	}
+
 	return workers
 }
 
@@ -141,6 +206,58 @@ func (workers *Workers) Start() *Workers {
 			go workers.Cloud.Run()
 		}
 
+		if workers.PollClosing != nil {
+			go workers.PollClosing.Run()
+		}
+
+		if workers.CustomStatusExpiry != nil {
+			go workers.CustomStatusExpiry.Run()
+		}
+
+		if workers.ExtractContent != nil {
+			go workers.ExtractContent.Run()
+		}
+
+		if workers.EmailQueue != nil {
+			go workers.EmailQueue.Run()
+		}
+
+		if workers.EmailDigest != nil {
+			go workers.EmailDigest.Run()
+		}
+
+		if workers.DndSchedule != nil {
+			go workers.DndSchedule.Run()
+		}
+
+		if workers.SavedSearchAlerts != nil {
+			go workers.SavedSearchAlerts.Run()
+		}
+
+		if workers.ReencryptFiles != nil {
+			go workers.ReencryptFiles.Run()
+		}
+
+		if workers.DeduplicateFiles != nil {
+			go workers.DeduplicateFiles.Run()
+		}
+
+		if workers.ScanFiles != nil {
+			go workers.ScanFiles.Run()
+		}
+
+		if workers.GeneratePreviews != nil {
+			go workers.GeneratePreviews.Run()
+		}
+
+		if workers.MigrateFileStore != nil {
+			go workers.MigrateFileStore.Run()
+		}
+
+		if workers.CleanupUploadSessions != nil {
+			go workers.CleanupUploadSessions.Run()
+		}
+
 		go workers.Watcher.Start()
 	})
 
@@ -253,6 +370,58 @@ func (workers *Workers) Stop() *Workers {
 		workers.Cloud.Stop()
 	}
 
+	if workers.PollClosing != nil {
+		workers.PollClosing.Stop()
+	}
+
+	if workers.CustomStatusExpiry != nil {
+		workers.CustomStatusExpiry.Stop()
+	}
+
+	if workers.ExtractContent != nil {
+		workers.ExtractContent.Stop()
+	}
+
+	if workers.EmailQueue != nil {
+		workers.EmailQueue.Stop()
+	}
+
+	if workers.EmailDigest != nil {
+		workers.EmailDigest.Stop()
+	}
+
+	if workers.DndSchedule != nil {
+		workers.DndSchedule.Stop()
+	}
+
+	if workers.SavedSearchAlerts != nil {
+		workers.SavedSearchAlerts.Stop()
+	}
+
+	if workers.ReencryptFiles != nil {
+		workers.ReencryptFiles.Stop()
+	}
+
+	if workers.DeduplicateFiles != nil {
+		workers.DeduplicateFiles.Stop()
+	}
+
+	if workers.ScanFiles != nil {
+		workers.ScanFiles.Stop()
+	}
+
+	if workers.GeneratePreviews != nil {
+		workers.GeneratePreviews.Stop()
+	}
+
+	if workers.MigrateFileStore != nil {
+		workers.MigrateFileStore.Stop()
+	}
+
+	if workers.CleanupUploadSessions != nil {
+		workers.CleanupUploadSessions.Stop()
+	}
+
 	mlog.Info("Stopped workers")
 
 	return workers
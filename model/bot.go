@@ -17,6 +17,8 @@ const (
 	BOT_DESCRIPTION_MAX_RUNES    = 1024
 	BOT_CREATOR_ID_MAX_RUNES     = KEY_VALUE_PLUGIN_ID_MAX_RUNES // UserId or PluginId
 	BOT_WARN_METRIC_BOT_USERNAME = "mattermost-advisor"
+	BOT_KEYWORD_WATCH_USERNAME   = "keyword-watch"
+	BOT_SAVED_SEARCH_USERNAME    = "saved-search"
 )
 
 // Bot is a special type of User meant for programmatic interactions.
@@ -0,0 +1,88 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChannelBookmarkJson(t *testing.T) {
+	bookmark := ChannelBookmark{Id: NewId(), ChannelId: NewId(), DisplayName: "Docs", Type: CHANNEL_BOOKMARK_LINK, LinkUrl: "https://example.com"}
+	rbookmark := ChannelBookmarkFromJson(strings.NewReader(bookmark.ToJson()))
+	require.Equal(t, bookmark, *rbookmark)
+
+	bookmarks := ChannelBookmarksFromJson(strings.NewReader(ChannelBookmarksToJson([]*ChannelBookmark{&bookmark})))
+	require.Len(t, bookmarks, 1)
+	require.Equal(t, bookmark.Id, bookmarks[0].Id)
+}
+
+func TestChannelBookmarkIsValid(t *testing.T) {
+	bookmark := ChannelBookmark{}
+	require.NotNil(t, bookmark.IsValid())
+
+	bookmark.PreSave()
+	require.NotNil(t, bookmark.IsValid())
+
+	bookmark.ChannelId = NewId()
+	require.NotNil(t, bookmark.IsValid())
+
+	bookmark.OwnerId = NewId()
+	require.NotNil(t, bookmark.IsValid())
+
+	bookmark.DisplayName = strings.Repeat("a", CHANNEL_BOOKMARK_DISPLAY_NAME_MAX_RUNES+1)
+	require.NotNil(t, bookmark.IsValid())
+
+	bookmark.DisplayName = "Docs"
+	require.NotNil(t, bookmark.IsValid())
+
+	t.Run("link", func(t *testing.T) {
+		link := bookmark
+		link.Type = CHANNEL_BOOKMARK_LINK
+		require.NotNil(t, link.IsValid())
+
+		link.LinkUrl = "not a url"
+		require.NotNil(t, link.IsValid())
+
+		link.LinkUrl = "https://example.com/docs"
+		require.Nil(t, link.IsValid())
+
+		link.FileId = NewId()
+		require.NotNil(t, link.IsValid())
+
+		link.FileId = ""
+		link.ImageUrl = "ftp://example.com/icon.png"
+		require.NotNil(t, link.IsValid())
+
+		link.ImageUrl = "https://example.com/icon.png"
+		require.Nil(t, link.IsValid())
+
+		link.Emoji = strings.Repeat("a", CHANNEL_BOOKMARK_EMOJI_MAX_RUNES+1)
+		require.NotNil(t, link.IsValid())
+	})
+
+	t.Run("file", func(t *testing.T) {
+		file := bookmark
+		file.Type = CHANNEL_BOOKMARK_FILE
+		require.NotNil(t, file.IsValid())
+
+		file.FileId = NewId()
+		require.Nil(t, file.IsValid())
+
+		file.LinkUrl = "https://example.com"
+		require.NotNil(t, file.IsValid())
+	})
+}
+
+func TestChannelBookmarkPatch(t *testing.T) {
+	bookmark := ChannelBookmark{DisplayName: "Docs", LinkUrl: "https://example.com", Emoji: "smile"}
+	patch := &ChannelBookmarkPatch{DisplayName: NewString("Handbook"), Emoji: NewString("")}
+
+	bookmark.Patch(patch)
+	require.Equal(t, "Handbook", bookmark.DisplayName)
+	require.Equal(t, "https://example.com", bookmark.LinkUrl)
+	require.Equal(t, "", bookmark.Emoji)
+}
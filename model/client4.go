@@ -33,6 +33,7 @@ const (
 	HEADER_AUTH               = "Authorization"
 	HEADER_REQUESTED_WITH     = "X-Requested-With"
 	HEADER_REQUESTED_WITH_XML = "XMLHttpRequest"
+	HEADER_CHUNK_CHECKSUM     = "X-Chunk-Checksum"
 	STATUS                    = "status"
 	STATUS_OK                 = "OK"
 	STATUS_FAIL               = "FAIL"
@@ -458,6 +459,58 @@ func (c *Client4) GetReactionsRoute() string {
 	return "/reactions"
 }
 
+func (c *Client4) GetPollsRoute() string {
+	return "/polls"
+}
+
+func (c *Client4) GetPollRoute(pollId string) string {
+	return fmt.Sprintf(c.GetPollsRoute()+"/%v", pollId)
+}
+
+func (c *Client4) GetDraftsRoute() string {
+	return "/drafts"
+}
+
+func (c *Client4) GetChannelBookmarksRoute(channelId string) string {
+	return c.GetChannelRoute(channelId) + "/bookmarks"
+}
+
+func (c *Client4) GetChannelBookmarkRoute(channelId, bookmarkId string) string {
+	return fmt.Sprintf(c.GetChannelBookmarksRoute(channelId)+"/%v", bookmarkId)
+}
+
+func (c *Client4) GetEmailQueueRoute() string {
+	return "/email_queue"
+}
+
+func (c *Client4) GetQueuedEmailRoute(emailId string) string {
+	return fmt.Sprintf(c.GetEmailQueueRoute()+"/%v", emailId)
+}
+
+func (c *Client4) GetKeywordWatchesRoute(userId string) string {
+	return c.GetUserRoute(userId) + "/keyword_watches"
+}
+
+func (c *Client4) GetKeywordWatchRoute(userId, watchId string) string {
+	return fmt.Sprintf(c.GetKeywordWatchesRoute(userId)+"/%v", watchId)
+}
+
+func (c *Client4) GetSavedSearchesRoute(userId string) string {
+	return c.GetUserRoute(userId) + "/saved_searches"
+}
+
+func (c *Client4) GetSavedSearchRoute(userId, savedSearchId string) string {
+	return fmt.Sprintf(c.GetSavedSearchesRoute(userId)+"/%v", savedSearchId)
+}
+
+func (c *Client4) GetPublicLinksRoute(userId string) string {
+	return c.GetUserRoute(userId) + "/public_links"
+}
+
+func (c *Client4) GetPublicLinkRoute(userId, publicLinkId string) string {
+	return fmt.Sprintf(c.GetPublicLinksRoute(userId)+"/%v", publicLinkId)
+}
+
 func (c *Client4) GetOAuthAppsRoute() string {
 	return "/oauth/apps"
 }
@@ -575,13 +628,21 @@ func (c *Client4) doApiRequestBytes(method, url string, data []byte, etag string
 }
 
 func (c *Client4) doApiRequestReader(method, url string, data io.Reader, etag string) (*http.Response, *AppError) {
+	headers := map[string]string{}
+	if len(etag) > 0 {
+		headers[HEADER_ETAG_CLIENT] = etag
+	}
+	return c.doApiRequestWithHeaders(method, url, data, headers)
+}
+
+func (c *Client4) doApiRequestWithHeaders(method, url string, data io.Reader, headers map[string]string) (*http.Response, *AppError) {
 	rq, err := http.NewRequest(method, url, data)
 	if err != nil {
 		return nil, NewAppError(url, "model.client.connecting.app_error", nil, err.Error(), http.StatusBadRequest)
 	}
 
-	if len(etag) > 0 {
-		rq.Header.Set(HEADER_ETAG_CLIENT, etag)
+	for k, v := range headers {
+		rq.Header.Set(k, v)
 	}
 
 	if len(c.AuthToken) > 0 {
@@ -1430,6 +1491,17 @@ func (c *Client4) VerifyUserEmail(token string) (bool, *Response) {
 	return CheckStatusOK(r), BuildResponse(r)
 }
 
+// UnsubscribeFromEmailDigests turns off the email notifications of a user using
+// the token from the unsubscribe link of their email digests.
+func (c *Client4) UnsubscribeFromEmailDigests(userId, token string) (bool, *Response) {
+	r, err := c.DoApiPost(c.GetUserRoute(userId)+"/email_digest/unsubscribe?token="+url.QueryEscape(token), "")
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return CheckStatusOK(r), BuildResponse(r)
+}
+
 // VerifyUserEmailWithoutToken will verify a user's email by its Id. (Requires manage system role)
 func (c *Client4) VerifyUserEmailWithoutToken(userId string) (*User, *Response) {
 	r, err := c.DoApiPost(c.GetUserRoute(userId)+"/email/verify/member", "")
@@ -3249,6 +3321,29 @@ func (c *Client4) GetFileInfosForPost(postId string, etag string) ([]*FileInfo,
 	return FileInfosFromJson(r.Body), BuildResponse(r)
 }
 
+// SearchFiles returns the files in a team whose name or content match the terms string.
+func (c *Client4) SearchFiles(teamId string, terms string, isOrSearch bool) (*FileInfoList, *Response) {
+	values := url.Values{}
+	values.Set("terms", terms)
+	values.Set("is_or_search", strconv.FormatBool(isOrSearch))
+	r, err := c.DoApiGet(c.GetTeamRoute(teamId)+"/files/search?"+values.Encode(), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return FileInfoListFromJson(r.Body), BuildResponse(r)
+}
+
+// GetPostReadReceipts gets the members of a direct or group channel that have read a post.
+func (c *Client4) GetPostReadReceipts(postId string) ([]*PostReadReceipt, *Response) {
+	r, err := c.DoApiGet(c.GetPostRoute(postId)+"/read_by", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return PostReadReceiptsFromJson(r.Body), BuildResponse(r)
+}
+
 // General/System Section
 
 // GetPing will return ok if the running goRoutines are below the threshold and unhealthy for above.
@@ -3490,6 +3585,18 @@ func (c *Client4) GetAnalyticsOld(name, teamId string) (AnalyticsRows, *Response
 	return AnalyticsRowsFromJson(r.Body), BuildResponse(r)
 }
 
+// GetStorageUsageReport returns a page of the storage usage of the users or
+// the teams, the largest first.
+func (c *Client4) GetStorageUsageReport(targetType string, page, perPage int) ([]*StorageUsage, *Response) {
+	query := fmt.Sprintf("?target_type=%v&page=%v&per_page=%v", targetType, page, perPage)
+	r, err := c.DoApiGet(c.GetAnalyticsRoute()+"/storage_usage"+query, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return StorageUsageListFromJson(r.Body), BuildResponse(r)
+}
+
 // Webhooks Section
 
 // CreateIncomingWebhook creates an incoming webhook for a channel.
@@ -4606,6 +4713,66 @@ func (c *Client4) UpdateUserStatus(userId string, userStatus *Status) (*Status,
 	return StatusFromJson(r.Body), BuildResponse(r)
 }
 
+// GetUserCustomStatus returns the custom status of the user with the provided user id.
+func (c *Client4) GetUserCustomStatus(userId, etag string) (*CustomStatus, *Response) {
+	r, err := c.DoApiGet(c.GetUserStatusRoute(userId)+"/custom", etag)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return CustomStatusFromJson(r.Body), BuildResponse(r)
+}
+
+// UpdateUserCustomStatus sets the custom status of the user with the provided user id.
+func (c *Client4) UpdateUserCustomStatus(userId string, customStatus *CustomStatus) (*CustomStatus, *Response) {
+	r, err := c.DoApiPut(c.GetUserStatusRoute(userId)+"/custom", customStatus.ToJson())
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return CustomStatusFromJson(r.Body), BuildResponse(r)
+}
+
+// RemoveUserCustomStatus clears the custom status of the user with the provided user id.
+func (c *Client4) RemoveUserCustomStatus(userId string) (bool, *Response) {
+	r, err := c.DoApiDelete(c.GetUserStatusRoute(userId) + "/custom")
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return CheckStatusOK(r), BuildResponse(r)
+}
+
+// GetUserDndSchedule returns the Do Not Disturb schedule of the user with the provided user id.
+func (c *Client4) GetUserDndSchedule(userId, etag string) (*DndSchedule, *Response) {
+	r, err := c.DoApiGet(c.GetUserStatusRoute(userId)+"/dnd_schedule", etag)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return DndScheduleFromJson(r.Body), BuildResponse(r)
+}
+
+// UpdateUserDndSchedule sets the Do Not Disturb schedule of the user with the provided user id.
+func (c *Client4) UpdateUserDndSchedule(userId string, schedule *DndSchedule) (*DndSchedule, *Response) {
+	r, err := c.DoApiPut(c.GetUserStatusRoute(userId)+"/dnd_schedule", schedule.ToJson())
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return DndScheduleFromJson(r.Body), BuildResponse(r)
+}
+
+// RemoveUserDndSchedule removes the Do Not Disturb schedule of the user with the provided user id.
+func (c *Client4) RemoveUserDndSchedule(userId string) (bool, *Response) {
+	r, err := c.DoApiDelete(c.GetUserStatusRoute(userId) + "/dnd_schedule")
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return CheckStatusOK(r), BuildResponse(r)
+}
+
 // Emoji Section
 
 // CreateEmoji will save an emoji to the server if the current user has permission
@@ -4767,6 +4934,147 @@ func (c *Client4) GetBulkReactions(postIds []string) (map[string][]*Reaction, *R
 	return MapPostIdToReactionsFromJson(r.Body), BuildResponse(r)
 }
 
+// Poll Section
+
+// CreatePoll creates a poll and the post that displays it.
+func (c *Client4) CreatePoll(poll *Poll) (*Poll, *Response) {
+	r, err := c.DoApiPost(c.GetPollsRoute(), poll.ToJson())
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return PollFromJson(r.Body), BuildResponse(r)
+}
+
+// GetPoll gets a single poll.
+func (c *Client4) GetPoll(pollId string) (*Poll, *Response) {
+	r, err := c.DoApiGet(c.GetPollRoute(pollId), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return PollFromJson(r.Body), BuildResponse(r)
+}
+
+// GetPollResults gets the current tally of a poll.
+func (c *Client4) GetPollResults(pollId string) (*PollResults, *Response) {
+	r, err := c.DoApiGet(c.GetPollRoute(pollId)+"/results", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return PollResultsFromJson(r.Body), BuildResponse(r)
+}
+
+// VoteOnPoll replaces the current user's votes on a poll with the given option indexes.
+func (c *Client4) VoteOnPoll(pollId string, options []int) (*PollResults, *Response) {
+	vote := &PollVoteRequest{Options: options}
+	r, err := c.DoApiPost(c.GetPollRoute(pollId)+"/votes", vote.ToJson())
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return PollResultsFromJson(r.Body), BuildResponse(r)
+}
+
+// ClosePoll stops a poll from accepting further votes.
+func (c *Client4) ClosePoll(pollId string) (*Poll, *Response) {
+	r, err := c.DoApiPost(c.GetPollRoute(pollId)+"/close", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return PollFromJson(r.Body), BuildResponse(r)
+}
+
+// Draft Section
+
+// UpsertDraft saves the current user's draft for a channel or thread, replacing any existing one.
+func (c *Client4) UpsertDraft(draft *Draft) (*Draft, *Response) {
+	r, err := c.DoApiPost(c.GetDraftsRoute(), draft.ToJson())
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return DraftFromJson(r.Body), BuildResponse(r)
+}
+
+// GetDrafts returns the user's drafts in a team, including those in direct and group messages.
+func (c *Client4) GetDrafts(userId, teamId string) ([]*Draft, *Response) {
+	r, err := c.DoApiGet(c.GetUserRoute(userId)+c.GetTeamRoute(teamId)+"/drafts", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return DraftsFromJson(r.Body), BuildResponse(r)
+}
+
+// DeleteDraft deletes the user's draft for a channel, or for a thread when rootId is set.
+func (c *Client4) DeleteDraft(userId, channelId, rootId string) (bool, *Response) {
+	route := c.GetUserRoute(userId) + c.GetChannelRoute(channelId) + "/drafts"
+	if rootId != "" {
+		route += "/" + rootId
+	}
+	r, err := c.DoApiDelete(route)
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return CheckStatusOK(r), BuildResponse(r)
+}
+
+// Channel Bookmark Section
+
+// GetChannelBookmarks returns the bookmarks of a channel in display order.
+func (c *Client4) GetChannelBookmarks(channelId string) ([]*ChannelBookmark, *Response) {
+	r, err := c.DoApiGet(c.GetChannelBookmarksRoute(channelId), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return ChannelBookmarksFromJson(r.Body), BuildResponse(r)
+}
+
+// CreateChannelBookmark adds a bookmark after the existing bookmarks of its channel.
+func (c *Client4) CreateChannelBookmark(bookmark *ChannelBookmark) (*ChannelBookmark, *Response) {
+	r, err := c.DoApiPost(c.GetChannelBookmarksRoute(bookmark.ChannelId), bookmark.ToJson())
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return ChannelBookmarkFromJson(r.Body), BuildResponse(r)
+}
+
+// PatchChannelBookmark partially updates a bookmark. Any missing fields in the patch will be ignored.
+func (c *Client4) PatchChannelBookmark(channelId, bookmarkId string, patch *ChannelBookmarkPatch) (*ChannelBookmark, *Response) {
+	r, err := c.DoApiPut(c.GetChannelBookmarkRoute(channelId, bookmarkId)+"/patch", patch.ToJson())
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return ChannelBookmarkFromJson(r.Body), BuildResponse(r)
+}
+
+// UpdateChannelBookmarkSortOrder moves a bookmark to the given position and returns the channel's bookmarks in their new order.
+func (c *Client4) UpdateChannelBookmarkSortOrder(channelId, bookmarkId string, sortOrder int64) ([]*ChannelBookmark, *Response) {
+	r, err := c.DoApiPost(c.GetChannelBookmarkRoute(channelId, bookmarkId)+"/sort_order", strconv.FormatInt(sortOrder, 10))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return ChannelBookmarksFromJson(r.Body), BuildResponse(r)
+}
+
+// DeleteChannelBookmark deletes a bookmark from a channel.
+func (c *Client4) DeleteChannelBookmark(channelId, bookmarkId string) (bool, *Response) {
+	r, err := c.DoApiDelete(c.GetChannelBookmarkRoute(channelId, bookmarkId))
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return CheckStatusOK(r), BuildResponse(r)
+}
+
 // Timezone Section
 
 // GetSupportedTimezone returns a page of supported timezones on the system.
@@ -4863,6 +5171,175 @@ func (c *Client4) DownloadJob(jobId string) ([]byte, *Response) {
 	return data, BuildResponse(r)
 }
 
+// Email Queue Section
+
+// GetQueuedEmails gets the emails in the outbound email queue with the given
+// status, or with any status if it is empty, newest first.
+func (c *Client4) GetQueuedEmails(status string, page int, perPage int) ([]*QueuedEmail, *Response) {
+	query := fmt.Sprintf("?page=%v&per_page=%v", page, perPage)
+	if status != "" {
+		query += "&status=" + url.QueryEscape(status)
+	}
+	r, err := c.DoApiGet(c.GetEmailQueueRoute()+query, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return QueuedEmailListFromJson(r.Body), BuildResponse(r)
+}
+
+// GetQueuedEmail gets an email from the outbound email queue.
+func (c *Client4) GetQueuedEmail(emailId string) (*QueuedEmail, *Response) {
+	r, err := c.DoApiGet(c.GetQueuedEmailRoute(emailId), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return QueuedEmailFromJson(r.Body), BuildResponse(r)
+}
+
+// RequeueEmail schedules a queued email, such as a dead-lettered one, to be sent again.
+func (c *Client4) RequeueEmail(emailId string) (*QueuedEmail, *Response) {
+	r, err := c.DoApiPost(c.GetQueuedEmailRoute(emailId)+"/requeue", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return QueuedEmailFromJson(r.Body), BuildResponse(r)
+}
+
+// Keyword Watches Section
+
+// GetKeywordWatches gets the keyword watches of a user.
+func (c *Client4) GetKeywordWatches(userId string) ([]*KeywordWatch, *Response) {
+	r, err := c.DoApiGet(c.GetKeywordWatchesRoute(userId), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return KeywordWatchListFromJson(r.Body), BuildResponse(r)
+}
+
+// CreateKeywordWatch creates a keyword watch for a user on one of their teams.
+func (c *Client4) CreateKeywordWatch(userId string, watch *KeywordWatch) (*KeywordWatch, *Response) {
+	r, err := c.DoApiPost(c.GetKeywordWatchesRoute(userId), watch.ToJson())
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return KeywordWatchFromJson(r.Body), BuildResponse(r)
+}
+
+// UpdateKeywordWatch changes the pattern and the delivery of a keyword watch.
+func (c *Client4) UpdateKeywordWatch(userId string, watch *KeywordWatch) (*KeywordWatch, *Response) {
+	r, err := c.DoApiPut(c.GetKeywordWatchRoute(userId, watch.Id), watch.ToJson())
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return KeywordWatchFromJson(r.Body), BuildResponse(r)
+}
+
+// DeleteKeywordWatch deletes a keyword watch.
+func (c *Client4) DeleteKeywordWatch(userId, watchId string) (bool, *Response) {
+	r, err := c.DoApiDelete(c.GetKeywordWatchRoute(userId, watchId))
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return CheckStatusOK(r), BuildResponse(r)
+}
+
+// Saved Searches Section
+
+// GetSavedSearches gets the saved searches of a user.
+func (c *Client4) GetSavedSearches(userId string) ([]*SavedSearch, *Response) {
+	r, err := c.DoApiGet(c.GetSavedSearchesRoute(userId), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return SavedSearchListFromJson(r.Body), BuildResponse(r)
+}
+
+// GetSavedSearch gets a single saved search of a user.
+func (c *Client4) GetSavedSearch(userId, savedSearchId string) (*SavedSearch, *Response) {
+	r, err := c.DoApiGet(c.GetSavedSearchRoute(userId, savedSearchId), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return SavedSearchFromJson(r.Body), BuildResponse(r)
+}
+
+// CreateSavedSearch saves a search for a user on one of their teams.
+func (c *Client4) CreateSavedSearch(userId string, search *SavedSearch) (*SavedSearch, *Response) {
+	r, err := c.DoApiPost(c.GetSavedSearchesRoute(userId), search.ToJson())
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return SavedSearchFromJson(r.Body), BuildResponse(r)
+}
+
+// UpdateSavedSearch changes the name, the terms, the options and the
+// notifications of a saved search.
+func (c *Client4) UpdateSavedSearch(userId string, search *SavedSearch) (*SavedSearch, *Response) {
+	r, err := c.DoApiPut(c.GetSavedSearchRoute(userId, search.Id), search.ToJson())
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return SavedSearchFromJson(r.Body), BuildResponse(r)
+}
+
+// DeleteSavedSearch deletes a saved search.
+func (c *Client4) DeleteSavedSearch(userId, savedSearchId string) (bool, *Response) {
+	r, err := c.DoApiDelete(c.GetSavedSearchRoute(userId, savedSearchId))
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return CheckStatusOK(r), BuildResponse(r)
+}
+
+// Public Links Section
+
+// GetPublicLinks gets a page of the public links created by a user, the most
+// recent first.
+func (c *Client4) GetPublicLinks(userId string, page, perPage int) ([]*PublicLink, *Response) {
+	query := fmt.Sprintf("?page=%v&per_page=%v", page, perPage)
+	r, err := c.DoApiGet(c.GetPublicLinksRoute(userId)+query, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return PublicLinksFromJson(r.Body), BuildResponse(r)
+}
+
+// CreatePublicLink creates a public link to a file, expiring at the given
+// time, or never when it is 0.
+func (c *Client4) CreatePublicLink(userId, fileId string, expireAt int64) (*PublicLink, *Response) {
+	link := &PublicLink{FileId: fileId, ExpireAt: expireAt}
+	r, err := c.DoApiPost(c.GetPublicLinksRoute(userId), link.ToJson())
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return PublicLinkFromJson(r.Body), BuildResponse(r)
+}
+
+// RevokePublicLink revokes a public link, which no longer gives access to its
+// file.
+func (c *Client4) RevokePublicLink(userId, publicLinkId string) (bool, *Response) {
+	r, err := c.DoApiDelete(c.GetPublicLinkRoute(userId, publicLinkId))
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return CheckStatusOK(r), BuildResponse(r)
+}
+
 // Roles Section
 
 // GetRole gets a single role by ID.
@@ -5634,6 +6111,42 @@ func (c *Client4) UploadData(uploadId string, data io.Reader) (*FileInfo, *Respo
 	return FileInfoFromJson(r.Body), BuildResponse(r)
 }
 
+// UploadDataWithChecksum uploads a chunk of data along with its checksum, so
+// that the server rejects it if it was corrupted in transit. On completion it
+// returns a FileInfo object.
+func (c *Client4) UploadDataWithChecksum(uploadId string, data []byte) (*FileInfo, *Response) {
+	r, err := c.doUploadChunk(c.GetUploadRoute(uploadId), data)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return FileInfoFromJson(r.Body), BuildResponse(r)
+}
+
+// UploadPluginBundleData uploads a chunk of a plugin bundle along with its
+// checksum. Once the bundle is complete it is installed, replacing an existing
+// plugin with the same id if force is set, and its manifest is returned.
+func (c *Client4) UploadPluginBundleData(uploadId string, data []byte, force bool) (*Manifest, *Response) {
+	url := c.GetUploadRoute(uploadId)
+	if force {
+		url += "?force=true"
+	}
+	r, err := c.doUploadChunk(url, data)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	if r.StatusCode == http.StatusNoContent {
+		return nil, BuildResponse(r)
+	}
+	return ManifestFromJson(r.Body), BuildResponse(r)
+}
+
+func (c *Client4) doUploadChunk(url string, data []byte) (*http.Response, *AppError) {
+	headers := map[string]string{HEADER_CHUNK_CHECKSUM: ChunkChecksum(data)}
+	return c.doApiRequestWithHeaders(http.MethodPost, c.ApiUrl+url, bytes.NewReader(data), headers)
+}
+
 func (c *Client4) UpdatePassword(userId, currentPassword, newPassword string) *Response {
 	requestBody := map[string]string{"current_password": currentPassword, "new_password": newPassword}
 	r, err := c.DoApiPut(c.GetUserRoute(userId)+"/password", MapToJson(requestBody))
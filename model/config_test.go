@@ -4,6 +4,7 @@
 package model
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"reflect"
@@ -106,6 +107,14 @@ func TestConfigDefaultEmailNotificationContentsType(t *testing.T) {
 	require.Equal(t, *c1.EmailSettings.EmailNotificationContentsType, EMAIL_NOTIFICATION_CONTENTS_FULL)
 }
 
+func TestConfigDefaultEmailQueue(t *testing.T) {
+	c1 := Config{}
+	c1.SetDefaults()
+
+	require.False(t, *c1.EmailSettings.EnableEmailQueue)
+	require.Equal(t, EMAIL_QUEUE_MAX_ATTEMPTS, *c1.EmailSettings.EmailQueueMaxAttempts)
+}
+
 func TestConfigDefaultFileSettingsS3SSE(t *testing.T) {
 	c1 := Config{}
 	c1.SetDefaults()
@@ -113,6 +122,139 @@ func TestConfigDefaultFileSettingsS3SSE(t *testing.T) {
 	require.False(t, *c1.FileSettings.AmazonS3SSE)
 }
 
+func TestConfigFileSettingsEncryptionKey(t *testing.T) {
+	c1 := Config{}
+	c1.SetDefaults()
+
+	require.False(t, *c1.FileSettings.EnableEncryptionAtRest)
+	require.Nil(t, c1.FileSettings.isValid())
+
+	*c1.FileSettings.EnableEncryptionAtRest = true
+	require.NotNil(t, c1.FileSettings.isValid())
+
+	*c1.FileSettings.EncryptionKey = base64.StdEncoding.EncodeToString([]byte("too short"))
+	require.NotNil(t, c1.FileSettings.isValid())
+
+	*c1.FileSettings.EncryptionKey = base64.StdEncoding.EncodeToString(make([]byte, 32))
+	require.Nil(t, c1.FileSettings.isValid())
+
+	c1.FileSettings.PreviousEncryptionKeys = []string{"not base64"}
+	require.NotNil(t, c1.FileSettings.isValid())
+}
+
+func TestConfigFileSettingsVirusScan(t *testing.T) {
+	c1 := Config{}
+	c1.SetDefaults()
+
+	require.False(t, *c1.FileSettings.EnableVirusScan)
+	require.Equal(t, VIRUS_SCAN_DRIVER_CLAMD, *c1.FileSettings.VirusScanDriver)
+
+	*c1.FileSettings.EnableVirusScan = true
+	require.Nil(t, c1.FileSettings.isValid())
+
+	*c1.FileSettings.VirusScanDriver = "unknown"
+	require.NotNil(t, c1.FileSettings.isValid())
+
+	*c1.FileSettings.VirusScanDriver = VIRUS_SCAN_DRIVER_ICAP
+	*c1.FileSettings.VirusScanAddress = ""
+	require.NotNil(t, c1.FileSettings.isValid())
+
+	*c1.FileSettings.VirusScanAddress = "icap://localhost/avscan"
+	*c1.FileSettings.VirusScanTimeoutSeconds = 0
+	require.NotNil(t, c1.FileSettings.isValid())
+}
+
+func TestConfigFileSettingsStorageQuota(t *testing.T) {
+	c1 := Config{}
+	c1.SetDefaults()
+
+	require.Equal(t, int64(0), *c1.FileSettings.UserStorageQuota)
+	require.Equal(t, int64(0), *c1.FileSettings.TeamStorageQuota)
+	require.Equal(t, FILE_SETTINGS_DEFAULT_STORAGE_WARNING_PERCENT, *c1.FileSettings.StorageWarningPercent)
+	require.Nil(t, c1.FileSettings.isValid())
+
+	*c1.FileSettings.UserStorageQuota = -1
+	require.NotNil(t, c1.FileSettings.isValid())
+
+	*c1.FileSettings.UserStorageQuota = 1024
+	*c1.FileSettings.StorageWarningPercent = 0
+	require.NotNil(t, c1.FileSettings.isValid())
+
+	*c1.FileSettings.StorageWarningPercent = 101
+	require.NotNil(t, c1.FileSettings.isValid())
+}
+
+func TestConfigFileSettingsPreviews(t *testing.T) {
+	c1 := Config{}
+	c1.SetDefaults()
+
+	require.False(t, *c1.FileSettings.EnableFilePreviews)
+	require.Equal(t, FILE_SETTINGS_DEFAULT_PREVIEWER_TIMEOUT_SECONDS, *c1.FileSettings.PreviewerTimeoutSeconds)
+
+	*c1.FileSettings.EnableFilePreviews = true
+	require.Nil(t, c1.FileSettings.isValid())
+
+	*c1.FileSettings.PreviewerTimeoutSeconds = 0
+	require.NotNil(t, c1.FileSettings.isValid())
+}
+
+func TestConfigFileSettingsPublicLinkExpiry(t *testing.T) {
+	c1 := Config{}
+	c1.SetDefaults()
+
+	require.Equal(t, 0, *c1.FileSettings.PublicLinkExpiryHours)
+	require.True(t, *c1.FileSettings.EnableLegacyPublicLinks)
+
+	*c1.FileSettings.PublicLinkExpiryHours = 24
+	require.Nil(t, c1.FileSettings.isValid())
+
+	*c1.FileSettings.PublicLinkExpiryHours = -1
+	require.NotNil(t, c1.FileSettings.isValid())
+}
+
+func TestConfigFileSettingsPreviewFormats(t *testing.T) {
+	c1 := Config{}
+	c1.SetDefaults()
+
+	require.Empty(t, c1.FileSettings.PreviewFormats)
+	require.Nil(t, c1.FileSettings.isValid())
+
+	c1.FileSettings.PreviewFormats = []string{PREVIEW_FORMAT_WEBP, PREVIEW_FORMAT_AVIF}
+	require.NotNil(t, c1.FileSettings.isValid(), "converting previews requires ffmpeg")
+
+	*c1.FileSettings.FFmpegPath = "/usr/bin/ffmpeg"
+	require.Nil(t, c1.FileSettings.isValid())
+
+	c1.FileSettings.PreviewFormats = []string{"gif"}
+	require.NotNil(t, c1.FileSettings.isValid())
+}
+
+func TestConfigFileSettingsUploadExpiry(t *testing.T) {
+	c1 := Config{}
+	c1.SetDefaults()
+
+	require.Equal(t, FILE_SETTINGS_DEFAULT_UPLOAD_EXPIRY_HOURS, *c1.FileSettings.UploadExpiryHours)
+
+	*c1.FileSettings.UploadExpiryHours = 0
+	require.Nil(t, c1.FileSettings.isValid())
+
+	*c1.FileSettings.UploadExpiryHours = -1
+	require.NotNil(t, c1.FileSettings.isValid())
+}
+
+func TestConfigFileSettingsStripMetadataFailure(t *testing.T) {
+	c1 := Config{}
+	c1.SetDefaults()
+
+	require.Equal(t, STRIP_METADATA_FAILURE_REJECT, *c1.FileSettings.StripMetadataFailure)
+
+	*c1.FileSettings.StripMetadataFailure = STRIP_METADATA_FAILURE_REENCODE
+	require.Nil(t, c1.FileSettings.isValid())
+
+	*c1.FileSettings.StripMetadataFailure = "keep"
+	require.NotNil(t, c1.FileSettings.isValid())
+}
+
 func TestConfigDefaultSignatureAlgorithm(t *testing.T) {
 	c1 := Config{}
 	c1.SetDefaults()
@@ -1275,6 +1417,8 @@ func TestConfigSanitize(t *testing.T) {
 
 	*c.LdapSettings.BindPassword = "foo"
 	*c.FileSettings.AmazonS3SecretAccessKey = "bar"
+	*c.FileSettings.EncryptionKey = "key"
+	c.FileSettings.PreviousEncryptionKeys = []string{"previous key"}
 	*c.EmailSettings.SMTPPassword = "baz"
 	*c.GitLabSettings.Secret = "bingo"
 	c.SqlSettings.DataSourceReplicas = []string{"stuff"}
@@ -1285,6 +1429,8 @@ func TestConfigSanitize(t *testing.T) {
 	assert.Equal(t, FAKE_SETTING, *c.LdapSettings.BindPassword)
 	assert.Equal(t, FAKE_SETTING, *c.FileSettings.PublicLinkSalt)
 	assert.Equal(t, FAKE_SETTING, *c.FileSettings.AmazonS3SecretAccessKey)
+	assert.Equal(t, FAKE_SETTING, *c.FileSettings.EncryptionKey)
+	assert.Equal(t, FAKE_SETTING, c.FileSettings.PreviousEncryptionKeys[0])
 	assert.Equal(t, FAKE_SETTING, *c.EmailSettings.SMTPPassword)
 	assert.Equal(t, FAKE_SETTING, *c.GitLabSettings.Secret)
 	assert.Equal(t, FAKE_SETTING, *c.SqlSettings.DataSource)
@@ -0,0 +1,83 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	CUSTOM_STATUS_EMOJI_MAX_RUNES = 64
+	CUSTOM_STATUS_TEXT_MAX_RUNES  = 100
+)
+
+// CustomStatus is a short message with an optional emoji that a user shows
+// alongside their presence, such as "On vacation until Monday". A zero
+// ExpiresAt means the custom status does not expire.
+type CustomStatus struct {
+	UserId    string `json:"user_id"`
+	Emoji     string `json:"emoji"`
+	Text      string `json:"text"`
+	ExpiresAt int64  `json:"expires_at"`
+	UpdateAt  int64  `json:"update_at"`
+}
+
+func (o *CustomStatus) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func CustomStatusFromJson(data io.Reader) *CustomStatus {
+	var o *CustomStatus
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+// IsEmpty reports whether the custom status has neither emoji nor text, which
+// is how a cleared custom status is sent to clients.
+func (o *CustomStatus) IsEmpty() bool {
+	return o.Emoji == "" && o.Text == ""
+}
+
+func (o *CustomStatus) IsExpired() bool {
+	return o.ExpiresAt != 0 && o.ExpiresAt <= GetMillis()
+}
+
+func (o *CustomStatus) IsValid() *AppError {
+	if !IsValidId(o.UserId) {
+		return NewAppError("CustomStatus.IsValid", "model.custom_status.is_valid.user_id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if o.IsEmpty() {
+		return NewAppError("CustomStatus.IsValid", "model.custom_status.is_valid.empty.app_error", nil, "user_id="+o.UserId, http.StatusBadRequest)
+	}
+
+	if utf8.RuneCountInString(o.Emoji) > CUSTOM_STATUS_EMOJI_MAX_RUNES {
+		return NewAppError("CustomStatus.IsValid", "model.custom_status.is_valid.emoji.app_error", nil, "user_id="+o.UserId, http.StatusBadRequest)
+	}
+
+	if utf8.RuneCountInString(o.Text) > CUSTOM_STATUS_TEXT_MAX_RUNES {
+		return NewAppError("CustomStatus.IsValid", "model.custom_status.is_valid.text.app_error", nil, "user_id="+o.UserId, http.StatusBadRequest)
+	}
+
+	if o.ExpiresAt < 0 {
+		return NewAppError("CustomStatus.IsValid", "model.custom_status.is_valid.expires_at.app_error", nil, "user_id="+o.UserId, http.StatusBadRequest)
+	}
+
+	if o.UpdateAt == 0 {
+		return NewAppError("CustomStatus.IsValid", "model.custom_status.is_valid.update_at.app_error", nil, "user_id="+o.UserId, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (o *CustomStatus) PreSave() {
+	o.Emoji = strings.Trim(strings.TrimSpace(o.Emoji), ":")
+	o.Text = strings.TrimSpace(o.Text)
+	o.UpdateAt = GetMillis()
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCustomStatusJson(t *testing.T) {
+	customStatus := CustomStatus{UserId: NewId(), Emoji: "palm_tree", Text: "On vacation", ExpiresAt: 1234}
+	rcustomStatus := CustomStatusFromJson(strings.NewReader(customStatus.ToJson()))
+	require.Equal(t, customStatus, *rcustomStatus)
+}
+
+func TestCustomStatusIsValid(t *testing.T) {
+	customStatus := CustomStatus{}
+	require.NotNil(t, customStatus.IsValid())
+
+	customStatus.UserId = NewId()
+	customStatus.PreSave()
+	require.NotNil(t, customStatus.IsValid(), "empty custom status should be invalid")
+
+	customStatus.Emoji = ":palm_tree: "
+	customStatus.PreSave()
+	require.Equal(t, "palm_tree", customStatus.Emoji)
+	require.Nil(t, customStatus.IsValid())
+
+	customStatus.Text = strings.Repeat("a", CUSTOM_STATUS_TEXT_MAX_RUNES+1)
+	require.NotNil(t, customStatus.IsValid())
+
+	customStatus.Text = "  On vacation  "
+	customStatus.PreSave()
+	require.Equal(t, "On vacation", customStatus.Text)
+	require.Nil(t, customStatus.IsValid())
+
+	customStatus.Emoji = strings.Repeat("a", CUSTOM_STATUS_EMOJI_MAX_RUNES+1)
+	require.NotNil(t, customStatus.IsValid())
+	customStatus.Emoji = ""
+
+	customStatus.ExpiresAt = -1
+	require.NotNil(t, customStatus.IsValid())
+}
+
+func TestCustomStatusIsExpired(t *testing.T) {
+	customStatus := CustomStatus{}
+	require.False(t, customStatus.IsExpired())
+
+	customStatus.ExpiresAt = GetMillis() - 1000
+	require.True(t, customStatus.IsExpired())
+
+	customStatus.ExpiresAt = GetMillis() + 60000
+	require.False(t, customStatus.IsExpired())
+}
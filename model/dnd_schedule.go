@@ -0,0 +1,180 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	DND_SCHEDULE_TIME_FORMAT         = "15:04"
+	DND_SCHEDULE_MAX_EXEMPT_CHANNELS = 50
+	DND_SCHEDULE_MAX_DAYS            = 7
+)
+
+var dndScheduleWeekdays = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
+// DndSchedule describes the recurring quiet hours of a user, such as
+// 19:00 to 08:00 on weekdays. Days lists the days on which a quiet period
+// starts and the times are interpreted in the user's timezone, so a period
+// whose end is before its start lasts until the following day.
+//
+// While the user is in Do Not Disturb, posts in ExemptChannelIds and, when
+// AllowUrgent is set, posts marked as urgent still send push and email
+// notifications.
+type DndSchedule struct {
+	UserId           string      `json:"user_id"`
+	Days             StringArray `json:"days"`
+	StartTime        string      `json:"start_time"`
+	EndTime          string      `json:"end_time"`
+	ExemptChannelIds StringArray `json:"exempt_channel_ids"`
+	AllowUrgent      bool        `json:"allow_urgent"`
+	// ActiveUntil is the end of the last quiet period that the schedule
+	// started, which prevents it from turning Do Not Disturb back on after
+	// the user turned it off during that period.
+	ActiveUntil int64 `json:"active_until"`
+	UpdateAt    int64 `json:"update_at"`
+}
+
+func (o *DndSchedule) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func DndScheduleFromJson(data io.Reader) *DndSchedule {
+	var o *DndSchedule
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+func (o *DndSchedule) IsValid() *AppError {
+	if !IsValidId(o.UserId) {
+		return NewAppError("DndSchedule.IsValid", "model.dnd_schedule.is_valid.user_id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if len(o.Days) == 0 || len(o.Days) > DND_SCHEDULE_MAX_DAYS {
+		return NewAppError("DndSchedule.IsValid", "model.dnd_schedule.is_valid.days.app_error", nil, "user_id="+o.UserId, http.StatusBadRequest)
+	}
+
+	for _, day := range o.Days {
+		if _, ok := dndScheduleWeekdays[day]; !ok {
+			return NewAppError("DndSchedule.IsValid", "model.dnd_schedule.is_valid.days.app_error", nil, "user_id="+o.UserId, http.StatusBadRequest)
+		}
+	}
+
+	start, err := time.Parse(DND_SCHEDULE_TIME_FORMAT, o.StartTime)
+	if err != nil {
+		return NewAppError("DndSchedule.IsValid", "model.dnd_schedule.is_valid.start_time.app_error", nil, "user_id="+o.UserId, http.StatusBadRequest)
+	}
+
+	end, err := time.Parse(DND_SCHEDULE_TIME_FORMAT, o.EndTime)
+	if err != nil {
+		return NewAppError("DndSchedule.IsValid", "model.dnd_schedule.is_valid.end_time.app_error", nil, "user_id="+o.UserId, http.StatusBadRequest)
+	}
+
+	if start.Equal(end) {
+		return NewAppError("DndSchedule.IsValid", "model.dnd_schedule.is_valid.end_time.app_error", nil, "user_id="+o.UserId, http.StatusBadRequest)
+	}
+
+	if len(o.ExemptChannelIds) > DND_SCHEDULE_MAX_EXEMPT_CHANNELS {
+		return NewAppError("DndSchedule.IsValid", "model.dnd_schedule.is_valid.exempt_channel_ids.app_error", nil, "user_id="+o.UserId, http.StatusBadRequest)
+	}
+
+	for _, channelId := range o.ExemptChannelIds {
+		if !IsValidId(channelId) {
+			return NewAppError("DndSchedule.IsValid", "model.dnd_schedule.is_valid.exempt_channel_ids.app_error", nil, "user_id="+o.UserId, http.StatusBadRequest)
+		}
+	}
+
+	if o.UpdateAt == 0 {
+		return NewAppError("DndSchedule.IsValid", "model.dnd_schedule.is_valid.update_at.app_error", nil, "user_id="+o.UserId, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (o *DndSchedule) PreSave() {
+	for i, day := range o.Days {
+		o.Days[i] = strings.ToLower(strings.TrimSpace(day))
+	}
+
+	if o.ExemptChannelIds == nil {
+		o.ExemptChannelIds = StringArray{}
+	}
+
+	o.UpdateAt = GetMillis()
+}
+
+// CurrentPeriod returns the start and end of the quiet period that includes
+// now, interpreting the schedule in the given location. ok is false when now
+// is outside of every quiet period.
+func (o *DndSchedule) CurrentPeriod(now time.Time, loc *time.Location) (start, end time.Time, ok bool) {
+	startClock, err := time.Parse(DND_SCHEDULE_TIME_FORMAT, o.StartTime)
+	if err != nil {
+		return time.Time{}, time.Time{}, false
+	}
+	endClock, err := time.Parse(DND_SCHEDULE_TIME_FORMAT, o.EndTime)
+	if err != nil {
+		return time.Time{}, time.Time{}, false
+	}
+
+	now = now.In(loc)
+
+	// A period that started yesterday may still be running.
+	for _, offset := range []int{0, -1} {
+		day := now.AddDate(0, 0, offset)
+		if !o.startsOn(day.Weekday()) {
+			continue
+		}
+
+		start = time.Date(day.Year(), day.Month(), day.Day(), startClock.Hour(), startClock.Minute(), 0, 0, loc)
+		end = time.Date(day.Year(), day.Month(), day.Day(), endClock.Hour(), endClock.Minute(), 0, 0, loc)
+		if !end.After(start) {
+			end = end.AddDate(0, 0, 1)
+		}
+
+		if !now.Before(start) && now.Before(end) {
+			return start, end, true
+		}
+	}
+
+	return time.Time{}, time.Time{}, false
+}
+
+func (o *DndSchedule) startsOn(weekday time.Weekday) bool {
+	for _, day := range o.Days {
+		if dndScheduleWeekdays[day] == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsPost reports whether the post should notify the user even though they
+// are in Do Not Disturb.
+func (o *DndSchedule) AllowsPost(post *Post) bool {
+	if o.AllowUrgent && post.GetProp(POST_PROPS_PRIORITY) == POST_PRIORITY_URGENT {
+		return true
+	}
+
+	for _, channelId := range o.ExemptChannelIds {
+		if channelId == post.ChannelId {
+			return true
+		}
+	}
+
+	return false
+}
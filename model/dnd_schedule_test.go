@@ -0,0 +1,117 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDndScheduleJson(t *testing.T) {
+	schedule := DndSchedule{UserId: NewId(), Days: StringArray{"monday", "friday"}, StartTime: "19:00", EndTime: "08:00", ExemptChannelIds: StringArray{NewId()}, AllowUrgent: true}
+	rschedule := DndScheduleFromJson(strings.NewReader(schedule.ToJson()))
+	require.Equal(t, schedule, *rschedule)
+}
+
+func TestDndScheduleIsValid(t *testing.T) {
+	schedule := DndSchedule{}
+	require.NotNil(t, schedule.IsValid())
+
+	schedule = DndSchedule{UserId: NewId(), Days: StringArray{" Monday ", "tuesday"}, StartTime: "19:00", EndTime: "08:00"}
+	schedule.PreSave()
+	require.Nil(t, schedule.IsValid())
+	assert.Equal(t, StringArray{"monday", "tuesday"}, schedule.Days)
+	assert.Equal(t, StringArray{}, schedule.ExemptChannelIds)
+
+	for name, update := range map[string]func(s *DndSchedule){
+		"no days":             func(s *DndSchedule) { s.Days = StringArray{} },
+		"unknown day":         func(s *DndSchedule) { s.Days = StringArray{"someday"} },
+		"invalid start time":  func(s *DndSchedule) { s.StartTime = "7pm" },
+		"invalid end time":    func(s *DndSchedule) { s.EndTime = "25:00" },
+		"same start and end":  func(s *DndSchedule) { s.EndTime = s.StartTime },
+		"invalid channel id":  func(s *DndSchedule) { s.ExemptChannelIds = StringArray{"channel"} },
+		"too many channels":   func(s *DndSchedule) { s.ExemptChannelIds = make(StringArray, DND_SCHEDULE_MAX_EXEMPT_CHANNELS+1) },
+		"missing update time": func(s *DndSchedule) { s.UpdateAt = 0 },
+	} {
+		t.Run(name, func(t *testing.T) {
+			invalid := schedule
+			update(&invalid)
+			assert.NotNil(t, invalid.IsValid())
+		})
+	}
+}
+
+func TestDndScheduleCurrentPeriod(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	weekdays := DndSchedule{Days: StringArray{"monday", "tuesday", "wednesday", "thursday", "friday"}, StartTime: "19:00", EndTime: "08:00"}
+
+	t.Run("should include the evening a period starts", func(t *testing.T) {
+		start, end, ok := weekdays.CurrentPeriod(time.Date(2021, time.January, 4, 20, 30, 0, 0, loc), loc)
+		require.True(t, ok)
+		assert.Equal(t, time.Date(2021, time.January, 4, 19, 0, 0, 0, loc), start)
+		assert.Equal(t, time.Date(2021, time.January, 5, 8, 0, 0, 0, loc), end)
+	})
+
+	t.Run("should include the morning after a period starts", func(t *testing.T) {
+		start, end, ok := weekdays.CurrentPeriod(time.Date(2021, time.January, 5, 7, 59, 0, 0, loc), loc)
+		require.True(t, ok)
+		assert.Equal(t, time.Date(2021, time.January, 4, 19, 0, 0, 0, loc), start)
+		assert.Equal(t, time.Date(2021, time.January, 5, 8, 0, 0, 0, loc), end)
+	})
+
+	t.Run("should end the period at the end time", func(t *testing.T) {
+		_, _, ok := weekdays.CurrentPeriod(time.Date(2021, time.January, 5, 8, 0, 0, 0, loc), loc)
+		assert.False(t, ok)
+	})
+
+	t.Run("should not start a period on other days", func(t *testing.T) {
+		_, _, ok := weekdays.CurrentPeriod(time.Date(2021, time.January, 9, 20, 0, 0, 0, loc), loc)
+		assert.False(t, ok)
+
+		// The period that started on Friday evening still ends on Saturday morning.
+		_, end, ok := weekdays.CurrentPeriod(time.Date(2021, time.January, 9, 7, 0, 0, 0, loc), loc)
+		require.True(t, ok)
+		assert.Equal(t, time.Date(2021, time.January, 9, 8, 0, 0, 0, loc), end)
+	})
+
+	t.Run("should use the given location", func(t *testing.T) {
+		_, _, ok := weekdays.CurrentPeriod(time.Date(2021, time.January, 4, 23, 0, 0, 0, time.UTC), loc)
+		assert.False(t, ok, "23:00 UTC is 18:00 on Monday in New York")
+
+		_, _, ok = weekdays.CurrentPeriod(time.Date(2021, time.January, 4, 23, 0, 0, 0, time.UTC), time.UTC)
+		assert.True(t, ok)
+	})
+
+	t.Run("should support periods within a day", func(t *testing.T) {
+		lunch := DndSchedule{Days: StringArray{"monday"}, StartTime: "12:00", EndTime: "13:00"}
+
+		_, end, ok := lunch.CurrentPeriod(time.Date(2021, time.January, 4, 12, 30, 0, 0, loc), loc)
+		require.True(t, ok)
+		assert.Equal(t, time.Date(2021, time.January, 4, 13, 0, 0, 0, loc), end)
+
+		_, _, ok = lunch.CurrentPeriod(time.Date(2021, time.January, 4, 13, 30, 0, 0, loc), loc)
+		assert.False(t, ok)
+	})
+}
+
+func TestDndScheduleAllowsPost(t *testing.T) {
+	onCallChannelId := NewId()
+	schedule := DndSchedule{ExemptChannelIds: StringArray{onCallChannelId}}
+
+	assert.True(t, schedule.AllowsPost(&Post{ChannelId: onCallChannelId}))
+	assert.False(t, schedule.AllowsPost(&Post{ChannelId: NewId()}))
+
+	urgent := &Post{ChannelId: NewId()}
+	urgent.AddProp(POST_PROPS_PRIORITY, POST_PRIORITY_URGENT)
+	assert.False(t, schedule.AllowsPost(urgent))
+
+	schedule.AllowUrgent = true
+	assert.True(t, schedule.AllowsPost(urgent))
+}
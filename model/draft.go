@@ -0,0 +1,106 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"unicode/utf8"
+)
+
+// Draft is an unsent message kept on the server so that it is available on all
+// of a user's devices. A user has at most one draft per channel and thread;
+// RootId is empty for a draft in the channel itself.
+type Draft struct {
+	CreateAt  int64           `json:"create_at"`
+	UpdateAt  int64           `json:"update_at"`
+	UserId    string          `json:"user_id"`
+	ChannelId string          `json:"channel_id"`
+	RootId    string          `json:"root_id"`
+	Message   string          `json:"message"`
+	Props     StringInterface `json:"props"`
+	FileIds   StringArray     `json:"file_ids,omitempty"`
+}
+
+func (o *Draft) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func DraftFromJson(data io.Reader) *Draft {
+	var o *Draft
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+func DraftsToJson(o []*Draft) string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func DraftsFromJson(data io.Reader) []*Draft {
+	var o []*Draft
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+func (o *Draft) IsValid(maxDraftSize int) *AppError {
+	if !IsValidId(o.UserId) {
+		return NewAppError("Draft.IsValid", "model.draft.is_valid.user_id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.ChannelId) {
+		return NewAppError("Draft.IsValid", "model.draft.is_valid.channel_id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if !(IsValidId(o.RootId) || o.RootId == "") {
+		return NewAppError("Draft.IsValid", "model.draft.is_valid.root_id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if o.CreateAt == 0 {
+		return NewAppError("Draft.IsValid", "model.draft.is_valid.create_at.app_error", nil, "channel_id="+o.ChannelId, http.StatusBadRequest)
+	}
+
+	if o.UpdateAt == 0 {
+		return NewAppError("Draft.IsValid", "model.draft.is_valid.update_at.app_error", nil, "channel_id="+o.ChannelId, http.StatusBadRequest)
+	}
+
+	if utf8.RuneCountInString(o.Message) > maxDraftSize {
+		return NewAppError("Draft.IsValid", "model.draft.is_valid.msg.app_error", nil, "channel_id="+o.ChannelId, http.StatusBadRequest)
+	}
+
+	if utf8.RuneCountInString(ArrayToJson(o.FileIds)) > POST_FILEIDS_MAX_RUNES {
+		return NewAppError("Draft.IsValid", "model.draft.is_valid.file_ids.app_error", nil, "channel_id="+o.ChannelId, http.StatusBadRequest)
+	}
+
+	if utf8.RuneCountInString(StringInterfaceToJson(o.Props)) > POST_PROPS_MAX_RUNES {
+		return NewAppError("Draft.IsValid", "model.draft.is_valid.props.app_error", nil, "channel_id="+o.ChannelId, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (o *Draft) PreSave() {
+	if o.CreateAt == 0 {
+		o.CreateAt = GetMillis()
+	}
+
+	o.PreCommit()
+}
+
+func (o *Draft) PreCommit() {
+	o.UpdateAt = GetMillis()
+
+	if o.Props == nil {
+		o.Props = make(map[string]interface{})
+	}
+
+	if o.FileIds == nil {
+		o.FileIds = []string{}
+	}
+
+	// There's a rare bug where the client sends up duplicate FileIds so protect against that
+	o.FileIds = RemoveDuplicateStrings(o.FileIds)
+}
@@ -0,0 +1,60 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDraftJson(t *testing.T) {
+	draft := Draft{UserId: NewId(), ChannelId: NewId(), Message: NewId()}
+	rdraft := DraftFromJson(strings.NewReader(draft.ToJson()))
+	require.Equal(t, draft.Message, rdraft.Message)
+
+	drafts := DraftsFromJson(strings.NewReader(DraftsToJson([]*Draft{&draft})))
+	require.Len(t, drafts, 1)
+	require.Equal(t, draft.ChannelId, drafts[0].ChannelId)
+}
+
+func TestDraftIsValid(t *testing.T) {
+	draft := Draft{}
+	maxDraftSize := 10000
+
+	require.NotNil(t, draft.IsValid(maxDraftSize))
+
+	draft.UserId = NewId()
+	require.NotNil(t, draft.IsValid(maxDraftSize))
+
+	draft.ChannelId = NewId()
+	draft.RootId = "123"
+	require.NotNil(t, draft.IsValid(maxDraftSize))
+
+	draft.RootId = ""
+	require.NotNil(t, draft.IsValid(maxDraftSize))
+
+	draft.PreSave()
+	require.Nil(t, draft.IsValid(maxDraftSize))
+
+	draft.Message = strings.Repeat("0", maxDraftSize+1)
+	require.NotNil(t, draft.IsValid(maxDraftSize))
+
+	draft.Message = strings.Repeat("0", maxDraftSize)
+	require.Nil(t, draft.IsValid(maxDraftSize))
+
+	draft.FileIds = StringArray{strings.Repeat("0", POST_FILEIDS_MAX_RUNES)}
+	require.NotNil(t, draft.IsValid(maxDraftSize))
+}
+
+func TestDraftPreSave(t *testing.T) {
+	draft := Draft{FileIds: StringArray{"a", "a", "b"}}
+	draft.PreSave()
+
+	require.NotZero(t, draft.CreateAt)
+	require.GreaterOrEqual(t, draft.UpdateAt, draft.CreateAt)
+	require.NotNil(t, draft.Props)
+	require.Equal(t, StringArray{"a", "b"}, draft.FileIds)
+}
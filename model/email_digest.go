@@ -0,0 +1,134 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	EMAIL_DIGEST_FREQUENCY_HOURLY = "hourly"
+	EMAIL_DIGEST_FREQUENCY_DAILY  = "daily"
+	EMAIL_DIGEST_FREQUENCY_WEEKLY = "weekly"
+	EMAIL_DIGEST_FREQUENCY_NEVER  = "never"
+)
+
+// PendingEmailNotification is a notification waiting to be sent to a user as
+// part of their next email digest.
+type PendingEmailNotification struct {
+	Id        string `json:"id"`
+	UserId    string `json:"user_id"`
+	PostId    string `json:"post_id"`
+	ChannelId string `json:"channel_id"`
+	// TeamId is the team used to link to the post, which is empty for direct
+	// and group messages of users that aren't on any team.
+	TeamId   string `json:"team_id"`
+	CreateAt int64  `json:"create_at"`
+}
+
+func (o *PendingEmailNotification) IsValid() *AppError {
+	if !IsValidId(o.Id) {
+		return NewAppError("PendingEmailNotification.IsValid", "model.pending_email_notification.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.UserId) {
+		return NewAppError("PendingEmailNotification.IsValid", "model.pending_email_notification.is_valid.user_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.PostId) {
+		return NewAppError("PendingEmailNotification.IsValid", "model.pending_email_notification.is_valid.post_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.ChannelId) {
+		return NewAppError("PendingEmailNotification.IsValid", "model.pending_email_notification.is_valid.channel_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.TeamId != "" && !IsValidId(o.TeamId) {
+		return NewAppError("PendingEmailNotification.IsValid", "model.pending_email_notification.is_valid.team_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.CreateAt == 0 {
+		return NewAppError("PendingEmailNotification.IsValid", "model.pending_email_notification.is_valid.create_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (o *PendingEmailNotification) PreSave() {
+	if o.Id == "" {
+		o.Id = NewId()
+	}
+
+	if o.CreateAt == 0 {
+		o.CreateAt = GetMillis()
+	}
+}
+
+// EmailDigestSchedule is a user's choice of when to receive their email
+// digest. It's stored as a preference and takes precedence over the email
+// interval preference.
+type EmailDigestSchedule struct {
+	Frequency string `json:"frequency"`
+	// Hour is the hour of the day, in the user's timezone, at which daily and
+	// weekly digests are sent.
+	Hour int `json:"hour"`
+	// Weekday is the day of the week on which weekly digests are sent.
+	Weekday time.Weekday `json:"weekday"`
+}
+
+func (s *EmailDigestSchedule) ToJson() string {
+	b, _ := json.Marshal(s)
+	return string(b)
+}
+
+func EmailDigestScheduleFromJson(data io.Reader) *EmailDigestSchedule {
+	var s *EmailDigestSchedule
+	json.NewDecoder(data).Decode(&s)
+	return s
+}
+
+func (s *EmailDigestSchedule) IsValid() *AppError {
+	switch s.Frequency {
+	case EMAIL_DIGEST_FREQUENCY_HOURLY, EMAIL_DIGEST_FREQUENCY_DAILY, EMAIL_DIGEST_FREQUENCY_WEEKLY, EMAIL_DIGEST_FREQUENCY_NEVER:
+	default:
+		return NewAppError("EmailDigestSchedule.IsValid", "model.email_digest_schedule.is_valid.frequency.app_error", nil, "frequency="+s.Frequency, http.StatusBadRequest)
+	}
+
+	if s.Hour < 0 || s.Hour > 23 {
+		return NewAppError("EmailDigestSchedule.IsValid", "model.email_digest_schedule.is_valid.hour.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if s.Weekday < time.Sunday || s.Weekday > time.Saturday {
+		return NewAppError("EmailDigestSchedule.IsValid", "model.email_digest_schedule.is_valid.weekday.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// NextSendTime returns the first time after since at which a digest should be
+// sent, since being when the oldest notification of the digest was queued.
+func (s *EmailDigestSchedule) NextSendTime(since time.Time, loc *time.Location) time.Time {
+	t := since.In(loc)
+
+	switch s.Frequency {
+	case EMAIL_DIGEST_FREQUENCY_DAILY:
+		next := time.Date(t.Year(), t.Month(), t.Day(), s.Hour, 0, 0, 0, loc)
+		if !next.After(t) {
+			next = time.Date(t.Year(), t.Month(), t.Day()+1, s.Hour, 0, 0, 0, loc)
+		}
+		return next
+	case EMAIL_DIGEST_FREQUENCY_WEEKLY:
+		days := (int(s.Weekday) - int(t.Weekday()) + 7) % 7
+		next := time.Date(t.Year(), t.Month(), t.Day()+days, s.Hour, 0, 0, 0, loc)
+		if !next.After(t) {
+			next = time.Date(t.Year(), t.Month(), t.Day()+days+7, s.Hour, 0, 0, 0, loc)
+		}
+		return next
+	default:
+		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+	}
+}
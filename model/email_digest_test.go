@@ -0,0 +1,137 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPendingEmailNotificationIsValid(t *testing.T) {
+	notification := PendingEmailNotification{}
+	require.NotNil(t, notification.IsValid())
+
+	notification.PreSave()
+	require.NotNil(t, notification.IsValid(), "notification without user should be invalid")
+
+	notification.UserId = NewId()
+	notification.PostId = NewId()
+	notification.ChannelId = NewId()
+	require.Nil(t, notification.IsValid(), "notification without team should be valid")
+
+	notification.TeamId = "invalid"
+	require.NotNil(t, notification.IsValid())
+
+	notification.TeamId = NewId()
+	require.Nil(t, notification.IsValid())
+}
+
+func TestEmailDigestScheduleIsValid(t *testing.T) {
+	for name, tc := range map[string]struct {
+		schedule EmailDigestSchedule
+		valid    bool
+	}{
+		"hourly":          {EmailDigestSchedule{Frequency: EMAIL_DIGEST_FREQUENCY_HOURLY}, true},
+		"daily":           {EmailDigestSchedule{Frequency: EMAIL_DIGEST_FREQUENCY_DAILY, Hour: 23}, true},
+		"weekly":          {EmailDigestSchedule{Frequency: EMAIL_DIGEST_FREQUENCY_WEEKLY, Hour: 9, Weekday: time.Saturday}, true},
+		"never":           {EmailDigestSchedule{Frequency: EMAIL_DIGEST_FREQUENCY_NEVER}, true},
+		"no frequency":    {EmailDigestSchedule{}, false},
+		"unknown":         {EmailDigestSchedule{Frequency: "monthly"}, false},
+		"invalid hour":    {EmailDigestSchedule{Frequency: EMAIL_DIGEST_FREQUENCY_DAILY, Hour: 24}, false},
+		"negative hour":   {EmailDigestSchedule{Frequency: EMAIL_DIGEST_FREQUENCY_DAILY, Hour: -1}, false},
+		"invalid weekday": {EmailDigestSchedule{Frequency: EMAIL_DIGEST_FREQUENCY_WEEKLY, Weekday: 7}, false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := tc.schedule.IsValid()
+			if tc.valid {
+				assert.Nil(t, err)
+			} else {
+				assert.NotNil(t, err)
+			}
+		})
+	}
+}
+
+func TestEmailDigestScheduleNextSendTime(t *testing.T) {
+	location, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	// Tuesday
+	since := time.Date(2021, time.March, 2, 10, 30, 0, 0, location)
+
+	for name, tc := range map[string]struct {
+		schedule EmailDigestSchedule
+		since    time.Time
+		expected time.Time
+	}{
+		"hourly": {
+			EmailDigestSchedule{Frequency: EMAIL_DIGEST_FREQUENCY_HOURLY},
+			since,
+			time.Date(2021, time.March, 2, 11, 0, 0, 0, location),
+		},
+		"daily later today": {
+			EmailDigestSchedule{Frequency: EMAIL_DIGEST_FREQUENCY_DAILY, Hour: 17},
+			since,
+			time.Date(2021, time.March, 2, 17, 0, 0, 0, location),
+		},
+		"daily tomorrow": {
+			EmailDigestSchedule{Frequency: EMAIL_DIGEST_FREQUENCY_DAILY, Hour: 9},
+			since,
+			time.Date(2021, time.March, 3, 9, 0, 0, 0, location),
+		},
+		"daily at the exact time": {
+			EmailDigestSchedule{Frequency: EMAIL_DIGEST_FREQUENCY_DAILY, Hour: 10},
+			time.Date(2021, time.March, 2, 10, 0, 0, 0, location),
+			time.Date(2021, time.March, 3, 10, 0, 0, 0, location),
+		},
+		"daily across a DST change": {
+			EmailDigestSchedule{Frequency: EMAIL_DIGEST_FREQUENCY_DAILY, Hour: 9},
+			time.Date(2021, time.March, 13, 12, 0, 0, 0, location),
+			time.Date(2021, time.March, 14, 9, 0, 0, 0, location),
+		},
+		"weekly later this week": {
+			EmailDigestSchedule{Frequency: EMAIL_DIGEST_FREQUENCY_WEEKLY, Hour: 9, Weekday: time.Friday},
+			since,
+			time.Date(2021, time.March, 5, 9, 0, 0, 0, location),
+		},
+		"weekly later today": {
+			EmailDigestSchedule{Frequency: EMAIL_DIGEST_FREQUENCY_WEEKLY, Hour: 17, Weekday: time.Tuesday},
+			since,
+			time.Date(2021, time.March, 2, 17, 0, 0, 0, location),
+		},
+		"weekly next week": {
+			EmailDigestSchedule{Frequency: EMAIL_DIGEST_FREQUENCY_WEEKLY, Hour: 9, Weekday: time.Tuesday},
+			since,
+			time.Date(2021, time.March, 9, 9, 0, 0, 0, location),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			next := tc.schedule.NextSendTime(tc.since.UTC(), location)
+			assert.True(t, tc.expected.Equal(next), "expected %v, got %v", tc.expected, next)
+		})
+	}
+}
+
+func TestEmailDigestPreferenceIsValid(t *testing.T) {
+	preference := Preference{
+		UserId:   NewId(),
+		Category: PREFERENCE_CATEGORY_NOTIFICATIONS,
+		Name:     PREFERENCE_NAME_EMAIL_DIGEST,
+		Value:    (&EmailDigestSchedule{Frequency: EMAIL_DIGEST_FREQUENCY_DAILY, Hour: 9}).ToJson(),
+	}
+	require.Nil(t, preference.IsValid())
+
+	schedule := EmailDigestScheduleFromJson(strings.NewReader(preference.Value))
+	require.Equal(t, &EmailDigestSchedule{Frequency: EMAIL_DIGEST_FREQUENCY_DAILY, Hour: 9}, schedule)
+
+	preference.Value = "not json"
+	require.NotNil(t, preference.IsValid())
+
+	preference.Value = `{"frequency": "monthly"}`
+	require.NotNil(t, preference.IsValid())
+}
@@ -59,6 +59,18 @@ type FileInfo struct {
 	HasPreviewImage bool    `json:"has_preview_image,omitempty"`
 	MiniPreview     *[]byte `json:"mini_preview"` // declared as *[]byte to avoid postgres/mysql differences in deserialization
 	Content         string  `json:"-"`
+	ContentHash     string  `json:"-"` // hex encoded SHA-256 of the file when it is deduplicated
+	TeamId          string  `json:"-"` // team of the channel the file was uploaded to, for its storage usage
+	// StrippedMetadata lists the kinds of image metadata, such as "exif" or
+	// "gps", that were removed from the file when it was uploaded.
+	StrippedMetadata StringArray `json:"stripped_metadata,omitempty"`
+}
+
+// FileForIndexing is a file along with the channel of the post it is attached
+// to, as needed to index it for search.
+type FileForIndexing struct {
+	FileInfo
+	ChannelId string `json:"channel_id"`
 }
 
 func (fi *FileInfo) ToJson() string {
@@ -0,0 +1,26 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileInfoListJson(t *testing.T) {
+	list := NewFileInfoList()
+	info := &FileInfo{Id: NewId(), Name: "report.pdf", Extension: "pdf", Content: "secret"}
+	list.AddFileInfo(info)
+	list.AddOrder(info.Id)
+
+	json := list.ToJson()
+	require.NotContains(t, json, "secret", "file content should not be sent to clients")
+
+	rlist := FileInfoListFromJson(strings.NewReader(json))
+	require.Equal(t, []string{info.Id}, rlist.Order)
+	require.Equal(t, info.Name, rlist.FileInfos[info.Id].Name)
+	require.Len(t, rlist.ToSlice(), 1)
+}
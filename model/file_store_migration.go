@@ -0,0 +1,114 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// The keys of the data of a file store migration job, which describe the
+// backend the files are migrated to. The S3 credentials aren't part of it, so
+// that they aren't stored with the job: they are those of the configuration.
+const (
+	FILE_STORE_MIGRATION_DRIVER_NAME           = "driver_name"
+	FILE_STORE_MIGRATION_DIRECTORY             = "directory"
+	FILE_STORE_MIGRATION_AMAZON_S3_BUCKET      = "amazon_s3_bucket"
+	FILE_STORE_MIGRATION_AMAZON_S3_PATH_PREFIX = "amazon_s3_path_prefix"
+	FILE_STORE_MIGRATION_AMAZON_S3_REGION      = "amazon_s3_region"
+	FILE_STORE_MIGRATION_AMAZON_S3_ENDPOINT    = "amazon_s3_endpoint"
+	FILE_STORE_MIGRATION_AMAZON_S3_SSL         = "amazon_s3_ssl"
+	FILE_STORE_MIGRATION_AMAZON_S3_SIGN_V2     = "amazon_s3_sign_v2"
+	FILE_STORE_MIGRATION_AMAZON_S3_SSE         = "amazon_s3_sse"
+	FILE_STORE_MIGRATION_ID                    = "migration_id"
+)
+
+// FileStoreMigrationReport counts the files handled by a file store migration.
+type FileStoreMigrationReport struct {
+	Copied  int `json:"copied"`
+	Skipped int `json:"skipped"`
+	Missing int `json:"missing"`
+	Failed  int `json:"failed"`
+}
+
+// FileStoreMigrationDestination returns the file settings of the backend
+// described by the data of a file store migration job. The settings not given
+// in the data, such as the credentials and the encryption ones, are those of
+// the current configuration.
+func FileStoreMigrationDestination(current *FileSettings, data map[string]string) (*FileSettings, *AppError) {
+	destination := *current
+
+	for key, field := range map[string]**string{
+		FILE_STORE_MIGRATION_DRIVER_NAME:           &destination.DriverName,
+		FILE_STORE_MIGRATION_DIRECTORY:             &destination.Directory,
+		FILE_STORE_MIGRATION_AMAZON_S3_BUCKET:      &destination.AmazonS3Bucket,
+		FILE_STORE_MIGRATION_AMAZON_S3_PATH_PREFIX: &destination.AmazonS3PathPrefix,
+		FILE_STORE_MIGRATION_AMAZON_S3_REGION:      &destination.AmazonS3Region,
+		FILE_STORE_MIGRATION_AMAZON_S3_ENDPOINT:    &destination.AmazonS3Endpoint,
+	} {
+		if value, ok := data[key]; ok {
+			*field = NewString(value)
+		}
+	}
+
+	for key, field := range map[string]**bool{
+		FILE_STORE_MIGRATION_AMAZON_S3_SSL:     &destination.AmazonS3SSL,
+		FILE_STORE_MIGRATION_AMAZON_S3_SIGN_V2: &destination.AmazonS3SignV2,
+		FILE_STORE_MIGRATION_AMAZON_S3_SSE:     &destination.AmazonS3SSE,
+	} {
+		if value, ok := data[key]; ok {
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return nil, NewAppError("FileStoreMigrationDestination", "model.file_store_migration.invalid_value.app_error", map[string]interface{}{"Key": key}, err.Error(), http.StatusBadRequest)
+			}
+			*field = NewBool(b)
+		}
+	}
+
+	switch *destination.DriverName {
+	case IMAGE_DRIVER_LOCAL:
+		if *destination.Directory == "" {
+			return nil, NewAppError("FileStoreMigrationDestination", "model.file_store_migration.directory.app_error", nil, "", http.StatusBadRequest)
+		}
+	case IMAGE_DRIVER_S3:
+		if *destination.AmazonS3Bucket == "" {
+			return nil, NewAppError("FileStoreMigrationDestination", "model.file_store_migration.amazon_s3_bucket.app_error", nil, "", http.StatusBadRequest)
+		}
+	default:
+		return nil, NewAppError("FileStoreMigrationDestination", "model.file_store_migration.driver_name.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if destination.SameBackend(current) {
+		return nil, NewAppError("FileStoreMigrationDestination", "model.file_store_migration.same_backend.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	return &destination, nil
+}
+
+// FileStoreMigrationData returns the data describing the backend with the
+// given settings, from which FileStoreMigrationDestination returns them back.
+func FileStoreMigrationData(settings *FileSettings) map[string]string {
+	return map[string]string{
+		FILE_STORE_MIGRATION_DRIVER_NAME:           *settings.DriverName,
+		FILE_STORE_MIGRATION_DIRECTORY:             *settings.Directory,
+		FILE_STORE_MIGRATION_AMAZON_S3_BUCKET:      *settings.AmazonS3Bucket,
+		FILE_STORE_MIGRATION_AMAZON_S3_PATH_PREFIX: *settings.AmazonS3PathPrefix,
+		FILE_STORE_MIGRATION_AMAZON_S3_REGION:      *settings.AmazonS3Region,
+		FILE_STORE_MIGRATION_AMAZON_S3_ENDPOINT:    *settings.AmazonS3Endpoint,
+		FILE_STORE_MIGRATION_AMAZON_S3_SSL:         strconv.FormatBool(*settings.AmazonS3SSL),
+		FILE_STORE_MIGRATION_AMAZON_S3_SIGN_V2:     strconv.FormatBool(*settings.AmazonS3SignV2),
+		FILE_STORE_MIGRATION_AMAZON_S3_SSE:         strconv.FormatBool(*settings.AmazonS3SSE),
+	}
+}
+
+// SameBackend returns whether both settings point to the same storage.
+func (s *FileSettings) SameBackend(other *FileSettings) bool {
+	if *s.DriverName != *other.DriverName {
+		return false
+	}
+	if *s.DriverName == IMAGE_DRIVER_LOCAL {
+		return *s.Directory == *other.Directory
+	}
+	return *s.AmazonS3Endpoint == *other.AmazonS3Endpoint && *s.AmazonS3Bucket == *other.AmazonS3Bucket && *s.AmazonS3PathPrefix == *other.AmazonS3PathPrefix
+}
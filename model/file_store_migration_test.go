@@ -0,0 +1,90 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileStoreMigrationDestination(t *testing.T) {
+	current := &FileSettings{}
+	current.SetDefaults(false)
+	current.AmazonS3SecretAccessKey = NewString("secret")
+
+	t.Run("local to s3", func(t *testing.T) {
+		settings, err := FileStoreMigrationDestination(current, map[string]string{
+			FILE_STORE_MIGRATION_DRIVER_NAME:        IMAGE_DRIVER_S3,
+			FILE_STORE_MIGRATION_AMAZON_S3_BUCKET:   "bucket",
+			FILE_STORE_MIGRATION_AMAZON_S3_ENDPOINT: "localhost:9000",
+			FILE_STORE_MIGRATION_AMAZON_S3_SSL:      "false",
+			"amazon_s3_secret_access_key":           "ignored",
+		})
+		require.Nil(t, err)
+		assert.Equal(t, IMAGE_DRIVER_S3, *settings.DriverName)
+		assert.Equal(t, "bucket", *settings.AmazonS3Bucket)
+		assert.Equal(t, "localhost:9000", *settings.AmazonS3Endpoint)
+		assert.Equal(t, "secret", *settings.AmazonS3SecretAccessKey, "the credentials should be those of the configuration")
+		assert.False(t, *settings.AmazonS3SSL)
+		assert.Equal(t, *current.AmazonS3Region, *settings.AmazonS3Region)
+
+		// The current settings are left untouched.
+		assert.Equal(t, IMAGE_DRIVER_LOCAL, *current.DriverName)
+		assert.True(t, *current.AmazonS3SSL)
+	})
+
+	t.Run("another directory", func(t *testing.T) {
+		settings, err := FileStoreMigrationDestination(current, map[string]string{
+			FILE_STORE_MIGRATION_DIRECTORY: "/var/data",
+		})
+		require.Nil(t, err)
+		assert.Equal(t, IMAGE_DRIVER_LOCAL, *settings.DriverName)
+		assert.Equal(t, "/var/data", *settings.Directory)
+	})
+
+	t.Run("from the data of the destination", func(t *testing.T) {
+		destination := *current
+		destination.DriverName = NewString(IMAGE_DRIVER_S3)
+		destination.AmazonS3Bucket = NewString("bucket")
+		destination.AmazonS3SSE = NewBool(true)
+
+		settings, err := FileStoreMigrationDestination(current, FileStoreMigrationData(&destination))
+		require.Nil(t, err)
+		assert.Equal(t, destination, *settings)
+	})
+
+	for name, tc := range map[string]struct {
+		data          map[string]string
+		expectedError string
+	}{
+		"same backend": {
+			data:          map[string]string{FILE_STORE_MIGRATION_DRIVER_NAME: IMAGE_DRIVER_LOCAL},
+			expectedError: "model.file_store_migration.same_backend.app_error",
+		},
+		"invalid driver": {
+			data:          map[string]string{FILE_STORE_MIGRATION_DRIVER_NAME: "ftp"},
+			expectedError: "model.file_store_migration.driver_name.app_error",
+		},
+		"missing directory": {
+			data:          map[string]string{FILE_STORE_MIGRATION_DIRECTORY: ""},
+			expectedError: "model.file_store_migration.directory.app_error",
+		},
+		"missing bucket": {
+			data:          map[string]string{FILE_STORE_MIGRATION_DRIVER_NAME: IMAGE_DRIVER_S3},
+			expectedError: "model.file_store_migration.amazon_s3_bucket.app_error",
+		},
+		"invalid boolean": {
+			data:          map[string]string{FILE_STORE_MIGRATION_AMAZON_S3_SSE: "maybe"},
+			expectedError: "model.file_store_migration.invalid_value.app_error",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := FileStoreMigrationDestination(current, tc.data)
+			require.NotNil(t, err)
+			assert.Equal(t, tc.expectedError, err.Id)
+		})
+	}
+}
@@ -23,6 +23,19 @@ const (
 	JOB_TYPE_PRODUCT_NOTICES                = "product_notices"
 	JOB_TYPE_ACTIVE_USERS                   = "active_users"
 	JOB_TYPE_CLOUD                          = "cloud"
+	JOB_TYPE_POLL_CLOSING                   = "poll_closing"
+	JOB_TYPE_CUSTOM_STATUS_EXPIRY           = "custom_status_expiry"
+	JOB_TYPE_EXTRACT_CONTENT                = "extract_content"
+	JOB_TYPE_EMAIL_QUEUE                    = "email_queue"
+	JOB_TYPE_EMAIL_DIGEST                   = "email_digest"
+	JOB_TYPE_DND_SCHEDULE                   = "dnd_schedule"
+	JOB_TYPE_SAVED_SEARCH_ALERTS            = "saved_search_alerts"
+	JOB_TYPE_REENCRYPT_FILES                = "reencrypt_files"
+	JOB_TYPE_DEDUPLICATE_FILES              = "deduplicate_files"
+	JOB_TYPE_SCAN_FILES                     = "scan_files"
+	JOB_TYPE_GENERATE_PREVIEWS              = "generate_previews"
+	JOB_TYPE_MIGRATE_FILE_STORE             = "migrate_file_store"
+	JOB_TYPE_CLEANUP_UPLOAD_SESSIONS        = "cleanup_upload_sessions"
 
 	JOB_STATUS_PENDING          = "pending"
 	JOB_STATUS_IN_PROGRESS      = "in_progress"
@@ -67,6 +80,19 @@ func (j *Job) IsValid() *AppError {
 	case JOB_TYPE_EXPIRY_NOTIFY:
 	case JOB_TYPE_ACTIVE_USERS:
 	case JOB_TYPE_CLOUD:
+	case JOB_TYPE_POLL_CLOSING:
+	case JOB_TYPE_CUSTOM_STATUS_EXPIRY:
+	case JOB_TYPE_EXTRACT_CONTENT:
+	case JOB_TYPE_EMAIL_QUEUE:
+	case JOB_TYPE_EMAIL_DIGEST:
+	case JOB_TYPE_DND_SCHEDULE:
+	case JOB_TYPE_SAVED_SEARCH_ALERTS:
+	case JOB_TYPE_REENCRYPT_FILES:
+	case JOB_TYPE_DEDUPLICATE_FILES:
+	case JOB_TYPE_SCAN_FILES:
+	case JOB_TYPE_GENERATE_PREVIEWS:
+	case JOB_TYPE_MIGRATE_FILE_STORE:
+	case JOB_TYPE_CLEANUP_UPLOAD_SESSIONS:
 	default:
 		return NewAppError("Job.IsValid", "model.job.is_valid.type.app_error", nil, "id="+j.Id, http.StatusBadRequest)
 	}
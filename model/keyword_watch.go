@@ -0,0 +1,147 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	KEYWORD_WATCH_DELIVERY_NOTIFICATION = "notification"
+	KEYWORD_WATCH_DELIVERY_DIGEST       = "digest"
+
+	KEYWORD_WATCH_PATTERN_MAX_RUNES = 256
+)
+
+// KeywordWatch is a keyword or a regular expression that a user watches for
+// in every public channel of a team, including the ones that they haven't
+// joined. Matching posts are sent to the user as a notification or are added
+// to their email digest.
+type KeywordWatch struct {
+	Id      string `json:"id"`
+	UserId  string `json:"user_id"`
+	TeamId  string `json:"team_id"`
+	Pattern string `json:"pattern"`
+	// IsRegex is set when the pattern is a regular expression. Otherwise it
+	// is a keyword, which matches whole words regardless of case.
+	IsRegex  bool   `json:"is_regex"`
+	Delivery string `json:"delivery"`
+	// LastAlertAt is when the last notification of the watch was sent, which
+	// rate limits the notifications of watches that match many posts.
+	LastAlertAt int64 `json:"last_alert_at"`
+	CreateAt    int64 `json:"create_at"`
+	UpdateAt    int64 `json:"update_at"`
+}
+
+func (o *KeywordWatch) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func KeywordWatchFromJson(data io.Reader) *KeywordWatch {
+	var o *KeywordWatch
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+func KeywordWatchListToJson(l []*KeywordWatch) string {
+	b, _ := json.Marshal(l)
+	return string(b)
+}
+
+func KeywordWatchListFromJson(data io.Reader) []*KeywordWatch {
+	var l []*KeywordWatch
+	json.NewDecoder(data).Decode(&l)
+	return l
+}
+
+func (o *KeywordWatch) IsValid() *AppError {
+	if !IsValidId(o.Id) {
+		return NewAppError("KeywordWatch.IsValid", "model.keyword_watch.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.UserId) {
+		return NewAppError("KeywordWatch.IsValid", "model.keyword_watch.is_valid.user_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.TeamId) {
+		return NewAppError("KeywordWatch.IsValid", "model.keyword_watch.is_valid.team_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.Pattern == "" || utf8.RuneCountInString(o.Pattern) > KEYWORD_WATCH_PATTERN_MAX_RUNES {
+		return NewAppError("KeywordWatch.IsValid", "model.keyword_watch.is_valid.pattern.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if _, err := o.Matcher(); err != nil {
+		return NewAppError("KeywordWatch.IsValid", "model.keyword_watch.is_valid.regex.app_error", nil, "id="+o.Id+", "+err.Error(), http.StatusBadRequest)
+	}
+
+	if o.Delivery != KEYWORD_WATCH_DELIVERY_NOTIFICATION && o.Delivery != KEYWORD_WATCH_DELIVERY_DIGEST {
+		return NewAppError("KeywordWatch.IsValid", "model.keyword_watch.is_valid.delivery.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.CreateAt == 0 {
+		return NewAppError("KeywordWatch.IsValid", "model.keyword_watch.is_valid.create_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.UpdateAt == 0 {
+		return NewAppError("KeywordWatch.IsValid", "model.keyword_watch.is_valid.update_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (o *KeywordWatch) PreSave() {
+	if o.Id == "" {
+		o.Id = NewId()
+	}
+
+	if o.Delivery == "" {
+		o.Delivery = KEYWORD_WATCH_DELIVERY_NOTIFICATION
+	}
+
+	o.Pattern = strings.TrimSpace(o.Pattern)
+	o.CreateAt = GetMillis()
+	o.UpdateAt = o.CreateAt
+}
+
+func (o *KeywordWatch) PreUpdate() {
+	if o.Delivery == "" {
+		o.Delivery = KEYWORD_WATCH_DELIVERY_NOTIFICATION
+	}
+
+	o.Pattern = strings.TrimSpace(o.Pattern)
+	o.UpdateAt = GetMillis()
+}
+
+// Matcher returns the regular expression that finds the watched keyword or
+// pattern in a message.
+func (o *KeywordWatch) Matcher() (*regexp.Regexp, error) {
+	if o.IsRegex {
+		return regexp.Compile(o.Pattern)
+	}
+
+	expr := "(?i)" + regexp.QuoteMeta(o.Pattern)
+
+	// Only require word boundaries next to word characters, so that keywords
+	// such as "#incident" or "c++" still match.
+	if first, _ := utf8.DecodeRuneInString(o.Pattern); isKeywordWordRune(first) {
+		expr = `\b` + expr
+	}
+	if last, _ := utf8.DecodeLastRuneInString(o.Pattern); isKeywordWordRune(last) {
+		expr += `\b`
+	}
+
+	return regexp.Compile(expr)
+}
+
+func isKeywordWordRune(r rune) bool {
+	return r == '_' || (r < utf8.RuneSelf && (unicode.IsLetter(r) || unicode.IsDigit(r)))
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeywordWatchJson(t *testing.T) {
+	watch := KeywordWatch{Id: NewId(), UserId: NewId(), TeamId: NewId(), Pattern: `CVE-\d+`, IsRegex: true, Delivery: KEYWORD_WATCH_DELIVERY_DIGEST}
+	assert.Equal(t, &watch, KeywordWatchFromJson(strings.NewReader(watch.ToJson())))
+
+	list := []*KeywordWatch{&watch}
+	assert.Equal(t, list, KeywordWatchListFromJson(strings.NewReader(KeywordWatchListToJson(list))))
+}
+
+func TestKeywordWatchIsValid(t *testing.T) {
+	newWatch := func(pattern string, isRegex bool) KeywordWatch {
+		watch := KeywordWatch{UserId: NewId(), TeamId: NewId(), Pattern: pattern, IsRegex: isRegex}
+		watch.PreSave()
+		return watch
+	}
+
+	for name, tc := range map[string]struct {
+		Watch KeywordWatch
+		Valid bool
+	}{
+		"keyword":         {newWatch("outage", false), true},
+		"regex":           {newWatch(`CVE-\d+`, true), true},
+		"empty pattern":   {newWatch("  ", false), false},
+		"long pattern":    {newWatch(strings.Repeat("a", KEYWORD_WATCH_PATTERN_MAX_RUNES+1), false), false},
+		"invalid regex":   {newWatch("CVE-(", true), false},
+		"regex as string": {newWatch("CVE-(", false), true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if tc.Valid {
+				assert.Nil(t, tc.Watch.IsValid())
+			} else {
+				assert.NotNil(t, tc.Watch.IsValid())
+			}
+		})
+	}
+
+	t.Run("invalid delivery", func(t *testing.T) {
+		watch := newWatch("outage", false)
+		watch.Delivery = "pager"
+		assert.NotNil(t, watch.IsValid())
+	})
+
+	t.Run("invalid team", func(t *testing.T) {
+		watch := newWatch("outage", false)
+		watch.TeamId = ""
+		assert.NotNil(t, watch.IsValid())
+	})
+}
+
+func TestKeywordWatchPreSave(t *testing.T) {
+	watch := KeywordWatch{Pattern: " outage "}
+	watch.PreSave()
+
+	assert.NotEmpty(t, watch.Id)
+	assert.Equal(t, "outage", watch.Pattern)
+	assert.Equal(t, KEYWORD_WATCH_DELIVERY_NOTIFICATION, watch.Delivery)
+	assert.NotZero(t, watch.CreateAt)
+	assert.Equal(t, watch.CreateAt, watch.UpdateAt)
+}
+
+func TestKeywordWatchMatcher(t *testing.T) {
+	for name, tc := range map[string]struct {
+		Pattern string
+		IsRegex bool
+		Message string
+		Matches bool
+	}{
+		"keyword":                      {"outage", false, "We have an outage in eu-west", true},
+		"keyword ignores case":         {"outage", false, "OUTAGE!", true},
+		"keyword matches whole words":  {"outage", false, "no outages today", false},
+		"keyword with several words":   {"database down", false, "the Database Down alert fired", true},
+		"keyword with symbols":         {"c++", false, "who knows c++?", true},
+		"keyword starting with symbol": {"#incident", false, "see #incident-42", true},
+		"keyword is not a regex":       {"CVE-.*", false, "CVE-2021-44228", false},
+		"regex":                        {`CVE-\d+`, true, "patch CVE-2021-44228 now", true},
+		"regex is case sensitive":      {`CVE-\d+`, true, "patch cve-2021-44228 now", false},
+		"regex with flags":             {`(?i)cve-\d+`, true, "patch cve-2021-44228 now", true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			watch := KeywordWatch{Pattern: tc.Pattern, IsRegex: tc.IsRegex}
+			matcher, err := watch.Matcher()
+			require.NoError(t, err)
+			assert.Equal(t, tc.Matches, matcher.MatchString(tc.Message))
+		})
+	}
+}
@@ -24,4 +24,5 @@ const (
 	MIGRATION_KEY_ADD_SYSTEM_ROLES_PERMISSIONS                = "add_system_roles_permissions"
 	MIGRATION_KEY_ADD_MANAGE_SHARED_CHANNEL_PERMISSIONS       = "manage_shared_channel_permissions"
 	MIGRATION_KEY_ADD_MANAGE_REMOTE_CLUSTERS_PERMISSIONS      = "manage_remote_clusters_permissions"
+	MIGRATION_KEY_ADD_CHANNEL_BOOKMARKS_PERMISSIONS           = "add_channel_bookmarks_permissions"
 )
@@ -0,0 +1,102 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+const (
+	NOTIFICATION_ENDPOINT_SIGNATURE_HEADER = "X-Mattermost-Signature"
+	NOTIFICATION_ENDPOINT_TIMESTAMP_HEADER = "X-Mattermost-Timestamp"
+
+	NOTIFICATION_ENDPOINT_URL_MAX_LENGTH    = 1024
+	NOTIFICATION_ENDPOINT_SECRET_MAX_LENGTH = 128
+)
+
+// NotificationEndpoint is where a user wants their push notifications to be
+// POSTed to, such as a ntfy or Gotify server, in addition to their mobile
+// devices. It is stored as a preference.
+type NotificationEndpoint struct {
+	URL string `json:"url"`
+	// Secret is the key used to sign the payloads so that the endpoint can
+	// verify that they were sent by this server.
+	Secret string `json:"secret"`
+}
+
+func (e *NotificationEndpoint) ToJson() string {
+	b, _ := json.Marshal(e)
+	return string(b)
+}
+
+func NotificationEndpointFromJson(data io.Reader) *NotificationEndpoint {
+	var e *NotificationEndpoint
+	json.NewDecoder(data).Decode(&e)
+	return e
+}
+
+func (e *NotificationEndpoint) IsValid() *AppError {
+	if len(e.URL) > NOTIFICATION_ENDPOINT_URL_MAX_LENGTH {
+		return NewAppError("NotificationEndpoint.IsValid", "model.notification_endpoint.is_valid.url.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	u, err := url.Parse(e.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return NewAppError("NotificationEndpoint.IsValid", "model.notification_endpoint.is_valid.url.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if e.Secret == "" || len(e.Secret) > NOTIFICATION_ENDPOINT_SECRET_MAX_LENGTH {
+		return NewAppError("NotificationEndpoint.IsValid", "model.notification_endpoint.is_valid.secret.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// Sign returns the signature of a payload sent at the given time, in
+// milliseconds. It is the hex encoded HMAC-SHA256 of the timestamp and the
+// body joined by a dot, so that a captured request can't be replayed later
+// with another timestamp.
+func (e *NotificationEndpoint) Sign(timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(e.Secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// NotificationEndpointPayload is the JSON body POSTed to a notification
+// endpoint. The title and message fields are understood as is by services
+// such as Gotify, the other fields are there for tools that want more.
+type NotificationEndpointPayload struct {
+	Title       string `json:"title"`
+	Message     string `json:"message"`
+	UserId      string `json:"user_id"`
+	TeamId      string `json:"team_id,omitempty"`
+	ChannelId   string `json:"channel_id"`
+	ChannelName string `json:"channel_name,omitempty"`
+	PostId      string `json:"post_id"`
+	RootId      string `json:"root_id,omitempty"`
+	SenderId    string `json:"sender_id"`
+	SenderName  string `json:"sender_name,omitempty"`
+	Url         string `json:"url,omitempty"`
+	CreateAt    int64  `json:"create_at"`
+}
+
+func (p *NotificationEndpointPayload) ToJson() string {
+	b, _ := json.Marshal(p)
+	return string(b)
+}
+
+func NotificationEndpointPayloadFromJson(data io.Reader) *NotificationEndpointPayload {
+	var p *NotificationEndpointPayload
+	json.NewDecoder(data).Decode(&p)
+	return p
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotificationEndpointJson(t *testing.T) {
+	endpoint := NotificationEndpoint{URL: "https://ntfy.example.com/alerts", Secret: "secret"}
+	assert.Equal(t, &endpoint, NotificationEndpointFromJson(strings.NewReader(endpoint.ToJson())))
+}
+
+func TestNotificationEndpointIsValid(t *testing.T) {
+	for name, tc := range map[string]struct {
+		Endpoint NotificationEndpoint
+		Valid    bool
+	}{
+		"https":           {NotificationEndpoint{URL: "https://ntfy.example.com/alerts", Secret: "secret"}, true},
+		"http with port":  {NotificationEndpoint{URL: "http://192.168.1.10:8123/api/webhook/abc", Secret: "secret"}, true},
+		"missing secret":  {NotificationEndpoint{URL: "https://ntfy.example.com/alerts"}, false},
+		"long secret":     {NotificationEndpoint{URL: "https://ntfy.example.com/alerts", Secret: strings.Repeat("a", NOTIFICATION_ENDPOINT_SECRET_MAX_LENGTH+1)}, false},
+		"missing url":     {NotificationEndpoint{Secret: "secret"}, false},
+		"other scheme":    {NotificationEndpoint{URL: "ftp://ntfy.example.com/alerts", Secret: "secret"}, false},
+		"relative url":    {NotificationEndpoint{URL: "/alerts", Secret: "secret"}, false},
+		"long url":        {NotificationEndpoint{URL: "https://ntfy.example.com/" + strings.Repeat("a", NOTIFICATION_ENDPOINT_URL_MAX_LENGTH), Secret: "secret"}, false},
+		"unparsable url":  {NotificationEndpoint{URL: "https://ntfy.example.com/%zz", Secret: "secret"}, false},
+		"whitespace host": {NotificationEndpoint{URL: "https:// /alerts", Secret: "secret"}, false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if tc.Valid {
+				assert.Nil(t, tc.Endpoint.IsValid())
+			} else {
+				assert.NotNil(t, tc.Endpoint.IsValid())
+			}
+		})
+	}
+}
+
+func TestNotificationEndpointSign(t *testing.T) {
+	endpoint := NotificationEndpoint{URL: "https://ntfy.example.com/alerts", Secret: "secret"}
+	body := []byte(`{"title":"town-square"}`)
+
+	signature := endpoint.Sign(1600000000000, body)
+	assert.Equal(t, "sha256=", signature[:7])
+	assert.Len(t, signature, 7+64)
+	assert.Equal(t, signature, endpoint.Sign(1600000000000, body))
+	assert.NotEqual(t, signature, endpoint.Sign(1600000000001, body))
+	assert.NotEqual(t, signature, endpoint.Sign(1600000000000, []byte(`{"title":"off-topic"}`)))
+
+	other := NotificationEndpoint{URL: endpoint.URL, Secret: "other"}
+	assert.NotEqual(t, signature, other.Sign(1600000000000, body))
+}
+
+func TestPreferenceIsValidNotificationEndpoint(t *testing.T) {
+	preference := Preference{
+		UserId:   NewId(),
+		Category: PREFERENCE_CATEGORY_NOTIFICATIONS,
+		Name:     PREFERENCE_NAME_NOTIFICATION_ENDPOINT,
+		Value:    (&NotificationEndpoint{URL: "https://ntfy.example.com/alerts", Secret: "secret"}).ToJson(),
+	}
+	assert.Nil(t, preference.IsValid())
+
+	preference.Value = (&NotificationEndpoint{URL: "https://ntfy.example.com/alerts"}).ToJson()
+	assert.NotNil(t, preference.IsValid())
+
+	preference.Value = "not json"
+	assert.NotNil(t, preference.IsValid())
+}
@@ -97,6 +97,14 @@ var PERMISSION_PROMOTE_GUEST *Permission
 var PERMISSION_DEMOTE_TO_GUEST *Permission
 var PERMISSION_USE_CHANNEL_MENTIONS *Permission
 var PERMISSION_USE_GROUP_MENTIONS *Permission
+var PERMISSION_ADD_BOOKMARK_PUBLIC_CHANNEL *Permission
+var PERMISSION_EDIT_BOOKMARK_PUBLIC_CHANNEL *Permission
+var PERMISSION_DELETE_BOOKMARK_PUBLIC_CHANNEL *Permission
+var PERMISSION_ORDER_BOOKMARK_PUBLIC_CHANNEL *Permission
+var PERMISSION_ADD_BOOKMARK_PRIVATE_CHANNEL *Permission
+var PERMISSION_EDIT_BOOKMARK_PRIVATE_CHANNEL *Permission
+var PERMISSION_DELETE_BOOKMARK_PRIVATE_CHANNEL *Permission
+var PERMISSION_ORDER_BOOKMARK_PRIVATE_CHANNEL *Permission
 var PERMISSION_READ_OTHER_USERS_TEAMS *Permission
 var PERMISSION_EDIT_BRAND *Permission
 var PERMISSION_MANAGE_SHARED_CHANNELS *Permission
@@ -671,6 +679,54 @@ func initializePermissions() {
 		"authentication.permissions.use_group_mentions.description",
 		PermissionScopeChannel,
 	}
+	PERMISSION_ADD_BOOKMARK_PUBLIC_CHANNEL = &Permission{
+		"add_bookmark_public_channel",
+		"authentication.permissions.add_bookmark_public_channel.name",
+		"authentication.permissions.add_bookmark_public_channel.description",
+		PermissionScopeChannel,
+	}
+	PERMISSION_EDIT_BOOKMARK_PUBLIC_CHANNEL = &Permission{
+		"edit_bookmark_public_channel",
+		"authentication.permissions.edit_bookmark_public_channel.name",
+		"authentication.permissions.edit_bookmark_public_channel.description",
+		PermissionScopeChannel,
+	}
+	PERMISSION_DELETE_BOOKMARK_PUBLIC_CHANNEL = &Permission{
+		"delete_bookmark_public_channel",
+		"authentication.permissions.delete_bookmark_public_channel.name",
+		"authentication.permissions.delete_bookmark_public_channel.description",
+		PermissionScopeChannel,
+	}
+	PERMISSION_ORDER_BOOKMARK_PUBLIC_CHANNEL = &Permission{
+		"order_bookmark_public_channel",
+		"authentication.permissions.order_bookmark_public_channel.name",
+		"authentication.permissions.order_bookmark_public_channel.description",
+		PermissionScopeChannel,
+	}
+	PERMISSION_ADD_BOOKMARK_PRIVATE_CHANNEL = &Permission{
+		"add_bookmark_private_channel",
+		"authentication.permissions.add_bookmark_private_channel.name",
+		"authentication.permissions.add_bookmark_private_channel.description",
+		PermissionScopeChannel,
+	}
+	PERMISSION_EDIT_BOOKMARK_PRIVATE_CHANNEL = &Permission{
+		"edit_bookmark_private_channel",
+		"authentication.permissions.edit_bookmark_private_channel.name",
+		"authentication.permissions.edit_bookmark_private_channel.description",
+		PermissionScopeChannel,
+	}
+	PERMISSION_DELETE_BOOKMARK_PRIVATE_CHANNEL = &Permission{
+		"delete_bookmark_private_channel",
+		"authentication.permissions.delete_bookmark_private_channel.name",
+		"authentication.permissions.delete_bookmark_private_channel.description",
+		PermissionScopeChannel,
+	}
+	PERMISSION_ORDER_BOOKMARK_PRIVATE_CHANNEL = &Permission{
+		"order_bookmark_private_channel",
+		"authentication.permissions.order_bookmark_private_channel.name",
+		"authentication.permissions.order_bookmark_private_channel.description",
+		PermissionScopeChannel,
+	}
 	PERMISSION_READ_OTHER_USERS_TEAMS = &Permission{
 		"read_other_users_teams",
 		"authentication.permissions.read_other_users_teams.name",
@@ -1000,6 +1056,14 @@ func initializePermissions() {
 		PERMISSION_DELETE_OTHERS_POSTS,
 		PERMISSION_USE_CHANNEL_MENTIONS,
 		PERMISSION_USE_GROUP_MENTIONS,
+		PERMISSION_ADD_BOOKMARK_PUBLIC_CHANNEL,
+		PERMISSION_EDIT_BOOKMARK_PUBLIC_CHANNEL,
+		PERMISSION_DELETE_BOOKMARK_PUBLIC_CHANNEL,
+		PERMISSION_ORDER_BOOKMARK_PUBLIC_CHANNEL,
+		PERMISSION_ADD_BOOKMARK_PRIVATE_CHANNEL,
+		PERMISSION_EDIT_BOOKMARK_PRIVATE_CHANNEL,
+		PERMISSION_DELETE_BOOKMARK_PRIVATE_CHANNEL,
+		PERMISSION_ORDER_BOOKMARK_PRIVATE_CHANNEL,
 	}
 
 	DeprecatedPermissions = []*Permission{
@@ -0,0 +1,223 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"unicode/utf8"
+)
+
+const (
+	POLL_QUESTION_MAX_RUNES = 512
+	POLL_OPTION_MAX_RUNES   = 128
+	POLL_MIN_OPTIONS        = 2
+	POLL_MAX_OPTIONS        = 20
+
+	POST_PROPS_POLL_ID      = "poll_id"
+	POST_PROPS_POLL_RESULTS = "poll"
+)
+
+// Poll is a question with a fixed set of options that channel members can
+// vote on. Each poll is attached to a single post of type POST_POLL.
+type Poll struct {
+	Id             string      `json:"id"`
+	PostId         string      `json:"post_id"`
+	ChannelId      string      `json:"channel_id"`
+	UserId         string      `json:"user_id"`
+	Question       string      `json:"question"`
+	Options        StringArray `json:"options"`
+	Anonymous      bool        `json:"anonymous"`
+	MultipleChoice bool        `json:"multiple_choice"`
+	CreateAt       int64       `json:"create_at"`
+	UpdateAt       int64       `json:"update_at"`
+	CloseAt        int64       `json:"close_at"`
+	ClosedAt       int64       `json:"closed_at"`
+	DeleteAt       int64       `json:"delete_at"`
+}
+
+// PollVote is a single user's vote for one of the options of a poll. Users
+// may have several votes on a poll that allows multiple choices.
+type PollVote struct {
+	PollId      string `json:"poll_id"`
+	UserId      string `json:"user_id"`
+	OptionIndex int    `json:"option_index"`
+	CreateAt    int64  `json:"create_at"`
+}
+
+// PollVoteRequest is the body of a request to vote on a poll. An empty list
+// of options removes the user's votes.
+type PollVoteRequest struct {
+	Options []int `json:"options"`
+}
+
+// PollResults is the tally of a poll. Voters are only listed for polls that
+// are not anonymous.
+type PollResults struct {
+	PollId      string     `json:"poll_id"`
+	Question    string     `json:"question"`
+	Options     []string   `json:"options"`
+	Counts      []int      `json:"counts"`
+	Voters      [][]string `json:"voters,omitempty"`
+	TotalVoters int        `json:"total_voters"`
+	Anonymous   bool       `json:"anonymous"`
+	CloseAt     int64      `json:"close_at"`
+	ClosedAt    int64      `json:"closed_at"`
+}
+
+func (p *Poll) ToJson() string {
+	b, _ := json.Marshal(p)
+	return string(b)
+}
+
+func PollFromJson(data io.Reader) *Poll {
+	var p *Poll
+	json.NewDecoder(data).Decode(&p)
+	return p
+}
+
+func (r *PollVoteRequest) ToJson() string {
+	b, _ := json.Marshal(r)
+	return string(b)
+}
+
+func PollVoteRequestFromJson(data io.Reader) *PollVoteRequest {
+	var r *PollVoteRequest
+	json.NewDecoder(data).Decode(&r)
+	return r
+}
+
+func (r *PollResults) ToJson() string {
+	b, _ := json.Marshal(r)
+	return string(b)
+}
+
+func PollResultsFromJson(data io.Reader) *PollResults {
+	var r *PollResults
+	json.NewDecoder(data).Decode(&r)
+	return r
+}
+
+func (p *Poll) PreSave() {
+	if p.Id == "" {
+		p.Id = NewId()
+	}
+
+	if p.CreateAt == 0 {
+		p.CreateAt = GetMillis()
+	}
+
+	p.UpdateAt = p.CreateAt
+}
+
+func (p *Poll) PreUpdate() {
+	p.UpdateAt = GetMillis()
+}
+
+func (p *Poll) IsValid() *AppError {
+	if !IsValidId(p.Id) {
+		return NewAppError("Poll.IsValid", "model.poll.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if !IsValidId(p.PostId) {
+		return NewAppError("Poll.IsValid", "model.poll.is_valid.post_id.app_error", nil, "id="+p.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidId(p.ChannelId) {
+		return NewAppError("Poll.IsValid", "model.poll.is_valid.channel_id.app_error", nil, "id="+p.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidId(p.UserId) {
+		return NewAppError("Poll.IsValid", "model.poll.is_valid.user_id.app_error", nil, "id="+p.Id, http.StatusBadRequest)
+	}
+
+	if p.Question == "" || utf8.RuneCountInString(p.Question) > POLL_QUESTION_MAX_RUNES {
+		return NewAppError("Poll.IsValid", "model.poll.is_valid.question.app_error", nil, "id="+p.Id, http.StatusBadRequest)
+	}
+
+	if len(p.Options) < POLL_MIN_OPTIONS || len(p.Options) > POLL_MAX_OPTIONS {
+		return NewAppError("Poll.IsValid", "model.poll.is_valid.options.app_error", nil, "id="+p.Id, http.StatusBadRequest)
+	}
+
+	for _, option := range p.Options {
+		if option == "" || utf8.RuneCountInString(option) > POLL_OPTION_MAX_RUNES {
+			return NewAppError("Poll.IsValid", "model.poll.is_valid.option.app_error", nil, "id="+p.Id, http.StatusBadRequest)
+		}
+	}
+
+	if p.CreateAt == 0 {
+		return NewAppError("Poll.IsValid", "model.poll.is_valid.create_at.app_error", nil, "id="+p.Id, http.StatusBadRequest)
+	}
+
+	if p.UpdateAt == 0 {
+		return NewAppError("Poll.IsValid", "model.poll.is_valid.update_at.app_error", nil, "id="+p.Id, http.StatusBadRequest)
+	}
+
+	if p.CloseAt < 0 || (p.CloseAt > 0 && p.CloseAt <= p.CreateAt) {
+		return NewAppError("Poll.IsValid", "model.poll.is_valid.close_at.app_error", nil, "id="+p.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// IsClosed reports whether the poll no longer accepts votes at the given time.
+func (p *Poll) IsClosed(now int64) bool {
+	return p.ClosedAt != 0 || (p.CloseAt != 0 && p.CloseAt <= now)
+}
+
+// IsValidVote checks the requested option indexes against the poll.
+func (p *Poll) IsValidVote(r *PollVoteRequest) *AppError {
+	if !p.MultipleChoice && len(r.Options) > 1 {
+		return NewAppError("Poll.IsValidVote", "model.poll.is_valid_vote.single_choice.app_error", nil, "id="+p.Id, http.StatusBadRequest)
+	}
+
+	seen := make(map[int]bool, len(r.Options))
+	for _, index := range r.Options {
+		if index < 0 || index >= len(p.Options) || seen[index] {
+			return NewAppError("Poll.IsValidVote", "model.poll.is_valid_vote.option.app_error", nil, "id="+p.Id, http.StatusBadRequest)
+		}
+		seen[index] = true
+	}
+
+	return nil
+}
+
+// Results tallies the given votes for the poll. When includeVoters is false,
+// or the poll is anonymous, the voters for each option are omitted.
+func (p *Poll) Results(votes []*PollVote, includeVoters bool) *PollResults {
+	results := &PollResults{
+		PollId:    p.Id,
+		Question:  p.Question,
+		Options:   p.Options,
+		Counts:    make([]int, len(p.Options)),
+		Anonymous: p.Anonymous,
+		CloseAt:   p.CloseAt,
+		ClosedAt:  p.ClosedAt,
+	}
+
+	includeVoters = includeVoters && !p.Anonymous
+	if includeVoters {
+		results.Voters = make([][]string, len(p.Options))
+		for i := range results.Voters {
+			results.Voters[i] = []string{}
+		}
+	}
+
+	voters := map[string]bool{}
+	for _, vote := range votes {
+		if vote.OptionIndex < 0 || vote.OptionIndex >= len(p.Options) {
+			continue
+		}
+
+		results.Counts[vote.OptionIndex]++
+		voters[vote.UserId] = true
+		if includeVoters {
+			results.Voters[vote.OptionIndex] = append(results.Voters[vote.OptionIndex], vote.UserId)
+		}
+	}
+	results.TotalVoters = len(voters)
+
+	return results
+}
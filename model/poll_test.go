@@ -0,0 +1,126 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPollJson(t *testing.T) {
+	poll := Poll{Id: NewId(), Question: "Lunch?", Options: StringArray{"Pizza", "Sushi"}}
+	rpoll := PollFromJson(strings.NewReader(poll.ToJson()))
+	require.Equal(t, poll.Id, rpoll.Id)
+	require.Equal(t, poll.Options, rpoll.Options)
+
+	vote := PollVoteRequest{Options: []int{0, 1}}
+	rvote := PollVoteRequestFromJson(strings.NewReader(vote.ToJson()))
+	require.Equal(t, vote.Options, rvote.Options)
+}
+
+func TestPollIsValid(t *testing.T) {
+	poll := Poll{}
+	require.NotNil(t, poll.IsValid())
+
+	poll.Id = NewId()
+	require.NotNil(t, poll.IsValid())
+
+	poll.PostId = NewId()
+	require.NotNil(t, poll.IsValid())
+
+	poll.ChannelId = NewId()
+	require.NotNil(t, poll.IsValid())
+
+	poll.UserId = NewId()
+	require.NotNil(t, poll.IsValid())
+
+	poll.Question = strings.Repeat("a", POLL_QUESTION_MAX_RUNES+1)
+	require.NotNil(t, poll.IsValid())
+
+	poll.Question = "Lunch?"
+	require.NotNil(t, poll.IsValid())
+
+	poll.Options = StringArray{"Pizza"}
+	require.NotNil(t, poll.IsValid())
+
+	poll.Options = StringArray{"Pizza", ""}
+	require.NotNil(t, poll.IsValid())
+
+	poll.Options = StringArray{"Pizza", "Sushi"}
+	require.NotNil(t, poll.IsValid())
+
+	poll.PreSave()
+	require.Nil(t, poll.IsValid())
+
+	poll.CloseAt = poll.CreateAt
+	require.NotNil(t, poll.IsValid())
+
+	poll.CloseAt = poll.CreateAt + 1000
+	require.Nil(t, poll.IsValid())
+}
+
+func TestPollIsClosed(t *testing.T) {
+	now := GetMillis()
+
+	poll := Poll{}
+	assert.False(t, poll.IsClosed(now))
+
+	poll.CloseAt = now + 1000
+	assert.False(t, poll.IsClosed(now))
+
+	poll.CloseAt = now
+	assert.True(t, poll.IsClosed(now))
+
+	poll = Poll{ClosedAt: now - 1000}
+	assert.True(t, poll.IsClosed(now))
+}
+
+func TestPollIsValidVote(t *testing.T) {
+	poll := Poll{Options: StringArray{"Pizza", "Sushi", "Tacos"}}
+
+	assert.Nil(t, poll.IsValidVote(&PollVoteRequest{Options: []int{}}))
+	assert.Nil(t, poll.IsValidVote(&PollVoteRequest{Options: []int{2}}))
+	assert.NotNil(t, poll.IsValidVote(&PollVoteRequest{Options: []int{3}}))
+	assert.NotNil(t, poll.IsValidVote(&PollVoteRequest{Options: []int{-1}}))
+	assert.NotNil(t, poll.IsValidVote(&PollVoteRequest{Options: []int{0, 1}}))
+
+	poll.MultipleChoice = true
+	assert.Nil(t, poll.IsValidVote(&PollVoteRequest{Options: []int{0, 1}}))
+	assert.NotNil(t, poll.IsValidVote(&PollVoteRequest{Options: []int{1, 1}}))
+}
+
+func TestPollResults(t *testing.T) {
+	userId1 := NewId()
+	userId2 := NewId()
+	poll := Poll{Id: NewId(), Options: StringArray{"Pizza", "Sushi", "Tacos"}, MultipleChoice: true}
+	votes := []*PollVote{
+		{PollId: poll.Id, UserId: userId1, OptionIndex: 0},
+		{PollId: poll.Id, UserId: userId1, OptionIndex: 2},
+		{PollId: poll.Id, UserId: userId2, OptionIndex: 2},
+	}
+
+	t.Run("with voters", func(t *testing.T) {
+		results := poll.Results(votes, true)
+		assert.Equal(t, []int{1, 0, 2}, results.Counts)
+		assert.Equal(t, 2, results.TotalVoters)
+		assert.Equal(t, [][]string{{userId1}, {}, {userId1, userId2}}, results.Voters)
+	})
+
+	t.Run("without voters", func(t *testing.T) {
+		results := poll.Results(votes, false)
+		assert.Equal(t, []int{1, 0, 2}, results.Counts)
+		assert.Nil(t, results.Voters)
+	})
+
+	t.Run("anonymous", func(t *testing.T) {
+		anonymous := poll
+		anonymous.Anonymous = true
+		results := anonymous.Results(votes, true)
+		assert.Equal(t, []int{1, 0, 2}, results.Counts)
+		assert.Nil(t, results.Voters)
+	})
+}
@@ -55,6 +55,7 @@ const (
 	POST_PROPS_MAX_USER_RUNES   = POST_PROPS_MAX_RUNES - 400 // Leave some room for system / pre-save modifications
 	POST_CUSTOM_TYPE_PREFIX     = "custom_"
 	POST_ME                     = "me"
+	POST_POLL                   = "poll"
 	PROPS_ADD_CHANNEL_MEMBER    = "add_channel_member"
 
 	POST_PROPS_ADDED_USER_ID       = "addedUserId"
@@ -64,6 +65,8 @@ const (
 
 	POST_PROPS_MENTION_HIGHLIGHT_DISABLED = "mentionHighlightDisabled"
 	POST_PROPS_GROUP_HIGHLIGHT_DISABLED   = "disable_group_highlight"
+	POST_PROPS_PRIORITY                   = "priority"
+	POST_PRIORITY_URGENT                  = "urgent"
 	POST_SYSTEM_WARN_METRIC_STATUS        = "warn_metric_status"
 )
 
@@ -327,6 +330,7 @@ func (o *Post) IsValid(maxPostSize int) *AppError {
 		POST_CHANNEL_RESTORED,
 		POST_CHANGE_CHANNEL_PRIVACY,
 		POST_ME,
+		POST_POLL,
 		POST_ADD_BOT_TEAMS_CHANNELS,
 		POST_SYSTEM_WARN_METRIC_STATUS:
 	default:
@@ -0,0 +1,28 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// PostReadReceipt records that a member of a direct or group channel has
+// viewed the channel after a given post was created.
+type PostReadReceipt struct {
+	PostId string `json:"post_id"`
+	UserId string `json:"user_id"`
+	ReadAt int64  `json:"read_at"`
+}
+
+func PostReadReceiptsToJson(receipts []*PostReadReceipt) string {
+	b, _ := json.Marshal(receipts)
+	return string(b)
+}
+
+func PostReadReceiptsFromJson(data io.Reader) []*PostReadReceipt {
+	var o []*PostReadReceipt
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
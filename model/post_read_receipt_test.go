@@ -0,0 +1,27 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostReadReceiptsJson(t *testing.T) {
+	receipts := []*PostReadReceipt{
+		{PostId: NewId(), UserId: NewId(), ReadAt: 12345},
+		{PostId: NewId(), UserId: NewId(), ReadAt: 67890},
+	}
+
+	json := PostReadReceiptsToJson(receipts)
+	rreceipts := PostReadReceiptsFromJson(strings.NewReader(json))
+
+	require.Len(t, rreceipts, 2)
+	assert.Equal(t, receipts[0], rreceipts[0])
+	assert.Equal(t, receipts[1], rreceipts[1])
+	assert.Equal(t, "[]", PostReadReceiptsToJson([]*PostReadReceipt{}))
+}
@@ -29,6 +29,7 @@ const (
 	PREFERENCE_NAME_MESSAGE_DISPLAY           = "message_display"
 	PREFERENCE_NAME_NAME_FORMAT               = "name_format"
 	PREFERENCE_NAME_USE_MILITARY_TIME         = "use_military_time"
+	PREFERENCE_NAME_READ_RECEIPTS             = "read_receipts"
 
 	PREFERENCE_CATEGORY_THEME = "theme"
 	// the name for theme props is the team id
@@ -40,8 +41,10 @@ const (
 	PREFERENCE_NAME_LAST_CHANNEL = "channel"
 	PREFERENCE_NAME_LAST_TEAM    = "team"
 
-	PREFERENCE_CATEGORY_NOTIFICATIONS = "notifications"
-	PREFERENCE_NAME_EMAIL_INTERVAL    = "email_interval"
+	PREFERENCE_CATEGORY_NOTIFICATIONS     = "notifications"
+	PREFERENCE_NAME_EMAIL_INTERVAL        = "email_interval"
+	PREFERENCE_NAME_EMAIL_DIGEST          = "email_digest"
+	PREFERENCE_NAME_NOTIFICATION_ENDPOINT = "notification_endpoint"
 
 	PREFERENCE_EMAIL_INTERVAL_NO_BATCHING_SECONDS = "30"  // the "immediate" setting is actually 30s
 	PREFERENCE_EMAIL_INTERVAL_BATCHING_SECONDS    = "900" // fifteen minutes is 900 seconds
@@ -94,6 +97,26 @@ func (o *Preference) IsValid() *AppError {
 		}
 	}
 
+	if o.Category == PREFERENCE_CATEGORY_NOTIFICATIONS && o.Name == PREFERENCE_NAME_EMAIL_DIGEST {
+		schedule := EmailDigestScheduleFromJson(strings.NewReader(o.Value))
+		if schedule == nil {
+			return NewAppError("Preference.IsValid", "model.preference.is_valid.email_digest.app_error", nil, "value="+o.Value, http.StatusBadRequest)
+		}
+		if err := schedule.IsValid(); err != nil {
+			return err
+		}
+	}
+
+	if o.Category == PREFERENCE_CATEGORY_NOTIFICATIONS && o.Name == PREFERENCE_NAME_NOTIFICATION_ENDPOINT {
+		endpoint := NotificationEndpointFromJson(strings.NewReader(o.Value))
+		if endpoint == nil {
+			return NewAppError("Preference.IsValid", "model.preference.is_valid.notification_endpoint.app_error", nil, "", http.StatusBadRequest)
+		}
+		if err := endpoint.IsValid(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
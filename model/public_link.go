@@ -0,0 +1,87 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+// PublicLink is a link giving access to a file to anyone who has it, until it
+// expires or its creator revokes it.
+type PublicLink struct {
+	Id             string `json:"id"`
+	FileId         string `json:"file_id"`
+	CreatorId      string `json:"creator_id"`
+	CreateAt       int64  `json:"create_at"`
+	ExpireAt       int64  `json:"expire_at"`
+	RevokeAt       int64  `json:"revoke_at"`
+	DownloadCount  int64  `json:"download_count"`
+	LastDownloadAt int64  `json:"last_download_at"`
+	// Link is the URL of the public link, only given to its creator.
+	Link string `json:"link,omitempty" db:"-"`
+}
+
+func (o *PublicLink) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func PublicLinkFromJson(data io.Reader) *PublicLink {
+	var o *PublicLink
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+func PublicLinksToJson(o []*PublicLink) string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func PublicLinksFromJson(data io.Reader) []*PublicLink {
+	var o []*PublicLink
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+func (o *PublicLink) PreSave() {
+	if o.Id == "" {
+		o.Id = NewId()
+	}
+
+	if o.CreateAt == 0 {
+		o.CreateAt = GetMillis()
+	}
+}
+
+func (o *PublicLink) IsValid() *AppError {
+	if !IsValidId(o.Id) {
+		return NewAppError("PublicLink.IsValid", "model.public_link.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.FileId) {
+		return NewAppError("PublicLink.IsValid", "model.public_link.is_valid.file_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.CreatorId) {
+		return NewAppError("PublicLink.IsValid", "model.public_link.is_valid.creator_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.CreateAt == 0 {
+		return NewAppError("PublicLink.IsValid", "model.public_link.is_valid.create_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.ExpireAt != 0 && o.ExpireAt <= o.CreateAt {
+		return NewAppError("PublicLink.IsValid", "model.public_link.is_valid.expire_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// IsActive returns whether the link gives access to its file at the given
+// time.
+func (o *PublicLink) IsActive(now int64) bool {
+	return o.RevokeAt == 0 && (o.ExpireAt == 0 || o.ExpireAt > now)
+}
@@ -0,0 +1,33 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublicLinkIsValid(t *testing.T) {
+	link := &PublicLink{FileId: NewId(), CreatorId: NewId()}
+	link.PreSave()
+	require.Nil(t, link.IsValid())
+
+	link.ExpireAt = link.CreateAt
+	require.NotNil(t, link.IsValid())
+
+	link.ExpireAt = link.CreateAt + 1
+	require.Nil(t, link.IsValid())
+
+	link.CreatorId = ""
+	require.NotNil(t, link.IsValid())
+}
+
+func TestPublicLinkIsActive(t *testing.T) {
+	assert.True(t, (&PublicLink{}).IsActive(1000))
+	assert.True(t, (&PublicLink{ExpireAt: 2000}).IsActive(1000))
+	assert.False(t, (&PublicLink{ExpireAt: 1000}).IsActive(1000))
+	assert.False(t, (&PublicLink{RevokeAt: 500}).IsActive(1000))
+}
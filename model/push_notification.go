@@ -15,6 +15,7 @@ const (
 	PUSH_NOTIFY_ANDROID              = "android"
 	PUSH_NOTIFY_APPLE_REACT_NATIVE   = "apple_rn"
 	PUSH_NOTIFY_ANDROID_REACT_NATIVE = "android_rn"
+	PUSH_NOTIFY_WEBPUSH              = "webpush"
 
 	PUSH_TYPE_MESSAGE      = "message"
 	PUSH_TYPE_CLEAR        = "clear"
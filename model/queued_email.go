@@ -0,0 +1,180 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	QUEUED_EMAIL_STATUS_PENDING = "pending"
+	QUEUED_EMAIL_STATUS_SENT    = "sent"
+	QUEUED_EMAIL_STATUS_DEAD    = "dead"
+
+	QUEUED_EMAIL_ADDRESS_MAX_LENGTH = 1024
+	QUEUED_EMAIL_SUBJECT_MAX_LENGTH = 1024
+	QUEUED_EMAIL_ERROR_MAX_LENGTH   = 1024
+
+	QUEUED_EMAIL_RETRY_BASE_DELAY = 1 * time.Minute
+	QUEUED_EMAIL_RETRY_MAX_DELAY  = 6 * time.Hour
+)
+
+// QueuedEmail is an outbound email persisted before it is sent, so that it
+// can be retried if the SMTP server is unavailable. Emails that keep failing,
+// or that the SMTP server permanently rejects, are dead-lettered.
+type QueuedEmail struct {
+	Id            string `json:"id"`
+	Recipient     string `json:"recipient"`
+	Cc            string `json:"cc"`
+	ReplyTo       string `json:"reply_to"`
+	Subject       string `json:"subject"`
+	HtmlBody      string `json:"html_body"`
+	Status        string `json:"status"`
+	Attempts      int    `json:"attempts"`
+	LastError     string `json:"last_error"`
+	NextAttemptAt int64  `json:"next_attempt_at"`
+	CreateAt      int64  `json:"create_at"`
+	UpdateAt      int64  `json:"update_at"`
+	SentAt        int64  `json:"sent_at"`
+}
+
+func (o *QueuedEmail) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func QueuedEmailFromJson(data io.Reader) *QueuedEmail {
+	var o *QueuedEmail
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+func QueuedEmailListToJson(l []*QueuedEmail) string {
+	b, _ := json.Marshal(l)
+	return string(b)
+}
+
+func QueuedEmailListFromJson(data io.Reader) []*QueuedEmail {
+	var o []*QueuedEmail
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+// Sanitize removes the body of the email, which can contain private content
+// such as message excerpts or password reset links.
+func (o *QueuedEmail) Sanitize() {
+	o.HtmlBody = ""
+}
+
+func IsValidQueuedEmailStatus(status string) bool {
+	switch status {
+	case QUEUED_EMAIL_STATUS_PENDING, QUEUED_EMAIL_STATUS_SENT, QUEUED_EMAIL_STATUS_DEAD:
+		return true
+	}
+	return false
+}
+
+func (o *QueuedEmail) IsValid() *AppError {
+	if !IsValidId(o.Id) {
+		return NewAppError("QueuedEmail.IsValid", "model.queued_email.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if o.Recipient == "" || len(o.Recipient) > QUEUED_EMAIL_ADDRESS_MAX_LENGTH || len(o.Cc) > QUEUED_EMAIL_ADDRESS_MAX_LENGTH || len(o.ReplyTo) > QUEUED_EMAIL_ADDRESS_MAX_LENGTH {
+		return NewAppError("QueuedEmail.IsValid", "model.queued_email.is_valid.recipient.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if len(o.Subject) > QUEUED_EMAIL_SUBJECT_MAX_LENGTH {
+		return NewAppError("QueuedEmail.IsValid", "model.queued_email.is_valid.subject.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidQueuedEmailStatus(o.Status) {
+		return NewAppError("QueuedEmail.IsValid", "model.queued_email.is_valid.status.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.CreateAt == 0 {
+		return NewAppError("QueuedEmail.IsValid", "model.queued_email.is_valid.create_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.UpdateAt == 0 {
+		return NewAppError("QueuedEmail.IsValid", "model.queued_email.is_valid.update_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (o *QueuedEmail) PreSave() {
+	if o.Id == "" {
+		o.Id = NewId()
+	}
+
+	if o.Status == "" {
+		o.Status = QUEUED_EMAIL_STATUS_PENDING
+	}
+
+	if o.CreateAt == 0 {
+		o.CreateAt = GetMillis()
+	}
+	o.UpdateAt = o.CreateAt
+
+	if o.NextAttemptAt == 0 {
+		o.NextAttemptAt = o.CreateAt
+	}
+}
+
+func (o *QueuedEmail) PreUpdate() {
+	o.UpdateAt = GetMillis()
+}
+
+// MarkSent records a successful delivery attempt.
+func (o *QueuedEmail) MarkSent() {
+	o.Attempts++
+	o.Status = QUEUED_EMAIL_STATUS_SENT
+	o.SentAt = GetMillis()
+	o.NextAttemptAt = 0
+}
+
+// MarkFailed records a failed delivery attempt. The email is dead-lettered if
+// the failure is permanent or it has reached maxAttempts, otherwise its next
+// attempt is scheduled with an exponential backoff.
+func (o *QueuedEmail) MarkFailed(errorMessage string, permanent bool, maxAttempts int) {
+	o.Attempts++
+	if len(errorMessage) > QUEUED_EMAIL_ERROR_MAX_LENGTH {
+		errorMessage = errorMessage[:QUEUED_EMAIL_ERROR_MAX_LENGTH]
+	}
+	o.LastError = errorMessage
+
+	if permanent || o.Attempts >= maxAttempts {
+		o.Status = QUEUED_EMAIL_STATUS_DEAD
+		o.NextAttemptAt = 0
+		return
+	}
+
+	o.Status = QUEUED_EMAIL_STATUS_PENDING
+	o.NextAttemptAt = GetMillis() + int64(QueuedEmailRetryDelay(o.Attempts)/time.Millisecond)
+}
+
+// Requeue makes a dead-lettered or already sent email eligible to be sent
+// again right away, with a fresh attempt budget.
+func (o *QueuedEmail) Requeue() {
+	o.Status = QUEUED_EMAIL_STATUS_PENDING
+	o.Attempts = 0
+	o.NextAttemptAt = GetMillis()
+	o.SentAt = 0
+}
+
+// QueuedEmailRetryDelay returns how long to wait before retrying an email
+// that has failed the given number of attempts.
+func QueuedEmailRetryDelay(attempts int) time.Duration {
+	delay := QUEUED_EMAIL_RETRY_BASE_DELAY
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= QUEUED_EMAIL_RETRY_MAX_DELAY {
+			return QUEUED_EMAIL_RETRY_MAX_DELAY
+		}
+	}
+	return delay
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueuedEmailJson(t *testing.T) {
+	email := QueuedEmail{Id: NewId(), Recipient: "test@example.com", Subject: "Subject", HtmlBody: "<p>Body</p>", Status: QUEUED_EMAIL_STATUS_PENDING}
+	remail := QueuedEmailFromJson(strings.NewReader(email.ToJson()))
+	require.Equal(t, email, *remail)
+
+	emails := QueuedEmailListFromJson(strings.NewReader(QueuedEmailListToJson([]*QueuedEmail{&email})))
+	require.Len(t, emails, 1)
+	require.Equal(t, email, *emails[0])
+}
+
+func TestQueuedEmailIsValid(t *testing.T) {
+	email := QueuedEmail{}
+	require.NotNil(t, email.IsValid())
+
+	email.PreSave()
+	require.NotNil(t, email.IsValid(), "email without recipient should be invalid")
+
+	email.Recipient = "test@example.com"
+	require.Nil(t, email.IsValid())
+	require.Equal(t, QUEUED_EMAIL_STATUS_PENDING, email.Status)
+	require.Equal(t, email.CreateAt, email.NextAttemptAt)
+
+	email.Subject = strings.Repeat("a", QUEUED_EMAIL_SUBJECT_MAX_LENGTH+1)
+	require.NotNil(t, email.IsValid())
+	email.Subject = "Subject"
+
+	email.Status = "unknown"
+	require.NotNil(t, email.IsValid())
+}
+
+func TestQueuedEmailMarkFailed(t *testing.T) {
+	email := QueuedEmail{Recipient: "test@example.com"}
+	email.PreSave()
+
+	email.MarkFailed("connection refused", false, 3)
+	require.Equal(t, QUEUED_EMAIL_STATUS_PENDING, email.Status)
+	require.Equal(t, 1, email.Attempts)
+	require.Equal(t, "connection refused", email.LastError)
+	require.True(t, email.NextAttemptAt > GetMillis())
+
+	email.MarkFailed("connection refused", false, 3)
+	require.Equal(t, QUEUED_EMAIL_STATUS_PENDING, email.Status)
+
+	email.MarkFailed("connection refused", false, 3)
+	require.Equal(t, QUEUED_EMAIL_STATUS_DEAD, email.Status, "email should be dead-lettered after the last attempt")
+	require.Equal(t, int64(0), email.NextAttemptAt)
+
+	email.Requeue()
+	require.Equal(t, QUEUED_EMAIL_STATUS_PENDING, email.Status)
+	require.Equal(t, 0, email.Attempts)
+
+	email.MarkFailed(strings.Repeat("a", QUEUED_EMAIL_ERROR_MAX_LENGTH+1), true, 3)
+	require.Equal(t, QUEUED_EMAIL_STATUS_DEAD, email.Status, "permanent failures should be dead-lettered right away")
+	require.Len(t, email.LastError, QUEUED_EMAIL_ERROR_MAX_LENGTH)
+
+	email.Requeue()
+	email.MarkSent()
+	require.Equal(t, QUEUED_EMAIL_STATUS_SENT, email.Status)
+	require.NotZero(t, email.SentAt)
+}
+
+func TestQueuedEmailRetryDelay(t *testing.T) {
+	require.Equal(t, QUEUED_EMAIL_RETRY_BASE_DELAY, QueuedEmailRetryDelay(1))
+	require.Equal(t, 2*QUEUED_EMAIL_RETRY_BASE_DELAY, QueuedEmailRetryDelay(2))
+	require.Equal(t, 8*QUEUED_EMAIL_RETRY_BASE_DELAY, QueuedEmailRetryDelay(4))
+	require.Equal(t, QUEUED_EMAIL_RETRY_MAX_DELAY, QueuedEmailRetryDelay(100))
+	require.True(t, QueuedEmailRetryDelay(9) <= QUEUED_EMAIL_RETRY_MAX_DELAY)
+	require.Equal(t, time.Minute, QueuedEmailRetryDelay(0))
+}
@@ -565,6 +565,14 @@ func MakeDefaultRoles() map[string]*Role {
 			PERMISSION_CREATE_POST.Id,
 			PERMISSION_USE_CHANNEL_MENTIONS.Id,
 			PERMISSION_USE_SLASH_COMMANDS.Id,
+			PERMISSION_ADD_BOOKMARK_PUBLIC_CHANNEL.Id,
+			PERMISSION_EDIT_BOOKMARK_PUBLIC_CHANNEL.Id,
+			PERMISSION_DELETE_BOOKMARK_PUBLIC_CHANNEL.Id,
+			PERMISSION_ORDER_BOOKMARK_PUBLIC_CHANNEL.Id,
+			PERMISSION_ADD_BOOKMARK_PRIVATE_CHANNEL.Id,
+			PERMISSION_EDIT_BOOKMARK_PRIVATE_CHANNEL.Id,
+			PERMISSION_DELETE_BOOKMARK_PRIVATE_CHANNEL.Id,
+			PERMISSION_ORDER_BOOKMARK_PRIVATE_CHANNEL.Id,
 		},
 		SchemeManaged: true,
 		BuiltIn:       true,
@@ -0,0 +1,112 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	SAVED_SEARCH_NAME_MAX_RUNES  = 64
+	SAVED_SEARCH_TERMS_MAX_RUNES = 1024
+)
+
+// SavedSearch is a search that a user runs again and again. Besides its
+// terms, it keeps the options of the search that aren't part of the terms,
+// and it can notify the user about new posts that match it.
+type SavedSearch struct {
+	Id                     string `json:"id"`
+	UserId                 string `json:"user_id"`
+	TeamId                 string `json:"team_id"`
+	Name                   string `json:"name"`
+	Terms                  string `json:"terms"`
+	IsOrSearch             bool   `json:"is_or_search"`
+	TimeZoneOffset         int    `json:"time_zone_offset"`
+	IncludeDeletedChannels bool   `json:"include_deleted_channels"`
+	NotifyOnMatch          bool   `json:"notify_on_match"`
+	// LastCheckedAt is the time up to which new posts have been checked
+	// against the search in order to notify the user.
+	LastCheckedAt int64 `json:"last_checked_at"`
+	CreateAt      int64 `json:"create_at"`
+	UpdateAt      int64 `json:"update_at"`
+}
+
+func (o *SavedSearch) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func SavedSearchFromJson(data io.Reader) *SavedSearch {
+	var o *SavedSearch
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+func SavedSearchListToJson(l []*SavedSearch) string {
+	b, _ := json.Marshal(l)
+	return string(b)
+}
+
+func SavedSearchListFromJson(data io.Reader) []*SavedSearch {
+	var l []*SavedSearch
+	json.NewDecoder(data).Decode(&l)
+	return l
+}
+
+func (o *SavedSearch) IsValid() *AppError {
+	if !IsValidId(o.Id) {
+		return NewAppError("SavedSearch.IsValid", "model.saved_search.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.UserId) {
+		return NewAppError("SavedSearch.IsValid", "model.saved_search.is_valid.user_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.TeamId) {
+		return NewAppError("SavedSearch.IsValid", "model.saved_search.is_valid.team_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.Name == "" || utf8.RuneCountInString(o.Name) > SAVED_SEARCH_NAME_MAX_RUNES {
+		return NewAppError("SavedSearch.IsValid", "model.saved_search.is_valid.name.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.Terms == "" || utf8.RuneCountInString(o.Terms) > SAVED_SEARCH_TERMS_MAX_RUNES {
+		return NewAppError("SavedSearch.IsValid", "model.saved_search.is_valid.terms.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.CreateAt == 0 {
+		return NewAppError("SavedSearch.IsValid", "model.saved_search.is_valid.create_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.UpdateAt == 0 {
+		return NewAppError("SavedSearch.IsValid", "model.saved_search.is_valid.update_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (o *SavedSearch) PreSave() {
+	if o.Id == "" {
+		o.Id = NewId()
+	}
+
+	o.Name = strings.TrimSpace(o.Name)
+	o.Terms = strings.TrimSpace(o.Terms)
+	o.CreateAt = GetMillis()
+	o.UpdateAt = o.CreateAt
+
+	if o.LastCheckedAt == 0 {
+		o.LastCheckedAt = o.CreateAt
+	}
+}
+
+func (o *SavedSearch) PreUpdate() {
+	o.Name = strings.TrimSpace(o.Name)
+	o.Terms = strings.TrimSpace(o.Terms)
+	o.UpdateAt = GetMillis()
+}
@@ -0,0 +1,64 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSavedSearchJson(t *testing.T) {
+	search := SavedSearch{Id: NewId(), UserId: NewId(), TeamId: NewId(), Name: "Incidents", Terms: "from:alertbot in:prod-incidents error", IsOrSearch: true, TimeZoneOffset: 3600, NotifyOnMatch: true}
+	assert.Equal(t, &search, SavedSearchFromJson(strings.NewReader(search.ToJson())))
+
+	list := []*SavedSearch{&search}
+	assert.Equal(t, list, SavedSearchListFromJson(strings.NewReader(SavedSearchListToJson(list))))
+}
+
+func TestSavedSearchIsValid(t *testing.T) {
+	newSearch := func(name, terms string) SavedSearch {
+		search := SavedSearch{UserId: NewId(), TeamId: NewId(), Name: name, Terms: terms}
+		search.PreSave()
+		return search
+	}
+
+	for name, tc := range map[string]struct {
+		Search SavedSearch
+		Valid  bool
+	}{
+		"valid":       {newSearch("Incidents", "from:alertbot error"), true},
+		"empty name":  {newSearch(" ", "from:alertbot error"), false},
+		"long name":   {newSearch(strings.Repeat("a", SAVED_SEARCH_NAME_MAX_RUNES+1), "error"), false},
+		"empty terms": {newSearch("Incidents", " "), false},
+		"long terms":  {newSearch("Incidents", strings.Repeat("a", SAVED_SEARCH_TERMS_MAX_RUNES+1)), false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if tc.Valid {
+				assert.Nil(t, tc.Search.IsValid())
+			} else {
+				assert.NotNil(t, tc.Search.IsValid())
+			}
+		})
+	}
+
+	t.Run("invalid user", func(t *testing.T) {
+		search := newSearch("Incidents", "error")
+		search.UserId = "junk"
+		assert.NotNil(t, search.IsValid())
+	})
+}
+
+func TestSavedSearchPreSave(t *testing.T) {
+	search := SavedSearch{Name: " Incidents ", Terms: " error "}
+	search.PreSave()
+
+	assert.NotEmpty(t, search.Id)
+	assert.Equal(t, "Incidents", search.Name)
+	assert.Equal(t, "error", search.Terms)
+	assert.NotZero(t, search.CreateAt)
+	assert.Equal(t, search.CreateAt, search.UpdateAt)
+	assert.Equal(t, search.CreateAt, search.LastCheckedAt)
+}
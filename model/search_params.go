@@ -13,20 +13,61 @@ import (
 var searchTermPuncStart = regexp.MustCompile(`^[^\pL\d\s#"]+`)
 var searchTermPuncEnd = regexp.MustCompile(`[^\pL\d\s*"]+$`)
 
+const (
+	SEARCH_HAS_FILE     = "file"
+	SEARCH_HAS_LINK     = "link"
+	SEARCH_HAS_REACTION = "reaction"
+
+	SEARCH_IS_PINNED  = "pinned"
+	SEARCH_IS_FLAGGED = "flagged"
+	SEARCH_IS_THREAD  = "thread"
+)
+
+var searchHasValues = map[string]bool{SEARCH_HAS_FILE: true, SEARCH_HAS_LINK: true, SEARCH_HAS_REACTION: true}
+var searchIsValues = map[string]bool{SEARCH_IS_PINNED: true, SEARCH_IS_FLAGGED: true, SEARCH_IS_THREAD: true}
+
+// The layouts of the dates of the date flags, which can be followed by a time
+// to search with an hour, a minute or a second precision.
+var searchDateTimeLayouts = []struct {
+	layout    string
+	precision time.Duration
+}{
+	{"2006-01-02T15:04:05", time.Second},
+	{"2006-01-02T15:04", time.Minute},
+	{"2006-01-02T15", time.Hour},
+}
+
 type SearchParams struct {
-	Terms                  string
-	ExcludedTerms          string
-	IsHashtag              bool
-	InChannels             []string
-	ExcludedChannels       []string
-	FromUsers              []string
-	ExcludedUsers          []string
-	AfterDate              string
-	ExcludedAfterDate      string
-	BeforeDate             string
-	ExcludedBeforeDate     string
-	OnDate                 string
-	ExcludedDate           string
+	Terms                string
+	ExcludedTerms        string
+	IsHashtag            bool
+	InChannels           []string
+	ExcludedChannels     []string
+	FromUsers            []string
+	ExcludedUsers        []string
+	Extensions           []string
+	ExcludedExtensions   []string
+	AfterDate            string
+	ExcludedAfterDate    string
+	BeforeDate           string
+	ExcludedBeforeDate   string
+	OnDate               string
+	ExcludedDate         string
+	HasFilters           []string
+	ExcludedHasFilters   []string
+	IsFilters            []string
+	ExcludedIsFilters    []string
+	ReactedUsers         []string
+	ExcludedReactedUsers []string
+	// PostIds and ThreadIds restrict the search to the posts, or to the posts
+	// of the threads, that the filters depending on the searching user
+	// resolve to: is:flagged and reacted: for the posts, is:thread for the
+	// threads. They are set by the search layer for the search engines that
+	// only index the posts themselves, rather than by the parser.
+	PostIds                []string
+	ExcludedPostIds        []string
+	ThreadIds              []string
+	ExcludedThreadIds      []string
 	OrTerms                bool
 	IncludeDeletedChannels bool
 	TimeZoneOffset         int
@@ -34,79 +75,97 @@ type SearchParams struct {
 	SearchWithoutUserId bool
 }
 
-// Returns the epoch timestamp of the start of the day specified by SearchParams.AfterDate
+// getSearchPeriodMillis returns the epoch timestamps of the start and the end
+// of the period named by the value of a date flag: the day of a date, or the
+// hour, minute or second of a date followed by a time, such as
+// "2021-03-04T09:30".
+func getSearchPeriodMillis(value string, timeZoneOffset int) (int64, int64, error) {
+	parts := strings.SplitN(value, "T", 2)
+	date := PadDateStringZeros(parts[0])
+
+	if len(parts) == 1 {
+		day, err := time.Parse("2006-01-02", date)
+		if err != nil {
+			return 0, 0, err
+		}
+		return GetStartOfDayMillis(day, timeZoneOffset), GetEndOfDayMillis(day, timeZoneOffset), nil
+	}
+
+	location := time.FixedZone("Local Search Time Zone", timeZoneOffset)
+	var err error
+	for _, dateTime := range searchDateTimeLayouts {
+		var start time.Time
+		if start, err = time.ParseInLocation(dateTime.layout, date+"T"+parts[1], location); err == nil {
+			return GetMillisForTime(start), GetMillisForTime(start.Add(dateTime.precision)) - 1, nil
+		}
+	}
+
+	return 0, 0, err
+}
+
+// Returns the epoch timestamp of the end of the period specified by SearchParams.AfterDate
 func (p *SearchParams) GetAfterDateMillis() int64 {
-	date, err := time.Parse("2006-01-02", PadDateStringZeros(p.AfterDate))
+	_, end, err := getSearchPeriodMillis(p.AfterDate, p.TimeZoneOffset)
 	if err != nil {
-		date = time.Now()
+		// fall back to the start of tomorrow
+		return GetStartOfDayMillis(time.Now().Add(time.Hour*24), p.TimeZoneOffset)
 	}
 
-	// travel forward 1 day
-	oneDay := time.Hour * 24
-	afterDate := date.Add(oneDay)
-	return GetStartOfDayMillis(afterDate, p.TimeZoneOffset)
+	return end + 1
 }
 
-// Returns the epoch timestamp of the start of the day specified by SearchParams.ExcludedAfterDate
+// Returns the epoch timestamp of the end of the period specified by SearchParams.ExcludedAfterDate
 func (p *SearchParams) GetExcludedAfterDateMillis() int64 {
-	date, err := time.Parse("2006-01-02", PadDateStringZeros(p.ExcludedAfterDate))
+	_, end, err := getSearchPeriodMillis(p.ExcludedAfterDate, p.TimeZoneOffset)
 	if err != nil {
-		date = time.Now()
+		// fall back to the start of tomorrow
+		return GetStartOfDayMillis(time.Now().Add(time.Hour*24), p.TimeZoneOffset)
 	}
 
-	// travel forward 1 day
-	oneDay := time.Hour * 24
-	afterDate := date.Add(oneDay)
-	return GetStartOfDayMillis(afterDate, p.TimeZoneOffset)
+	return end + 1
 }
 
-// Returns the epoch timestamp of the end of the day specified by SearchParams.BeforeDate
+// Returns the epoch timestamp right before the start of the period specified by SearchParams.BeforeDate
 func (p *SearchParams) GetBeforeDateMillis() int64 {
-	date, err := time.Parse("2006-01-02", PadDateStringZeros(p.BeforeDate))
+	start, _, err := getSearchPeriodMillis(p.BeforeDate, p.TimeZoneOffset)
 	if err != nil {
 		return 0
 	}
 
-	// travel back 1 day
-	oneDay := time.Hour * -24
-	beforeDate := date.Add(oneDay)
-	return GetEndOfDayMillis(beforeDate, p.TimeZoneOffset)
+	return start - 1
 }
 
-// Returns the epoch timestamp of the end of the day specified by SearchParams.ExcludedBeforeDate
+// Returns the epoch timestamp right before the start of the period specified by SearchParams.ExcludedBeforeDate
 func (p *SearchParams) GetExcludedBeforeDateMillis() int64 {
-	date, err := time.Parse("2006-01-02", PadDateStringZeros(p.ExcludedBeforeDate))
+	start, _, err := getSearchPeriodMillis(p.ExcludedBeforeDate, p.TimeZoneOffset)
 	if err != nil {
 		return 0
 	}
 
-	// travel back 1 day
-	oneDay := time.Hour * -24
-	beforeDate := date.Add(oneDay)
-	return GetEndOfDayMillis(beforeDate, p.TimeZoneOffset)
+	return start - 1
 }
 
-// Returns the epoch timestamps of the start and end of the day specified by SearchParams.OnDate
+// Returns the epoch timestamps of the start and end of the period specified by SearchParams.OnDate
 func (p *SearchParams) GetOnDateMillis() (int64, int64) {
-	date, err := time.Parse("2006-01-02", PadDateStringZeros(p.OnDate))
+	start, end, err := getSearchPeriodMillis(p.OnDate, p.TimeZoneOffset)
 	if err != nil {
 		return 0, 0
 	}
 
-	return GetStartOfDayMillis(date, p.TimeZoneOffset), GetEndOfDayMillis(date, p.TimeZoneOffset)
+	return start, end
 }
 
-// Returns the epoch timestamps of the start and end of the day specified by SearchParams.ExcludedDate
+// Returns the epoch timestamps of the start and end of the period specified by SearchParams.ExcludedDate
 func (p *SearchParams) GetExcludedDateMillis() (int64, int64) {
-	date, err := time.Parse("2006-01-02", PadDateStringZeros(p.ExcludedDate))
+	start, end, err := getSearchPeriodMillis(p.ExcludedDate, p.TimeZoneOffset)
 	if err != nil {
 		return 0, 0
 	}
 
-	return GetStartOfDayMillis(date, p.TimeZoneOffset), GetEndOfDayMillis(date, p.TimeZoneOffset)
+	return start, end
 }
 
-var searchFlags = [...]string{"from", "channel", "in", "before", "after", "on"}
+var searchFlags = [...]string{"from", "channel", "in", "before", "after", "on", "ext", "has", "is", "reacted"}
 
 type flag struct {
 	name    string
@@ -259,12 +318,20 @@ func ParseSearchParams(text string, timeZoneOffset int) []*SearchParams {
 	excludedChannels := []string{}
 	fromUsers := []string{}
 	excludedUsers := []string{}
+	var extensions []string
+	var excludedExtensions []string
 	afterDate := ""
 	excludedAfterDate := ""
 	beforeDate := ""
 	excludedBeforeDate := ""
 	onDate := ""
 	excludedDate := ""
+	var hasFilters []string
+	var excludedHasFilters []string
+	var isFilters []string
+	var excludedIsFilters []string
+	var reactedUsers []string
+	var excludedReactedUsers []string
 
 	for _, flag := range flags {
 		if flag.name == "in" || flag.name == "channel" {
@@ -279,6 +346,14 @@ func ParseSearchParams(text string, timeZoneOffset int) []*SearchParams {
 			} else {
 				fromUsers = append(fromUsers, flag.value)
 			}
+		} else if flag.name == "ext" {
+			// extensions are stored lowercase and without the leading dot
+			extension := strings.ToLower(strings.TrimPrefix(flag.value, "."))
+			if flag.exclude {
+				excludedExtensions = append(excludedExtensions, extension)
+			} else {
+				extensions = append(extensions, extension)
+			}
 		} else if flag.name == "after" {
 			if flag.exclude {
 				excludedAfterDate = flag.value
@@ -297,6 +372,34 @@ func ParseSearchParams(text string, timeZoneOffset int) []*SearchParams {
 			} else {
 				onDate = flag.value
 			}
+		} else if flag.name == "has" {
+			// unknown values are ignored rather than matching nothing
+			value := strings.ToLower(flag.value)
+			if !searchHasValues[value] {
+				continue
+			}
+			if flag.exclude {
+				excludedHasFilters = append(excludedHasFilters, value)
+			} else {
+				hasFilters = append(hasFilters, value)
+			}
+		} else if flag.name == "is" {
+			value := strings.ToLower(flag.value)
+			if !searchIsValues[value] {
+				continue
+			}
+			if flag.exclude {
+				excludedIsFilters = append(excludedIsFilters, value)
+			} else {
+				isFilters = append(isFilters, value)
+			}
+		} else if flag.name == "reacted" {
+			username := strings.TrimPrefix(flag.value, "@")
+			if flag.exclude {
+				excludedReactedUsers = append(excludedReactedUsers, username)
+			} else {
+				reactedUsers = append(reactedUsers, username)
+			}
 		}
 	}
 
@@ -304,39 +407,55 @@ func ParseSearchParams(text string, timeZoneOffset int) []*SearchParams {
 
 	if len(plainTerms) > 0 || len(excludedPlainTerms) > 0 {
 		paramsList = append(paramsList, &SearchParams{
-			Terms:              plainTerms,
-			ExcludedTerms:      excludedPlainTerms,
-			IsHashtag:          false,
-			InChannels:         inChannels,
-			ExcludedChannels:   excludedChannels,
-			FromUsers:          fromUsers,
-			ExcludedUsers:      excludedUsers,
-			AfterDate:          afterDate,
-			ExcludedAfterDate:  excludedAfterDate,
-			BeforeDate:         beforeDate,
-			ExcludedBeforeDate: excludedBeforeDate,
-			OnDate:             onDate,
-			ExcludedDate:       excludedDate,
-			TimeZoneOffset:     timeZoneOffset,
+			Terms:                plainTerms,
+			ExcludedTerms:        excludedPlainTerms,
+			IsHashtag:            false,
+			InChannels:           inChannels,
+			ExcludedChannels:     excludedChannels,
+			FromUsers:            fromUsers,
+			ExcludedUsers:        excludedUsers,
+			Extensions:           extensions,
+			ExcludedExtensions:   excludedExtensions,
+			AfterDate:            afterDate,
+			ExcludedAfterDate:    excludedAfterDate,
+			BeforeDate:           beforeDate,
+			ExcludedBeforeDate:   excludedBeforeDate,
+			OnDate:               onDate,
+			ExcludedDate:         excludedDate,
+			HasFilters:           hasFilters,
+			ExcludedHasFilters:   excludedHasFilters,
+			IsFilters:            isFilters,
+			ExcludedIsFilters:    excludedIsFilters,
+			ReactedUsers:         reactedUsers,
+			ExcludedReactedUsers: excludedReactedUsers,
+			TimeZoneOffset:       timeZoneOffset,
 		})
 	}
 
 	if len(hashtagTerms) > 0 || len(excludedHashtagTerms) > 0 {
 		paramsList = append(paramsList, &SearchParams{
-			Terms:              hashtagTerms,
-			ExcludedTerms:      excludedHashtagTerms,
-			IsHashtag:          true,
-			InChannels:         inChannels,
-			ExcludedChannels:   excludedChannels,
-			FromUsers:          fromUsers,
-			ExcludedUsers:      excludedUsers,
-			AfterDate:          afterDate,
-			ExcludedAfterDate:  excludedAfterDate,
-			BeforeDate:         beforeDate,
-			ExcludedBeforeDate: excludedBeforeDate,
-			OnDate:             onDate,
-			ExcludedDate:       excludedDate,
-			TimeZoneOffset:     timeZoneOffset,
+			Terms:                hashtagTerms,
+			ExcludedTerms:        excludedHashtagTerms,
+			IsHashtag:            true,
+			InChannels:           inChannels,
+			ExcludedChannels:     excludedChannels,
+			FromUsers:            fromUsers,
+			ExcludedUsers:        excludedUsers,
+			Extensions:           extensions,
+			ExcludedExtensions:   excludedExtensions,
+			AfterDate:            afterDate,
+			ExcludedAfterDate:    excludedAfterDate,
+			BeforeDate:           beforeDate,
+			ExcludedBeforeDate:   excludedBeforeDate,
+			OnDate:               onDate,
+			ExcludedDate:         excludedDate,
+			HasFilters:           hasFilters,
+			ExcludedHasFilters:   excludedHasFilters,
+			IsFilters:            isFilters,
+			ExcludedIsFilters:    excludedIsFilters,
+			ReactedUsers:         reactedUsers,
+			ExcludedReactedUsers: excludedReactedUsers,
+			TimeZoneOffset:       timeZoneOffset,
 		})
 	}
 
@@ -345,24 +464,36 @@ func ParseSearchParams(text string, timeZoneOffset int) []*SearchParams {
 		len(excludedPlainTerms) == 0 && len(excludedHashtagTerms) == 0 &&
 		(len(inChannels) != 0 || len(fromUsers) != 0 ||
 			len(excludedChannels) != 0 || len(excludedUsers) != 0 ||
+			len(extensions) != 0 || len(excludedExtensions) != 0 ||
 			len(afterDate) != 0 || len(excludedAfterDate) != 0 ||
 			len(beforeDate) != 0 || len(excludedBeforeDate) != 0 ||
-			len(onDate) != 0 || len(excludedDate) != 0) {
+			len(onDate) != 0 || len(excludedDate) != 0 ||
+			len(hasFilters) != 0 || len(excludedHasFilters) != 0 ||
+			len(isFilters) != 0 || len(excludedIsFilters) != 0 ||
+			len(reactedUsers) != 0 || len(excludedReactedUsers) != 0) {
 		paramsList = append(paramsList, &SearchParams{
-			Terms:              "",
-			ExcludedTerms:      "",
-			IsHashtag:          false,
-			InChannels:         inChannels,
-			ExcludedChannels:   excludedChannels,
-			FromUsers:          fromUsers,
-			ExcludedUsers:      excludedUsers,
-			AfterDate:          afterDate,
-			ExcludedAfterDate:  excludedAfterDate,
-			BeforeDate:         beforeDate,
-			ExcludedBeforeDate: excludedBeforeDate,
-			OnDate:             onDate,
-			ExcludedDate:       excludedDate,
-			TimeZoneOffset:     timeZoneOffset,
+			Terms:                "",
+			ExcludedTerms:        "",
+			IsHashtag:            false,
+			InChannels:           inChannels,
+			ExcludedChannels:     excludedChannels,
+			FromUsers:            fromUsers,
+			ExcludedUsers:        excludedUsers,
+			Extensions:           extensions,
+			ExcludedExtensions:   excludedExtensions,
+			AfterDate:            afterDate,
+			ExcludedAfterDate:    excludedAfterDate,
+			BeforeDate:           beforeDate,
+			ExcludedBeforeDate:   excludedBeforeDate,
+			OnDate:               onDate,
+			ExcludedDate:         excludedDate,
+			HasFilters:           hasFilters,
+			ExcludedHasFilters:   excludedHasFilters,
+			IsFilters:            isFilters,
+			ExcludedIsFilters:    excludedIsFilters,
+			ReactedUsers:         reactedUsers,
+			ExcludedReactedUsers: excludedReactedUsers,
+			TimeZoneOffset:       timeZoneOffset,
 		})
 	}
 
@@ -1501,6 +1501,91 @@ func TestParseSearchParams(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name:  "input has an extension, should result in one Extensions without the leading dot",
+			Input: "report ext:.PDF",
+			Output: []*SearchParams{
+				{
+					Terms:            "report",
+					ExcludedTerms:    "",
+					IsHashtag:        false,
+					InChannels:       []string{},
+					ExcludedChannels: []string{},
+					FromUsers:        []string{},
+					ExcludedUsers:    []string{},
+					Extensions:       []string{"pdf"},
+				},
+			},
+		},
+		{
+			Name:  "input has only excluded extensions, should result in one ExcludedExtensions",
+			Input: "-ext:png -ext:jpg",
+			Output: []*SearchParams{
+				{
+					Terms:              "",
+					ExcludedTerms:      "",
+					IsHashtag:          false,
+					InChannels:         []string{},
+					ExcludedChannels:   []string{},
+					FromUsers:          []string{},
+					ExcludedUsers:      []string{},
+					ExcludedExtensions: []string{"png", "jpg"},
+				},
+			},
+		},
+		{
+			Name:  "input has has and is filters, should result in one param with the known ones",
+			Input: "deploy has:FILE -has:reaction is:pinned -is:thread has:unicorns",
+			Output: []*SearchParams{
+				{
+					Terms:              "deploy",
+					ExcludedTerms:      "",
+					IsHashtag:          false,
+					InChannels:         []string{},
+					ExcludedChannels:   []string{},
+					FromUsers:          []string{},
+					ExcludedUsers:      []string{},
+					HasFilters:         []string{SEARCH_HAS_FILE},
+					ExcludedHasFilters: []string{SEARCH_HAS_REACTION},
+					IsFilters:          []string{SEARCH_IS_PINNED},
+					ExcludedIsFilters:  []string{SEARCH_IS_THREAD},
+				},
+			},
+		},
+		{
+			Name:  "input has only reacted filters, should result in one param with the usernames",
+			Input: "reacted:@alice -reacted:bob",
+			Output: []*SearchParams{
+				{
+					Terms:                "",
+					ExcludedTerms:        "",
+					IsHashtag:            false,
+					InChannels:           []string{},
+					ExcludedChannels:     []string{},
+					FromUsers:            []string{},
+					ExcludedUsers:        []string{},
+					ReactedUsers:         []string{"alice"},
+					ExcludedReactedUsers: []string{"bob"},
+				},
+			},
+		},
+		{
+			Name:  "input has only a flagged filter and a date with a time, should result in one param",
+			Input: "is:flagged after:2018-08-01T09:30",
+			Output: []*SearchParams{
+				{
+					Terms:            "",
+					ExcludedTerms:    "",
+					IsHashtag:        false,
+					InChannels:       []string{},
+					ExcludedChannels: []string{},
+					FromUsers:        []string{},
+					ExcludedUsers:    []string{},
+					AfterDate:        "2018-08-01T09:30",
+					IsFilters:        []string{SEARCH_IS_FLAGGED},
+				},
+			},
+		},
 	} {
 		t.Run(testCase.Name, func(t *testing.T) {
 			require.Equal(t, testCase.Output, ParseSearchParams(testCase.Input, 0))
@@ -1527,12 +1612,36 @@ func TestGetOnDateMillis(t *testing.T) {
 			StartOnDate: 1533081600000,
 			EndOnDate:   1533167999999,
 		},
+		{
+			Name:        "Valid date and hour",
+			Input:       "2018-08-01T09",
+			StartOnDate: 1533114000000,
+			EndOnDate:   1533117599999,
+		},
+		{
+			Name:        "Valid date and minute",
+			Input:       "2018-8-1T09:30",
+			StartOnDate: 1533115800000,
+			EndOnDate:   1533115859999,
+		},
+		{
+			Name:        "Valid date and second",
+			Input:       "2018-08-01T09:30:15",
+			StartOnDate: 1533115815000,
+			EndOnDate:   1533115815999,
+		},
 		{
 			Name:        "Invalid date, date not exist",
 			Input:       "2018-02-29",
 			StartOnDate: 0,
 			EndOnDate:   0,
 		},
+		{
+			Name:        "Invalid time",
+			Input:       "2018-08-01T25:00",
+			StartOnDate: 0,
+			EndOnDate:   0,
+		},
 		{
 			Name:        "Invalid date, not date format",
 			Input:       "holiday",
@@ -1565,6 +1674,11 @@ func TestGetBeforeDateMillis(t *testing.T) {
 			Input:      "2018-8-1",
 			BeforeDate: 1533081599999,
 		},
+		{
+			Name:       "Valid date and minute",
+			Input:      "2018-08-01T09:30",
+			BeforeDate: 1533115799999,
+		},
 		{
 			Name:       "Invalid date, date not exist",
 			Input:      "2018-02-29",
@@ -1600,6 +1714,11 @@ func TestGetAfterDateMillis(t *testing.T) {
 			Input:     "2018-8-1",
 			AfterDate: 1533168000000,
 		},
+		{
+			Name:      "Valid date and hour",
+			Input:     "2018-08-01T09",
+			AfterDate: 1533117600000,
+		},
 		{
 			Name:      "Invalid date, date not exist",
 			Input:     "2018-02-29",
@@ -1619,6 +1738,21 @@ func TestGetAfterDateMillis(t *testing.T) {
 	}
 }
 
+func TestGetDateMillisWithTimeZoneOffset(t *testing.T) {
+	sp := &SearchParams{
+		OnDate:         "2018-08-01T09:30",
+		AfterDate:      "2018-08-01T09:30",
+		BeforeDate:     "2018-08-01T09:30",
+		TimeZoneOffset: 2 * 60 * 60,
+	}
+
+	start, end := sp.GetOnDateMillis()
+	assert.Equal(t, int64(1533108600000), start)
+	assert.Equal(t, int64(1533108659999), end)
+	assert.Equal(t, int64(1533108660000), sp.GetAfterDateMillis())
+	assert.Equal(t, int64(1533108599999), sp.GetBeforeDateMillis())
+}
+
 func TestIsSearchParamsListValid(t *testing.T) {
 	var err *AppError
 
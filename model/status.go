@@ -25,11 +25,17 @@ type Status struct {
 	Manual         bool   `json:"manual"`
 	LastActivityAt int64  `json:"last_activity_at"`
 	ActiveChannel  string `json:"active_channel,omitempty" db:"-"`
+	// DNDEndTime is when a timed Do Not Disturb ends and the user goes back
+	// to PrevStatus. It is zero when Do Not Disturb was set until further
+	// notice.
+	DNDEndTime int64  `json:"dnd_end_time"`
+	PrevStatus string `json:"prev_status,omitempty"`
 }
 
 func (o *Status) ToJson() string {
 	oCopy := *o
 	oCopy.ActiveChannel = ""
+	oCopy.PrevStatus = ""
 	b, _ := json.Marshal(oCopy)
 	return string(b)
 }
@@ -51,6 +57,7 @@ func StatusListToJson(u []*Status) string {
 	for i, s := range u {
 		sCopy := *s
 		sCopy.ActiveChannel = ""
+		sCopy.PrevStatus = ""
 		uCopy[i] = sCopy
 	}
 
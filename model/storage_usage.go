@@ -0,0 +1,55 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+)
+
+const (
+	STORAGE_USAGE_TARGET_USER = "user"
+	STORAGE_USAGE_TARGET_TEAM = "team"
+)
+
+// StorageUsage is the storage used by the files, not deleted, that a user
+// uploaded or that were uploaded to the channels of a team.
+type StorageUsage struct {
+	TargetType string `json:"target_type"`
+	TargetId   string `json:"target_id"`
+	UsedBytes  int64  `json:"used_bytes"`
+	FileCount  int64  `json:"file_count"`
+	UpdateAt   int64  `json:"update_at"`
+	// QuotaBytes is the quota that applies to the target, or 0 when its
+	// storage is unlimited.
+	QuotaBytes int64 `json:"quota_bytes" db:"-"`
+}
+
+func (o *StorageUsage) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func StorageUsageFromJson(data io.Reader) *StorageUsage {
+	var o *StorageUsage
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+func StorageUsageListToJson(l []*StorageUsage) string {
+	b, _ := json.Marshal(l)
+	return string(b)
+}
+
+func StorageUsageListFromJson(data io.Reader) []*StorageUsage {
+	var l []*StorageUsage
+	json.NewDecoder(data).Decode(&l)
+	return l
+}
+
+// IsValidStorageUsageTarget returns whether storage usage is tracked for the
+// given type of target.
+func IsValidStorageUsageTarget(targetType string) bool {
+	return targetType == STORAGE_USAGE_TARGET_USER || targetType == STORAGE_USAGE_TARGET_TEAM
+}
@@ -21,6 +21,7 @@ const (
 	SYSTEM_FIRST_SERVER_RUN_TIMESTAMP_KEY         = "FirstServerRunTimestamp"
 	SYSTEM_CLUSTER_ENCRYPTION_KEY                 = "ClusterEncryptionKey"
 	SYSTEM_UPGRADED_FROM_TE_ID                    = "UpgradedFromTE"
+	SYSTEM_FILE_STORE_MIRROR_FAILED_PREFIX        = "FileStoreMirrorFailed_"
 	SYSTEM_WARN_METRIC_NUMBER_OF_TEAMS_5          = "warn_metric_number_of_teams_5"
 	SYSTEM_WARN_METRIC_NUMBER_OF_CHANNELS_50      = "warn_metric_number_of_channels_50"
 	SYSTEM_WARN_METRIC_MFA                        = "warn_metric_mfa"
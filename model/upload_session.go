@@ -4,6 +4,8 @@
 package model
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -14,8 +16,9 @@ import (
 type UploadType string
 
 const (
-	UploadTypeAttachment UploadType = "attachment"
-	UploadTypeImport     UploadType = "import"
+	UploadTypeAttachment   UploadType = "attachment"
+	UploadTypeImport       UploadType = "import"
+	UploadTypePluginBundle UploadType = "plugin_bundle"
 )
 
 // UploadSession contains information used to keep track of a file upload.
@@ -26,6 +29,8 @@ type UploadSession struct {
 	Type UploadType `json:"type"`
 	// The timestamp of creation.
 	CreateAt int64 `json:"create_at"`
+	// The timestamp of the last data received.
+	UpdateAt int64 `json:"update_at"`
 	// The id of the user performing the upload.
 	UserId string `json:"user_id"`
 	// The id of the channel to upload to.
@@ -41,6 +46,13 @@ type UploadSession struct {
 	FileOffset int64 `json:"file_offset"`
 }
 
+// ChunkChecksum returns the checksum of a chunk of upload data, as sent in the
+// X-Chunk-Checksum header: the hex encoded SHA-256 of the chunk.
+func ChunkChecksum(chunk []byte) string {
+	sum := sha256.Sum256(chunk)
+	return hex.EncodeToString(sum[:])
+}
+
 // ToJson serializes the UploadSession into JSON and returns it as string.
 func (us *UploadSession) ToJson() string {
 	b, _ := json.Marshal(us)
@@ -83,6 +95,10 @@ func (us *UploadSession) PreSave() {
 	if us.CreateAt == 0 {
 		us.CreateAt = GetMillis()
 	}
+
+	if us.UpdateAt == 0 {
+		us.UpdateAt = us.CreateAt
+	}
 }
 
 // IsValid validates an UploadType. It returns an error in case of
@@ -93,6 +109,8 @@ func (t UploadType) IsValid() error {
 		return nil
 	case UploadTypeImport:
 		return nil
+	case UploadTypePluginBundle:
+		return nil
 	default:
 	}
 	return fmt.Errorf("invalid UploadType %s", t)
@@ -81,6 +81,14 @@ func TestUploadSessionIsValid(t *testing.T) {
 		require.Nil(t, err)
 	})
 
+	t.Run("plugin bundle without ChannelId should succeed", func(t *testing.T) {
+		us := session
+		us.ChannelId = ""
+		us.Type = UploadTypePluginBundle
+		err := us.IsValid()
+		require.Nil(t, err)
+	})
+
 	t.Run("invalid Filename should fail", func(t *testing.T) {
 		us := session
 		us.Filename = ""
@@ -123,3 +131,20 @@ func TestUploadSessionIsValid(t *testing.T) {
 		require.Equal(t, "model.upload_session.is_valid.file_offset.app_error", err.Id)
 	})
 }
+
+func TestUploadSessionPreSave(t *testing.T) {
+	us := UploadSession{}
+	us.PreSave()
+	require.True(t, IsValidId(us.Id))
+	require.NotZero(t, us.CreateAt)
+	require.Equal(t, us.CreateAt, us.UpdateAt)
+
+	us = UploadSession{CreateAt: 1, UpdateAt: 2}
+	us.PreSave()
+	require.Equal(t, int64(2), us.UpdateAt)
+}
+
+func TestChunkChecksum(t *testing.T) {
+	require.Equal(t, "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", ChunkChecksum(nil))
+	require.Equal(t, "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824", ChunkChecksum([]byte("hello")))
+}
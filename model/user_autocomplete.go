@@ -20,6 +20,8 @@ type UserAutocompleteInTeam struct {
 type UserAutocomplete struct {
 	Users        []*User `json:"users"`
 	OutOfChannel []*User `json:"out_of_channel,omitempty"`
+	// CustomStatuses holds the custom statuses of the users above, by user id.
+	CustomStatuses map[string]*CustomStatus `json:"custom_statuses,omitempty"`
 }
 
 func (o *UserAutocomplete) ToJson() string {
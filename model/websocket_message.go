@@ -16,6 +16,7 @@ const (
 	WEBSOCKET_EVENT_POST_EDITED                              = "post_edited"
 	WEBSOCKET_EVENT_POST_DELETED                             = "post_deleted"
 	WEBSOCKET_EVENT_POST_UNREAD                              = "post_unread"
+	WEBSOCKET_EVENT_POST_READ                                = "post_read"
 	WEBSOCKET_EVENT_CHANNEL_CONVERTED                        = "channel_converted"
 	WEBSOCKET_EVENT_CHANNEL_CREATED                          = "channel_created"
 	WEBSOCKET_EVENT_CHANNEL_DELETED                          = "channel_deleted"
@@ -73,6 +74,13 @@ const (
 	WEBSOCKET_EVENT_THREAD_UPDATED                           = "thread_updated"
 	WEBSOCKET_EVENT_THREAD_FOLLOW_CHANGED                    = "thread_follow_changed"
 	WEBSOCKET_EVENT_THREAD_READ_CHANGED                      = "thread_read_changed"
+	WEBSOCKET_EVENT_DRAFT_CREATED                            = "draft_created"
+	WEBSOCKET_EVENT_DRAFT_DELETED                            = "draft_deleted"
+	WEBSOCKET_EVENT_CHANNEL_BOOKMARK_CREATED                 = "channel_bookmark_created"
+	WEBSOCKET_EVENT_CHANNEL_BOOKMARK_UPDATED                 = "channel_bookmark_updated"
+	WEBSOCKET_EVENT_CHANNEL_BOOKMARK_DELETED                 = "channel_bookmark_deleted"
+	WEBSOCKET_EVENT_CHANNEL_BOOKMARK_SORTED                  = "channel_bookmark_sorted"
+	WEBSOCKET_EVENT_STORAGE_QUOTA_WARNING                    = "storage_quota_warning"
 )
 
 type WebSocketMessage interface {
@@ -0,0 +1,619 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package filesstore
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"golang.org/x/crypto/hkdf"
+
+	"github.com/zacmm/zacmm-server/mlog"
+	"github.com/zacmm/zacmm-server/model"
+)
+
+// Encrypted files are stored as one or more segments. WriteFile writes a
+// file as a single segment and AppendFile adds a segment to its end:
+//
+//	offset  size  field
+//	0       4     magic, "MMFE"
+//	4       1     format version, 1
+//	5       8     id of the key, the first bytes of the SHA-256 of the key
+//	13      4     size of the plaintext chunks, big endian
+//	17      32    random salt of the segment
+//	49            the chunks
+//	              size of the chunks, 8 bytes big endian
+//
+// The chunks of a segment are sealed with AES-256-GCM under a key derived
+// from the encryption key and the salt of the segment with HKDF-SHA256, so
+// that no two segments share a key. Every chunk holds chunk size bytes of
+// plaintext but the last one, which holds the rest, and is followed by its 16
+// bytes GCM tag. The nonce of a chunk is the index of the chunk as a big
+// endian uint32 followed by a byte set to 1 for the last chunk only, so that
+// the chunks of a segment can't be reordered, dropped or truncated. The
+// header is authenticated with every chunk. The size that ends a segment
+// lets readers find the segments from the end of the file.
+const (
+	ENCRYPTED_FILE_MAGIC      = "MMFE"
+	ENCRYPTED_FILE_VERSION    = 1
+	ENCRYPTED_FILE_CHUNK_SIZE = 64 * 1024
+
+	// The largest chunk size accepted when reading a file, so that a corrupted
+	// header can't make the reader allocate an unbounded chunk.
+	encryptedFileMaxChunkSize = 16 * 1024 * 1024
+
+	encryptedFileKeyIdSize   = 8
+	encryptedFileSaltSize    = 32
+	encryptedFileHeaderSize  = len(ENCRYPTED_FILE_MAGIC) + 1 + encryptedFileKeyIdSize + 4 + encryptedFileSaltSize
+	encryptedFileTagSize     = 16
+	encryptedFileTrailerSize = 8
+
+	encryptedFileSegmentKeyInfo = "MMFE segment key"
+
+	reencryptTmpSuffix = ".reencrypt"
+)
+
+type encryptedFileHeader struct {
+	keyId     [encryptedFileKeyIdSize]byte
+	chunkSize uint32
+	salt      [encryptedFileSaltSize]byte
+}
+
+func newEncryptedFileHeader(keyId [encryptedFileKeyIdSize]byte) (*encryptedFileHeader, error) {
+	header := &encryptedFileHeader{
+		keyId:     keyId,
+		chunkSize: ENCRYPTED_FILE_CHUNK_SIZE,
+	}
+	if _, err := rand.Read(header.salt[:]); err != nil {
+		return nil, err
+	}
+	return header, nil
+}
+
+func (h *encryptedFileHeader) bytes() []byte {
+	b := make([]byte, 0, encryptedFileHeaderSize)
+	b = append(b, ENCRYPTED_FILE_MAGIC...)
+	b = append(b, ENCRYPTED_FILE_VERSION)
+	b = append(b, h.keyId[:]...)
+	b = append(b, 0, 0, 0, 0)
+	binary.BigEndian.PutUint32(b[len(b)-4:], h.chunkSize)
+	return append(b, h.salt[:]...)
+}
+
+// parseEncryptedFileHeader returns false when b isn't the header of an
+// encrypted file, such as for the files written before the encryption was
+// enabled.
+func parseEncryptedFileHeader(b []byte) (*encryptedFileHeader, bool) {
+	if len(b) < encryptedFileHeaderSize || string(b[:len(ENCRYPTED_FILE_MAGIC)]) != ENCRYPTED_FILE_MAGIC || b[len(ENCRYPTED_FILE_MAGIC)] != ENCRYPTED_FILE_VERSION {
+		return nil, false
+	}
+
+	h := &encryptedFileHeader{}
+	offset := len(ENCRYPTED_FILE_MAGIC) + 1
+	offset += copy(h.keyId[:], b[offset:])
+	h.chunkSize = binary.BigEndian.Uint32(b[offset:])
+	offset += 4
+	copy(h.salt[:], b[offset:])
+
+	if h.chunkSize == 0 || h.chunkSize > encryptedFileMaxChunkSize {
+		return nil, false
+	}
+
+	return h, true
+}
+
+func (h *encryptedFileHeader) nonce(index uint32, last bool) []byte {
+	nonce := make([]byte, 12)
+	binary.BigEndian.PutUint32(nonce[7:], index)
+	if last {
+		nonce[11] = 1
+	}
+	return nonce
+}
+
+// ParseEncryptionKey decodes a base64 encoded AES-256 key of the file settings.
+func ParseEncryptionKey(key string) ([]byte, error) {
+	decoded, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(decoded) != 32 {
+		return nil, errors.New("encryption keys must be 32 bytes long")
+	}
+	return decoded, nil
+}
+
+// EncryptionKeyId returns the id under which the files encrypted with the key
+// are recorded.
+func EncryptionKeyId(key []byte) string {
+	sum := sha256.Sum256(key)
+	return hex.EncodeToString(sum[:encryptedFileKeyIdSize])
+}
+
+// EncryptedFileBackend encrypts the files written to another backend, and
+// decrypts them when they are read back. Files are encrypted with the current
+// key, and can be decrypted with it or any of the previous keys, so that the
+// key can be rotated while the existing files are re-encrypted. Files that
+// aren't encrypted are read as they are.
+type EncryptedFileBackend struct {
+	FileBackend
+	keyId [encryptedFileKeyIdSize]byte
+	keys  map[[encryptedFileKeyIdSize]byte][]byte
+}
+
+func NewEncryptedFileBackend(backend FileBackend, key string, previousKeys []string) (*EncryptedFileBackend, *model.AppError) {
+	b := &EncryptedFileBackend{
+		FileBackend: backend,
+		keys:        make(map[[encryptedFileKeyIdSize]byte][]byte),
+	}
+
+	for i, encodedKey := range append([]string{key}, previousKeys...) {
+		keyId, err := b.addKey(encodedKey)
+		if err != nil {
+			return nil, model.NewAppError("NewEncryptedFileBackend", "api.file.new_backend.encryption_key.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+		if i == 0 {
+			b.keyId = keyId
+		}
+	}
+
+	return b, nil
+}
+
+func (b *EncryptedFileBackend) addKey(encodedKey string) ([encryptedFileKeyIdSize]byte, error) {
+	var keyId [encryptedFileKeyIdSize]byte
+
+	key, err := ParseEncryptionKey(encodedKey)
+	if err != nil {
+		return keyId, err
+	}
+
+	sum := sha256.Sum256(key)
+	copy(keyId[:], sum[:])
+	b.keys[keyId] = key
+	return keyId, nil
+}
+
+// segmentAEAD returns the cipher that seals the chunks of the segment with
+// the given header.
+func (b *EncryptedFileBackend) segmentAEAD(header *encryptedFileHeader) (cipher.AEAD, error) {
+	key, ok := b.keys[header.keyId]
+	if !ok {
+		return nil, errors.New("the file is encrypted with an unknown key " + hex.EncodeToString(header.keyId[:]))
+	}
+
+	segmentKey := make([]byte, 32)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, key, header.salt[:], []byte(encryptedFileSegmentKeyInfo)), segmentKey); err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(segmentKey)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// KeyId returns the id of the key that the files are encrypted with.
+func (b *EncryptedFileBackend) KeyId() string {
+	return hex.EncodeToString(b.keyId[:])
+}
+
+func (b *EncryptedFileBackend) Reader(path string) (ReadCloseSeeker, *model.AppError) {
+	r, appErr := b.FileBackend.Reader(path)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	dr, err := b.newDecryptingReader(r)
+	if err != nil {
+		r.Close()
+		return nil, model.NewAppError("Reader", "api.file.reader.decrypting.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	return dr, nil
+}
+
+func (b *EncryptedFileBackend) ReadFile(path string) ([]byte, *model.AppError) {
+	r, appErr := b.Reader(path)
+	if appErr != nil {
+		return nil, appErr
+	}
+	defer r.Close()
+
+	f, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, model.NewAppError("ReadFile", "api.file.read_file.decrypting.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	return f, nil
+}
+
+// WriteFile encrypts the file while it is written, and returns the number of
+// bytes of plaintext written.
+func (b *EncryptedFileBackend) WriteFile(fr io.Reader, path string) (int64, *model.AppError) {
+	return b.writeSegment(fr, path, b.FileBackend.WriteFile)
+}
+
+// AppendFile encrypts the content as a new segment at the end of the file.
+// Files that aren't encrypted yet are rewritten instead, so that they don't
+// end up partly encrypted.
+func (b *EncryptedFileBackend) AppendFile(fr io.Reader, path string) (int64, *model.AppError) {
+	r, appErr := b.FileBackend.Reader(path)
+	if appErr != nil {
+		return 0, model.NewAppError("AppendFile", "api.file.append_file.no_exist.app_error", nil, "path="+path, http.StatusInternalServerError)
+	}
+	dr, err := b.newDecryptingReader(r)
+	if err != nil {
+		r.Close()
+		return 0, model.NewAppError("AppendFile", "api.file.append_file.opening.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	defer dr.Close()
+
+	if _, ok := dr.(*decryptingReader); ok {
+		return b.writeSegment(fr, path, b.FileBackend.AppendFile)
+	}
+
+	size, err := dr.Seek(0, io.SeekEnd)
+	if err == nil {
+		_, err = dr.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		return 0, model.NewAppError("AppendFile", "api.file.append_file.opening.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	written, appErr := b.rewriteFile(io.MultiReader(dr, fr), path)
+	if appErr != nil {
+		return 0, appErr
+	}
+	return written - size, nil
+}
+
+// writeSegment encrypts the content as a segment that is handed to write,
+// and returns the number of bytes of plaintext written.
+func (b *EncryptedFileBackend) writeSegment(fr io.Reader, path string, write func(io.Reader, string) (int64, *model.AppError)) (int64, *model.AppError) {
+	header, err := newEncryptedFileHeader(b.keyId)
+	if err != nil {
+		return 0, model.NewAppError("WriteFile", "api.file.write_file.encrypting.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	pr, pw := io.Pipe()
+	written := make(chan int64, 1)
+	go func() {
+		n, err := b.encrypt(pw, fr, header)
+		written <- n
+		pw.CloseWithError(err)
+	}()
+
+	_, appErr := write(pr, path)
+	// Unblock the encryption when the backend stopped reading on an error.
+	pr.Close()
+	n := <-written
+	if appErr != nil {
+		return 0, appErr
+	}
+	return n, nil
+}
+
+// rewriteFile encrypts the content into a temporary file before replacing the
+// file with it, so that the file is left as it was on errors. The temporary
+// file is removed whenever it doesn't replace the file.
+func (b *EncryptedFileBackend) rewriteFile(fr io.Reader, path string) (written int64, appErr *model.AppError) {
+	tmpPath := path + reencryptTmpSuffix
+	defer func() {
+		if appErr == nil {
+			return
+		}
+		if exists, _ := b.FileBackend.FileExists(tmpPath); exists {
+			if removeErr := b.FileBackend.RemoveFile(tmpPath); removeErr != nil {
+				mlog.Warn("Failed to remove temporary file", mlog.String("path", tmpPath), mlog.Err(removeErr))
+			}
+		}
+	}()
+
+	written, appErr = b.WriteFile(fr, tmpPath)
+	if appErr != nil {
+		return 0, appErr
+	}
+
+	if appErr = b.FileBackend.MoveFile(tmpPath, path); appErr != nil {
+		return 0, appErr
+	}
+	return written, nil
+}
+
+// ReencryptFile encrypts the file with the current key when it isn't
+// encrypted, or is partly encrypted with a previous key, and reports whether
+// it did.
+func (b *EncryptedFileBackend) ReencryptFile(path string) (bool, *model.AppError) {
+	// The temporary files of other re-encryptions are left to them.
+	if strings.HasSuffix(path, reencryptTmpSuffix) {
+		return false, nil
+	}
+
+	dr, appErr := b.Reader(path)
+	if appErr != nil {
+		return false, appErr
+	}
+	defer dr.Close()
+
+	if d, ok := dr.(*decryptingReader); ok {
+		current := true
+		for _, segment := range d.segments {
+			current = current && segment.header.keyId == b.keyId
+		}
+		if current {
+			return false, nil
+		}
+	}
+
+	if _, appErr := b.rewriteFile(dr, path); appErr != nil {
+		return false, appErr
+	}
+	return true, nil
+}
+
+func (b *EncryptedFileBackend) encrypt(w io.Writer, r io.Reader, header *encryptedFileHeader) (int64, error) {
+	aead, err := b.segmentAEAD(header)
+	if err != nil {
+		return 0, err
+	}
+
+	headerBytes := header.bytes()
+	if _, err := w.Write(headerBytes); err != nil {
+		return 0, err
+	}
+
+	chunk := make([]byte, header.chunkSize)
+	next := make([]byte, header.chunkSize)
+	sealed := make([]byte, 0, int(header.chunkSize)+encryptedFileTagSize)
+
+	n, err := readChunk(r, chunk)
+	if err != nil {
+		return 0, err
+	}
+
+	var written, sealedSize int64
+	for index := uint32(0); ; index++ {
+		// A chunk is only known to be the last one once the next one is empty.
+		var nextN int
+		if n == len(chunk) {
+			if nextN, err = readChunk(r, next); err != nil {
+				return written, err
+			}
+		}
+		last := nextN == 0
+
+		sealed = aead.Seal(sealed[:0], header.nonce(index, last), chunk[:n], headerBytes)
+		if _, err := w.Write(sealed); err != nil {
+			return written, err
+		}
+		written += int64(n)
+		sealedSize += int64(len(sealed))
+
+		if last {
+			trailer := make([]byte, encryptedFileTrailerSize)
+			binary.BigEndian.PutUint64(trailer, uint64(sealedSize))
+			if _, err := w.Write(trailer); err != nil {
+				return written, err
+			}
+			return written, nil
+		}
+		chunk, next, n = next, chunk, nextN
+	}
+}
+
+// readChunk fills the chunk as much as the reader allows.
+func readChunk(r io.Reader, chunk []byte) (int, error) {
+	n, err := io.ReadFull(r, chunk)
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return n, nil
+	}
+	return n, err
+}
+
+// encryptedSegment locates a segment of an encrypted file.
+type encryptedSegment struct {
+	header      *encryptedFileHeader
+	headerBytes []byte
+	aead        cipher.AEAD
+	// offset is where the chunks of the segment start in the file.
+	offset    int64
+	numChunks int64
+	lastSize  int64
+	// start and size locate the plaintext of the segment in the file.
+	start int64
+	size  int64
+}
+
+type decryptingReader struct {
+	r            ReadCloseSeeker
+	segments     []*encryptedSegment
+	size         int64
+	offset       int64
+	segmentIndex int
+	chunkIndex   int64
+	chunk        []byte
+}
+
+func (b *EncryptedFileBackend) newDecryptingReader(r ReadCloseSeeker) (ReadCloseSeeker, error) {
+	headerBytes, err := readAt(r, 0, encryptedFileHeaderSize)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return nil, err
+	}
+
+	if _, ok := parseEncryptedFileHeader(headerBytes); !ok {
+		if _, err := r.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+		return r, nil
+	}
+
+	end, err := r.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	// The segments are found from the end of the file.
+	var segments []*encryptedSegment
+	for end > 0 {
+		segment, err := b.readSegment(r, end)
+		if err != nil {
+			return nil, err
+		}
+		segments = append([]*encryptedSegment{segment}, segments...)
+		end = segment.offset - int64(encryptedFileHeaderSize)
+	}
+
+	var size int64
+	for _, segment := range segments {
+		segment.start = size
+		size += segment.size
+	}
+
+	return &decryptingReader{
+		r:            r,
+		segments:     segments,
+		size:         size,
+		segmentIndex: -1,
+		chunkIndex:   -1,
+	}, nil
+}
+
+// readSegment reads the segment that ends at the given offset of the file.
+func (b *EncryptedFileBackend) readSegment(r ReadCloseSeeker, end int64) (*encryptedSegment, error) {
+	if end < int64(encryptedFileHeaderSize+encryptedFileTagSize+encryptedFileTrailerSize) {
+		return nil, errors.New("the encrypted file is truncated")
+	}
+
+	trailer, err := readAt(r, end-encryptedFileTrailerSize, encryptedFileTrailerSize)
+	if err != nil {
+		return nil, err
+	}
+	bodySize := int64(binary.BigEndian.Uint64(trailer))
+	offset := end - encryptedFileTrailerSize - bodySize
+	if bodySize < encryptedFileTagSize || offset < int64(encryptedFileHeaderSize) {
+		return nil, errors.New("the encrypted file is truncated")
+	}
+
+	headerBytes, err := readAt(r, offset-int64(encryptedFileHeaderSize), encryptedFileHeaderSize)
+	if err != nil {
+		return nil, err
+	}
+	header, ok := parseEncryptedFileHeader(headerBytes)
+	if !ok {
+		return nil, errors.New("the encrypted file is corrupted")
+	}
+
+	aead, err := b.segmentAEAD(header)
+	if err != nil {
+		return nil, err
+	}
+
+	sealedChunkSize := int64(header.chunkSize) + encryptedFileTagSize
+	numChunks := (bodySize + sealedChunkSize - 1) / sealedChunkSize
+	lastSize := bodySize - (numChunks-1)*sealedChunkSize
+	if lastSize < encryptedFileTagSize {
+		return nil, errors.New("the encrypted file is truncated")
+	}
+
+	return &encryptedSegment{
+		header:      header,
+		headerBytes: headerBytes,
+		aead:        aead,
+		offset:      offset,
+		numChunks:   numChunks,
+		lastSize:    lastSize,
+		size:        bodySize - numChunks*encryptedFileTagSize,
+	}, nil
+}
+
+// readAt reads size bytes at the given offset of the file.
+func readAt(r io.ReadSeeker, offset int64, size int) ([]byte, error) {
+	if _, err := r.Seek(offset, io.SeekStart); err != nil {
+		return nil, err
+	}
+	b := make([]byte, size)
+	n, err := io.ReadFull(r, b)
+	return b[:n], err
+}
+
+func (d *decryptingReader) loadChunk(segmentIndex int, index int64) error {
+	segment := d.segments[segmentIndex]
+	sealedChunkSize := int64(segment.header.chunkSize) + encryptedFileTagSize
+
+	size := sealedChunkSize
+	last := index == segment.numChunks-1
+	if last {
+		size = segment.lastSize
+	}
+	sealed, err := readAt(d.r, segment.offset+index*sealedChunkSize, int(size))
+	if err != nil {
+		return err
+	}
+
+	chunk, err := segment.aead.Open(sealed[:0], segment.header.nonce(uint32(index), last), sealed, segment.headerBytes)
+	if err != nil {
+		return err
+	}
+
+	d.chunk = chunk
+	d.segmentIndex = segmentIndex
+	d.chunkIndex = index
+	return nil
+}
+
+func (d *decryptingReader) Read(p []byte) (int, error) {
+	if d.offset >= d.size {
+		return 0, io.EOF
+	}
+
+	segmentIndex := 0
+	for d.offset >= d.segments[segmentIndex].start+d.segments[segmentIndex].size {
+		segmentIndex++
+	}
+	segment := d.segments[segmentIndex]
+
+	chunkSize := int64(segment.header.chunkSize)
+	index := (d.offset - segment.start) / chunkSize
+	if segmentIndex != d.segmentIndex || index != d.chunkIndex {
+		if err := d.loadChunk(segmentIndex, index); err != nil {
+			return 0, err
+		}
+	}
+
+	n := copy(p, d.chunk[d.offset-segment.start-index*chunkSize:])
+	d.offset += int64(n)
+	return n, nil
+}
+
+func (d *decryptingReader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += d.offset
+	case io.SeekEnd:
+		offset += d.size
+	default:
+		return 0, errors.New("invalid whence")
+	}
+
+	if offset < 0 {
+		return 0, errors.New("negative position")
+	}
+	d.offset = offset
+	return offset, nil
+}
+
+func (d *decryptingReader) Close() error {
+	return d.r.Close()
+}
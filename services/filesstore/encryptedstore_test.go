@@ -0,0 +1,311 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package filesstore
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestEncryptionKey() string {
+	key := make([]byte, 32)
+	rand.Read(key)
+	return base64.StdEncoding.EncodeToString(key)
+}
+
+func newTestEncryptedFileBackend(t *testing.T, key string, previousKeys ...string) (*EncryptedFileBackend, string) {
+	dir, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+
+	backend, appErr := NewEncryptedFileBackend(&LocalFileBackend{directory: dir}, key, previousKeys)
+	require.Nil(t, appErr)
+	return backend, dir
+}
+
+func newTestContent(size int) []byte {
+	content := make([]byte, size)
+	rand.Read(content)
+	return content
+}
+
+func TestNewEncryptedFileBackend(t *testing.T) {
+	_, appErr := NewEncryptedFileBackend(&LocalFileBackend{}, "", nil)
+	assert.NotNil(t, appErr)
+
+	_, appErr = NewEncryptedFileBackend(&LocalFileBackend{}, base64.StdEncoding.EncodeToString([]byte("short")), nil)
+	assert.NotNil(t, appErr)
+
+	_, appErr = NewEncryptedFileBackend(&LocalFileBackend{}, newTestEncryptionKey(), []string{"not base64"})
+	assert.NotNil(t, appErr)
+
+	backend, appErr := NewEncryptedFileBackend(&LocalFileBackend{}, newTestEncryptionKey(), []string{newTestEncryptionKey()})
+	require.Nil(t, appErr)
+	assert.Len(t, backend.KeyId(), 16)
+}
+
+func TestEncryptedFileBackendReadWrite(t *testing.T) {
+	backend, dir := newTestEncryptedFileBackend(t, newTestEncryptionKey())
+	defer os.RemoveAll(dir)
+
+	for name, size := range map[string]int{
+		"empty":                0,
+		"small":                10,
+		"one chunk":            ENCRYPTED_FILE_CHUNK_SIZE,
+		"one chunk and a byte": ENCRYPTED_FILE_CHUNK_SIZE + 1,
+		"several chunks":       3*ENCRYPTED_FILE_CHUNK_SIZE + 100,
+	} {
+		t.Run(name, func(t *testing.T) {
+			content := newTestContent(size)
+
+			written, appErr := backend.WriteFile(bytes.NewReader(content), name)
+			require.Nil(t, appErr)
+			assert.EqualValues(t, size, written)
+
+			stored, err := ioutil.ReadFile(filepath.Join(dir, name))
+			require.NoError(t, err)
+			assert.Equal(t, ENCRYPTED_FILE_MAGIC, string(stored[:4]))
+			if size > 0 {
+				assert.False(t, bytes.Contains(stored, content[:size/2+1]))
+			}
+
+			read, appErr := backend.ReadFile(name)
+			require.Nil(t, appErr)
+			assert.Equal(t, content, append([]byte{}, read...))
+		})
+	}
+}
+
+func TestEncryptedFileBackendSeek(t *testing.T) {
+	backend, dir := newTestEncryptedFileBackend(t, newTestEncryptionKey())
+	defer os.RemoveAll(dir)
+
+	content := newTestContent(2*ENCRYPTED_FILE_CHUNK_SIZE + 10)
+	_, appErr := backend.WriteFile(bytes.NewReader(content), "file")
+	require.Nil(t, appErr)
+
+	r, appErr := backend.Reader("file")
+	require.Nil(t, appErr)
+	defer r.Close()
+
+	size, err := r.Seek(0, io.SeekEnd)
+	require.NoError(t, err)
+	assert.EqualValues(t, len(content), size)
+
+	offset, err := r.Seek(ENCRYPTED_FILE_CHUNK_SIZE-5, io.SeekStart)
+	require.NoError(t, err)
+	buf := make([]byte, 10)
+	_, err = io.ReadFull(r, buf)
+	require.NoError(t, err)
+	assert.Equal(t, content[offset:offset+10], buf)
+
+	_, err = r.Seek(-10, io.SeekEnd)
+	require.NoError(t, err)
+	rest, err := ioutil.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, content[len(content)-10:], rest)
+
+	_, err = r.Seek(-1, io.SeekStart)
+	assert.Error(t, err)
+}
+
+func TestEncryptedFileBackendPlaintextFiles(t *testing.T) {
+	backend, dir := newTestEncryptedFileBackend(t, newTestEncryptionKey())
+	defer os.RemoveAll(dir)
+
+	content := []byte("written before the encryption was enabled")
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "plain"), content, 0600))
+
+	read, appErr := backend.ReadFile("plain")
+	require.Nil(t, appErr)
+	assert.Equal(t, content, read)
+
+	reencrypted, appErr := backend.ReencryptFile("plain")
+	require.Nil(t, appErr)
+	assert.True(t, reencrypted)
+
+	stored, err := ioutil.ReadFile(filepath.Join(dir, "plain"))
+	require.NoError(t, err)
+	assert.Equal(t, ENCRYPTED_FILE_MAGIC, string(stored[:4]))
+
+	read, appErr = backend.ReadFile("plain")
+	require.Nil(t, appErr)
+	assert.Equal(t, content, read)
+}
+
+func TestEncryptedFileBackendKeyRotation(t *testing.T) {
+	oldKey := newTestEncryptionKey()
+	oldBackend, dir := newTestEncryptedFileBackend(t, oldKey)
+	defer os.RemoveAll(dir)
+
+	content := newTestContent(ENCRYPTED_FILE_CHUNK_SIZE + 10)
+	_, appErr := oldBackend.WriteFile(bytes.NewReader(content), "file")
+	require.Nil(t, appErr)
+
+	newKey := newTestEncryptionKey()
+	backend, appErr := NewEncryptedFileBackend(&LocalFileBackend{directory: dir}, newKey, []string{oldKey})
+	require.Nil(t, appErr)
+
+	read, appErr := backend.ReadFile("file")
+	require.Nil(t, appErr)
+	assert.Equal(t, content, read)
+
+	reencrypted, appErr := backend.ReencryptFile("file")
+	require.Nil(t, appErr)
+	assert.True(t, reencrypted)
+
+	reencrypted, appErr = backend.ReencryptFile("file")
+	require.Nil(t, appErr)
+	assert.False(t, reencrypted)
+
+	// the file can't be read with the old key anymore
+	_, appErr = oldBackend.ReadFile("file")
+	assert.NotNil(t, appErr)
+
+	backend, appErr = NewEncryptedFileBackend(&LocalFileBackend{directory: dir}, newKey, nil)
+	require.Nil(t, appErr)
+	read, appErr = backend.ReadFile("file")
+	require.Nil(t, appErr)
+	assert.Equal(t, content, read)
+}
+
+func TestEncryptedFileBackendReencryptFailure(t *testing.T) {
+	oldKey := newTestEncryptionKey()
+	oldBackend, dir := newTestEncryptedFileBackend(t, oldKey)
+	defer os.RemoveAll(dir)
+
+	content := newTestContent(2*ENCRYPTED_FILE_CHUNK_SIZE + 10)
+	_, appErr := oldBackend.WriteFile(bytes.NewReader(content), "file")
+	require.Nil(t, appErr)
+
+	// corrupt the second chunk, so that the re-encryption fails after
+	// writing part of the temporary file
+	stored, err := ioutil.ReadFile(filepath.Join(dir, "file"))
+	require.NoError(t, err)
+	stored[len(stored)/2] ^= 0xff
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "file"), stored, 0600))
+
+	backend, appErr := NewEncryptedFileBackend(&LocalFileBackend{directory: dir}, newTestEncryptionKey(), []string{oldKey})
+	require.Nil(t, appErr)
+
+	_, appErr = backend.ReencryptFile("file")
+	require.NotNil(t, appErr)
+
+	_, err = os.Stat(filepath.Join(dir, "file"+reencryptTmpSuffix))
+	assert.True(t, os.IsNotExist(err), "the temporary file should be removed")
+
+	unchanged, err := ioutil.ReadFile(filepath.Join(dir, "file"))
+	require.NoError(t, err)
+	assert.Equal(t, stored, unchanged)
+}
+
+func TestEncryptedFileBackendTampering(t *testing.T) {
+	backend, dir := newTestEncryptedFileBackend(t, newTestEncryptionKey())
+	defer os.RemoveAll(dir)
+
+	content := newTestContent(2*ENCRYPTED_FILE_CHUNK_SIZE + 10)
+	_, appErr := backend.WriteFile(bytes.NewReader(content), "file")
+	require.Nil(t, appErr)
+
+	stored, err := ioutil.ReadFile(filepath.Join(dir, "file"))
+	require.NoError(t, err)
+
+	t.Run("modified chunk", func(t *testing.T) {
+		modified := append([]byte{}, stored...)
+		modified[len(modified)-20] ^= 1
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "modified"), modified, 0600))
+
+		_, appErr := backend.ReadFile("modified")
+		assert.NotNil(t, appErr)
+	})
+
+	t.Run("truncated at a chunk boundary", func(t *testing.T) {
+		truncated := stored[:encryptedFileHeaderSize+ENCRYPTED_FILE_CHUNK_SIZE+encryptedFileTagSize]
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "truncated"), truncated, 0600))
+
+		_, appErr := backend.ReadFile("truncated")
+		assert.NotNil(t, appErr)
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		otherBackend, otherDir := newTestEncryptedFileBackend(t, newTestEncryptionKey())
+		defer os.RemoveAll(otherDir)
+		require.NoError(t, ioutil.WriteFile(filepath.Join(otherDir, "file"), stored, 0600))
+
+		_, appErr := otherBackend.Reader("file")
+		assert.NotNil(t, appErr)
+	})
+}
+
+func TestEncryptedFileBackendAppend(t *testing.T) {
+	backend, dir := newTestEncryptedFileBackend(t, newTestEncryptionKey())
+	defer os.RemoveAll(dir)
+
+	t.Run("encrypted file", func(t *testing.T) {
+		content := newTestContent(ENCRYPTED_FILE_CHUNK_SIZE + 10)
+		_, appErr := backend.WriteFile(bytes.NewReader(content), "encrypted")
+		require.Nil(t, appErr)
+
+		stored, err := ioutil.ReadFile(filepath.Join(dir, "encrypted"))
+		require.NoError(t, err)
+
+		appended := newTestContent(20)
+		written, appErr := backend.AppendFile(bytes.NewReader(appended), "encrypted")
+		require.Nil(t, appErr)
+		assert.Equal(t, int64(len(appended)), written)
+
+		storedAfter, err := ioutil.ReadFile(filepath.Join(dir, "encrypted"))
+		require.NoError(t, err)
+		assert.Equal(t, stored, storedAfter[:len(stored)], "the existing segment should be left as it is")
+		assert.Len(t, storedAfter, len(stored)+encryptedFileHeaderSize+len(appended)+encryptedFileTagSize+encryptedFileTrailerSize)
+
+		read, appErr := backend.ReadFile("encrypted")
+		require.Nil(t, appErr)
+		assert.Equal(t, append(content, appended...), read)
+
+		r, appErr := backend.Reader("encrypted")
+		require.Nil(t, appErr)
+		defer r.Close()
+		_, err = r.Seek(int64(len(content)-5), io.SeekStart)
+		require.NoError(t, err)
+		part := make([]byte, 10)
+		_, err = io.ReadFull(r, part)
+		require.NoError(t, err)
+		assert.Equal(t, append(content[len(content)-5:], appended[:5]...), part)
+	})
+
+	t.Run("plaintext file", func(t *testing.T) {
+		content := []byte("plaintext")
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "plaintext"), content, 0600))
+
+		written, appErr := backend.AppendFile(bytes.NewReader([]byte(" appended")), "plaintext")
+		require.Nil(t, appErr)
+		assert.Equal(t, int64(9), written)
+
+		stored, err := ioutil.ReadFile(filepath.Join(dir, "plaintext"))
+		require.NoError(t, err)
+		assert.NotContains(t, string(stored), "plaintext")
+
+		read, appErr := backend.ReadFile("plaintext")
+		require.Nil(t, appErr)
+		assert.Equal(t, "plaintext appended", string(read))
+	})
+
+	t.Run("segment with the trailer removed", func(t *testing.T) {
+		stored, err := ioutil.ReadFile(filepath.Join(dir, "encrypted"))
+		require.NoError(t, err)
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "truncated"), stored[:len(stored)-encryptedFileTrailerSize], 0600))
+
+		_, appErr := backend.ReadFile("truncated")
+		assert.NotNil(t, appErr)
+	})
+}
@@ -6,6 +6,7 @@ package filesstore
 import (
 	"io"
 	"net/http"
+	"time"
 
 	"github.com/zacmm/zacmm-server/model"
 )
@@ -21,6 +22,7 @@ type FileBackend interface {
 	Reader(path string) (ReadCloseSeeker, *model.AppError)
 	ReadFile(path string) ([]byte, *model.AppError)
 	FileExists(path string) (bool, *model.AppError)
+	FileModTime(path string) (time.Time, *model.AppError)
 	CopyFile(oldPath, newPath string) *model.AppError
 	MoveFile(oldPath, newPath string) *model.AppError
 	WriteFile(fr io.Reader, path string) (int64, *model.AppError)
@@ -28,10 +30,24 @@ type FileBackend interface {
 	RemoveFile(path string) *model.AppError
 
 	ListDirectory(path string) (*[]string, *model.AppError)
+	ListDirectoryRecursively(path string) (*[]string, *model.AppError)
 	RemoveDirectory(path string) *model.AppError
 }
 
 func NewFileBackend(settings *model.FileSettings, enableComplianceFeatures bool) (FileBackend, *model.AppError) {
+	backend, appErr := newFileBackend(settings, enableComplianceFeatures)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if settings.EnableEncryptionAtRest != nil && *settings.EnableEncryptionAtRest {
+		return NewEncryptedFileBackend(backend, *settings.EncryptionKey, settings.PreviousEncryptionKeys)
+	}
+
+	return backend, nil
+}
+
+func newFileBackend(settings *model.FileSettings, enableComplianceFeatures bool) (FileBackend, *model.AppError) {
 	switch *settings.DriverName {
 	case model.IMAGE_DRIVER_S3:
 		backend, err := NewS3FileBackend(settings, enableComplianceFeatures)
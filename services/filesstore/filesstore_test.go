@@ -9,6 +9,7 @@ import (
 	"io/ioutil"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
@@ -47,6 +48,21 @@ func TestLocalFileBackendTestSuite(t *testing.T) {
 	})
 }
 
+func TestEncryptedLocalFileBackendTestSuite(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	suite.Run(t, &FileBackendTestSuite{
+		settings: model.FileSettings{
+			DriverName:             model.NewString(model.IMAGE_DRIVER_LOCAL),
+			Directory:              &dir,
+			EnableEncryptionAtRest: model.NewBool(true),
+			EncryptionKey:          model.NewString(newTestEncryptionKey()),
+		},
+	})
+}
+
 func TestS3FileBackendTestSuite(t *testing.T) {
 	runBackendTest(t, false)
 }
@@ -147,6 +163,22 @@ func (s *FileBackendTestSuite) TestFileExists() {
 	s.False(res)
 }
 
+func (s *FileBackendTestSuite) TestFileModTime() {
+	b := []byte("testimage")
+	path := "tests/" + model.NewId() + ".png"
+
+	_, err := s.backend.WriteFile(bytes.NewReader(b), path)
+	s.Nil(err)
+	defer s.backend.RemoveFile(path)
+
+	modTime, err := s.backend.FileModTime(path)
+	s.Nil(err)
+	s.WithinDuration(time.Now(), modTime, time.Minute)
+
+	_, err = s.backend.FileModTime("tests/idontexist.png")
+	s.NotNil(err)
+}
+
 func (s *FileBackendTestSuite) TestCopyFile() {
 	b := []byte("test")
 	path1 := "tests/" + model.NewId()
@@ -287,6 +319,28 @@ func (s *FileBackendTestSuite) TestListDirectory() {
 	s.backend.RemoveFile(path2)
 }
 
+func (s *FileBackendTestSuite) TestListDirectoryRecursively() {
+	b := []byte("test")
+	path1 := "19700101/" + model.NewId()
+	path2 := "19700101/" + model.NewId() + "/" + model.NewId()
+
+	paths, err := s.backend.ListDirectoryRecursively("19700101")
+	s.Nil(err)
+	s.Len(*paths, 0)
+
+	for _, path := range []string{path1, path2} {
+		written, err := s.backend.WriteFile(bytes.NewReader(b), path)
+		s.Nil(err)
+		s.EqualValues(len(b), written, "expected given number of bytes to have been written")
+	}
+
+	paths, err = s.backend.ListDirectoryRecursively("19700101")
+	s.Nil(err)
+	s.ElementsMatch([]string{path1, path2}, *paths)
+
+	s.Nil(s.backend.RemoveDirectory("19700101"))
+}
+
 func (s *FileBackendTestSuite) TestRemoveDirectory() {
 	b := []byte("test")
 
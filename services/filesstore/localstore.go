@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/zacmm/zacmm-server/mlog"
 	"github.com/zacmm/zacmm-server/model"
@@ -63,6 +64,14 @@ func (b *LocalFileBackend) FileExists(path string) (bool, *model.AppError) {
 	return true, nil
 }
 
+func (b *LocalFileBackend) FileModTime(path string) (time.Time, *model.AppError) {
+	info, err := os.Stat(filepath.Join(b.directory, path))
+	if err != nil {
+		return time.Time{}, model.NewAppError("FileModTime", "api.file.file_mod_time.local.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	return info.ModTime(), nil
+}
+
 func (b *LocalFileBackend) CopyFile(oldPath, newPath string) *model.AppError {
 	if err := utils.CopyFile(filepath.Join(b.directory, oldPath), filepath.Join(b.directory, newPath)); err != nil {
 		return model.NewAppError("copyFile", "api.file.move_file.rename.app_error", nil, err.Error(), http.StatusInternalServerError)
@@ -142,6 +151,31 @@ func (b *LocalFileBackend) ListDirectory(path string) (*[]string, *model.AppErro
 	return &paths, nil
 }
 
+func (b *LocalFileBackend) ListDirectoryRecursively(path string) (*[]string, *model.AppError) {
+	var paths []string
+	root := filepath.Join(b.directory, path)
+	err := filepath.Walk(root, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			relPath, err := filepath.Rel(b.directory, filePath)
+			if err != nil {
+				return err
+			}
+			paths = append(paths, relPath)
+		}
+		return nil
+	})
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &paths, nil
+		}
+		return nil, model.NewAppError("ListDirectoryRecursively", "utils.file.list_directory.local.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	return &paths, nil
+}
+
 func (b *LocalFileBackend) RemoveDirectory(path string) *model.AppError {
 	if err := os.RemoveAll(filepath.Join(b.directory, path)); err != nil {
 		return model.NewAppError("RemoveDirectory", "utils.file.remove_directory.local.app_error", nil, err.Error(), http.StatusInternalServerError)
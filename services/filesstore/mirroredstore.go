@@ -0,0 +1,109 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package filesstore
+
+import (
+	"io"
+
+	"github.com/zacmm/zacmm-server/mlog"
+	"github.com/zacmm/zacmm-server/model"
+)
+
+// MirroredFileBackend reads the files from a backend and applies the changes
+// made to them to another one too, so that no file written while the files
+// are migrated to the other backend is missed. The changes that fail on the
+// mirror are reported rather than failing, as the primary backend is still
+// the one in use.
+type MirroredFileBackend struct {
+	FileBackend
+	mirror    FileBackend
+	onFailure func()
+}
+
+// NewMirroredFileBackend returns a backend mirroring the changes to the files
+// of backend to mirror, calling onFailure for each change that fails.
+func NewMirroredFileBackend(backend, mirror FileBackend, onFailure func()) *MirroredFileBackend {
+	return &MirroredFileBackend{
+		FileBackend: backend,
+		mirror:      mirror,
+		onFailure:   onFailure,
+	}
+}
+
+func (b *MirroredFileBackend) fail(path string, appErr *model.AppError) {
+	mlog.Warn("Failed to mirror file change", mlog.String("path", path), mlog.Err(appErr))
+	b.onFailure()
+}
+
+// mirrorFile copies the file at the given path as it is in the primary
+// backend to the mirror.
+func (b *MirroredFileBackend) mirrorFile(path string) {
+	r, appErr := b.FileBackend.Reader(path)
+	if appErr != nil {
+		b.fail(path, appErr)
+		return
+	}
+	defer r.Close()
+
+	if _, appErr := b.mirror.WriteFile(r, path); appErr != nil {
+		b.fail(path, appErr)
+	}
+}
+
+func (b *MirroredFileBackend) CopyFile(oldPath, newPath string) *model.AppError {
+	if appErr := b.FileBackend.CopyFile(oldPath, newPath); appErr != nil {
+		return appErr
+	}
+	b.mirrorFile(newPath)
+	return nil
+}
+
+func (b *MirroredFileBackend) MoveFile(oldPath, newPath string) *model.AppError {
+	if appErr := b.FileBackend.MoveFile(oldPath, newPath); appErr != nil {
+		return appErr
+	}
+	b.mirrorFile(newPath)
+	// The file may not have been migrated yet.
+	b.mirror.RemoveFile(oldPath)
+	return nil
+}
+
+func (b *MirroredFileBackend) WriteFile(fr io.Reader, path string) (int64, *model.AppError) {
+	written, appErr := b.FileBackend.WriteFile(fr, path)
+	if appErr != nil {
+		return 0, appErr
+	}
+	b.mirrorFile(path)
+	return written, nil
+}
+
+// AppendFile copies the whole file to the mirror once appended to, as the
+// beginning of the file may not have been migrated yet.
+func (b *MirroredFileBackend) AppendFile(fr io.Reader, path string) (int64, *model.AppError) {
+	written, appErr := b.FileBackend.AppendFile(fr, path)
+	if appErr != nil {
+		return 0, appErr
+	}
+	b.mirrorFile(path)
+	return written, nil
+}
+
+func (b *MirroredFileBackend) RemoveFile(path string) *model.AppError {
+	if appErr := b.FileBackend.RemoveFile(path); appErr != nil {
+		return appErr
+	}
+	// The file may not have been migrated yet.
+	b.mirror.RemoveFile(path)
+	return nil
+}
+
+func (b *MirroredFileBackend) RemoveDirectory(path string) *model.AppError {
+	if appErr := b.FileBackend.RemoveDirectory(path); appErr != nil {
+		return appErr
+	}
+	if appErr := b.mirror.RemoveDirectory(path); appErr != nil {
+		b.fail(path, appErr)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package filesstore
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLocalFileBackend(t *testing.T) (*LocalFileBackend, string) {
+	dir, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+	return &LocalFileBackend{directory: dir}, dir
+}
+
+func TestMirroredFileBackend(t *testing.T) {
+	primary, primaryDir := newTestLocalFileBackend(t)
+	defer os.RemoveAll(primaryDir)
+	mirror, mirrorDir := newTestLocalFileBackend(t)
+	defer os.RemoveAll(mirrorDir)
+
+	var failures int64
+	backend := NewMirroredFileBackend(primary, mirror, func() { failures++ })
+
+	readMirror := func(t *testing.T, path string) string {
+		data, appErr := mirror.ReadFile(path)
+		require.Nil(t, appErr)
+		return string(data)
+	}
+	existsInMirror := func(t *testing.T, path string) bool {
+		exists, appErr := mirror.FileExists(path)
+		require.Nil(t, appErr)
+		return exists
+	}
+
+	t.Run("write", func(t *testing.T) {
+		written, appErr := backend.WriteFile(bytes.NewReader([]byte("content")), "data/file")
+		require.Nil(t, appErr)
+		assert.EqualValues(t, 7, written)
+		assert.Equal(t, "content", readMirror(t, "data/file"))
+	})
+
+	t.Run("append to a file not migrated yet", func(t *testing.T) {
+		require.NoError(t, os.MkdirAll(filepath.Join(primaryDir, "uploads"), 0700))
+		require.NoError(t, ioutil.WriteFile(filepath.Join(primaryDir, "uploads", "partial"), []byte("first "), 0600))
+
+		written, appErr := backend.AppendFile(bytes.NewReader([]byte("second")), "uploads/partial")
+		require.Nil(t, appErr)
+		assert.EqualValues(t, 6, written)
+		assert.Equal(t, "first second", readMirror(t, "uploads/partial"))
+	})
+
+	t.Run("copy and move", func(t *testing.T) {
+		require.Nil(t, backend.CopyFile("data/file", "data/copy"))
+		assert.Equal(t, "content", readMirror(t, "data/copy"))
+
+		require.Nil(t, backend.MoveFile("data/copy", "data/moved"))
+		assert.Equal(t, "content", readMirror(t, "data/moved"))
+		assert.False(t, existsInMirror(t, "data/copy"))
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		require.Nil(t, backend.RemoveFile("data/moved"))
+		assert.False(t, existsInMirror(t, "data/moved"))
+
+		require.Nil(t, backend.RemoveDirectory("data"))
+		assert.False(t, existsInMirror(t, "data/file"))
+	})
+
+	assert.Zero(t, failures)
+
+	t.Run("failures are counted", func(t *testing.T) {
+		// A file in place of the directory of the mirrored file.
+		require.NoError(t, ioutil.WriteFile(filepath.Join(mirrorDir, "blocked"), nil, 0600))
+
+		_, appErr := backend.WriteFile(bytes.NewReader([]byte("content")), "blocked/file")
+		require.Nil(t, appErr, "the primary backend should still be written to")
+		assert.EqualValues(t, 1, failures)
+	})
+}
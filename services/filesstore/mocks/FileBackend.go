@@ -12,6 +12,8 @@ import (
 	mock "github.com/stretchr/testify/mock"
 
 	model "github.com/zacmm/zacmm-server/model"
+
+	time "time"
 )
 
 // FileBackend is an autogenerated mock type for the FileBackend type
@@ -81,6 +83,29 @@ func (_m *FileBackend) FileExists(path string) (bool, *model.AppError) {
 	return r0, r1
 }
 
+// FileModTime provides a mock function with given fields: path
+func (_m *FileBackend) FileModTime(path string) (time.Time, *model.AppError) {
+	ret := _m.Called(path)
+
+	var r0 time.Time
+	if rf, ok := ret.Get(0).(func(string) time.Time); ok {
+		r0 = rf(path)
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func(string) *model.AppError); ok {
+		r1 = rf(path)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // ListDirectory provides a mock function with given fields: path
 func (_m *FileBackend) ListDirectory(path string) (*[]string, *model.AppError) {
 	ret := _m.Called(path)
@@ -106,6 +131,31 @@ func (_m *FileBackend) ListDirectory(path string) (*[]string, *model.AppError) {
 	return r0, r1
 }
 
+// ListDirectoryRecursively provides a mock function with given fields: path
+func (_m *FileBackend) ListDirectoryRecursively(path string) (*[]string, *model.AppError) {
+	ret := _m.Called(path)
+
+	var r0 *[]string
+	if rf, ok := ret.Get(0).(func(string) *[]string); ok {
+		r0 = rf(path)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]string)
+		}
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func(string) *model.AppError); ok {
+		r1 = rf(path)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // MoveFile provides a mock function with given fields: oldPath, newPath
 func (_m *FileBackend) MoveFile(oldPath string, newPath string) *model.AppError {
 	ret := _m.Called(oldPath, newPath)
@@ -12,6 +12,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	s3 "github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/credentials"
@@ -195,6 +196,16 @@ func (b *S3FileBackend) FileExists(path string) (bool, *model.AppError) {
 	return false, model.NewAppError("FileExists", "api.file.file_exists.s3.app_error", nil, err.Error(), http.StatusInternalServerError)
 }
 
+func (b *S3FileBackend) FileModTime(path string) (time.Time, *model.AppError) {
+	path = filepath.Join(b.pathPrefix, path)
+
+	info, err := b.client.StatObject(context.Background(), b.bucket, path, s3.StatObjectOptions{})
+	if err != nil {
+		return time.Time{}, model.NewAppError("FileModTime", "api.file.file_mod_time.s3.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	return info.LastModified, nil
+}
+
 func (b *S3FileBackend) CopyFile(oldPath, newPath string) *model.AppError {
 	oldPath = filepath.Join(b.pathPrefix, oldPath)
 	newPath = filepath.Join(b.pathPrefix, newPath)
@@ -362,6 +373,32 @@ func (b *S3FileBackend) ListDirectory(path string) (*[]string, *model.AppError)
 	return &paths, nil
 }
 
+func (b *S3FileBackend) ListDirectoryRecursively(path string) (*[]string, *model.AppError) {
+	var paths []string
+
+	path = filepath.Join(b.pathPrefix, path)
+	if !strings.HasSuffix(path, "/") && len(path) > 0 {
+		path = path + "/"
+	}
+
+	opts := s3.ListObjectsOptions{
+		Prefix:    path,
+		Recursive: true,
+	}
+	for object := range b.client.ListObjects(context.Background(), b.bucket, opts) {
+		if object.Err != nil {
+			return nil, model.NewAppError("ListDirectoryRecursively", "utils.file.list_directory.s3.app_error", nil, object.Err.Error(), http.StatusInternalServerError)
+		}
+		object.Key = strings.TrimPrefix(object.Key, b.pathPrefix)
+		trimmed := strings.Trim(object.Key, "/")
+		if trimmed != "" {
+			paths = append(paths, trimmed)
+		}
+	}
+
+	return &paths, nil
+}
+
 func (b *S3FileBackend) RemoveDirectory(path string) *model.AppError {
 	opts := s3.ListObjectsOptions{
 		Prefix:    filepath.Join(b.pathPrefix, path),
@@ -0,0 +1,368 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package mailservice
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jaytaylor/html2text"
+
+	"github.com/zacmm/zacmm-server/mlog"
+)
+
+const (
+	inboundCommandTimeout = 5 * time.Minute
+	inboundMaxRecipients  = 100
+	inboundMaxMimeDepth   = 5
+)
+
+// InboundMessageHandler is called for every message received by an
+// InboundServer with the envelope sender, the envelope recipients and the raw
+// message. Returning an error rejects the message.
+type InboundMessageHandler func(from string, to []string, data []byte) error
+
+// InboundServer is a minimal SMTP server that receives messages and hands them
+// to an InboundMessageHandler. It doesn't relay messages, nor support
+// authentication or TLS, and is meant to sit behind the organization's MTA.
+type InboundServer struct {
+	hostname       string
+	maxMessageSize int64
+	handler        InboundMessageHandler
+
+	mutex    sync.Mutex
+	listener net.Listener
+	conns    map[net.Conn]struct{}
+	wg       sync.WaitGroup
+}
+
+func NewInboundServer(hostname string, maxMessageSize int64, handler InboundMessageHandler) *InboundServer {
+	return &InboundServer{
+		hostname:       hostname,
+		maxMessageSize: maxMessageSize,
+		handler:        handler,
+		conns:          make(map[net.Conn]struct{}),
+	}
+}
+
+// Start listens on the given address and serves connections in the
+// background until Close is called.
+func (s *InboundServer) Start(address string) error {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	s.listener = listener
+	s.mutex.Unlock()
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			s.mutex.Lock()
+			s.conns[conn] = struct{}{}
+			s.mutex.Unlock()
+
+			s.wg.Add(1)
+			go func() {
+				defer s.wg.Done()
+				s.serveConn(conn)
+
+				s.mutex.Lock()
+				delete(s.conns, conn)
+				s.mutex.Unlock()
+			}()
+		}
+	}()
+
+	return nil
+}
+
+// Addr returns the address the server is listening on, or nil if it isn't
+// started.
+func (s *InboundServer) Addr() net.Addr {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.listener == nil {
+		return nil
+	}
+	return s.listener.Addr()
+}
+
+// Close stops listening, closes the open connections and waits for them to
+// finish.
+func (s *InboundServer) Close() error {
+	s.mutex.Lock()
+	var err error
+	if s.listener != nil {
+		err = s.listener.Close()
+	}
+	for conn := range s.conns {
+		conn.Close()
+	}
+	s.mutex.Unlock()
+
+	s.wg.Wait()
+	return err
+}
+
+type inboundSession struct {
+	from string
+	to   []string
+}
+
+func (s *InboundServer) serveConn(conn net.Conn) {
+	defer conn.Close()
+
+	text := textproto.NewConn(conn)
+	reply := func(code int, message string) bool {
+		conn.SetWriteDeadline(time.Now().Add(inboundCommandTimeout))
+		return text.PrintfLine("%d %s", code, message) == nil
+	}
+
+	if !reply(220, s.hostname+" ESMTP ready") {
+		return
+	}
+
+	var session *inboundSession
+	for {
+		conn.SetReadDeadline(time.Now().Add(inboundCommandTimeout))
+		line, err := text.ReadLine()
+		if err != nil {
+			return
+		}
+
+		verb, arg := line, ""
+		if i := strings.IndexByte(line, ' '); i >= 0 {
+			verb, arg = line[:i], strings.TrimSpace(line[i+1:])
+		}
+
+		switch strings.ToUpper(verb) {
+		case "HELO":
+			session = nil
+			reply(250, s.hostname)
+		case "EHLO":
+			session = nil
+			conn.SetWriteDeadline(time.Now().Add(inboundCommandTimeout))
+			text.PrintfLine("250-%s", s.hostname)
+			text.PrintfLine("250-SIZE %d", s.maxMessageSize)
+			reply(250, "8BITMIME")
+		case "MAIL":
+			address, ok := parsePathArg(arg, "FROM:")
+			if !ok {
+				reply(501, "Syntax: MAIL FROM:<address>")
+				continue
+			}
+			session = &inboundSession{from: address}
+			reply(250, "OK")
+		case "RCPT":
+			if session == nil {
+				reply(503, "Need MAIL command first")
+				continue
+			}
+			address, ok := parsePathArg(arg, "TO:")
+			if !ok || address == "" {
+				reply(501, "Syntax: RCPT TO:<address>")
+				continue
+			}
+			if len(session.to) >= inboundMaxRecipients {
+				reply(452, "Too many recipients")
+				continue
+			}
+			session.to = append(session.to, address)
+			reply(250, "OK")
+		case "DATA":
+			if session == nil || len(session.to) == 0 {
+				reply(503, "Need RCPT command first")
+				continue
+			}
+			if !reply(354, "End data with <CR><LF>.<CR><LF>") {
+				return
+			}
+
+			conn.SetReadDeadline(time.Now().Add(inboundCommandTimeout))
+			data, err := readMessageData(text.DotReader(), s.maxMessageSize)
+			if err == errMessageTooLarge {
+				reply(552, "Message exceeds the maximum size")
+			} else if err != nil {
+				return
+			} else if err := s.handler(session.from, session.to, data); err != nil {
+				mlog.Debug("Rejected inbound email", mlog.String("from", session.from), mlog.Err(err))
+				reply(550, "Message rejected")
+			} else {
+				reply(250, "OK")
+			}
+			session = nil
+		case "RSET":
+			session = nil
+			reply(250, "OK")
+		case "NOOP":
+			reply(250, "OK")
+		case "VRFY":
+			reply(252, "Cannot verify user")
+		case "QUIT":
+			reply(221, "Bye")
+			return
+		default:
+			reply(502, "Command not implemented")
+		}
+	}
+}
+
+var errMessageTooLarge = errors.New("message exceeds the maximum size")
+
+// readMessageData reads the whole message, discarding the rest of it if it's
+// larger than maxSize so that the connection can be reused.
+func readMessageData(r io.Reader, maxSize int64) ([]byte, error) {
+	data, err := ioutil.ReadAll(io.LimitReader(r, maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if int64(len(data)) > maxSize {
+		if _, err := io.Copy(ioutil.Discard, r); err != nil {
+			return nil, err
+		}
+		return nil, errMessageTooLarge
+	}
+
+	return data, nil
+}
+
+// parsePathArg parses the argument of the MAIL and RCPT commands, such as
+// "FROM:<user@example.com> SIZE=1024", ignoring any extension parameter.
+func parsePathArg(arg, prefix string) (string, bool) {
+	if len(arg) < len(prefix) || !strings.EqualFold(arg[:len(prefix)], prefix) {
+		return "", false
+	}
+
+	path := strings.TrimSpace(arg[len(prefix):])
+	if i := strings.IndexByte(path, ' '); i >= 0 {
+		path = path[:i]
+	}
+
+	if !strings.HasPrefix(path, "<") || !strings.HasSuffix(path, ">") {
+		return "", false
+	}
+	path = path[1 : len(path)-1]
+
+	if path == "" {
+		// The null reverse-path, used by bounces.
+		return "", true
+	}
+
+	address, err := mail.ParseAddress(path)
+	if err != nil {
+		return "", false
+	}
+	return address.Address, true
+}
+
+// ReadMessageText parses a raw message and returns its sender address and
+// its plain text body, converting it from HTML if it has no plain text part.
+func ReadMessageText(data []byte) (string, string, error) {
+	msg, err := mail.ReadMessage(bytes.NewReader(data))
+	if err != nil {
+		return "", "", fmt.Errorf("failed to parse message: %w", err)
+	}
+
+	from, err := mail.ParseAddress(msg.Header.Get("From"))
+	if err != nil {
+		return "", "", fmt.Errorf("failed to parse the From header: %w", err)
+	}
+
+	body, err := readTextBody(textproto.MIMEHeader(msg.Header), msg.Body, 0)
+	if err != nil {
+		return "", "", err
+	}
+
+	return from.Address, body, nil
+}
+
+// readTextBody returns the text of a message or message part, picking the
+// plain text alternative of multipart messages when there is one.
+func readTextBody(header textproto.MIMEHeader, body io.Reader, depth int) (string, error) {
+	mediaType, params, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		mediaType = "text/plain"
+	}
+
+	switch strings.ToLower(header.Get("Content-Transfer-Encoding")) {
+	case "base64":
+		body = base64.NewDecoder(base64.StdEncoding, body)
+	case "quoted-printable":
+		body = quotedprintable.NewReader(body)
+	}
+
+	switch {
+	case strings.HasPrefix(mediaType, "multipart/"):
+		if depth >= inboundMaxMimeDepth {
+			return "", errors.New("message is nested too deeply")
+		}
+
+		var fallback string
+		reader := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := reader.NextPart()
+			if err == io.EOF {
+				break
+			} else if err != nil {
+				return "", fmt.Errorf("failed to read message part: %w", err)
+			}
+
+			if part.FileName() != "" {
+				continue
+			}
+
+			text, err := readTextBody(part.Header, part, depth+1)
+			if err != nil {
+				return "", err
+			}
+
+			partType, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type"))
+			if (partType == "" || partType == "text/plain") && text != "" {
+				return text, nil
+			}
+			if fallback == "" {
+				fallback = text
+			}
+		}
+		return fallback, nil
+	case mediaType == "text/plain":
+		data, err := ioutil.ReadAll(body)
+		if err != nil {
+			return "", fmt.Errorf("failed to read message body: %w", err)
+		}
+		return string(data), nil
+	case mediaType == "text/html":
+		data, err := ioutil.ReadAll(body)
+		if err != nil {
+			return "", fmt.Errorf("failed to read message body: %w", err)
+		}
+		return html2text.FromString(string(data))
+	}
+
+	return "", nil
+}
@@ -0,0 +1,155 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package mailservice
+
+import (
+	"errors"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInboundServer(t *testing.T) {
+	type received struct {
+		from string
+		to   []string
+		data string
+	}
+	messages := make(chan received, 10)
+
+	server := NewInboundServer("test.example.com", 1024, func(from string, to []string, data []byte) error {
+		if strings.Contains(string(data), "reject me") {
+			return errors.New("rejected")
+		}
+		messages <- received{from, to, string(data)}
+		return nil
+	})
+	require.NoError(t, server.Start("127.0.0.1:0"))
+	defer server.Close()
+
+	address := server.Addr().String()
+
+	t.Run("should receive a message", func(t *testing.T) {
+		message := "From: sender@example.com\r\nSubject: Hello\r\n\r\nHello there\r\n.. and a dot\r\n"
+		err := smtp.SendMail(address, nil, "sender@example.com", []string{"reply+token@example.com", "other@example.com"}, []byte(message))
+		require.NoError(t, err)
+
+		msg := <-messages
+		assert.Equal(t, "sender@example.com", msg.from)
+		assert.Equal(t, []string{"reply+token@example.com", "other@example.com"}, msg.to)
+		assert.Equal(t, "From: sender@example.com\nSubject: Hello\n\nHello there\n.. and a dot\n", msg.data)
+	})
+
+	t.Run("should reject a message refused by the handler", func(t *testing.T) {
+		err := smtp.SendMail(address, nil, "sender@example.com", []string{"reply@example.com"}, []byte("Subject: Hi\r\n\r\nreject me\r\n"))
+		require.Error(t, err)
+
+		var protoErr *textproto.Error
+		require.True(t, errors.As(err, &protoErr))
+		assert.Equal(t, 550, protoErr.Code)
+	})
+
+	t.Run("should reject a message that is too large", func(t *testing.T) {
+		err := smtp.SendMail(address, nil, "sender@example.com", []string{"reply@example.com"}, []byte("Subject: Hi\r\n\r\n"+strings.Repeat("a", 2048)+"\r\n"))
+		require.Error(t, err)
+
+		var protoErr *textproto.Error
+		require.True(t, errors.As(err, &protoErr))
+		assert.Equal(t, 552, protoErr.Code)
+	})
+
+	t.Run("should require a recipient before the data", func(t *testing.T) {
+		client, err := smtp.Dial(address)
+		require.NoError(t, err)
+		defer client.Close()
+
+		require.NoError(t, client.Mail("sender@example.com"))
+		_, err = client.Data()
+		require.Error(t, err)
+	})
+}
+
+func TestParsePathArg(t *testing.T) {
+	for name, tc := range map[string]struct {
+		arg      string
+		prefix   string
+		expected string
+		ok       bool
+	}{
+		"simple address":    {"FROM:<user@example.com>", "FROM:", "user@example.com", true},
+		"lower case":        {"to:<user@example.com>", "TO:", "user@example.com", true},
+		"with parameters":   {"FROM:<user@example.com> SIZE=1024 BODY=8BITMIME", "FROM:", "user@example.com", true},
+		"space after colon": {"FROM: <user@example.com>", "FROM:", "user@example.com", true},
+		"null reverse-path": {"FROM:<>", "FROM:", "", true},
+		"missing brackets":  {"FROM:user@example.com", "FROM:", "", false},
+		"wrong prefix":      {"TO:<user@example.com>", "FROM:", "", false},
+		"invalid address":   {"TO:<not an address>", "TO:", "", false},
+		"empty argument":    {"", "TO:", "", false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			address, ok := parsePathArg(tc.arg, tc.prefix)
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.expected, address)
+		})
+	}
+}
+
+func TestReadMessageText(t *testing.T) {
+	t.Run("plain text", func(t *testing.T) {
+		from, text, err := ReadMessageText([]byte("From: \"Some User\" <user@example.com>\r\nSubject: Re: Hi\r\n\r\nSounds good\r\n"))
+		require.NoError(t, err)
+		assert.Equal(t, "user@example.com", from)
+		assert.Equal(t, "Sounds good\r\n", text)
+	})
+
+	t.Run("multipart alternative prefers the plain text part", func(t *testing.T) {
+		message := "From: user@example.com\r\n" +
+			"Content-Type: multipart/alternative; boundary=\"b1\"\r\n" +
+			"\r\n" +
+			"--b1\r\n" +
+			"Content-Type: text/html; charset=utf-8\r\n" +
+			"\r\n" +
+			"<p>From <b>HTML</b></p>\r\n" +
+			"--b1\r\n" +
+			"Content-Type: text/plain; charset=utf-8\r\n" +
+			"Content-Transfer-Encoding: quoted-printable\r\n" +
+			"\r\n" +
+			"Caf=C3=A9 at noon?\r\n" +
+			"--b1--\r\n"
+
+		_, text, err := ReadMessageText([]byte(message))
+		require.NoError(t, err)
+		assert.Equal(t, "Café at noon?", strings.TrimSpace(text))
+	})
+
+	t.Run("html only in a mixed message with an attachment", func(t *testing.T) {
+		message := "From: user@example.com\r\n" +
+			"Content-Type: multipart/mixed; boundary=\"b1\"\r\n" +
+			"\r\n" +
+			"--b1\r\n" +
+			"Content-Type: text/plain\r\n" +
+			"Content-Disposition: attachment; filename=\"notes.txt\"\r\n" +
+			"\r\n" +
+			"attached notes\r\n" +
+			"--b1\r\n" +
+			"Content-Type: text/html\r\n" +
+			"Content-Transfer-Encoding: base64\r\n" +
+			"\r\n" +
+			"PHA+SGVsbG8gZnJvbSBIVE1MPC9wPg==\r\n" +
+			"--b1--\r\n"
+
+		_, text, err := ReadMessageText([]byte(message))
+		require.NoError(t, err)
+		assert.Equal(t, "Hello from HTML", strings.TrimSpace(text))
+	})
+
+	t.Run("missing sender", func(t *testing.T) {
+		_, _, err := ReadMessageText([]byte("Subject: Hi\r\n\r\nHello\r\n"))
+		require.Error(t, err)
+	})
+}
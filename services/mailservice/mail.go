@@ -12,6 +12,7 @@ import (
 	"net"
 	"net/mail"
 	"net/smtp"
+	"net/textproto"
 	"time"
 
 	gomail "gopkg.in/mail.v2"
@@ -273,6 +274,24 @@ func SendMailUsingConfig(to, subject, htmlBody string, config *model.Config, ena
 	return SendMailWithEmbeddedFilesUsingConfig(to, subject, htmlBody, nil, config, enableComplianceFeatures, ccMail)
 }
 
+// SendMailWithReplyToUsingConfig sends an email whose replies go to the given
+// address instead of the configured reply-to address.
+func SendMailWithReplyToUsingConfig(to, subject, htmlBody, replyTo string, config *model.Config, enableComplianceFeatures bool, ccMail string) *model.AppError {
+	fromMail := mail.Address{Name: *config.EmailSettings.FeedbackName, Address: *config.EmailSettings.FeedbackEmail}
+
+	mail := mailData{
+		mimeTo:   to,
+		smtpTo:   to,
+		from:     fromMail,
+		cc:       ccMail,
+		replyTo:  mail.Address{Name: *config.EmailSettings.FeedbackName, Address: replyTo},
+		subject:  subject,
+		htmlBody: htmlBody,
+	}
+
+	return sendMailUsingConfigAdvanced(mail, config, enableComplianceFeatures)
+}
+
 // allows for sending an email with attachments and differing MIME/SMTP recipients
 func sendMailUsingConfigAdvanced(mail mailData, config *model.Config, enableComplianceFeatures bool) *model.AppError {
 	if len(*config.EmailSettings.SMTPServer) == 0 {
@@ -363,16 +382,16 @@ func SendMail(c smtpClient, mail mailData, fileBackend filesstore.FileBackend, d
 	}
 
 	if err = c.Mail(mail.from.Address); err != nil {
-		return model.NewAppError("SendMail", "utils.mail.send_mail.from_address.app_error", nil, err.Error(), http.StatusInternalServerError)
+		return model.NewAppError("SendMail", "utils.mail.send_mail.from_address.app_error", nil, err.Error(), smtpErrorStatusCode(err))
 	}
 
 	if err = c.Rcpt(mail.smtpTo); err != nil {
-		return model.NewAppError("SendMail", "utils.mail.send_mail.to_address.app_error", nil, err.Error(), http.StatusInternalServerError)
+		return model.NewAppError("SendMail", "utils.mail.send_mail.to_address.app_error", nil, err.Error(), smtpErrorStatusCode(err))
 	}
 
 	w, err := c.Data()
 	if err != nil {
-		return model.NewAppError("SendMail", "utils.mail.send_mail.msg_data.app_error", nil, err.Error(), http.StatusInternalServerError)
+		return model.NewAppError("SendMail", "utils.mail.send_mail.msg_data.app_error", nil, err.Error(), smtpErrorStatusCode(err))
 	}
 
 	_, err = m.WriteTo(w)
@@ -381,8 +400,25 @@ func SendMail(c smtpClient, mail mailData, fileBackend filesstore.FileBackend, d
 	}
 	err = w.Close()
 	if err != nil {
-		return model.NewAppError("SendMail", "utils.mail.send_mail.close.app_error", nil, err.Error(), http.StatusInternalServerError)
+		return model.NewAppError("SendMail", "utils.mail.send_mail.close.app_error", nil, err.Error(), smtpErrorStatusCode(err))
 	}
 
 	return nil
 }
+
+// smtpErrorStatusCode reports permanent SMTP failures, those with a 5xx reply
+// code such as an unknown recipient, as bad requests so that callers can tell
+// them apart from transient failures that are worth retrying.
+func smtpErrorStatusCode(err error) int {
+	var protoErr *textproto.Error
+	if errors.As(err, &protoErr) && protoErr.Code >= 500 {
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+// IsPermanentError reports whether an error returned while sending an email
+// means that retrying to send it will fail again.
+func IsPermanentError(err *model.AppError) bool {
+	return err != nil && err.StatusCode == http.StatusBadRequest
+}
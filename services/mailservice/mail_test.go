@@ -6,6 +6,7 @@ package mailservice
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -17,6 +18,7 @@ import (
 
 	"net/mail"
 	"net/smtp"
+	"net/textproto"
 
 	"github.com/zacmm/zacmm-server/config"
 	"github.com/zacmm/zacmm-server/model"
@@ -378,11 +380,12 @@ func TestAuthMethods(t *testing.T) {
 }
 
 type mockMailer struct {
-	data []byte
+	data    []byte
+	rcptErr error
 }
 
 func (m *mockMailer) Mail(string) error             { return nil }
-func (m *mockMailer) Rcpt(string) error             { return nil }
+func (m *mockMailer) Rcpt(string) error             { return m.rcptErr }
 func (m *mockMailer) Data() (io.WriteCloser, error) { return m, nil }
 func (m *mockMailer) Write(p []byte) (int, error) {
 	m.data = append(m.data, p...)
@@ -434,3 +437,38 @@ func TestSendMail(t *testing.T) {
 		})
 	}
 }
+
+func TestSendMailPermanentError(t *testing.T) {
+	dir, err := ioutil.TempDir(".", "mail-test-")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	settings := model.FileSettings{
+		DriverName: model.NewString(model.IMAGE_DRIVER_LOCAL),
+		Directory:  &dir,
+	}
+	mockBackend, appErr := filesstore.NewFileBackend(&settings, true)
+	require.Nil(t, appErr)
+
+	mail := mailData{"", "", mail.Address{}, "", mail.Address{}, "", "", nil, nil, nil}
+
+	t.Run("rejected recipient should be permanent", func(t *testing.T) {
+		mocm := &mockMailer{rcptErr: &textproto.Error{Code: 550, Msg: "No such user"}}
+		appErr := SendMail(mocm, mail, mockBackend, time.Now())
+		require.NotNil(t, appErr)
+		require.True(t, IsPermanentError(appErr))
+	})
+
+	t.Run("temporary failure should not be permanent", func(t *testing.T) {
+		mocm := &mockMailer{rcptErr: &textproto.Error{Code: 451, Msg: "Try again later"}}
+		appErr := SendMail(mocm, mail, mockBackend, time.Now())
+		require.NotNil(t, appErr)
+		require.False(t, IsPermanentError(appErr))
+	})
+
+	t.Run("connection failure should not be permanent", func(t *testing.T) {
+		mocm := &mockMailer{rcptErr: errors.New("connection reset by peer")}
+		appErr := SendMail(mocm, mail, mockBackend, time.Now())
+		require.NotNil(t, appErr)
+		require.False(t, IsPermanentError(appErr))
+	})
+}
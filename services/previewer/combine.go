@@ -0,0 +1,48 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package previewer
+
+import (
+	"image"
+	"io"
+
+	"github.com/zacmm/zacmm-server/mlog"
+)
+
+type combinePreviewer struct {
+	SubPreviewers []Previewer
+}
+
+func (cp *combinePreviewer) Add(previewer Previewer) {
+	cp.SubPreviewers = append(cp.SubPreviewers, previewer)
+}
+
+func (cp *combinePreviewer) Match(filename string) bool {
+	for _, previewer := range cp.SubPreviewers {
+		if previewer.Match(filename) {
+			return true
+		}
+	}
+	return false
+}
+
+func (cp *combinePreviewer) Preview(filename string, file io.ReadSeeker) (image.Image, error) {
+	for _, previewer := range cp.SubPreviewers {
+		if !previewer.Match(filename) {
+			continue
+		}
+		if _, err := file.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+		img, err := previewer.Preview(filename, file)
+		if err != nil {
+			if err != ErrNoPreview {
+				mlog.Warn("unable to generate file preview", mlog.String("filename", filename), mlog.Err(err))
+			}
+			continue
+		}
+		return img, nil
+	}
+	return nil, ErrNoPreview
+}
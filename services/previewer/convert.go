@@ -0,0 +1,56 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package previewer
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+)
+
+// ErrNoConverter is returned when converting an image without ffmpeg.
+var ErrNoConverter = errors.New("ffmpeg is needed to convert images")
+
+// convertArgs are the ffmpeg encoder arguments of the formats images can be
+// converted to, by extension.
+var convertArgs = map[string][]string{
+	"webp": {"-c:v", "libwebp", "-quality", "80"},
+	"avif": {"-c:v", "libaom-av1", "-still-picture", "1", "-crf", "32", "-cpu-used", "6"},
+}
+
+// CanConvert returns whether images can be converted to the format, given by
+// its extension.
+func CanConvert(format string) bool {
+	_, ok := convertArgs[format]
+	return ok
+}
+
+// Convert converts an image, such as a JPEG preview, to the format given by
+// its extension with ffmpeg.
+func Convert(settings Settings, img io.Reader, format string) ([]byte, error) {
+	args, ok := convertArgs[format]
+	if !ok {
+		return nil, fmt.Errorf("cannot convert images to %q", format)
+	}
+	if settings.FFmpegPath == "" {
+		return nil, ErrNoConverter
+	}
+
+	var converted []byte
+	err := withTempFile(img, func(dir, input string) error {
+		// ffmpeg needs to seek in some of the files it writes.
+		output := filepath.Join(dir, "output."+format)
+		args = append(append([]string{"-v", "error", "-nostdin", "-i", input, "-frames:v", "1"}, args...), output)
+		if _, err := runTool(settings.Timeout, settings.FFmpegPath, args...); err != nil {
+			return err
+		}
+
+		var err error
+		converted, err = ioutil.ReadFile(output)
+		return err
+	})
+	return converted, err
+}
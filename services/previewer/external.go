@@ -0,0 +1,134 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package previewer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+var ffmpegSupportedExtensions = map[string]bool{
+	"3gp":  true,
+	"avi":  true,
+	"m4v":  true,
+	"mkv":  true,
+	"mov":  true,
+	"mp4":  true,
+	"mpeg": true,
+	"mpg":  true,
+	"ogv":  true,
+	"webm": true,
+	"wmv":  true,
+}
+
+// ffmpegPreviewer uses the thumbnail filter of ffmpeg to pick a
+// representative frame among the first ones of a video.
+type ffmpegPreviewer struct {
+	path         string
+	timeout      time.Duration
+	maxImageSize int64
+}
+
+func (fp *ffmpegPreviewer) Match(filename string) bool {
+	return ffmpegSupportedExtensions[extension(filename)]
+}
+
+func (fp *ffmpegPreviewer) Preview(filename string, file io.ReadSeeker) (image.Image, error) {
+	var img image.Image
+	err := withTempFile(file, func(dir, input string) error {
+		output, err := runTool(fp.timeout, fp.path, "-v", "error", "-nostdin", "-i", input, "-vf", "thumbnail", "-frames:v", "1", "-f", "image2pipe", "-c:v", "png", "-")
+		if err != nil {
+			return err
+		}
+		if len(output) == 0 {
+			return ErrNoPreview
+		}
+		img, err = decodeImage(bytes.NewReader(output), fp.maxImageSize)
+		return err
+	})
+	return img, err
+}
+
+// pdftoppmPreviewer renders the first page of a PDF with pdftoppm, from
+// poppler.
+type pdftoppmPreviewer struct {
+	path         string
+	timeout      time.Duration
+	maxImageSize int64
+}
+
+func (pp *pdftoppmPreviewer) Match(filename string) bool {
+	return extension(filename) == "pdf"
+}
+
+func (pp *pdftoppmPreviewer) Preview(filename string, file io.ReadSeeker) (image.Image, error) {
+	var img image.Image
+	err := withTempFile(file, func(dir, input string) error {
+		outputRoot := filepath.Join(dir, "page")
+		if _, err := runTool(pp.timeout, pp.path, "-png", "-f", "1", "-l", "1", "-singlefile", "-scale-to", "2048", input, outputRoot); err != nil {
+			return err
+		}
+		output, err := os.Open(outputRoot + ".png")
+		if err != nil {
+			return err
+		}
+		defer output.Close()
+		img, err = decodeImage(output, pp.maxImageSize)
+		return err
+	})
+	return img, err
+}
+
+// withTempFile copies the file into a temporary directory, as the external
+// programs need to seek in the files they read.
+func withTempFile(file io.Reader, fn func(dir, input string) error) error {
+	dir, err := ioutil.TempDir("", "previewer")
+	if err != nil {
+		return fmt.Errorf("error creating temporary directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	input := filepath.Join(dir, "input")
+	f, err := os.Create(input)
+	if err != nil {
+		return fmt.Errorf("error creating temporary file: %w", err)
+	}
+	_, err = io.Copy(f, file)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("error copying data into temporary file: %w", err)
+	}
+
+	return fn(dir, input)
+}
+
+// runTool runs an external program and returns what it wrote to its standard
+// output, killing it after the timeout.
+func runTool(timeout time.Duration, name string, args ...string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, fmt.Errorf("%s timed out after %v", filepath.Base(name), timeout)
+		}
+		return nil, fmt.Errorf("%s failed: %w: %s", filepath.Base(name), err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
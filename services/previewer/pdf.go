@@ -0,0 +1,168 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package previewer
+
+import (
+	"bytes"
+	"image"
+	"image/jpeg"
+	"io"
+)
+
+const (
+	// pdfMinImageSize is the size under which the images embedded in a PDF,
+	// such as logos or icons, aren't used as its preview.
+	pdfMinImageSize = 64
+	// pdfMaxImageStreamSize bounds the embedded images read from a PDF.
+	pdfMaxImageStreamSize = 16 * 1024 * 1024
+	// pdfMaxDictSize bounds the dictionary of the embedded images, read around
+	// their filter.
+	pdfMaxDictSize = 4096
+	// pdfScanBufferSize is the size of the parts of a PDF scanned at once for
+	// embedded images.
+	pdfScanBufferSize = 1024 * 1024
+)
+
+var pdfJPEGFilter = []byte("/DCTDecode")
+
+// pdfImagePreviewer uses the first JPEG image embedded in a PDF as its
+// preview. It isn't necessarily the first page, but it is for most scanned
+// documents. Rendering the first page of other documents needs pdftoppm.
+type pdfImagePreviewer struct {
+	maxImageSize int64
+}
+
+func (pp *pdfImagePreviewer) Match(filename string) bool {
+	return extension(filename) == "pdf"
+}
+
+func (pp *pdfImagePreviewer) Preview(filename string, file io.ReadSeeker) (image.Image, error) {
+	header := make([]byte, len("%PDF-"))
+	if _, err := io.ReadFull(file, header); err != nil || string(header) != "%PDF-" {
+		return nil, ErrNoPreview
+	}
+
+	filters, err := findAll(file, pdfJPEGFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	// Filters within the content of a stream aren't the ones of an image.
+	var streamEnd int64
+	for _, filter := range filters {
+		if filter < streamEnd {
+			continue
+		}
+
+		stream, end, err := pdfJPEGStream(file, filter)
+		if err != nil {
+			return nil, err
+		}
+		if stream == nil {
+			continue
+		}
+		streamEnd = end
+
+		config, err := jpeg.DecodeConfig(bytes.NewReader(stream))
+		if err != nil || config.Width < pdfMinImageSize || config.Height < pdfMinImageSize {
+			continue
+		}
+		if pp.maxImageSize > 0 && int64(config.Width)*int64(config.Height) > pp.maxImageSize {
+			continue
+		}
+		if img, err := jpeg.Decode(bytes.NewReader(stream)); err == nil {
+			return img, nil
+		}
+	}
+	return nil, ErrNoPreview
+}
+
+// findAll returns the offsets of all the occurrences of sep in the file,
+// which is read a part at a time.
+func findAll(file io.ReadSeeker, sep []byte) ([]int64, error) {
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	var offsets []int64
+	buf := make([]byte, pdfScanBufferSize)
+	// The end of the previous part is kept in case sep spans both.
+	var kept int
+	var bufOffset int64
+	for {
+		n, err := io.ReadFull(file, buf[kept:])
+		n += kept
+		for i := 0; ; {
+			j := bytes.Index(buf[i:n], sep)
+			if j < 0 {
+				break
+			}
+			offsets = append(offsets, bufOffset+int64(i+j))
+			i += j + len(sep)
+		}
+
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return offsets, nil
+		} else if err != nil {
+			return nil, err
+		}
+
+		kept = len(sep) - 1
+		copy(buf, buf[n-kept:n])
+		bufOffset += int64(n - kept)
+	}
+}
+
+// pdfJPEGStream returns the content of the image object whose filter is at
+// the given offset of the file when it is only compressed as JPEG, along with
+// the offset where the content ends. Streams can't be stored in object
+// streams, so they are all found at the top level.
+func pdfJPEGStream(file io.ReadSeeker, filter int64) ([]byte, int64, error) {
+	dictStart := filter - pdfMaxDictSize
+	if dictStart < 0 {
+		dictStart = 0
+	}
+	dict, err := readAt(file, dictStart, int(filter-dictStart)+pdfMaxDictSize)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	filterIndex := int(filter - dictStart)
+	objStart := bytes.LastIndex(dict[:filterIndex], []byte(" obj"))
+	streamStart := bytes.Index(dict[filterIndex:], []byte("stream"))
+	if objStart < 0 || streamStart < 0 {
+		return nil, 0, nil
+	}
+	streamStart += filterIndex
+	dict = dict[objStart:streamStart]
+	if bytes.Contains(dict, []byte("endobj")) || !bytes.Contains(dict, []byte("/Image")) || bytes.Contains(dict, []byte("/FlateDecode")) {
+		return nil, 0, nil
+	}
+
+	contentStart := dictStart + int64(streamStart+len("stream"))
+	content, err := readAt(file, contentStart, pdfMaxImageStreamSize+len("\r\nendstream"))
+	if err != nil {
+		return nil, 0, err
+	}
+	trimmed := bytes.TrimPrefix(content, []byte("\r"))
+	trimmed = bytes.TrimPrefix(trimmed, []byte("\n"))
+	end := bytes.Index(trimmed, []byte("endstream"))
+	if end < 0 {
+		return nil, 0, nil
+	}
+	return trimmed[:end], contentStart + int64(len(content)-len(trimmed)+end), nil
+}
+
+// readAt reads up to size bytes at the given offset of the file.
+func readAt(file io.ReadSeeker, offset int64, size int) ([]byte, error) {
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return nil, err
+	}
+	b := make([]byte, size)
+	n, err := io.ReadFull(file, b)
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		err = nil
+	}
+	return b[:n], err
+}
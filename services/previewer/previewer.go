@@ -0,0 +1,86 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+// Package previewer generates the images representing files that aren't
+// images, such as the first page of a PDF or a frame of a video, to use as
+// their thumbnail and preview. Without pdftoppm, PDFs are only represented by
+// the images they embed, which is their first page for most scanned documents.
+package previewer
+
+import (
+	"errors"
+	"image"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	// The decoders of the images embedded in files.
+	_ "image/jpeg"
+	_ "image/png"
+)
+
+// ErrNoPreview is returned when a file contains nothing to generate a preview
+// from.
+var ErrNoPreview = errors.New("no preview available for the file")
+
+// ErrImageTooLarge is returned when the image to generate a preview from is
+// larger than Settings.MaxImageSize.
+var ErrImageTooLarge = errors.New("the image of the file is too large")
+
+// Settings defines the external programs used to generate previews. Without
+// them, previews are only generated in pure Go for the files that embed an
+// image, such as scanned PDFs or videos with cover art.
+type Settings struct {
+	FFmpegPath   string
+	PdftoppmPath string
+	// Timeout is how long the external programs may run for a file.
+	Timeout time.Duration
+	// MaxImageSize bounds the width times the height of the images decoded,
+	// which is checked before decoding them so that a small file can't
+	// decompress into a huge image.
+	MaxImageSize int64
+}
+
+// Previewer generates the image representing a file.
+type Previewer interface {
+	Match(filename string) bool
+	Preview(filename string, file io.ReadSeeker) (image.Image, error)
+}
+
+// New returns a previewer that tries the external programs, when configured,
+// before falling back to the pure Go previewers.
+func New(settings Settings) Previewer {
+	previewers := &combinePreviewer{}
+	if settings.PdftoppmPath != "" {
+		previewers.Add(&pdftoppmPreviewer{path: settings.PdftoppmPath, timeout: settings.Timeout, maxImageSize: settings.MaxImageSize})
+	}
+	if settings.FFmpegPath != "" {
+		previewers.Add(&ffmpegPreviewer{path: settings.FFmpegPath, timeout: settings.Timeout, maxImageSize: settings.MaxImageSize})
+	}
+	previewers.Add(&pdfImagePreviewer{maxImageSize: settings.MaxImageSize})
+	previewers.Add(&videoPreviewer{maxImageSize: settings.MaxImageSize})
+	return previewers
+}
+
+// decodeImage decodes an image after checking from its header that it isn't
+// larger than maxImageSize, when it is set.
+func decodeImage(r io.ReadSeeker, maxImageSize int64) (image.Image, error) {
+	config, _, err := image.DecodeConfig(r)
+	if err != nil {
+		return nil, err
+	}
+	if maxImageSize > 0 && int64(config.Width)*int64(config.Height) > maxImageSize {
+		return nil, ErrImageTooLarge
+	}
+
+	if _, err = r.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	img, _, err := image.Decode(r)
+	return img, err
+}
+
+func extension(filename string) string {
+	return strings.ToLower(strings.TrimPrefix(path.Ext(filename), "."))
+}
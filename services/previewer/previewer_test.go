@@ -0,0 +1,256 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package previewer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testImage(t *testing.T, width, height int, encode func(*bytes.Buffer, image.Image) error) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for x := 0; x < width; x++ {
+		for y := 0; y < height; y++ {
+			img.Set(x, y, color.RGBA{R: 200, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, encode(&buf, img))
+	return buf.Bytes()
+}
+
+func testJPEG(t *testing.T, width, height int) []byte {
+	return testImage(t, width, height, func(buf *bytes.Buffer, img image.Image) error {
+		return jpeg.Encode(buf, img, nil)
+	})
+}
+
+func testPNG(t *testing.T, width, height int) []byte {
+	return testImage(t, width, height, func(buf *bytes.Buffer, img image.Image) error {
+		return png.Encode(buf, img)
+	})
+}
+
+func testPDF(images ...[]byte) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n1 0 obj\n<< /Type /Catalog /Pages 2 0 R >>\nendobj\n")
+	for i, img := range images {
+		fmt.Fprintf(&buf, "%d 0 obj\n<< /Type /XObject /Subtype /Image /Width 1 /Height 1 /Filter /DCTDecode /Length %d >>\nstream\r\n", i+3, len(img))
+		buf.Write(img)
+		buf.WriteString("\nendstream\nendobj\n")
+	}
+	buf.WriteString("trailer\n<< /Root 1 0 R >>\n%%EOF\n")
+	return buf.Bytes()
+}
+
+func box(boxType string, content ...[]byte) []byte {
+	body := bytes.Join(content, nil)
+	b := make([]byte, 8, 8+len(body))
+	binary.BigEndian.PutUint32(b, uint32(8+len(body)))
+	copy(b[4:], boxType)
+	return append(b, body...)
+}
+
+func testMP4(cover []byte) []byte {
+	meta := box("meta", make([]byte, 4), box("hdlr", make([]byte, 25)), box("ilst",
+		box("covr", box("data", []byte{0, 0, 0, 13, 0, 0, 0, 0}, cover)),
+	))
+	return bytes.Join([][]byte{
+		box("ftyp", []byte("isom"), make([]byte, 4)),
+		box("mdat", make([]byte, 1024)),
+		box("moov", box("mvhd", make([]byte, 100)), box("udta", meta)),
+	}, nil)
+}
+
+func TestPDFPreviewer(t *testing.T) {
+	previewer := &pdfImagePreviewer{}
+	assert.True(t, previewer.Match("scan.PDF"))
+	assert.False(t, previewer.Match("scan.docx"))
+
+	t.Run("scanned document", func(t *testing.T) {
+		data := testPDF(testJPEG(t, 16, 16), testJPEG(t, 120, 160), testJPEG(t, 300, 200))
+		img, err := previewer.Preview("scan.pdf", bytes.NewReader(data))
+		require.NoError(t, err)
+		assert.Equal(t, image.Rect(0, 0, 120, 160), img.Bounds())
+	})
+
+	t.Run("images larger than the maximum", func(t *testing.T) {
+		previewer := &pdfImagePreviewer{maxImageSize: 100 * 100}
+		data := testPDF(testJPEG(t, 300, 200), testJPEG(t, 80, 100))
+		img, err := previewer.Preview("scan.pdf", bytes.NewReader(data))
+		require.NoError(t, err)
+		assert.Equal(t, image.Rect(0, 0, 80, 100), img.Bounds())
+	})
+
+	t.Run("image after the first part scanned", func(t *testing.T) {
+		// The filter of the image spans the first two parts.
+		data := testPDF(testJPEG(t, 120, 160))
+		padding := bytes.Repeat([]byte("%"), pdfScanBufferSize-3-len("%PDF-1.4\n")-bytes.Index(data, pdfJPEGFilter))
+		data = append(append([]byte("%PDF-1.4\n"), padding...), data...)
+		require.Equal(t, pdfScanBufferSize-3, bytes.Index(data, pdfJPEGFilter))
+		img, err := previewer.Preview("scan.pdf", bytes.NewReader(data))
+		require.NoError(t, err)
+		assert.Equal(t, image.Rect(0, 0, 120, 160), img.Bounds())
+	})
+
+	t.Run("document without images", func(t *testing.T) {
+		_, err := previewer.Preview("text.pdf", bytes.NewReader(testPDF()))
+		assert.Equal(t, ErrNoPreview, err)
+	})
+
+	t.Run("not a pdf", func(t *testing.T) {
+		_, err := previewer.Preview("fake.pdf", bytes.NewReader(testJPEG(t, 100, 100)))
+		assert.Equal(t, ErrNoPreview, err)
+	})
+}
+
+func TestVideoPreviewer(t *testing.T) {
+	previewer := &videoPreviewer{}
+	assert.True(t, previewer.Match("clip.mp4"))
+	assert.True(t, previewer.Match("clip.MOV"))
+	assert.False(t, previewer.Match("clip.webm"))
+
+	t.Run("video with cover art", func(t *testing.T) {
+		img, err := previewer.Preview("clip.mp4", bytes.NewReader(testMP4(testPNG(t, 64, 48))))
+		require.NoError(t, err)
+		assert.Equal(t, image.Rect(0, 0, 64, 48), img.Bounds())
+	})
+
+	t.Run("cover art larger than the maximum", func(t *testing.T) {
+		previewer := &videoPreviewer{maxImageSize: 32 * 32}
+		_, err := previewer.Preview("clip.mp4", bytes.NewReader(testMP4(testPNG(t, 64, 48))))
+		assert.Equal(t, ErrImageTooLarge, err)
+	})
+
+	t.Run("video without cover art", func(t *testing.T) {
+		data := bytes.Join([][]byte{box("ftyp", []byte("isom")), box("moov", box("mvhd", make([]byte, 100)))}, nil)
+		_, err := previewer.Preview("clip.mp4", bytes.NewReader(data))
+		assert.Equal(t, ErrNoPreview, err)
+	})
+
+	t.Run("truncated video", func(t *testing.T) {
+		data := testMP4(testPNG(t, 64, 48))
+		_, err := previewer.Preview("clip.mp4", bytes.NewReader(data[:len(data)-10]))
+		assert.Error(t, err)
+	})
+}
+
+// writeFakeTool writes a shell script standing in for an external program.
+func writeFakeTool(t *testing.T, dir, name, script string) string {
+	path := filepath.Join(dir, name)
+	require.NoError(t, ioutil.WriteFile(path, []byte("#!/bin/sh\n"+script+"\n"), 0700))
+	return path
+}
+
+func TestExternalPreviewers(t *testing.T) {
+	dir, err := ioutil.TempDir("", "previewertest")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	frame := filepath.Join(dir, "frame.png")
+	require.NoError(t, ioutil.WriteFile(frame, testPNG(t, 320, 240), 0600))
+
+	settings := Settings{
+		// ffmpeg writes the frame to its standard output.
+		FFmpegPath: writeFakeTool(t, dir, "ffmpeg", "cat "+frame),
+		// pdftoppm writes the page to the file named after its last argument.
+		PdftoppmPath: writeFakeTool(t, dir, "pdftoppm", `for last; do :; done; cp `+frame+` "$last.png"`),
+		Timeout:      10 * time.Second,
+	}
+	previewer := New(settings)
+
+	t.Run("video", func(t *testing.T) {
+		assert.True(t, previewer.Match("clip.webm"))
+		img, err := previewer.Preview("clip.webm", bytes.NewReader([]byte("video")))
+		require.NoError(t, err)
+		assert.Equal(t, image.Rect(0, 0, 320, 240), img.Bounds())
+	})
+
+	t.Run("pdf", func(t *testing.T) {
+		img, err := previewer.Preview("document.pdf", bytes.NewReader(testPDF()))
+		require.NoError(t, err)
+		assert.Equal(t, image.Rect(0, 0, 320, 240), img.Bounds())
+	})
+
+	t.Run("failing program falls back to pure go", func(t *testing.T) {
+		settings.PdftoppmPath = writeFakeTool(t, dir, "failing", "echo broken >&2; exit 1")
+		img, err := New(settings).Preview("scan.pdf", bytes.NewReader(testPDF(testJPEG(t, 100, 80))))
+		require.NoError(t, err)
+		assert.Equal(t, image.Rect(0, 0, 100, 80), img.Bounds())
+	})
+
+	t.Run("frame larger than the maximum", func(t *testing.T) {
+		settings := settings
+		settings.MaxImageSize = 100 * 100
+		_, err := New(settings).Preview("clip.webm", bytes.NewReader([]byte("video")))
+		assert.Equal(t, ErrNoPreview, err)
+	})
+
+	t.Run("program timing out", func(t *testing.T) {
+		_, err := runTool(100*time.Millisecond, writeFakeTool(t, dir, "slow", "exec sleep 5"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "timed out")
+	})
+}
+
+func TestPreviewerWithoutPrograms(t *testing.T) {
+	previewer := New(Settings{})
+	assert.True(t, previewer.Match("scan.pdf"))
+	assert.True(t, previewer.Match("clip.mp4"))
+	assert.False(t, previewer.Match("clip.webm"))
+	assert.False(t, previewer.Match("notes.txt"))
+
+	_, err := previewer.Preview("text.pdf", bytes.NewReader(testPDF()))
+	assert.Equal(t, ErrNoPreview, err)
+}
+
+func TestConvert(t *testing.T) {
+	dir, err := ioutil.TempDir("", "previewertest")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// ffmpeg writes the converted image to the file named by its last argument.
+	settings := Settings{
+		FFmpegPath: writeFakeTool(t, dir, "ffmpeg", `for last; do :; done; case "$last" in *.webp) printf webp > "$last";; *) exit 1;; esac`),
+		Timeout:    10 * time.Second,
+	}
+
+	assert.True(t, CanConvert("webp"))
+	assert.True(t, CanConvert("avif"))
+	assert.False(t, CanConvert("gif"))
+
+	t.Run("supported format", func(t *testing.T) {
+		converted, err := Convert(settings, bytes.NewReader(testJPEG(t, 10, 10)), "webp")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("webp"), converted)
+	})
+
+	t.Run("failing conversion", func(t *testing.T) {
+		_, err := Convert(settings, bytes.NewReader(testJPEG(t, 10, 10)), "avif")
+		assert.Error(t, err)
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		_, err := Convert(settings, bytes.NewReader(testJPEG(t, 10, 10)), "gif")
+		assert.Error(t, err)
+	})
+
+	t.Run("without ffmpeg", func(t *testing.T) {
+		_, err := Convert(Settings{}, bytes.NewReader(testJPEG(t, 10, 10)), "webp")
+		assert.Equal(t, ErrNoConverter, err)
+	})
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package previewer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"io"
+)
+
+// videoMaxCoverSize bounds the cover art read from a video.
+const videoMaxCoverSize = 16 * 1024 * 1024
+
+var videoSupportedExtensions = map[string]bool{
+	"3gp": true,
+	"m4v": true,
+	"mov": true,
+	"mp4": true,
+}
+
+var errInvalidBox = errors.New("invalid ISO base media file box")
+
+// videoPreviewer uses the cover art stored in the metadata of MP4 and
+// QuickTime videos, as iTunes does, as their poster frame. Decoding the
+// frames of a video needs ffmpeg.
+type videoPreviewer struct {
+	maxImageSize int64
+}
+
+func (vp *videoPreviewer) Match(filename string) bool {
+	return videoSupportedExtensions[extension(filename)]
+}
+
+func (vp *videoPreviewer) Preview(filename string, file io.ReadSeeker) (image.Image, error) {
+	end, err := file.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	// The cover art is at moov/udta/meta/ilst/covr/data.
+	start := int64(0)
+	for _, boxType := range []string{"moov", "udta", "meta", "ilst", "covr", "data"} {
+		start, end, err = findBox(file, start, end, boxType)
+		if err != nil {
+			return nil, err
+		}
+		if boxType == "meta" {
+			// meta is a full box with a version and flags in MP4 files, but
+			// not in QuickTime ones, where its first child is hdlr.
+			var header [8]byte
+			if _, err = file.Seek(start, io.SeekStart); err != nil {
+				return nil, err
+			}
+			if _, err = io.ReadFull(file, header[:]); err != nil {
+				return nil, ErrNoPreview
+			}
+			if string(header[4:8]) != "hdlr" {
+				start += 4
+			}
+		}
+	}
+
+	// The data box starts with the type of its content and a locale.
+	start += 8
+	if end-start <= 0 || end-start > videoMaxCoverSize {
+		return nil, ErrNoPreview
+	}
+	if _, err = file.Seek(start, io.SeekStart); err != nil {
+		return nil, err
+	}
+	cover := make([]byte, end-start)
+	if _, err = io.ReadFull(file, cover); err != nil {
+		return nil, err
+	}
+
+	img, err := decodeImage(bytes.NewReader(cover), vp.maxImageSize)
+	if err == ErrImageTooLarge {
+		return nil, err
+	} else if err != nil {
+		return nil, ErrNoPreview
+	}
+	return img, nil
+}
+
+// findBox returns the bounds of the content of the first box of the given
+// type between start and end.
+func findBox(file io.ReadSeeker, start, end int64, boxType string) (int64, int64, error) {
+	for offset := start; offset+8 <= end; {
+		if _, err := file.Seek(offset, io.SeekStart); err != nil {
+			return 0, 0, err
+		}
+		var header [16]byte
+		if _, err := io.ReadFull(file, header[:8]); err != nil {
+			return 0, 0, errInvalidBox
+		}
+
+		size := int64(binary.BigEndian.Uint32(header[:4]))
+		headerSize := int64(8)
+		switch size {
+		case 0:
+			size = end - offset
+		case 1:
+			if _, err := io.ReadFull(file, header[8:16]); err != nil {
+				return 0, 0, errInvalidBox
+			}
+			size = int64(binary.BigEndian.Uint64(header[8:16]))
+			headerSize = 16
+		}
+		if size < headerSize || offset+size > end {
+			return 0, 0, errInvalidBox
+		}
+
+		if string(header[4:8]) == boxType {
+			return offset + headerSize, offset + size, nil
+		}
+		offset += size
+	}
+	return 0, 0, ErrNoPreview
+}
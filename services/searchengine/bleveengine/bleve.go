@@ -26,12 +26,14 @@ const (
 	POST_INDEX    = "posts"
 	USER_INDEX    = "users"
 	CHANNEL_INDEX = "channels"
+	FILE_INDEX    = "files"
 )
 
 type BleveEngine struct {
 	PostIndex    bleve.Index
 	UserIndex    bleve.Index
 	ChannelIndex bleve.Index
+	FileIndex    bleve.Index
 	Mutex        sync.RWMutex
 	ready        int32
 	cfg          *model.Config
@@ -42,6 +44,7 @@ type BleveEngine struct {
 var keywordMapping *mapping.FieldMapping
 var standardMapping *mapping.FieldMapping
 var dateMapping *mapping.FieldMapping
+var booleanMapping *mapping.FieldMapping
 
 func init() {
 	keywordMapping = bleve.NewTextFieldMapping()
@@ -51,6 +54,8 @@ func init() {
 	standardMapping.Analyzer = standard.Name
 
 	dateMapping = bleve.NewNumericFieldMapping()
+
+	booleanMapping = bleve.NewBooleanFieldMapping()
 }
 
 func getChannelIndexMapping() *mapping.IndexMappingImpl {
@@ -71,11 +76,16 @@ func getPostIndexMapping() *mapping.IndexMappingImpl {
 	postMapping.AddFieldMappingsAt("TeamId", keywordMapping)
 	postMapping.AddFieldMappingsAt("ChannelId", keywordMapping)
 	postMapping.AddFieldMappingsAt("UserId", keywordMapping)
+	postMapping.AddFieldMappingsAt("RootId", keywordMapping)
 	postMapping.AddFieldMappingsAt("CreateAt", dateMapping)
 	postMapping.AddFieldMappingsAt("Message", standardMapping)
 	postMapping.AddFieldMappingsAt("Type", keywordMapping)
 	postMapping.AddFieldMappingsAt("Hashtags", standardMapping)
 	postMapping.AddFieldMappingsAt("Attachments", standardMapping)
+	postMapping.AddFieldMappingsAt("IsPinned", booleanMapping)
+	postMapping.AddFieldMappingsAt("HasFiles", booleanMapping)
+	postMapping.AddFieldMappingsAt("HasLinks", booleanMapping)
+	postMapping.AddFieldMappingsAt("HasReactions", booleanMapping)
 
 	indexMapping := bleve.NewIndexMapping()
 	indexMapping.AddDocumentMapping("_default", postMapping)
@@ -97,6 +107,23 @@ func getUserIndexMapping() *mapping.IndexMappingImpl {
 	return indexMapping
 }
 
+func getFileIndexMapping() *mapping.IndexMappingImpl {
+	fileMapping := bleve.NewDocumentMapping()
+	fileMapping.AddFieldMappingsAt("Id", keywordMapping)
+	fileMapping.AddFieldMappingsAt("CreatorId", keywordMapping)
+	fileMapping.AddFieldMappingsAt("ChannelId", keywordMapping)
+	fileMapping.AddFieldMappingsAt("PostId", keywordMapping)
+	fileMapping.AddFieldMappingsAt("CreateAt", dateMapping)
+	fileMapping.AddFieldMappingsAt("Name", standardMapping)
+	fileMapping.AddFieldMappingsAt("Content", standardMapping)
+	fileMapping.AddFieldMappingsAt("Extension", keywordMapping)
+
+	indexMapping := bleve.NewIndexMapping()
+	indexMapping.AddDocumentMapping("_default", fileMapping)
+
+	return indexMapping
+}
+
 func NewBleveEngine(cfg *model.Config, jobServer *jobs.JobServer) *BleveEngine {
 	return &BleveEngine{
 		cfg:       cfg,
@@ -142,6 +169,11 @@ func (b *BleveEngine) openIndexes() *model.AppError {
 		return model.NewAppError("Bleveengine.Start", "bleveengine.create_channel_index.error", nil, err.Error(), http.StatusInternalServerError)
 	}
 
+	b.FileIndex, err = b.createOrOpenIndex(FILE_INDEX, getFileIndexMapping())
+	if err != nil {
+		return model.NewAppError("Bleveengine.Start", "bleveengine.create_file_index.error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
 	atomic.StoreInt32(&b.ready, 1)
 	return nil
 }
@@ -172,6 +204,10 @@ func (b *BleveEngine) closeIndexes() *model.AppError {
 		if err := b.ChannelIndex.Close(); err != nil {
 			return model.NewAppError("Bleveengine.Stop", "bleveengine.stop_channel_index.error", nil, err.Error(), http.StatusInternalServerError)
 		}
+
+		if err := b.FileIndex.Close(); err != nil {
+			return model.NewAppError("Bleveengine.Stop", "bleveengine.stop_file_index.error", nil, err.Error(), http.StatusInternalServerError)
+		}
 	}
 
 	atomic.StoreInt32(&b.ready, 0)
@@ -221,6 +257,9 @@ func (b *BleveEngine) deleteIndexes() *model.AppError {
 	if err := os.RemoveAll(b.getIndexDir(CHANNEL_INDEX)); err != nil {
 		return model.NewAppError("Bleveengine.PurgeIndexes", "bleveengine.purge_channel_index.error", nil, err.Error(), http.StatusInternalServerError)
 	}
+	if err := os.RemoveAll(b.getIndexDir(FILE_INDEX)); err != nil {
+		return model.NewAppError("Bleveengine.PurgeIndexes", "bleveengine.purge_file_index.error", nil, err.Error(), http.StatusInternalServerError)
+	}
 	return nil
 }
 
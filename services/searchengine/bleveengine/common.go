@@ -25,15 +25,31 @@ type BLVUser struct {
 }
 
 type BLVPost struct {
-	Id          string
-	TeamId      string
-	ChannelId   string
-	UserId      string
-	CreateAt    int64
-	Message     string
-	Type        string
-	Hashtags    []string
-	Attachments string
+	Id           string
+	TeamId       string
+	ChannelId    string
+	UserId       string
+	RootId       string
+	CreateAt     int64
+	Message      string
+	Type         string
+	Hashtags     []string
+	Attachments  string
+	IsPinned     bool
+	HasFiles     bool
+	HasLinks     bool
+	HasReactions bool
+}
+
+type BLVFile struct {
+	Id        string
+	CreatorId string
+	ChannelId string
+	PostId    string
+	CreateAt  int64
+	Name      string
+	Content   string
+	Extension string
 }
 
 func BLVChannelFromChannel(channel *model.Channel) *BLVChannel {
@@ -104,13 +120,35 @@ func BLVPostFromPost(post *model.Post, teamId string) *BLVPost {
 
 func BLVPostFromPostForIndexing(post *model.PostForIndexing) *BLVPost {
 	return &BLVPost{
-		Id:        post.Id,
-		TeamId:    post.TeamId,
-		ChannelId: post.ChannelId,
-		UserId:    post.UserId,
-		CreateAt:  post.CreateAt,
-		Message:   post.Message,
-		Type:      post.Type,
-		Hashtags:  strings.Fields(post.Hashtags),
+		Id:           post.Id,
+		TeamId:       post.TeamId,
+		ChannelId:    post.ChannelId,
+		UserId:       post.UserId,
+		RootId:       post.RootId,
+		CreateAt:     post.CreateAt,
+		Message:      post.Message,
+		Type:         post.Type,
+		Hashtags:     strings.Fields(post.Hashtags),
+		IsPinned:     post.IsPinned,
+		HasFiles:     len(post.FileIds) > 0,
+		HasLinks:     strings.Contains(post.Message, "http://") || strings.Contains(post.Message, "https://"),
+		HasReactions: post.HasReactions,
+	}
+}
+
+func BLVFileFromFileInfo(file *model.FileInfo, channelId string) *BLVFile {
+	return &BLVFile{
+		Id:        file.Id,
+		CreatorId: file.CreatorId,
+		ChannelId: channelId,
+		PostId:    file.PostId,
+		CreateAt:  file.CreateAt,
+		Name:      file.Name,
+		Content:   file.Content,
+		Extension: file.Extension,
 	}
 }
+
+func BLVFileFromFileForIndexing(file *model.FileForIndexing) *BLVFile {
+	return BLVFileFromFileInfo(&file.FileInfo, file.ChannelId)
+}
@@ -23,6 +23,7 @@ const (
 	ESTIMATED_POST_COUNT    = 10000000
 	ESTIMATED_CHANNEL_COUNT = 100000
 	ESTIMATED_USER_COUNT    = 10000
+	ESTIMATED_FILE_COUNT    = 100000
 )
 
 func init() {
@@ -74,14 +75,17 @@ type IndexingProgress struct {
 	TotalUsersCount    int64
 	DoneUsersCount     int64
 	DoneUsers          bool
+	TotalFilesCount    int64
+	DoneFilesCount     int64
+	DoneFiles          bool
 }
 
 func (ip *IndexingProgress) CurrentProgress() int64 {
-	return (ip.DonePostsCount + ip.DoneChannelsCount + ip.DoneUsersCount) * 100 / (ip.TotalPostsCount + ip.TotalChannelsCount + ip.TotalUsersCount)
+	return (ip.DonePostsCount + ip.DoneChannelsCount + ip.DoneUsersCount + ip.DoneFilesCount) * 100 / (ip.TotalPostsCount + ip.TotalChannelsCount + ip.TotalUsersCount + ip.TotalFilesCount)
 }
 
 func (ip *IndexingProgress) IsDone() bool {
-	return ip.DonePosts && ip.DoneChannels && ip.DoneUsers
+	return ip.DonePosts && ip.DoneChannels && ip.DoneUsers && ip.DoneFiles
 }
 
 func (worker *BleveIndexerWorker) JobChannel() chan<- model.Job {
@@ -139,6 +143,7 @@ func (worker *BleveIndexerWorker) DoJob(job *model.Job) {
 		DonePosts:    false,
 		DoneChannels: false,
 		DoneUsers:    false,
+		DoneFiles:    false,
 		StartAtTime:  0,
 		EndAtTime:    model.GetMillis(),
 	}
@@ -209,6 +214,9 @@ func (worker *BleveIndexerWorker) DoJob(job *model.Job) {
 		progress.TotalUsersCount = count
 	}
 
+	// There is no cheap way to count the files, so an estimate is used for progress reporting.
+	progress.TotalFilesCount = ESTIMATED_FILE_COUNT
+
 	cancelCtx, cancelCancelWatcher := context.WithCancel(context.Background())
 	cancelWatcherChan := make(chan interface{}, 1)
 	go worker.jobServer.CancellationWatcher(cancelCtx, job.Id, cancelWatcherChan)
@@ -273,6 +281,9 @@ func (worker *BleveIndexerWorker) IndexBatch(progress IndexingProgress) (Indexin
 	if !progress.DoneUsers {
 		return worker.IndexUsersBatch(progress)
 	}
+	if !progress.DoneFiles {
+		return worker.IndexFilesBatch(progress)
+	}
 	return progress, model.NewAppError("BleveIndexerWorker", "bleveengine.indexer.index_batch.nothing_left_to_index.error", nil, "", http.StatusInternalServerError)
 }
 
@@ -511,3 +522,83 @@ func (worker *BleveIndexerWorker) BulkIndexUsers(users []*model.UserForIndexing,
 	}
 	return lastCreateAt, nil
 }
+
+func (worker *BleveIndexerWorker) IndexFilesBatch(progress IndexingProgress) (IndexingProgress, *model.AppError) {
+	endTime := progress.LastEntityTime + int64(*worker.jobServer.Config().BleveSettings.BulkIndexingTimeWindowSeconds*1000)
+
+	var files []*model.FileForIndexing
+
+	tries := 0
+	for files == nil {
+		var err error
+		files, err = worker.jobServer.Store.FileInfo().GetFilesBatchForIndexing(progress.LastEntityTime, endTime, BATCH_SIZE)
+		if err != nil {
+			if tries >= 10 {
+				return progress, model.NewAppError("IndexFilesBatch", "app.file_info.get_files_batch_for_indexing.get.app_error", nil, err.Error(), http.StatusInternalServerError)
+			}
+
+			mlog.Warn("Failed to get files batch for indexing. Retrying.", mlog.Err(err))
+
+			// Wait a bit before trying again.
+			time.Sleep(15 * time.Second)
+		}
+
+		tries++
+	}
+
+	newLastFileTime, err := worker.BulkIndexFiles(files, progress)
+	if err != nil {
+		return progress, err
+	}
+
+	// Due to the "endTime" parameter in the store query, we might get an incomplete batch before the end. In this
+	// case, set the "newLastFileTime" to the endTime so we don't get stuck running the same query in a loop.
+	if len(files) < BATCH_SIZE {
+		newLastFileTime = endTime
+	}
+
+	// When to Stop: we index either until we pass a batch of files where the last
+	// file is created at or after the specified end time when setting up the batch
+	// index, or until two consecutive full batches have the same end time of their final
+	// files. This second case is safe as long as the assumption that the database
+	// cannot contain more files with the same CreateAt time than the batch size holds.
+	if progress.EndAtTime <= newLastFileTime {
+		progress.DoneFiles = true
+		progress.LastEntityTime = progress.StartAtTime
+	} else if progress.LastEntityTime == newLastFileTime && len(files) == BATCH_SIZE {
+		mlog.Error("More files with the same CreateAt time were detected than the permitted batch size. Aborting indexing job.", mlog.Int64("CreateAt", newLastFileTime), mlog.Int("Batch Size", BATCH_SIZE))
+		progress.DoneFiles = true
+		progress.LastEntityTime = progress.StartAtTime
+	} else {
+		progress.LastEntityTime = newLastFileTime
+	}
+
+	progress.DoneFilesCount += int64(len(files))
+
+	return progress, nil
+}
+
+func (worker *BleveIndexerWorker) BulkIndexFiles(files []*model.FileForIndexing, progress IndexingProgress) (int64, *model.AppError) {
+	lastCreateAt := int64(0)
+	batch := worker.engine.FileIndex.NewBatch()
+
+	for _, file := range files {
+		// Files that are not attached to a post can't be searched yet
+		if file.DeleteAt == 0 && file.ChannelId != "" {
+			searchFile := bleveengine.BLVFileFromFileForIndexing(file)
+			batch.Index(searchFile.Id, searchFile)
+		} else {
+			batch.Delete(file.Id)
+		}
+
+		lastCreateAt = file.CreateAt
+	}
+
+	worker.engine.Mutex.RLock()
+	defer worker.engine.Mutex.RUnlock()
+
+	if err := worker.engine.FileIndex.Batch(batch); err != nil {
+		return 0, model.NewAppError("BleveIndexerWorker.BulkIndexFiles", "bleveengine.indexer.do_job.bulk_index_files.batch_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	return lastCreateAt, nil
+}
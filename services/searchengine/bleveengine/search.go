@@ -5,6 +5,7 @@ package bleveengine
 
 import (
 	"net/http"
+	"regexp"
 	"strings"
 
 	"github.com/zacmm/zacmm-server/mlog"
@@ -16,6 +17,55 @@ import (
 
 const DELETE_POSTS_BATCH_SIZE = 500
 
+var searchPhraseRegexp = regexp.MustCompile(`"[^"]+"`)
+
+// The indexed fields that the has: and is: search filters match against.
+// The filters missing here depend on the searching user and are resolved
+// into post ids before the search.
+var searchFilterFields = map[string]string{
+	model.SEARCH_HAS_FILE:     "HasFiles",
+	model.SEARCH_HAS_LINK:     "HasLinks",
+	model.SEARCH_HAS_REACTION: "HasReactions",
+	model.SEARCH_IS_PINNED:    "IsPinned",
+}
+
+func getSearchFilterQueries(filterValues []string) []query.Query {
+	filterQueries := []query.Query{}
+	for _, value := range filterValues {
+		if field, ok := searchFilterFields[value]; ok {
+			filterQ := bleve.NewBoolFieldQuery(true)
+			filterQ.SetField(field)
+			filterQueries = append(filterQueries, filterQ)
+		}
+	}
+	return filterQueries
+}
+
+func getTermsQuery(field string, values []string) query.Query {
+	termQueries := []query.Query{}
+	for _, value := range values {
+		termQ := bleve.NewTermQuery(value)
+		termQ.SetField(field)
+		termQueries = append(termQueries, termQ)
+	}
+	return bleve.NewDisjunctionQuery(termQueries...)
+}
+
+// getThreadsQuery matches the root posts of the threads and their replies.
+func getThreadsQuery(threadIds []string) query.Query {
+	return bleve.NewDisjunctionQuery(getTermsQuery("Id", threadIds), getTermsQuery("RootId", threadIds))
+}
+
+// splitSearchPhrases separates the phrases in quotes from the rest of the
+// terms, so that the phrases can match the exact order of their words.
+func splitSearchPhrases(terms string) ([]string, string) {
+	phrases := []string{}
+	for _, phrase := range searchPhraseRegexp.FindAllString(terms, -1) {
+		phrases = append(phrases, strings.Trim(phrase, `"`))
+	}
+	return phrases, strings.TrimSpace(searchPhraseRegexp.ReplaceAllString(terms, ""))
+}
+
 func (b *BleveEngine) IndexPost(post *model.Post, teamId string) *model.AppError {
 	b.Mutex.RLock()
 	defer b.Mutex.RUnlock()
@@ -95,6 +145,27 @@ func (b *BleveEngine) SearchPosts(channels *model.ChannelList, searchParams []*m
 				notFilters = append(notFilters, bleve.NewDisjunctionQuery(excludedUsers...))
 			}
 
+			filters = append(filters, getSearchFilterQueries(params.HasFilters)...)
+			filters = append(filters, getSearchFilterQueries(params.IsFilters)...)
+			notFilters = append(notFilters, getSearchFilterQueries(params.ExcludedHasFilters)...)
+			notFilters = append(notFilters, getSearchFilterQueries(params.ExcludedIsFilters)...)
+
+			if len(params.PostIds) > 0 {
+				filters = append(filters, getTermsQuery("Id", params.PostIds))
+			}
+
+			if len(params.ExcludedPostIds) > 0 {
+				notFilters = append(notFilters, getTermsQuery("Id", params.ExcludedPostIds))
+			}
+
+			if len(params.ThreadIds) > 0 {
+				filters = append(filters, getThreadsQuery(params.ThreadIds))
+			}
+
+			if len(params.ExcludedThreadIds) > 0 {
+				notFilters = append(notFilters, getThreadsQuery(params.ExcludedThreadIds))
+			}
+
 			if params.OnDate != "" {
 				before, after := params.GetOnDateMillis()
 				beforeFloat64 := float64(before)
@@ -159,8 +230,15 @@ func (b *BleveEngine) SearchPosts(channels *model.ChannelList, searchParams []*m
 			}
 		} else {
 			if len(params.Terms) > 0 {
+				phrases, remainingTerms := splitSearchPhrases(params.Terms)
+				for _, phrase := range phrases {
+					phraseQ := bleve.NewMatchPhraseQuery(phrase)
+					phraseQ.SetField("Message")
+					termQueries = append(termQueries, phraseQ)
+				}
+
 				terms := []string{}
-				for _, term := range strings.Split(params.Terms, " ") {
+				for _, term := range strings.Fields(remainingTerms) {
 					if strings.HasSuffix(term, "*") {
 						messageQ := bleve.NewWildcardQuery(term)
 						messageQ.SetField("Message")
@@ -179,10 +257,19 @@ func (b *BleveEngine) SearchPosts(channels *model.ChannelList, searchParams []*m
 			}
 
 			if len(params.ExcludedTerms) > 0 {
-				messageQ := bleve.NewMatchQuery(params.ExcludedTerms)
-				messageQ.SetField("Message")
-				messageQ.SetOperator(termOperator)
-				notTermQueries = append(notTermQueries, messageQ)
+				phrases, remainingTerms := splitSearchPhrases(params.ExcludedTerms)
+				for _, phrase := range phrases {
+					phraseQ := bleve.NewMatchPhraseQuery(phrase)
+					phraseQ.SetField("Message")
+					notTermQueries = append(notTermQueries, phraseQ)
+				}
+
+				if remainingTerms != "" {
+					messageQ := bleve.NewMatchQuery(remainingTerms)
+					messageQ.SetField("Message")
+					messageQ.SetOperator(termOperator)
+					notTermQueries = append(notTermQueries, messageQ)
+				}
 			}
 		}
 	}
@@ -507,3 +594,261 @@ func (b *BleveEngine) DeleteUser(user *model.User) *model.AppError {
 	}
 	return nil
 }
+
+func (b *BleveEngine) IndexFile(file *model.FileInfo, channelId string) *model.AppError {
+	b.Mutex.RLock()
+	defer b.Mutex.RUnlock()
+
+	blvFile := BLVFileFromFileInfo(file, channelId)
+	if err := b.FileIndex.Index(blvFile.Id, blvFile); err != nil {
+		return model.NewAppError("Bleveengine.IndexFile", "bleveengine.index_file.error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	return nil
+}
+
+// newTermsDisjunctionQuery matches documents whose field equals any of the terms.
+func newTermsDisjunctionQuery(field string, terms []string) query.Query {
+	queries := []query.Query{}
+	for _, term := range terms {
+		termQ := bleve.NewTermQuery(term)
+		termQ.SetField(field)
+		queries = append(queries, termQ)
+	}
+	return bleve.NewDisjunctionQuery(queries...)
+}
+
+// newFileTermsQuery matches files whose name or content match the terms.
+func newFileTermsQuery(terms string, operator query.MatchQueryOperator) query.Query {
+	queries := []query.Query{}
+	for _, field := range []string{"Name", "Content"} {
+		matchTerms := []string{}
+		for _, term := range strings.Fields(terms) {
+			if strings.HasSuffix(term, "*") {
+				wildcardQ := bleve.NewWildcardQuery(strings.ToLower(term))
+				wildcardQ.SetField(field)
+				queries = append(queries, wildcardQ)
+			} else {
+				matchTerms = append(matchTerms, term)
+			}
+		}
+
+		if len(matchTerms) > 0 {
+			matchQ := bleve.NewMatchQuery(strings.Join(matchTerms, " "))
+			matchQ.SetField(field)
+			matchQ.SetOperator(operator)
+			queries = append(queries, matchQ)
+		}
+	}
+	return bleve.NewDisjunctionQuery(queries...)
+}
+
+func (b *BleveEngine) SearchFiles(channels *model.ChannelList, searchParams []*model.SearchParams, page, perPage int) ([]string, *model.AppError) {
+	channelIds := []string{}
+	for _, channel := range *channels {
+		channelIds = append(channelIds, channel.Id)
+	}
+
+	var termQueries []query.Query
+	var notTermQueries []query.Query
+	var filters []query.Query
+	var notFilters []query.Query
+
+	for i, params := range searchParams {
+		var termOperator query.MatchQueryOperator = query.MatchQueryOperatorAnd
+		if searchParams[0].OrTerms {
+			termOperator = query.MatchQueryOperatorOr
+		}
+
+		// Date, channel, user and extension filters come in all
+		// searchParams iteration, and as they are global to the
+		// query, we only need to process them once
+		if i == 0 {
+			if len(params.InChannels) > 0 {
+				filters = append(filters, newTermsDisjunctionQuery("ChannelId", params.InChannels))
+			}
+			if len(params.ExcludedChannels) > 0 {
+				notFilters = append(notFilters, newTermsDisjunctionQuery("ChannelId", params.ExcludedChannels))
+			}
+			if len(params.FromUsers) > 0 {
+				filters = append(filters, newTermsDisjunctionQuery("CreatorId", params.FromUsers))
+			}
+			if len(params.ExcludedUsers) > 0 {
+				notFilters = append(notFilters, newTermsDisjunctionQuery("CreatorId", params.ExcludedUsers))
+			}
+			if len(params.Extensions) > 0 {
+				filters = append(filters, newTermsDisjunctionQuery("Extension", params.Extensions))
+			}
+			if len(params.ExcludedExtensions) > 0 {
+				notFilters = append(notFilters, newTermsDisjunctionQuery("Extension", params.ExcludedExtensions))
+			}
+
+			if params.OnDate != "" {
+				before, after := params.GetOnDateMillis()
+				beforeFloat64 := float64(before)
+				afterFloat64 := float64(after)
+				onDateQ := bleve.NewNumericRangeQuery(&beforeFloat64, &afterFloat64)
+				onDateQ.SetField("CreateAt")
+				filters = append(filters, onDateQ)
+			} else {
+				if params.AfterDate != "" || params.BeforeDate != "" {
+					var min, max *float64
+					if params.AfterDate != "" {
+						minf := float64(params.GetAfterDateMillis())
+						min = &minf
+					}
+
+					if params.BeforeDate != "" {
+						maxf := float64(params.GetBeforeDateMillis())
+						max = &maxf
+					}
+
+					dateQ := bleve.NewNumericRangeQuery(min, max)
+					dateQ.SetField("CreateAt")
+					filters = append(filters, dateQ)
+				}
+
+				if params.ExcludedAfterDate != "" {
+					minf := float64(params.GetExcludedAfterDateMillis())
+					dateQ := bleve.NewNumericRangeQuery(&minf, nil)
+					dateQ.SetField("CreateAt")
+					notFilters = append(notFilters, dateQ)
+				}
+
+				if params.ExcludedBeforeDate != "" {
+					maxf := float64(params.GetExcludedBeforeDateMillis())
+					dateQ := bleve.NewNumericRangeQuery(nil, &maxf)
+					dateQ.SetField("CreateAt")
+					notFilters = append(notFilters, dateQ)
+				}
+
+				if params.ExcludedDate != "" {
+					before, after := params.GetExcludedDateMillis()
+					beforef := float64(before)
+					afterf := float64(after)
+					onDateQ := bleve.NewNumericRangeQuery(&beforef, &afterf)
+					onDateQ.SetField("CreateAt")
+					notFilters = append(notFilters, onDateQ)
+				}
+			}
+		}
+
+		if params.Terms != "" {
+			termQueries = append(termQueries, newFileTermsQuery(params.Terms, termOperator))
+		}
+		if params.ExcludedTerms != "" {
+			notTermQueries = append(notTermQueries, newFileTermsQuery(params.ExcludedTerms, query.MatchQueryOperatorOr))
+		}
+	}
+
+	allTermsQ := bleve.NewBooleanQuery()
+	allTermsQ.AddMustNot(notTermQueries...)
+	if searchParams[0].OrTerms {
+		allTermsQ.AddShould(termQueries...)
+	} else {
+		allTermsQ.AddMust(termQueries...)
+	}
+
+	query := bleve.NewBooleanQuery()
+	query.AddMust(newTermsDisjunctionQuery("ChannelId", channelIds))
+
+	if len(termQueries) > 0 || len(notTermQueries) > 0 {
+		query.AddMust(allTermsQ)
+	}
+
+	if len(filters) > 0 {
+		query.AddMust(bleve.NewConjunctionQuery(filters...))
+	}
+	if len(notFilters) > 0 {
+		query.AddMustNot(notFilters...)
+	}
+
+	search := bleve.NewSearchRequestOptions(query, perPage, page*perPage, false)
+	search.SortBy([]string{"-CreateAt"})
+	results, err := b.FileIndex.Search(search)
+	if err != nil {
+		return nil, model.NewAppError("Bleveengine.SearchFiles", "bleveengine.search_files.error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	fileIds := []string{}
+	for _, r := range results.Hits {
+		fileIds = append(fileIds, r.ID)
+	}
+
+	return fileIds, nil
+}
+
+func (b *BleveEngine) deleteFiles(searchRequest *bleve.SearchRequest, batchSize int) (int64, error) {
+	resultsCount := int64(0)
+
+	for {
+		// As we are deleting the files after fetching them, we need to keep
+		// From fixed always to 0
+		searchRequest.From = 0
+		searchRequest.Size = batchSize
+		results, err := b.FileIndex.Search(searchRequest)
+		if err != nil {
+			return -1, err
+		}
+		batch := b.FileIndex.NewBatch()
+		for _, file := range results.Hits {
+			batch.Delete(file.ID)
+		}
+		if err := b.FileIndex.Batch(batch); err != nil {
+			return -1, err
+		}
+		resultsCount += int64(results.Hits.Len())
+		if results.Hits.Len() < batchSize {
+			break
+		}
+	}
+
+	return resultsCount, nil
+}
+
+func (b *BleveEngine) DeleteFile(fileID string) *model.AppError {
+	b.Mutex.RLock()
+	defer b.Mutex.RUnlock()
+
+	if err := b.FileIndex.Delete(fileID); err != nil {
+		return model.NewAppError("Bleveengine.DeleteFile", "bleveengine.delete_file.error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	return nil
+}
+
+func (b *BleveEngine) DeletePostFiles(postID string) *model.AppError {
+	b.Mutex.RLock()
+	defer b.Mutex.RUnlock()
+
+	query := bleve.NewTermQuery(postID)
+	query.SetField("PostId")
+	search := bleve.NewSearchRequest(query)
+	deleted, err := b.deleteFiles(search, DELETE_POSTS_BATCH_SIZE)
+	if err != nil {
+		return model.NewAppError("Bleveengine.DeletePostFiles",
+			"bleveengine.delete_post_files.error", nil,
+			err.Error(), http.StatusInternalServerError)
+	}
+
+	mlog.Info("Files for post deleted", mlog.String("post_id", postID), mlog.Int64("deleted", deleted))
+
+	return nil
+}
+
+func (b *BleveEngine) DeleteUserFiles(userID string) *model.AppError {
+	b.Mutex.RLock()
+	defer b.Mutex.RUnlock()
+
+	query := bleve.NewTermQuery(userID)
+	query.SetField("CreatorId")
+	search := bleve.NewSearchRequest(query)
+	deleted, err := b.deleteFiles(search, DELETE_POSTS_BATCH_SIZE)
+	if err != nil {
+		return model.NewAppError("Bleveengine.DeleteUserFiles",
+			"bleveengine.delete_user_files.error", nil,
+			err.Error(), http.StatusInternalServerError)
+	}
+
+	mlog.Info("Files for user deleted", mlog.String("user_id", userID), mlog.Int64("deleted", deleted))
+
+	return nil
+}
@@ -64,6 +64,22 @@ func (_m *SearchEngineInterface) DeleteChannelPosts(channelID string) *model.App
 	return r0
 }
 
+// DeleteFile provides a mock function with given fields: fileID
+func (_m *SearchEngineInterface) DeleteFile(fileID string) *model.AppError {
+	ret := _m.Called(fileID)
+
+	var r0 *model.AppError
+	if rf, ok := ret.Get(0).(func(string) *model.AppError); ok {
+		r0 = rf(fileID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.AppError)
+		}
+	}
+
+	return r0
+}
+
 // DeletePost provides a mock function with given fields: post
 func (_m *SearchEngineInterface) DeletePost(post *model.Post) *model.AppError {
 	ret := _m.Called(post)
@@ -80,6 +96,22 @@ func (_m *SearchEngineInterface) DeletePost(post *model.Post) *model.AppError {
 	return r0
 }
 
+// DeletePostFiles provides a mock function with given fields: postID
+func (_m *SearchEngineInterface) DeletePostFiles(postID string) *model.AppError {
+	ret := _m.Called(postID)
+
+	var r0 *model.AppError
+	if rf, ok := ret.Get(0).(func(string) *model.AppError); ok {
+		r0 = rf(postID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.AppError)
+		}
+	}
+
+	return r0
+}
+
 // DeleteUser provides a mock function with given fields: user
 func (_m *SearchEngineInterface) DeleteUser(user *model.User) *model.AppError {
 	ret := _m.Called(user)
@@ -96,6 +128,22 @@ func (_m *SearchEngineInterface) DeleteUser(user *model.User) *model.AppError {
 	return r0
 }
 
+// DeleteUserFiles provides a mock function with given fields: userID
+func (_m *SearchEngineInterface) DeleteUserFiles(userID string) *model.AppError {
+	ret := _m.Called(userID)
+
+	var r0 *model.AppError
+	if rf, ok := ret.Get(0).(func(string) *model.AppError); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.AppError)
+		}
+	}
+
+	return r0
+}
+
 // DeleteUserPosts provides a mock function with given fields: userID
 func (_m *SearchEngineInterface) DeleteUserPosts(userID string) *model.AppError {
 	ret := _m.Called(userID)
@@ -156,6 +204,22 @@ func (_m *SearchEngineInterface) IndexChannel(channel *model.Channel) *model.App
 	return r0
 }
 
+// IndexFile provides a mock function with given fields: file, channelId
+func (_m *SearchEngineInterface) IndexFile(file *model.FileInfo, channelId string) *model.AppError {
+	ret := _m.Called(file, channelId)
+
+	var r0 *model.AppError
+	if rf, ok := ret.Get(0).(func(*model.FileInfo, string) *model.AppError); ok {
+		r0 = rf(file, channelId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.AppError)
+		}
+	}
+
+	return r0
+}
+
 // IndexPost provides a mock function with given fields: post, teamId
 func (_m *SearchEngineInterface) IndexPost(post *model.Post, teamId string) *model.AppError {
 	ret := _m.Called(post, teamId)
@@ -315,6 +379,31 @@ func (_m *SearchEngineInterface) SearchChannels(teamId string, term string) ([]s
 	return r0, r1
 }
 
+// SearchFiles provides a mock function with given fields: channels, searchParams, page, perPage
+func (_m *SearchEngineInterface) SearchFiles(channels *model.ChannelList, searchParams []*model.SearchParams, page int, perPage int) ([]string, *model.AppError) {
+	ret := _m.Called(channels, searchParams, page, perPage)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(*model.ChannelList, []*model.SearchParams, int, int) []string); ok {
+		r0 = rf(channels, searchParams, page, perPage)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func(*model.ChannelList, []*model.SearchParams, int, int) *model.AppError); ok {
+		r1 = rf(channels, searchParams, page, perPage)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // SearchPosts provides a mock function with given fields: channels, searchParams, page, perPage
 func (_m *SearchEngineInterface) SearchPosts(channels *model.ChannelList, searchParams []*model.SearchParams, page int, perPage int) ([]string, model.PostSearchMatches, *model.AppError) {
 	ret := _m.Called(channels, searchParams, page, perPage)
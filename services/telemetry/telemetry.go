@@ -395,6 +395,7 @@ func (ts *TelemetryService) trackConfig() {
 		"post_edit_time_limit":                                    *cfg.ServiceSettings.PostEditTimeLimit,
 		"enable_user_typing_messages":                             *cfg.ServiceSettings.EnableUserTypingMessages,
 		"enable_channel_viewed_messages":                          *cfg.ServiceSettings.EnableChannelViewedMessages,
+		"enable_read_receipts":                                    *cfg.ServiceSettings.EnableReadReceipts,
 		"time_between_user_typing_updates_milliseconds":           *cfg.ServiceSettings.TimeBetweenUserTypingUpdatesMilliseconds,
 		"cluster_log_timeout_milliseconds":                        *cfg.ServiceSettings.ClusterLogTimeoutMilliseconds,
 		"enable_post_search":                                      *cfg.ServiceSettings.EnablePostSearch,
@@ -532,6 +533,22 @@ func (ts *TelemetryService) trackConfig() {
 		"amazon_s3_sse":           *cfg.FileSettings.AmazonS3SSE,
 		"amazon_s3_signv2":        *cfg.FileSettings.AmazonS3SignV2,
 		"amazon_s3_trace":         *cfg.FileSettings.AmazonS3Trace,
+		"enable_encryption":       *cfg.FileSettings.EnableEncryptionAtRest,
+		"enable_deduplication":    *cfg.FileSettings.EnableDeduplication,
+		"enable_virus_scan":       *cfg.FileSettings.EnableVirusScan,
+		"virus_scan_driver":       *cfg.FileSettings.VirusScanDriver,
+		"user_storage_quota":      *cfg.FileSettings.UserStorageQuota,
+		"team_storage_quota":      *cfg.FileSettings.TeamStorageQuota,
+		"storage_warning_percent": *cfg.FileSettings.StorageWarningPercent,
+		"enable_file_previews":    *cfg.FileSettings.EnableFilePreviews,
+		"isdefault_ffmpeg_path":   isDefault(*cfg.FileSettings.FFmpegPath, ""),
+		"isdefault_pdftoppm_path": isDefault(*cfg.FileSettings.PdftoppmPath, ""),
+		"public_link_expiry":      *cfg.FileSettings.PublicLinkExpiryHours,
+		"legacy_public_links":     *cfg.FileSettings.EnableLegacyPublicLinks,
+		"strip_image_metadata":    *cfg.FileSettings.StripImageMetadata,
+		"strip_metadata_failure":  *cfg.FileSettings.StripMetadataFailure,
+		"preview_formats":         strings.Join(cfg.FileSettings.PreviewFormats, ","),
+		"upload_expiry_hours":     *cfg.FileSettings.UploadExpiryHours,
 		"max_file_size":           *cfg.FileSettings.MaxFileSize,
 		"enable_file_attachments": *cfg.FileSettings.EnableFileAttachments,
 		"enable_mobile_upload":    *cfg.FileSettings.EnableMobileUpload,
@@ -0,0 +1,95 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package virusscan
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// clamdScanner streams the content to the ClamAV daemon with the INSTREAM
+// command, in chunks prefixed by their length as a 4 bytes big endian
+// integer and terminated by an empty chunk.
+type clamdScanner struct {
+	address string
+	timeout time.Duration
+}
+
+func newClamdScanner(address string, timeout time.Duration) *clamdScanner {
+	return &clamdScanner{
+		address: address,
+		timeout: timeout,
+	}
+}
+
+func (s *clamdScanner) Scan(r io.Reader) (*Result, error) {
+	conn, err := dial(s.address, s.timeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to clamd: %w", err)
+	}
+	defer conn.Close()
+
+	w := &deadlineWriter{conn: conn, timeout: s.timeout}
+	writeErr := writeClamdStream(w, r)
+
+	// clamd replies and closes the connection when the stream is larger than
+	// its StreamMaxLength, so the reply is read even if the write failed.
+	if err = conn.SetDeadline(time.Now().Add(s.timeout)); err != nil {
+		return nil, err
+	}
+	reply, err := bufio.NewReader(conn).ReadString(0)
+	if err != nil && reply == "" {
+		if writeErr != nil {
+			return nil, fmt.Errorf("failed to send the file to clamd: %w", writeErr)
+		}
+		return nil, fmt.Errorf("failed to read the reply of clamd: %w", err)
+	}
+
+	return parseClamdReply(strings.TrimRight(reply, "\x00\n"))
+}
+
+func writeClamdStream(w io.Writer, r io.Reader) error {
+	if _, err := io.WriteString(w, "zINSTREAM\x00"); err != nil {
+		return err
+	}
+
+	buf := make([]byte, 4+chunkSize)
+	for {
+		n, err := r.Read(buf[4:])
+		if n > 0 {
+			binary.BigEndian.PutUint32(buf, uint32(n))
+			if _, werr := w.Write(buf[:4+n]); werr != nil {
+				return werr
+			}
+		}
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+	}
+
+	_, err := w.Write([]byte{0, 0, 0, 0})
+	return err
+}
+
+// parseClamdReply parses replies such as "stream: OK",
+// "stream: Eicar-Signature FOUND" and "INSTREAM size limit exceeded. ERROR".
+func parseClamdReply(reply string) (*Result, error) {
+	switch {
+	case strings.HasSuffix(reply, " FOUND"):
+		signature := strings.TrimSuffix(reply, " FOUND")
+		if i := strings.Index(signature, ": "); i >= 0 {
+			signature = signature[i+2:]
+		}
+		return &Result{Infected: true, Signature: signature}, nil
+	case strings.HasSuffix(reply, ": OK"):
+		return &Result{}, nil
+	}
+	return nil, fmt.Errorf("clamd failed to scan the file: %s", reply)
+}
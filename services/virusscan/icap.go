@@ -0,0 +1,141 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package virusscan
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"net/textproto"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const icapDefaultPort = "1344"
+
+// icapScanner sends the content to an ICAP server in a RESPMOD request, as
+// the body of an HTTP response, and allows the server to reply with 204 No
+// Content when the content is clean.
+type icapScanner struct {
+	service *url.URL
+	timeout time.Duration
+}
+
+func newICAPScanner(address string, timeout time.Duration) (*icapScanner, error) {
+	service, err := url.Parse(address)
+	if err != nil || service.Scheme != "icap" || service.Host == "" {
+		return nil, fmt.Errorf("invalid ICAP service URL %q", address)
+	}
+
+	return &icapScanner{
+		service: service,
+		timeout: timeout,
+	}, nil
+}
+
+func (s *icapScanner) Scan(r io.Reader) (*Result, error) {
+	address := s.service.Host
+	if s.service.Port() == "" {
+		address = net.JoinHostPort(s.service.Hostname(), icapDefaultPort)
+	}
+
+	conn, err := dial(address, s.timeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the ICAP server: %w", err)
+	}
+	defer conn.Close()
+
+	w := bufio.NewWriterSize(&deadlineWriter{conn: conn, timeout: s.timeout}, chunkSize+16)
+	writeErr := s.writeRequest(w, r)
+
+	// The server may reply with an error before reading the whole request.
+	if err = conn.SetDeadline(time.Now().Add(s.timeout)); err != nil {
+		return nil, err
+	}
+	tp := textproto.NewReader(bufio.NewReader(conn))
+	status, err := tp.ReadLine()
+	if err != nil {
+		if writeErr != nil {
+			return nil, fmt.Errorf("failed to send the file to the ICAP server: %w", writeErr)
+		}
+		return nil, fmt.Errorf("failed to read the reply of the ICAP server: %w", err)
+	}
+	header, err := tp.ReadMIMEHeader()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the reply of the ICAP server: %w", err)
+	}
+
+	return parseICAPResponse(status, header)
+}
+
+func (s *icapScanner) writeRequest(w *bufio.Writer, r io.Reader) error {
+	httpHeader := "HTTP/1.1 200 OK\r\nContent-Type: application/octet-stream\r\n\r\n"
+	fmt.Fprintf(w, "RESPMOD %s ICAP/1.0\r\n", s.service.String())
+	fmt.Fprintf(w, "Host: %s\r\n", s.service.Host)
+	fmt.Fprintf(w, "Allow: 204\r\n")
+	fmt.Fprintf(w, "Encapsulated: res-hdr=0, res-body=%d\r\n\r\n", len(httpHeader))
+	w.WriteString(httpHeader)
+
+	// The body uses the chunked transfer encoding of HTTP/1.1.
+	buf := make([]byte, chunkSize)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			fmt.Fprintf(w, "%x\r\n", n)
+			w.Write(buf[:n])
+			if _, werr := w.WriteString("\r\n"); werr != nil {
+				return werr
+			}
+		}
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+	}
+
+	w.WriteString("0\r\n\r\n")
+	return w.Flush()
+}
+
+// parseICAPResponse reads the outcome of the scan from the status line and
+// the headers of the ICAP response. Servers report the malware they find in
+// one of the X-Infection-Found, X-Virus-ID or X-Violations-Found headers.
+func parseICAPResponse(status string, header textproto.MIMEHeader) (*Result, error) {
+	parts := strings.SplitN(status, " ", 3)
+	if len(parts) < 2 || !strings.HasPrefix(parts[0], "ICAP/") {
+		return nil, fmt.Errorf("invalid ICAP response: %s", status)
+	}
+
+	switch parts[1] {
+	case "204":
+		return &Result{}, nil
+	case "200":
+		if infection := header.Get("X-Infection-Found"); infection != "" {
+			return &Result{Infected: true, Signature: parseICAPThreat(infection)}, nil
+		}
+		if virusId := header.Get("X-Virus-ID"); virusId != "" {
+			return &Result{Infected: true, Signature: strings.TrimSpace(virusId)}, nil
+		}
+		if header.Get("X-Violations-Found") != "" {
+			return &Result{Infected: true}, nil
+		}
+		return &Result{}, nil
+	}
+	return nil, fmt.Errorf("the ICAP server failed to scan the file: %s", status)
+}
+
+// parseICAPThreat returns the threat of an X-Infection-Found header such as
+// "Type=0; Resolution=2; Threat=EICAR-Test-File;".
+func parseICAPThreat(infection string) string {
+	for _, field := range strings.Split(infection, ";") {
+		field = strings.TrimSpace(field)
+		if strings.HasPrefix(field, "Threat=") {
+			return strings.TrimPrefix(field, "Threat=")
+		}
+	}
+	return ""
+}
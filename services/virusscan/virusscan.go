@@ -0,0 +1,75 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+// Package virusscan scans the content of files for malware, either with the
+// ClamAV daemon using the clamd protocol or with an ICAP server as described
+// in RFC 3507.
+package virusscan
+
+import (
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+const (
+	DriverClamd = "clamd"
+	DriverICAP  = "icap"
+
+	// chunkSize is the size of the chunks the content is streamed in.
+	chunkSize = 64 * 1024
+)
+
+var ErrUnknownDriver = errors.New("unknown virus scan driver")
+
+// Result is the outcome of the scan of a file.
+type Result struct {
+	Infected bool
+	// Signature is the name of the malware found, when known.
+	Signature string
+}
+
+// Scanner scans the content of files for malware.
+type Scanner interface {
+	Scan(r io.Reader) (*Result, error)
+}
+
+// NewScanner returns a scanner for the given driver. The clamd driver takes
+// either the path of the unix socket of the daemon or its host:port address,
+// and the icap driver takes the icap:// URL of the scanning service. The
+// timeout applies to connecting and to each network read and write.
+func NewScanner(driver, address string, timeout time.Duration) (Scanner, error) {
+	switch driver {
+	case DriverClamd:
+		return newClamdScanner(address, timeout), nil
+	case DriverICAP:
+		return newICAPScanner(address, timeout)
+	}
+	return nil, ErrUnknownDriver
+}
+
+// dial connects to the given address, which is either the path of a unix
+// socket or a host:port TCP address.
+func dial(address string, timeout time.Duration) (net.Conn, error) {
+	network := "tcp"
+	if strings.HasPrefix(address, "/") {
+		network = "unix"
+	}
+	return net.DialTimeout(network, address, timeout)
+}
+
+// deadlineWriter extends the deadline of the connection before each write,
+// so that large files are not limited by the timeout as a whole.
+type deadlineWriter struct {
+	conn    net.Conn
+	timeout time.Duration
+}
+
+func (w *deadlineWriter) Write(p []byte) (int, error) {
+	if err := w.conn.SetDeadline(time.Now().Add(w.timeout)); err != nil {
+		return 0, err
+	}
+	return w.conn.Write(p)
+}
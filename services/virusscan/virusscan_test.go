@@ -0,0 +1,121 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package virusscan
+
+import (
+	"bytes"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zacmm/zacmm-server/services/virusscan/virusscantest"
+)
+
+func TestScanner(t *testing.T) {
+	clamd, err := virusscantest.NewClamd()
+	require.NoError(t, err)
+	defer clamd.Close()
+
+	icap, err := virusscantest.NewICAP()
+	require.NoError(t, err)
+	defer icap.Close()
+
+	for name, server := range map[string]struct {
+		driver  string
+		address string
+	}{
+		"clamd": {DriverClamd, clamd.Address},
+		"icap":  {DriverICAP, icap.Address},
+	} {
+		t.Run(name, func(t *testing.T) {
+			scanner, err := NewScanner(server.driver, server.address, 5*time.Second)
+			require.NoError(t, err)
+
+			t.Run("clean file", func(t *testing.T) {
+				result, err := scanner.Scan(strings.NewReader("some harmless content"))
+				require.NoError(t, err)
+				assert.False(t, result.Infected)
+			})
+
+			t.Run("empty file", func(t *testing.T) {
+				result, err := scanner.Scan(bytes.NewReader(nil))
+				require.NoError(t, err)
+				assert.False(t, result.Infected)
+			})
+
+			t.Run("infected file", func(t *testing.T) {
+				result, err := scanner.Scan(strings.NewReader("before " + virusscantest.Malware + " after"))
+				require.NoError(t, err)
+				assert.True(t, result.Infected)
+				assert.Equal(t, virusscantest.Signature, result.Signature)
+			})
+
+			t.Run("file larger than a chunk", func(t *testing.T) {
+				content := bytes.Repeat([]byte("a"), 3*chunkSize+17)
+				content = append(content, virusscantest.Malware...)
+				result, err := scanner.Scan(bytes.NewReader(content))
+				require.NoError(t, err)
+				assert.True(t, result.Infected)
+			})
+		})
+	}
+
+	t.Run("unreachable server", func(t *testing.T) {
+		scanner, err := NewScanner(DriverClamd, "127.0.0.1:1", time.Second)
+		require.NoError(t, err)
+		_, err = scanner.Scan(strings.NewReader("content"))
+		require.Error(t, err)
+	})
+
+	t.Run("invalid settings", func(t *testing.T) {
+		_, err := NewScanner("unknown", clamd.Address, time.Second)
+		assert.Equal(t, ErrUnknownDriver, err)
+
+		_, err = NewScanner(DriverICAP, "http://localhost/avscan", time.Second)
+		assert.Error(t, err)
+	})
+}
+
+func TestParseClamdReply(t *testing.T) {
+	result, err := parseClamdReply("stream: OK")
+	require.NoError(t, err)
+	assert.False(t, result.Infected)
+
+	result, err = parseClamdReply("stream: Win.Test.EICAR_HDB-1 FOUND")
+	require.NoError(t, err)
+	assert.True(t, result.Infected)
+	assert.Equal(t, "Win.Test.EICAR_HDB-1", result.Signature)
+
+	_, err = parseClamdReply("INSTREAM size limit exceeded. ERROR")
+	assert.Error(t, err)
+}
+
+func TestParseICAPResponse(t *testing.T) {
+	result, err := parseICAPResponse("ICAP/1.0 204 No Content", textproto.MIMEHeader{})
+	require.NoError(t, err)
+	assert.False(t, result.Infected)
+
+	result, err = parseICAPResponse("ICAP/1.0 200 OK", textproto.MIMEHeader{"X-Virus-Id": {"EICAR"}})
+	require.NoError(t, err)
+	assert.True(t, result.Infected)
+	assert.Equal(t, "EICAR", result.Signature)
+
+	result, err = parseICAPResponse("ICAP/1.0 200 OK", textproto.MIMEHeader{"X-Violations-Found": {"1"}})
+	require.NoError(t, err)
+	assert.True(t, result.Infected)
+
+	result, err = parseICAPResponse("ICAP/1.0 200 OK", textproto.MIMEHeader{})
+	require.NoError(t, err)
+	assert.False(t, result.Infected)
+
+	_, err = parseICAPResponse("ICAP/1.0 500 Server Error", textproto.MIMEHeader{})
+	assert.Error(t, err)
+
+	_, err = parseICAPResponse("HTTP/1.1 200 OK", textproto.MIMEHeader{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,179 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+// Package virusscantest provides fake clamd and ICAP servers to test the
+// scanning of files without running a real scanner.
+package virusscantest
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http/httputil"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	// Malware is the content that the fake servers report as infected when a
+	// file contains it. It is used instead of the EICAR test file so that the
+	// sources aren't flagged by antivirus software.
+	Malware = "VIRUSSCANTEST-FAKE-MALWARE"
+	// Signature is the name the fake servers give to the malware.
+	Signature = "Virusscantest.Fake.Malware"
+)
+
+// Server is a fake clamd or ICAP server listening on a local TCP port.
+type Server struct {
+	// Address is the address to configure the scanner with.
+	Address string
+
+	listener net.Listener
+	wg       sync.WaitGroup
+	mut      sync.Mutex
+	scanned  int
+}
+
+// NewClamd starts a fake ClamAV daemon supporting the INSTREAM command.
+func NewClamd() (*Server, error) {
+	s, err := newServer()
+	if err != nil {
+		return nil, err
+	}
+	s.Address = s.listener.Addr().String()
+	go s.serve(s.handleClamd)
+	return s, nil
+}
+
+// NewICAP starts a fake ICAP server supporting RESPMOD requests.
+func NewICAP() (*Server, error) {
+	s, err := newServer()
+	if err != nil {
+		return nil, err
+	}
+	s.Address = "icap://" + s.listener.Addr().String() + "/avscan"
+	go s.serve(s.handleICAP)
+	return s, nil
+}
+
+func newServer() (*Server, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	return &Server{listener: listener}, nil
+}
+
+// Scanned returns the number of files scanned so far.
+func (s *Server) Scanned() int {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	return s.scanned
+}
+
+// Close stops the server.
+func (s *Server) Close() {
+	s.listener.Close()
+	s.wg.Wait()
+}
+
+func (s *Server) serve(handle func(conn net.Conn) error) {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			defer conn.Close()
+			handle(conn)
+		}()
+	}
+}
+
+func (s *Server) verdict(content []byte) bool {
+	s.mut.Lock()
+	s.scanned++
+	s.mut.Unlock()
+	return bytes.Contains(content, []byte(Malware))
+}
+
+func (s *Server) handleClamd(conn net.Conn) error {
+	r := bufio.NewReader(conn)
+	command, err := r.ReadString(0)
+	if err != nil {
+		return err
+	}
+	if command != "zINSTREAM\x00" {
+		_, err = fmt.Fprintf(conn, "%s: Unknown command. ERROR\x00", strings.TrimRight(command, "\x00"))
+		return err
+	}
+
+	var content bytes.Buffer
+	for {
+		var size uint32
+		if err = binary.Read(r, binary.BigEndian, &size); err != nil {
+			return err
+		}
+		if size == 0 {
+			break
+		}
+		if _, err = io.CopyN(&content, r, int64(size)); err != nil {
+			return err
+		}
+	}
+
+	if s.verdict(content.Bytes()) {
+		_, err = fmt.Fprintf(conn, "stream: %s FOUND\x00", Signature)
+	} else {
+		_, err = io.WriteString(conn, "stream: OK\x00")
+	}
+	return err
+}
+
+func (s *Server) handleICAP(conn net.Conn) error {
+	r := bufio.NewReader(conn)
+	tp := textproto.NewReader(r)
+	request, err := tp.ReadLine()
+	if err != nil {
+		return err
+	}
+	header, err := tp.ReadMIMEHeader()
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(request, "RESPMOD ") {
+		_, err = io.WriteString(conn, "ICAP/1.0 405 Method Not Allowed\r\nEncapsulated: null-body=0\r\n\r\n")
+		return err
+	}
+
+	// Skip the encapsulated HTTP header to get to the chunked body.
+	bodyOffset := 0
+	for _, part := range strings.Split(header.Get("Encapsulated"), ",") {
+		part = strings.TrimSpace(part)
+		if strings.HasPrefix(part, "res-body=") {
+			bodyOffset, _ = strconv.Atoi(strings.TrimPrefix(part, "res-body="))
+		}
+	}
+	if _, err = io.CopyN(ioutil.Discard, r, int64(bodyOffset)); err != nil {
+		return err
+	}
+	content, err := ioutil.ReadAll(httputil.NewChunkedReader(r))
+	if err != nil {
+		return err
+	}
+
+	if s.verdict(content) {
+		_, err = fmt.Fprintf(conn, "ICAP/1.0 200 OK\r\nX-Infection-Found: Type=0; Resolution=2; Threat=%s;\r\nEncapsulated: null-body=0\r\n\r\n", Signature)
+	} else {
+		_, err = io.WriteString(conn, "ICAP/1.0 204 No Content\r\nEncapsulated: null-body=0\r\n\r\n")
+	}
+	return err
+}
@@ -0,0 +1,289 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+// Package webpush sends messages to Web Push endpoints, which browsers and
+// UnifiedPush distributors provide, without going through a push proxy.
+// Messages are encrypted as described in RFC 8291 and the requests are
+// authenticated with VAPID as described in RFC 8292.
+package webpush
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/hkdf"
+)
+
+const (
+	// MaxPayloadSize is the largest payload that fits in the 4096 bytes that
+	// push services are required to accept once encrypted.
+	MaxPayloadSize = 4096 - headerSize - paddingDelimiterSize - tagSize
+
+	saltSize             = 16
+	authSecretSize       = 16
+	publicKeySize        = 65
+	headerSize           = saltSize + 4 + 1 + publicKeySize
+	paddingDelimiterSize = 1
+	tagSize              = 16
+	recordSize           = 4096
+
+	vapidExpiration = 12 * time.Hour
+)
+
+var (
+	ErrInvalidSubscription = errors.New("invalid web push subscription")
+	ErrPayloadTooLarge     = errors.New("web push payload is too large")
+)
+
+// Subscription is where and how to deliver messages to one client, as given
+// by the PushSubscription of the browser or by the UnifiedPush distributor.
+type Subscription struct {
+	Endpoint string `json:"endpoint"`
+	Keys     struct {
+		P256dh string `json:"p256dh"`
+		Auth   string `json:"auth"`
+	} `json:"keys"`
+}
+
+// ParseSubscription parses a subscription serialized by DeviceId.
+func ParseSubscription(deviceId string) (*Subscription, error) {
+	parts := strings.SplitN(deviceId, ":", 3)
+	if len(parts) != 3 {
+		return nil, ErrInvalidSubscription
+	}
+
+	s := &Subscription{Endpoint: parts[2]}
+	s.Keys.P256dh = parts[0]
+	s.Keys.Auth = parts[1]
+
+	return s, s.IsValid()
+}
+
+// DeviceId serializes the subscription so that it can be stored as the device
+// id of a session. The keys come first since the endpoint contains colons.
+func (s *Subscription) DeviceId() string {
+	return s.Keys.P256dh + ":" + s.Keys.Auth + ":" + s.Endpoint
+}
+
+func (s *Subscription) IsValid() error {
+	endpoint, err := url.Parse(s.Endpoint)
+	if err != nil || endpoint.Scheme != "https" || endpoint.Host == "" {
+		return ErrInvalidSubscription
+	}
+
+	if _, _, err := s.publicKey(); err != nil {
+		return err
+	}
+
+	if _, err := s.authSecret(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *Subscription) publicKey() (*big.Int, *big.Int, error) {
+	data, err := decodeBase64(s.Keys.P256dh)
+	if err != nil || len(data) != publicKeySize {
+		return nil, nil, ErrInvalidSubscription
+	}
+
+	x, y := elliptic.Unmarshal(elliptic.P256(), data)
+	if x == nil {
+		return nil, nil, ErrInvalidSubscription
+	}
+
+	return x, y, nil
+}
+
+func (s *Subscription) authSecret() ([]byte, error) {
+	data, err := decodeBase64(s.Keys.Auth)
+	if err != nil || len(data) != authSecretSize {
+		return nil, ErrInvalidSubscription
+	}
+	return data, nil
+}
+
+// Encrypt encrypts the payload for the subscription using the aes128gcm
+// content coding.
+func Encrypt(s *Subscription, payload []byte) ([]byte, error) {
+	localKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+
+	return encrypt(s, payload, localKey, salt)
+}
+
+func encrypt(s *Subscription, payload []byte, localKey *ecdsa.PrivateKey, salt []byte) ([]byte, error) {
+	if len(payload) > MaxPayloadSize {
+		return nil, ErrPayloadTooLarge
+	}
+
+	x, y, err := s.publicKey()
+	if err != nil {
+		return nil, err
+	}
+
+	authSecret, err := s.authSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	sharedX, _ := elliptic.P256().ScalarMult(x, y, localKey.D.Bytes())
+	sharedSecret := make([]byte, 32)
+	fillBytes(sharedX, sharedSecret)
+
+	userAgentPublicKey := elliptic.Marshal(elliptic.P256(), x, y)
+	localPublicKey := elliptic.Marshal(elliptic.P256(), localKey.X, localKey.Y)
+
+	keyInfo := append([]byte("WebPush: info\x00"), userAgentPublicKey...)
+	keyInfo = append(keyInfo, localPublicKey...)
+	ikm, err := deriveKey(sharedSecret, authSecret, keyInfo, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	contentKey, err := deriveKey(ikm, salt, []byte("Content-Encoding: aes128gcm\x00"), 16)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, err := deriveKey(ikm, salt, []byte("Content-Encoding: nonce\x00"), 12)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(contentKey)
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	// The whole payload fits in a single record, which is marked as the last
+	// one by the padding delimiter.
+	plaintext := append(append([]byte{}, payload...), 2)
+
+	body := bytes.NewBuffer(make([]byte, 0, headerSize+len(plaintext)+tagSize))
+	body.Write(salt)
+	binary.Write(body, binary.BigEndian, uint32(recordSize))
+	body.WriteByte(publicKeySize)
+	body.Write(localPublicKey)
+	body.Write(gcm.Seal(nil, nonce, plaintext, nil))
+
+	return body.Bytes(), nil
+}
+
+func deriveKey(secret, salt, info []byte, size int) ([]byte, error) {
+	key := make([]byte, size)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, secret, salt, info), key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// PublicKey returns the VAPID public key in the form that clients pass as the
+// applicationServerKey when subscribing.
+func PublicKey(key *ecdsa.PrivateKey) string {
+	return base64.RawURLEncoding.EncodeToString(elliptic.Marshal(elliptic.P256(), key.X, key.Y))
+}
+
+// VAPIDAuthorization returns the Authorization header that identifies the
+// server to the push service of the endpoint. The subject is a mailto: or
+// https: URL that the push service can use to contact the operator.
+func VAPIDAuthorization(endpoint, subject string, key *ecdsa.PrivateKey, now time.Time) (string, error) {
+	endpointURL, err := url.Parse(endpoint)
+	if err != nil {
+		return "", err
+	}
+
+	header, _ := json.Marshal(map[string]string{"typ": "JWT", "alg": "ES256"})
+	claims, _ := json.Marshal(map[string]interface{}{
+		"aud": endpointURL.Scheme + "://" + endpointURL.Host,
+		"exp": now.Add(vapidExpiration).Unix(),
+		"sub": subject,
+	})
+
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	hash := sha256.Sum256([]byte(unsigned))
+
+	r, s, err := ecdsa.Sign(rand.Reader, key, hash[:])
+	if err != nil {
+		return "", err
+	}
+
+	signature := make([]byte, 64)
+	fillBytes(r, signature[:32])
+	fillBytes(s, signature[32:])
+
+	token := unsigned + "." + base64.RawURLEncoding.EncodeToString(signature)
+
+	return "vapid t=" + token + ", k=" + PublicKey(key), nil
+}
+
+// NewRequest builds the request that delivers an encrypted payload to the
+// subscription. The push service keeps the message for at most ttl while the
+// client is unreachable.
+func NewRequest(s *Subscription, encrypted []byte, key *ecdsa.PrivateKey, subject string, ttl time.Duration) (*http.Request, error) {
+	authorization, err := VAPIDAuthorization(s.Endpoint, subject, key, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := http.NewRequest("POST", s.Endpoint, bytes.NewReader(encrypted))
+	if err != nil {
+		return nil, err
+	}
+
+	request.Header.Set("Authorization", authorization)
+	request.Header.Set("Content-Encoding", "aes128gcm")
+	request.Header.Set("Content-Type", "application/octet-stream")
+	request.Header.Set("TTL", strconv.Itoa(int(ttl.Seconds())))
+	request.Header.Set("Urgency", "high")
+
+	return request, nil
+}
+
+// IsSubscriptionGone reports whether the push service answered that the
+// subscription expired or was removed by the client.
+func IsSubscriptionGone(resp *http.Response) bool {
+	return resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone
+}
+
+func decodeBase64(value string) ([]byte, error) {
+	// Clients are not consistent about padding and the URL-safe alphabet.
+	value = strings.TrimRight(value, "=")
+	value = strings.NewReplacer("+", "-", "/", "_").Replace(value)
+	return base64.RawURLEncoding.DecodeString(value)
+}
+
+// fillBytes writes n into buf as a zero-padded big-endian number.
+func fillBytes(n *big.Int, buf []byte) {
+	b := n.Bytes()
+	copy(buf[len(buf)-len(b):], b)
+}
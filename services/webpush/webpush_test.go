@@ -0,0 +1,143 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package webpush
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Values from the example in appendix A of RFC 8291.
+const (
+	testUserAgentPublicKey = "BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4"
+	testAuthSecret         = "BTBZMqHH6r4Tts7J_aSIgg"
+)
+
+func newTestSubscription() *Subscription {
+	s := &Subscription{Endpoint: "https://push.example.net/push/JzLQ3raZJfFBR0aqvOMsLrt54w4rJUsV"}
+	s.Keys.P256dh = testUserAgentPublicKey
+	s.Keys.Auth = testAuthSecret
+	return s
+}
+
+func TestParseSubscription(t *testing.T) {
+	subscription := newTestSubscription()
+
+	parsed, err := ParseSubscription(subscription.DeviceId())
+	require.NoError(t, err)
+	assert.Equal(t, subscription, parsed)
+
+	for name, deviceId := range map[string]string{
+		"missing parts":       testUserAgentPublicKey + ":" + testAuthSecret,
+		"invalid endpoint":    testUserAgentPublicKey + ":" + testAuthSecret + ":push.example.net",
+		"http endpoint":       testUserAgentPublicKey + ":" + testAuthSecret + ":http://push.example.net/push",
+		"invalid public key":  "BCVxsr7N:" + testAuthSecret + ":https://push.example.net/push",
+		"invalid auth secret": testUserAgentPublicKey + ":BTBZ:https://push.example.net/push",
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseSubscription(deviceId)
+			assert.Equal(t, ErrInvalidSubscription, err)
+		})
+	}
+
+	t.Run("padded standard base64 keys", func(t *testing.T) {
+		publicKey, _ := base64.RawURLEncoding.DecodeString(testUserAgentPublicKey)
+		authSecret, _ := base64.RawURLEncoding.DecodeString(testAuthSecret)
+
+		_, err := ParseSubscription(base64.StdEncoding.EncodeToString(publicKey) + ":" + base64.StdEncoding.EncodeToString(authSecret) + ":https://push.example.net/push")
+		assert.NoError(t, err)
+	})
+}
+
+func TestEncrypt(t *testing.T) {
+	t.Run("should match the example of RFC 8291", func(t *testing.T) {
+		privateKey, err := base64.RawURLEncoding.DecodeString("yfWPiYE-n46HLnH0KqZOF1fJJU3MYrct3AELtAQ-oRw")
+		require.NoError(t, err)
+		salt, err := base64.RawURLEncoding.DecodeString("DGv6ra1nlYgDCS1FRnbzlw")
+		require.NoError(t, err)
+
+		localKey := &ecdsa.PrivateKey{D: new(big.Int).SetBytes(privateKey)}
+		localKey.Curve = elliptic.P256()
+		localKey.X, localKey.Y = elliptic.P256().ScalarBaseMult(privateKey)
+
+		encrypted, err := encrypt(newTestSubscription(), []byte("When I grow up, I want to be a watermelon"), localKey, salt)
+		require.NoError(t, err)
+		assert.Equal(t, "DGv6ra1nlYgDCS1FRnbzlwAAEABBBP4z9KsN6nGRTbVYI_c7VJSPQTBtkgcy27mlmlMoZIIgDll6e3vCYLocInmYWAmS6TlzAC8wEqKK6PBru3jl7A_yl95bQpu6cVPTpK4Mqgkf1CXztLVBSt2Ks3oZwbuwXPXLWyouBWLVWGNWQexSgSxsj_Qulcy4a-fN", base64.RawURLEncoding.EncodeToString(encrypted))
+	})
+
+	t.Run("should use a new key and salt every time", func(t *testing.T) {
+		first, err := Encrypt(newTestSubscription(), []byte("message"))
+		require.NoError(t, err)
+		second, err := Encrypt(newTestSubscription(), []byte("message"))
+		require.NoError(t, err)
+		assert.NotEqual(t, first, second)
+		assert.Len(t, first, headerSize+len("message")+paddingDelimiterSize+tagSize)
+	})
+
+	t.Run("should reject a payload that is too large", func(t *testing.T) {
+		_, err := Encrypt(newTestSubscription(), make([]byte, MaxPayloadSize))
+		assert.NoError(t, err)
+
+		_, err = Encrypt(newTestSubscription(), make([]byte, MaxPayloadSize+1))
+		assert.Equal(t, ErrPayloadTooLarge, err)
+	})
+}
+
+func TestVAPIDAuthorization(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	now := time.Unix(1600000000, 0)
+	authorization, err := VAPIDAuthorization("https://push.example.net:8443/push/abc", "mailto:admin@example.com", key, now)
+	require.NoError(t, err)
+
+	require.True(t, strings.HasPrefix(authorization, "vapid t="))
+	parts := strings.SplitN(strings.TrimPrefix(authorization, "vapid t="), ", k=", 2)
+	require.Len(t, parts, 2)
+	assert.Equal(t, PublicKey(key), parts[1])
+
+	token := strings.Split(parts[0], ".")
+	require.Len(t, token, 3)
+
+	var claims map[string]interface{}
+	data, err := base64.RawURLEncoding.DecodeString(token[1])
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, &claims))
+	assert.Equal(t, "https://push.example.net:8443", claims["aud"])
+	assert.Equal(t, "mailto:admin@example.com", claims["sub"])
+	assert.Equal(t, float64(now.Add(vapidExpiration).Unix()), claims["exp"])
+
+	signature, err := base64.RawURLEncoding.DecodeString(token[2])
+	require.NoError(t, err)
+	require.Len(t, signature, 64)
+
+	hash := sha256.Sum256([]byte(token[0] + "." + token[1]))
+	assert.True(t, ecdsa.Verify(&key.PublicKey, hash[:], new(big.Int).SetBytes(signature[:32]), new(big.Int).SetBytes(signature[32:])))
+}
+
+func TestNewRequest(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	subscription := newTestSubscription()
+	request, err := NewRequest(subscription, []byte("encrypted"), key, "mailto:admin@example.com", 24*time.Hour)
+	require.NoError(t, err)
+
+	assert.Equal(t, "POST", request.Method)
+	assert.Equal(t, subscription.Endpoint, request.URL.String())
+	assert.Equal(t, "aes128gcm", request.Header.Get("Content-Encoding"))
+	assert.Equal(t, "86400", request.Header.Get("TTL"))
+	assert.True(t, strings.HasPrefix(request.Header.Get("Authorization"), "vapid t="))
+}
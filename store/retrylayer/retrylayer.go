@@ -23,27 +23,38 @@ type RetryLayer struct {
 	AuditStore                store.AuditStore
 	BotStore                  store.BotStore
 	ChannelStore              store.ChannelStore
+	ChannelBookmarkStore      store.ChannelBookmarkStore
 	ChannelMemberHistoryStore store.ChannelMemberHistoryStore
 	ClusterDiscoveryStore     store.ClusterDiscoveryStore
 	CommandStore              store.CommandStore
 	CommandWebhookStore       store.CommandWebhookStore
 	ComplianceStore           store.ComplianceStore
+	CustomStatusStore         store.CustomStatusStore
+	DndScheduleStore          store.DndScheduleStore
+	DraftStore                store.DraftStore
+	EmailDigestStore          store.EmailDigestStore
+	EmailQueueStore           store.EmailQueueStore
 	EmojiStore                store.EmojiStore
 	FileInfoStore             store.FileInfoStore
 	GroupStore                store.GroupStore
 	JobStore                  store.JobStore
+	KeywordWatchStore         store.KeywordWatchStore
 	LicenseStore              store.LicenseStore
 	LinkMetadataStore         store.LinkMetadataStore
 	OAuthStore                store.OAuthStore
 	PluginStore               store.PluginStore
+	PollStore                 store.PollStore
 	PostStore                 store.PostStore
 	PreferenceStore           store.PreferenceStore
 	ProductNoticesStore       store.ProductNoticesStore
+	PublicLinkStore           store.PublicLinkStore
 	ReactionStore             store.ReactionStore
 	RoleStore                 store.RoleStore
+	SavedSearchStore          store.SavedSearchStore
 	SchemeStore               store.SchemeStore
 	SessionStore              store.SessionStore
 	StatusStore               store.StatusStore
+	StorageUsageStore         store.StorageUsageStore
 	SystemStore               store.SystemStore
 	TeamStore                 store.TeamStore
 	TermsOfServiceStore       store.TermsOfServiceStore
@@ -70,6 +81,10 @@ func (s *RetryLayer) Channel() store.ChannelStore {
 	return s.ChannelStore
 }
 
+func (s *RetryLayer) ChannelBookmark() store.ChannelBookmarkStore {
+	return s.ChannelBookmarkStore
+}
+
 func (s *RetryLayer) ChannelMemberHistory() store.ChannelMemberHistoryStore {
 	return s.ChannelMemberHistoryStore
 }
@@ -90,6 +105,26 @@ func (s *RetryLayer) Compliance() store.ComplianceStore {
 	return s.ComplianceStore
 }
 
+func (s *RetryLayer) CustomStatus() store.CustomStatusStore {
+	return s.CustomStatusStore
+}
+
+func (s *RetryLayer) DndSchedule() store.DndScheduleStore {
+	return s.DndScheduleStore
+}
+
+func (s *RetryLayer) Draft() store.DraftStore {
+	return s.DraftStore
+}
+
+func (s *RetryLayer) EmailDigest() store.EmailDigestStore {
+	return s.EmailDigestStore
+}
+
+func (s *RetryLayer) EmailQueue() store.EmailQueueStore {
+	return s.EmailQueueStore
+}
+
 func (s *RetryLayer) Emoji() store.EmojiStore {
 	return s.EmojiStore
 }
@@ -106,6 +141,10 @@ func (s *RetryLayer) Job() store.JobStore {
 	return s.JobStore
 }
 
+func (s *RetryLayer) KeywordWatch() store.KeywordWatchStore {
+	return s.KeywordWatchStore
+}
+
 func (s *RetryLayer) License() store.LicenseStore {
 	return s.LicenseStore
 }
@@ -122,6 +161,10 @@ func (s *RetryLayer) Plugin() store.PluginStore {
 	return s.PluginStore
 }
 
+func (s *RetryLayer) Poll() store.PollStore {
+	return s.PollStore
+}
+
 func (s *RetryLayer) Post() store.PostStore {
 	return s.PostStore
 }
@@ -134,6 +177,10 @@ func (s *RetryLayer) ProductNotices() store.ProductNoticesStore {
 	return s.ProductNoticesStore
 }
 
+func (s *RetryLayer) PublicLink() store.PublicLinkStore {
+	return s.PublicLinkStore
+}
+
 func (s *RetryLayer) Reaction() store.ReactionStore {
 	return s.ReactionStore
 }
@@ -142,6 +189,10 @@ func (s *RetryLayer) Role() store.RoleStore {
 	return s.RoleStore
 }
 
+func (s *RetryLayer) SavedSearch() store.SavedSearchStore {
+	return s.SavedSearchStore
+}
+
 func (s *RetryLayer) Scheme() store.SchemeStore {
 	return s.SchemeStore
 }
@@ -154,6 +205,10 @@ func (s *RetryLayer) Status() store.StatusStore {
 	return s.StatusStore
 }
 
+func (s *RetryLayer) StorageUsage() store.StorageUsageStore {
+	return s.StorageUsageStore
+}
+
 func (s *RetryLayer) System() store.SystemStore {
 	return s.SystemStore
 }
@@ -217,6 +272,11 @@ type RetryLayerChannelStore struct {
 	Root *RetryLayer
 }
 
+type RetryLayerChannelBookmarkStore struct {
+	store.ChannelBookmarkStore
+	Root *RetryLayer
+}
+
 type RetryLayerChannelMemberHistoryStore struct {
 	store.ChannelMemberHistoryStore
 	Root *RetryLayer
@@ -242,6 +302,31 @@ type RetryLayerComplianceStore struct {
 	Root *RetryLayer
 }
 
+type RetryLayerCustomStatusStore struct {
+	store.CustomStatusStore
+	Root *RetryLayer
+}
+
+type RetryLayerDndScheduleStore struct {
+	store.DndScheduleStore
+	Root *RetryLayer
+}
+
+type RetryLayerDraftStore struct {
+	store.DraftStore
+	Root *RetryLayer
+}
+
+type RetryLayerEmailDigestStore struct {
+	store.EmailDigestStore
+	Root *RetryLayer
+}
+
+type RetryLayerEmailQueueStore struct {
+	store.EmailQueueStore
+	Root *RetryLayer
+}
+
 type RetryLayerEmojiStore struct {
 	store.EmojiStore
 	Root *RetryLayer
@@ -262,6 +347,11 @@ type RetryLayerJobStore struct {
 	Root *RetryLayer
 }
 
+type RetryLayerKeywordWatchStore struct {
+	store.KeywordWatchStore
+	Root *RetryLayer
+}
+
 type RetryLayerLicenseStore struct {
 	store.LicenseStore
 	Root *RetryLayer
@@ -282,6 +372,11 @@ type RetryLayerPluginStore struct {
 	Root *RetryLayer
 }
 
+type RetryLayerPollStore struct {
+	store.PollStore
+	Root *RetryLayer
+}
+
 type RetryLayerPostStore struct {
 	store.PostStore
 	Root *RetryLayer
@@ -297,6 +392,11 @@ type RetryLayerProductNoticesStore struct {
 	Root *RetryLayer
 }
 
+type RetryLayerPublicLinkStore struct {
+	store.PublicLinkStore
+	Root *RetryLayer
+}
+
 type RetryLayerReactionStore struct {
 	store.ReactionStore
 	Root *RetryLayer
@@ -307,6 +407,11 @@ type RetryLayerRoleStore struct {
 	Root *RetryLayer
 }
 
+type RetryLayerSavedSearchStore struct {
+	store.SavedSearchStore
+	Root *RetryLayer
+}
+
 type RetryLayerSchemeStore struct {
 	store.SchemeStore
 	Root *RetryLayer
@@ -322,6 +427,11 @@ type RetryLayerStatusStore struct {
 	Root *RetryLayer
 }
 
+type RetryLayerStorageUsageStore struct {
+	store.StorageUsageStore
+	Root *RetryLayer
+}
+
 type RetryLayerSystemStore struct {
 	store.SystemStore
 	Root *RetryLayer
@@ -2438,6 +2548,166 @@ func (s *RetryLayerChannelStore) UserBelongsToChannels(userId string, channelIds
 
 }
 
+func (s *RetryLayerChannelBookmarkStore) Delete(bookmarkId string) error {
+
+	tries := 0
+	for {
+		err := s.ChannelBookmarkStore.Delete(bookmarkId)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+	}
+
+}
+
+func (s *RetryLayerChannelBookmarkStore) Get(bookmarkId string, includeDeleted bool) (*model.ChannelBookmark, error) {
+
+	tries := 0
+	for {
+		result, err := s.ChannelBookmarkStore.Get(bookmarkId, includeDeleted)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerChannelBookmarkStore) GetAllForExport(limit int, afterId string) ([]*model.ChannelBookmarkForExport, error) {
+
+	tries := 0
+	for {
+		result, err := s.ChannelBookmarkStore.GetAllForExport(limit, afterId)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerChannelBookmarkStore) GetBookmarksForChannel(channelId string) ([]*model.ChannelBookmark, error) {
+
+	tries := 0
+	for {
+		result, err := s.ChannelBookmarkStore.GetBookmarksForChannel(channelId)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerChannelBookmarkStore) GetBookmarksForFile(fileId string) ([]*model.ChannelBookmark, error) {
+
+	tries := 0
+	for {
+		result, err := s.ChannelBookmarkStore.GetBookmarksForFile(fileId)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerChannelBookmarkStore) Save(bookmark *model.ChannelBookmark) (*model.ChannelBookmark, error) {
+
+	tries := 0
+	for {
+		result, err := s.ChannelBookmarkStore.Save(bookmark)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerChannelBookmarkStore) Update(bookmark *model.ChannelBookmark) error {
+
+	tries := 0
+	for {
+		err := s.ChannelBookmarkStore.Update(bookmark)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+	}
+
+}
+
+func (s *RetryLayerChannelBookmarkStore) UpdateSortOrder(bookmarkId string, channelId string, newIndex int64) ([]*model.ChannelBookmark, error) {
+
+	tries := 0
+	for {
+		result, err := s.ChannelBookmarkStore.UpdateSortOrder(bookmarkId, channelId, newIndex)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
 func (s *RetryLayerChannelMemberHistoryStore) GetUsersInChannelDuring(startTime int64, endTime int64, channelId string) ([]*model.ChannelMemberHistoryResult, error) {
 
 	tries := 0
@@ -3004,11 +3274,11 @@ func (s *RetryLayerComplianceStore) Update(compliance *model.Compliance) (*model
 
 }
 
-func (s *RetryLayerEmojiStore) Delete(emoji *model.Emoji, time int64) error {
+func (s *RetryLayerCustomStatusStore) Delete(userId string) error {
 
 	tries := 0
 	for {
-		err := s.EmojiStore.Delete(emoji, time)
+		err := s.CustomStatusStore.Delete(userId)
 		if err == nil {
 			return nil
 		}
@@ -3024,11 +3294,11 @@ func (s *RetryLayerEmojiStore) Delete(emoji *model.Emoji, time int64) error {
 
 }
 
-func (s *RetryLayerEmojiStore) Get(id string, allowFromCache bool) (*model.Emoji, error) {
+func (s *RetryLayerCustomStatusStore) Get(userId string) (*model.CustomStatus, error) {
 
 	tries := 0
 	for {
-		result, err := s.EmojiStore.Get(id, allowFromCache)
+		result, err := s.CustomStatusStore.Get(userId)
 		if err == nil {
 			return result, nil
 		}
@@ -3044,11 +3314,11 @@ func (s *RetryLayerEmojiStore) Get(id string, allowFromCache bool) (*model.Emoji
 
 }
 
-func (s *RetryLayerEmojiStore) GetByName(name string, allowFromCache bool) (*model.Emoji, error) {
+func (s *RetryLayerCustomStatusStore) GetByUserIds(userIds []string) ([]*model.CustomStatus, error) {
 
 	tries := 0
 	for {
-		result, err := s.EmojiStore.GetByName(name, allowFromCache)
+		result, err := s.CustomStatusStore.GetByUserIds(userIds)
 		if err == nil {
 			return result, nil
 		}
@@ -3064,11 +3334,11 @@ func (s *RetryLayerEmojiStore) GetByName(name string, allowFromCache bool) (*mod
 
 }
 
-func (s *RetryLayerEmojiStore) GetList(offset int, limit int, sort string) ([]*model.Emoji, error) {
+func (s *RetryLayerCustomStatusStore) GetExpired(expiredAt int64, limit int) ([]*model.CustomStatus, error) {
 
 	tries := 0
 	for {
-		result, err := s.EmojiStore.GetList(offset, limit, sort)
+		result, err := s.CustomStatusStore.GetExpired(expiredAt, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -3084,11 +3354,11 @@ func (s *RetryLayerEmojiStore) GetList(offset int, limit int, sort string) ([]*m
 
 }
 
-func (s *RetryLayerEmojiStore) GetMultipleByName(names []string) ([]*model.Emoji, error) {
+func (s *RetryLayerCustomStatusStore) Save(customStatus *model.CustomStatus) (*model.CustomStatus, error) {
 
 	tries := 0
 	for {
-		result, err := s.EmojiStore.GetMultipleByName(names)
+		result, err := s.CustomStatusStore.Save(customStatus)
 		if err == nil {
 			return result, nil
 		}
@@ -3104,31 +3374,31 @@ func (s *RetryLayerEmojiStore) GetMultipleByName(names []string) ([]*model.Emoji
 
 }
 
-func (s *RetryLayerEmojiStore) Save(emoji *model.Emoji) (*model.Emoji, error) {
+func (s *RetryLayerDndScheduleStore) Delete(userId string) error {
 
 	tries := 0
 	for {
-		result, err := s.EmojiStore.Save(emoji)
+		err := s.DndScheduleStore.Delete(userId)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 	}
 
 }
 
-func (s *RetryLayerEmojiStore) Search(name string, prefixOnly bool, limit int) ([]*model.Emoji, error) {
+func (s *RetryLayerDndScheduleStore) Get(userId string) (*model.DndSchedule, error) {
 
 	tries := 0
 	for {
-		result, err := s.EmojiStore.Search(name, prefixOnly, limit)
+		result, err := s.DndScheduleStore.Get(userId)
 		if err == nil {
 			return result, nil
 		}
@@ -3144,37 +3414,31 @@ func (s *RetryLayerEmojiStore) Search(name string, prefixOnly bool, limit int) (
 
 }
 
-func (s *RetryLayerFileInfoStore) AttachToPost(fileId string, postId string, creatorId string) error {
+func (s *RetryLayerDndScheduleStore) GetAll(afterUserId string, limit int) ([]*model.DndSchedule, error) {
 
 	tries := 0
 	for {
-		err := s.FileInfoStore.AttachToPost(fileId, postId, creatorId)
+		result, err := s.DndScheduleStore.GetAll(afterUserId, limit)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 	}
 
 }
 
-func (s *RetryLayerFileInfoStore) ClearCaches() {
-
-	s.FileInfoStore.ClearCaches()
-
-}
-
-func (s *RetryLayerFileInfoStore) DeleteForPost(postId string) (string, error) {
+func (s *RetryLayerDndScheduleStore) Save(schedule *model.DndSchedule) (*model.DndSchedule, error) {
 
 	tries := 0
 	for {
-		result, err := s.FileInfoStore.DeleteForPost(postId)
+		result, err := s.DndScheduleStore.Save(schedule)
 		if err == nil {
 			return result, nil
 		}
@@ -3190,31 +3454,31 @@ func (s *RetryLayerFileInfoStore) DeleteForPost(postId string) (string, error) {
 
 }
 
-func (s *RetryLayerFileInfoStore) Get(id string) (*model.FileInfo, error) {
+func (s *RetryLayerDraftStore) Delete(userId string, channelId string, rootId string) error {
 
 	tries := 0
 	for {
-		result, err := s.FileInfoStore.Get(id)
+		err := s.DraftStore.Delete(userId, channelId, rootId)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 	}
 
 }
 
-func (s *RetryLayerFileInfoStore) GetByPath(path string) (*model.FileInfo, error) {
+func (s *RetryLayerDraftStore) Get(userId string, channelId string, rootId string) (*model.Draft, error) {
 
 	tries := 0
 	for {
-		result, err := s.FileInfoStore.GetByPath(path)
+		result, err := s.DraftStore.Get(userId, channelId, rootId)
 		if err == nil {
 			return result, nil
 		}
@@ -3230,11 +3494,11 @@ func (s *RetryLayerFileInfoStore) GetByPath(path string) (*model.FileInfo, error
 
 }
 
-func (s *RetryLayerFileInfoStore) GetForPost(postId string, readFromMaster bool, includeDeleted bool, allowFromCache bool) ([]*model.FileInfo, error) {
+func (s *RetryLayerDraftStore) GetDraftsForUser(userId string, teamId string) ([]*model.Draft, error) {
 
 	tries := 0
 	for {
-		result, err := s.FileInfoStore.GetForPost(postId, readFromMaster, includeDeleted, allowFromCache)
+		result, err := s.DraftStore.GetDraftsForUser(userId, teamId)
 		if err == nil {
 			return result, nil
 		}
@@ -3250,11 +3514,11 @@ func (s *RetryLayerFileInfoStore) GetForPost(postId string, readFromMaster bool,
 
 }
 
-func (s *RetryLayerFileInfoStore) GetForUser(userId string) ([]*model.FileInfo, error) {
+func (s *RetryLayerDraftStore) Save(draft *model.Draft) (*model.Draft, error) {
 
 	tries := 0
 	for {
-		result, err := s.FileInfoStore.GetForUser(userId)
+		result, err := s.DraftStore.Save(draft)
 		if err == nil {
 			return result, nil
 		}
@@ -3270,37 +3534,31 @@ func (s *RetryLayerFileInfoStore) GetForUser(userId string) ([]*model.FileInfo,
 
 }
 
-func (s *RetryLayerFileInfoStore) GetWithOptions(page int, perPage int, opt *model.GetFileInfosOptions) ([]*model.FileInfo, error) {
+func (s *RetryLayerEmailDigestStore) Delete(ids []string) error {
 
 	tries := 0
 	for {
-		result, err := s.FileInfoStore.GetWithOptions(page, perPage, opt)
+		err := s.EmailDigestStore.Delete(ids)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 	}
 
 }
 
-func (s *RetryLayerFileInfoStore) InvalidateFileInfosForPostCache(postId string, deleted bool) {
-
-	s.FileInfoStore.InvalidateFileInfosForPostCache(postId, deleted)
-
-}
-
-func (s *RetryLayerFileInfoStore) PermanentDelete(fileId string) error {
+func (s *RetryLayerEmailDigestStore) DeleteForUser(userId string) error {
 
 	tries := 0
 	for {
-		err := s.FileInfoStore.PermanentDelete(fileId)
+		err := s.EmailDigestStore.DeleteForUser(userId)
 		if err == nil {
 			return nil
 		}
@@ -3316,11 +3574,11 @@ func (s *RetryLayerFileInfoStore) PermanentDelete(fileId string) error {
 
 }
 
-func (s *RetryLayerFileInfoStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+func (s *RetryLayerEmailDigestStore) GetForUser(userId string) ([]*model.PendingEmailNotification, error) {
 
 	tries := 0
 	for {
-		result, err := s.FileInfoStore.PermanentDeleteBatch(endTime, limit)
+		result, err := s.EmailDigestStore.GetForUser(userId)
 		if err == nil {
 			return result, nil
 		}
@@ -3336,11 +3594,11 @@ func (s *RetryLayerFileInfoStore) PermanentDeleteBatch(endTime int64, limit int6
 
 }
 
-func (s *RetryLayerFileInfoStore) PermanentDeleteByUser(userId string) (int64, error) {
+func (s *RetryLayerEmailDigestStore) GetUserIdsWithPending(afterUserId string, limit int) ([]string, error) {
 
 	tries := 0
 	for {
-		result, err := s.FileInfoStore.PermanentDeleteByUser(userId)
+		result, err := s.EmailDigestStore.GetUserIdsWithPending(afterUserId, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -3356,11 +3614,11 @@ func (s *RetryLayerFileInfoStore) PermanentDeleteByUser(userId string) (int64, e
 
 }
 
-func (s *RetryLayerFileInfoStore) Save(info *model.FileInfo) (*model.FileInfo, error) {
+func (s *RetryLayerEmailDigestStore) Save(notification *model.PendingEmailNotification) (*model.PendingEmailNotification, error) {
 
 	tries := 0
 	for {
-		result, err := s.FileInfoStore.Save(info)
+		result, err := s.EmailDigestStore.Save(notification)
 		if err == nil {
 			return result, nil
 		}
@@ -3376,31 +3634,31 @@ func (s *RetryLayerFileInfoStore) Save(info *model.FileInfo) (*model.FileInfo, e
 
 }
 
-func (s *RetryLayerFileInfoStore) SetContent(fileId string, content string) error {
+func (s *RetryLayerEmailQueueStore) Get(id string) (*model.QueuedEmail, error) {
 
 	tries := 0
 	for {
-		err := s.FileInfoStore.SetContent(fileId, content)
+		result, err := s.EmailQueueStore.Get(id)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 	}
 
 }
 
-func (s *RetryLayerFileInfoStore) Upsert(info *model.FileInfo) (*model.FileInfo, error) {
+func (s *RetryLayerEmailQueueStore) GetAll(status string, offset int, limit int) ([]*model.QueuedEmail, error) {
 
 	tries := 0
 	for {
-		result, err := s.FileInfoStore.Upsert(info)
+		result, err := s.EmailQueueStore.GetAll(status, offset, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -3416,11 +3674,11 @@ func (s *RetryLayerFileInfoStore) Upsert(info *model.FileInfo) (*model.FileInfo,
 
 }
 
-func (s *RetryLayerGroupStore) AdminRoleGroupsForSyncableMember(userID string, syncableID string, syncableType model.GroupSyncableType) ([]string, error) {
+func (s *RetryLayerEmailQueueStore) GetReadyToSend(now int64, limit int) ([]*model.QueuedEmail, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.AdminRoleGroupsForSyncableMember(userID, syncableID, syncableType)
+		result, err := s.EmailQueueStore.GetReadyToSend(now, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -3436,11 +3694,11 @@ func (s *RetryLayerGroupStore) AdminRoleGroupsForSyncableMember(userID string, s
 
 }
 
-func (s *RetryLayerGroupStore) ChannelMembersMinusGroupMembers(channelID string, groupIDs []string, page int, perPage int) ([]*model.UserWithGroups, error) {
+func (s *RetryLayerEmailQueueStore) PermanentDeleteSentBefore(before int64, limit int64) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.ChannelMembersMinusGroupMembers(channelID, groupIDs, page, perPage)
+		result, err := s.EmailQueueStore.PermanentDeleteSentBefore(before, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -3456,11 +3714,11 @@ func (s *RetryLayerGroupStore) ChannelMembersMinusGroupMembers(channelID string,
 
 }
 
-func (s *RetryLayerGroupStore) ChannelMembersToAdd(since int64, channelID *string) ([]*model.UserChannelIDPair, error) {
+func (s *RetryLayerEmailQueueStore) Save(email *model.QueuedEmail) (*model.QueuedEmail, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.ChannelMembersToAdd(since, channelID)
+		result, err := s.EmailQueueStore.Save(email)
 		if err == nil {
 			return result, nil
 		}
@@ -3476,11 +3734,11 @@ func (s *RetryLayerGroupStore) ChannelMembersToAdd(since int64, channelID *strin
 
 }
 
-func (s *RetryLayerGroupStore) ChannelMembersToRemove(channelID *string) ([]*model.ChannelMember, error) {
+func (s *RetryLayerEmailQueueStore) Update(email *model.QueuedEmail) (*model.QueuedEmail, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.ChannelMembersToRemove(channelID)
+		result, err := s.EmailQueueStore.Update(email)
 		if err == nil {
 			return result, nil
 		}
@@ -3496,31 +3754,31 @@ func (s *RetryLayerGroupStore) ChannelMembersToRemove(channelID *string) ([]*mod
 
 }
 
-func (s *RetryLayerGroupStore) CountChannelMembersMinusGroupMembers(channelID string, groupIDs []string) (int64, error) {
+func (s *RetryLayerEmojiStore) Delete(emoji *model.Emoji, time int64) error {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.CountChannelMembersMinusGroupMembers(channelID, groupIDs)
+		err := s.EmojiStore.Delete(emoji, time)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 	}
 
 }
 
-func (s *RetryLayerGroupStore) CountGroupsByChannel(channelId string, opts model.GroupSearchOpts) (int64, error) {
+func (s *RetryLayerEmojiStore) Get(id string, allowFromCache bool) (*model.Emoji, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.CountGroupsByChannel(channelId, opts)
+		result, err := s.EmojiStore.Get(id, allowFromCache)
 		if err == nil {
 			return result, nil
 		}
@@ -3536,11 +3794,11 @@ func (s *RetryLayerGroupStore) CountGroupsByChannel(channelId string, opts model
 
 }
 
-func (s *RetryLayerGroupStore) CountGroupsByTeam(teamId string, opts model.GroupSearchOpts) (int64, error) {
+func (s *RetryLayerEmojiStore) GetByName(name string, allowFromCache bool) (*model.Emoji, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.CountGroupsByTeam(teamId, opts)
+		result, err := s.EmojiStore.GetByName(name, allowFromCache)
 		if err == nil {
 			return result, nil
 		}
@@ -3556,11 +3814,11 @@ func (s *RetryLayerGroupStore) CountGroupsByTeam(teamId string, opts model.Group
 
 }
 
-func (s *RetryLayerGroupStore) CountTeamMembersMinusGroupMembers(teamID string, groupIDs []string) (int64, error) {
+func (s *RetryLayerEmojiStore) GetList(offset int, limit int, sort string) ([]*model.Emoji, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.CountTeamMembersMinusGroupMembers(teamID, groupIDs)
+		result, err := s.EmojiStore.GetList(offset, limit, sort)
 		if err == nil {
 			return result, nil
 		}
@@ -3576,11 +3834,11 @@ func (s *RetryLayerGroupStore) CountTeamMembersMinusGroupMembers(teamID string,
 
 }
 
-func (s *RetryLayerGroupStore) Create(group *model.Group) (*model.Group, error) {
+func (s *RetryLayerEmojiStore) GetMultipleByName(names []string) ([]*model.Emoji, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.Create(group)
+		result, err := s.EmojiStore.GetMultipleByName(names)
 		if err == nil {
 			return result, nil
 		}
@@ -3596,11 +3854,11 @@ func (s *RetryLayerGroupStore) Create(group *model.Group) (*model.Group, error)
 
 }
 
-func (s *RetryLayerGroupStore) CreateGroupSyncable(groupSyncable *model.GroupSyncable) (*model.GroupSyncable, error) {
+func (s *RetryLayerEmojiStore) Save(emoji *model.Emoji) (*model.Emoji, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.CreateGroupSyncable(groupSyncable)
+		result, err := s.EmojiStore.Save(emoji)
 		if err == nil {
 			return result, nil
 		}
@@ -3616,11 +3874,11 @@ func (s *RetryLayerGroupStore) CreateGroupSyncable(groupSyncable *model.GroupSyn
 
 }
 
-func (s *RetryLayerGroupStore) Delete(groupID string) (*model.Group, error) {
+func (s *RetryLayerEmojiStore) Search(name string, prefixOnly bool, limit int) ([]*model.Emoji, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.Delete(groupID)
+		result, err := s.EmojiStore.Search(name, prefixOnly, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -3636,31 +3894,37 @@ func (s *RetryLayerGroupStore) Delete(groupID string) (*model.Group, error) {
 
 }
 
-func (s *RetryLayerGroupStore) DeleteGroupSyncable(groupID string, syncableID string, syncableType model.GroupSyncableType) (*model.GroupSyncable, error) {
+func (s *RetryLayerFileInfoStore) AttachToPost(fileId string, postId string, creatorId string) error {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.DeleteGroupSyncable(groupID, syncableID, syncableType)
+		err := s.FileInfoStore.AttachToPost(fileId, postId, creatorId)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 	}
 
 }
 
-func (s *RetryLayerGroupStore) DeleteMember(groupID string, userID string) (*model.GroupMember, error) {
+func (s *RetryLayerFileInfoStore) ClearCaches() {
+
+	s.FileInfoStore.ClearCaches()
+
+}
+
+func (s *RetryLayerFileInfoStore) CountByContentHash(contentHash string) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.DeleteMember(groupID, userID)
+		result, err := s.FileInfoStore.CountByContentHash(contentHash)
 		if err == nil {
 			return result, nil
 		}
@@ -3676,11 +3940,11 @@ func (s *RetryLayerGroupStore) DeleteMember(groupID string, userID string) (*mod
 
 }
 
-func (s *RetryLayerGroupStore) DistinctGroupMemberCount() (int64, error) {
+func (s *RetryLayerFileInfoStore) DeleteForPost(postId string) (string, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.DistinctGroupMemberCount()
+		result, err := s.FileInfoStore.DeleteForPost(postId)
 		if err == nil {
 			return result, nil
 		}
@@ -3696,11 +3960,11 @@ func (s *RetryLayerGroupStore) DistinctGroupMemberCount() (int64, error) {
 
 }
 
-func (s *RetryLayerGroupStore) Get(groupID string) (*model.Group, error) {
+func (s *RetryLayerFileInfoStore) Get(id string) (*model.FileInfo, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.Get(groupID)
+		result, err := s.FileInfoStore.Get(id)
 		if err == nil {
 			return result, nil
 		}
@@ -3716,11 +3980,11 @@ func (s *RetryLayerGroupStore) Get(groupID string) (*model.Group, error) {
 
 }
 
-func (s *RetryLayerGroupStore) GetAllBySource(groupSource model.GroupSource) ([]*model.Group, error) {
+func (s *RetryLayerFileInfoStore) GetByPath(path string) (*model.FileInfo, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.GetAllBySource(groupSource)
+		result, err := s.FileInfoStore.GetByPath(path)
 		if err == nil {
 			return result, nil
 		}
@@ -3736,11 +4000,11 @@ func (s *RetryLayerGroupStore) GetAllBySource(groupSource model.GroupSource) ([]
 
 }
 
-func (s *RetryLayerGroupStore) GetAllGroupSyncablesByGroupId(groupID string, syncableType model.GroupSyncableType) ([]*model.GroupSyncable, error) {
+func (s *RetryLayerFileInfoStore) GetFilesBatchForIndexing(startTime int64, endTime int64, limit int) ([]*model.FileForIndexing, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.GetAllGroupSyncablesByGroupId(groupID, syncableType)
+		result, err := s.FileInfoStore.GetFilesBatchForIndexing(startTime, endTime, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -3756,11 +4020,11 @@ func (s *RetryLayerGroupStore) GetAllGroupSyncablesByGroupId(groupID string, syn
 
 }
 
-func (s *RetryLayerGroupStore) GetByIDs(groupIDs []string) ([]*model.Group, error) {
+func (s *RetryLayerFileInfoStore) GetForPost(postId string, readFromMaster bool, includeDeleted bool, allowFromCache bool) ([]*model.FileInfo, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.GetByIDs(groupIDs)
+		result, err := s.FileInfoStore.GetForPost(postId, readFromMaster, includeDeleted, allowFromCache)
 		if err == nil {
 			return result, nil
 		}
@@ -3776,11 +4040,11 @@ func (s *RetryLayerGroupStore) GetByIDs(groupIDs []string) ([]*model.Group, erro
 
 }
 
-func (s *RetryLayerGroupStore) GetByName(name string, opts model.GroupSearchOpts) (*model.Group, error) {
+func (s *RetryLayerFileInfoStore) GetForUser(userId string) ([]*model.FileInfo, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.GetByName(name, opts)
+		result, err := s.FileInfoStore.GetForUser(userId)
 		if err == nil {
 			return result, nil
 		}
@@ -3796,11 +4060,11 @@ func (s *RetryLayerGroupStore) GetByName(name string, opts model.GroupSearchOpts
 
 }
 
-func (s *RetryLayerGroupStore) GetByRemoteID(remoteID string, groupSource model.GroupSource) (*model.Group, error) {
+func (s *RetryLayerFileInfoStore) GetPostIdsForExtensions(extensions []string, limit int) ([]string, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.GetByRemoteID(remoteID, groupSource)
+		result, err := s.FileInfoStore.GetPostIdsForExtensions(extensions, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -3816,11 +4080,11 @@ func (s *RetryLayerGroupStore) GetByRemoteID(remoteID string, groupSource model.
 
 }
 
-func (s *RetryLayerGroupStore) GetByUser(userId string) ([]*model.Group, error) {
+func (s *RetryLayerFileInfoStore) GetWithOptions(page int, perPage int, opt *model.GetFileInfosOptions) ([]*model.FileInfo, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.GetByUser(userId)
+		result, err := s.FileInfoStore.GetWithOptions(page, perPage, opt)
 		if err == nil {
 			return result, nil
 		}
@@ -3836,31 +4100,37 @@ func (s *RetryLayerGroupStore) GetByUser(userId string) ([]*model.Group, error)
 
 }
 
-func (s *RetryLayerGroupStore) GetGroupSyncable(groupID string, syncableID string, syncableType model.GroupSyncableType) (*model.GroupSyncable, error) {
+func (s *RetryLayerFileInfoStore) InvalidateFileInfosForPostCache(postId string, deleted bool) {
+
+	s.FileInfoStore.InvalidateFileInfosForPostCache(postId, deleted)
+
+}
+
+func (s *RetryLayerFileInfoStore) PermanentDelete(fileId string) error {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.GetGroupSyncable(groupID, syncableID, syncableType)
+		err := s.FileInfoStore.PermanentDelete(fileId)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 	}
 
 }
 
-func (s *RetryLayerGroupStore) GetGroups(page int, perPage int, opts model.GroupSearchOpts) ([]*model.Group, error) {
+func (s *RetryLayerFileInfoStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.GetGroups(page, perPage, opts)
+		result, err := s.FileInfoStore.PermanentDeleteBatch(endTime, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -3876,11 +4146,11 @@ func (s *RetryLayerGroupStore) GetGroups(page int, perPage int, opts model.Group
 
 }
 
-func (s *RetryLayerGroupStore) GetGroupsAssociatedToChannelsByTeam(teamId string, opts model.GroupSearchOpts) (map[string][]*model.GroupWithSchemeAdmin, error) {
+func (s *RetryLayerFileInfoStore) PermanentDeleteByUser(userId string) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.GetGroupsAssociatedToChannelsByTeam(teamId, opts)
+		result, err := s.FileInfoStore.PermanentDeleteByUser(userId)
 		if err == nil {
 			return result, nil
 		}
@@ -3896,31 +4166,31 @@ func (s *RetryLayerGroupStore) GetGroupsAssociatedToChannelsByTeam(teamId string
 
 }
 
-func (s *RetryLayerGroupStore) GetGroupsByChannel(channelId string, opts model.GroupSearchOpts) ([]*model.GroupWithSchemeAdmin, error) {
+func (s *RetryLayerFileInfoStore) Quarantine(path string, quarantinePath string) error {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.GetGroupsByChannel(channelId, opts)
+		err := s.FileInfoStore.Quarantine(path, quarantinePath)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 	}
 
 }
 
-func (s *RetryLayerGroupStore) GetGroupsByTeam(teamId string, opts model.GroupSearchOpts) ([]*model.GroupWithSchemeAdmin, error) {
+func (s *RetryLayerFileInfoStore) Save(info *model.FileInfo) (*model.FileInfo, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.GetGroupsByTeam(teamId, opts)
+		result, err := s.FileInfoStore.Save(info)
 		if err == nil {
 			return result, nil
 		}
@@ -3936,11 +4206,11 @@ func (s *RetryLayerGroupStore) GetGroupsByTeam(teamId string, opts model.GroupSe
 
 }
 
-func (s *RetryLayerGroupStore) GetMemberCount(groupID string) (int64, error) {
+func (s *RetryLayerFileInfoStore) Search(paramsList []*model.SearchParams, userId string, teamId string, page int, perPage int) (*model.FileInfoList, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.GetMemberCount(groupID)
+		result, err := s.FileInfoStore.Search(paramsList, userId, teamId, page, perPage)
 		if err == nil {
 			return result, nil
 		}
@@ -3956,51 +4226,51 @@ func (s *RetryLayerGroupStore) GetMemberCount(groupID string) (int64, error) {
 
 }
 
-func (s *RetryLayerGroupStore) GetMemberUsers(groupID string) ([]*model.User, error) {
+func (s *RetryLayerFileInfoStore) SetContent(fileId string, content string) error {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.GetMemberUsers(groupID)
+		err := s.FileInfoStore.SetContent(fileId, content)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 	}
 
 }
 
-func (s *RetryLayerGroupStore) GetMemberUsersInTeam(groupID string, teamID string) ([]*model.User, error) {
+func (s *RetryLayerFileInfoStore) SetContentHash(path string, contentHash string, blobPath string) error {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.GetMemberUsersInTeam(groupID, teamID)
+		err := s.FileInfoStore.SetContentHash(path, contentHash, blobPath)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 	}
 
 }
 
-func (s *RetryLayerGroupStore) GetMemberUsersNotInChannel(groupID string, channelID string) ([]*model.User, error) {
+func (s *RetryLayerFileInfoStore) Upsert(info *model.FileInfo) (*model.FileInfo, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.GetMemberUsersNotInChannel(groupID, channelID)
+		result, err := s.FileInfoStore.Upsert(info)
 		if err == nil {
 			return result, nil
 		}
@@ -4016,11 +4286,11 @@ func (s *RetryLayerGroupStore) GetMemberUsersNotInChannel(groupID string, channe
 
 }
 
-func (s *RetryLayerGroupStore) GetMemberUsersPage(groupID string, page int, perPage int) ([]*model.User, error) {
+func (s *RetryLayerGroupStore) AdminRoleGroupsForSyncableMember(userID string, syncableID string, syncableType model.GroupSyncableType) ([]string, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.GetMemberUsersPage(groupID, page, perPage)
+		result, err := s.GroupStore.AdminRoleGroupsForSyncableMember(userID, syncableID, syncableType)
 		if err == nil {
 			return result, nil
 		}
@@ -4036,11 +4306,11 @@ func (s *RetryLayerGroupStore) GetMemberUsersPage(groupID string, page int, perP
 
 }
 
-func (s *RetryLayerGroupStore) GroupChannelCount() (int64, error) {
+func (s *RetryLayerGroupStore) ChannelMembersMinusGroupMembers(channelID string, groupIDs []string, page int, perPage int) ([]*model.UserWithGroups, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.GroupChannelCount()
+		result, err := s.GroupStore.ChannelMembersMinusGroupMembers(channelID, groupIDs, page, perPage)
 		if err == nil {
 			return result, nil
 		}
@@ -4056,11 +4326,11 @@ func (s *RetryLayerGroupStore) GroupChannelCount() (int64, error) {
 
 }
 
-func (s *RetryLayerGroupStore) GroupCount() (int64, error) {
+func (s *RetryLayerGroupStore) ChannelMembersToAdd(since int64, channelID *string) ([]*model.UserChannelIDPair, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.GroupCount()
+		result, err := s.GroupStore.ChannelMembersToAdd(since, channelID)
 		if err == nil {
 			return result, nil
 		}
@@ -4076,11 +4346,11 @@ func (s *RetryLayerGroupStore) GroupCount() (int64, error) {
 
 }
 
-func (s *RetryLayerGroupStore) GroupCountWithAllowReference() (int64, error) {
+func (s *RetryLayerGroupStore) ChannelMembersToRemove(channelID *string) ([]*model.ChannelMember, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.GroupCountWithAllowReference()
+		result, err := s.GroupStore.ChannelMembersToRemove(channelID)
 		if err == nil {
 			return result, nil
 		}
@@ -4096,11 +4366,11 @@ func (s *RetryLayerGroupStore) GroupCountWithAllowReference() (int64, error) {
 
 }
 
-func (s *RetryLayerGroupStore) GroupMemberCount() (int64, error) {
+func (s *RetryLayerGroupStore) CountChannelMembersMinusGroupMembers(channelID string, groupIDs []string) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.GroupMemberCount()
+		result, err := s.GroupStore.CountChannelMembersMinusGroupMembers(channelID, groupIDs)
 		if err == nil {
 			return result, nil
 		}
@@ -4116,11 +4386,11 @@ func (s *RetryLayerGroupStore) GroupMemberCount() (int64, error) {
 
 }
 
-func (s *RetryLayerGroupStore) GroupTeamCount() (int64, error) {
+func (s *RetryLayerGroupStore) CountGroupsByChannel(channelId string, opts model.GroupSearchOpts) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.GroupTeamCount()
+		result, err := s.GroupStore.CountGroupsByChannel(channelId, opts)
 		if err == nil {
 			return result, nil
 		}
@@ -4136,31 +4406,31 @@ func (s *RetryLayerGroupStore) GroupTeamCount() (int64, error) {
 
 }
 
-func (s *RetryLayerGroupStore) PermanentDeleteMembersByUser(userId string) error {
+func (s *RetryLayerGroupStore) CountGroupsByTeam(teamId string, opts model.GroupSearchOpts) (int64, error) {
 
 	tries := 0
 	for {
-		err := s.GroupStore.PermanentDeleteMembersByUser(userId)
+		result, err := s.GroupStore.CountGroupsByTeam(teamId, opts)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 	}
 
 }
 
-func (s *RetryLayerGroupStore) PermittedSyncableAdmins(syncableID string, syncableType model.GroupSyncableType) ([]string, error) {
+func (s *RetryLayerGroupStore) CountTeamMembersMinusGroupMembers(teamID string, groupIDs []string) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.PermittedSyncableAdmins(syncableID, syncableType)
+		result, err := s.GroupStore.CountTeamMembersMinusGroupMembers(teamID, groupIDs)
 		if err == nil {
 			return result, nil
 		}
@@ -4176,11 +4446,11 @@ func (s *RetryLayerGroupStore) PermittedSyncableAdmins(syncableID string, syncab
 
 }
 
-func (s *RetryLayerGroupStore) TeamMembersMinusGroupMembers(teamID string, groupIDs []string, page int, perPage int) ([]*model.UserWithGroups, error) {
+func (s *RetryLayerGroupStore) Create(group *model.Group) (*model.Group, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.TeamMembersMinusGroupMembers(teamID, groupIDs, page, perPage)
+		result, err := s.GroupStore.Create(group)
 		if err == nil {
 			return result, nil
 		}
@@ -4196,11 +4466,11 @@ func (s *RetryLayerGroupStore) TeamMembersMinusGroupMembers(teamID string, group
 
 }
 
-func (s *RetryLayerGroupStore) TeamMembersToAdd(since int64, teamID *string) ([]*model.UserTeamIDPair, error) {
+func (s *RetryLayerGroupStore) CreateGroupSyncable(groupSyncable *model.GroupSyncable) (*model.GroupSyncable, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.TeamMembersToAdd(since, teamID)
+		result, err := s.GroupStore.CreateGroupSyncable(groupSyncable)
 		if err == nil {
 			return result, nil
 		}
@@ -4216,11 +4486,11 @@ func (s *RetryLayerGroupStore) TeamMembersToAdd(since int64, teamID *string) ([]
 
 }
 
-func (s *RetryLayerGroupStore) TeamMembersToRemove(teamID *string) ([]*model.TeamMember, error) {
+func (s *RetryLayerGroupStore) Delete(groupID string) (*model.Group, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.TeamMembersToRemove(teamID)
+		result, err := s.GroupStore.Delete(groupID)
 		if err == nil {
 			return result, nil
 		}
@@ -4236,11 +4506,11 @@ func (s *RetryLayerGroupStore) TeamMembersToRemove(teamID *string) ([]*model.Tea
 
 }
 
-func (s *RetryLayerGroupStore) Update(group *model.Group) (*model.Group, error) {
+func (s *RetryLayerGroupStore) DeleteGroupSyncable(groupID string, syncableID string, syncableType model.GroupSyncableType) (*model.GroupSyncable, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.Update(group)
+		result, err := s.GroupStore.DeleteGroupSyncable(groupID, syncableID, syncableType)
 		if err == nil {
 			return result, nil
 		}
@@ -4256,11 +4526,11 @@ func (s *RetryLayerGroupStore) Update(group *model.Group) (*model.Group, error)
 
 }
 
-func (s *RetryLayerGroupStore) UpdateGroupSyncable(groupSyncable *model.GroupSyncable) (*model.GroupSyncable, error) {
+func (s *RetryLayerGroupStore) DeleteMember(groupID string, userID string) (*model.GroupMember, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.UpdateGroupSyncable(groupSyncable)
+		result, err := s.GroupStore.DeleteMember(groupID, userID)
 		if err == nil {
 			return result, nil
 		}
@@ -4276,11 +4546,11 @@ func (s *RetryLayerGroupStore) UpdateGroupSyncable(groupSyncable *model.GroupSyn
 
 }
 
-func (s *RetryLayerGroupStore) UpsertMember(groupID string, userID string) (*model.GroupMember, error) {
+func (s *RetryLayerGroupStore) DistinctGroupMemberCount() (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.UpsertMember(groupID, userID)
+		result, err := s.GroupStore.DistinctGroupMemberCount()
 		if err == nil {
 			return result, nil
 		}
@@ -4296,11 +4566,11 @@ func (s *RetryLayerGroupStore) UpsertMember(groupID string, userID string) (*mod
 
 }
 
-func (s *RetryLayerJobStore) Delete(id string) (string, error) {
+func (s *RetryLayerGroupStore) Get(groupID string) (*model.Group, error) {
 
 	tries := 0
 	for {
-		result, err := s.JobStore.Delete(id)
+		result, err := s.GroupStore.Get(groupID)
 		if err == nil {
 			return result, nil
 		}
@@ -4316,11 +4586,11 @@ func (s *RetryLayerJobStore) Delete(id string) (string, error) {
 
 }
 
-func (s *RetryLayerJobStore) Get(id string) (*model.Job, error) {
+func (s *RetryLayerGroupStore) GetAllBySource(groupSource model.GroupSource) ([]*model.Group, error) {
 
 	tries := 0
 	for {
-		result, err := s.JobStore.Get(id)
+		result, err := s.GroupStore.GetAllBySource(groupSource)
 		if err == nil {
 			return result, nil
 		}
@@ -4336,11 +4606,11 @@ func (s *RetryLayerJobStore) Get(id string) (*model.Job, error) {
 
 }
 
-func (s *RetryLayerJobStore) GetAllByStatus(status string) ([]*model.Job, error) {
+func (s *RetryLayerGroupStore) GetAllGroupSyncablesByGroupId(groupID string, syncableType model.GroupSyncableType) ([]*model.GroupSyncable, error) {
 
 	tries := 0
 	for {
-		result, err := s.JobStore.GetAllByStatus(status)
+		result, err := s.GroupStore.GetAllGroupSyncablesByGroupId(groupID, syncableType)
 		if err == nil {
 			return result, nil
 		}
@@ -4356,11 +4626,11 @@ func (s *RetryLayerJobStore) GetAllByStatus(status string) ([]*model.Job, error)
 
 }
 
-func (s *RetryLayerJobStore) GetAllByType(jobType string) ([]*model.Job, error) {
+func (s *RetryLayerGroupStore) GetByIDs(groupIDs []string) ([]*model.Group, error) {
 
 	tries := 0
 	for {
-		result, err := s.JobStore.GetAllByType(jobType)
+		result, err := s.GroupStore.GetByIDs(groupIDs)
 		if err == nil {
 			return result, nil
 		}
@@ -4376,11 +4646,11 @@ func (s *RetryLayerJobStore) GetAllByType(jobType string) ([]*model.Job, error)
 
 }
 
-func (s *RetryLayerJobStore) GetAllByTypePage(jobType string, offset int, limit int) ([]*model.Job, error) {
+func (s *RetryLayerGroupStore) GetByName(name string, opts model.GroupSearchOpts) (*model.Group, error) {
 
 	tries := 0
 	for {
-		result, err := s.JobStore.GetAllByTypePage(jobType, offset, limit)
+		result, err := s.GroupStore.GetByName(name, opts)
 		if err == nil {
 			return result, nil
 		}
@@ -4396,11 +4666,11 @@ func (s *RetryLayerJobStore) GetAllByTypePage(jobType string, offset int, limit
 
 }
 
-func (s *RetryLayerJobStore) GetAllPage(offset int, limit int) ([]*model.Job, error) {
+func (s *RetryLayerGroupStore) GetByRemoteID(remoteID string, groupSource model.GroupSource) (*model.Group, error) {
 
 	tries := 0
 	for {
-		result, err := s.JobStore.GetAllPage(offset, limit)
+		result, err := s.GroupStore.GetByRemoteID(remoteID, groupSource)
 		if err == nil {
 			return result, nil
 		}
@@ -4416,11 +4686,11 @@ func (s *RetryLayerJobStore) GetAllPage(offset int, limit int) ([]*model.Job, er
 
 }
 
-func (s *RetryLayerJobStore) GetCountByStatusAndType(status string, jobType string) (int64, error) {
+func (s *RetryLayerGroupStore) GetByUser(userId string) ([]*model.Group, error) {
 
 	tries := 0
 	for {
-		result, err := s.JobStore.GetCountByStatusAndType(status, jobType)
+		result, err := s.GroupStore.GetByUser(userId)
 		if err == nil {
 			return result, nil
 		}
@@ -4436,11 +4706,11 @@ func (s *RetryLayerJobStore) GetCountByStatusAndType(status string, jobType stri
 
 }
 
-func (s *RetryLayerJobStore) GetNewestJobByStatusAndType(status string, jobType string) (*model.Job, error) {
+func (s *RetryLayerGroupStore) GetGroupSyncable(groupID string, syncableID string, syncableType model.GroupSyncableType) (*model.GroupSyncable, error) {
 
 	tries := 0
 	for {
-		result, err := s.JobStore.GetNewestJobByStatusAndType(status, jobType)
+		result, err := s.GroupStore.GetGroupSyncable(groupID, syncableID, syncableType)
 		if err == nil {
 			return result, nil
 		}
@@ -4456,11 +4726,11 @@ func (s *RetryLayerJobStore) GetNewestJobByStatusAndType(status string, jobType
 
 }
 
-func (s *RetryLayerJobStore) GetNewestJobByStatusesAndType(statuses []string, jobType string) (*model.Job, error) {
+func (s *RetryLayerGroupStore) GetGroups(page int, perPage int, opts model.GroupSearchOpts) ([]*model.Group, error) {
 
 	tries := 0
 	for {
-		result, err := s.JobStore.GetNewestJobByStatusesAndType(statuses, jobType)
+		result, err := s.GroupStore.GetGroups(page, perPage, opts)
 		if err == nil {
 			return result, nil
 		}
@@ -4476,11 +4746,11 @@ func (s *RetryLayerJobStore) GetNewestJobByStatusesAndType(statuses []string, jo
 
 }
 
-func (s *RetryLayerJobStore) Save(job *model.Job) (*model.Job, error) {
+func (s *RetryLayerGroupStore) GetGroupsAssociatedToChannelsByTeam(teamId string, opts model.GroupSearchOpts) (map[string][]*model.GroupWithSchemeAdmin, error) {
 
 	tries := 0
 	for {
-		result, err := s.JobStore.Save(job)
+		result, err := s.GroupStore.GetGroupsAssociatedToChannelsByTeam(teamId, opts)
 		if err == nil {
 			return result, nil
 		}
@@ -4496,11 +4766,11 @@ func (s *RetryLayerJobStore) Save(job *model.Job) (*model.Job, error) {
 
 }
 
-func (s *RetryLayerJobStore) UpdateOptimistically(job *model.Job, currentStatus string) (bool, error) {
+func (s *RetryLayerGroupStore) GetGroupsByChannel(channelId string, opts model.GroupSearchOpts) ([]*model.GroupWithSchemeAdmin, error) {
 
 	tries := 0
 	for {
-		result, err := s.JobStore.UpdateOptimistically(job, currentStatus)
+		result, err := s.GroupStore.GetGroupsByChannel(channelId, opts)
 		if err == nil {
 			return result, nil
 		}
@@ -4516,11 +4786,11 @@ func (s *RetryLayerJobStore) UpdateOptimistically(job *model.Job, currentStatus
 
 }
 
-func (s *RetryLayerJobStore) UpdateStatus(id string, status string) (*model.Job, error) {
+func (s *RetryLayerGroupStore) GetGroupsByTeam(teamId string, opts model.GroupSearchOpts) ([]*model.GroupWithSchemeAdmin, error) {
 
 	tries := 0
 	for {
-		result, err := s.JobStore.UpdateStatus(id, status)
+		result, err := s.GroupStore.GetGroupsByTeam(teamId, opts)
 		if err == nil {
 			return result, nil
 		}
@@ -4536,11 +4806,11 @@ func (s *RetryLayerJobStore) UpdateStatus(id string, status string) (*model.Job,
 
 }
 
-func (s *RetryLayerJobStore) UpdateStatusOptimistically(id string, currentStatus string, newStatus string) (bool, error) {
+func (s *RetryLayerGroupStore) GetMemberCount(groupID string) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.JobStore.UpdateStatusOptimistically(id, currentStatus, newStatus)
+		result, err := s.GroupStore.GetMemberCount(groupID)
 		if err == nil {
 			return result, nil
 		}
@@ -4556,11 +4826,11 @@ func (s *RetryLayerJobStore) UpdateStatusOptimistically(id string, currentStatus
 
 }
 
-func (s *RetryLayerLicenseStore) Get(id string) (*model.LicenseRecord, error) {
+func (s *RetryLayerGroupStore) GetMemberUsers(groupID string) ([]*model.User, error) {
 
 	tries := 0
 	for {
-		result, err := s.LicenseStore.Get(id)
+		result, err := s.GroupStore.GetMemberUsers(groupID)
 		if err == nil {
 			return result, nil
 		}
@@ -4576,11 +4846,11 @@ func (s *RetryLayerLicenseStore) Get(id string) (*model.LicenseRecord, error) {
 
 }
 
-func (s *RetryLayerLicenseStore) Save(license *model.LicenseRecord) (*model.LicenseRecord, error) {
+func (s *RetryLayerGroupStore) GetMemberUsersInTeam(groupID string, teamID string) ([]*model.User, error) {
 
 	tries := 0
 	for {
-		result, err := s.LicenseStore.Save(license)
+		result, err := s.GroupStore.GetMemberUsersInTeam(groupID, teamID)
 		if err == nil {
 			return result, nil
 		}
@@ -4596,11 +4866,11 @@ func (s *RetryLayerLicenseStore) Save(license *model.LicenseRecord) (*model.Lice
 
 }
 
-func (s *RetryLayerLinkMetadataStore) Get(url string, timestamp int64) (*model.LinkMetadata, error) {
+func (s *RetryLayerGroupStore) GetMemberUsersNotInChannel(groupID string, channelID string) ([]*model.User, error) {
 
 	tries := 0
 	for {
-		result, err := s.LinkMetadataStore.Get(url, timestamp)
+		result, err := s.GroupStore.GetMemberUsersNotInChannel(groupID, channelID)
 		if err == nil {
 			return result, nil
 		}
@@ -4616,11 +4886,11 @@ func (s *RetryLayerLinkMetadataStore) Get(url string, timestamp int64) (*model.L
 
 }
 
-func (s *RetryLayerLinkMetadataStore) Save(linkMetadata *model.LinkMetadata) (*model.LinkMetadata, error) {
+func (s *RetryLayerGroupStore) GetMemberUsersPage(groupID string, page int, perPage int) ([]*model.User, error) {
 
 	tries := 0
 	for {
-		result, err := s.LinkMetadataStore.Save(linkMetadata)
+		result, err := s.GroupStore.GetMemberUsersPage(groupID, page, perPage)
 		if err == nil {
 			return result, nil
 		}
@@ -4636,31 +4906,31 @@ func (s *RetryLayerLinkMetadataStore) Save(linkMetadata *model.LinkMetadata) (*m
 
 }
 
-func (s *RetryLayerOAuthStore) DeleteApp(id string) error {
+func (s *RetryLayerGroupStore) GroupChannelCount() (int64, error) {
 
 	tries := 0
 	for {
-		err := s.OAuthStore.DeleteApp(id)
+		result, err := s.GroupStore.GroupChannelCount()
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 	}
 
 }
 
-func (s *RetryLayerOAuthStore) GetAccessData(token string) (*model.AccessData, error) {
+func (s *RetryLayerGroupStore) GroupCount() (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.OAuthStore.GetAccessData(token)
+		result, err := s.GroupStore.GroupCount()
 		if err == nil {
 			return result, nil
 		}
@@ -4676,11 +4946,11 @@ func (s *RetryLayerOAuthStore) GetAccessData(token string) (*model.AccessData, e
 
 }
 
-func (s *RetryLayerOAuthStore) GetAccessDataByRefreshToken(token string) (*model.AccessData, error) {
+func (s *RetryLayerGroupStore) GroupCountWithAllowReference() (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.OAuthStore.GetAccessDataByRefreshToken(token)
+		result, err := s.GroupStore.GroupCountWithAllowReference()
 		if err == nil {
 			return result, nil
 		}
@@ -4696,11 +4966,11 @@ func (s *RetryLayerOAuthStore) GetAccessDataByRefreshToken(token string) (*model
 
 }
 
-func (s *RetryLayerOAuthStore) GetAccessDataByUserForApp(userId string, clientId string) ([]*model.AccessData, error) {
+func (s *RetryLayerGroupStore) GroupMemberCount() (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.OAuthStore.GetAccessDataByUserForApp(userId, clientId)
+		result, err := s.GroupStore.GroupMemberCount()
 		if err == nil {
 			return result, nil
 		}
@@ -4716,11 +4986,11 @@ func (s *RetryLayerOAuthStore) GetAccessDataByUserForApp(userId string, clientId
 
 }
 
-func (s *RetryLayerOAuthStore) GetApp(id string) (*model.OAuthApp, error) {
+func (s *RetryLayerGroupStore) GroupTeamCount() (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.OAuthStore.GetApp(id)
+		result, err := s.GroupStore.GroupTeamCount()
 		if err == nil {
 			return result, nil
 		}
@@ -4736,31 +5006,31 @@ func (s *RetryLayerOAuthStore) GetApp(id string) (*model.OAuthApp, error) {
 
 }
 
-func (s *RetryLayerOAuthStore) GetAppByUser(userId string, offset int, limit int) ([]*model.OAuthApp, error) {
+func (s *RetryLayerGroupStore) PermanentDeleteMembersByUser(userId string) error {
 
 	tries := 0
 	for {
-		result, err := s.OAuthStore.GetAppByUser(userId, offset, limit)
+		err := s.GroupStore.PermanentDeleteMembersByUser(userId)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 	}
 
 }
 
-func (s *RetryLayerOAuthStore) GetApps(offset int, limit int) ([]*model.OAuthApp, error) {
+func (s *RetryLayerGroupStore) PermittedSyncableAdmins(syncableID string, syncableType model.GroupSyncableType) ([]string, error) {
 
 	tries := 0
 	for {
-		result, err := s.OAuthStore.GetApps(offset, limit)
+		result, err := s.GroupStore.PermittedSyncableAdmins(syncableID, syncableType)
 		if err == nil {
 			return result, nil
 		}
@@ -4776,11 +5046,11 @@ func (s *RetryLayerOAuthStore) GetApps(offset int, limit int) ([]*model.OAuthApp
 
 }
 
-func (s *RetryLayerOAuthStore) GetAuthData(code string) (*model.AuthData, error) {
+func (s *RetryLayerGroupStore) TeamMembersMinusGroupMembers(teamID string, groupIDs []string, page int, perPage int) ([]*model.UserWithGroups, error) {
 
 	tries := 0
 	for {
-		result, err := s.OAuthStore.GetAuthData(code)
+		result, err := s.GroupStore.TeamMembersMinusGroupMembers(teamID, groupIDs, page, perPage)
 		if err == nil {
 			return result, nil
 		}
@@ -4796,11 +5066,11 @@ func (s *RetryLayerOAuthStore) GetAuthData(code string) (*model.AuthData, error)
 
 }
 
-func (s *RetryLayerOAuthStore) GetAuthorizedApps(userId string, offset int, limit int) ([]*model.OAuthApp, error) {
+func (s *RetryLayerGroupStore) TeamMembersToAdd(since int64, teamID *string) ([]*model.UserTeamIDPair, error) {
 
 	tries := 0
 	for {
-		result, err := s.OAuthStore.GetAuthorizedApps(userId, offset, limit)
+		result, err := s.GroupStore.TeamMembersToAdd(since, teamID)
 		if err == nil {
 			return result, nil
 		}
@@ -4816,11 +5086,11 @@ func (s *RetryLayerOAuthStore) GetAuthorizedApps(userId string, offset int, limi
 
 }
 
-func (s *RetryLayerOAuthStore) GetPreviousAccessData(userId string, clientId string) (*model.AccessData, error) {
+func (s *RetryLayerGroupStore) TeamMembersToRemove(teamID *string) ([]*model.TeamMember, error) {
 
 	tries := 0
 	for {
-		result, err := s.OAuthStore.GetPreviousAccessData(userId, clientId)
+		result, err := s.GroupStore.TeamMembersToRemove(teamID)
 		if err == nil {
 			return result, nil
 		}
@@ -4836,91 +5106,91 @@ func (s *RetryLayerOAuthStore) GetPreviousAccessData(userId string, clientId str
 
 }
 
-func (s *RetryLayerOAuthStore) PermanentDeleteAuthDataByUser(userId string) error {
+func (s *RetryLayerGroupStore) Update(group *model.Group) (*model.Group, error) {
 
 	tries := 0
 	for {
-		err := s.OAuthStore.PermanentDeleteAuthDataByUser(userId)
+		result, err := s.GroupStore.Update(group)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 	}
 
 }
 
-func (s *RetryLayerOAuthStore) RemoveAccessData(token string) error {
+func (s *RetryLayerGroupStore) UpdateGroupSyncable(groupSyncable *model.GroupSyncable) (*model.GroupSyncable, error) {
 
 	tries := 0
 	for {
-		err := s.OAuthStore.RemoveAccessData(token)
+		result, err := s.GroupStore.UpdateGroupSyncable(groupSyncable)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 	}
 
 }
 
-func (s *RetryLayerOAuthStore) RemoveAllAccessData() error {
+func (s *RetryLayerGroupStore) UpsertMember(groupID string, userID string) (*model.GroupMember, error) {
 
 	tries := 0
 	for {
-		err := s.OAuthStore.RemoveAllAccessData()
+		result, err := s.GroupStore.UpsertMember(groupID, userID)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 	}
 
 }
 
-func (s *RetryLayerOAuthStore) RemoveAuthData(code string) error {
+func (s *RetryLayerJobStore) Delete(id string) (string, error) {
 
 	tries := 0
 	for {
-		err := s.OAuthStore.RemoveAuthData(code)
+		result, err := s.JobStore.Delete(id)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 	}
 
 }
 
-func (s *RetryLayerOAuthStore) SaveAccessData(accessData *model.AccessData) (*model.AccessData, error) {
+func (s *RetryLayerJobStore) Get(id string) (*model.Job, error) {
 
 	tries := 0
 	for {
-		result, err := s.OAuthStore.SaveAccessData(accessData)
+		result, err := s.JobStore.Get(id)
 		if err == nil {
 			return result, nil
 		}
@@ -4936,11 +5206,11 @@ func (s *RetryLayerOAuthStore) SaveAccessData(accessData *model.AccessData) (*mo
 
 }
 
-func (s *RetryLayerOAuthStore) SaveApp(app *model.OAuthApp) (*model.OAuthApp, error) {
+func (s *RetryLayerJobStore) GetAllByStatus(status string) ([]*model.Job, error) {
 
 	tries := 0
 	for {
-		result, err := s.OAuthStore.SaveApp(app)
+		result, err := s.JobStore.GetAllByStatus(status)
 		if err == nil {
 			return result, nil
 		}
@@ -4956,11 +5226,11 @@ func (s *RetryLayerOAuthStore) SaveApp(app *model.OAuthApp) (*model.OAuthApp, er
 
 }
 
-func (s *RetryLayerOAuthStore) SaveAuthData(authData *model.AuthData) (*model.AuthData, error) {
+func (s *RetryLayerJobStore) GetAllByType(jobType string) ([]*model.Job, error) {
 
 	tries := 0
 	for {
-		result, err := s.OAuthStore.SaveAuthData(authData)
+		result, err := s.JobStore.GetAllByType(jobType)
 		if err == nil {
 			return result, nil
 		}
@@ -4976,11 +5246,11 @@ func (s *RetryLayerOAuthStore) SaveAuthData(authData *model.AuthData) (*model.Au
 
 }
 
-func (s *RetryLayerOAuthStore) UpdateAccessData(accessData *model.AccessData) (*model.AccessData, error) {
+func (s *RetryLayerJobStore) GetAllByTypePage(jobType string, offset int, limit int) ([]*model.Job, error) {
 
 	tries := 0
 	for {
-		result, err := s.OAuthStore.UpdateAccessData(accessData)
+		result, err := s.JobStore.GetAllByTypePage(jobType, offset, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -4996,11 +5266,11 @@ func (s *RetryLayerOAuthStore) UpdateAccessData(accessData *model.AccessData) (*
 
 }
 
-func (s *RetryLayerOAuthStore) UpdateApp(app *model.OAuthApp) (*model.OAuthApp, error) {
+func (s *RetryLayerJobStore) GetAllPage(offset int, limit int) ([]*model.Job, error) {
 
 	tries := 0
 	for {
-		result, err := s.OAuthStore.UpdateApp(app)
+		result, err := s.JobStore.GetAllPage(offset, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -5016,11 +5286,11 @@ func (s *RetryLayerOAuthStore) UpdateApp(app *model.OAuthApp) (*model.OAuthApp,
 
 }
 
-func (s *RetryLayerPluginStore) CompareAndDelete(keyVal *model.PluginKeyValue, oldValue []byte) (bool, error) {
+func (s *RetryLayerJobStore) GetCountByStatusAndType(status string, jobType string) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.PluginStore.CompareAndDelete(keyVal, oldValue)
+		result, err := s.JobStore.GetCountByStatusAndType(status, jobType)
 		if err == nil {
 			return result, nil
 		}
@@ -5036,11 +5306,11 @@ func (s *RetryLayerPluginStore) CompareAndDelete(keyVal *model.PluginKeyValue, o
 
 }
 
-func (s *RetryLayerPluginStore) CompareAndSet(keyVal *model.PluginKeyValue, oldValue []byte) (bool, error) {
+func (s *RetryLayerJobStore) GetNewestJobByStatusAndType(status string, jobType string) (*model.Job, error) {
 
 	tries := 0
 	for {
-		result, err := s.PluginStore.CompareAndSet(keyVal, oldValue)
+		result, err := s.JobStore.GetNewestJobByStatusAndType(status, jobType)
 		if err == nil {
 			return result, nil
 		}
@@ -5056,71 +5326,71 @@ func (s *RetryLayerPluginStore) CompareAndSet(keyVal *model.PluginKeyValue, oldV
 
 }
 
-func (s *RetryLayerPluginStore) Delete(pluginId string, key string) error {
+func (s *RetryLayerJobStore) GetNewestJobByStatusesAndType(statuses []string, jobType string) (*model.Job, error) {
 
 	tries := 0
 	for {
-		err := s.PluginStore.Delete(pluginId, key)
+		result, err := s.JobStore.GetNewestJobByStatusesAndType(statuses, jobType)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 	}
 
 }
 
-func (s *RetryLayerPluginStore) DeleteAllExpired() error {
+func (s *RetryLayerJobStore) Save(job *model.Job) (*model.Job, error) {
 
 	tries := 0
 	for {
-		err := s.PluginStore.DeleteAllExpired()
+		result, err := s.JobStore.Save(job)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 	}
 
 }
 
-func (s *RetryLayerPluginStore) DeleteAllForPlugin(PluginId string) error {
+func (s *RetryLayerJobStore) UpdateOptimistically(job *model.Job, currentStatus string) (bool, error) {
 
 	tries := 0
 	for {
-		err := s.PluginStore.DeleteAllForPlugin(PluginId)
+		result, err := s.JobStore.UpdateOptimistically(job, currentStatus)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 	}
 
 }
 
-func (s *RetryLayerPluginStore) Get(pluginId string, key string) (*model.PluginKeyValue, error) {
+func (s *RetryLayerJobStore) UpdateStatus(id string, status string) (*model.Job, error) {
 
 	tries := 0
 	for {
-		result, err := s.PluginStore.Get(pluginId, key)
+		result, err := s.JobStore.UpdateStatus(id, status)
 		if err == nil {
 			return result, nil
 		}
@@ -5136,11 +5406,11 @@ func (s *RetryLayerPluginStore) Get(pluginId string, key string) (*model.PluginK
 
 }
 
-func (s *RetryLayerPluginStore) List(pluginId string, page int, perPage int) ([]string, error) {
+func (s *RetryLayerJobStore) UpdateStatusOptimistically(id string, currentStatus string, newStatus string) (bool, error) {
 
 	tries := 0
 	for {
-		result, err := s.PluginStore.List(pluginId, page, perPage)
+		result, err := s.JobStore.UpdateStatusOptimistically(id, currentStatus, newStatus)
 		if err == nil {
 			return result, nil
 		}
@@ -5156,31 +5426,31 @@ func (s *RetryLayerPluginStore) List(pluginId string, page int, perPage int) ([]
 
 }
 
-func (s *RetryLayerPluginStore) SaveOrUpdate(keyVal *model.PluginKeyValue) (*model.PluginKeyValue, error) {
+func (s *RetryLayerKeywordWatchStore) Delete(id string) error {
 
 	tries := 0
 	for {
-		result, err := s.PluginStore.SaveOrUpdate(keyVal)
+		err := s.KeywordWatchStore.Delete(id)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 	}
 
 }
 
-func (s *RetryLayerPluginStore) SetWithOptions(pluginId string, key string, value []byte, options model.PluginKVSetOptions) (bool, error) {
+func (s *RetryLayerKeywordWatchStore) Get(id string) (*model.KeywordWatch, error) {
 
 	tries := 0
 	for {
-		result, err := s.PluginStore.SetWithOptions(pluginId, key, value, options)
+		result, err := s.KeywordWatchStore.Get(id)
 		if err == nil {
 			return result, nil
 		}
@@ -5196,11 +5466,11 @@ func (s *RetryLayerPluginStore) SetWithOptions(pluginId string, key string, valu
 
 }
 
-func (s *RetryLayerPostStore) AnalyticsPostCount(teamId string, mustHaveFile bool, mustHaveHashtag bool) (int64, error) {
+func (s *RetryLayerKeywordWatchStore) GetForTeam(teamId string) ([]*model.KeywordWatch, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.AnalyticsPostCount(teamId, mustHaveFile, mustHaveHashtag)
+		result, err := s.KeywordWatchStore.GetForTeam(teamId)
 		if err == nil {
 			return result, nil
 		}
@@ -5216,11 +5486,11 @@ func (s *RetryLayerPostStore) AnalyticsPostCount(teamId string, mustHaveFile boo
 
 }
 
-func (s *RetryLayerPostStore) AnalyticsPostCountsByDay(options *model.AnalyticsPostCountsOptions) (model.AnalyticsRows, error) {
+func (s *RetryLayerKeywordWatchStore) GetForUser(userId string) ([]*model.KeywordWatch, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.AnalyticsPostCountsByDay(options)
+		result, err := s.KeywordWatchStore.GetForUser(userId)
 		if err == nil {
 			return result, nil
 		}
@@ -5236,11 +5506,11 @@ func (s *RetryLayerPostStore) AnalyticsPostCountsByDay(options *model.AnalyticsP
 
 }
 
-func (s *RetryLayerPostStore) AnalyticsUserCountsWithPostsByDay(teamId string) (model.AnalyticsRows, error) {
+func (s *RetryLayerKeywordWatchStore) Save(watch *model.KeywordWatch) (*model.KeywordWatch, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.AnalyticsUserCountsWithPostsByDay(teamId)
+		result, err := s.KeywordWatchStore.Save(watch)
 		if err == nil {
 			return result, nil
 		}
@@ -5256,37 +5526,31 @@ func (s *RetryLayerPostStore) AnalyticsUserCountsWithPostsByDay(teamId string) (
 
 }
 
-func (s *RetryLayerPostStore) ClearCaches() {
-
-	s.PostStore.ClearCaches()
-
-}
-
-func (s *RetryLayerPostStore) Delete(postId string, time int64, deleteByID string) error {
+func (s *RetryLayerKeywordWatchStore) Update(watch *model.KeywordWatch) (*model.KeywordWatch, error) {
 
 	tries := 0
 	for {
-		err := s.PostStore.Delete(postId, time, deleteByID)
+		result, err := s.KeywordWatchStore.Update(watch)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 	}
 
 }
 
-func (s *RetryLayerPostStore) Get(id string, skipFetchThreads bool) (*model.PostList, error) {
+func (s *RetryLayerKeywordWatchStore) UpdateLastAlertAt(id string, alertAt int64, since int64) (bool, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.Get(id, skipFetchThreads)
+		result, err := s.KeywordWatchStore.UpdateLastAlertAt(id, alertAt, since)
 		if err == nil {
 			return result, nil
 		}
@@ -5302,11 +5566,11 @@ func (s *RetryLayerPostStore) Get(id string, skipFetchThreads bool) (*model.Post
 
 }
 
-func (s *RetryLayerPostStore) GetDirectPostParentsForExportAfter(limit int, afterId string) ([]*model.DirectPostForExport, error) {
+func (s *RetryLayerLicenseStore) Get(id string) (*model.LicenseRecord, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetDirectPostParentsForExportAfter(limit, afterId)
+		result, err := s.LicenseStore.Get(id)
 		if err == nil {
 			return result, nil
 		}
@@ -5322,17 +5586,11 @@ func (s *RetryLayerPostStore) GetDirectPostParentsForExportAfter(limit int, afte
 
 }
 
-func (s *RetryLayerPostStore) GetEtag(channelId string, allowFromCache bool) string {
-
-	return s.PostStore.GetEtag(channelId, allowFromCache)
-
-}
-
-func (s *RetryLayerPostStore) GetFlaggedPosts(userId string, offset int, limit int) (*model.PostList, error) {
+func (s *RetryLayerLicenseStore) Save(license *model.LicenseRecord) (*model.LicenseRecord, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetFlaggedPosts(userId, offset, limit)
+		result, err := s.LicenseStore.Save(license)
 		if err == nil {
 			return result, nil
 		}
@@ -5348,11 +5606,11 @@ func (s *RetryLayerPostStore) GetFlaggedPosts(userId string, offset int, limit i
 
 }
 
-func (s *RetryLayerPostStore) GetFlaggedPostsForChannel(userId string, channelId string, offset int, limit int) (*model.PostList, error) {
+func (s *RetryLayerLinkMetadataStore) Get(url string, timestamp int64) (*model.LinkMetadata, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetFlaggedPostsForChannel(userId, channelId, offset, limit)
+		result, err := s.LinkMetadataStore.Get(url, timestamp)
 		if err == nil {
 			return result, nil
 		}
@@ -5368,11 +5626,11 @@ func (s *RetryLayerPostStore) GetFlaggedPostsForChannel(userId string, channelId
 
 }
 
-func (s *RetryLayerPostStore) GetFlaggedPostsForTeam(userId string, teamId string, offset int, limit int) (*model.PostList, error) {
+func (s *RetryLayerLinkMetadataStore) Save(linkMetadata *model.LinkMetadata) (*model.LinkMetadata, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetFlaggedPostsForTeam(userId, teamId, offset, limit)
+		result, err := s.LinkMetadataStore.Save(linkMetadata)
 		if err == nil {
 			return result, nil
 		}
@@ -5388,17 +5646,31 @@ func (s *RetryLayerPostStore) GetFlaggedPostsForTeam(userId string, teamId strin
 
 }
 
-func (s *RetryLayerPostStore) GetMaxPostSize() int {
+func (s *RetryLayerOAuthStore) DeleteApp(id string) error {
 
-	return s.PostStore.GetMaxPostSize()
+	tries := 0
+	for {
+		err := s.OAuthStore.DeleteApp(id)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+	}
 
 }
 
-func (s *RetryLayerPostStore) GetOldest() (*model.Post, error) {
+func (s *RetryLayerOAuthStore) GetAccessData(token string) (*model.AccessData, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetOldest()
+		result, err := s.OAuthStore.GetAccessData(token)
 		if err == nil {
 			return result, nil
 		}
@@ -5414,11 +5686,11 @@ func (s *RetryLayerPostStore) GetOldest() (*model.Post, error) {
 
 }
 
-func (s *RetryLayerPostStore) GetOldestEntityCreationTime() (int64, error) {
+func (s *RetryLayerOAuthStore) GetAccessDataByRefreshToken(token string) (*model.AccessData, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetOldestEntityCreationTime()
+		result, err := s.OAuthStore.GetAccessDataByRefreshToken(token)
 		if err == nil {
 			return result, nil
 		}
@@ -5434,11 +5706,11 @@ func (s *RetryLayerPostStore) GetOldestEntityCreationTime() (int64, error) {
 
 }
 
-func (s *RetryLayerPostStore) GetParentsForExportAfter(limit int, afterId string) ([]*model.PostForExport, error) {
+func (s *RetryLayerOAuthStore) GetAccessDataByUserForApp(userId string, clientId string) ([]*model.AccessData, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetParentsForExportAfter(limit, afterId)
+		result, err := s.OAuthStore.GetAccessDataByUserForApp(userId, clientId)
 		if err == nil {
 			return result, nil
 		}
@@ -5454,11 +5726,11 @@ func (s *RetryLayerPostStore) GetParentsForExportAfter(limit int, afterId string
 
 }
 
-func (s *RetryLayerPostStore) GetPostAfterTime(channelId string, time int64) (*model.Post, error) {
+func (s *RetryLayerOAuthStore) GetApp(id string) (*model.OAuthApp, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetPostAfterTime(channelId, time)
+		result, err := s.OAuthStore.GetApp(id)
 		if err == nil {
 			return result, nil
 		}
@@ -5474,11 +5746,11 @@ func (s *RetryLayerPostStore) GetPostAfterTime(channelId string, time int64) (*m
 
 }
 
-func (s *RetryLayerPostStore) GetPostIdAfterTime(channelId string, time int64) (string, error) {
+func (s *RetryLayerOAuthStore) GetAppByUser(userId string, offset int, limit int) ([]*model.OAuthApp, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetPostIdAfterTime(channelId, time)
+		result, err := s.OAuthStore.GetAppByUser(userId, offset, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -5494,11 +5766,11 @@ func (s *RetryLayerPostStore) GetPostIdAfterTime(channelId string, time int64) (
 
 }
 
-func (s *RetryLayerPostStore) GetPostIdBeforeTime(channelId string, time int64) (string, error) {
+func (s *RetryLayerOAuthStore) GetApps(offset int, limit int) ([]*model.OAuthApp, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetPostIdBeforeTime(channelId, time)
+		result, err := s.OAuthStore.GetApps(offset, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -5514,11 +5786,11 @@ func (s *RetryLayerPostStore) GetPostIdBeforeTime(channelId string, time int64)
 
 }
 
-func (s *RetryLayerPostStore) GetPosts(options model.GetPostsOptions, allowFromCache bool) (*model.PostList, error) {
+func (s *RetryLayerOAuthStore) GetAuthData(code string) (*model.AuthData, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetPosts(options, allowFromCache)
+		result, err := s.OAuthStore.GetAuthData(code)
 		if err == nil {
 			return result, nil
 		}
@@ -5534,11 +5806,11 @@ func (s *RetryLayerPostStore) GetPosts(options model.GetPostsOptions, allowFromC
 
 }
 
-func (s *RetryLayerPostStore) GetPostsAfter(options model.GetPostsOptions) (*model.PostList, error) {
+func (s *RetryLayerOAuthStore) GetAuthorizedApps(userId string, offset int, limit int) ([]*model.OAuthApp, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetPostsAfter(options)
+		result, err := s.OAuthStore.GetAuthorizedApps(userId, offset, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -5554,11 +5826,11 @@ func (s *RetryLayerPostStore) GetPostsAfter(options model.GetPostsOptions) (*mod
 
 }
 
-func (s *RetryLayerPostStore) GetPostsBatchForIndexing(startTime int64, endTime int64, limit int) ([]*model.PostForIndexing, error) {
+func (s *RetryLayerOAuthStore) GetPreviousAccessData(userId string, clientId string) (*model.AccessData, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetPostsBatchForIndexing(startTime, endTime, limit)
+		result, err := s.OAuthStore.GetPreviousAccessData(userId, clientId)
 		if err == nil {
 			return result, nil
 		}
@@ -5574,71 +5846,91 @@ func (s *RetryLayerPostStore) GetPostsBatchForIndexing(startTime int64, endTime
 
 }
 
-func (s *RetryLayerPostStore) GetPostsBefore(options model.GetPostsOptions) (*model.PostList, error) {
+func (s *RetryLayerOAuthStore) PermanentDeleteAuthDataByUser(userId string) error {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetPostsBefore(options)
+		err := s.OAuthStore.PermanentDeleteAuthDataByUser(userId)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 	}
 
 }
 
-func (s *RetryLayerPostStore) GetPostsByIds(postIds []string) ([]*model.Post, error) {
+func (s *RetryLayerOAuthStore) RemoveAccessData(token string) error {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetPostsByIds(postIds)
+		err := s.OAuthStore.RemoveAccessData(token)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 	}
 
 }
 
-func (s *RetryLayerPostStore) GetPostsCreatedAt(channelId string, time int64) ([]*model.Post, error) {
+func (s *RetryLayerOAuthStore) RemoveAllAccessData() error {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetPostsCreatedAt(channelId, time)
+		err := s.OAuthStore.RemoveAllAccessData()
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 	}
 
 }
 
-func (s *RetryLayerPostStore) GetPostsSince(options model.GetPostsSinceOptions, allowFromCache bool) (*model.PostList, error) {
+func (s *RetryLayerOAuthStore) RemoveAuthData(code string) error {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetPostsSince(options, allowFromCache)
+		err := s.OAuthStore.RemoveAuthData(code)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+	}
+
+}
+
+func (s *RetryLayerOAuthStore) SaveAccessData(accessData *model.AccessData) (*model.AccessData, error) {
+
+	tries := 0
+	for {
+		result, err := s.OAuthStore.SaveAccessData(accessData)
 		if err == nil {
 			return result, nil
 		}
@@ -5654,11 +5946,11 @@ func (s *RetryLayerPostStore) GetPostsSince(options model.GetPostsSinceOptions,
 
 }
 
-func (s *RetryLayerPostStore) GetRepliesForExport(parentId string) ([]*model.ReplyForExport, error) {
+func (s *RetryLayerOAuthStore) SaveApp(app *model.OAuthApp) (*model.OAuthApp, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetRepliesForExport(parentId)
+		result, err := s.OAuthStore.SaveApp(app)
 		if err == nil {
 			return result, nil
 		}
@@ -5674,11 +5966,11 @@ func (s *RetryLayerPostStore) GetRepliesForExport(parentId string) ([]*model.Rep
 
 }
 
-func (s *RetryLayerPostStore) GetSingle(id string) (*model.Post, error) {
+func (s *RetryLayerOAuthStore) SaveAuthData(authData *model.AuthData) (*model.AuthData, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetSingle(id)
+		result, err := s.OAuthStore.SaveAuthData(authData)
 		if err == nil {
 			return result, nil
 		}
@@ -5694,17 +5986,31 @@ func (s *RetryLayerPostStore) GetSingle(id string) (*model.Post, error) {
 
 }
 
-func (s *RetryLayerPostStore) InvalidateLastPostTimeCache(channelId string) {
+func (s *RetryLayerOAuthStore) UpdateAccessData(accessData *model.AccessData) (*model.AccessData, error) {
 
-	s.PostStore.InvalidateLastPostTimeCache(channelId)
+	tries := 0
+	for {
+		result, err := s.OAuthStore.UpdateAccessData(accessData)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
 
 }
 
-func (s *RetryLayerPostStore) Overwrite(post *model.Post) (*model.Post, error) {
+func (s *RetryLayerOAuthStore) UpdateApp(app *model.OAuthApp) (*model.OAuthApp, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.Overwrite(post)
+		result, err := s.OAuthStore.UpdateApp(app)
 		if err == nil {
 			return result, nil
 		}
@@ -5720,31 +6026,31 @@ func (s *RetryLayerPostStore) Overwrite(post *model.Post) (*model.Post, error) {
 
 }
 
-func (s *RetryLayerPostStore) OverwriteMultiple(posts []*model.Post) ([]*model.Post, int, error) {
+func (s *RetryLayerPluginStore) CompareAndDelete(keyVal *model.PluginKeyValue, oldValue []byte) (bool, error) {
 
 	tries := 0
 	for {
-		result, resultVar1, err := s.PostStore.OverwriteMultiple(posts)
+		result, err := s.PluginStore.CompareAndDelete(keyVal, oldValue)
 		if err == nil {
-			return result, resultVar1, nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return result, resultVar1, err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, resultVar1, err
+			return result, err
 		}
 	}
 
 }
 
-func (s *RetryLayerPostStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+func (s *RetryLayerPluginStore) CompareAndSet(keyVal *model.PluginKeyValue, oldValue []byte) (bool, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.PermanentDeleteBatch(endTime, limit)
+		result, err := s.PluginStore.CompareAndSet(keyVal, oldValue)
 		if err == nil {
 			return result, nil
 		}
@@ -5760,11 +6066,11 @@ func (s *RetryLayerPostStore) PermanentDeleteBatch(endTime int64, limit int64) (
 
 }
 
-func (s *RetryLayerPostStore) PermanentDeleteByChannel(channelId string) error {
+func (s *RetryLayerPluginStore) Delete(pluginId string, key string) error {
 
 	tries := 0
 	for {
-		err := s.PostStore.PermanentDeleteByChannel(channelId)
+		err := s.PluginStore.Delete(pluginId, key)
 		if err == nil {
 			return nil
 		}
@@ -5780,11 +6086,11 @@ func (s *RetryLayerPostStore) PermanentDeleteByChannel(channelId string) error {
 
 }
 
-func (s *RetryLayerPostStore) PermanentDeleteByUser(userId string) error {
+func (s *RetryLayerPluginStore) DeleteAllExpired() error {
 
 	tries := 0
 	for {
-		err := s.PostStore.PermanentDeleteByUser(userId)
+		err := s.PluginStore.DeleteAllExpired()
 		if err == nil {
 			return nil
 		}
@@ -5800,11 +6106,31 @@ func (s *RetryLayerPostStore) PermanentDeleteByUser(userId string) error {
 
 }
 
-func (s *RetryLayerPostStore) Save(post *model.Post) (*model.Post, error) {
+func (s *RetryLayerPluginStore) DeleteAllForPlugin(PluginId string) error {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.Save(post)
+		err := s.PluginStore.DeleteAllForPlugin(PluginId)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+	}
+
+}
+
+func (s *RetryLayerPluginStore) Get(pluginId string, key string) (*model.PluginKeyValue, error) {
+
+	tries := 0
+	for {
+		result, err := s.PluginStore.Get(pluginId, key)
 		if err == nil {
 			return result, nil
 		}
@@ -5820,31 +6146,31 @@ func (s *RetryLayerPostStore) Save(post *model.Post) (*model.Post, error) {
 
 }
 
-func (s *RetryLayerPostStore) SaveMultiple(posts []*model.Post) ([]*model.Post, int, error) {
+func (s *RetryLayerPluginStore) List(pluginId string, page int, perPage int) ([]string, error) {
 
 	tries := 0
 	for {
-		result, resultVar1, err := s.PostStore.SaveMultiple(posts)
+		result, err := s.PluginStore.List(pluginId, page, perPage)
 		if err == nil {
-			return result, resultVar1, nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return result, resultVar1, err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, resultVar1, err
+			return result, err
 		}
 	}
 
 }
 
-func (s *RetryLayerPostStore) Search(teamId string, userId string, params *model.SearchParams) (*model.PostList, error) {
+func (s *RetryLayerPluginStore) SaveOrUpdate(keyVal *model.PluginKeyValue) (*model.PluginKeyValue, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.Search(teamId, userId, params)
+		result, err := s.PluginStore.SaveOrUpdate(keyVal)
 		if err == nil {
 			return result, nil
 		}
@@ -5860,11 +6186,11 @@ func (s *RetryLayerPostStore) Search(teamId string, userId string, params *model
 
 }
 
-func (s *RetryLayerPostStore) SearchPostsInTeamForUser(paramsList []*model.SearchParams, userId string, teamId string, page int, perPage int) (*model.PostSearchResults, error) {
+func (s *RetryLayerPluginStore) SetWithOptions(pluginId string, key string, value []byte, options model.PluginKVSetOptions) (bool, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.SearchPostsInTeamForUser(paramsList, userId, teamId, page, perPage)
+		result, err := s.PluginStore.SetWithOptions(pluginId, key, value, options)
 		if err == nil {
 			return result, nil
 		}
@@ -5880,11 +6206,11 @@ func (s *RetryLayerPostStore) SearchPostsInTeamForUser(paramsList []*model.Searc
 
 }
 
-func (s *RetryLayerPostStore) Update(newPost *model.Post, oldPost *model.Post) (*model.Post, error) {
+func (s *RetryLayerPollStore) Get(id string) (*model.Poll, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.Update(newPost, oldPost)
+		result, err := s.PollStore.Get(id)
 		if err == nil {
 			return result, nil
 		}
@@ -5900,11 +6226,11 @@ func (s *RetryLayerPostStore) Update(newPost *model.Post, oldPost *model.Post) (
 
 }
 
-func (s *RetryLayerPreferenceStore) CleanupFlagsBatch(limit int64) (int64, error) {
+func (s *RetryLayerPollStore) GetForPosts(postIds []string) ([]*model.Poll, error) {
 
 	tries := 0
 	for {
-		result, err := s.PreferenceStore.CleanupFlagsBatch(limit)
+		result, err := s.PollStore.GetForPosts(postIds)
 		if err == nil {
 			return result, nil
 		}
@@ -5920,71 +6246,71 @@ func (s *RetryLayerPreferenceStore) CleanupFlagsBatch(limit int64) (int64, error
 
 }
 
-func (s *RetryLayerPreferenceStore) Delete(userId string, category string, name string) error {
+func (s *RetryLayerPollStore) GetPollsToClose(now int64, limit int) ([]*model.Poll, error) {
 
 	tries := 0
 	for {
-		err := s.PreferenceStore.Delete(userId, category, name)
+		result, err := s.PollStore.GetPollsToClose(now, limit)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 	}
 
 }
 
-func (s *RetryLayerPreferenceStore) DeleteCategory(userId string, category string) error {
+func (s *RetryLayerPollStore) GetVotes(pollId string) ([]*model.PollVote, error) {
 
 	tries := 0
 	for {
-		err := s.PreferenceStore.DeleteCategory(userId, category)
+		result, err := s.PollStore.GetVotes(pollId)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 	}
 
 }
 
-func (s *RetryLayerPreferenceStore) DeleteCategoryAndName(category string, name string) error {
+func (s *RetryLayerPollStore) Save(poll *model.Poll) (*model.Poll, error) {
 
 	tries := 0
 	for {
-		err := s.PreferenceStore.DeleteCategoryAndName(category, name)
+		result, err := s.PollStore.Save(poll)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 	}
 
 }
 
-func (s *RetryLayerPreferenceStore) Get(userId string, category string, name string) (*model.Preference, error) {
+func (s *RetryLayerPollStore) SaveVotes(pollId string, userId string, votes []*model.PollVote) (*model.Post, error) {
 
 	tries := 0
 	for {
-		result, err := s.PreferenceStore.Get(userId, category, name)
+		result, err := s.PollStore.SaveVotes(pollId, userId, votes)
 		if err == nil {
 			return result, nil
 		}
@@ -6000,11 +6326,1155 @@ func (s *RetryLayerPreferenceStore) Get(userId string, category string, name str
 
 }
 
-func (s *RetryLayerPreferenceStore) GetAll(userId string) (model.Preferences, error) {
+func (s *RetryLayerPollStore) Update(poll *model.Poll) (*model.Poll, error) {
 
 	tries := 0
 	for {
-		result, err := s.PreferenceStore.GetAll(userId)
+		result, err := s.PollStore.Update(poll)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerPollStore) UpdateTally(pollId string) (*model.Post, error) {
+
+	tries := 0
+	for {
+		result, err := s.PollStore.UpdateTally(pollId)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerPostStore) AnalyticsPostCount(teamId string, mustHaveFile bool, mustHaveHashtag bool) (int64, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostStore.AnalyticsPostCount(teamId, mustHaveFile, mustHaveHashtag)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerPostStore) AnalyticsPostCountsByDay(options *model.AnalyticsPostCountsOptions) (model.AnalyticsRows, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostStore.AnalyticsPostCountsByDay(options)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerPostStore) AnalyticsUserCountsWithPostsByDay(teamId string) (model.AnalyticsRows, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostStore.AnalyticsUserCountsWithPostsByDay(teamId)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerPostStore) ClearCaches() {
+
+	s.PostStore.ClearCaches()
+
+}
+
+func (s *RetryLayerPostStore) Delete(postId string, time int64, deleteByID string) error {
+
+	tries := 0
+	for {
+		err := s.PostStore.Delete(postId, time, deleteByID)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+	}
+
+}
+
+func (s *RetryLayerPostStore) Get(id string, skipFetchThreads bool) (*model.PostList, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostStore.Get(id, skipFetchThreads)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerPostStore) GetDirectPostParentsForExportAfter(limit int, afterId string) ([]*model.DirectPostForExport, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostStore.GetDirectPostParentsForExportAfter(limit, afterId)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerPostStore) GetEtag(channelId string, allowFromCache bool) string {
+
+	return s.PostStore.GetEtag(channelId, allowFromCache)
+
+}
+
+func (s *RetryLayerPostStore) GetFlaggedPosts(userId string, offset int, limit int) (*model.PostList, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostStore.GetFlaggedPosts(userId, offset, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerPostStore) GetFlaggedPostsForChannel(userId string, channelId string, offset int, limit int) (*model.PostList, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostStore.GetFlaggedPostsForChannel(userId, channelId, offset, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerPostStore) GetFlaggedPostsForTeam(userId string, teamId string, offset int, limit int) (*model.PostList, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostStore.GetFlaggedPostsForTeam(userId, teamId, offset, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerPostStore) GetMaxPostSize() int {
+
+	return s.PostStore.GetMaxPostSize()
+
+}
+
+func (s *RetryLayerPostStore) GetOldest() (*model.Post, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostStore.GetOldest()
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerPostStore) GetOldestEntityCreationTime() (int64, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostStore.GetOldestEntityCreationTime()
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerPostStore) GetParentsForExportAfter(limit int, afterId string) ([]*model.PostForExport, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostStore.GetParentsForExportAfter(limit, afterId)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerPostStore) GetPostAfterTime(channelId string, time int64) (*model.Post, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostStore.GetPostAfterTime(channelId, time)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerPostStore) GetPostIdAfterTime(channelId string, time int64) (string, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostStore.GetPostIdAfterTime(channelId, time)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerPostStore) GetPostIdBeforeTime(channelId string, time int64) (string, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostStore.GetPostIdBeforeTime(channelId, time)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerPostStore) GetPosts(options model.GetPostsOptions, allowFromCache bool) (*model.PostList, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostStore.GetPosts(options, allowFromCache)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerPostStore) GetPostsAfter(options model.GetPostsOptions) (*model.PostList, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostStore.GetPostsAfter(options)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerPostStore) GetPostsBatchForIndexing(startTime int64, endTime int64, limit int) ([]*model.PostForIndexing, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostStore.GetPostsBatchForIndexing(startTime, endTime, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerPostStore) GetPostsBefore(options model.GetPostsOptions) (*model.PostList, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostStore.GetPostsBefore(options)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerPostStore) GetPostsByIds(postIds []string) ([]*model.Post, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostStore.GetPostsByIds(postIds)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerPostStore) GetPostsCreatedAt(channelId string, time int64) ([]*model.Post, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostStore.GetPostsCreatedAt(channelId, time)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerPostStore) GetPostsSince(options model.GetPostsSinceOptions, allowFromCache bool) (*model.PostList, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostStore.GetPostsSince(options, allowFromCache)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerPostStore) GetRepliesForExport(parentId string) ([]*model.ReplyForExport, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostStore.GetRepliesForExport(parentId)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerPostStore) GetSingle(id string) (*model.Post, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostStore.GetSingle(id)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerPostStore) InvalidateLastPostTimeCache(channelId string) {
+
+	s.PostStore.InvalidateLastPostTimeCache(channelId)
+
+}
+
+func (s *RetryLayerPostStore) Overwrite(post *model.Post) (*model.Post, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostStore.Overwrite(post)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerPostStore) OverwriteMultiple(posts []*model.Post) ([]*model.Post, int, error) {
+
+	tries := 0
+	for {
+		result, resultVar1, err := s.PostStore.OverwriteMultiple(posts)
+		if err == nil {
+			return result, resultVar1, nil
+		}
+		if !isRepeatableError(err) {
+			return result, resultVar1, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, resultVar1, err
+		}
+	}
+
+}
+
+func (s *RetryLayerPostStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostStore.PermanentDeleteBatch(endTime, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerPostStore) PermanentDeleteByChannel(channelId string) error {
+
+	tries := 0
+	for {
+		err := s.PostStore.PermanentDeleteByChannel(channelId)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+	}
+
+}
+
+func (s *RetryLayerPostStore) PermanentDeleteByUser(userId string) error {
+
+	tries := 0
+	for {
+		err := s.PostStore.PermanentDeleteByUser(userId)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+	}
+
+}
+
+func (s *RetryLayerPostStore) Save(post *model.Post) (*model.Post, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostStore.Save(post)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerPostStore) SaveMultiple(posts []*model.Post) ([]*model.Post, int, error) {
+
+	tries := 0
+	for {
+		result, resultVar1, err := s.PostStore.SaveMultiple(posts)
+		if err == nil {
+			return result, resultVar1, nil
+		}
+		if !isRepeatableError(err) {
+			return result, resultVar1, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, resultVar1, err
+		}
+	}
+
+}
+
+func (s *RetryLayerPostStore) Search(teamId string, userId string, params *model.SearchParams) (*model.PostList, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostStore.Search(teamId, userId, params)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerPostStore) SearchPostsInTeamForUser(paramsList []*model.SearchParams, userId string, teamId string, page int, perPage int) (*model.PostSearchResults, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostStore.SearchPostsInTeamForUser(paramsList, userId, teamId, page, perPage)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerPostStore) Update(newPost *model.Post, oldPost *model.Post) (*model.Post, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostStore.Update(newPost, oldPost)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerPreferenceStore) CleanupFlagsBatch(limit int64) (int64, error) {
+
+	tries := 0
+	for {
+		result, err := s.PreferenceStore.CleanupFlagsBatch(limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerPreferenceStore) Delete(userId string, category string, name string) error {
+
+	tries := 0
+	for {
+		err := s.PreferenceStore.Delete(userId, category, name)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+	}
+
+}
+
+func (s *RetryLayerPreferenceStore) DeleteCategory(userId string, category string) error {
+
+	tries := 0
+	for {
+		err := s.PreferenceStore.DeleteCategory(userId, category)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+	}
+
+}
+
+func (s *RetryLayerPreferenceStore) DeleteCategoryAndName(category string, name string) error {
+
+	tries := 0
+	for {
+		err := s.PreferenceStore.DeleteCategoryAndName(category, name)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+	}
+
+}
+
+func (s *RetryLayerPreferenceStore) Get(userId string, category string, name string) (*model.Preference, error) {
+
+	tries := 0
+	for {
+		result, err := s.PreferenceStore.Get(userId, category, name)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerPreferenceStore) GetAll(userId string) (model.Preferences, error) {
+
+	tries := 0
+	for {
+		result, err := s.PreferenceStore.GetAll(userId)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerPreferenceStore) GetCategory(userId string, category string) (model.Preferences, error) {
+
+	tries := 0
+	for {
+		result, err := s.PreferenceStore.GetCategory(userId, category)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerPreferenceStore) GetForUsers(userIds []string, category string, name string) (model.Preferences, error) {
+
+	tries := 0
+	for {
+		result, err := s.PreferenceStore.GetForUsers(userIds, category, name)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerPreferenceStore) PermanentDeleteByUser(userId string) error {
+
+	tries := 0
+	for {
+		err := s.PreferenceStore.PermanentDeleteByUser(userId)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+	}
+
+}
+
+func (s *RetryLayerPreferenceStore) Save(preferences *model.Preferences) error {
+
+	tries := 0
+	for {
+		err := s.PreferenceStore.Save(preferences)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+	}
+
+}
+
+func (s *RetryLayerProductNoticesStore) Clear(notices []string) error {
+
+	tries := 0
+	for {
+		err := s.ProductNoticesStore.Clear(notices)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+	}
+
+}
+
+func (s *RetryLayerProductNoticesStore) ClearOldNotices(currentNotices *model.ProductNotices) error {
+
+	tries := 0
+	for {
+		err := s.ProductNoticesStore.ClearOldNotices(currentNotices)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+	}
+
+}
+
+func (s *RetryLayerProductNoticesStore) GetViews(userId string) ([]model.ProductNoticeViewState, error) {
+
+	tries := 0
+	for {
+		result, err := s.ProductNoticesStore.GetViews(userId)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerProductNoticesStore) View(userId string, notices []string) error {
+
+	tries := 0
+	for {
+		err := s.ProductNoticesStore.View(userId, notices)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+	}
+
+}
+
+func (s *RetryLayerPublicLinkStore) Get(id string) (*model.PublicLink, error) {
+
+	tries := 0
+	for {
+		result, err := s.PublicLinkStore.Get(id)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerPublicLinkStore) GetActive(fileId string, creatorId string, now int64) (*model.PublicLink, error) {
+
+	tries := 0
+	for {
+		result, err := s.PublicLinkStore.GetActive(fileId, creatorId, now)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerPublicLinkStore) GetForUser(userId string, offset int, limit int) ([]*model.PublicLink, error) {
+
+	tries := 0
+	for {
+		result, err := s.PublicLinkStore.GetForUser(userId, offset, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerPublicLinkStore) RecordDownload(id string, downloadAt int64) error {
+
+	tries := 0
+	for {
+		err := s.PublicLinkStore.RecordDownload(id, downloadAt)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+	}
+
+}
+
+func (s *RetryLayerPublicLinkStore) Revoke(id string, revokeAt int64) error {
+
+	tries := 0
+	for {
+		err := s.PublicLinkStore.Revoke(id, revokeAt)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+	}
+
+}
+
+func (s *RetryLayerPublicLinkStore) Save(link *model.PublicLink) (*model.PublicLink, error) {
+
+	tries := 0
+	for {
+		result, err := s.PublicLinkStore.Save(link)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerReactionStore) BulkGetForPosts(postIds []string) ([]*model.Reaction, error) {
+
+	tries := 0
+	for {
+		result, err := s.ReactionStore.BulkGetForPosts(postIds)
 		if err == nil {
 			return result, nil
 		}
@@ -6020,11 +7490,11 @@ func (s *RetryLayerPreferenceStore) GetAll(userId string) (model.Preferences, er
 
 }
 
-func (s *RetryLayerPreferenceStore) GetCategory(userId string, category string) (model.Preferences, error) {
+func (s *RetryLayerReactionStore) Delete(reaction *model.Reaction) (*model.Reaction, error) {
 
 	tries := 0
 	for {
-		result, err := s.PreferenceStore.GetCategory(userId, category)
+		result, err := s.ReactionStore.Delete(reaction)
 		if err == nil {
 			return result, nil
 		}
@@ -6040,11 +7510,11 @@ func (s *RetryLayerPreferenceStore) GetCategory(userId string, category string)
 
 }
 
-func (s *RetryLayerPreferenceStore) PermanentDeleteByUser(userId string) error {
+func (s *RetryLayerReactionStore) DeleteAllWithEmojiName(emojiName string) error {
 
 	tries := 0
 	for {
-		err := s.PreferenceStore.PermanentDeleteByUser(userId)
+		err := s.ReactionStore.DeleteAllWithEmojiName(emojiName)
 		if err == nil {
 			return nil
 		}
@@ -6060,71 +7530,71 @@ func (s *RetryLayerPreferenceStore) PermanentDeleteByUser(userId string) error {
 
 }
 
-func (s *RetryLayerPreferenceStore) Save(preferences *model.Preferences) error {
+func (s *RetryLayerReactionStore) GetForPost(postId string, allowFromCache bool) ([]*model.Reaction, error) {
 
 	tries := 0
 	for {
-		err := s.PreferenceStore.Save(preferences)
+		result, err := s.ReactionStore.GetForPost(postId, allowFromCache)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 	}
 
 }
 
-func (s *RetryLayerProductNoticesStore) Clear(notices []string) error {
+func (s *RetryLayerReactionStore) GetPostIdsForUsers(userIds []string, limit int) ([]string, error) {
 
 	tries := 0
 	for {
-		err := s.ProductNoticesStore.Clear(notices)
+		result, err := s.ReactionStore.GetPostIdsForUsers(userIds, limit)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 	}
 
 }
 
-func (s *RetryLayerProductNoticesStore) ClearOldNotices(currentNotices *model.ProductNotices) error {
+func (s *RetryLayerReactionStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
 
 	tries := 0
 	for {
-		err := s.ProductNoticesStore.ClearOldNotices(currentNotices)
+		result, err := s.ReactionStore.PermanentDeleteBatch(endTime, limit)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 	}
 
 }
 
-func (s *RetryLayerProductNoticesStore) GetViews(userId string) ([]model.ProductNoticeViewState, error) {
+func (s *RetryLayerReactionStore) Save(reaction *model.Reaction) (*model.Reaction, error) {
 
 	tries := 0
 	for {
-		result, err := s.ProductNoticesStore.GetViews(userId)
+		result, err := s.ReactionStore.Save(reaction)
 		if err == nil {
 			return result, nil
 		}
@@ -6140,31 +7610,31 @@ func (s *RetryLayerProductNoticesStore) GetViews(userId string) ([]model.Product
 
 }
 
-func (s *RetryLayerProductNoticesStore) View(userId string, notices []string) error {
+func (s *RetryLayerRoleStore) AllChannelSchemeRoles() ([]*model.Role, error) {
 
 	tries := 0
 	for {
-		err := s.ProductNoticesStore.View(userId, notices)
+		result, err := s.RoleStore.AllChannelSchemeRoles()
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 	}
 
 }
 
-func (s *RetryLayerReactionStore) BulkGetForPosts(postIds []string) ([]*model.Reaction, error) {
+func (s *RetryLayerRoleStore) ChannelHigherScopedPermissions(roleNames []string) (map[string]*model.RolePermissions, error) {
 
 	tries := 0
 	for {
-		result, err := s.ReactionStore.BulkGetForPosts(postIds)
+		result, err := s.RoleStore.ChannelHigherScopedPermissions(roleNames)
 		if err == nil {
 			return result, nil
 		}
@@ -6180,11 +7650,11 @@ func (s *RetryLayerReactionStore) BulkGetForPosts(postIds []string) ([]*model.Re
 
 }
 
-func (s *RetryLayerReactionStore) Delete(reaction *model.Reaction) (*model.Reaction, error) {
+func (s *RetryLayerRoleStore) ChannelRolesUnderTeamRole(roleName string) ([]*model.Role, error) {
 
 	tries := 0
 	for {
-		result, err := s.ReactionStore.Delete(reaction)
+		result, err := s.RoleStore.ChannelRolesUnderTeamRole(roleName)
 		if err == nil {
 			return result, nil
 		}
@@ -6200,31 +7670,31 @@ func (s *RetryLayerReactionStore) Delete(reaction *model.Reaction) (*model.React
 
 }
 
-func (s *RetryLayerReactionStore) DeleteAllWithEmojiName(emojiName string) error {
+func (s *RetryLayerRoleStore) Delete(roleId string) (*model.Role, error) {
 
 	tries := 0
 	for {
-		err := s.ReactionStore.DeleteAllWithEmojiName(emojiName)
+		result, err := s.RoleStore.Delete(roleId)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 	}
 
 }
 
-func (s *RetryLayerReactionStore) GetForPost(postId string, allowFromCache bool) ([]*model.Reaction, error) {
+func (s *RetryLayerRoleStore) Get(roleId string) (*model.Role, error) {
 
 	tries := 0
 	for {
-		result, err := s.ReactionStore.GetForPost(postId, allowFromCache)
+		result, err := s.RoleStore.Get(roleId)
 		if err == nil {
 			return result, nil
 		}
@@ -6240,11 +7710,11 @@ func (s *RetryLayerReactionStore) GetForPost(postId string, allowFromCache bool)
 
 }
 
-func (s *RetryLayerReactionStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+func (s *RetryLayerRoleStore) GetAll() ([]*model.Role, error) {
 
 	tries := 0
 	for {
-		result, err := s.ReactionStore.PermanentDeleteBatch(endTime, limit)
+		result, err := s.RoleStore.GetAll()
 		if err == nil {
 			return result, nil
 		}
@@ -6260,11 +7730,11 @@ func (s *RetryLayerReactionStore) PermanentDeleteBatch(endTime int64, limit int6
 
 }
 
-func (s *RetryLayerReactionStore) Save(reaction *model.Reaction) (*model.Reaction, error) {
+func (s *RetryLayerRoleStore) GetByName(name string) (*model.Role, error) {
 
 	tries := 0
 	for {
-		result, err := s.ReactionStore.Save(reaction)
+		result, err := s.RoleStore.GetByName(name)
 		if err == nil {
 			return result, nil
 		}
@@ -6280,11 +7750,11 @@ func (s *RetryLayerReactionStore) Save(reaction *model.Reaction) (*model.Reactio
 
 }
 
-func (s *RetryLayerRoleStore) AllChannelSchemeRoles() ([]*model.Role, error) {
+func (s *RetryLayerRoleStore) GetByNames(names []string) ([]*model.Role, error) {
 
 	tries := 0
 	for {
-		result, err := s.RoleStore.AllChannelSchemeRoles()
+		result, err := s.RoleStore.GetByNames(names)
 		if err == nil {
 			return result, nil
 		}
@@ -6300,31 +7770,31 @@ func (s *RetryLayerRoleStore) AllChannelSchemeRoles() ([]*model.Role, error) {
 
 }
 
-func (s *RetryLayerRoleStore) ChannelHigherScopedPermissions(roleNames []string) (map[string]*model.RolePermissions, error) {
+func (s *RetryLayerRoleStore) PermanentDeleteAll() error {
 
 	tries := 0
 	for {
-		result, err := s.RoleStore.ChannelHigherScopedPermissions(roleNames)
+		err := s.RoleStore.PermanentDeleteAll()
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 	}
 
 }
 
-func (s *RetryLayerRoleStore) ChannelRolesUnderTeamRole(roleName string) ([]*model.Role, error) {
+func (s *RetryLayerRoleStore) Save(role *model.Role) (*model.Role, error) {
 
 	tries := 0
 	for {
-		result, err := s.RoleStore.ChannelRolesUnderTeamRole(roleName)
+		result, err := s.RoleStore.Save(role)
 		if err == nil {
 			return result, nil
 		}
@@ -6340,31 +7810,31 @@ func (s *RetryLayerRoleStore) ChannelRolesUnderTeamRole(roleName string) ([]*mod
 
 }
 
-func (s *RetryLayerRoleStore) Delete(roleId string) (*model.Role, error) {
+func (s *RetryLayerSavedSearchStore) Delete(id string) error {
 
 	tries := 0
 	for {
-		result, err := s.RoleStore.Delete(roleId)
+		err := s.SavedSearchStore.Delete(id)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 	}
 
 }
 
-func (s *RetryLayerRoleStore) Get(roleId string) (*model.Role, error) {
+func (s *RetryLayerSavedSearchStore) Get(id string) (*model.SavedSearch, error) {
 
 	tries := 0
 	for {
-		result, err := s.RoleStore.Get(roleId)
+		result, err := s.SavedSearchStore.Get(id)
 		if err == nil {
 			return result, nil
 		}
@@ -6380,11 +7850,11 @@ func (s *RetryLayerRoleStore) Get(roleId string) (*model.Role, error) {
 
 }
 
-func (s *RetryLayerRoleStore) GetAll() ([]*model.Role, error) {
+func (s *RetryLayerSavedSearchStore) GetForNotification(afterId string, limit int) ([]*model.SavedSearch, error) {
 
 	tries := 0
 	for {
-		result, err := s.RoleStore.GetAll()
+		result, err := s.SavedSearchStore.GetForNotification(afterId, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -6400,11 +7870,11 @@ func (s *RetryLayerRoleStore) GetAll() ([]*model.Role, error) {
 
 }
 
-func (s *RetryLayerRoleStore) GetByName(name string) (*model.Role, error) {
+func (s *RetryLayerSavedSearchStore) GetForUser(userId string) ([]*model.SavedSearch, error) {
 
 	tries := 0
 	for {
-		result, err := s.RoleStore.GetByName(name)
+		result, err := s.SavedSearchStore.GetForUser(userId)
 		if err == nil {
 			return result, nil
 		}
@@ -6420,11 +7890,11 @@ func (s *RetryLayerRoleStore) GetByName(name string) (*model.Role, error) {
 
 }
 
-func (s *RetryLayerRoleStore) GetByNames(names []string) ([]*model.Role, error) {
+func (s *RetryLayerSavedSearchStore) Save(search *model.SavedSearch) (*model.SavedSearch, error) {
 
 	tries := 0
 	for {
-		result, err := s.RoleStore.GetByNames(names)
+		result, err := s.SavedSearchStore.Save(search)
 		if err == nil {
 			return result, nil
 		}
@@ -6440,41 +7910,41 @@ func (s *RetryLayerRoleStore) GetByNames(names []string) ([]*model.Role, error)
 
 }
 
-func (s *RetryLayerRoleStore) PermanentDeleteAll() error {
+func (s *RetryLayerSavedSearchStore) Update(search *model.SavedSearch) (*model.SavedSearch, error) {
 
 	tries := 0
 	for {
-		err := s.RoleStore.PermanentDeleteAll()
+		result, err := s.SavedSearchStore.Update(search)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 	}
 
 }
 
-func (s *RetryLayerRoleStore) Save(role *model.Role) (*model.Role, error) {
+func (s *RetryLayerSavedSearchStore) UpdateLastCheckedAt(id string, checkedAt int64) error {
 
 	tries := 0
 	for {
-		result, err := s.RoleStore.Save(role)
+		err := s.SavedSearchStore.UpdateLastCheckedAt(id, checkedAt)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 	}
 
@@ -6986,6 +8456,26 @@ func (s *RetryLayerStatusStore) GetByIds(userIds []string) ([]*model.Status, err
 
 }
 
+func (s *RetryLayerStatusStore) GetExpiredDND(expiredAt int64, limit int) ([]*model.Status, error) {
+
+	tries := 0
+	for {
+		result, err := s.StatusStore.GetExpiredDND(expiredAt, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
 func (s *RetryLayerStatusStore) GetTotalActiveUsersCount() (int64, error) {
 
 	tries := 0
@@ -7066,6 +8556,66 @@ func (s *RetryLayerStatusStore) UpdateLastActivityAt(userId string, lastActivity
 
 }
 
+func (s *RetryLayerStorageUsageStore) Get(targetType string, targetId string) (*model.StorageUsage, error) {
+
+	tries := 0
+	for {
+		result, err := s.StorageUsageStore.Get(targetType, targetId)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerStorageUsageStore) GetAll(targetType string, offset int, limit int) ([]*model.StorageUsage, error) {
+
+	tries := 0
+	for {
+		result, err := s.StorageUsageStore.GetAll(targetType, offset, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerStorageUsageStore) Recompute() error {
+
+	tries := 0
+	for {
+		err := s.StorageUsageStore.Recompute()
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+	}
+
+}
+
 func (s *RetryLayerSystemStore) Get() (model.StringMap, error) {
 
 	tries := 0
@@ -8478,6 +10028,26 @@ func (s *RetryLayerThreadStore) GetThreadsForUser(userId string, opts model.GetU
 
 }
 
+func (s *RetryLayerThreadStore) GetUnreadThreadCountsByTeam(userId string) (map[string]int64, error) {
+
+	tries := 0
+	for {
+		result, err := s.ThreadStore.GetUnreadThreadCountsByTeam(userId)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
 func (s *RetryLayerThreadStore) MarkAllAsRead(userId string, timestamp int64) error {
 
 	tries := 0
@@ -8764,6 +10334,26 @@ func (s *RetryLayerUploadSessionStore) Get(id string) (*model.UploadSession, err
 
 }
 
+func (s *RetryLayerUploadSessionStore) GetAllPage(offset int, limit int) ([]*model.UploadSession, error) {
+
+	tries := 0
+	for {
+		result, err := s.UploadSessionStore.GetAllPage(offset, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
 func (s *RetryLayerUploadSessionStore) GetForUser(userId string) ([]*model.UploadSession, error) {
 
 	tries := 0
@@ -8784,6 +10374,26 @@ func (s *RetryLayerUploadSessionStore) GetForUser(userId string) ([]*model.Uploa
 
 }
 
+func (s *RetryLayerUploadSessionStore) GetUpdatedBefore(updateAt int64, limit int) ([]*model.UploadSession, error) {
+
+	tries := 0
+	for {
+		result, err := s.UploadSessionStore.GetUpdatedBefore(updateAt, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
 func (s *RetryLayerUploadSessionStore) Save(session *model.UploadSession) (*model.UploadSession, error) {
 
 	tries := 0
@@ -10906,27 +12516,38 @@ func New(childStore store.Store) *RetryLayer {
 	newStore.AuditStore = &RetryLayerAuditStore{AuditStore: childStore.Audit(), Root: &newStore}
 	newStore.BotStore = &RetryLayerBotStore{BotStore: childStore.Bot(), Root: &newStore}
 	newStore.ChannelStore = &RetryLayerChannelStore{ChannelStore: childStore.Channel(), Root: &newStore}
+	newStore.ChannelBookmarkStore = &RetryLayerChannelBookmarkStore{ChannelBookmarkStore: childStore.ChannelBookmark(), Root: &newStore}
 	newStore.ChannelMemberHistoryStore = &RetryLayerChannelMemberHistoryStore{ChannelMemberHistoryStore: childStore.ChannelMemberHistory(), Root: &newStore}
 	newStore.ClusterDiscoveryStore = &RetryLayerClusterDiscoveryStore{ClusterDiscoveryStore: childStore.ClusterDiscovery(), Root: &newStore}
 	newStore.CommandStore = &RetryLayerCommandStore{CommandStore: childStore.Command(), Root: &newStore}
 	newStore.CommandWebhookStore = &RetryLayerCommandWebhookStore{CommandWebhookStore: childStore.CommandWebhook(), Root: &newStore}
 	newStore.ComplianceStore = &RetryLayerComplianceStore{ComplianceStore: childStore.Compliance(), Root: &newStore}
+	newStore.CustomStatusStore = &RetryLayerCustomStatusStore{CustomStatusStore: childStore.CustomStatus(), Root: &newStore}
+	newStore.DndScheduleStore = &RetryLayerDndScheduleStore{DndScheduleStore: childStore.DndSchedule(), Root: &newStore}
+	newStore.DraftStore = &RetryLayerDraftStore{DraftStore: childStore.Draft(), Root: &newStore}
+	newStore.EmailDigestStore = &RetryLayerEmailDigestStore{EmailDigestStore: childStore.EmailDigest(), Root: &newStore}
+	newStore.EmailQueueStore = &RetryLayerEmailQueueStore{EmailQueueStore: childStore.EmailQueue(), Root: &newStore}
 	newStore.EmojiStore = &RetryLayerEmojiStore{EmojiStore: childStore.Emoji(), Root: &newStore}
 	newStore.FileInfoStore = &RetryLayerFileInfoStore{FileInfoStore: childStore.FileInfo(), Root: &newStore}
 	newStore.GroupStore = &RetryLayerGroupStore{GroupStore: childStore.Group(), Root: &newStore}
 	newStore.JobStore = &RetryLayerJobStore{JobStore: childStore.Job(), Root: &newStore}
+	newStore.KeywordWatchStore = &RetryLayerKeywordWatchStore{KeywordWatchStore: childStore.KeywordWatch(), Root: &newStore}
 	newStore.LicenseStore = &RetryLayerLicenseStore{LicenseStore: childStore.License(), Root: &newStore}
 	newStore.LinkMetadataStore = &RetryLayerLinkMetadataStore{LinkMetadataStore: childStore.LinkMetadata(), Root: &newStore}
 	newStore.OAuthStore = &RetryLayerOAuthStore{OAuthStore: childStore.OAuth(), Root: &newStore}
 	newStore.PluginStore = &RetryLayerPluginStore{PluginStore: childStore.Plugin(), Root: &newStore}
+	newStore.PollStore = &RetryLayerPollStore{PollStore: childStore.Poll(), Root: &newStore}
 	newStore.PostStore = &RetryLayerPostStore{PostStore: childStore.Post(), Root: &newStore}
 	newStore.PreferenceStore = &RetryLayerPreferenceStore{PreferenceStore: childStore.Preference(), Root: &newStore}
 	newStore.ProductNoticesStore = &RetryLayerProductNoticesStore{ProductNoticesStore: childStore.ProductNotices(), Root: &newStore}
+	newStore.PublicLinkStore = &RetryLayerPublicLinkStore{PublicLinkStore: childStore.PublicLink(), Root: &newStore}
 	newStore.ReactionStore = &RetryLayerReactionStore{ReactionStore: childStore.Reaction(), Root: &newStore}
 	newStore.RoleStore = &RetryLayerRoleStore{RoleStore: childStore.Role(), Root: &newStore}
+	newStore.SavedSearchStore = &RetryLayerSavedSearchStore{SavedSearchStore: childStore.SavedSearch(), Root: &newStore}
 	newStore.SchemeStore = &RetryLayerSchemeStore{SchemeStore: childStore.Scheme(), Root: &newStore}
 	newStore.SessionStore = &RetryLayerSessionStore{SessionStore: childStore.Session(), Root: &newStore}
 	newStore.StatusStore = &RetryLayerStatusStore{StatusStore: childStore.Status(), Root: &newStore}
+	newStore.StorageUsageStore = &RetryLayerStorageUsageStore{StorageUsageStore: childStore.StorageUsage(), Root: &newStore}
 	newStore.SystemStore = &RetryLayerSystemStore{SystemStore: childStore.System(), Root: &newStore}
 	newStore.TeamStore = &RetryLayerTeamStore{TeamStore: childStore.Team(), Root: &newStore}
 	newStore.TermsOfServiceStore = &RetryLayerTermsOfServiceStore{TermsOfServiceStore: childStore.TermsOfService(), Root: &newStore}
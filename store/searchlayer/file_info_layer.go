@@ -0,0 +1,206 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package searchlayer
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/zacmm/zacmm-server/mlog"
+	"github.com/zacmm/zacmm-server/model"
+	"github.com/zacmm/zacmm-server/services/searchengine"
+	"github.com/zacmm/zacmm-server/store"
+)
+
+type SearchFileInfoStore struct {
+	store.FileInfoStore
+	rootStore *SearchStore
+}
+
+// indexFileFromID indexes the file under the channel of the post it is
+// attached to. Files that are not attached to a post yet are not searchable,
+// so they are indexed once they are.
+func (s SearchFileInfoStore) indexFileFromID(fileId string) {
+	for _, engine := range s.rootStore.searchEngine.GetActiveEngines() {
+		if engine.IsIndexingEnabled() {
+			runIndexFn(engine, func(engineCopy searchengine.SearchEngineInterface) {
+				file, err := s.FileInfoStore.Get(fileId)
+				if err != nil {
+					mlog.Error("Couldn't get file for SearchEngine indexing.", mlog.String("file_info_id", fileId), mlog.String("search_engine", engineCopy.GetName()), mlog.Err(err))
+					return
+				}
+				if file.PostId == "" {
+					return
+				}
+
+				post, err := s.rootStore.Post().GetSingle(file.PostId)
+				if err != nil {
+					mlog.Error("Couldn't get post for file for SearchEngine indexing.", mlog.String("post_id", file.PostId), mlog.String("search_engine", engineCopy.GetName()), mlog.String("file_info_id", file.Id), mlog.Err(err))
+					return
+				}
+
+				if err := engineCopy.IndexFile(file, post.ChannelId); err != nil {
+					mlog.Error("Encountered error indexing file", mlog.String("file_info_id", file.Id), mlog.String("search_engine", engineCopy.GetName()), mlog.Err(err))
+					return
+				}
+				mlog.Debug("Indexed file in search engine", mlog.String("search_engine", engineCopy.GetName()), mlog.String("file_info_id", file.Id))
+			})
+		}
+	}
+}
+
+func (s SearchFileInfoStore) deleteFileIndex(fileId string) {
+	for _, engine := range s.rootStore.searchEngine.GetActiveEngines() {
+		if engine.IsIndexingEnabled() {
+			runIndexFn(engine, func(engineCopy searchengine.SearchEngineInterface) {
+				if err := engineCopy.DeleteFile(fileId); err != nil {
+					mlog.Error("Encountered error deleting file", mlog.String("file_info_id", fileId), mlog.String("search_engine", engineCopy.GetName()), mlog.Err(err))
+					return
+				}
+				mlog.Debug("Removed file from the index in search engine", mlog.String("search_engine", engineCopy.GetName()), mlog.String("file_info_id", fileId))
+			})
+		}
+	}
+}
+
+func (s SearchFileInfoStore) deletePostFilesIndex(postId string) {
+	for _, engine := range s.rootStore.searchEngine.GetActiveEngines() {
+		if engine.IsIndexingEnabled() {
+			runIndexFn(engine, func(engineCopy searchengine.SearchEngineInterface) {
+				if err := engineCopy.DeletePostFiles(postId); err != nil {
+					mlog.Error("Encountered error deleting post files", mlog.String("post_id", postId), mlog.String("search_engine", engineCopy.GetName()), mlog.Err(err))
+					return
+				}
+				mlog.Debug("Removed all post files from the index in search engine", mlog.String("post_id", postId), mlog.String("search_engine", engineCopy.GetName()))
+			})
+		}
+	}
+}
+
+func (s SearchFileInfoStore) deleteUserFilesIndex(userId string) {
+	for _, engine := range s.rootStore.searchEngine.GetActiveEngines() {
+		if engine.IsIndexingEnabled() {
+			runIndexFn(engine, func(engineCopy searchengine.SearchEngineInterface) {
+				if err := engineCopy.DeleteUserFiles(userId); err != nil {
+					mlog.Error("Encountered error deleting user files", mlog.String("user_id", userId), mlog.String("search_engine", engineCopy.GetName()), mlog.Err(err))
+					return
+				}
+				mlog.Debug("Removed all user files from the index in search engine", mlog.String("user_id", userId), mlog.String("search_engine", engineCopy.GetName()))
+			})
+		}
+	}
+}
+
+func (s SearchFileInfoStore) Save(info *model.FileInfo) (*model.FileInfo, error) {
+	nfile, err := s.FileInfoStore.Save(info)
+	if err == nil && nfile.PostId != "" {
+		s.indexFileFromID(nfile.Id)
+	}
+	return nfile, err
+}
+
+func (s SearchFileInfoStore) AttachToPost(fileId, postId, creatorId string) error {
+	err := s.FileInfoStore.AttachToPost(fileId, postId, creatorId)
+	if err == nil {
+		s.indexFileFromID(fileId)
+	}
+	return err
+}
+
+func (s SearchFileInfoStore) SetContent(fileId, content string) error {
+	err := s.FileInfoStore.SetContent(fileId, content)
+	if err == nil {
+		s.indexFileFromID(fileId)
+	}
+	return err
+}
+
+func (s SearchFileInfoStore) DeleteForPost(postId string) (string, error) {
+	result, err := s.FileInfoStore.DeleteForPost(postId)
+	if err == nil {
+		s.deletePostFilesIndex(postId)
+	}
+	return result, err
+}
+
+func (s SearchFileInfoStore) PermanentDelete(fileId string) error {
+	err := s.FileInfoStore.PermanentDelete(fileId)
+	if err == nil {
+		s.deleteFileIndex(fileId)
+	}
+	return err
+}
+
+func (s SearchFileInfoStore) PermanentDeleteByUser(userId string) (int64, error) {
+	result, err := s.FileInfoStore.PermanentDeleteByUser(userId)
+	if err == nil {
+		s.deleteUserFilesIndex(userId)
+	}
+	return result, err
+}
+
+func (s SearchFileInfoStore) searchFilesInTeamForUserByEngine(engine searchengine.SearchEngineInterface, paramsList []*model.SearchParams, userId, teamId string, page, perPage int) (*model.FileInfoList, error) {
+	if err := model.IsSearchParamsListValid(paramsList); err != nil {
+		return nil, err
+	}
+
+	// We only allow the user to search in channels they are a member of.
+	userChannels, nErr := s.rootStore.Channel().GetChannels(teamId, userId, paramsList[0].IncludeDeletedChannels, 0)
+	if nErr != nil {
+		mlog.Error("error getting channel for user", mlog.Err(nErr))
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(nErr, &nfErr):
+			return nil, model.NewAppError("searchFilesInTeamForUserByEngine", "app.channel.get_channels.not_found.app_error", nil, nfErr.Error(), http.StatusNotFound)
+		default:
+			return nil, model.NewAppError("searchFilesInTeamForUserByEngine", "app.channel.get_channels.get.app_error", nil, nErr.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	fileIds, appErr := engine.SearchFiles(userChannels, paramsList, page, perPage)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	// Get the files, keeping the order of the search results
+	fileInfoList := model.NewFileInfoList()
+	for _, fileId := range fileIds {
+		fileInfo, err := s.FileInfoStore.Get(fileId)
+		if err != nil {
+			var nfErr *store.ErrNotFound
+			if errors.As(err, &nfErr) {
+				continue
+			}
+			return nil, err
+		}
+		if fileInfo.DeleteAt == 0 && fileInfo.PostId != "" {
+			fileInfoList.AddFileInfo(fileInfo)
+			fileInfoList.AddOrder(fileInfo.Id)
+		}
+	}
+
+	return fileInfoList, nil
+}
+
+func (s SearchFileInfoStore) Search(paramsList []*model.SearchParams, userId, teamId string, page, perPage int) (*model.FileInfoList, error) {
+	for _, engine := range s.rootStore.searchEngine.GetActiveEngines() {
+		if engine.IsSearchEnabled() {
+			results, err := s.searchFilesInTeamForUserByEngine(engine, paramsList, userId, teamId, page, perPage)
+			if err != nil {
+				mlog.Error("Encountered error on Search.", mlog.String("search_engine", engine.GetName()), mlog.Err(err))
+				continue
+			}
+			mlog.Debug("Using the first available search engine", mlog.String("search_engine", engine.GetName()))
+			return results, err
+		}
+	}
+
+	if *s.rootStore.config.SqlSettings.DisableDatabaseSearch {
+		mlog.Debug("Returning empty results for file Search as the database search is disabled")
+		return model.NewFileInfoList(), nil
+	}
+
+	mlog.Debug("Using database search because no other search engine is available")
+	return s.FileInfoStore.Search(paramsList, userId, teamId, page, perPage)
+}
@@ -17,6 +17,8 @@ type SearchStore struct {
 	team         *SearchTeamStore
 	channel      *SearchChannelStore
 	post         *SearchPostStore
+	fileInfo     *SearchFileInfoStore
+	reaction     *SearchReactionStore
 	config       *model.Config
 }
 
@@ -28,6 +30,8 @@ func NewSearchLayer(baseStore store.Store, searchEngine *searchengine.Broker, cf
 	}
 	searchStore.channel = &SearchChannelStore{ChannelStore: baseStore.Channel(), rootStore: searchStore}
 	searchStore.post = &SearchPostStore{PostStore: baseStore.Post(), rootStore: searchStore}
+	searchStore.fileInfo = &SearchFileInfoStore{FileInfoStore: baseStore.FileInfo(), rootStore: searchStore}
+	searchStore.reaction = &SearchReactionStore{ReactionStore: baseStore.Reaction(), rootStore: searchStore}
 	searchStore.team = &SearchTeamStore{TeamStore: baseStore.Team(), rootStore: searchStore}
 	searchStore.user = &SearchUserStore{UserStore: baseStore.User(), rootStore: searchStore}
 
@@ -46,6 +50,14 @@ func (s *SearchStore) Post() store.PostStore {
 	return s.post
 }
 
+func (s *SearchStore) FileInfo() store.FileInfoStore {
+	return s.fileInfo
+}
+
+func (s *SearchStore) Reaction() store.ReactionStore {
+	return s.reaction
+}
+
 func (s *SearchStore) Team() store.TeamStore {
 	return s.team
 }
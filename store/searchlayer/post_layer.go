@@ -131,6 +131,150 @@ func (s SearchPostStore) PermanentDeleteByChannel(channelID string) error {
 	return err
 }
 
+// The maximum number of posts that the reacted: and ext: filters resolve to
+// when searching with an engine.
+const searchResolvedPostsLimit = 1000
+
+// resolveUserFilters turns the search filters that depend on the searching
+// user or on the posts' attachments, which the engines don't index, into the
+// post and thread ids that the engines can filter by. It returns false when a
+// filter matches no posts at all.
+func (s SearchPostStore) resolveUserFilters(paramsList []*model.SearchParams, userId string) ([]*model.SearchParams, bool, error) {
+	resolvedList := make([]*model.SearchParams, 0, len(paramsList))
+	for _, params := range paramsList {
+		resolved := *params
+		resolved.IsFilters = []string{}
+		resolved.ExcludedIsFilters = []string{}
+
+		var postIds []string
+		restricted := false
+		restrict := func(ids []string) {
+			if !restricted {
+				postIds = ids
+				restricted = true
+				return
+			}
+			postIds = intersectStrings(postIds, ids)
+		}
+
+		for _, filter := range params.IsFilters {
+			switch filter {
+			case model.SEARCH_IS_FLAGGED:
+				flaggedIds, err := s.getFlaggedPostIds(userId)
+				if err != nil {
+					return nil, false, err
+				}
+				restrict(flaggedIds)
+			case model.SEARCH_IS_THREAD:
+				threadIds, err := s.getFollowedThreadIds(userId)
+				if err != nil {
+					return nil, false, err
+				}
+				if len(threadIds) == 0 {
+					return nil, false, nil
+				}
+				resolved.ThreadIds = threadIds
+			default:
+				resolved.IsFilters = append(resolved.IsFilters, filter)
+			}
+		}
+
+		for _, filter := range params.ExcludedIsFilters {
+			switch filter {
+			case model.SEARCH_IS_FLAGGED:
+				flaggedIds, err := s.getFlaggedPostIds(userId)
+				if err != nil {
+					return nil, false, err
+				}
+				resolved.ExcludedPostIds = append(resolved.ExcludedPostIds, flaggedIds...)
+			case model.SEARCH_IS_THREAD:
+				threadIds, err := s.getFollowedThreadIds(userId)
+				if err != nil {
+					return nil, false, err
+				}
+				resolved.ExcludedThreadIds = append(resolved.ExcludedThreadIds, threadIds...)
+			default:
+				resolved.ExcludedIsFilters = append(resolved.ExcludedIsFilters, filter)
+			}
+		}
+
+		if len(params.ReactedUsers) > 0 {
+			reactedIds, err := s.rootStore.Reaction().GetPostIdsForUsers(params.ReactedUsers, searchResolvedPostsLimit)
+			if err != nil {
+				return nil, false, err
+			}
+			restrict(reactedIds)
+		}
+
+		if len(params.ExcludedReactedUsers) > 0 {
+			reactedIds, err := s.rootStore.Reaction().GetPostIdsForUsers(params.ExcludedReactedUsers, searchResolvedPostsLimit)
+			if err != nil {
+				return nil, false, err
+			}
+			resolved.ExcludedPostIds = append(resolved.ExcludedPostIds, reactedIds...)
+		}
+
+		if len(params.Extensions) > 0 {
+			extensionIds, err := s.rootStore.FileInfo().GetPostIdsForExtensions(params.Extensions, searchResolvedPostsLimit)
+			if err != nil {
+				return nil, false, err
+			}
+			restrict(extensionIds)
+		}
+
+		if len(params.ExcludedExtensions) > 0 {
+			extensionIds, err := s.rootStore.FileInfo().GetPostIdsForExtensions(params.ExcludedExtensions, searchResolvedPostsLimit)
+			if err != nil {
+				return nil, false, err
+			}
+			resolved.ExcludedPostIds = append(resolved.ExcludedPostIds, extensionIds...)
+		}
+
+		if restricted {
+			if len(postIds) == 0 {
+				return nil, false, nil
+			}
+			resolved.PostIds = postIds
+		}
+
+		resolvedList = append(resolvedList, &resolved)
+	}
+
+	return resolvedList, true, nil
+}
+
+func (s SearchPostStore) getFlaggedPostIds(userId string) ([]string, error) {
+	preferences, err := s.rootStore.Preference().GetCategory(userId, model.PREFERENCE_CATEGORY_FLAGGED_POST)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		if errors.As(err, &nfErr) {
+			return []string{}, nil
+		}
+		return nil, err
+	}
+
+	postIds := make([]string, 0, len(preferences))
+	for _, preference := range preferences {
+		postIds = append(postIds, preference.Name)
+	}
+	return postIds, nil
+}
+
+func (s SearchPostStore) getFollowedThreadIds(userId string) ([]string, error) {
+	memberships, err := s.rootStore.Thread().GetMembershipsForUser(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	threadIds := []string{}
+	for _, membership := range memberships {
+		if membership.Following {
+			threadIds = append(threadIds, membership.PostId)
+		}
+	}
+	return threadIds, nil
+}
+
 func (s SearchPostStore) searchPostsInTeamForUserByEngine(engine searchengine.SearchEngineInterface, paramsList []*model.SearchParams, userId, teamId string, page, perPage int) (*model.PostSearchResults, error) {
 	if err := model.IsSearchParamsListValid(paramsList); err != nil {
 		return nil, err
@@ -150,6 +294,14 @@ func (s SearchPostStore) searchPostsInTeamForUserByEngine(engine searchengine.Se
 		}
 	}
 
+	paramsList, ok, nErr := s.resolveUserFilters(paramsList, userId)
+	if nErr != nil {
+		return nil, nErr
+	}
+	if !ok {
+		return model.MakePostSearchResults(model.NewPostList(), nil), nil
+	}
+
 	postIds, matches, err := engine.SearchPosts(userChannels, paramsList, page, perPage)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,42 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package searchlayer
+
+import (
+	"github.com/zacmm/zacmm-server/mlog"
+	"github.com/zacmm/zacmm-server/model"
+	"github.com/zacmm/zacmm-server/store"
+)
+
+type SearchReactionStore struct {
+	store.ReactionStore
+	rootStore *SearchStore
+}
+
+// indexPostFromID reindexes the post of a reaction, as the engines index
+// whether posts have reactions.
+func (s SearchReactionStore) indexPostFromID(postId string) {
+	post, err := s.rootStore.Post().GetSingle(postId)
+	if err != nil {
+		mlog.Error("Couldn't get post of reaction for SearchEngine indexing.", mlog.String("post_id", postId), mlog.Err(err))
+		return
+	}
+	s.rootStore.post.indexPost(post)
+}
+
+func (s SearchReactionStore) Save(reaction *model.Reaction) (*model.Reaction, error) {
+	savedReaction, err := s.ReactionStore.Save(reaction)
+	if err == nil {
+		s.indexPostFromID(savedReaction.PostId)
+	}
+	return savedReaction, err
+}
+
+func (s SearchReactionStore) Delete(reaction *model.Reaction) (*model.Reaction, error) {
+	deletedReaction, err := s.ReactionStore.Delete(reaction)
+	if err == nil {
+		s.indexPostFromID(deletedReaction.PostId)
+	}
+	return deletedReaction, err
+}
@@ -8,3 +8,20 @@ import "strings"
 func sanitizeSearchTerm(term string) string {
 	return strings.TrimLeft(term, "@")
 }
+
+// intersectStrings returns the strings of a that are also in b, in the order
+// of a.
+func intersectStrings(a, b []string) []string {
+	inB := make(map[string]bool, len(b))
+	for _, s := range b {
+		inB[s] = true
+	}
+
+	result := []string{}
+	for _, s := range a {
+		if inB[s] {
+			result = append(result, s)
+		}
+	}
+	return result
+}
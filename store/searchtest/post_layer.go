@@ -31,7 +31,7 @@ var searchPostStoreTests = []searchTest{
 	{
 		Name: "Should be able to search for exact phrases in quotes",
 		Fn:   testSearchExactPhraseInQuotes,
-		Tags: []string{ENGINE_POSTGRES, ENGINE_MYSQL, ENGINE_ELASTICSEARCH},
+		Tags: []string{ENGINE_POSTGRES, ENGINE_MYSQL, ENGINE_ELASTICSEARCH, ENGINE_BLEVE},
 	},
 	{
 		Name: "Should be able to search for email addresses with or without quotes",
@@ -261,6 +261,31 @@ var searchPostStoreTests = []searchTest{
 		Fn:   testShouldNotReturnLinksEmbeddedInMarkdown,
 		Tags: []string{ENGINE_POSTGRES, ENGINE_ELASTICSEARCH},
 	},
+	{
+		Name: "Should be able to filter messages written after a specific time",
+		Fn:   testFilterMessagesAfterSpecificTime,
+		Tags: []string{ENGINE_MYSQL, ENGINE_POSTGRES, ENGINE_BLEVE},
+	},
+	{
+		Name: "Should be able to filter messages with links or reactions",
+		Fn:   testFilterMessagesWithLinksOrReactions,
+		Tags: []string{ENGINE_MYSQL, ENGINE_POSTGRES, ENGINE_BLEVE},
+	},
+	{
+		Name: "Should be able to filter pinned and flagged messages",
+		Fn:   testFilterPinnedAndFlaggedMessages,
+		Tags: []string{ENGINE_MYSQL, ENGINE_POSTGRES, ENGINE_BLEVE},
+	},
+	{
+		Name: "Should be able to filter messages by the users who reacted to them",
+		Fn:   testFilterMessagesReactedByUsers,
+		Tags: []string{ENGINE_MYSQL, ENGINE_POSTGRES, ENGINE_BLEVE},
+	},
+	{
+		Name: "Should be able to filter messages by the extensions of their attachments",
+		Fn:   testFilterMessagesByAttachmentExtensions,
+		Tags: []string{ENGINE_MYSQL, ENGINE_POSTGRES, ENGINE_BLEVE},
+	},
 }
 
 func TestSearchPostStore(t *testing.T, s store.Store, testEngine *SearchTestEngine) {
@@ -1812,3 +1837,211 @@ func testShouldNotReturnLinksEmbeddedInMarkdown(t *testing.T, th *SearchTestHelp
 
 	require.Len(t, results.Posts, 0)
 }
+
+func testFilterMessagesAfterSpecificTime(t *testing.T, th *SearchTestHelper) {
+	creationDate := model.GetMillisForTime(time.Date(2020, 03, 01, 9, 29, 59, 0, time.UTC))
+	p1, err := th.createPost(th.User.Id, th.ChannelBasic.Id, "test before the time", "", model.POST_DEFAULT, creationDate, false)
+	require.Nil(t, err)
+	creationDate2 := model.GetMillisForTime(time.Date(2020, 03, 01, 9, 31, 0, 0, time.UTC))
+	p2, err := th.createPost(th.User.Id, th.ChannelBasic.Id, "test after the time", "", model.POST_DEFAULT, creationDate2, false)
+	require.Nil(t, err)
+	defer th.deleteUserPosts(th.User.Id)
+
+	t.Run("Should be able to search posts after a time", func(t *testing.T) {
+		params := &model.SearchParams{
+			Terms:     "test",
+			AfterDate: "2020-03-01T09:30",
+		}
+		results, apperr := th.Store.Post().SearchPostsInTeamForUser([]*model.SearchParams{params}, th.User.Id, th.Team.Id, 0, 20)
+		require.Nil(t, apperr)
+
+		require.Len(t, results.Posts, 1)
+		th.checkPostInSearchResults(t, p2.Id, results.Posts)
+	})
+
+	t.Run("Should be able to search posts before a time", func(t *testing.T) {
+		params := &model.SearchParams{
+			Terms:      "test",
+			BeforeDate: "2020-03-01T09:30",
+		}
+		results, apperr := th.Store.Post().SearchPostsInTeamForUser([]*model.SearchParams{params}, th.User.Id, th.Team.Id, 0, 20)
+		require.Nil(t, apperr)
+
+		require.Len(t, results.Posts, 1)
+		th.checkPostInSearchResults(t, p1.Id, results.Posts)
+	})
+}
+
+func testFilterMessagesWithLinksOrReactions(t *testing.T, th *SearchTestHelper) {
+	p1, err := th.createPost(th.User.Id, th.ChannelBasic.Id, "test with https://example.com", "", model.POST_DEFAULT, 0, false)
+	require.Nil(t, err)
+	p2, err := th.createPost(th.User.Id, th.ChannelBasic.Id, "test with a reaction", "", model.POST_DEFAULT, 0, false)
+	require.Nil(t, err)
+	p3, err := th.createPost(th.User.Id, th.ChannelBasic.Id, "test without anything", "", model.POST_DEFAULT, 0, false)
+	require.Nil(t, err)
+	defer th.deleteUserPosts(th.User.Id)
+
+	_, err = th.Store.Reaction().Save(&model.Reaction{UserId: th.User2.Id, PostId: p2.Id, EmojiName: "smile"})
+	require.Nil(t, err)
+
+	t.Run("Should be able to search posts with links", func(t *testing.T) {
+		params := &model.SearchParams{Terms: "test", HasFilters: []string{model.SEARCH_HAS_LINK}}
+		results, apperr := th.Store.Post().SearchPostsInTeamForUser([]*model.SearchParams{params}, th.User.Id, th.Team.Id, 0, 20)
+		require.Nil(t, apperr)
+
+		require.Len(t, results.Posts, 1)
+		th.checkPostInSearchResults(t, p1.Id, results.Posts)
+	})
+
+	t.Run("Should be able to search posts with reactions", func(t *testing.T) {
+		params := &model.SearchParams{Terms: "test", HasFilters: []string{model.SEARCH_HAS_REACTION}}
+		results, apperr := th.Store.Post().SearchPostsInTeamForUser([]*model.SearchParams{params}, th.User.Id, th.Team.Id, 0, 20)
+		require.Nil(t, apperr)
+
+		require.Len(t, results.Posts, 1)
+		th.checkPostInSearchResults(t, p2.Id, results.Posts)
+	})
+
+	t.Run("Should be able to exclude posts with links or reactions", func(t *testing.T) {
+		params := &model.SearchParams{Terms: "test", ExcludedHasFilters: []string{model.SEARCH_HAS_LINK, model.SEARCH_HAS_REACTION}}
+		results, apperr := th.Store.Post().SearchPostsInTeamForUser([]*model.SearchParams{params}, th.User.Id, th.Team.Id, 0, 20)
+		require.Nil(t, apperr)
+
+		require.Len(t, results.Posts, 1)
+		th.checkPostInSearchResults(t, p3.Id, results.Posts)
+	})
+}
+
+func testFilterPinnedAndFlaggedMessages(t *testing.T, th *SearchTestHelper) {
+	p1, err := th.createPost(th.User.Id, th.ChannelBasic.Id, "test pinned", "", model.POST_DEFAULT, 0, true)
+	require.Nil(t, err)
+	p2, err := th.createPost(th.User.Id, th.ChannelBasic.Id, "test flagged", "", model.POST_DEFAULT, 0, false)
+	require.Nil(t, err)
+	p3, err := th.createPost(th.User.Id, th.ChannelBasic.Id, "test neither", "", model.POST_DEFAULT, 0, false)
+	require.Nil(t, err)
+	defer th.deleteUserPosts(th.User.Id)
+
+	err = th.Store.Preference().Save(&model.Preferences{{UserId: th.User.Id, Category: model.PREFERENCE_CATEGORY_FLAGGED_POST, Name: p2.Id, Value: "true"}})
+	require.Nil(t, err)
+	defer th.Store.Preference().Delete(th.User.Id, model.PREFERENCE_CATEGORY_FLAGGED_POST, p2.Id)
+
+	t.Run("Should be able to search pinned posts", func(t *testing.T) {
+		params := &model.SearchParams{Terms: "test", IsFilters: []string{model.SEARCH_IS_PINNED}}
+		results, apperr := th.Store.Post().SearchPostsInTeamForUser([]*model.SearchParams{params}, th.User.Id, th.Team.Id, 0, 20)
+		require.Nil(t, apperr)
+
+		require.Len(t, results.Posts, 1)
+		th.checkPostInSearchResults(t, p1.Id, results.Posts)
+	})
+
+	t.Run("Should be able to search flagged posts", func(t *testing.T) {
+		params := &model.SearchParams{Terms: "test", IsFilters: []string{model.SEARCH_IS_FLAGGED}}
+		results, apperr := th.Store.Post().SearchPostsInTeamForUser([]*model.SearchParams{params}, th.User.Id, th.Team.Id, 0, 20)
+		require.Nil(t, apperr)
+
+		require.Len(t, results.Posts, 1)
+		th.checkPostInSearchResults(t, p2.Id, results.Posts)
+	})
+
+	t.Run("Should not return the flagged posts of other users", func(t *testing.T) {
+		params := &model.SearchParams{Terms: "test", IsFilters: []string{model.SEARCH_IS_FLAGGED}}
+		results, apperr := th.Store.Post().SearchPostsInTeamForUser([]*model.SearchParams{params}, th.User2.Id, th.Team.Id, 0, 20)
+		require.Nil(t, apperr)
+
+		require.Len(t, results.Posts, 0)
+	})
+
+	t.Run("Should be able to exclude pinned and flagged posts", func(t *testing.T) {
+		params := &model.SearchParams{Terms: "test", ExcludedIsFilters: []string{model.SEARCH_IS_PINNED, model.SEARCH_IS_FLAGGED}}
+		results, apperr := th.Store.Post().SearchPostsInTeamForUser([]*model.SearchParams{params}, th.User.Id, th.Team.Id, 0, 20)
+		require.Nil(t, apperr)
+
+		require.Len(t, results.Posts, 1)
+		th.checkPostInSearchResults(t, p3.Id, results.Posts)
+	})
+}
+
+func testFilterMessagesReactedByUsers(t *testing.T, th *SearchTestHelper) {
+	p1, err := th.createPost(th.User.Id, th.ChannelBasic.Id, "test reacted", "", model.POST_DEFAULT, 0, false)
+	require.Nil(t, err)
+	p2, err := th.createPost(th.User.Id, th.ChannelBasic.Id, "test not reacted", "", model.POST_DEFAULT, 0, false)
+	require.Nil(t, err)
+	defer th.deleteUserPosts(th.User.Id)
+
+	_, err = th.Store.Reaction().Save(&model.Reaction{UserId: th.User2.Id, PostId: p1.Id, EmojiName: "smile"})
+	require.Nil(t, err)
+
+	t.Run("Should be able to search posts reacted by a user", func(t *testing.T) {
+		params := &model.SearchParams{Terms: "test", ReactedUsers: []string{th.User2.Id}}
+		results, apperr := th.Store.Post().SearchPostsInTeamForUser([]*model.SearchParams{params}, th.User.Id, th.Team.Id, 0, 20)
+		require.Nil(t, apperr)
+
+		require.Len(t, results.Posts, 1)
+		th.checkPostInSearchResults(t, p1.Id, results.Posts)
+	})
+
+	t.Run("Should be able to exclude posts reacted by a user", func(t *testing.T) {
+		params := &model.SearchParams{Terms: "test", ExcludedReactedUsers: []string{th.User2.Id}}
+		results, apperr := th.Store.Post().SearchPostsInTeamForUser([]*model.SearchParams{params}, th.User.Id, th.Team.Id, 0, 20)
+		require.Nil(t, apperr)
+
+		require.Len(t, results.Posts, 1)
+		th.checkPostInSearchResults(t, p2.Id, results.Posts)
+	})
+
+	t.Run("Should return no posts when the user reacted to none", func(t *testing.T) {
+		params := &model.SearchParams{Terms: "test", ReactedUsers: []string{th.User.Id}}
+		results, apperr := th.Store.Post().SearchPostsInTeamForUser([]*model.SearchParams{params}, th.User.Id, th.Team.Id, 0, 20)
+		require.Nil(t, apperr)
+
+		require.Len(t, results.Posts, 0)
+	})
+}
+
+func testFilterMessagesByAttachmentExtensions(t *testing.T, th *SearchTestHelper) {
+	p1, err := th.createPost(th.User.Id, th.ChannelBasic.Id, "test with a pdf", "", model.POST_DEFAULT, 0, false)
+	require.Nil(t, err)
+	p2, err := th.createPost(th.User.Id, th.ChannelBasic.Id, "test with a png", "", model.POST_DEFAULT, 0, false)
+	require.Nil(t, err)
+	p3, err := th.createPost(th.User.Id, th.ChannelBasic.Id, "test without attachments", "", model.POST_DEFAULT, 0, false)
+	require.Nil(t, err)
+	defer th.deleteUserPosts(th.User.Id)
+
+	for postId, extension := range map[string]string{p1.Id: "pdf", p2.Id: "png"} {
+		info, err := th.Store.FileInfo().Save(&model.FileInfo{
+			CreatorId: th.User.Id,
+			PostId:    postId,
+			Path:      "file." + extension,
+			Name:      "file." + extension,
+			Extension: extension,
+		})
+		require.Nil(t, err)
+		defer th.Store.FileInfo().PermanentDelete(info.Id)
+	}
+
+	t.Run("Should be able to search posts with an attachment extension", func(t *testing.T) {
+		params := &model.SearchParams{Terms: "test", Extensions: []string{"pdf"}}
+		results, apperr := th.Store.Post().SearchPostsInTeamForUser([]*model.SearchParams{params}, th.User.Id, th.Team.Id, 0, 20)
+		require.Nil(t, apperr)
+
+		require.Len(t, results.Posts, 1)
+		th.checkPostInSearchResults(t, p1.Id, results.Posts)
+	})
+
+	t.Run("Should be able to exclude posts with an attachment extension", func(t *testing.T) {
+		params := &model.SearchParams{Terms: "test", ExcludedExtensions: []string{"pdf", "png"}}
+		results, apperr := th.Store.Post().SearchPostsInTeamForUser([]*model.SearchParams{params}, th.User.Id, th.Team.Id, 0, 20)
+		require.Nil(t, apperr)
+
+		require.Len(t, results.Posts, 1)
+		th.checkPostInSearchResults(t, p3.Id, results.Posts)
+	})
+
+	t.Run("Should return no posts when no attachment has the extension", func(t *testing.T) {
+		params := &model.SearchParams{Terms: "test", Extensions: []string{"doc"}}
+		results, apperr := th.Store.Post().SearchPostsInTeamForUser([]*model.SearchParams{params}, th.User.Id, th.Team.Id, 0, 20)
+		require.Nil(t, apperr)
+
+		require.Len(t, results.Posts, 0)
+	})
+}
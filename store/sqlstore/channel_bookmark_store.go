@@ -0,0 +1,234 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	"github.com/pkg/errors"
+
+	"github.com/zacmm/zacmm-server/model"
+	"github.com/zacmm/zacmm-server/store"
+)
+
+type SqlChannelBookmarkStore struct {
+	*SqlSupplier
+}
+
+func newSqlChannelBookmarkStore(sqlSupplier *SqlSupplier) store.ChannelBookmarkStore {
+	s := &SqlChannelBookmarkStore{
+		SqlSupplier: sqlSupplier,
+	}
+
+	for _, db := range sqlSupplier.GetAllConns() {
+		table := db.AddTableWithName(model.ChannelBookmark{}, "ChannelBookmarks").SetKeys(false, "Id")
+		table.ColMap("Id").SetMaxSize(26)
+		table.ColMap("ChannelId").SetMaxSize(26)
+		table.ColMap("OwnerId").SetMaxSize(26)
+		table.ColMap("FileId").SetMaxSize(26)
+		table.ColMap("DisplayName").SetMaxSize(model.CHANNEL_BOOKMARK_DISPLAY_NAME_MAX_RUNES)
+		table.ColMap("LinkUrl").SetMaxSize(model.CHANNEL_BOOKMARK_URL_MAX_RUNES)
+		table.ColMap("ImageUrl").SetMaxSize(model.CHANNEL_BOOKMARK_URL_MAX_RUNES)
+		table.ColMap("Emoji").SetMaxSize(model.CHANNEL_BOOKMARK_EMOJI_MAX_RUNES)
+		table.ColMap("Type").SetMaxSize(26)
+	}
+
+	return s
+}
+
+func (s *SqlChannelBookmarkStore) createIndexesIfNotExists() {
+	s.CreateIndexIfNotExists("idx_channelbookmarks_channel_id", "ChannelBookmarks", "ChannelId")
+	s.CreateIndexIfNotExists("idx_channelbookmarks_file_id", "ChannelBookmarks", "FileId")
+	s.CreateIndexIfNotExists("idx_channelbookmarks_delete_at", "ChannelBookmarks", "DeleteAt")
+}
+
+// Save stores a new bookmark after the existing bookmarks of its channel.
+func (s *SqlChannelBookmarkStore) Save(bookmark *model.ChannelBookmark) (*model.ChannelBookmark, error) {
+	bookmark.PreSave()
+	if err := bookmark.IsValid(); err != nil {
+		return nil, err
+	}
+
+	transaction, err := s.GetMaster().Begin()
+	if err != nil {
+		return nil, errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransaction(transaction)
+
+	sortOrder, err := transaction.SelectInt("SELECT COALESCE(MAX(SortOrder), -1) + 1 FROM ChannelBookmarks WHERE ChannelId = :ChannelId AND DeleteAt = 0", map[string]interface{}{"ChannelId": bookmark.ChannelId})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get next sort order for ChannelBookmarks with channelId=%s", bookmark.ChannelId)
+	}
+	bookmark.SortOrder = sortOrder
+
+	if err = transaction.Insert(bookmark); err != nil {
+		return nil, errors.Wrapf(err, "failed to save ChannelBookmark with id=%s", bookmark.Id)
+	}
+
+	if err = transaction.Commit(); err != nil {
+		return nil, errors.Wrap(err, "commit_transaction")
+	}
+
+	return bookmark, nil
+}
+
+func (s *SqlChannelBookmarkStore) Update(bookmark *model.ChannelBookmark) error {
+	bookmark.PreUpdate()
+	if err := bookmark.IsValid(); err != nil {
+		return err
+	}
+
+	count, err := s.GetMaster().Update(bookmark)
+	if err != nil {
+		return errors.Wrapf(err, "failed to update ChannelBookmark with id=%s", bookmark.Id)
+	}
+
+	if count == 0 {
+		return store.NewErrNotFound("ChannelBookmark", bookmark.Id)
+	}
+
+	return nil
+}
+
+func (s *SqlChannelBookmarkStore) Get(bookmarkId string, includeDeleted bool) (*model.ChannelBookmark, error) {
+	query := "SELECT * FROM ChannelBookmarks WHERE Id = :Id"
+	if !includeDeleted {
+		query += " AND DeleteAt = 0"
+	}
+
+	var bookmark model.ChannelBookmark
+	if err := s.GetReplica().SelectOne(&bookmark, query, map[string]interface{}{"Id": bookmarkId}); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("ChannelBookmark", bookmarkId)
+		}
+		return nil, errors.Wrapf(err, "failed to get ChannelBookmark with id=%s", bookmarkId)
+	}
+
+	return &bookmark, nil
+}
+
+// GetBookmarksForFile returns the bookmarks that are not deleted and point to
+// the file, oldest first.
+func (s *SqlChannelBookmarkStore) GetBookmarksForFile(fileId string) ([]*model.ChannelBookmark, error) {
+	var bookmarks []*model.ChannelBookmark
+	if _, err := s.GetReplica().Select(&bookmarks, "SELECT * FROM ChannelBookmarks WHERE FileId = :FileId AND DeleteAt = 0 ORDER BY CreateAt", map[string]interface{}{"FileId": fileId}); err != nil {
+		return nil, errors.Wrapf(err, "failed to find ChannelBookmarks with fileId=%s", fileId)
+	}
+
+	return bookmarks, nil
+}
+
+func (s *SqlChannelBookmarkStore) GetBookmarksForChannel(channelId string) ([]*model.ChannelBookmark, error) {
+	var bookmarks []*model.ChannelBookmark
+	if _, err := s.GetReplica().Select(&bookmarks, "SELECT * FROM ChannelBookmarks WHERE ChannelId = :ChannelId AND DeleteAt = 0 ORDER BY SortOrder, CreateAt", map[string]interface{}{"ChannelId": channelId}); err != nil {
+		return nil, errors.Wrapf(err, "failed to find ChannelBookmarks with channelId=%s", channelId)
+	}
+
+	return bookmarks, nil
+}
+
+// UpdateSortOrder moves the bookmark to newIndex within its channel, renumbering
+// the channel's other bookmarks to match, and returns them in their new order.
+func (s *SqlChannelBookmarkStore) UpdateSortOrder(bookmarkId, channelId string, newIndex int64) ([]*model.ChannelBookmark, error) {
+	transaction, err := s.GetMaster().Begin()
+	if err != nil {
+		return nil, errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransaction(transaction)
+
+	var bookmarks []*model.ChannelBookmark
+	if _, err = transaction.Select(&bookmarks, "SELECT * FROM ChannelBookmarks WHERE ChannelId = :ChannelId AND DeleteAt = 0 ORDER BY SortOrder, CreateAt", map[string]interface{}{"ChannelId": channelId}); err != nil {
+		return nil, errors.Wrapf(err, "failed to find ChannelBookmarks with channelId=%s", channelId)
+	}
+
+	currentIndex := -1
+	for i, bookmark := range bookmarks {
+		if bookmark.Id == bookmarkId {
+			currentIndex = i
+			break
+		}
+	}
+
+	if currentIndex == -1 {
+		return nil, store.NewErrNotFound("ChannelBookmark", bookmarkId)
+	}
+
+	if newIndex < 0 || newIndex >= int64(len(bookmarks)) {
+		return nil, store.NewErrInvalidInput("ChannelBookmark", "SortOrder", newIndex)
+	}
+
+	moved := bookmarks[currentIndex]
+	bookmarks = append(bookmarks[:currentIndex], bookmarks[currentIndex+1:]...)
+	bookmarks = append(bookmarks[:newIndex], append([]*model.ChannelBookmark{moved}, bookmarks[newIndex:]...)...)
+
+	now := model.GetMillis()
+	for i, bookmark := range bookmarks {
+		if bookmark.SortOrder == int64(i) {
+			continue
+		}
+
+		bookmark.SortOrder = int64(i)
+		bookmark.UpdateAt = now
+		if _, err = transaction.Exec("UPDATE ChannelBookmarks SET SortOrder = :SortOrder, UpdateAt = :UpdateAt WHERE Id = :Id", map[string]interface{}{"SortOrder": bookmark.SortOrder, "UpdateAt": bookmark.UpdateAt, "Id": bookmark.Id}); err != nil {
+			return nil, errors.Wrapf(err, "failed to update sort order of ChannelBookmark with id=%s", bookmark.Id)
+		}
+	}
+
+	if err = transaction.Commit(); err != nil {
+		return nil, errors.Wrap(err, "commit_transaction")
+	}
+
+	return bookmarks, nil
+}
+
+func (s *SqlChannelBookmarkStore) Delete(bookmarkId string) error {
+	now := model.GetMillis()
+	result, err := s.GetMaster().Exec("UPDATE ChannelBookmarks SET DeleteAt = :DeleteAt, UpdateAt = :UpdateAt WHERE Id = :Id AND DeleteAt = 0", map[string]interface{}{"DeleteAt": now, "UpdateAt": now, "Id": bookmarkId})
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete ChannelBookmark with id=%s", bookmarkId)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "unable to get rows affected")
+	}
+
+	if count == 0 {
+		return store.NewErrNotFound("ChannelBookmark", bookmarkId)
+	}
+
+	return nil
+}
+
+// GetAllForExport returns the bookmarks of team channels that have not been
+// deleted, along with the names of their team, channel and owner.
+func (s *SqlChannelBookmarkStore) GetAllForExport(limit int, afterId string) ([]*model.ChannelBookmarkForExport, error) {
+	var bookmarks []*model.ChannelBookmarkForExport
+	if _, err := s.GetReplica().Select(&bookmarks, `
+		SELECT
+			ChannelBookmarks.*,
+			Teams.Name as TeamName,
+			Channels.Name as ChannelName,
+			Users.Username as Username
+		FROM ChannelBookmarks
+		INNER JOIN
+			Channels ON ChannelBookmarks.ChannelId = Channels.Id
+		INNER JOIN
+			Teams ON Channels.TeamId = Teams.Id
+		INNER JOIN
+			Users ON ChannelBookmarks.OwnerId = Users.Id
+		WHERE
+			ChannelBookmarks.Id > :AfterId
+			AND ChannelBookmarks.DeleteAt = 0
+			AND Channels.DeleteAt = 0
+			AND Channels.Type IN ('O', 'P')
+		ORDER BY
+			ChannelBookmarks.Id
+		LIMIT :Limit`,
+		map[string]interface{}{"AfterId": afterId, "Limit": limit}); err != nil {
+		return nil, errors.Wrap(err, "failed to find ChannelBookmarks for export")
+	}
+
+	return bookmarks, nil
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/zacmm/zacmm-server/model"
+	"github.com/zacmm/zacmm-server/store"
+)
+
+type SqlCustomStatusStore struct {
+	*SqlSupplier
+}
+
+func newSqlCustomStatusStore(sqlSupplier *SqlSupplier) store.CustomStatusStore {
+	s := &SqlCustomStatusStore{
+		SqlSupplier: sqlSupplier,
+	}
+
+	for _, db := range sqlSupplier.GetAllConns() {
+		table := db.AddTableWithName(model.CustomStatus{}, "CustomStatuses").SetKeys(false, "UserId")
+		table.ColMap("UserId").SetMaxSize(26)
+		table.ColMap("Emoji").SetMaxSize(model.CUSTOM_STATUS_EMOJI_MAX_RUNES)
+		table.ColMap("Text").SetMaxSize(model.CUSTOM_STATUS_TEXT_MAX_RUNES)
+	}
+
+	return s
+}
+
+func (s *SqlCustomStatusStore) createIndexesIfNotExists() {
+	s.CreateIndexIfNotExists("idx_customstatuses_expires_at", "CustomStatuses", "ExpiresAt")
+}
+
+// Save sets the user's custom status, replacing any existing one.
+func (s *SqlCustomStatusStore) Save(customStatus *model.CustomStatus) (*model.CustomStatus, error) {
+	customStatus.PreSave()
+	if err := customStatus.IsValid(); err != nil {
+		return nil, err
+	}
+
+	transaction, err := s.GetMaster().Begin()
+	if err != nil {
+		return nil, errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransaction(transaction)
+
+	count, err := transaction.SelectInt("SELECT COUNT(*) FROM CustomStatuses WHERE UserId = :UserId", map[string]interface{}{"UserId": customStatus.UserId})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get CustomStatus with userId=%s", customStatus.UserId)
+	}
+
+	if count == 0 {
+		if err = transaction.Insert(customStatus); err != nil {
+			return nil, errors.Wrapf(err, "failed to save CustomStatus with userId=%s", customStatus.UserId)
+		}
+	} else if _, err = transaction.Update(customStatus); err != nil {
+		return nil, errors.Wrapf(err, "failed to update CustomStatus with userId=%s", customStatus.UserId)
+	}
+
+	if err = transaction.Commit(); err != nil {
+		return nil, errors.Wrap(err, "commit_transaction")
+	}
+
+	return customStatus, nil
+}
+
+func (s *SqlCustomStatusStore) Get(userId string) (*model.CustomStatus, error) {
+	var customStatus model.CustomStatus
+	if err := s.GetReplica().SelectOne(&customStatus, "SELECT * FROM CustomStatuses WHERE UserId = :UserId", map[string]interface{}{"UserId": userId}); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("CustomStatus", userId)
+		}
+		return nil, errors.Wrapf(err, "failed to get CustomStatus with userId=%s", userId)
+	}
+
+	return &customStatus, nil
+}
+
+func (s *SqlCustomStatusStore) GetByUserIds(userIds []string) ([]*model.CustomStatus, error) {
+	if len(userIds) == 0 {
+		return []*model.CustomStatus{}, nil
+	}
+
+	query, args, err := s.getQueryBuilder().
+		Select("*").
+		From("CustomStatuses").
+		Where(sq.Eq{"UserId": userIds}).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "custom_status_tosql")
+	}
+
+	var customStatuses []*model.CustomStatus
+	if _, err := s.GetReplica().Select(&customStatuses, query, args...); err != nil {
+		return nil, errors.Wrap(err, "failed to find CustomStatuses")
+	}
+
+	return customStatuses, nil
+}
+
+// GetExpired returns up to limit custom statuses that expired at or before
+// expiredAt, oldest first.
+func (s *SqlCustomStatusStore) GetExpired(expiredAt int64, limit int) ([]*model.CustomStatus, error) {
+	var customStatuses []*model.CustomStatus
+	if _, err := s.GetReplica().Select(&customStatuses, "SELECT * FROM CustomStatuses WHERE ExpiresAt > 0 AND ExpiresAt <= :ExpiredAt ORDER BY ExpiresAt LIMIT :Limit", map[string]interface{}{"ExpiredAt": expiredAt, "Limit": limit}); err != nil {
+		return nil, errors.Wrap(err, "failed to find expired CustomStatuses")
+	}
+
+	return customStatuses, nil
+}
+
+func (s *SqlCustomStatusStore) Delete(userId string) error {
+	result, err := s.GetMaster().Exec("DELETE FROM CustomStatuses WHERE UserId = :UserId", map[string]interface{}{"UserId": userId})
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete CustomStatus with userId=%s", userId)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "unable to get rows affected")
+	}
+
+	if count == 0 {
+		return store.NewErrNotFound("CustomStatus", userId)
+	}
+
+	return nil
+}
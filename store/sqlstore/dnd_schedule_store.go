@@ -0,0 +1,111 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	"github.com/pkg/errors"
+
+	"github.com/zacmm/zacmm-server/model"
+	"github.com/zacmm/zacmm-server/store"
+)
+
+type SqlDndScheduleStore struct {
+	*SqlSupplier
+}
+
+func newSqlDndScheduleStore(sqlSupplier *SqlSupplier) store.DndScheduleStore {
+	s := &SqlDndScheduleStore{
+		SqlSupplier: sqlSupplier,
+	}
+
+	for _, db := range sqlSupplier.GetAllConns() {
+		table := db.AddTableWithName(model.DndSchedule{}, "DndSchedules").SetKeys(false, "UserId")
+		table.ColMap("UserId").SetMaxSize(26)
+		table.ColMap("Days").SetMaxSize(128)
+		table.ColMap("StartTime").SetMaxSize(5)
+		table.ColMap("EndTime").SetMaxSize(5)
+		table.ColMap("ExemptChannelIds").SetMaxSize(2000)
+	}
+
+	return s
+}
+
+func (s *SqlDndScheduleStore) createIndexesIfNotExists() {
+}
+
+// Save sets the user's Do Not Disturb schedule, replacing any existing one.
+func (s *SqlDndScheduleStore) Save(schedule *model.DndSchedule) (*model.DndSchedule, error) {
+	schedule.PreSave()
+	if err := schedule.IsValid(); err != nil {
+		return nil, err
+	}
+
+	transaction, err := s.GetMaster().Begin()
+	if err != nil {
+		return nil, errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransaction(transaction)
+
+	count, err := transaction.SelectInt("SELECT COUNT(*) FROM DndSchedules WHERE UserId = :UserId", map[string]interface{}{"UserId": schedule.UserId})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get DndSchedule with userId=%s", schedule.UserId)
+	}
+
+	if count == 0 {
+		if err = transaction.Insert(schedule); err != nil {
+			return nil, errors.Wrapf(err, "failed to save DndSchedule with userId=%s", schedule.UserId)
+		}
+	} else if _, err = transaction.Update(schedule); err != nil {
+		return nil, errors.Wrapf(err, "failed to update DndSchedule with userId=%s", schedule.UserId)
+	}
+
+	if err = transaction.Commit(); err != nil {
+		return nil, errors.Wrap(err, "commit_transaction")
+	}
+
+	return schedule, nil
+}
+
+func (s *SqlDndScheduleStore) Get(userId string) (*model.DndSchedule, error) {
+	var schedule model.DndSchedule
+	if err := s.GetReplica().SelectOne(&schedule, "SELECT * FROM DndSchedules WHERE UserId = :UserId", map[string]interface{}{"UserId": userId}); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("DndSchedule", userId)
+		}
+		return nil, errors.Wrapf(err, "failed to get DndSchedule with userId=%s", userId)
+	}
+
+	return &schedule, nil
+}
+
+// GetAll returns up to limit schedules of users whose id sorts after
+// afterUserId, so that every schedule can be visited in pages.
+func (s *SqlDndScheduleStore) GetAll(afterUserId string, limit int) ([]*model.DndSchedule, error) {
+	var schedules []*model.DndSchedule
+	if _, err := s.GetReplica().Select(&schedules, "SELECT * FROM DndSchedules WHERE UserId > :AfterUserId ORDER BY UserId LIMIT :Limit", map[string]interface{}{"AfterUserId": afterUserId, "Limit": limit}); err != nil {
+		return nil, errors.Wrap(err, "failed to find DndSchedules")
+	}
+
+	return schedules, nil
+}
+
+func (s *SqlDndScheduleStore) Delete(userId string) error {
+	result, err := s.GetMaster().Exec("DELETE FROM DndSchedules WHERE UserId = :UserId", map[string]interface{}{"UserId": userId})
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete DndSchedule with userId=%s", userId)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "unable to get rows affected")
+	}
+
+	if count == 0 {
+		return store.NewErrNotFound("DndSchedule", userId)
+	}
+
+	return nil
+}
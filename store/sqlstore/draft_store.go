@@ -0,0 +1,143 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	"github.com/pkg/errors"
+
+	"github.com/zacmm/zacmm-server/model"
+	"github.com/zacmm/zacmm-server/store"
+)
+
+type SqlDraftStore struct {
+	*SqlSupplier
+}
+
+func newSqlDraftStore(sqlSupplier *SqlSupplier) store.DraftStore {
+	s := &SqlDraftStore{
+		SqlSupplier: sqlSupplier,
+	}
+
+	for _, db := range sqlSupplier.GetAllConns() {
+		table := db.AddTableWithName(model.Draft{}, "Drafts").SetKeys(false, "UserId", "ChannelId", "RootId")
+		table.ColMap("UserId").SetMaxSize(26)
+		table.ColMap("ChannelId").SetMaxSize(26)
+		table.ColMap("RootId").SetMaxSize(26)
+		table.ColMap("Message").SetMaxSize(model.POST_MESSAGE_MAX_BYTES_V2)
+		table.ColMap("Props").SetMaxSize(8000)
+		table.ColMap("FileIds").SetMaxSize(150)
+	}
+
+	return s
+}
+
+func (s *SqlDraftStore) createIndexesIfNotExists() {
+	s.CreateIndexIfNotExists("idx_drafts_channel_id", "Drafts", "ChannelId")
+}
+
+// Save creates the draft, or replaces the user's existing draft for the same
+// channel and thread. It is a single upsert so that concurrent saves of the
+// same draft, which clients send on every keystroke, cannot conflict.
+func (s *SqlDraftStore) Save(draft *model.Draft) (*model.Draft, error) {
+	draft.PreSave()
+	if err := draft.IsValid(model.POST_MESSAGE_MAX_RUNES_V2); err != nil {
+		return nil, err
+	}
+
+	var query string
+	if s.DriverName() == model.DATABASE_DRIVER_POSTGRES {
+		query = `INSERT INTO Drafts
+				(CreateAt, UpdateAt, UserId, ChannelId, RootId, Message, Props, FileIds)
+			VALUES
+				(:CreateAt, :UpdateAt, :UserId, :ChannelId, :RootId, :Message, :Props, :FileIds)
+			ON CONFLICT (UserId, ChannelId, RootId) DO UPDATE SET
+				UpdateAt = :UpdateAt,
+				Message = :Message,
+				Props = :Props,
+				FileIds = :FileIds`
+	} else {
+		query = `INSERT INTO Drafts
+				(CreateAt, UpdateAt, UserId, ChannelId, RootId, Message, Props, FileIds)
+			VALUES
+				(:CreateAt, :UpdateAt, :UserId, :ChannelId, :RootId, :Message, :Props, :FileIds)
+			ON DUPLICATE KEY UPDATE
+				UpdateAt = :UpdateAt,
+				Message = :Message,
+				Props = :Props,
+				FileIds = :FileIds`
+	}
+
+	if _, err := s.GetMaster().Exec(query, map[string]interface{}{
+		"CreateAt":  draft.CreateAt,
+		"UpdateAt":  draft.UpdateAt,
+		"UserId":    draft.UserId,
+		"ChannelId": draft.ChannelId,
+		"RootId":    draft.RootId,
+		"Message":   draft.Message,
+		"Props":     model.StringInterfaceToJson(draft.Props),
+		"FileIds":   model.ArrayToJson(draft.FileIds),
+	}); err != nil {
+		return nil, errors.Wrapf(err, "failed to save Draft with channelId=%s and rootId=%s", draft.ChannelId, draft.RootId)
+	}
+
+	// An existing draft keeps its original creation time.
+	createAt, err := s.GetMaster().SelectInt("SELECT CreateAt FROM Drafts WHERE UserId = :UserId AND ChannelId = :ChannelId AND RootId = :RootId", map[string]interface{}{"UserId": draft.UserId, "ChannelId": draft.ChannelId, "RootId": draft.RootId})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get Draft with channelId=%s and rootId=%s", draft.ChannelId, draft.RootId)
+	}
+	draft.CreateAt = createAt
+
+	return draft, nil
+}
+
+func (s *SqlDraftStore) Get(userId, channelId, rootId string) (*model.Draft, error) {
+	var draft model.Draft
+	if err := s.GetReplica().SelectOne(&draft, "SELECT * FROM Drafts WHERE UserId = :UserId AND ChannelId = :ChannelId AND RootId = :RootId", map[string]interface{}{"UserId": userId, "ChannelId": channelId, "RootId": rootId}); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("Draft", channelId)
+		}
+		return nil, errors.Wrapf(err, "failed to get Draft with channelId=%s and rootId=%s", channelId, rootId)
+	}
+
+	return &draft, nil
+}
+
+func (s *SqlDraftStore) Delete(userId, channelId, rootId string) error {
+	result, err := s.GetMaster().Exec("DELETE FROM Drafts WHERE UserId = :UserId AND ChannelId = :ChannelId AND RootId = :RootId", map[string]interface{}{"UserId": userId, "ChannelId": channelId, "RootId": rootId})
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete Draft with channelId=%s and rootId=%s", channelId, rootId)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "unable to get rows affected")
+	}
+
+	if count == 0 {
+		return store.NewErrNotFound("Draft", channelId)
+	}
+
+	return nil
+}
+
+// GetDraftsForUser returns the user's drafts in the channels of the given team,
+// as well as in their direct and group message channels, most recent first.
+func (s *SqlDraftStore) GetDraftsForUser(userId, teamId string) ([]*model.Draft, error) {
+	var drafts []*model.Draft
+	query := `SELECT Drafts.*
+		FROM Drafts
+		INNER JOIN Channels ON Channels.Id = Drafts.ChannelId
+		WHERE Drafts.UserId = :UserId
+			AND (Channels.TeamId = :TeamId OR Channels.TeamId = '')
+			AND Channels.DeleteAt = 0
+		ORDER BY Drafts.UpdateAt DESC`
+
+	if _, err := s.GetReplica().Select(&drafts, query, map[string]interface{}{"UserId": userId, "TeamId": teamId}); err != nil {
+		return nil, errors.Wrapf(err, "failed to find Drafts with userId=%s", userId)
+	}
+
+	return drafts, nil
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/zacmm/zacmm-server/model"
+	"github.com/zacmm/zacmm-server/store"
+)
+
+type SqlEmailDigestStore struct {
+	*SqlSupplier
+}
+
+func newSqlEmailDigestStore(sqlSupplier *SqlSupplier) store.EmailDigestStore {
+	s := &SqlEmailDigestStore{
+		SqlSupplier: sqlSupplier,
+	}
+
+	for _, db := range sqlSupplier.GetAllConns() {
+		table := db.AddTableWithName(model.PendingEmailNotification{}, "PendingEmailNotifications").SetKeys(false, "Id")
+		table.ColMap("Id").SetMaxSize(26)
+		table.ColMap("UserId").SetMaxSize(26)
+		table.ColMap("PostId").SetMaxSize(26)
+		table.ColMap("ChannelId").SetMaxSize(26)
+		table.ColMap("TeamId").SetMaxSize(26)
+	}
+
+	return s
+}
+
+func (s *SqlEmailDigestStore) createIndexesIfNotExists() {
+	s.CreateCompositeIndexIfNotExists("idx_pendingemailnotifications_user_id_create_at", "PendingEmailNotifications", []string{"UserId", "CreateAt"})
+}
+
+func (s *SqlEmailDigestStore) Save(notification *model.PendingEmailNotification) (*model.PendingEmailNotification, error) {
+	if notification.Id != "" {
+		return nil, store.NewErrInvalidInput("PendingEmailNotification", "id", notification.Id)
+	}
+
+	notification.PreSave()
+	if err := notification.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if err := s.GetMaster().Insert(notification); err != nil {
+		return nil, errors.Wrapf(err, "failed to save PendingEmailNotification with id=%s", notification.Id)
+	}
+
+	return notification, nil
+}
+
+// GetForUser returns the pending notifications of a user, oldest first.
+func (s *SqlEmailDigestStore) GetForUser(userId string) ([]*model.PendingEmailNotification, error) {
+	var notifications []*model.PendingEmailNotification
+	if _, err := s.GetMaster().Select(&notifications, "SELECT * FROM PendingEmailNotifications WHERE UserId = :UserId ORDER BY CreateAt, Id", map[string]interface{}{"UserId": userId}); err != nil {
+		return nil, errors.Wrapf(err, "failed to find PendingEmailNotifications with userId=%s", userId)
+	}
+
+	return notifications, nil
+}
+
+// GetUserIdsWithPending returns, in order, the ids of the users that have
+// pending notifications and whose id comes after afterUserId.
+func (s *SqlEmailDigestStore) GetUserIdsWithPending(afterUserId string, limit int) ([]string, error) {
+	query, args, err := s.getQueryBuilder().
+		Select("DISTINCT UserId").
+		From("PendingEmailNotifications").
+		Where(sq.Gt{"UserId": afterUserId}).
+		OrderBy("UserId").
+		Limit(uint64(limit)).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "pending_email_notifications_tosql")
+	}
+
+	var userIds []string
+	if _, err := s.GetMaster().Select(&userIds, query, args...); err != nil {
+		return nil, errors.Wrap(err, "failed to find users with PendingEmailNotifications")
+	}
+
+	return userIds, nil
+}
+
+func (s *SqlEmailDigestStore) Delete(ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	query, args, err := s.getQueryBuilder().
+		Delete("PendingEmailNotifications").
+		Where(sq.Eq{"Id": ids}).
+		ToSql()
+	if err != nil {
+		return errors.Wrap(err, "pending_email_notifications_tosql")
+	}
+
+	if _, err := s.GetMaster().Exec(query, args...); err != nil {
+		return errors.Wrap(err, "failed to delete PendingEmailNotifications")
+	}
+
+	return nil
+}
+
+func (s *SqlEmailDigestStore) DeleteForUser(userId string) error {
+	if _, err := s.GetMaster().Exec("DELETE FROM PendingEmailNotifications WHERE UserId = :UserId", map[string]interface{}{"UserId": userId}); err != nil {
+		return errors.Wrapf(err, "failed to delete PendingEmailNotifications with userId=%s", userId)
+	}
+
+	return nil
+}
@@ -0,0 +1,160 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/zacmm/zacmm-server/model"
+	"github.com/zacmm/zacmm-server/store"
+)
+
+type SqlEmailQueueStore struct {
+	*SqlSupplier
+}
+
+func newSqlEmailQueueStore(sqlSupplier *SqlSupplier) store.EmailQueueStore {
+	s := &SqlEmailQueueStore{
+		SqlSupplier: sqlSupplier,
+	}
+
+	for _, db := range sqlSupplier.GetAllConns() {
+		table := db.AddTableWithName(model.QueuedEmail{}, "EmailQueue").SetKeys(false, "Id")
+		table.ColMap("Id").SetMaxSize(26)
+		table.ColMap("Recipient").SetMaxSize(model.QUEUED_EMAIL_ADDRESS_MAX_LENGTH)
+		table.ColMap("Cc").SetMaxSize(model.QUEUED_EMAIL_ADDRESS_MAX_LENGTH)
+		table.ColMap("ReplyTo").SetMaxSize(model.QUEUED_EMAIL_ADDRESS_MAX_LENGTH)
+		table.ColMap("Subject").SetMaxSize(model.QUEUED_EMAIL_SUBJECT_MAX_LENGTH)
+		table.ColMap("Status").SetMaxSize(32)
+		table.ColMap("LastError").SetMaxSize(model.QUEUED_EMAIL_ERROR_MAX_LENGTH)
+	}
+
+	return s
+}
+
+func (s *SqlEmailQueueStore) createIndexesIfNotExists() {
+	s.CreateCompositeIndexIfNotExists("idx_emailqueue_status_next_attempt_at", "EmailQueue", []string{"Status", "NextAttemptAt"})
+	s.CreateIndexIfNotExists("idx_emailqueue_create_at", "EmailQueue", "CreateAt")
+}
+
+func (s *SqlEmailQueueStore) Save(email *model.QueuedEmail) (*model.QueuedEmail, error) {
+	if email.Id != "" {
+		return nil, store.NewErrInvalidInput("QueuedEmail", "id", email.Id)
+	}
+
+	email.PreSave()
+	if err := email.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if err := s.GetMaster().Insert(email); err != nil {
+		return nil, errors.Wrapf(err, "failed to save QueuedEmail with id=%s", email.Id)
+	}
+
+	return email, nil
+}
+
+func (s *SqlEmailQueueStore) Update(email *model.QueuedEmail) (*model.QueuedEmail, error) {
+	email.PreUpdate()
+	if err := email.IsValid(); err != nil {
+		return nil, err
+	}
+
+	count, err := s.GetMaster().Update(email)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to update QueuedEmail with id=%s", email.Id)
+	}
+	if count == 0 {
+		return nil, store.NewErrNotFound("QueuedEmail", email.Id)
+	}
+
+	return email, nil
+}
+
+func (s *SqlEmailQueueStore) Get(id string) (*model.QueuedEmail, error) {
+	var email model.QueuedEmail
+	if err := s.GetMaster().SelectOne(&email, "SELECT * FROM EmailQueue WHERE Id = :Id", map[string]interface{}{"Id": id}); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("QueuedEmail", id)
+		}
+		return nil, errors.Wrapf(err, "failed to get QueuedEmail with id=%s", id)
+	}
+
+	return &email, nil
+}
+
+// GetReadyToSend returns the pending emails whose next attempt is due, oldest
+// first.
+func (s *SqlEmailQueueStore) GetReadyToSend(now int64, limit int) ([]*model.QueuedEmail, error) {
+	query, args, err := s.getQueryBuilder().
+		Select("*").
+		From("EmailQueue").
+		Where(sq.Eq{"Status": model.QUEUED_EMAIL_STATUS_PENDING}).
+		Where(sq.LtOrEq{"NextAttemptAt": now}).
+		OrderBy("NextAttemptAt", "CreateAt").
+		Limit(uint64(limit)).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "email_queue_tosql")
+	}
+
+	var emails []*model.QueuedEmail
+	if _, err := s.GetMaster().Select(&emails, query, args...); err != nil {
+		return nil, errors.Wrap(err, "failed to find QueuedEmails ready to send")
+	}
+
+	return emails, nil
+}
+
+// GetAll returns the queued emails with the given status, or with any status
+// if it is empty, newest first.
+func (s *SqlEmailQueueStore) GetAll(status string, offset, limit int) ([]*model.QueuedEmail, error) {
+	builder := s.getQueryBuilder().
+		Select("*").
+		From("EmailQueue").
+		OrderBy("CreateAt DESC", "Id").
+		Limit(uint64(limit)).
+		Offset(uint64(offset))
+	if status != "" {
+		builder = builder.Where(sq.Eq{"Status": status})
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "email_queue_tosql")
+	}
+
+	var emails []*model.QueuedEmail
+	if _, err := s.GetReplica().Select(&emails, query, args...); err != nil {
+		return nil, errors.Wrap(err, "failed to find QueuedEmails")
+	}
+
+	return emails, nil
+}
+
+// PermanentDeleteSentBefore deletes up to limit emails that were successfully
+// sent before the given time, returning how many were deleted.
+func (s *SqlEmailQueueStore) PermanentDeleteSentBefore(before int64, limit int64) (int64, error) {
+	var query string
+	if s.DriverName() == model.DATABASE_DRIVER_POSTGRES {
+		query = "DELETE FROM EmailQueue WHERE Id = any (array (SELECT Id FROM EmailQueue WHERE Status = :Status AND SentAt < :Before LIMIT :Limit))"
+	} else {
+		query = "DELETE FROM EmailQueue WHERE Status = :Status AND SentAt < :Before LIMIT :Limit"
+	}
+
+	result, err := s.GetMaster().Exec(query, map[string]interface{}{"Status": model.QUEUED_EMAIL_STATUS_SENT, "Before": before, "Limit": limit})
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to delete sent QueuedEmails")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "unable to get rows affected")
+	}
+
+	return rowsAffected, nil
+}
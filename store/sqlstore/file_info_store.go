@@ -6,11 +6,15 @@ package sqlstore
 import (
 	"database/sql"
 	"fmt"
+	"regexp"
+	"strings"
 
 	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/gorp"
 	"github.com/pkg/errors"
 
 	"github.com/zacmm/zacmm-server/einterfaces"
+	"github.com/zacmm/zacmm-server/mlog"
 	"github.com/zacmm/zacmm-server/model"
 	"github.com/zacmm/zacmm-server/store"
 )
@@ -49,6 +53,9 @@ func newSqlFileInfoStore(sqlSupplier *SqlSupplier, metrics einterfaces.MetricsIn
 		"FileInfo.HasPreviewImage",
 		"FileInfo.MiniPreview",
 		"Coalesce(FileInfo.Content, '') AS Content",
+		"FileInfo.ContentHash",
+		"FileInfo.TeamId",
+		"FileInfo.StrippedMetadata",
 	}
 
 	for _, db := range sqlSupplier.GetAllConns() {
@@ -56,13 +63,16 @@ func newSqlFileInfoStore(sqlSupplier *SqlSupplier, metrics einterfaces.MetricsIn
 		table.ColMap("Id").SetMaxSize(26)
 		table.ColMap("CreatorId").SetMaxSize(26)
 		table.ColMap("PostId").SetMaxSize(26)
+		table.ColMap("TeamId").SetMaxSize(26)
 		table.ColMap("Path").SetMaxSize(512)
 		table.ColMap("ThumbnailPath").SetMaxSize(512)
 		table.ColMap("PreviewPath").SetMaxSize(512)
 		table.ColMap("Name").SetMaxSize(256)
 		table.ColMap("Content").SetMaxSize(0)
+		table.ColMap("ContentHash").SetMaxSize(64)
 		table.ColMap("Extension").SetMaxSize(64)
 		table.ColMap("MimeType").SetMaxSize(256)
+		table.ColMap("StrippedMetadata").SetMaxSize(128)
 	}
 
 	return s
@@ -73,6 +83,9 @@ func (fs SqlFileInfoStore) createIndexesIfNotExists() {
 	fs.CreateIndexIfNotExists("idx_fileinfo_create_at", "FileInfo", "CreateAt")
 	fs.CreateIndexIfNotExists("idx_fileinfo_delete_at", "FileInfo", "DeleteAt")
 	fs.CreateIndexIfNotExists("idx_fileinfo_postid_at", "FileInfo", "PostId")
+	fs.CreateIndexIfNotExists("idx_fileinfo_content_hash", "FileInfo", "ContentHash")
+	fs.CreateFullTextIndexIfNotExists("idx_fileinfo_name_txt", "FileInfo", "Name")
+	fs.CreateFullTextIndexIfNotExists("idx_fileinfo_content_txt", "FileInfo", "Content")
 }
 
 func (fs SqlFileInfoStore) Save(info *model.FileInfo) (*model.FileInfo, error) {
@@ -81,9 +94,24 @@ func (fs SqlFileInfoStore) Save(info *model.FileInfo) (*model.FileInfo, error) {
 		return nil, err
 	}
 
-	if err := fs.GetMaster().Insert(info); err != nil {
+	transaction, err := fs.GetMaster().Begin()
+	if err != nil {
+		return nil, errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransaction(transaction)
+
+	if err = transaction.Insert(info); err != nil {
 		return nil, errors.Wrap(err, "failed to save FileInfo")
 	}
+	if info.DeleteAt == 0 {
+		if err = updateStorageUsage(transaction, fs.DriverName(), []*model.FileInfo{info}, 1); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = transaction.Commit(); err != nil {
+		return nil, errors.Wrap(err, "commit_transaction")
+	}
 	return info, nil
 }
 
@@ -93,14 +121,29 @@ func (fs SqlFileInfoStore) Upsert(info *model.FileInfo) (*model.FileInfo, error)
 		return nil, err
 	}
 
-	n, err := fs.GetMaster().Update(info)
+	transaction, err := fs.GetMaster().Begin()
+	if err != nil {
+		return nil, errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransaction(transaction)
+
+	n, err := transaction.Update(info)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to update FileInfo")
 	}
 	if n == 0 {
-		if err = fs.GetMaster().Insert(info); err != nil {
+		if err = transaction.Insert(info); err != nil {
 			return nil, errors.Wrap(err, "failed to save FileInfo")
 		}
+		if info.DeleteAt == 0 {
+			if err = updateStorageUsage(transaction, fs.DriverName(), []*model.FileInfo{info}, 1); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err = transaction.Commit(); err != nil {
+		return nil, errors.Wrap(err, "commit_transaction")
 	}
 	return info, nil
 }
@@ -333,8 +376,350 @@ func (fs SqlFileInfoStore) SetContent(fileId, content string) error {
 	return nil
 }
 
+// SetContentHash points every file stored at path to the deduplicated copy
+// of its content stored at blobPath.
+func (fs SqlFileInfoStore) SetContentHash(path, contentHash, blobPath string) error {
+	query := fs.getQueryBuilder().
+		Update("FileInfo").
+		Set("Path", blobPath).
+		Set("ContentHash", contentHash).
+		Where(sq.Eq{"Path": path})
+
+	queryString, args, err := query.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "file_info_tosql")
+	}
+
+	sqlResult, err := fs.GetMaster().Exec(queryString, args...)
+	if err != nil {
+		return errors.Wrapf(err, "failed to update FileInfo content hash with path=%s", path)
+	}
+
+	count, err := sqlResult.RowsAffected()
+	if err != nil {
+		// RowsAffected should never fail with the MySQL or Postgres drivers
+		return errors.Wrap(err, "unable to retrieve rows affected")
+	} else if count == 0 {
+		return store.NewErrNotFound("FileInfo", fmt.Sprintf("path=%s", path))
+	}
+	return nil
+}
+
+// Quarantine deletes every file stored at path and points them to the
+// quarantined copy stored at quarantinePath.
+func (fs SqlFileInfoStore) Quarantine(path, quarantinePath string) error {
+	query := fs.getQueryBuilder().
+		Update("FileInfo").
+		Set("Path", quarantinePath).
+		Set("ContentHash", "").
+		Set("DeleteAt", model.GetMillis()).
+		Where(sq.Eq{"Path": path})
+
+	queryString, args, err := query.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "file_info_tosql")
+	}
+
+	transaction, err := fs.GetMaster().Begin()
+	if err != nil {
+		return errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransaction(transaction)
+
+	files, err := fs.getFilesForStorageUsage(transaction, sq.Eq{"Path": path, "DeleteAt": 0})
+	if err != nil {
+		return err
+	}
+	if _, err = transaction.Exec(queryString, args...); err != nil {
+		return errors.Wrapf(err, "failed to quarantine FileInfo with path=%s", path)
+	}
+	if err = updateStorageUsage(transaction, fs.DriverName(), files, -1); err != nil {
+		return err
+	}
+
+	if err = transaction.Commit(); err != nil {
+		return errors.Wrap(err, "commit_transaction")
+	}
+	return nil
+}
+
+// CountByContentHash returns the number of files, deleted or not, that share
+// the deduplicated content with the given hash.
+func (fs SqlFileInfoStore) CountByContentHash(contentHash string) (int64, error) {
+	query := fs.getQueryBuilder().
+		Select("COUNT(*)").
+		From("FileInfo").
+		Where(sq.Eq{"ContentHash": contentHash})
+
+	queryString, args, err := query.ToSql()
+	if err != nil {
+		return 0, errors.Wrap(err, "file_info_tosql")
+	}
+
+	count, err := fs.GetMaster().SelectInt(queryString, args...)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to count FileInfos with contentHash=%s", contentHash)
+	}
+	return count, nil
+}
+
+// GetFilesBatchForIndexing returns up to limit files created in the given time
+// range, oldest first, along with the channel of the post each is attached to.
+// Files not attached to a post have an empty ChannelId.
+// GetPostIdsForExtensions returns the ids of the posts with an attachment of
+// any of the extensions, the most recently attached first.
+func (fs SqlFileInfoStore) GetPostIdsForExtensions(extensions []string, limit int) ([]string, error) {
+	if len(extensions) == 0 {
+		return []string{}, nil
+	}
+
+	query := fs.getQueryBuilder().
+		Select("PostId").
+		From("FileInfo").
+		Where(sq.Eq{"Extension": extensions}).
+		Where(sq.NotEq{"PostId": ""}).
+		Where(sq.Eq{"DeleteAt": 0}).
+		GroupBy("PostId").
+		OrderBy("MAX(CreateAt) DESC").
+		Limit(uint64(limit))
+
+	queryString, args, err := query.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "file_info_tosql")
+	}
+
+	var postIds []string
+	if _, err := fs.GetReplica().Select(&postIds, queryString, args...); err != nil {
+		return nil, errors.Wrap(err, "failed to get the PostIds of FileInfos")
+	}
+	return postIds, nil
+}
+
+func (fs SqlFileInfoStore) GetFilesBatchForIndexing(startTime, endTime int64, limit int) ([]*model.FileForIndexing, error) {
+	query := fs.getQueryBuilder().
+		Select(append(fs.queryFields, "COALESCE(Posts.ChannelId, '') AS ChannelId")...).
+		From("FileInfo").
+		LeftJoin("Posts ON FileInfo.PostId = Posts.Id").
+		Where(sq.GtOrEq{"FileInfo.CreateAt": startTime}).
+		Where(sq.Lt{"FileInfo.CreateAt": endTime}).
+		OrderBy("FileInfo.CreateAt").
+		Limit(uint64(limit))
+
+	queryString, args, err := query.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "file_info_tosql")
+	}
+
+	var files []*model.FileForIndexing
+	if _, err := fs.GetSearchReplica().Select(&files, queryString, args...); err != nil {
+		return nil, errors.Wrap(err, "failed to find FileInfos")
+	}
+
+	return files, nil
+}
+
+func (fs SqlFileInfoStore) Search(paramsList []*model.SearchParams, userId, teamId string, page, perPage int) (*model.FileInfoList, error) {
+	// Since we don't support paging for DB search, we just return nothing for later pages
+	if page > 0 {
+		return model.NewFileInfoList(), nil
+	}
+
+	if err := model.IsSearchParamsListValid(paramsList); err != nil {
+		return nil, err
+	}
+
+	list := model.NewFileInfoList()
+	for _, params := range paramsList {
+		// remove any unquoted term that contains only non-alphanumeric chars
+		// ex: abcd "**" && abc     >>     abcd "**" abc
+		params.Terms = removeNonAlphaNumericUnquotedTerms(params.Terms, " ")
+
+		fileInfos, err := fs.search(teamId, userId, params)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, fileInfo := range fileInfos {
+			if _, ok := list.FileInfos[fileInfo.Id]; ok {
+				continue
+			}
+			list.AddFileInfo(fileInfo)
+			list.AddOrder(fileInfo.Id)
+		}
+	}
+
+	list.SortByCreateAt()
+
+	return list, nil
+}
+
+// search finds the files attached to posts in the channels the user can see
+// whose name or extracted content match the search terms.
+func (fs SqlFileInfoStore) search(teamId, userId string, params *model.SearchParams) ([]*model.FileInfo, error) {
+	if params.Terms == "" && params.ExcludedTerms == "" &&
+		len(params.InChannels) == 0 && len(params.ExcludedChannels) == 0 &&
+		len(params.FromUsers) == 0 && len(params.ExcludedUsers) == 0 &&
+		len(params.Extensions) == 0 && len(params.ExcludedExtensions) == 0 &&
+		len(params.OnDate) == 0 && len(params.AfterDate) == 0 && len(params.BeforeDate) == 0 {
+		return []*model.FileInfo{}, nil
+	}
+
+	// The subquery uses the default placeholders, the outer query converts them.
+	channelQuery := sq.Select("Channels.Id").
+		From("Channels").
+		Join("ChannelMembers ON Channels.Id = ChannelMembers.ChannelId").
+		Where(sq.Or{sq.Eq{"Channels.TeamId": teamId}, sq.Eq{"Channels.TeamId": ""}})
+	if !params.SearchWithoutUserId {
+		channelQuery = channelQuery.Where(sq.Eq{"ChannelMembers.UserId": userId})
+	}
+	if !params.IncludeDeletedChannels {
+		channelQuery = channelQuery.Where(sq.Eq{"Channels.DeleteAt": 0})
+	}
+	if len(params.InChannels) > 0 {
+		channelQuery = channelQuery.Where(sq.Eq{"Channels.Id": params.InChannels})
+	}
+	if len(params.ExcludedChannels) > 0 {
+		channelQuery = channelQuery.Where(sq.NotEq{"Channels.Id": params.ExcludedChannels})
+	}
+
+	query := fs.getQueryBuilder().
+		Select(fs.queryFields...).
+		From("FileInfo").
+		Join("Posts ON FileInfo.PostId = Posts.Id").
+		Where(sq.Eq{"FileInfo.DeleteAt": 0, "Posts.DeleteAt": 0}).
+		Where(sq.Expr("Posts.ChannelId IN (?)", channelQuery)).
+		OrderBy("FileInfo.CreateAt DESC").
+		Limit(100)
+
+	if len(params.FromUsers) > 0 {
+		query = query.Where(sq.Eq{"FileInfo.CreatorId": params.FromUsers})
+	}
+	if len(params.ExcludedUsers) > 0 {
+		query = query.Where(sq.NotEq{"FileInfo.CreatorId": params.ExcludedUsers})
+	}
+	if len(params.Extensions) > 0 {
+		query = query.Where(sq.Eq{"FileInfo.Extension": params.Extensions})
+	}
+	if len(params.ExcludedExtensions) > 0 {
+		query = query.Where(sq.NotEq{"FileInfo.Extension": params.ExcludedExtensions})
+	}
+
+	if params.OnDate != "" {
+		onDateStart, onDateEnd := params.GetOnDateMillis()
+		query = query.Where(sq.Expr("FileInfo.CreateAt BETWEEN ? AND ?", onDateStart, onDateEnd))
+	} else {
+		if params.AfterDate != "" {
+			query = query.Where(sq.GtOrEq{"FileInfo.CreateAt": params.GetAfterDateMillis()})
+		}
+		if params.BeforeDate != "" {
+			query = query.Where(sq.LtOrEq{"FileInfo.CreateAt": params.GetBeforeDateMillis()})
+		}
+		if params.ExcludedAfterDate != "" {
+			query = query.Where(sq.Lt{"FileInfo.CreateAt": params.GetExcludedAfterDateMillis()})
+		}
+		if params.ExcludedBeforeDate != "" {
+			query = query.Where(sq.Gt{"FileInfo.CreateAt": params.GetExcludedBeforeDateMillis()})
+		}
+		if params.ExcludedDate != "" {
+			excludedDateStart, excludedDateEnd := params.GetExcludedDateMillis()
+			query = query.Where(sq.Expr("FileInfo.CreateAt NOT BETWEEN ? AND ?", excludedDateStart, excludedDateEnd))
+		}
+	}
+
+	terms := params.Terms
+	excludedTerms := params.ExcludedTerms
+
+	// these chars have special meaning and can be treated as spaces
+	for _, c := range specialSearchChar {
+		terms = strings.Replace(terms, c, " ", -1)
+		excludedTerms = strings.Replace(excludedTerms, c, " ", -1)
+	}
+
+	if terms == "" && excludedTerms == "" {
+		// we've already confirmed that we have a channel, user or extension to search for
+	} else if fs.DriverName() == model.DATABASE_DRIVER_POSTGRES {
+		// Parse text for wildcards
+		if wildcard, err := regexp.Compile(`\*($| )`); err == nil {
+			terms = wildcard.ReplaceAllLiteralString(terms, ":* ")
+			excludedTerms = wildcard.ReplaceAllLiteralString(excludedTerms, ":* ")
+		}
+
+		excludeClause := ""
+		if excludedTerms != "" {
+			excludeClause = " & !(" + strings.Join(strings.Fields(excludedTerms), " | ") + ")"
+		}
+
+		var queryTerms string
+		if params.OrTerms {
+			queryTerms = "(" + strings.Join(strings.Fields(terms), " | ") + ")" + excludeClause
+		} else {
+			queryTerms = "(" + strings.Join(strings.Fields(terms), " & ") + ")" + excludeClause
+		}
+
+		query = query.Where(sq.Or{
+			sq.Expr("to_tsvector('english', FileInfo.Name) @@  to_tsquery('english', ?)", queryTerms),
+			sq.Expr("to_tsvector('english', FileInfo.Content) @@  to_tsquery('english', ?)", queryTerms),
+		})
+	} else if fs.DriverName() == model.DATABASE_DRIVER_MYSQL {
+		var err error
+		terms, err = removeMysqlStopWordsFromTerms(terms)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to remove Mysql stop-words from terms")
+		}
+
+		if terms == "" {
+			return []*model.FileInfo{}, nil
+		}
+
+		excludeClause := ""
+		if excludedTerms != "" {
+			excludeClause = " -(" + excludedTerms + ")"
+		}
+
+		var queryTerms string
+		if params.OrTerms {
+			queryTerms = terms + excludeClause
+		} else {
+			splitTerms := []string{}
+			for _, t := range strings.Fields(terms) {
+				splitTerms = append(splitTerms, "+"+t)
+			}
+			queryTerms = strings.Join(splitTerms, " ") + excludeClause
+		}
+
+		query = query.Where(sq.Or{
+			sq.Expr("MATCH (FileInfo.Name) AGAINST (? IN BOOLEAN MODE)", queryTerms),
+			sq.Expr("MATCH (FileInfo.Content) AGAINST (? IN BOOLEAN MODE)", queryTerms),
+		})
+	}
+
+	queryString, args, err := query.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "file_info_tosql")
+	}
+
+	var fileInfos []*model.FileInfo
+	if _, err := fs.GetSearchReplica().Select(&fileInfos, queryString, args...); err != nil {
+		mlog.Warn("Query error searching files.", mlog.Err(err))
+		// Don't return the error to the caller as it is of no use to the user. Instead return an empty set of search results.
+		return []*model.FileInfo{}, nil
+	}
+
+	return fileInfos, nil
+}
+
 func (fs SqlFileInfoStore) DeleteForPost(postId string) (string, error) {
-	if _, err := fs.GetMaster().Exec(
+	transaction, err := fs.GetMaster().Begin()
+	if err != nil {
+		return "", errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransaction(transaction)
+
+	files, err := fs.getFilesForStorageUsage(transaction, sq.Eq{"PostId": postId, "DeleteAt": 0})
+	if err != nil {
+		return "", err
+	}
+	if _, err = transaction.Exec(
 		`UPDATE
 				FileInfo
 			SET
@@ -343,29 +728,73 @@ func (fs SqlFileInfoStore) DeleteForPost(postId string) (string, error) {
 				PostId = :PostId`, map[string]interface{}{"DeleteAt": model.GetMillis(), "PostId": postId}); err != nil {
 		return "", errors.Wrapf(err, "failed to update FileInfo with postId=%s", postId)
 	}
+	if err = updateStorageUsage(transaction, fs.DriverName(), files, -1); err != nil {
+		return "", err
+	}
+
+	if err = transaction.Commit(); err != nil {
+		return "", errors.Wrap(err, "commit_transaction")
+	}
 	return postId, nil
 }
 
 func (fs SqlFileInfoStore) PermanentDelete(fileId string) error {
-	if _, err := fs.GetMaster().Exec(
+	transaction, err := fs.GetMaster().Begin()
+	if err != nil {
+		return errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransaction(transaction)
+
+	files, err := fs.getFilesForStorageUsage(transaction, sq.Eq{"Id": fileId, "DeleteAt": 0})
+	if err != nil {
+		return err
+	}
+	if _, err = transaction.Exec(
 		`DELETE FROM
 				FileInfo
 			WHERE
 				Id = :FileId`, map[string]interface{}{"FileId": fileId}); err != nil {
 		return errors.Wrapf(err, "failed to delete FileInfo with id=%s", fileId)
 	}
+	if err = updateStorageUsage(transaction, fs.DriverName(), files, -1); err != nil {
+		return err
+	}
+
+	if err = transaction.Commit(); err != nil {
+		return errors.Wrap(err, "commit_transaction")
+	}
 	return nil
 }
 
 func (fs SqlFileInfoStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
-	var query string
-	if fs.DriverName() == "postgres" {
-		query = "DELETE from FileInfo WHERE Id = any (array (SELECT Id FROM FileInfo WHERE CreateAt < :EndTime LIMIT :Limit))"
-	} else {
-		query = "DELETE from FileInfo WHERE CreateAt < :EndTime LIMIT :Limit"
+	transaction, err := fs.GetMaster().Begin()
+	if err != nil {
+		return 0, errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransaction(transaction)
+
+	var files []*model.FileInfo
+	if _, err = transaction.Select(&files, "SELECT Id, CreatorId, TeamId, Size, DeleteAt FROM FileInfo WHERE CreateAt < :EndTime LIMIT :Limit", map[string]interface{}{"EndTime": endTime, "Limit": limit}); err != nil {
+		return 0, errors.Wrap(err, "failed to find FileInfos to delete in batch")
+	}
+	if len(files) == 0 {
+		return 0, nil
+	}
+
+	ids := make([]string, 0, len(files))
+	var notDeleted []*model.FileInfo
+	for _, file := range files {
+		ids = append(ids, file.Id)
+		if file.DeleteAt == 0 {
+			notDeleted = append(notDeleted, file)
+		}
 	}
 
-	sqlResult, err := fs.GetMaster().Exec(query, map[string]interface{}{"EndTime": endTime, "Limit": limit})
+	query, args, err := fs.getQueryBuilder().Delete("FileInfo").Where(sq.Eq{"Id": ids}).ToSql()
+	if err != nil {
+		return 0, errors.Wrap(err, "file_info_tosql")
+	}
+	sqlResult, err := transaction.Exec(query, args...)
 	if err != nil {
 		return 0, errors.Wrap(err, "failed to delete FileInfos in batch")
 	}
@@ -374,14 +803,31 @@ func (fs SqlFileInfoStore) PermanentDeleteBatch(endTime int64, limit int64) (int
 	if err != nil {
 		return 0, errors.Wrapf(err, "unable to retrieve rows affected")
 	}
+	if err = updateStorageUsage(transaction, fs.DriverName(), notDeleted, -1); err != nil {
+		return 0, err
+	}
 
+	if err = transaction.Commit(); err != nil {
+		return 0, errors.Wrap(err, "commit_transaction")
+	}
 	return rowsAffected, nil
 }
 
 func (fs SqlFileInfoStore) PermanentDeleteByUser(userId string) (int64, error) {
+	transaction, err := fs.GetMaster().Begin()
+	if err != nil {
+		return 0, errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransaction(transaction)
+
+	files, err := fs.getFilesForStorageUsage(transaction, sq.Eq{"CreatorId": userId, "DeleteAt": 0})
+	if err != nil {
+		return 0, err
+	}
+
 	query := "DELETE from FileInfo WHERE CreatorId = :CreatorId"
 
-	sqlResult, err := fs.GetMaster().Exec(query, map[string]interface{}{"CreatorId": userId})
+	sqlResult, err := transaction.Exec(query, map[string]interface{}{"CreatorId": userId})
 	if err != nil {
 		return 0, errors.Wrapf(err, "failed to delete FileInfo with creatorId=%s", userId)
 	}
@@ -390,6 +836,31 @@ func (fs SqlFileInfoStore) PermanentDeleteByUser(userId string) (int64, error) {
 	if err != nil {
 		return 0, errors.Wrapf(err, "unable to retrieve rows affected")
 	}
+	if err = updateStorageUsage(transaction, fs.DriverName(), files, -1); err != nil {
+		return 0, err
+	}
 
+	if err = transaction.Commit(); err != nil {
+		return 0, errors.Wrap(err, "commit_transaction")
+	}
 	return rowsAffected, nil
 }
+
+// getFilesForStorageUsage returns the owners and sizes of the files matching
+// the condition, to update the storage usage before they are deleted.
+func (fs SqlFileInfoStore) getFilesForStorageUsage(transaction *gorp.Transaction, condition sq.Sqlizer) ([]*model.FileInfo, error) {
+	query, args, err := fs.getQueryBuilder().
+		Select("Id", "CreatorId", "TeamId", "Size").
+		From("FileInfo").
+		Where(condition).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "file_info_tosql")
+	}
+
+	var files []*model.FileInfo
+	if _, err = transaction.Select(&files, query, args...); err != nil {
+		return nil, errors.Wrap(err, "failed to find FileInfos")
+	}
+	return files, nil
+}
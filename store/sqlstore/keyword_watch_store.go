@@ -0,0 +1,138 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	"github.com/pkg/errors"
+
+	"github.com/zacmm/zacmm-server/model"
+	"github.com/zacmm/zacmm-server/store"
+)
+
+type SqlKeywordWatchStore struct {
+	*SqlSupplier
+}
+
+func newSqlKeywordWatchStore(sqlSupplier *SqlSupplier) store.KeywordWatchStore {
+	s := &SqlKeywordWatchStore{
+		SqlSupplier: sqlSupplier,
+	}
+
+	for _, db := range sqlSupplier.GetAllConns() {
+		table := db.AddTableWithName(model.KeywordWatch{}, "KeywordWatches").SetKeys(false, "Id")
+		table.ColMap("Id").SetMaxSize(26)
+		table.ColMap("UserId").SetMaxSize(26)
+		table.ColMap("TeamId").SetMaxSize(26)
+		table.ColMap("Pattern").SetMaxSize(1024)
+		table.ColMap("Delivery").SetMaxSize(32)
+	}
+
+	return s
+}
+
+func (s *SqlKeywordWatchStore) createIndexesIfNotExists() {
+	s.CreateIndexIfNotExists("idx_keywordwatches_user_id", "KeywordWatches", "UserId")
+	s.CreateIndexIfNotExists("idx_keywordwatches_team_id", "KeywordWatches", "TeamId")
+}
+
+func (s *SqlKeywordWatchStore) Save(watch *model.KeywordWatch) (*model.KeywordWatch, error) {
+	if watch.Id != "" {
+		return nil, store.NewErrInvalidInput("KeywordWatch", "id", watch.Id)
+	}
+
+	watch.PreSave()
+	if err := watch.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if err := s.GetMaster().Insert(watch); err != nil {
+		return nil, errors.Wrapf(err, "failed to save KeywordWatch with id=%s", watch.Id)
+	}
+
+	return watch, nil
+}
+
+func (s *SqlKeywordWatchStore) Update(watch *model.KeywordWatch) (*model.KeywordWatch, error) {
+	watch.PreUpdate()
+	if err := watch.IsValid(); err != nil {
+		return nil, err
+	}
+
+	count, err := s.GetMaster().Update(watch)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to update KeywordWatch with id=%s", watch.Id)
+	}
+
+	if count == 0 {
+		return nil, store.NewErrNotFound("KeywordWatch", watch.Id)
+	}
+
+	return watch, nil
+}
+
+func (s *SqlKeywordWatchStore) Get(id string) (*model.KeywordWatch, error) {
+	var watch model.KeywordWatch
+	if err := s.GetReplica().SelectOne(&watch, "SELECT * FROM KeywordWatches WHERE Id = :Id", map[string]interface{}{"Id": id}); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("KeywordWatch", id)
+		}
+		return nil, errors.Wrapf(err, "failed to get KeywordWatch with id=%s", id)
+	}
+
+	return &watch, nil
+}
+
+func (s *SqlKeywordWatchStore) GetForUser(userId string) ([]*model.KeywordWatch, error) {
+	var watches []*model.KeywordWatch
+	if _, err := s.GetReplica().Select(&watches, "SELECT * FROM KeywordWatches WHERE UserId = :UserId ORDER BY CreateAt", map[string]interface{}{"UserId": userId}); err != nil {
+		return nil, errors.Wrapf(err, "failed to find KeywordWatches with userId=%s", userId)
+	}
+
+	return watches, nil
+}
+
+func (s *SqlKeywordWatchStore) GetForTeam(teamId string) ([]*model.KeywordWatch, error) {
+	var watches []*model.KeywordWatch
+	if _, err := s.GetReplica().Select(&watches, "SELECT * FROM KeywordWatches WHERE TeamId = :TeamId", map[string]interface{}{"TeamId": teamId}); err != nil {
+		return nil, errors.Wrapf(err, "failed to find KeywordWatches with teamId=%s", teamId)
+	}
+
+	return watches, nil
+}
+
+func (s *SqlKeywordWatchStore) Delete(id string) error {
+	result, err := s.GetMaster().Exec("DELETE FROM KeywordWatches WHERE Id = :Id", map[string]interface{}{"Id": id})
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete KeywordWatch with id=%s", id)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "unable to get rows affected")
+	}
+
+	if count == 0 {
+		return store.NewErrNotFound("KeywordWatch", id)
+	}
+
+	return nil
+}
+
+func (s *SqlKeywordWatchStore) UpdateLastAlertAt(id string, alertAt, since int64) (bool, error) {
+	// The condition makes the update atomic, so that only one server of a
+	// cluster sends the alert when several posts match at once.
+	result, err := s.GetMaster().Exec("UPDATE KeywordWatches SET LastAlertAt = :AlertAt WHERE Id = :Id AND LastAlertAt <= :Since", map[string]interface{}{"Id": id, "AlertAt": alertAt, "Since": since})
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to update KeywordWatch with id=%s", id)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(err, "unable to get rows affected")
+	}
+
+	return count == 1, nil
+}
@@ -0,0 +1,246 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/gorp"
+	"github.com/pkg/errors"
+
+	"github.com/zacmm/zacmm-server/model"
+	"github.com/zacmm/zacmm-server/store"
+)
+
+type SqlPollStore struct {
+	*SqlSupplier
+}
+
+func newSqlPollStore(sqlSupplier *SqlSupplier) store.PollStore {
+	s := &SqlPollStore{
+		SqlSupplier: sqlSupplier,
+	}
+
+	for _, db := range sqlSupplier.GetAllConns() {
+		table := db.AddTableWithName(model.Poll{}, "Polls").SetKeys(false, "Id")
+		table.ColMap("Id").SetMaxSize(26)
+		table.ColMap("PostId").SetMaxSize(26)
+		table.ColMap("ChannelId").SetMaxSize(26)
+		table.ColMap("UserId").SetMaxSize(26)
+		table.ColMap("Question").SetMaxSize(model.POLL_QUESTION_MAX_RUNES * 4)
+		table.ColMap("Options").SetMaxSize(model.POLL_MAX_OPTIONS * model.POLL_OPTION_MAX_RUNES * 4)
+
+		tableVotes := db.AddTableWithName(model.PollVote{}, "PollVotes").SetKeys(false, "PollId", "UserId", "OptionIndex")
+		tableVotes.ColMap("PollId").SetMaxSize(26)
+		tableVotes.ColMap("UserId").SetMaxSize(26)
+	}
+
+	return s
+}
+
+func (s *SqlPollStore) createIndexesIfNotExists() {
+	s.CreateIndexIfNotExists("idx_polls_post_id", "Polls", "PostId")
+	s.CreateIndexIfNotExists("idx_polls_close_at", "Polls", "CloseAt")
+	s.CreateIndexIfNotExists("idx_pollvotes_poll_id", "PollVotes", "PollId")
+}
+
+func (s *SqlPollStore) Save(poll *model.Poll) (*model.Poll, error) {
+	poll.PreSave()
+	if err := poll.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if err := s.GetMaster().Insert(poll); err != nil {
+		return nil, errors.Wrapf(err, "failed to save Poll with id=%s", poll.Id)
+	}
+
+	return poll, nil
+}
+
+func (s *SqlPollStore) Update(poll *model.Poll) (*model.Poll, error) {
+	poll.PreUpdate()
+	if err := poll.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.GetMaster().Update(poll); err != nil {
+		return nil, errors.Wrapf(err, "failed to update Poll with id=%s", poll.Id)
+	}
+
+	return poll, nil
+}
+
+func (s *SqlPollStore) Get(id string) (*model.Poll, error) {
+	var poll model.Poll
+	if err := s.GetReplica().SelectOne(&poll, "SELECT * FROM Polls WHERE Id = :Id AND DeleteAt = 0", map[string]interface{}{"Id": id}); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("Poll", id)
+		}
+		return nil, errors.Wrapf(err, "failed to get Poll with id=%s", id)
+	}
+
+	return &poll, nil
+}
+
+func (s *SqlPollStore) GetForPosts(postIds []string) ([]*model.Poll, error) {
+	var polls []*model.Poll
+	if len(postIds) == 0 {
+		return polls, nil
+	}
+
+	query, args, err := s.getQueryBuilder().
+		Select("*").
+		From("Polls").
+		Where(sq.Eq{"PostId": postIds, "DeleteAt": 0}).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "polls_tosql")
+	}
+
+	if _, err := s.GetReplica().Select(&polls, query, args...); err != nil {
+		return nil, errors.Wrap(err, "failed to find Polls for posts")
+	}
+
+	return polls, nil
+}
+
+func (s *SqlPollStore) GetPollsToClose(now int64, limit int) ([]*model.Poll, error) {
+	query, args, err := s.getQueryBuilder().
+		Select("*").
+		From("Polls").
+		Where(sq.And{
+			sq.Gt{"CloseAt": 0},
+			sq.LtOrEq{"CloseAt": now},
+			sq.Eq{"ClosedAt": 0, "DeleteAt": 0},
+		}).
+		OrderBy("CloseAt ASC").
+		Limit(uint64(limit)).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "polls_tosql")
+	}
+
+	var polls []*model.Poll
+	if _, err := s.GetMaster().Select(&polls, query, args...); err != nil {
+		return nil, errors.Wrap(err, "failed to find Polls to close")
+	}
+
+	return polls, nil
+}
+
+// SaveVotes replaces all of the user's votes on the poll with the given votes
+// and returns the poll's post with the updated tally in its props. The poll
+// row is locked until the transaction commits so that concurrent votes cannot
+// overwrite each other's tally.
+func (s *SqlPollStore) SaveVotes(pollId string, userId string, votes []*model.PollVote) (*model.Post, error) {
+	transaction, err := s.GetMaster().Begin()
+	if err != nil {
+		return nil, errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransaction(transaction)
+
+	poll, err := s.getForUpdate(transaction, pollId)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := transaction.Exec("DELETE FROM PollVotes WHERE PollId = :PollId AND UserId = :UserId", map[string]interface{}{"PollId": pollId, "UserId": userId}); err != nil {
+		return nil, errors.Wrapf(err, "failed to delete PollVotes with pollId=%s and userId=%s", pollId, userId)
+	}
+
+	now := model.GetMillis()
+	for _, vote := range votes {
+		vote.PollId = pollId
+		vote.UserId = userId
+		vote.CreateAt = now
+		if err := transaction.Insert(vote); err != nil {
+			return nil, errors.Wrapf(err, "failed to save PollVote with pollId=%s and userId=%s", pollId, userId)
+		}
+	}
+
+	post, err := s.updateTally(transaction, poll)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := transaction.Commit(); err != nil {
+		return nil, errors.Wrap(err, "commit_transaction")
+	}
+
+	return post, nil
+}
+
+// UpdateTally recomputes the tally kept in the poll's post props from the
+// stored votes and returns the updated post.
+func (s *SqlPollStore) UpdateTally(pollId string) (*model.Post, error) {
+	transaction, err := s.GetMaster().Begin()
+	if err != nil {
+		return nil, errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransaction(transaction)
+
+	poll, err := s.getForUpdate(transaction, pollId)
+	if err != nil {
+		return nil, err
+	}
+
+	post, err := s.updateTally(transaction, poll)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := transaction.Commit(); err != nil {
+		return nil, errors.Wrap(err, "commit_transaction")
+	}
+
+	return post, nil
+}
+
+func (s *SqlPollStore) getForUpdate(transaction *gorp.Transaction, pollId string) (*model.Poll, error) {
+	var poll model.Poll
+	if err := transaction.SelectOne(&poll, "SELECT * FROM Polls WHERE Id = :Id AND DeleteAt = 0 FOR UPDATE", map[string]interface{}{"Id": pollId}); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("Poll", pollId)
+		}
+		return nil, errors.Wrapf(err, "failed to get Poll with id=%s", pollId)
+	}
+
+	return &poll, nil
+}
+
+// updateTally stores the poll's tally in its post. Voters are left out of the
+// post props, which are visible to everyone in the channel and have a limited
+// size.
+func (s *SqlPollStore) updateTally(transaction *gorp.Transaction, poll *model.Poll) (*model.Post, error) {
+	var votes []*model.PollVote
+	if _, err := transaction.Select(&votes, "SELECT * FROM PollVotes WHERE PollId = :PollId", map[string]interface{}{"PollId": poll.Id}); err != nil {
+		return nil, errors.Wrapf(err, "failed to find PollVotes with pollId=%s", poll.Id)
+	}
+
+	var post model.Post
+	if err := transaction.SelectOne(&post, "SELECT * FROM Posts WHERE Id = :Id", map[string]interface{}{"Id": poll.PostId}); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("Post", poll.PostId)
+		}
+		return nil, errors.Wrapf(err, "failed to get Post with id=%s", poll.PostId)
+	}
+
+	post.AddProp(model.POST_PROPS_POLL_RESULTS, poll.Results(votes, false))
+	post.UpdateAt = model.GetMillis()
+	if _, err := transaction.Update(&post); err != nil {
+		return nil, errors.Wrapf(err, "failed to update Post with id=%s", post.Id)
+	}
+
+	return &post, nil
+}
+
+func (s *SqlPollStore) GetVotes(pollId string) ([]*model.PollVote, error) {
+	var votes []*model.PollVote
+	if _, err := s.GetMaster().Select(&votes, "SELECT * FROM PollVotes WHERE PollId = :PollId ORDER BY CreateAt ASC, UserId ASC", map[string]interface{}{"PollId": pollId}); err != nil {
+		return nil, errors.Wrapf(err, "failed to find PollVotes with pollId=%s", pollId)
+	}
+
+	return votes, nil
+}
@@ -1249,6 +1249,91 @@ func (s *SqlPostStore) buildSearchPostFilterClause(fromUsers []string, excludedU
 	return filterQuery, queryParams
 }
 
+// buildSearchAttributeFilterClause filters the posts by the has:, is:, ext:
+// and reacted: filters of the search. Each has: and is: filter must match,
+// while ext: matches the posts with an attachment of any of the extensions.
+func (s *SqlPostStore) buildSearchAttributeFilterClause(params *model.SearchParams, queryParams map[string]interface{}, userByUsername bool) (string, map[string]interface{}) {
+	filterClauses := map[string]string{
+		model.SEARCH_HAS_FILE:     "EXISTS (SELECT 1 FROM FileInfo WHERE FileInfo.PostId = q2.Id AND FileInfo.DeleteAt = 0)",
+		model.SEARCH_HAS_LINK:     "(q2.Message LIKE :HttpLink OR q2.Message LIKE :HttpsLink)",
+		model.SEARCH_HAS_REACTION: "q2.HasReactions = :True",
+		model.SEARCH_IS_PINNED:    "q2.IsPinned = :True",
+		model.SEARCH_IS_FLAGGED:   "q2.Id IN (SELECT Preferences.Name FROM Preferences WHERE Preferences.UserId = :UserId AND Preferences.Category = :FlaggedPostCategory)",
+		model.SEARCH_IS_THREAD:    "(CASE WHEN q2.RootId = '' THEN q2.Id ELSE q2.RootId END) IN (SELECT ThreadMemberships.PostId FROM ThreadMemberships WHERE ThreadMemberships.UserId = :UserId AND ThreadMemberships.Following = :True)",
+	}
+
+	searchQuery := ""
+	for _, filter := range params.HasFilters {
+		searchQuery += "AND " + filterClauses[filter] + " "
+	}
+	for _, filter := range params.ExcludedHasFilters {
+		searchQuery += "AND NOT " + filterClauses[filter] + " "
+	}
+	for _, filter := range params.IsFilters {
+		searchQuery += "AND " + filterClauses[filter] + " "
+	}
+	for _, filter := range params.ExcludedIsFilters {
+		searchQuery += "AND NOT " + filterClauses[filter] + " "
+	}
+
+	if searchQuery != "" {
+		queryParams["HttpLink"] = "%http://%"
+		queryParams["HttpsLink"] = "%https://%"
+		queryParams["True"] = true
+		queryParams["FlaggedPostCategory"] = model.PREFERENCE_CATEGORY_FLAGGED_POST
+	}
+
+	if len(params.Extensions) > 0 {
+		keys, extensionParams := MapStringsToQueryParams(params.Extensions, "Extension")
+		for key, value := range extensionParams {
+			queryParams[key] = value
+		}
+		searchQuery += "AND q2.Id IN (SELECT FileInfo.PostId FROM FileInfo WHERE FileInfo.Extension IN " + keys + " AND FileInfo.DeleteAt = 0) "
+	}
+	if len(params.ExcludedExtensions) > 0 {
+		keys, extensionParams := MapStringsToQueryParams(params.ExcludedExtensions, "ExcludedExtension")
+		for key, value := range extensionParams {
+			queryParams[key] = value
+		}
+		searchQuery += "AND q2.Id NOT IN (SELECT FileInfo.PostId FROM FileInfo WHERE FileInfo.Extension IN " + keys + " AND FileInfo.DeleteAt = 0) "
+	}
+
+	reactedClause, queryParams := s.buildSearchReactedFilterClause(params.ReactedUsers, "ReactedUser", false, queryParams, userByUsername)
+	searchQuery += reactedClause
+
+	excludedReactedClause, queryParams := s.buildSearchReactedFilterClause(params.ExcludedReactedUsers, "ExcludedReactedUser", true, queryParams, userByUsername)
+	searchQuery += excludedReactedClause
+
+	return searchQuery, queryParams
+}
+
+func (s *SqlPostStore) buildSearchReactedFilterClause(users []string, paramPrefix string, exclusion bool, queryParams map[string]interface{}, byUsername bool) (string, map[string]interface{}) {
+	if len(users) == 0 {
+		return "", queryParams
+	}
+
+	clauseSlice := []string{}
+	for i, user := range users {
+		paramName := paramPrefix + strconv.FormatInt(int64(i), 10)
+		clauseSlice = append(clauseSlice, ":"+paramName)
+		queryParams[paramName] = user
+	}
+	clause := strings.Join(clauseSlice, ", ")
+
+	// Like from:, the post matches when any of the users reacted to it.
+	userClause := "Reactions.UserId IN (" + clause + ")"
+	if byUsername {
+		userClause = "Reactions.UserId IN (SELECT Users.Id FROM Users WHERE Users.Username IN (" + clause + "))"
+	}
+
+	filterQuery := "AND q2.Id IN (SELECT Reactions.PostId FROM Reactions WHERE " + userClause + ") "
+	if exclusion {
+		filterQuery = "AND q2.Id NOT IN (SELECT Reactions.PostId FROM Reactions WHERE " + userClause + ") "
+	}
+
+	return filterQuery, queryParams
+}
+
 func (s *SqlPostStore) Search(teamId string, userId string, params *model.SearchParams) (*model.PostList, error) {
 	return s.search(teamId, userId, params, true, true)
 }
@@ -1263,7 +1348,11 @@ func (s *SqlPostStore) search(teamId string, userId string, params *model.Search
 	if params.Terms == "" && params.ExcludedTerms == "" &&
 		len(params.InChannels) == 0 && len(params.ExcludedChannels) == 0 &&
 		len(params.FromUsers) == 0 && len(params.ExcludedUsers) == 0 &&
-		len(params.OnDate) == 0 && len(params.AfterDate) == 0 && len(params.BeforeDate) == 0 {
+		len(params.OnDate) == 0 && len(params.AfterDate) == 0 && len(params.BeforeDate) == 0 &&
+		len(params.HasFilters) == 0 && len(params.ExcludedHasFilters) == 0 &&
+		len(params.IsFilters) == 0 && len(params.ExcludedIsFilters) == 0 &&
+		len(params.Extensions) == 0 && len(params.ExcludedExtensions) == 0 &&
+		len(params.ReactedUsers) == 0 && len(params.ExcludedReactedUsers) == 0 {
 		return list, nil
 	}
 
@@ -1302,6 +1391,7 @@ func (s *SqlPostStore) search(teamId string, userId string, params *model.Search
 							IN_CHANNEL_FILTER
 							EXCLUDED_CHANNEL_FILTER)
 				CREATEDATE_CLAUSE
+				ATTRIBUTE_FILTER
 				SEARCH_CLAUSE
 				ORDER BY CreateAt DESC
 			LIMIT 100`
@@ -1318,6 +1408,9 @@ func (s *SqlPostStore) search(teamId string, userId string, params *model.Search
 	createDateFilterClause, queryParams := s.buildCreateDateFilterClause(params, queryParams)
 	searchQuery = strings.Replace(searchQuery, "CREATEDATE_CLAUSE", createDateFilterClause, 1)
 
+	attributeFilterClause, queryParams := s.buildSearchAttributeFilterClause(params, queryParams, userByUsername)
+	searchQuery = strings.Replace(searchQuery, "ATTRIBUTE_FILTER", attributeFilterClause, 1)
+
 	termMap := map[string]bool{}
 	terms := params.Terms
 	excludedTerms := params.ExcludedTerms
@@ -183,6 +183,32 @@ func (s SqlPreferenceStore) GetCategory(userId string, category string) (model.P
 
 }
 
+func (s SqlPreferenceStore) GetForUsers(userIds []string, category string, name string) (model.Preferences, error) {
+	var preferences model.Preferences
+
+	if len(userIds) == 0 {
+		return preferences, nil
+	}
+
+	keys, props := MapStringsToQueryParams(userIds, "User")
+	props["Category"] = category
+	props["Name"] = name
+
+	if _, err := s.GetReplica().Select(&preferences,
+		`SELECT
+				*
+			FROM
+				Preferences
+			WHERE
+				UserId IN `+keys+`
+				AND Category = :Category
+				AND Name = :Name`, props); err != nil {
+		return nil, errors.Wrapf(err, "failed to find Preferences with category=%s and name=%s", category, name)
+	}
+
+	return preferences, nil
+}
+
 func (s SqlPreferenceStore) GetAll(userId string) (model.Preferences, error) {
 	var preferences model.Preferences
 
@@ -0,0 +1,127 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/zacmm/zacmm-server/model"
+	"github.com/zacmm/zacmm-server/store"
+)
+
+type SqlPublicLinkStore struct {
+	*SqlSupplier
+}
+
+func newSqlPublicLinkStore(sqlSupplier *SqlSupplier) store.PublicLinkStore {
+	s := &SqlPublicLinkStore{
+		SqlSupplier: sqlSupplier,
+	}
+
+	for _, db := range sqlSupplier.GetAllConns() {
+		table := db.AddTableWithName(model.PublicLink{}, "PublicLinks").SetKeys(false, "Id")
+		table.ColMap("Id").SetMaxSize(26)
+		table.ColMap("FileId").SetMaxSize(26)
+		table.ColMap("CreatorId").SetMaxSize(26)
+	}
+
+	return s
+}
+
+func (s *SqlPublicLinkStore) createIndexesIfNotExists() {
+	s.CreateIndexIfNotExists("idx_publiclinks_file_id", "PublicLinks", "FileId")
+	s.CreateIndexIfNotExists("idx_publiclinks_creator_id", "PublicLinks", "CreatorId")
+}
+
+func (s *SqlPublicLinkStore) Save(link *model.PublicLink) (*model.PublicLink, error) {
+	link.PreSave()
+	if err := link.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if err := s.GetMaster().Insert(link); err != nil {
+		return nil, errors.Wrapf(err, "failed to save PublicLink with id=%s", link.Id)
+	}
+	return link, nil
+}
+
+func (s *SqlPublicLinkStore) Get(id string) (*model.PublicLink, error) {
+	var link model.PublicLink
+	if err := s.GetReplica().SelectOne(&link, "SELECT * FROM PublicLinks WHERE Id = :Id", map[string]interface{}{"Id": id}); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("PublicLink", id)
+		}
+		return nil, errors.Wrapf(err, "failed to get PublicLink with id=%s", id)
+	}
+	return &link, nil
+}
+
+func (s *SqlPublicLinkStore) GetActive(fileId, creatorId string, now int64) (*model.PublicLink, error) {
+	query := s.getQueryBuilder().
+		Select("*").
+		From("PublicLinks").
+		Where(sq.Eq{"FileId": fileId, "CreatorId": creatorId, "RevokeAt": 0}).
+		Where(sq.Or{sq.Eq{"ExpireAt": 0}, sq.Gt{"ExpireAt": now}}).
+		OrderBy("CreateAt DESC").
+		Limit(1)
+
+	queryString, args, err := query.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "public_link_tosql")
+	}
+
+	var link model.PublicLink
+	if err := s.GetMaster().SelectOne(&link, queryString, args...); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("PublicLink", "fileId="+fileId+", creatorId="+creatorId)
+		}
+		return nil, errors.Wrapf(err, "failed to get PublicLink with fileId=%s and creatorId=%s", fileId, creatorId)
+	}
+	return &link, nil
+}
+
+// GetForUser returns the links created by the user, the most recent first.
+func (s *SqlPublicLinkStore) GetForUser(userId string, offset, limit int) ([]*model.PublicLink, error) {
+	query := s.getQueryBuilder().
+		Select("*").
+		From("PublicLinks").
+		Where(sq.Eq{"CreatorId": userId}).
+		OrderBy("CreateAt DESC", "Id").
+		Limit(uint64(limit)).
+		Offset(uint64(offset))
+
+	queryString, args, err := query.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "public_link_tosql")
+	}
+
+	var links []*model.PublicLink
+	if _, err := s.GetReplica().Select(&links, queryString, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to find PublicLinks with creatorId=%s", userId)
+	}
+	return links, nil
+}
+
+func (s *SqlPublicLinkStore) Revoke(id string, revokeAt int64) error {
+	result, err := s.GetMaster().Exec("UPDATE PublicLinks SET RevokeAt = :RevokeAt WHERE Id = :Id AND RevokeAt = 0", map[string]interface{}{"Id": id, "RevokeAt": revokeAt})
+	if err != nil {
+		return errors.Wrapf(err, "failed to revoke PublicLink with id=%s", id)
+	}
+	if rows, err := result.RowsAffected(); err != nil {
+		return errors.Wrapf(err, "failed to revoke PublicLink with id=%s", id)
+	} else if rows == 0 {
+		return store.NewErrNotFound("PublicLink", id)
+	}
+	return nil
+}
+
+func (s *SqlPublicLinkStore) RecordDownload(id string, downloadAt int64) error {
+	if _, err := s.GetMaster().Exec("UPDATE PublicLinks SET DownloadCount = DownloadCount + 1, LastDownloadAt = :DownloadAt WHERE Id = :Id", map[string]interface{}{"Id": id, "DownloadAt": downloadAt}); err != nil {
+		return errors.Wrapf(err, "failed to record download of PublicLink with id=%s", id)
+	}
+	return nil
+}
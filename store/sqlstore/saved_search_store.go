@@ -0,0 +1,130 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	"github.com/pkg/errors"
+
+	"github.com/zacmm/zacmm-server/model"
+	"github.com/zacmm/zacmm-server/store"
+)
+
+type SqlSavedSearchStore struct {
+	*SqlSupplier
+}
+
+func newSqlSavedSearchStore(sqlSupplier *SqlSupplier) store.SavedSearchStore {
+	s := &SqlSavedSearchStore{
+		SqlSupplier: sqlSupplier,
+	}
+
+	for _, db := range sqlSupplier.GetAllConns() {
+		table := db.AddTableWithName(model.SavedSearch{}, "SavedSearches").SetKeys(false, "Id")
+		table.ColMap("Id").SetMaxSize(26)
+		table.ColMap("UserId").SetMaxSize(26)
+		table.ColMap("TeamId").SetMaxSize(26)
+		table.ColMap("Name").SetMaxSize(256)
+		table.ColMap("Terms").SetMaxSize(4096)
+	}
+
+	return s
+}
+
+func (s *SqlSavedSearchStore) createIndexesIfNotExists() {
+	s.CreateIndexIfNotExists("idx_savedsearches_user_id", "SavedSearches", "UserId")
+	s.CreateIndexIfNotExists("idx_savedsearches_notify_on_match", "SavedSearches", "NotifyOnMatch")
+}
+
+func (s *SqlSavedSearchStore) Save(search *model.SavedSearch) (*model.SavedSearch, error) {
+	if search.Id != "" {
+		return nil, store.NewErrInvalidInput("SavedSearch", "id", search.Id)
+	}
+
+	search.PreSave()
+	if err := search.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if err := s.GetMaster().Insert(search); err != nil {
+		return nil, errors.Wrapf(err, "failed to save SavedSearch with id=%s", search.Id)
+	}
+
+	return search, nil
+}
+
+func (s *SqlSavedSearchStore) Update(search *model.SavedSearch) (*model.SavedSearch, error) {
+	search.PreUpdate()
+	if err := search.IsValid(); err != nil {
+		return nil, err
+	}
+
+	count, err := s.GetMaster().Update(search)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to update SavedSearch with id=%s", search.Id)
+	}
+
+	if count == 0 {
+		return nil, store.NewErrNotFound("SavedSearch", search.Id)
+	}
+
+	return search, nil
+}
+
+func (s *SqlSavedSearchStore) Get(id string) (*model.SavedSearch, error) {
+	var search model.SavedSearch
+	if err := s.GetReplica().SelectOne(&search, "SELECT * FROM SavedSearches WHERE Id = :Id", map[string]interface{}{"Id": id}); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("SavedSearch", id)
+		}
+		return nil, errors.Wrapf(err, "failed to get SavedSearch with id=%s", id)
+	}
+
+	return &search, nil
+}
+
+func (s *SqlSavedSearchStore) GetForUser(userId string) ([]*model.SavedSearch, error) {
+	var searches []*model.SavedSearch
+	if _, err := s.GetReplica().Select(&searches, "SELECT * FROM SavedSearches WHERE UserId = :UserId ORDER BY CreateAt", map[string]interface{}{"UserId": userId}); err != nil {
+		return nil, errors.Wrapf(err, "failed to find SavedSearches with userId=%s", userId)
+	}
+
+	return searches, nil
+}
+
+func (s *SqlSavedSearchStore) GetForNotification(afterId string, limit int) ([]*model.SavedSearch, error) {
+	var searches []*model.SavedSearch
+	if _, err := s.GetReplica().Select(&searches, "SELECT * FROM SavedSearches WHERE NotifyOnMatch = :NotifyOnMatch AND Id > :AfterId ORDER BY Id LIMIT :Limit", map[string]interface{}{"NotifyOnMatch": true, "AfterId": afterId, "Limit": limit}); err != nil {
+		return nil, errors.Wrap(err, "failed to find SavedSearches to notify")
+	}
+
+	return searches, nil
+}
+
+func (s *SqlSavedSearchStore) Delete(id string) error {
+	result, err := s.GetMaster().Exec("DELETE FROM SavedSearches WHERE Id = :Id", map[string]interface{}{"Id": id})
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete SavedSearch with id=%s", id)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "unable to get rows affected")
+	}
+
+	if count == 0 {
+		return store.NewErrNotFound("SavedSearch", id)
+	}
+
+	return nil
+}
+
+func (s *SqlSavedSearchStore) UpdateLastCheckedAt(id string, checkedAt int64) error {
+	if _, err := s.GetMaster().Exec("UPDATE SavedSearches SET LastCheckedAt = :CheckedAt WHERE Id = :Id", map[string]interface{}{"Id": id, "CheckedAt": checkedAt}); err != nil {
+		return errors.Wrapf(err, "failed to update SavedSearch with id=%s", id)
+	}
+
+	return nil
+}
@@ -27,6 +27,7 @@ func newSqlStatusStore(sqlSupplier *SqlSupplier) store.StatusStore {
 		table.ColMap("UserId").SetMaxSize(26)
 		table.ColMap("Status").SetMaxSize(32)
 		table.ColMap("ActiveChannel").SetMaxSize(26)
+		table.ColMap("PrevStatus").SetMaxSize(32)
 	}
 
 	return s
@@ -35,6 +36,7 @@ func newSqlStatusStore(sqlSupplier *SqlSupplier) store.StatusStore {
 func (s SqlStatusStore) createIndexesIfNotExists() {
 	s.CreateIndexIfNotExists("idx_status_user_id", "Status", "UserId")
 	s.CreateIndexIfNotExists("idx_status_status", "Status", "Status")
+	s.CreateIndexIfNotExists("idx_status_dndendtime", "Status", "DNDEndTime")
 }
 
 func (s SqlStatusStore) SaveOrUpdate(status *model.Status) error {
@@ -72,7 +74,7 @@ func (s SqlStatusStore) Get(userId string) (*model.Status, error) {
 
 func (s SqlStatusStore) GetByIds(userIds []string) ([]*model.Status, error) {
 	query := s.getQueryBuilder().
-		Select("UserId, Status, Manual, LastActivityAt").
+		Select("UserId, Status, Manual, LastActivityAt, DNDEndTime").
 		From("Status").
 		Where(sq.Eq{"UserId": userIds})
 	queryString, args, err := query.ToSql()
@@ -87,7 +89,7 @@ func (s SqlStatusStore) GetByIds(userIds []string) ([]*model.Status, error) {
 	defer rows.Close()
 	for rows.Next() {
 		var status model.Status
-		if err = rows.Scan(&status.UserId, &status.Status, &status.Manual, &status.LastActivityAt); err != nil {
+		if err = rows.Scan(&status.UserId, &status.Status, &status.Manual, &status.LastActivityAt, &status.DNDEndTime); err != nil {
 			return nil, errors.Wrap(err, "unable to scan from rows")
 		}
 		statuses = append(statuses, &status)
@@ -122,3 +124,14 @@ func (s SqlStatusStore) UpdateLastActivityAt(userId string, lastActivityAt int64
 
 	return nil
 }
+
+// GetExpiredDND returns up to limit statuses whose timed Do Not Disturb ended
+// at or before expiredAt, oldest first.
+func (s SqlStatusStore) GetExpiredDND(expiredAt int64, limit int) ([]*model.Status, error) {
+	var statuses []*model.Status
+	if _, err := s.GetReplica().Select(&statuses, "SELECT * FROM Status WHERE Status = :Status AND DNDEndTime > 0 AND DNDEndTime <= :ExpiredAt ORDER BY DNDEndTime LIMIT :Limit", map[string]interface{}{"Status": model.STATUS_DND, "ExpiredAt": expiredAt, "Limit": limit}); err != nil {
+		return nil, errors.Wrap(err, "failed to find expired Do Not Disturb Statuses")
+	}
+
+	return statuses, nil
+}
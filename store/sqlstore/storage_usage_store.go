@@ -0,0 +1,213 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/gorp"
+	"github.com/pkg/errors"
+
+	"github.com/zacmm/zacmm-server/model"
+	"github.com/zacmm/zacmm-server/store"
+)
+
+type SqlStorageUsageStore struct {
+	*SqlSupplier
+}
+
+func newSqlStorageUsageStore(sqlSupplier *SqlSupplier) store.StorageUsageStore {
+	s := &SqlStorageUsageStore{
+		SqlSupplier: sqlSupplier,
+	}
+
+	for _, db := range sqlSupplier.GetAllConns() {
+		table := db.AddTableWithName(model.StorageUsage{}, "StorageUsage").SetKeys(false, "TargetType", "TargetId")
+		table.ColMap("TargetType").SetMaxSize(16)
+		table.ColMap("TargetId").SetMaxSize(26)
+	}
+
+	return s
+}
+
+func (s *SqlStorageUsageStore) createIndexesIfNotExists() {
+	s.CreateIndexIfNotExists("idx_storageusage_used_bytes", "StorageUsage", "UsedBytes")
+}
+
+func (s *SqlStorageUsageStore) Get(targetType, targetId string) (*model.StorageUsage, error) {
+	var usage model.StorageUsage
+	if err := s.GetReplica().SelectOne(&usage, "SELECT * FROM StorageUsage WHERE TargetType = :TargetType AND TargetId = :TargetId", map[string]interface{}{"TargetType": targetType, "TargetId": targetId}); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("StorageUsage", fmt.Sprintf("targetType=%s, targetId=%s", targetType, targetId))
+		}
+		return nil, errors.Wrapf(err, "failed to get StorageUsage with targetType=%s and targetId=%s", targetType, targetId)
+	}
+	return &usage, nil
+}
+
+// GetAll returns the storage usage of the targets of the given type, the
+// largest first.
+func (s *SqlStorageUsageStore) GetAll(targetType string, offset, limit int) ([]*model.StorageUsage, error) {
+	query := s.getQueryBuilder().
+		Select("*").
+		From("StorageUsage").
+		Where(sq.Eq{"TargetType": targetType}).
+		OrderBy("UsedBytes DESC", "TargetId").
+		Limit(uint64(limit)).
+		Offset(uint64(offset))
+
+	queryString, args, err := query.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "storage_usage_tosql")
+	}
+
+	var usages []*model.StorageUsage
+	if _, err := s.GetReplica().Select(&usages, queryString, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to find StorageUsage with targetType=%s", targetType)
+	}
+	return usages, nil
+}
+
+// Recompute rebuilds the storage usage of all the users and teams from the
+// files that aren't deleted. Files uploaded before the usage was tracked get
+// the team of the post they are attached to.
+func (s *SqlStorageUsageStore) Recompute() error {
+	transaction, err := s.GetMaster().Begin()
+	if err != nil {
+		return errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransaction(transaction)
+
+	if _, err = transaction.Exec(`
+		UPDATE
+			FileInfo
+		SET
+			TeamId = COALESCE((
+				SELECT Channels.TeamId
+				FROM Posts
+				JOIN Channels ON Channels.Id = Posts.ChannelId
+				WHERE Posts.Id = FileInfo.PostId
+			), '')
+		WHERE
+			TeamId = ''
+			AND PostId != ''`); err != nil {
+		return errors.Wrap(err, "failed to set the team of FileInfos")
+	}
+
+	if _, err = transaction.Exec("DELETE FROM StorageUsage"); err != nil {
+		return errors.Wrap(err, "failed to delete StorageUsage")
+	}
+
+	// The constants are inlined as Postgres can't infer the type of
+	// parameters in the select list.
+	now := model.GetMillis()
+	if _, err = transaction.Exec(fmt.Sprintf(`
+		INSERT INTO StorageUsage
+			(TargetType, TargetId, UsedBytes, FileCount, UpdateAt)
+		SELECT
+			'%s', CreatorId, SUM(Size), COUNT(*), %d
+		FROM
+			FileInfo
+		WHERE
+			DeleteAt = 0
+		GROUP BY
+			CreatorId`, model.STORAGE_USAGE_TARGET_USER, now)); err != nil {
+		return errors.Wrap(err, "failed to compute the StorageUsage of users")
+	}
+
+	if _, err = transaction.Exec(fmt.Sprintf(`
+		INSERT INTO StorageUsage
+			(TargetType, TargetId, UsedBytes, FileCount, UpdateAt)
+		SELECT
+			'%s', TeamId, SUM(Size), COUNT(*), %d
+		FROM
+			FileInfo
+		WHERE
+			DeleteAt = 0
+			AND TeamId != ''
+		GROUP BY
+			TeamId`, model.STORAGE_USAGE_TARGET_TEAM, now)); err != nil {
+		return errors.Wrap(err, "failed to compute the StorageUsage of teams")
+	}
+
+	if err = transaction.Commit(); err != nil {
+		return errors.Wrap(err, "commit_transaction")
+	}
+	return nil
+}
+
+type storageUsageDelta struct {
+	targetType string
+	targetId   string
+	bytes      int64
+	count      int64
+}
+
+// updateStorageUsage adds the given files to the storage usage of their
+// creators and teams, or subtracts them when sign is -1, as part of the
+// transaction that saves or deletes them.
+func updateStorageUsage(transaction *gorp.Transaction, driverName string, files []*model.FileInfo, sign int64) error {
+	deltas := map[string]*storageUsageDelta{}
+	add := func(targetType, targetId string, size int64) {
+		key := targetType + ":" + targetId
+		if deltas[key] == nil {
+			deltas[key] = &storageUsageDelta{targetType: targetType, targetId: targetId}
+		}
+		deltas[key].bytes += sign * size
+		deltas[key].count += sign
+	}
+	for _, file := range files {
+		add(model.STORAGE_USAGE_TARGET_USER, file.CreatorId, file.Size)
+		if file.TeamId != "" {
+			add(model.STORAGE_USAGE_TARGET_TEAM, file.TeamId, file.Size)
+		}
+	}
+
+	// The rows are always updated in the same order to avoid deadlocks
+	// between concurrent transactions.
+	keys := make([]string, 0, len(deltas))
+	for key := range deltas {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var query string
+	if driverName == model.DATABASE_DRIVER_POSTGRES {
+		query = `INSERT INTO StorageUsage
+				(TargetType, TargetId, UsedBytes, FileCount, UpdateAt)
+			VALUES
+				(:TargetType, :TargetId, GREATEST(:UsedBytes, 0), GREATEST(:FileCount, 0), :UpdateAt)
+			ON CONFLICT (TargetType, TargetId) DO UPDATE SET
+				UsedBytes = GREATEST(StorageUsage.UsedBytes + :UsedBytes, 0),
+				FileCount = GREATEST(StorageUsage.FileCount + :FileCount, 0),
+				UpdateAt = :UpdateAt`
+	} else {
+		query = `INSERT INTO StorageUsage
+				(TargetType, TargetId, UsedBytes, FileCount, UpdateAt)
+			VALUES
+				(:TargetType, :TargetId, GREATEST(:UsedBytes, 0), GREATEST(:FileCount, 0), :UpdateAt)
+			ON DUPLICATE KEY UPDATE
+				UsedBytes = GREATEST(UsedBytes + :UsedBytes, 0),
+				FileCount = GREATEST(FileCount + :FileCount, 0),
+				UpdateAt = :UpdateAt`
+	}
+
+	now := model.GetMillis()
+	for _, key := range keys {
+		delta := deltas[key]
+		if _, err := transaction.Exec(query, map[string]interface{}{
+			"TargetType": delta.targetType,
+			"TargetId":   delta.targetId,
+			"UsedBytes":  delta.bytes,
+			"FileCount":  delta.count,
+			"UpdateAt":   now,
+		}); err != nil {
+			return errors.Wrapf(err, "failed to update StorageUsage with targetType=%s and targetId=%s", delta.targetType, delta.targetId)
+		}
+	}
+	return nil
+}
@@ -108,6 +108,17 @@ type SqlSupplierStores struct {
 	linkMetadata         store.LinkMetadataStore
 	whitelist            store.WhitelistStore
 	invite               store.InviteStore
+	poll                 store.PollStore
+	draft                store.DraftStore
+	channelBookmark      store.ChannelBookmarkStore
+	customStatus         store.CustomStatusStore
+	emailQueue           store.EmailQueueStore
+	emailDigest          store.EmailDigestStore
+	dndSchedule          store.DndScheduleStore
+	keywordWatch         store.KeywordWatchStore
+	savedSearch          store.SavedSearchStore
+	storageUsage         store.StorageUsageStore
+	publicLink           store.PublicLinkStore
 }
 
 type SqlSupplier struct {
@@ -181,6 +192,17 @@ func NewSqlSupplier(settings model.SqlSettings, metrics einterfaces.MetricsInter
 	supplier.stores.whitelist = newSqlWhitelistStore(supplier)
 	supplier.stores.invite = newSqlInviteStore(supplier)
 	supplier.stores.productNotices = newSqlProductNoticesStore(supplier)
+	supplier.stores.poll = newSqlPollStore(supplier)
+	supplier.stores.draft = newSqlDraftStore(supplier)
+	supplier.stores.channelBookmark = newSqlChannelBookmarkStore(supplier)
+	supplier.stores.customStatus = newSqlCustomStatusStore(supplier)
+	supplier.stores.emailQueue = newSqlEmailQueueStore(supplier)
+	supplier.stores.emailDigest = newSqlEmailDigestStore(supplier)
+	supplier.stores.dndSchedule = newSqlDndScheduleStore(supplier)
+	supplier.stores.keywordWatch = newSqlKeywordWatchStore(supplier)
+	supplier.stores.savedSearch = newSqlSavedSearchStore(supplier)
+	supplier.stores.storageUsage = newSqlStorageUsageStore(supplier)
+	supplier.stores.publicLink = newSqlPublicLinkStore(supplier)
 	err := supplier.GetMaster().CreateTablesIfNotExists()
 	if err != nil {
 		if IsDuplicate(err) {
@@ -228,6 +250,17 @@ func NewSqlSupplier(settings model.SqlSettings, metrics einterfaces.MetricsInter
 	supplier.stores.linkMetadata.(*SqlLinkMetadataStore).createIndexesIfNotExists()
 	supplier.stores.group.(*SqlGroupStore).createIndexesIfNotExists()
 	supplier.stores.scheme.(*SqlSchemeStore).createIndexesIfNotExists()
+	supplier.stores.poll.(*SqlPollStore).createIndexesIfNotExists()
+	supplier.stores.draft.(*SqlDraftStore).createIndexesIfNotExists()
+	supplier.stores.channelBookmark.(*SqlChannelBookmarkStore).createIndexesIfNotExists()
+	supplier.stores.customStatus.(*SqlCustomStatusStore).createIndexesIfNotExists()
+	supplier.stores.emailQueue.(*SqlEmailQueueStore).createIndexesIfNotExists()
+	supplier.stores.emailDigest.(*SqlEmailDigestStore).createIndexesIfNotExists()
+	supplier.stores.dndSchedule.(*SqlDndScheduleStore).createIndexesIfNotExists()
+	supplier.stores.keywordWatch.(*SqlKeywordWatchStore).createIndexesIfNotExists()
+	supplier.stores.savedSearch.(*SqlSavedSearchStore).createIndexesIfNotExists()
+	supplier.stores.storageUsage.(*SqlStorageUsageStore).createIndexesIfNotExists()
+	supplier.stores.publicLink.(*SqlPublicLinkStore).createIndexesIfNotExists()
 	supplier.stores.preference.(*SqlPreferenceStore).deleteUnusedFeatures()
 
 	return supplier
@@ -1128,6 +1161,50 @@ func (ss *SqlSupplier) Invite() store.InviteStore {
 	return ss.stores.invite
 }
 
+func (ss *SqlSupplier) Poll() store.PollStore {
+	return ss.stores.poll
+}
+
+func (ss *SqlSupplier) Draft() store.DraftStore {
+	return ss.stores.draft
+}
+
+func (ss *SqlSupplier) ChannelBookmark() store.ChannelBookmarkStore {
+	return ss.stores.channelBookmark
+}
+
+func (ss *SqlSupplier) CustomStatus() store.CustomStatusStore {
+	return ss.stores.customStatus
+}
+
+func (ss *SqlSupplier) EmailQueue() store.EmailQueueStore {
+	return ss.stores.emailQueue
+}
+
+func (ss *SqlSupplier) EmailDigest() store.EmailDigestStore {
+	return ss.stores.emailDigest
+}
+
+func (ss *SqlSupplier) DndSchedule() store.DndScheduleStore {
+	return ss.stores.dndSchedule
+}
+
+func (ss *SqlSupplier) KeywordWatch() store.KeywordWatchStore {
+	return ss.stores.keywordWatch
+}
+
+func (ss *SqlSupplier) SavedSearch() store.SavedSearchStore {
+	return ss.stores.savedSearch
+}
+
+func (ss *SqlSupplier) StorageUsage() store.StorageUsageStore {
+	return ss.stores.storageUsage
+}
+
+func (ss *SqlSupplier) PublicLink() store.PublicLinkStore {
+	return ss.stores.publicLink
+}
+
 func (ss *SqlSupplier) Command() store.CommandStore {
 	return ss.stores.command
 }
@@ -108,6 +108,33 @@ func (s *SqlReactionStore) BulkGetForPosts(postIds []string) ([]*model.Reaction,
 	return reactions, nil
 }
 
+// GetPostIdsForUsers returns the ids of the posts that any of the users
+// reacted to, the most recently reacted first.
+func (s *SqlReactionStore) GetPostIdsForUsers(userIds []string, limit int) ([]string, error) {
+	if len(userIds) == 0 {
+		return []string{}, nil
+	}
+
+	keys, params := MapStringsToQueryParams(userIds, "userId")
+	params["Limit"] = limit
+	var postIds []string
+
+	if _, err := s.GetReplica().Select(&postIds, `SELECT
+				PostId
+			FROM
+				Reactions
+			WHERE
+				UserId IN `+keys+`
+			GROUP BY
+				PostId
+			ORDER BY
+				MAX(CreateAt) DESC
+			LIMIT :Limit`, params); err != nil {
+		return nil, errors.Wrap(err, "failed to get the PostIds of Reactions")
+	}
+	return postIds, nil
+}
+
 func (s *SqlReactionStore) DeleteAllWithEmojiName(emojiName string) error {
 	var reactions []*model.Reaction
 
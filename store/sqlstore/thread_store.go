@@ -337,3 +337,37 @@ func (s *SqlThreadStore) UpdateUnreadsByChannel(userId string, changedThreads []
 
 	return nil
 }
+
+// GetUnreadThreadCountsByTeam returns how many of the threads followed by a
+// user have replies they haven't read, keyed by team id. Threads in direct and
+// group messages are counted under an empty team id.
+func (s *SqlThreadStore) GetUnreadThreadCountsByTeam(userId string) (map[string]int64, error) {
+	query, args, _ := s.getQueryBuilder().
+		Select("Channels.TeamId, COUNT(ThreadMemberships.PostId) AS Count").
+		From("ThreadMemberships").
+		Join("Threads ON Threads.PostId = ThreadMemberships.PostId").
+		Join("Channels ON Channels.Id = Threads.ChannelId").
+		Where(sq.And{
+			sq.Eq{"ThreadMemberships.UserId": userId},
+			sq.Eq{"ThreadMemberships.Following": true},
+			sq.Eq{"Channels.DeleteAt": 0},
+			sq.Expr("Threads.LastReplyAt > ThreadMemberships.LastViewed"),
+		}).
+		GroupBy("Channels.TeamId").
+		ToSql()
+
+	var rows []struct {
+		TeamId string
+		Count  int64
+	}
+	if _, err := s.GetReplica().Select(&rows, query, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to count unread threads for user id=%s", userId)
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.TeamId] = row.Count
+	}
+
+	return counts, nil
+}
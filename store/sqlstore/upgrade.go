@@ -945,9 +945,22 @@ func upgradeDatabaseToVersion530(sqlSupplier *SqlSupplier) {
 	// if shouldPerformUpgrade(sqlSupplier, VERSION_5_29_0, VERSION_5_30_0) {
 
 	sqlSupplier.CreateColumnIfNotExistsNoDefault("FileInfo", "Content", "longtext", "text")
+	sqlSupplier.CreateColumnIfNotExists("FileInfo", "ContentHash", "varchar(64)", "varchar(64)", "")
+	sqlSupplier.CreateColumnIfNotExists("FileInfo", "TeamId", "varchar(26)", "varchar(26)", "")
+	sqlSupplier.CreateColumnIfNotExists("FileInfo", "StrippedMetadata", "varchar(128)", "varchar(128)", "[]")
+
+	// The sessions created before are considered last updated when created.
+	if sqlSupplier.CreateColumnIfNotExists("UploadSessions", "UpdateAt", "bigint(20)", "bigint", "0") {
+		if _, err := sqlSupplier.GetMaster().Exec("UPDATE UploadSessions SET UpdateAt = CreateAt WHERE UpdateAt = 0"); err != nil {
+			mlog.Error("Error setting UpdateAt of upload sessions", mlog.Err(err))
+		}
+	}
 
 	sqlSupplier.CreateColumnIfNotExists("SidebarCategories", "Muted", "tinyint(1)", "boolean", "0")
 
+	sqlSupplier.CreateColumnIfNotExists("Status", "DNDEndTime", "bigint(20)", "bigint", "0")
+	sqlSupplier.CreateColumnIfNotExists("Status", "PrevStatus", "varchar(32)", "varchar(32)", "")
+
 	// 	saveSchemaVersion(sqlSupplier, VERSION_5_30_0)
 	// }
 }
@@ -37,6 +37,7 @@ func newSqlUploadSessionStore(sqlSupplier *SqlSupplier) store.UploadSessionStore
 func (us SqlUploadSessionStore) createIndexesIfNotExists() {
 	us.CreateIndexIfNotExists("idx_uploadsessions_user_id", "UploadSessions", "Type")
 	us.CreateIndexIfNotExists("idx_uploadsessions_create_at", "UploadSessions", "CreateAt")
+	us.CreateIndexIfNotExists("idx_uploadsessions_update_at", "UploadSessions", "UpdateAt")
 	us.CreateIndexIfNotExists("idx_uploadsessions_user_id", "UploadSessions", "UserId")
 }
 
@@ -112,6 +113,45 @@ func (us SqlUploadSessionStore) GetForUser(userId string) ([]*model.UploadSessio
 	return sessions, nil
 }
 
+// GetUpdatedBefore returns up to limit sessions that received no data since
+// the given time, the least recently updated first.
+func (us SqlUploadSessionStore) GetUpdatedBefore(updateAt int64, limit int) ([]*model.UploadSession, error) {
+	query := us.getQueryBuilder().
+		Select("*").
+		From("UploadSessions").
+		Where(sq.Lt{"UpdateAt": updateAt}).
+		OrderBy("UpdateAt ASC").
+		Limit(uint64(limit))
+	queryString, args, err := query.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "SqlUploadSessionStore.GetUpdatedBefore: failed to build query")
+	}
+	var sessions []*model.UploadSession
+	if _, err := us.GetReplica().Select(&sessions, queryString, args...); err != nil {
+		return nil, errors.Wrap(err, "SqlUploadSessionStore.GetUpdatedBefore: failed to select")
+	}
+	return sessions, nil
+}
+
+// GetAllPage returns a page of all the sessions, the oldest first.
+func (us SqlUploadSessionStore) GetAllPage(offset, limit int) ([]*model.UploadSession, error) {
+	query := us.getQueryBuilder().
+		Select("*").
+		From("UploadSessions").
+		OrderBy("CreateAt ASC", "Id ASC").
+		Offset(uint64(offset)).
+		Limit(uint64(limit))
+	queryString, args, err := query.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "SqlUploadSessionStore.GetAllPage: failed to build query")
+	}
+	var sessions []*model.UploadSession
+	if _, err := us.GetReplica().Select(&sessions, queryString, args...); err != nil {
+		return nil, errors.Wrap(err, "SqlUploadSessionStore.GetAllPage: failed to select")
+	}
+	return sessions, nil
+}
+
 func (us SqlUploadSessionStore) Delete(id string) error {
 	if !model.IsValidId(id) {
 		return errors.New("SqlUploadSessionStore.Delete: id is not valid")
@@ -54,6 +54,17 @@ type Store interface {
 	Group() GroupStore
 	Whitelist() WhitelistStore
 	Invite() InviteStore
+	Poll() PollStore
+	Draft() DraftStore
+	ChannelBookmark() ChannelBookmarkStore
+	CustomStatus() CustomStatusStore
+	EmailQueue() EmailQueueStore
+	EmailDigest() EmailDigestStore
+	DndSchedule() DndScheduleStore
+	KeywordWatch() KeywordWatchStore
+	SavedSearch() SavedSearchStore
+	StorageUsage() StorageUsageStore
+	PublicLink() PublicLinkStore
 	UserTermsOfService() UserTermsOfServiceStore
 	LinkMetadata() LinkMetadataStore
 	MarkSystemRanUnitTests()
@@ -267,6 +278,7 @@ type ThreadStore interface {
 	CreateMembershipIfNeeded(userId, postId string, following bool) error
 	CollectThreadsWithNewerReplies(userId string, channelIds []string, timestamp int64) ([]string, error)
 	UpdateUnreadsByChannel(userId string, changedThreads []string, timestamp int64) error
+	GetUnreadThreadCountsByTeam(userId string) (map[string]int64, error)
 }
 
 type PostStore interface {
@@ -525,6 +537,7 @@ type PreferenceStore interface {
 	GetCategory(userId string, category string) (model.Preferences, error)
 	Get(userId string, category string, name string) (*model.Preference, error)
 	GetAll(userId string) (model.Preferences, error)
+	GetForUsers(userIds []string, category string, name string) (model.Preferences, error)
 	Delete(userId, category, name string) error
 	DeleteCategory(userId string, category string) error
 	DeleteCategoryAndName(category string, name string) error
@@ -562,6 +575,7 @@ type StatusStore interface {
 	ResetAll() error
 	GetTotalActiveUsersCount() (int64, error)
 	UpdateLastActivityAt(userId string, lastActivityAt int64) error
+	GetExpiredDND(expiredAt int64, limit int) ([]*model.Status, error)
 }
 
 type FileInfoStore interface {
@@ -579,6 +593,12 @@ type FileInfoStore interface {
 	PermanentDeleteBatch(endTime int64, limit int64) (int64, error)
 	PermanentDeleteByUser(userId string) (int64, error)
 	SetContent(fileId, content string) error
+	SetContentHash(path, contentHash, blobPath string) error
+	CountByContentHash(contentHash string) (int64, error)
+	Quarantine(path, quarantinePath string) error
+	GetFilesBatchForIndexing(startTime, endTime int64, limit int) ([]*model.FileForIndexing, error)
+	GetPostIdsForExtensions(extensions []string, limit int) ([]string, error)
+	Search(paramsList []*model.SearchParams, userId, teamId string, page, perPage int) (*model.FileInfoList, error)
 	ClearCaches()
 }
 
@@ -587,6 +607,8 @@ type UploadSessionStore interface {
 	Update(session *model.UploadSession) error
 	Get(id string) (*model.UploadSession, error)
 	GetForUser(userId string) ([]*model.UploadSession, error)
+	GetUpdatedBefore(updateAt int64, limit int) ([]*model.UploadSession, error)
+	GetAllPage(offset, limit int) ([]*model.UploadSession, error)
 	Delete(id string) error
 }
 
@@ -597,6 +619,7 @@ type ReactionStore interface {
 	DeleteAllWithEmojiName(emojiName string) error
 	PermanentDeleteBatch(endTime int64, limit int64) (int64, error)
 	BulkGetForPosts(postIds []string) ([]*model.Reaction, error)
+	GetPostIdsForUsers(userIds []string, limit int) ([]string, error)
 }
 
 type JobStore interface {
@@ -703,6 +726,110 @@ type InviteStore interface {
 	GetTeamId(inviteId string) (string, error)
 }
 
+type PollStore interface {
+	Save(poll *model.Poll) (*model.Poll, error)
+	Update(poll *model.Poll) (*model.Poll, error)
+	Get(id string) (*model.Poll, error)
+	GetForPosts(postIds []string) ([]*model.Poll, error)
+	GetPollsToClose(now int64, limit int) ([]*model.Poll, error)
+	SaveVotes(pollId string, userId string, votes []*model.PollVote) (*model.Post, error)
+	UpdateTally(pollId string) (*model.Post, error)
+	GetVotes(pollId string) ([]*model.PollVote, error)
+}
+
+type DraftStore interface {
+	Save(draft *model.Draft) (*model.Draft, error)
+	Get(userId, channelId, rootId string) (*model.Draft, error)
+	Delete(userId, channelId, rootId string) error
+	GetDraftsForUser(userId, teamId string) ([]*model.Draft, error)
+}
+
+type ChannelBookmarkStore interface {
+	Save(bookmark *model.ChannelBookmark) (*model.ChannelBookmark, error)
+	Update(bookmark *model.ChannelBookmark) error
+	Get(bookmarkId string, includeDeleted bool) (*model.ChannelBookmark, error)
+	GetBookmarksForFile(fileId string) ([]*model.ChannelBookmark, error)
+	GetBookmarksForChannel(channelId string) ([]*model.ChannelBookmark, error)
+	UpdateSortOrder(bookmarkId, channelId string, newIndex int64) ([]*model.ChannelBookmark, error)
+	Delete(bookmarkId string) error
+	GetAllForExport(limit int, afterId string) ([]*model.ChannelBookmarkForExport, error)
+}
+
+type CustomStatusStore interface {
+	Save(customStatus *model.CustomStatus) (*model.CustomStatus, error)
+	Get(userId string) (*model.CustomStatus, error)
+	GetByUserIds(userIds []string) ([]*model.CustomStatus, error)
+	GetExpired(expiredAt int64, limit int) ([]*model.CustomStatus, error)
+	Delete(userId string) error
+}
+
+type EmailQueueStore interface {
+	Save(email *model.QueuedEmail) (*model.QueuedEmail, error)
+	Update(email *model.QueuedEmail) (*model.QueuedEmail, error)
+	Get(id string) (*model.QueuedEmail, error)
+	GetReadyToSend(now int64, limit int) ([]*model.QueuedEmail, error)
+	GetAll(status string, offset, limit int) ([]*model.QueuedEmail, error)
+	PermanentDeleteSentBefore(before int64, limit int64) (int64, error)
+}
+
+type EmailDigestStore interface {
+	Save(notification *model.PendingEmailNotification) (*model.PendingEmailNotification, error)
+	GetForUser(userId string) ([]*model.PendingEmailNotification, error)
+	GetUserIdsWithPending(afterUserId string, limit int) ([]string, error)
+	Delete(ids []string) error
+	DeleteForUser(userId string) error
+}
+
+type DndScheduleStore interface {
+	Save(schedule *model.DndSchedule) (*model.DndSchedule, error)
+	Get(userId string) (*model.DndSchedule, error)
+	GetAll(afterUserId string, limit int) ([]*model.DndSchedule, error)
+	Delete(userId string) error
+}
+
+type KeywordWatchStore interface {
+	Save(watch *model.KeywordWatch) (*model.KeywordWatch, error)
+	Update(watch *model.KeywordWatch) (*model.KeywordWatch, error)
+	Get(id string) (*model.KeywordWatch, error)
+	GetForUser(userId string) ([]*model.KeywordWatch, error)
+	GetForTeam(teamId string) ([]*model.KeywordWatch, error)
+	Delete(id string) error
+	// UpdateLastAlertAt sets when the last alert of the watch was sent unless
+	// another alert was sent after since, and reports whether it did.
+	UpdateLastAlertAt(id string, alertAt, since int64) (bool, error)
+}
+
+type SavedSearchStore interface {
+	Save(search *model.SavedSearch) (*model.SavedSearch, error)
+	Update(search *model.SavedSearch) (*model.SavedSearch, error)
+	Get(id string) (*model.SavedSearch, error)
+	GetForUser(userId string) ([]*model.SavedSearch, error)
+	// GetForNotification returns the saved searches that notify their users
+	// about new matches, ordered by id, starting after afterId.
+	GetForNotification(afterId string, limit int) ([]*model.SavedSearch, error)
+	Delete(id string) error
+	UpdateLastCheckedAt(id string, checkedAt int64) error
+}
+
+type StorageUsageStore interface {
+	Get(targetType, targetId string) (*model.StorageUsage, error)
+	GetAll(targetType string, offset, limit int) ([]*model.StorageUsage, error)
+	// Recompute rebuilds the storage usage of all the users and teams from
+	// their files.
+	Recompute() error
+}
+
+type PublicLinkStore interface {
+	Save(link *model.PublicLink) (*model.PublicLink, error)
+	Get(id string) (*model.PublicLink, error)
+	// GetActive returns the most recent link to the file created by the user
+	// that is still active at the given time.
+	GetActive(fileId, creatorId string, now int64) (*model.PublicLink, error)
+	GetForUser(userId string, offset, limit int) ([]*model.PublicLink, error)
+	Revoke(id string, revokeAt int64) error
+	RecordDownload(id string, downloadAt int64) error
+}
+
 type GroupStore interface {
 	Create(group *model.Group) (*model.Group, error)
 	Get(groupID string) (*model.Group, error)
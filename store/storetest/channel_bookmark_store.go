@@ -0,0 +1,272 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/zacmm/zacmm-server/model"
+	"github.com/zacmm/zacmm-server/store"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChannelBookmarkStore(t *testing.T, ss store.Store) {
+	t.Run("ChannelBookmarkStoreSaveGet", func(t *testing.T) { testChannelBookmarkStoreSaveGet(t, ss) })
+	t.Run("ChannelBookmarkStoreUpdate", func(t *testing.T) { testChannelBookmarkStoreUpdate(t, ss) })
+	t.Run("ChannelBookmarkStoreUpdateSortOrder", func(t *testing.T) { testChannelBookmarkStoreUpdateSortOrder(t, ss) })
+	t.Run("ChannelBookmarkStoreDelete", func(t *testing.T) { testChannelBookmarkStoreDelete(t, ss) })
+	t.Run("ChannelBookmarkStoreGetAllForExport", func(t *testing.T) { testChannelBookmarkStoreGetAllForExport(t, ss) })
+}
+
+func testChannelBookmarkStoreSaveGet(t *testing.T, ss store.Store) {
+	channelId := model.NewId()
+	ownerId := model.NewId()
+
+	first, err := ss.ChannelBookmark().Save(&model.ChannelBookmark{
+		ChannelId:   channelId,
+		OwnerId:     ownerId,
+		DisplayName: "first",
+		Type:        model.CHANNEL_BOOKMARK_LINK,
+		LinkUrl:     "https://example.com/first",
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(0), first.SortOrder)
+
+	second, err := ss.ChannelBookmark().Save(&model.ChannelBookmark{
+		ChannelId:   channelId,
+		OwnerId:     ownerId,
+		DisplayName: "second",
+		Type:        model.CHANNEL_BOOKMARK_FILE,
+		FileId:      model.NewId(),
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), second.SortOrder)
+
+	t.Run("getting non-existing bookmark should fail", func(t *testing.T) {
+		_, err := ss.ChannelBookmark().Get(model.NewId(), true)
+		require.Error(t, err)
+		require.IsType(t, &store.ErrNotFound{}, err)
+	})
+
+	t.Run("get bookmark", func(t *testing.T) {
+		received, err := ss.ChannelBookmark().Get(second.Id, false)
+		require.NoError(t, err)
+		require.Equal(t, second, received)
+	})
+
+	t.Run("get bookmarks for file", func(t *testing.T) {
+		other, err := ss.ChannelBookmark().Save(&model.ChannelBookmark{
+			ChannelId:   model.NewId(),
+			OwnerId:     ownerId,
+			DisplayName: "other",
+			Type:        model.CHANNEL_BOOKMARK_FILE,
+			FileId:      second.FileId,
+		})
+		require.NoError(t, err)
+
+		bookmarks, err := ss.ChannelBookmark().GetBookmarksForFile(second.FileId)
+		require.NoError(t, err)
+		require.Len(t, bookmarks, 2)
+		require.ElementsMatch(t, []string{second.Id, other.Id}, []string{bookmarks[0].Id, bookmarks[1].Id})
+
+		bookmarks, err = ss.ChannelBookmark().GetBookmarksForFile(model.NewId())
+		require.NoError(t, err)
+		require.Empty(t, bookmarks)
+	})
+
+	t.Run("get bookmarks for channel in order", func(t *testing.T) {
+		bookmarks, err := ss.ChannelBookmark().GetBookmarksForChannel(channelId)
+		require.NoError(t, err)
+		require.Len(t, bookmarks, 2)
+		require.Equal(t, first.Id, bookmarks[0].Id)
+		require.Equal(t, second.Id, bookmarks[1].Id)
+
+		bookmarks, err = ss.ChannelBookmark().GetBookmarksForChannel(model.NewId())
+		require.NoError(t, err)
+		require.Empty(t, bookmarks)
+	})
+}
+
+func testChannelBookmarkStoreUpdate(t *testing.T, ss store.Store) {
+	bookmark, err := ss.ChannelBookmark().Save(&model.ChannelBookmark{
+		ChannelId:   model.NewId(),
+		OwnerId:     model.NewId(),
+		DisplayName: "docs",
+		Type:        model.CHANNEL_BOOKMARK_LINK,
+		LinkUrl:     "https://example.com/docs",
+	})
+	require.NoError(t, err)
+
+	bookmark.DisplayName = "handbook"
+	bookmark.Emoji = "book"
+	require.NoError(t, ss.ChannelBookmark().Update(bookmark))
+
+	received, err := ss.ChannelBookmark().Get(bookmark.Id, false)
+	require.NoError(t, err)
+	require.Equal(t, "handbook", received.DisplayName)
+	require.Equal(t, "book", received.Emoji)
+
+	t.Run("updating invalid bookmark should fail", func(t *testing.T) {
+		bookmark.LinkUrl = ""
+		require.Error(t, ss.ChannelBookmark().Update(bookmark))
+	})
+
+	t.Run("updating non-existing bookmark should fail", func(t *testing.T) {
+		missing := &model.ChannelBookmark{
+			ChannelId:   model.NewId(),
+			OwnerId:     model.NewId(),
+			DisplayName: "missing",
+			Type:        model.CHANNEL_BOOKMARK_LINK,
+			LinkUrl:     "https://example.com/missing",
+		}
+		missing.PreSave()
+		err := ss.ChannelBookmark().Update(missing)
+		require.Error(t, err)
+		require.IsType(t, &store.ErrNotFound{}, err)
+	})
+}
+
+func testChannelBookmarkStoreUpdateSortOrder(t *testing.T, ss store.Store) {
+	channelId := model.NewId()
+	ownerId := model.NewId()
+
+	var ids []string
+	for _, name := range []string{"a", "b", "c", "d"} {
+		bookmark, err := ss.ChannelBookmark().Save(&model.ChannelBookmark{
+			ChannelId:   channelId,
+			OwnerId:     ownerId,
+			DisplayName: name,
+			Type:        model.CHANNEL_BOOKMARK_LINK,
+			LinkUrl:     "https://example.com/" + name,
+		})
+		require.NoError(t, err)
+		ids = append(ids, bookmark.Id)
+	}
+
+	orderOf := func(bookmarks []*model.ChannelBookmark) []string {
+		var order []string
+		for i, bookmark := range bookmarks {
+			require.Equal(t, int64(i), bookmark.SortOrder)
+			order = append(order, bookmark.Id)
+		}
+		return order
+	}
+
+	bookmarks, err := ss.ChannelBookmark().UpdateSortOrder(ids[3], channelId, 1)
+	require.NoError(t, err)
+	require.Equal(t, []string{ids[0], ids[3], ids[1], ids[2]}, orderOf(bookmarks))
+
+	bookmarks, err = ss.ChannelBookmark().UpdateSortOrder(ids[0], channelId, 3)
+	require.NoError(t, err)
+	require.Equal(t, []string{ids[3], ids[1], ids[2], ids[0]}, orderOf(bookmarks))
+
+	bookmarks, err = ss.ChannelBookmark().GetBookmarksForChannel(channelId)
+	require.NoError(t, err)
+	require.Equal(t, []string{ids[3], ids[1], ids[2], ids[0]}, orderOf(bookmarks))
+
+	t.Run("out of range index should fail", func(t *testing.T) {
+		_, err := ss.ChannelBookmark().UpdateSortOrder(ids[0], channelId, 4)
+		require.Error(t, err)
+		require.IsType(t, &store.ErrInvalidInput{}, err)
+
+		_, err = ss.ChannelBookmark().UpdateSortOrder(ids[0], channelId, -1)
+		require.Error(t, err)
+	})
+
+	t.Run("bookmark from another channel should fail", func(t *testing.T) {
+		_, err := ss.ChannelBookmark().UpdateSortOrder(ids[0], model.NewId(), 0)
+		require.Error(t, err)
+		require.IsType(t, &store.ErrNotFound{}, err)
+	})
+}
+
+func testChannelBookmarkStoreDelete(t *testing.T, ss store.Store) {
+	channelId := model.NewId()
+	bookmark, err := ss.ChannelBookmark().Save(&model.ChannelBookmark{
+		ChannelId:   channelId,
+		OwnerId:     model.NewId(),
+		DisplayName: "docs",
+		Type:        model.CHANNEL_BOOKMARK_LINK,
+		LinkUrl:     "https://example.com/docs",
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, ss.ChannelBookmark().Delete(bookmark.Id))
+
+	_, err = ss.ChannelBookmark().Get(bookmark.Id, false)
+	require.IsType(t, &store.ErrNotFound{}, err)
+
+	deleted, err := ss.ChannelBookmark().Get(bookmark.Id, true)
+	require.NoError(t, err)
+	require.NotZero(t, deleted.DeleteAt)
+
+	bookmarks, err := ss.ChannelBookmark().GetBookmarksForChannel(channelId)
+	require.NoError(t, err)
+	require.Empty(t, bookmarks)
+
+	t.Run("deleting twice should fail", func(t *testing.T) {
+		err := ss.ChannelBookmark().Delete(bookmark.Id)
+		require.IsType(t, &store.ErrNotFound{}, err)
+	})
+}
+
+func testChannelBookmarkStoreGetAllForExport(t *testing.T, ss store.Store) {
+	team, err := ss.Team().Save(&model.Team{
+		DisplayName: "Name",
+		Name:        "zz" + model.NewId(),
+		Email:       MakeEmail(),
+		Type:        model.TEAM_OPEN,
+	})
+	require.NoError(t, err)
+
+	channel, err := ss.Channel().Save(&model.Channel{
+		TeamId:      team.Id,
+		DisplayName: "Channel",
+		Name:        "zz" + model.NewId() + "b",
+		Type:        model.CHANNEL_OPEN,
+	}, -1)
+	require.NoError(t, err)
+
+	user, err := ss.User().Save(&model.User{
+		Email:    MakeEmail(),
+		Username: "u" + model.NewId(),
+	})
+	require.NoError(t, err)
+
+	bookmark, err := ss.ChannelBookmark().Save(&model.ChannelBookmark{
+		ChannelId:   channel.Id,
+		OwnerId:     user.Id,
+		DisplayName: "docs",
+		Type:        model.CHANNEL_BOOKMARK_LINK,
+		LinkUrl:     "https://example.com/docs",
+	})
+	require.NoError(t, err)
+
+	deleted, err := ss.ChannelBookmark().Save(&model.ChannelBookmark{
+		ChannelId:   channel.Id,
+		OwnerId:     user.Id,
+		DisplayName: "deleted",
+		Type:        model.CHANNEL_BOOKMARK_LINK,
+		LinkUrl:     "https://example.com/deleted",
+	})
+	require.NoError(t, err)
+	require.NoError(t, ss.ChannelBookmark().Delete(deleted.Id))
+
+	bookmarks, err := ss.ChannelBookmark().GetAllForExport(10000, strings.Repeat("0", 26))
+	require.NoError(t, err)
+
+	var found *model.ChannelBookmarkForExport
+	for _, b := range bookmarks {
+		require.NotEqual(t, deleted.Id, b.Id)
+		if b.Id == bookmark.Id {
+			found = b
+		}
+	}
+	require.NotNil(t, found)
+	require.Equal(t, team.Name, found.TeamName)
+	require.Equal(t, channel.Name, found.ChannelName)
+	require.Equal(t, user.Username, found.Username)
+}
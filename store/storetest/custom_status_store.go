@@ -0,0 +1,111 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"testing"
+
+	"github.com/zacmm/zacmm-server/model"
+	"github.com/zacmm/zacmm-server/store"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCustomStatusStore(t *testing.T, ss store.Store) {
+	t.Run("CustomStatusStoreSaveGet", func(t *testing.T) { testCustomStatusStoreSaveGet(t, ss) })
+	t.Run("CustomStatusStoreGetByUserIds", func(t *testing.T) { testCustomStatusStoreGetByUserIds(t, ss) })
+	t.Run("CustomStatusStoreGetExpired", func(t *testing.T) { testCustomStatusStoreGetExpired(t, ss) })
+	t.Run("CustomStatusStoreDelete", func(t *testing.T) { testCustomStatusStoreDelete(t, ss) })
+}
+
+func testCustomStatusStoreSaveGet(t *testing.T, ss store.Store) {
+	userId := model.NewId()
+
+	t.Run("saving empty custom status should fail", func(t *testing.T) {
+		customStatus, err := ss.CustomStatus().Save(&model.CustomStatus{UserId: userId})
+		require.Error(t, err)
+		require.Nil(t, customStatus)
+	})
+
+	_, err := ss.CustomStatus().Save(&model.CustomStatus{UserId: userId, Emoji: "palm_tree", Text: "On vacation"})
+	require.NoError(t, err)
+
+	received, err := ss.CustomStatus().Get(userId)
+	require.NoError(t, err)
+	require.Equal(t, "palm_tree", received.Emoji)
+	require.Equal(t, "On vacation", received.Text)
+
+	t.Run("saving again should replace it", func(t *testing.T) {
+		_, err := ss.CustomStatus().Save(&model.CustomStatus{UserId: userId, Emoji: "calendar", Text: "In a meeting"})
+		require.NoError(t, err)
+
+		received, err := ss.CustomStatus().Get(userId)
+		require.NoError(t, err)
+		require.Equal(t, "calendar", received.Emoji)
+		require.Equal(t, "In a meeting", received.Text)
+	})
+
+	t.Run("getting non-existing custom status should fail", func(t *testing.T) {
+		_, err := ss.CustomStatus().Get(model.NewId())
+		require.Error(t, err)
+		require.IsType(t, &store.ErrNotFound{}, err)
+	})
+}
+
+func testCustomStatusStoreGetByUserIds(t *testing.T, ss store.Store) {
+	userId1 := model.NewId()
+	userId2 := model.NewId()
+
+	_, err := ss.CustomStatus().Save(&model.CustomStatus{UserId: userId1, Text: "first"})
+	require.NoError(t, err)
+	_, err = ss.CustomStatus().Save(&model.CustomStatus{UserId: userId2, Text: "second"})
+	require.NoError(t, err)
+
+	customStatuses, err := ss.CustomStatus().GetByUserIds([]string{userId1, userId2, model.NewId()})
+	require.NoError(t, err)
+	require.Len(t, customStatuses, 2)
+
+	customStatuses, err = ss.CustomStatus().GetByUserIds([]string{})
+	require.NoError(t, err)
+	require.Empty(t, customStatuses)
+}
+
+func testCustomStatusStoreGetExpired(t *testing.T, ss store.Store) {
+	now := model.GetMillis()
+	expiredId := model.NewId()
+	futureId := model.NewId()
+	neverId := model.NewId()
+
+	_, err := ss.CustomStatus().Save(&model.CustomStatus{UserId: expiredId, Text: "expired", ExpiresAt: now - 1000})
+	require.NoError(t, err)
+	_, err = ss.CustomStatus().Save(&model.CustomStatus{UserId: futureId, Text: "future", ExpiresAt: now + 60000})
+	require.NoError(t, err)
+	_, err = ss.CustomStatus().Save(&model.CustomStatus{UserId: neverId, Text: "never"})
+	require.NoError(t, err)
+
+	customStatuses, err := ss.CustomStatus().GetExpired(now, 1000)
+	require.NoError(t, err)
+
+	var userIds []string
+	for _, customStatus := range customStatuses {
+		userIds = append(userIds, customStatus.UserId)
+	}
+	require.Contains(t, userIds, expiredId)
+	require.NotContains(t, userIds, futureId)
+	require.NotContains(t, userIds, neverId)
+}
+
+func testCustomStatusStoreDelete(t *testing.T, ss store.Store) {
+	userId := model.NewId()
+	_, err := ss.CustomStatus().Save(&model.CustomStatus{UserId: userId, Text: "busy"})
+	require.NoError(t, err)
+
+	require.NoError(t, ss.CustomStatus().Delete(userId))
+
+	_, err = ss.CustomStatus().Get(userId)
+	require.IsType(t, &store.ErrNotFound{}, err)
+
+	err = ss.CustomStatus().Delete(userId)
+	require.IsType(t, &store.ErrNotFound{}, err)
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"testing"
+
+	"github.com/zacmm/zacmm-server/model"
+	"github.com/zacmm/zacmm-server/store"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDndScheduleStore(t *testing.T, ss store.Store) {
+	t.Run("DndScheduleStoreSaveGet", func(t *testing.T) { testDndScheduleStoreSaveGet(t, ss) })
+	t.Run("DndScheduleStoreGetAll", func(t *testing.T) { testDndScheduleStoreGetAll(t, ss) })
+	t.Run("DndScheduleStoreDelete", func(t *testing.T) { testDndScheduleStoreDelete(t, ss) })
+}
+
+func testDndScheduleStoreSaveGet(t *testing.T, ss store.Store) {
+	userId := model.NewId()
+	onCallChannelId := model.NewId()
+
+	schedule, err := ss.DndSchedule().Save(&model.DndSchedule{
+		UserId:           userId,
+		Days:             model.StringArray{"monday", "tuesday", "wednesday", "thursday", "friday"},
+		StartTime:        "19:00",
+		EndTime:          "08:00",
+		ExemptChannelIds: model.StringArray{onCallChannelId},
+		AllowUrgent:      true,
+	})
+	require.NoError(t, err)
+
+	received, err := ss.DndSchedule().Get(userId)
+	require.NoError(t, err)
+	require.Equal(t, schedule.Days, received.Days)
+	require.Equal(t, "19:00", received.StartTime)
+	require.Equal(t, "08:00", received.EndTime)
+	require.Equal(t, model.StringArray{onCallChannelId}, received.ExemptChannelIds)
+	require.True(t, received.AllowUrgent)
+
+	t.Run("saving again should replace it", func(t *testing.T) {
+		_, err := ss.DndSchedule().Save(&model.DndSchedule{
+			UserId:      userId,
+			Days:        model.StringArray{"saturday"},
+			StartTime:   "19:00",
+			EndTime:     "08:00",
+			ActiveUntil: 1234,
+		})
+		require.NoError(t, err)
+
+		received, err := ss.DndSchedule().Get(userId)
+		require.NoError(t, err)
+		require.Equal(t, model.StringArray{"saturday"}, received.Days)
+		require.Empty(t, received.ExemptChannelIds)
+		require.False(t, received.AllowUrgent)
+		require.Equal(t, int64(1234), received.ActiveUntil)
+	})
+
+	t.Run("getting non-existing schedule should fail", func(t *testing.T) {
+		_, err := ss.DndSchedule().Get(model.NewId())
+		require.Error(t, err)
+		require.IsType(t, &store.ErrNotFound{}, err)
+	})
+}
+
+func testDndScheduleStoreGetAll(t *testing.T, ss store.Store) {
+	userIds := map[string]bool{}
+	for i := 0; i < 3; i++ {
+		userId := model.NewId()
+		_, err := ss.DndSchedule().Save(&model.DndSchedule{
+			UserId:    userId,
+			Days:      model.StringArray{"monday"},
+			StartTime: "19:00",
+			EndTime:   "08:00",
+		})
+		require.NoError(t, err)
+		userIds[userId] = true
+	}
+
+	afterUserId := ""
+	for {
+		schedules, err := ss.DndSchedule().GetAll(afterUserId, 2)
+		require.NoError(t, err)
+		require.LessOrEqual(t, len(schedules), 2)
+
+		for _, schedule := range schedules {
+			require.Greater(t, schedule.UserId, afterUserId)
+			afterUserId = schedule.UserId
+			delete(userIds, schedule.UserId)
+		}
+
+		if len(schedules) < 2 {
+			break
+		}
+	}
+
+	require.Empty(t, userIds, "every schedule should be returned")
+}
+
+func testDndScheduleStoreDelete(t *testing.T, ss store.Store) {
+	userId := model.NewId()
+
+	_, err := ss.DndSchedule().Save(&model.DndSchedule{
+		UserId:    userId,
+		Days:      model.StringArray{"sunday"},
+		StartTime: "00:00",
+		EndTime:   "23:59",
+	})
+	require.NoError(t, err)
+
+	err = ss.DndSchedule().Delete(userId)
+	require.NoError(t, err)
+
+	_, err = ss.DndSchedule().Get(userId)
+	require.IsType(t, &store.ErrNotFound{}, err)
+
+	t.Run("deleting non-existing schedule should fail", func(t *testing.T) {
+		err := ss.DndSchedule().Delete(userId)
+		require.IsType(t, &store.ErrNotFound{}, err)
+	})
+}
@@ -0,0 +1,150 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/zacmm/zacmm-server/model"
+	"github.com/zacmm/zacmm-server/store"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDraftStore(t *testing.T, ss store.Store) {
+	t.Run("DraftStoreSaveGet", func(t *testing.T) { testDraftStoreSaveGet(t, ss) })
+	t.Run("DraftStoreDelete", func(t *testing.T) { testDraftStoreDelete(t, ss) })
+	t.Run("DraftStoreGetDraftsForUser", func(t *testing.T) { testDraftStoreGetDraftsForUser(t, ss) })
+}
+
+func testDraftStoreSaveGet(t *testing.T, ss store.Store) {
+	userId := model.NewId()
+	channelId := model.NewId()
+	rootId := model.NewId()
+
+	t.Run("saving invalid draft should fail", func(t *testing.T) {
+		draft, err := ss.Draft().Save(&model.Draft{UserId: userId})
+		require.Error(t, err)
+		require.Nil(t, draft)
+	})
+
+	draft, err := ss.Draft().Save(&model.Draft{UserId: userId, ChannelId: channelId, Message: "channel draft"})
+	require.NoError(t, err)
+	require.NotZero(t, draft.CreateAt)
+
+	_, err = ss.Draft().Save(&model.Draft{UserId: userId, ChannelId: channelId, RootId: rootId, Message: "thread draft"})
+	require.NoError(t, err)
+
+	t.Run("getting non-existing draft should fail", func(t *testing.T) {
+		_, err := ss.Draft().Get(model.NewId(), channelId, "")
+		require.Error(t, err)
+		require.IsType(t, &store.ErrNotFound{}, err)
+	})
+
+	t.Run("drafts are keyed by channel and thread", func(t *testing.T) {
+		received, err := ss.Draft().Get(userId, channelId, "")
+		require.NoError(t, err)
+		require.Equal(t, "channel draft", received.Message)
+
+		received, err = ss.Draft().Get(userId, channelId, rootId)
+		require.NoError(t, err)
+		require.Equal(t, "thread draft", received.Message)
+	})
+
+	t.Run("saving existing draft should replace it", func(t *testing.T) {
+		updated, err := ss.Draft().Save(&model.Draft{UserId: userId, ChannelId: channelId, Message: "updated draft"})
+		require.NoError(t, err)
+		require.Equal(t, draft.CreateAt, updated.CreateAt)
+
+		received, err := ss.Draft().Get(userId, channelId, "")
+		require.NoError(t, err)
+		require.Equal(t, "updated draft", received.Message)
+	})
+
+	t.Run("props and file ids are saved", func(t *testing.T) {
+		fileId := model.NewId()
+		_, err := ss.Draft().Save(&model.Draft{UserId: userId, ChannelId: channelId, Message: "with file", Props: model.StringInterface{"key": "value"}, FileIds: model.StringArray{fileId}})
+		require.NoError(t, err)
+
+		received, err := ss.Draft().Get(userId, channelId, "")
+		require.NoError(t, err)
+		require.Equal(t, "value", received.Props["key"])
+		require.Equal(t, model.StringArray{fileId}, received.FileIds)
+	})
+
+	t.Run("concurrent saves of a new draft should not fail", func(t *testing.T) {
+		newChannelId := model.NewId()
+
+		var wg sync.WaitGroup
+		errs := make(chan error, 10)
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				_, err := ss.Draft().Save(&model.Draft{UserId: userId, ChannelId: newChannelId, Message: fmt.Sprintf("draft %d", i)})
+				errs <- err
+			}(i)
+		}
+		wg.Wait()
+		close(errs)
+
+		for err := range errs {
+			require.NoError(t, err)
+		}
+	})
+}
+
+func testDraftStoreDelete(t *testing.T, ss store.Store) {
+	userId := model.NewId()
+	channelId := model.NewId()
+
+	_, err := ss.Draft().Save(&model.Draft{UserId: userId, ChannelId: channelId, Message: "draft"})
+	require.NoError(t, err)
+
+	err = ss.Draft().Delete(userId, channelId, "")
+	require.NoError(t, err)
+
+	_, err = ss.Draft().Get(userId, channelId, "")
+	require.IsType(t, &store.ErrNotFound{}, err)
+
+	t.Run("deleting non-existing draft should fail", func(t *testing.T) {
+		err := ss.Draft().Delete(userId, channelId, "")
+		require.IsType(t, &store.ErrNotFound{}, err)
+	})
+}
+
+func testDraftStoreGetDraftsForUser(t *testing.T, ss store.Store) {
+	userId := model.NewId()
+	teamId := model.NewId()
+
+	channel, err := ss.Channel().Save(&model.Channel{
+		TeamId:      teamId,
+		DisplayName: "Display " + model.NewId(),
+		Name:        "zz" + model.NewId() + "b",
+		Type:        model.CHANNEL_OPEN,
+	}, -1)
+	require.Nil(t, err)
+
+	otherTeamChannel, err := ss.Channel().Save(&model.Channel{
+		TeamId:      model.NewId(),
+		DisplayName: "Display " + model.NewId(),
+		Name:        "zz" + model.NewId() + "b",
+		Type:        model.CHANNEL_OPEN,
+	}, -1)
+	require.Nil(t, err)
+
+	_, err = ss.Draft().Save(&model.Draft{UserId: userId, ChannelId: channel.Id, Message: "draft"})
+	require.NoError(t, err)
+	_, err = ss.Draft().Save(&model.Draft{UserId: userId, ChannelId: otherTeamChannel.Id, Message: "other team draft"})
+	require.NoError(t, err)
+	_, err = ss.Draft().Save(&model.Draft{UserId: model.NewId(), ChannelId: channel.Id, Message: "other user draft"})
+	require.NoError(t, err)
+
+	drafts, err := ss.Draft().GetDraftsForUser(userId, teamId)
+	require.NoError(t, err)
+	require.Len(t, drafts, 1)
+	require.Equal(t, "draft", drafts[0].Message)
+}
@@ -0,0 +1,146 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"testing"
+
+	"github.com/zacmm/zacmm-server/model"
+	"github.com/zacmm/zacmm-server/store"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmailDigestStore(t *testing.T, ss store.Store) {
+	t.Run("EmailDigestStoreSaveGetForUser", func(t *testing.T) { testEmailDigestStoreSaveGetForUser(t, ss) })
+	t.Run("EmailDigestStoreGetUserIdsWithPending", func(t *testing.T) { testEmailDigestStoreGetUserIdsWithPending(t, ss) })
+	t.Run("EmailDigestStoreDelete", func(t *testing.T) { testEmailDigestStoreDelete(t, ss) })
+}
+
+func testEmailDigestStoreSaveGetForUser(t *testing.T, ss store.Store) {
+	userId := model.NewId()
+
+	second, err := ss.EmailDigest().Save(&model.PendingEmailNotification{
+		UserId:    userId,
+		PostId:    model.NewId(),
+		ChannelId: model.NewId(),
+		TeamId:    model.NewId(),
+		CreateAt:  2000,
+	})
+	require.NoError(t, err)
+	first, err := ss.EmailDigest().Save(&model.PendingEmailNotification{
+		UserId:    userId,
+		PostId:    model.NewId(),
+		ChannelId: model.NewId(),
+		TeamId:    model.NewId(),
+		CreateAt:  1000,
+	})
+	require.NoError(t, err)
+	_, err = ss.EmailDigest().Save(&model.PendingEmailNotification{
+		UserId:    model.NewId(),
+		PostId:    model.NewId(),
+		ChannelId: model.NewId(),
+		TeamId:    model.NewId(),
+		CreateAt:  1000,
+	})
+	require.NoError(t, err)
+	direct, err := ss.EmailDigest().Save(&model.PendingEmailNotification{
+		UserId:    userId,
+		PostId:    model.NewId(),
+		ChannelId: model.NewId(),
+		CreateAt:  3000,
+	})
+	require.NoError(t, err)
+
+	notifications, err := ss.EmailDigest().GetForUser(userId)
+	require.NoError(t, err)
+	require.Equal(t, []*model.PendingEmailNotification{first, second, direct}, notifications)
+}
+
+func testEmailDigestStoreGetUserIdsWithPending(t *testing.T, ss store.Store) {
+	userIds := []string{model.NewId(), model.NewId(), model.NewId()}
+	for _, userId := range userIds {
+		for i := 0; i < 2; i++ {
+			_, err := ss.EmailDigest().Save(&model.PendingEmailNotification{
+				UserId:    userId,
+				PostId:    model.NewId(),
+				ChannelId: model.NewId(),
+				TeamId:    model.NewId(),
+			})
+			require.NoError(t, err)
+		}
+	}
+
+	var found []string
+	afterUserId := ""
+	for {
+		page, err := ss.EmailDigest().GetUserIdsWithPending(afterUserId, 2)
+		require.NoError(t, err)
+		require.True(t, len(page) <= 2)
+
+		for i, userId := range page {
+			require.True(t, userId > afterUserId, "user ids should be returned in order")
+			if i > 0 {
+				require.True(t, userId > page[i-1], "user ids should be returned in order")
+			}
+		}
+		found = append(found, page...)
+
+		if len(page) < 2 {
+			break
+		}
+		afterUserId = page[len(page)-1]
+	}
+
+	for _, userId := range userIds {
+		require.Contains(t, found, userId)
+	}
+}
+
+func testEmailDigestStoreDelete(t *testing.T, ss store.Store) {
+	userId := model.NewId()
+	otherUserId := model.NewId()
+
+	first, err := ss.EmailDigest().Save(&model.PendingEmailNotification{
+		UserId:    userId,
+		PostId:    model.NewId(),
+		ChannelId: model.NewId(),
+		TeamId:    model.NewId(),
+		CreateAt:  1000,
+	})
+	require.NoError(t, err)
+	second, err := ss.EmailDigest().Save(&model.PendingEmailNotification{
+		UserId:    userId,
+		PostId:    model.NewId(),
+		ChannelId: model.NewId(),
+		TeamId:    model.NewId(),
+		CreateAt:  2000,
+	})
+	require.NoError(t, err)
+	_, err = ss.EmailDigest().Save(&model.PendingEmailNotification{
+		UserId:    otherUserId,
+		PostId:    model.NewId(),
+		ChannelId: model.NewId(),
+		TeamId:    model.NewId(),
+		CreateAt:  1000,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, ss.EmailDigest().Delete(nil))
+	require.NoError(t, ss.EmailDigest().Delete([]string{first.Id}))
+
+	notifications, err := ss.EmailDigest().GetForUser(userId)
+	require.NoError(t, err)
+	require.Equal(t, []*model.PendingEmailNotification{second}, notifications)
+
+	require.NoError(t, ss.EmailDigest().DeleteForUser(userId))
+
+	notifications, err = ss.EmailDigest().GetForUser(userId)
+	require.NoError(t, err)
+	require.Empty(t, notifications)
+
+	notifications, err = ss.EmailDigest().GetForUser(otherUserId)
+	require.NoError(t, err)
+	require.Len(t, notifications, 1)
+}
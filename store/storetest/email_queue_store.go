@@ -0,0 +1,140 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"testing"
+
+	"github.com/zacmm/zacmm-server/model"
+	"github.com/zacmm/zacmm-server/store"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmailQueueStore(t *testing.T, ss store.Store) {
+	t.Run("EmailQueueStoreSaveGetUpdate", func(t *testing.T) { testEmailQueueStoreSaveGetUpdate(t, ss) })
+	t.Run("EmailQueueStoreGetReadyToSend", func(t *testing.T) { testEmailQueueStoreGetReadyToSend(t, ss) })
+	t.Run("EmailQueueStoreGetAll", func(t *testing.T) { testEmailQueueStoreGetAll(t, ss) })
+	t.Run("EmailQueueStorePermanentDeleteSentBefore", func(t *testing.T) { testEmailQueueStorePermanentDeleteSentBefore(t, ss) })
+}
+
+func containsQueuedEmail(emails []*model.QueuedEmail, id string) bool {
+	for _, email := range emails {
+		if email.Id == id {
+			return true
+		}
+	}
+	return false
+}
+
+func testEmailQueueStoreSaveGetUpdate(t *testing.T, ss store.Store) {
+	t.Run("saving email without recipient should fail", func(t *testing.T) {
+		email, err := ss.EmailQueue().Save(&model.QueuedEmail{Subject: "Subject"})
+		require.Error(t, err)
+		require.Nil(t, email)
+	})
+
+	t.Run("saving email with an id should fail", func(t *testing.T) {
+		_, err := ss.EmailQueue().Save(&model.QueuedEmail{Id: model.NewId(), Recipient: "test@example.com"})
+		require.Error(t, err)
+		require.IsType(t, &store.ErrInvalidInput{}, err)
+	})
+
+	email, err := ss.EmailQueue().Save(&model.QueuedEmail{Recipient: "test@example.com", Subject: "Subject", HtmlBody: "<p>Body</p>"})
+	require.NoError(t, err)
+	require.NotEmpty(t, email.Id)
+
+	received, err := ss.EmailQueue().Get(email.Id)
+	require.NoError(t, err)
+	require.Equal(t, email, received)
+
+	received.MarkFailed("connection refused", false, 3)
+	_, err = ss.EmailQueue().Update(received)
+	require.NoError(t, err)
+
+	received, err = ss.EmailQueue().Get(email.Id)
+	require.NoError(t, err)
+	require.Equal(t, model.QUEUED_EMAIL_STATUS_PENDING, received.Status)
+	require.Equal(t, 1, received.Attempts)
+	require.Equal(t, "connection refused", received.LastError)
+
+	t.Run("getting non-existing email should fail", func(t *testing.T) {
+		_, err := ss.EmailQueue().Get(model.NewId())
+		require.Error(t, err)
+		require.IsType(t, &store.ErrNotFound{}, err)
+	})
+
+	t.Run("updating non-existing email should fail", func(t *testing.T) {
+		missing := &model.QueuedEmail{Recipient: "test@example.com"}
+		missing.PreSave()
+		_, err := ss.EmailQueue().Update(missing)
+		require.Error(t, err)
+		require.IsType(t, &store.ErrNotFound{}, err)
+	})
+}
+
+func testEmailQueueStoreGetReadyToSend(t *testing.T, ss store.Store) {
+	now := model.GetMillis()
+
+	due, err := ss.EmailQueue().Save(&model.QueuedEmail{Recipient: "due@example.com", NextAttemptAt: now - 1000})
+	require.NoError(t, err)
+
+	later, err := ss.EmailQueue().Save(&model.QueuedEmail{Recipient: "later@example.com", NextAttemptAt: now + 60000})
+	require.NoError(t, err)
+
+	dead, err := ss.EmailQueue().Save(&model.QueuedEmail{Recipient: "dead@example.com", Status: model.QUEUED_EMAIL_STATUS_DEAD, NextAttemptAt: now - 1000})
+	require.NoError(t, err)
+
+	emails, err := ss.EmailQueue().GetReadyToSend(now, 1000)
+	require.NoError(t, err)
+	require.True(t, containsQueuedEmail(emails, due.Id))
+	require.False(t, containsQueuedEmail(emails, later.Id), "emails scheduled for later should not be returned")
+	require.False(t, containsQueuedEmail(emails, dead.Id), "dead-lettered emails should not be returned")
+}
+
+func testEmailQueueStoreGetAll(t *testing.T, ss store.Store) {
+	pending, err := ss.EmailQueue().Save(&model.QueuedEmail{Recipient: "pending@example.com"})
+	require.NoError(t, err)
+
+	dead, err := ss.EmailQueue().Save(&model.QueuedEmail{Recipient: "dead@example.com", Status: model.QUEUED_EMAIL_STATUS_DEAD})
+	require.NoError(t, err)
+
+	emails, err := ss.EmailQueue().GetAll("", 0, 1000)
+	require.NoError(t, err)
+	require.True(t, containsQueuedEmail(emails, pending.Id))
+	require.True(t, containsQueuedEmail(emails, dead.Id))
+
+	emails, err = ss.EmailQueue().GetAll(model.QUEUED_EMAIL_STATUS_DEAD, 0, 1000)
+	require.NoError(t, err)
+	require.False(t, containsQueuedEmail(emails, pending.Id))
+	require.True(t, containsQueuedEmail(emails, dead.Id))
+
+	emails, err = ss.EmailQueue().GetAll("", 0, 1)
+	require.NoError(t, err)
+	require.Len(t, emails, 1)
+}
+
+func testEmailQueueStorePermanentDeleteSentBefore(t *testing.T, ss store.Store) {
+	oldSent, err := ss.EmailQueue().Save(&model.QueuedEmail{Recipient: "old@example.com", Status: model.QUEUED_EMAIL_STATUS_SENT, SentAt: 1000})
+	require.NoError(t, err)
+
+	newSent, err := ss.EmailQueue().Save(&model.QueuedEmail{Recipient: "new@example.com", Status: model.QUEUED_EMAIL_STATUS_SENT, SentAt: model.GetMillis()})
+	require.NoError(t, err)
+
+	pending, err := ss.EmailQueue().Save(&model.QueuedEmail{Recipient: "pending@example.com"})
+	require.NoError(t, err)
+
+	deleted, err := ss.EmailQueue().PermanentDeleteSentBefore(2000, 1000)
+	require.NoError(t, err)
+	require.True(t, deleted >= 1)
+
+	_, err = ss.EmailQueue().Get(oldSent.Id)
+	require.IsType(t, &store.ErrNotFound{}, err)
+
+	_, err = ss.EmailQueue().Get(newSent.Id)
+	require.NoError(t, err)
+
+	_, err = ss.EmailQueue().Get(pending.Id)
+	require.NoError(t, err)
+}
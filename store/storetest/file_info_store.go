@@ -4,6 +4,7 @@
 package storetest
 
 import (
+	"errors"
 	"fmt"
 	"sort"
 	"testing"
@@ -27,6 +28,10 @@ func TestFileInfoStore(t *testing.T, ss store.Store) {
 	t.Run("FileInfoPermanentDelete", func(t *testing.T) { testFileInfoPermanentDelete(t, ss) })
 	t.Run("FileInfoPermanentDeleteBatch", func(t *testing.T) { testFileInfoPermanentDeleteBatch(t, ss) })
 	t.Run("FileInfoPermanentDeleteByUser", func(t *testing.T) { testFileInfoPermanentDeleteByUser(t, ss) })
+	t.Run("FileInfoSetContentHash", func(t *testing.T) { testFileInfoSetContentHash(t, ss) })
+	t.Run("FileInfoCountByContentHash", func(t *testing.T) { testFileInfoCountByContentHash(t, ss) })
+	t.Run("FileInfoGetPostIdsForExtensions", func(t *testing.T) { testFileInfoGetPostIdsForExtensions(t, ss) })
+	t.Run("FileInfoQuarantine", func(t *testing.T) { testFileInfoQuarantine(t, ss) })
 }
 
 func testFileInfoSaveGet(t *testing.T, ss store.Store) {
@@ -602,3 +607,160 @@ func testFileInfoPermanentDeleteByUser(t *testing.T, ss store.Store) {
 	_, err = ss.FileInfo().PermanentDeleteByUser(userId)
 	require.Nil(t, err)
 }
+
+func testFileInfoSetContentHash(t *testing.T, ss store.Store) {
+	path := model.NewId() + "/file.txt"
+	contentHash := model.NewRandomString(64)
+	blobPath := "blobs/" + contentHash
+
+	info1, err := ss.FileInfo().Save(&model.FileInfo{
+		CreatorId: model.NewId(),
+		Path:      path,
+	})
+	require.Nil(t, err)
+	defer ss.FileInfo().PermanentDelete(info1.Id)
+
+	info2, err := ss.FileInfo().Save(&model.FileInfo{
+		CreatorId: model.NewId(),
+		Path:      path,
+	})
+	require.Nil(t, err)
+	defer ss.FileInfo().PermanentDelete(info2.Id)
+
+	other, err := ss.FileInfo().Save(&model.FileInfo{
+		CreatorId: model.NewId(),
+		Path:      model.NewId() + "/file.txt",
+	})
+	require.Nil(t, err)
+	defer ss.FileInfo().PermanentDelete(other.Id)
+
+	t.Run("should update all the files stored at the path", func(t *testing.T) {
+		err := ss.FileInfo().SetContentHash(path, contentHash, blobPath)
+		require.Nil(t, err)
+
+		for _, id := range []string{info1.Id, info2.Id} {
+			info, err := ss.FileInfo().Get(id)
+			require.Nil(t, err)
+			assert.Equal(t, blobPath, info.Path)
+			assert.Equal(t, contentHash, info.ContentHash)
+		}
+
+		info, err := ss.FileInfo().Get(other.Id)
+		require.Nil(t, err)
+		assert.Equal(t, other.Path, info.Path)
+		assert.Empty(t, info.ContentHash)
+	})
+
+	t.Run("should fail when no file is stored at the path", func(t *testing.T) {
+		err := ss.FileInfo().SetContentHash(model.NewId(), contentHash, blobPath)
+		var nfErr *store.ErrNotFound
+		require.True(t, errors.As(err, &nfErr))
+	})
+}
+
+func testFileInfoCountByContentHash(t *testing.T, ss store.Store) {
+	contentHash := model.NewRandomString(64)
+
+	count, err := ss.FileInfo().CountByContentHash(contentHash)
+	require.Nil(t, err)
+	assert.Equal(t, int64(0), count)
+
+	postId := model.NewId()
+	for i := 0; i < 2; i++ {
+		info, err := ss.FileInfo().Save(&model.FileInfo{
+			CreatorId:   model.NewId(),
+			PostId:      postId,
+			Path:        "blobs/" + contentHash,
+			ContentHash: contentHash,
+		})
+		require.Nil(t, err)
+		defer ss.FileInfo().PermanentDelete(info.Id)
+	}
+
+	count, err = ss.FileInfo().CountByContentHash(contentHash)
+	require.Nil(t, err)
+	assert.Equal(t, int64(2), count)
+
+	// Deleted files still reference the content until they are permanently deleted.
+	_, err = ss.FileInfo().DeleteForPost(postId)
+	require.Nil(t, err)
+
+	count, err = ss.FileInfo().CountByContentHash(contentHash)
+	require.Nil(t, err)
+	assert.Equal(t, int64(2), count)
+}
+
+func testFileInfoQuarantine(t *testing.T, ss store.Store) {
+	path := model.NewId() + "/file.txt"
+	quarantinePath := "quarantine/" + path
+
+	contentHash := model.NewId() + model.NewId()
+	info, err := ss.FileInfo().Save(&model.FileInfo{
+		CreatorId:   model.NewId(),
+		PostId:      model.NewId(),
+		Path:        path,
+		ContentHash: contentHash,
+	})
+	require.Nil(t, err)
+	defer ss.FileInfo().PermanentDelete(info.Id)
+
+	other, err := ss.FileInfo().Save(&model.FileInfo{
+		CreatorId: model.NewId(),
+		Path:      model.NewId() + "/file.txt",
+	})
+	require.Nil(t, err)
+	defer ss.FileInfo().PermanentDelete(other.Id)
+
+	err = ss.FileInfo().Quarantine(path, quarantinePath)
+	require.Nil(t, err)
+
+	_, err = ss.FileInfo().Get(info.Id)
+	var nfErr *store.ErrNotFound
+	require.True(t, errors.As(err, &nfErr), "quarantined files should be deleted")
+
+	infos, err := ss.FileInfo().GetForPost(info.PostId, true, true, false)
+	require.Nil(t, err)
+	require.Len(t, infos, 1)
+	assert.Equal(t, quarantinePath, infos[0].Path)
+	assert.NotZero(t, infos[0].DeleteAt)
+
+	count, err := ss.FileInfo().CountByContentHash(contentHash)
+	require.Nil(t, err)
+	assert.Zero(t, count, "quarantined files should no longer reference the deduplicated copy")
+
+	_, err = ss.FileInfo().Get(other.Id)
+	require.Nil(t, err)
+}
+
+func testFileInfoGetPostIdsForExtensions(t *testing.T, ss store.Store) {
+	postId := model.NewId()
+	post2Id := model.NewId()
+	extension := model.NewId()[:8]
+
+	infos := []*model.FileInfo{
+		{CreatorId: model.NewId(), PostId: postId, Path: "file1", Extension: extension, CreateAt: 1000},
+		{CreatorId: model.NewId(), PostId: postId, Path: "file2", Extension: extension, CreateAt: 1001},
+		{CreatorId: model.NewId(), PostId: post2Id, Path: "file3", Extension: extension, CreateAt: 2000},
+		// not attached to a post
+		{CreatorId: model.NewId(), Path: "file4", Extension: extension, CreateAt: 3000},
+		// another extension
+		{CreatorId: model.NewId(), PostId: model.NewId(), Path: "file5", Extension: model.NewId()[:8], CreateAt: 4000},
+	}
+	for _, info := range infos {
+		_, err := ss.FileInfo().Save(info)
+		require.Nil(t, err)
+		defer ss.FileInfo().PermanentDelete(info.Id)
+	}
+
+	postIds, err := ss.FileInfo().GetPostIdsForExtensions([]string{extension}, 10)
+	require.Nil(t, err)
+	assert.Equal(t, []string{post2Id, postId}, postIds)
+
+	postIds, err = ss.FileInfo().GetPostIdsForExtensions([]string{extension}, 1)
+	require.Nil(t, err)
+	assert.Equal(t, []string{post2Id}, postIds)
+
+	postIds, err = ss.FileInfo().GetPostIdsForExtensions([]string{model.NewId()[:8]}, 10)
+	require.Nil(t, err)
+	assert.Empty(t, postIds)
+}
@@ -0,0 +1,164 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/zacmm/zacmm-server/model"
+	"github.com/zacmm/zacmm-server/store"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeywordWatchStore(t *testing.T, ss store.Store) {
+	t.Run("KeywordWatchStoreSaveGet", func(t *testing.T) { testKeywordWatchStoreSaveGet(t, ss) })
+	t.Run("KeywordWatchStoreUpdate", func(t *testing.T) { testKeywordWatchStoreUpdate(t, ss) })
+	t.Run("KeywordWatchStoreGetForUserAndTeam", func(t *testing.T) { testKeywordWatchStoreGetForUserAndTeam(t, ss) })
+	t.Run("KeywordWatchStoreDelete", func(t *testing.T) { testKeywordWatchStoreDelete(t, ss) })
+	t.Run("KeywordWatchStoreUpdateLastAlertAt", func(t *testing.T) { testKeywordWatchStoreUpdateLastAlertAt(t, ss) })
+}
+
+func testKeywordWatchStoreSaveGet(t *testing.T, ss store.Store) {
+	watch, err := ss.KeywordWatch().Save(&model.KeywordWatch{
+		UserId:  model.NewId(),
+		TeamId:  model.NewId(),
+		Pattern: `CVE-\d+`,
+		IsRegex: true,
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, watch.Id)
+	assert.Equal(t, model.KEYWORD_WATCH_DELIVERY_NOTIFICATION, watch.Delivery)
+
+	received, err := ss.KeywordWatch().Get(watch.Id)
+	require.NoError(t, err)
+	assert.Equal(t, watch, received)
+
+	_, err = ss.KeywordWatch().Get(model.NewId())
+	var nfErr *store.ErrNotFound
+	require.True(t, errors.As(err, &nfErr))
+}
+
+func testKeywordWatchStoreUpdate(t *testing.T, ss store.Store) {
+	watch, err := ss.KeywordWatch().Save(&model.KeywordWatch{
+		UserId:  model.NewId(),
+		TeamId:  model.NewId(),
+		Pattern: `CVE-\d+`,
+		IsRegex: true,
+	})
+	require.NoError(t, err)
+
+	watch.Pattern = "outage"
+	watch.IsRegex = false
+	watch.Delivery = model.KEYWORD_WATCH_DELIVERY_DIGEST
+	_, err = ss.KeywordWatch().Update(watch)
+	require.NoError(t, err)
+
+	received, err := ss.KeywordWatch().Get(watch.Id)
+	require.NoError(t, err)
+	assert.Equal(t, "outage", received.Pattern)
+	assert.False(t, received.IsRegex)
+	assert.Equal(t, model.KEYWORD_WATCH_DELIVERY_DIGEST, received.Delivery)
+
+	_, err = ss.KeywordWatch().Update(&model.KeywordWatch{
+		Id:       model.NewId(),
+		UserId:   model.NewId(),
+		TeamId:   model.NewId(),
+		Pattern:  "outage",
+		CreateAt: model.GetMillis(),
+	})
+	var nfErr *store.ErrNotFound
+	require.True(t, errors.As(err, &nfErr))
+}
+
+func testKeywordWatchStoreGetForUserAndTeam(t *testing.T, ss store.Store) {
+	userId := model.NewId()
+	teamId := model.NewId()
+
+	watch1, err := ss.KeywordWatch().Save(&model.KeywordWatch{
+		UserId:  userId,
+		TeamId:  teamId,
+		Pattern: `CVE-\d+`,
+		IsRegex: true,
+	})
+	require.NoError(t, err)
+	watch2, err := ss.KeywordWatch().Save(&model.KeywordWatch{
+		UserId:  userId,
+		TeamId:  model.NewId(),
+		Pattern: `CVE-\d+`,
+		IsRegex: true,
+	})
+	require.NoError(t, err)
+	watch3, err := ss.KeywordWatch().Save(&model.KeywordWatch{
+		UserId:  model.NewId(),
+		TeamId:  teamId,
+		Pattern: `CVE-\d+`,
+		IsRegex: true,
+	})
+	require.NoError(t, err)
+
+	watches, err := ss.KeywordWatch().GetForUser(userId)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []*model.KeywordWatch{watch1, watch2}, watches)
+
+	watches, err = ss.KeywordWatch().GetForTeam(teamId)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []*model.KeywordWatch{watch1, watch3}, watches)
+
+	watches, err = ss.KeywordWatch().GetForUser(model.NewId())
+	require.NoError(t, err)
+	assert.Empty(t, watches)
+}
+
+func testKeywordWatchStoreDelete(t *testing.T, ss store.Store) {
+	watch, err := ss.KeywordWatch().Save(&model.KeywordWatch{
+		UserId:  model.NewId(),
+		TeamId:  model.NewId(),
+		Pattern: `CVE-\d+`,
+		IsRegex: true,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, ss.KeywordWatch().Delete(watch.Id))
+
+	_, err = ss.KeywordWatch().Get(watch.Id)
+	var nfErr *store.ErrNotFound
+	require.True(t, errors.As(err, &nfErr))
+
+	err = ss.KeywordWatch().Delete(watch.Id)
+	require.True(t, errors.As(err, &nfErr))
+}
+
+func testKeywordWatchStoreUpdateLastAlertAt(t *testing.T, ss store.Store) {
+	watch, err := ss.KeywordWatch().Save(&model.KeywordWatch{
+		UserId:  model.NewId(),
+		TeamId:  model.NewId(),
+		Pattern: `CVE-\d+`,
+		IsRegex: true,
+	})
+	require.NoError(t, err)
+
+	updated, err := ss.KeywordWatch().UpdateLastAlertAt(watch.Id, 1000, 0)
+	require.NoError(t, err)
+	assert.True(t, updated)
+
+	// another alert was sent within the cooldown
+	updated, err = ss.KeywordWatch().UpdateLastAlertAt(watch.Id, 1500, 900)
+	require.NoError(t, err)
+	assert.False(t, updated)
+
+	updated, err = ss.KeywordWatch().UpdateLastAlertAt(watch.Id, 2000, 1000)
+	require.NoError(t, err)
+	assert.True(t, updated)
+
+	received, err := ss.KeywordWatch().Get(watch.Id)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2000), received.LastAlertAt)
+
+	updated, err = ss.KeywordWatch().UpdateLastAlertAt(model.NewId(), 1000, 0)
+	require.NoError(t, err)
+	assert.False(t, updated)
+}
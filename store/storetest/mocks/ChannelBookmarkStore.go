@@ -0,0 +1,181 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+// Regenerate this file using `make store-mocks`.
+
+package mocks
+
+import (
+	model "github.com/zacmm/zacmm-server/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ChannelBookmarkStore is an autogenerated mock type for the ChannelBookmarkStore type
+type ChannelBookmarkStore struct {
+	mock.Mock
+}
+
+// Delete provides a mock function with given fields: bookmarkId
+func (_m *ChannelBookmarkStore) Delete(bookmarkId string) error {
+	ret := _m.Called(bookmarkId)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(bookmarkId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: bookmarkId, includeDeleted
+func (_m *ChannelBookmarkStore) Get(bookmarkId string, includeDeleted bool) (*model.ChannelBookmark, error) {
+	ret := _m.Called(bookmarkId, includeDeleted)
+
+	var r0 *model.ChannelBookmark
+	if rf, ok := ret.Get(0).(func(string, bool) *model.ChannelBookmark); ok {
+		r0 = rf(bookmarkId, includeDeleted)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ChannelBookmark)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, bool) error); ok {
+		r1 = rf(bookmarkId, includeDeleted)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAllForExport provides a mock function with given fields: limit, afterId
+func (_m *ChannelBookmarkStore) GetAllForExport(limit int, afterId string) ([]*model.ChannelBookmarkForExport, error) {
+	ret := _m.Called(limit, afterId)
+
+	var r0 []*model.ChannelBookmarkForExport
+	if rf, ok := ret.Get(0).(func(int, string) []*model.ChannelBookmarkForExport); ok {
+		r0 = rf(limit, afterId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.ChannelBookmarkForExport)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int, string) error); ok {
+		r1 = rf(limit, afterId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBookmarksForChannel provides a mock function with given fields: channelId
+func (_m *ChannelBookmarkStore) GetBookmarksForChannel(channelId string) ([]*model.ChannelBookmark, error) {
+	ret := _m.Called(channelId)
+
+	var r0 []*model.ChannelBookmark
+	if rf, ok := ret.Get(0).(func(string) []*model.ChannelBookmark); ok {
+		r0 = rf(channelId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.ChannelBookmark)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(channelId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBookmarksForFile provides a mock function with given fields: fileId
+func (_m *ChannelBookmarkStore) GetBookmarksForFile(fileId string) ([]*model.ChannelBookmark, error) {
+	ret := _m.Called(fileId)
+
+	var r0 []*model.ChannelBookmark
+	if rf, ok := ret.Get(0).(func(string) []*model.ChannelBookmark); ok {
+		r0 = rf(fileId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.ChannelBookmark)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(fileId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Save provides a mock function with given fields: bookmark
+func (_m *ChannelBookmarkStore) Save(bookmark *model.ChannelBookmark) (*model.ChannelBookmark, error) {
+	ret := _m.Called(bookmark)
+
+	var r0 *model.ChannelBookmark
+	if rf, ok := ret.Get(0).(func(*model.ChannelBookmark) *model.ChannelBookmark); ok {
+		r0 = rf(bookmark)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ChannelBookmark)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*model.ChannelBookmark) error); ok {
+		r1 = rf(bookmark)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: bookmark
+func (_m *ChannelBookmarkStore) Update(bookmark *model.ChannelBookmark) error {
+	ret := _m.Called(bookmark)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.ChannelBookmark) error); ok {
+		r0 = rf(bookmark)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateSortOrder provides a mock function with given fields: bookmarkId, channelId, newIndex
+func (_m *ChannelBookmarkStore) UpdateSortOrder(bookmarkId string, channelId string, newIndex int64) ([]*model.ChannelBookmark, error) {
+	ret := _m.Called(bookmarkId, channelId, newIndex)
+
+	var r0 []*model.ChannelBookmark
+	if rf, ok := ret.Get(0).(func(string, string, int64) []*model.ChannelBookmark); ok {
+		r0 = rf(bookmarkId, channelId, newIndex)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.ChannelBookmark)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, int64) error); ok {
+		r1 = rf(bookmarkId, channelId, newIndex)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -0,0 +1,121 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+// Regenerate this file using `make store-mocks`.
+
+package mocks
+
+import (
+	model "github.com/zacmm/zacmm-server/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// CustomStatusStore is an autogenerated mock type for the CustomStatusStore type
+type CustomStatusStore struct {
+	mock.Mock
+}
+
+// Delete provides a mock function with given fields: userId
+func (_m *CustomStatusStore) Delete(userId string) error {
+	ret := _m.Called(userId)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(userId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: userId
+func (_m *CustomStatusStore) Get(userId string) (*model.CustomStatus, error) {
+	ret := _m.Called(userId)
+
+	var r0 *model.CustomStatus
+	if rf, ok := ret.Get(0).(func(string) *model.CustomStatus); ok {
+		r0 = rf(userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.CustomStatus)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByUserIds provides a mock function with given fields: userIds
+func (_m *CustomStatusStore) GetByUserIds(userIds []string) ([]*model.CustomStatus, error) {
+	ret := _m.Called(userIds)
+
+	var r0 []*model.CustomStatus
+	if rf, ok := ret.Get(0).(func([]string) []*model.CustomStatus); ok {
+		r0 = rf(userIds)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.CustomStatus)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]string) error); ok {
+		r1 = rf(userIds)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetExpired provides a mock function with given fields: expiredAt, limit
+func (_m *CustomStatusStore) GetExpired(expiredAt int64, limit int) ([]*model.CustomStatus, error) {
+	ret := _m.Called(expiredAt, limit)
+
+	var r0 []*model.CustomStatus
+	if rf, ok := ret.Get(0).(func(int64, int) []*model.CustomStatus); ok {
+		r0 = rf(expiredAt, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.CustomStatus)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, int) error); ok {
+		r1 = rf(expiredAt, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Save provides a mock function with given fields: customStatus
+func (_m *CustomStatusStore) Save(customStatus *model.CustomStatus) (*model.CustomStatus, error) {
+	ret := _m.Called(customStatus)
+
+	var r0 *model.CustomStatus
+	if rf, ok := ret.Get(0).(func(*model.CustomStatus) *model.CustomStatus); ok {
+		r0 = rf(customStatus)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.CustomStatus)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*model.CustomStatus) error); ok {
+		r1 = rf(customStatus)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -0,0 +1,98 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+// Regenerate this file using `make store-mocks`.
+
+package mocks
+
+import (
+	model "github.com/zacmm/zacmm-server/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// DndScheduleStore is an autogenerated mock type for the DndScheduleStore type
+type DndScheduleStore struct {
+	mock.Mock
+}
+
+// Delete provides a mock function with given fields: userId
+func (_m *DndScheduleStore) Delete(userId string) error {
+	ret := _m.Called(userId)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(userId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: userId
+func (_m *DndScheduleStore) Get(userId string) (*model.DndSchedule, error) {
+	ret := _m.Called(userId)
+
+	var r0 *model.DndSchedule
+	if rf, ok := ret.Get(0).(func(string) *model.DndSchedule); ok {
+		r0 = rf(userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.DndSchedule)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAll provides a mock function with given fields: afterUserId, limit
+func (_m *DndScheduleStore) GetAll(afterUserId string, limit int) ([]*model.DndSchedule, error) {
+	ret := _m.Called(afterUserId, limit)
+
+	var r0 []*model.DndSchedule
+	if rf, ok := ret.Get(0).(func(string, int) []*model.DndSchedule); ok {
+		r0 = rf(afterUserId, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.DndSchedule)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int) error); ok {
+		r1 = rf(afterUserId, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Save provides a mock function with given fields: schedule
+func (_m *DndScheduleStore) Save(schedule *model.DndSchedule) (*model.DndSchedule, error) {
+	ret := _m.Called(schedule)
+
+	var r0 *model.DndSchedule
+	if rf, ok := ret.Get(0).(func(*model.DndSchedule) *model.DndSchedule); ok {
+		r0 = rf(schedule)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.DndSchedule)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*model.DndSchedule) error); ok {
+		r1 = rf(schedule)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
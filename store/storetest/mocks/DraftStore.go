@@ -0,0 +1,98 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+// Regenerate this file using `make store-mocks`.
+
+package mocks
+
+import (
+	model "github.com/zacmm/zacmm-server/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// DraftStore is an autogenerated mock type for the DraftStore type
+type DraftStore struct {
+	mock.Mock
+}
+
+// Delete provides a mock function with given fields: userId, channelId, rootId
+func (_m *DraftStore) Delete(userId string, channelId string, rootId string) error {
+	ret := _m.Called(userId, channelId, rootId)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, string) error); ok {
+		r0 = rf(userId, channelId, rootId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: userId, channelId, rootId
+func (_m *DraftStore) Get(userId string, channelId string, rootId string) (*model.Draft, error) {
+	ret := _m.Called(userId, channelId, rootId)
+
+	var r0 *model.Draft
+	if rf, ok := ret.Get(0).(func(string, string, string) *model.Draft); ok {
+		r0 = rf(userId, channelId, rootId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Draft)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = rf(userId, channelId, rootId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDraftsForUser provides a mock function with given fields: userId, teamId
+func (_m *DraftStore) GetDraftsForUser(userId string, teamId string) ([]*model.Draft, error) {
+	ret := _m.Called(userId, teamId)
+
+	var r0 []*model.Draft
+	if rf, ok := ret.Get(0).(func(string, string) []*model.Draft); ok {
+		r0 = rf(userId, teamId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Draft)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(userId, teamId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Save provides a mock function with given fields: draft
+func (_m *DraftStore) Save(draft *model.Draft) (*model.Draft, error) {
+	ret := _m.Called(draft)
+
+	var r0 *model.Draft
+	if rf, ok := ret.Get(0).(func(*model.Draft) *model.Draft); ok {
+		r0 = rf(draft)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Draft)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*model.Draft) error); ok {
+		r1 = rf(draft)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
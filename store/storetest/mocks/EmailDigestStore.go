@@ -0,0 +1,112 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+// Regenerate this file using `make store-mocks`.
+
+package mocks
+
+import (
+	model "github.com/zacmm/zacmm-server/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// EmailDigestStore is an autogenerated mock type for the EmailDigestStore type
+type EmailDigestStore struct {
+	mock.Mock
+}
+
+// Delete provides a mock function with given fields: ids
+func (_m *EmailDigestStore) Delete(ids []string) error {
+	ret := _m.Called(ids)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]string) error); ok {
+		r0 = rf(ids)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteForUser provides a mock function with given fields: userId
+func (_m *EmailDigestStore) DeleteForUser(userId string) error {
+	ret := _m.Called(userId)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(userId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetForUser provides a mock function with given fields: userId
+func (_m *EmailDigestStore) GetForUser(userId string) ([]*model.PendingEmailNotification, error) {
+	ret := _m.Called(userId)
+
+	var r0 []*model.PendingEmailNotification
+	if rf, ok := ret.Get(0).(func(string) []*model.PendingEmailNotification); ok {
+		r0 = rf(userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.PendingEmailNotification)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserIdsWithPending provides a mock function with given fields: afterUserId, limit
+func (_m *EmailDigestStore) GetUserIdsWithPending(afterUserId string, limit int) ([]string, error) {
+	ret := _m.Called(afterUserId, limit)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(string, int) []string); ok {
+		r0 = rf(afterUserId, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int) error); ok {
+		r1 = rf(afterUserId, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Save provides a mock function with given fields: notification
+func (_m *EmailDigestStore) Save(notification *model.PendingEmailNotification) (*model.PendingEmailNotification, error) {
+	ret := _m.Called(notification)
+
+	var r0 *model.PendingEmailNotification
+	if rf, ok := ret.Get(0).(func(*model.PendingEmailNotification) *model.PendingEmailNotification); ok {
+		r0 = rf(notification)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.PendingEmailNotification)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*model.PendingEmailNotification) error); ok {
+		r1 = rf(notification)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -0,0 +1,151 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+// Regenerate this file using `make store-mocks`.
+
+package mocks
+
+import (
+	model "github.com/zacmm/zacmm-server/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// EmailQueueStore is an autogenerated mock type for the EmailQueueStore type
+type EmailQueueStore struct {
+	mock.Mock
+}
+
+// Get provides a mock function with given fields: id
+func (_m *EmailQueueStore) Get(id string) (*model.QueuedEmail, error) {
+	ret := _m.Called(id)
+
+	var r0 *model.QueuedEmail
+	if rf, ok := ret.Get(0).(func(string) *model.QueuedEmail); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.QueuedEmail)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAll provides a mock function with given fields: status, offset, limit
+func (_m *EmailQueueStore) GetAll(status string, offset int, limit int) ([]*model.QueuedEmail, error) {
+	ret := _m.Called(status, offset, limit)
+
+	var r0 []*model.QueuedEmail
+	if rf, ok := ret.Get(0).(func(string, int, int) []*model.QueuedEmail); ok {
+		r0 = rf(status, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.QueuedEmail)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int, int) error); ok {
+		r1 = rf(status, offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReadyToSend provides a mock function with given fields: now, limit
+func (_m *EmailQueueStore) GetReadyToSend(now int64, limit int) ([]*model.QueuedEmail, error) {
+	ret := _m.Called(now, limit)
+
+	var r0 []*model.QueuedEmail
+	if rf, ok := ret.Get(0).(func(int64, int) []*model.QueuedEmail); ok {
+		r0 = rf(now, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.QueuedEmail)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, int) error); ok {
+		r1 = rf(now, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PermanentDeleteSentBefore provides a mock function with given fields: before, limit
+func (_m *EmailQueueStore) PermanentDeleteSentBefore(before int64, limit int64) (int64, error) {
+	ret := _m.Called(before, limit)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(int64, int64) int64); ok {
+		r0 = rf(before, limit)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, int64) error); ok {
+		r1 = rf(before, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Save provides a mock function with given fields: email
+func (_m *EmailQueueStore) Save(email *model.QueuedEmail) (*model.QueuedEmail, error) {
+	ret := _m.Called(email)
+
+	var r0 *model.QueuedEmail
+	if rf, ok := ret.Get(0).(func(*model.QueuedEmail) *model.QueuedEmail); ok {
+		r0 = rf(email)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.QueuedEmail)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*model.QueuedEmail) error); ok {
+		r1 = rf(email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: email
+func (_m *EmailQueueStore) Update(email *model.QueuedEmail) (*model.QueuedEmail, error) {
+	ret := _m.Called(email)
+
+	var r0 *model.QueuedEmail
+	if rf, ok := ret.Get(0).(func(*model.QueuedEmail) *model.QueuedEmail); ok {
+		r0 = rf(email)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.QueuedEmail)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*model.QueuedEmail) error); ok {
+		r1 = rf(email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
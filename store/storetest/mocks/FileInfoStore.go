@@ -33,6 +33,27 @@ func (_m *FileInfoStore) ClearCaches() {
 	_m.Called()
 }
 
+// CountByContentHash provides a mock function with given fields: contentHash
+func (_m *FileInfoStore) CountByContentHash(contentHash string) (int64, error) {
+	ret := _m.Called(contentHash)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(string) int64); ok {
+		r0 = rf(contentHash)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(contentHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeleteForPost provides a mock function with given fields: postId
 func (_m *FileInfoStore) DeleteForPost(postId string) (string, error) {
 	ret := _m.Called(postId)
@@ -100,6 +121,29 @@ func (_m *FileInfoStore) GetByPath(path string) (*model.FileInfo, error) {
 	return r0, r1
 }
 
+// GetFilesBatchForIndexing provides a mock function with given fields: startTime, endTime, limit
+func (_m *FileInfoStore) GetFilesBatchForIndexing(startTime int64, endTime int64, limit int) ([]*model.FileForIndexing, error) {
+	ret := _m.Called(startTime, endTime, limit)
+
+	var r0 []*model.FileForIndexing
+	if rf, ok := ret.Get(0).(func(int64, int64, int) []*model.FileForIndexing); ok {
+		r0 = rf(startTime, endTime, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.FileForIndexing)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, int64, int) error); ok {
+		r1 = rf(startTime, endTime, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetForPost provides a mock function with given fields: postId, readFromMaster, includeDeleted, allowFromCache
 func (_m *FileInfoStore) GetForPost(postId string, readFromMaster bool, includeDeleted bool, allowFromCache bool) ([]*model.FileInfo, error) {
 	ret := _m.Called(postId, readFromMaster, includeDeleted, allowFromCache)
@@ -146,6 +190,29 @@ func (_m *FileInfoStore) GetForUser(userId string) ([]*model.FileInfo, error) {
 	return r0, r1
 }
 
+// GetPostIdsForExtensions provides a mock function with given fields: extensions, limit
+func (_m *FileInfoStore) GetPostIdsForExtensions(extensions []string, limit int) ([]string, error) {
+	ret := _m.Called(extensions, limit)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func([]string, int) []string); ok {
+		r0 = rf(extensions, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]string, int) error); ok {
+		r1 = rf(extensions, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetWithOptions provides a mock function with given fields: page, perPage, opt
 func (_m *FileInfoStore) GetWithOptions(page int, perPage int, opt *model.GetFileInfosOptions) ([]*model.FileInfo, error) {
 	ret := _m.Called(page, perPage, opt)
@@ -230,6 +297,20 @@ func (_m *FileInfoStore) PermanentDeleteByUser(userId string) (int64, error) {
 	return r0, r1
 }
 
+// Quarantine provides a mock function with given fields: path, quarantinePath
+func (_m *FileInfoStore) Quarantine(path string, quarantinePath string) error {
+	ret := _m.Called(path, quarantinePath)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(path, quarantinePath)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Save provides a mock function with given fields: info
 func (_m *FileInfoStore) Save(info *model.FileInfo) (*model.FileInfo, error) {
 	ret := _m.Called(info)
@@ -253,6 +334,29 @@ func (_m *FileInfoStore) Save(info *model.FileInfo) (*model.FileInfo, error) {
 	return r0, r1
 }
 
+// Search provides a mock function with given fields: paramsList, userId, teamId, page, perPage
+func (_m *FileInfoStore) Search(paramsList []*model.SearchParams, userId string, teamId string, page int, perPage int) (*model.FileInfoList, error) {
+	ret := _m.Called(paramsList, userId, teamId, page, perPage)
+
+	var r0 *model.FileInfoList
+	if rf, ok := ret.Get(0).(func([]*model.SearchParams, string, string, int, int) *model.FileInfoList); ok {
+		r0 = rf(paramsList, userId, teamId, page, perPage)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.FileInfoList)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]*model.SearchParams, string, string, int, int) error); ok {
+		r1 = rf(paramsList, userId, teamId, page, perPage)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SetContent provides a mock function with given fields: fileId, content
 func (_m *FileInfoStore) SetContent(fileId string, content string) error {
 	ret := _m.Called(fileId, content)
@@ -267,6 +371,20 @@ func (_m *FileInfoStore) SetContent(fileId string, content string) error {
 	return r0
 }
 
+// SetContentHash provides a mock function with given fields: path, contentHash, blobPath
+func (_m *FileInfoStore) SetContentHash(path string, contentHash string, blobPath string) error {
+	ret := _m.Called(path, contentHash, blobPath)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, string) error); ok {
+		r0 = rf(path, contentHash, blobPath)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Upsert provides a mock function with given fields: info
 func (_m *FileInfoStore) Upsert(info *model.FileInfo) (*model.FileInfo, error) {
 	ret := _m.Called(info)
@@ -0,0 +1,165 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+// Regenerate this file using `make store-mocks`.
+
+package mocks
+
+import (
+	model "github.com/zacmm/zacmm-server/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// KeywordWatchStore is an autogenerated mock type for the KeywordWatchStore type
+type KeywordWatchStore struct {
+	mock.Mock
+}
+
+// Delete provides a mock function with given fields: id
+func (_m *KeywordWatchStore) Delete(id string) error {
+	ret := _m.Called(id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: id
+func (_m *KeywordWatchStore) Get(id string) (*model.KeywordWatch, error) {
+	ret := _m.Called(id)
+
+	var r0 *model.KeywordWatch
+	if rf, ok := ret.Get(0).(func(string) *model.KeywordWatch); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.KeywordWatch)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetForTeam provides a mock function with given fields: teamId
+func (_m *KeywordWatchStore) GetForTeam(teamId string) ([]*model.KeywordWatch, error) {
+	ret := _m.Called(teamId)
+
+	var r0 []*model.KeywordWatch
+	if rf, ok := ret.Get(0).(func(string) []*model.KeywordWatch); ok {
+		r0 = rf(teamId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.KeywordWatch)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(teamId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetForUser provides a mock function with given fields: userId
+func (_m *KeywordWatchStore) GetForUser(userId string) ([]*model.KeywordWatch, error) {
+	ret := _m.Called(userId)
+
+	var r0 []*model.KeywordWatch
+	if rf, ok := ret.Get(0).(func(string) []*model.KeywordWatch); ok {
+		r0 = rf(userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.KeywordWatch)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Save provides a mock function with given fields: watch
+func (_m *KeywordWatchStore) Save(watch *model.KeywordWatch) (*model.KeywordWatch, error) {
+	ret := _m.Called(watch)
+
+	var r0 *model.KeywordWatch
+	if rf, ok := ret.Get(0).(func(*model.KeywordWatch) *model.KeywordWatch); ok {
+		r0 = rf(watch)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.KeywordWatch)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*model.KeywordWatch) error); ok {
+		r1 = rf(watch)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: watch
+func (_m *KeywordWatchStore) Update(watch *model.KeywordWatch) (*model.KeywordWatch, error) {
+	ret := _m.Called(watch)
+
+	var r0 *model.KeywordWatch
+	if rf, ok := ret.Get(0).(func(*model.KeywordWatch) *model.KeywordWatch); ok {
+		r0 = rf(watch)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.KeywordWatch)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*model.KeywordWatch) error); ok {
+		r1 = rf(watch)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateLastAlertAt provides a mock function with given fields: id, alertAt, since
+func (_m *KeywordWatchStore) UpdateLastAlertAt(id string, alertAt int64, since int64) (bool, error) {
+	ret := _m.Called(id, alertAt, since)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string, int64, int64) bool); ok {
+		r0 = rf(id, alertAt, since)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int64, int64) error); ok {
+		r1 = rf(id, alertAt, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
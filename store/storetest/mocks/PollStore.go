@@ -0,0 +1,199 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+// Regenerate this file using `make store-mocks`.
+
+package mocks
+
+import (
+	model "github.com/zacmm/zacmm-server/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PollStore is an autogenerated mock type for the PollStore type
+type PollStore struct {
+	mock.Mock
+}
+
+// Get provides a mock function with given fields: id
+func (_m *PollStore) Get(id string) (*model.Poll, error) {
+	ret := _m.Called(id)
+
+	var r0 *model.Poll
+	if rf, ok := ret.Get(0).(func(string) *model.Poll); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Poll)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetForPosts provides a mock function with given fields: postIds
+func (_m *PollStore) GetForPosts(postIds []string) ([]*model.Poll, error) {
+	ret := _m.Called(postIds)
+
+	var r0 []*model.Poll
+	if rf, ok := ret.Get(0).(func([]string) []*model.Poll); ok {
+		r0 = rf(postIds)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Poll)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]string) error); ok {
+		r1 = rf(postIds)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPollsToClose provides a mock function with given fields: now, limit
+func (_m *PollStore) GetPollsToClose(now int64, limit int) ([]*model.Poll, error) {
+	ret := _m.Called(now, limit)
+
+	var r0 []*model.Poll
+	if rf, ok := ret.Get(0).(func(int64, int) []*model.Poll); ok {
+		r0 = rf(now, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Poll)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, int) error); ok {
+		r1 = rf(now, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetVotes provides a mock function with given fields: pollId
+func (_m *PollStore) GetVotes(pollId string) ([]*model.PollVote, error) {
+	ret := _m.Called(pollId)
+
+	var r0 []*model.PollVote
+	if rf, ok := ret.Get(0).(func(string) []*model.PollVote); ok {
+		r0 = rf(pollId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.PollVote)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(pollId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Save provides a mock function with given fields: poll
+func (_m *PollStore) Save(poll *model.Poll) (*model.Poll, error) {
+	ret := _m.Called(poll)
+
+	var r0 *model.Poll
+	if rf, ok := ret.Get(0).(func(*model.Poll) *model.Poll); ok {
+		r0 = rf(poll)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Poll)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*model.Poll) error); ok {
+		r1 = rf(poll)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveVotes provides a mock function with given fields: pollId, userId, votes
+func (_m *PollStore) SaveVotes(pollId string, userId string, votes []*model.PollVote) (*model.Post, error) {
+	ret := _m.Called(pollId, userId, votes)
+
+	var r0 *model.Post
+	if rf, ok := ret.Get(0).(func(string, string, []*model.PollVote) *model.Post); ok {
+		r0 = rf(pollId, userId, votes)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Post)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, []*model.PollVote) error); ok {
+		r1 = rf(pollId, userId, votes)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: poll
+func (_m *PollStore) Update(poll *model.Poll) (*model.Poll, error) {
+	ret := _m.Called(poll)
+
+	var r0 *model.Poll
+	if rf, ok := ret.Get(0).(func(*model.Poll) *model.Poll); ok {
+		r0 = rf(poll)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Poll)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*model.Poll) error); ok {
+		r1 = rf(poll)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateTally provides a mock function with given fields: pollId
+func (_m *PollStore) UpdateTally(pollId string) (*model.Post, error) {
+	ret := _m.Called(pollId)
+
+	var r0 *model.Post
+	if rf, ok := ret.Get(0).(func(string) *model.Post); ok {
+		r0 = rf(pollId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Post)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(pollId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
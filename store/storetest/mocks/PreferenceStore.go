@@ -146,6 +146,29 @@ func (_m *PreferenceStore) GetCategory(userId string, category string) (model.Pr
 	return r0, r1
 }
 
+// GetForUsers provides a mock function with given fields: userIds, category, name
+func (_m *PreferenceStore) GetForUsers(userIds []string, category string, name string) (model.Preferences, error) {
+	ret := _m.Called(userIds, category, name)
+
+	var r0 model.Preferences
+	if rf, ok := ret.Get(0).(func([]string, string, string) model.Preferences); ok {
+		r0 = rf(userIds, category, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(model.Preferences)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]string, string, string) error); ok {
+		r1 = rf(userIds, category, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // PermanentDeleteByUser provides a mock function with given fields: userId
 func (_m *PreferenceStore) PermanentDeleteByUser(userId string) error {
 	ret := _m.Called(userId)
@@ -0,0 +1,135 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+// Regenerate this file using `make store-mocks`.
+
+package mocks
+
+import (
+	model "github.com/zacmm/zacmm-server/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PublicLinkStore is an autogenerated mock type for the PublicLinkStore type
+type PublicLinkStore struct {
+	mock.Mock
+}
+
+// Get provides a mock function with given fields: id
+func (_m *PublicLinkStore) Get(id string) (*model.PublicLink, error) {
+	ret := _m.Called(id)
+
+	var r0 *model.PublicLink
+	if rf, ok := ret.Get(0).(func(string) *model.PublicLink); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.PublicLink)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetActive provides a mock function with given fields: fileId, creatorId, now
+func (_m *PublicLinkStore) GetActive(fileId string, creatorId string, now int64) (*model.PublicLink, error) {
+	ret := _m.Called(fileId, creatorId, now)
+
+	var r0 *model.PublicLink
+	if rf, ok := ret.Get(0).(func(string, string, int64) *model.PublicLink); ok {
+		r0 = rf(fileId, creatorId, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.PublicLink)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, int64) error); ok {
+		r1 = rf(fileId, creatorId, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetForUser provides a mock function with given fields: userId, offset, limit
+func (_m *PublicLinkStore) GetForUser(userId string, offset int, limit int) ([]*model.PublicLink, error) {
+	ret := _m.Called(userId, offset, limit)
+
+	var r0 []*model.PublicLink
+	if rf, ok := ret.Get(0).(func(string, int, int) []*model.PublicLink); ok {
+		r0 = rf(userId, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.PublicLink)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int, int) error); ok {
+		r1 = rf(userId, offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RecordDownload provides a mock function with given fields: id, downloadAt
+func (_m *PublicLinkStore) RecordDownload(id string, downloadAt int64) error {
+	ret := _m.Called(id, downloadAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, int64) error); ok {
+		r0 = rf(id, downloadAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Revoke provides a mock function with given fields: id, revokeAt
+func (_m *PublicLinkStore) Revoke(id string, revokeAt int64) error {
+	ret := _m.Called(id, revokeAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, int64) error); ok {
+		r0 = rf(id, revokeAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Save provides a mock function with given fields: link
+func (_m *PublicLinkStore) Save(link *model.PublicLink) (*model.PublicLink, error) {
+	ret := _m.Called(link)
+
+	var r0 *model.PublicLink
+	if rf, ok := ret.Get(0).(func(*model.PublicLink) *model.PublicLink); ok {
+		r0 = rf(link)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.PublicLink)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*model.PublicLink) error); ok {
+		r1 = rf(link)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
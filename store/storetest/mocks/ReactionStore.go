@@ -97,6 +97,29 @@ func (_m *ReactionStore) GetForPost(postId string, allowFromCache bool) ([]*mode
 	return r0, r1
 }
 
+// GetPostIdsForUsers provides a mock function with given fields: userIds, limit
+func (_m *ReactionStore) GetPostIdsForUsers(userIds []string, limit int) ([]string, error) {
+	ret := _m.Called(userIds, limit)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func([]string, int) []string); ok {
+		r0 = rf(userIds, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]string, int) error); ok {
+		r1 = rf(userIds, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // PermanentDeleteBatch provides a mock function with given fields: endTime, limit
 func (_m *ReactionStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
 	ret := _m.Called(endTime, limit)
@@ -0,0 +1,158 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+// Regenerate this file using `make store-mocks`.
+
+package mocks
+
+import (
+	model "github.com/zacmm/zacmm-server/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SavedSearchStore is an autogenerated mock type for the SavedSearchStore type
+type SavedSearchStore struct {
+	mock.Mock
+}
+
+// Delete provides a mock function with given fields: id
+func (_m *SavedSearchStore) Delete(id string) error {
+	ret := _m.Called(id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: id
+func (_m *SavedSearchStore) Get(id string) (*model.SavedSearch, error) {
+	ret := _m.Called(id)
+
+	var r0 *model.SavedSearch
+	if rf, ok := ret.Get(0).(func(string) *model.SavedSearch); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.SavedSearch)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetForNotification provides a mock function with given fields: afterId, limit
+func (_m *SavedSearchStore) GetForNotification(afterId string, limit int) ([]*model.SavedSearch, error) {
+	ret := _m.Called(afterId, limit)
+
+	var r0 []*model.SavedSearch
+	if rf, ok := ret.Get(0).(func(string, int) []*model.SavedSearch); ok {
+		r0 = rf(afterId, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.SavedSearch)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int) error); ok {
+		r1 = rf(afterId, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetForUser provides a mock function with given fields: userId
+func (_m *SavedSearchStore) GetForUser(userId string) ([]*model.SavedSearch, error) {
+	ret := _m.Called(userId)
+
+	var r0 []*model.SavedSearch
+	if rf, ok := ret.Get(0).(func(string) []*model.SavedSearch); ok {
+		r0 = rf(userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.SavedSearch)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Save provides a mock function with given fields: search
+func (_m *SavedSearchStore) Save(search *model.SavedSearch) (*model.SavedSearch, error) {
+	ret := _m.Called(search)
+
+	var r0 *model.SavedSearch
+	if rf, ok := ret.Get(0).(func(*model.SavedSearch) *model.SavedSearch); ok {
+		r0 = rf(search)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.SavedSearch)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*model.SavedSearch) error); ok {
+		r1 = rf(search)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: search
+func (_m *SavedSearchStore) Update(search *model.SavedSearch) (*model.SavedSearch, error) {
+	ret := _m.Called(search)
+
+	var r0 *model.SavedSearch
+	if rf, ok := ret.Get(0).(func(*model.SavedSearch) *model.SavedSearch); ok {
+		r0 = rf(search)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.SavedSearch)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*model.SavedSearch) error); ok {
+		r1 = rf(search)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateLastCheckedAt provides a mock function with given fields: id, checkedAt
+func (_m *SavedSearchStore) UpdateLastCheckedAt(id string, checkedAt int64) error {
+	ret := _m.Called(id, checkedAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, int64) error); ok {
+		r0 = rf(id, checkedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
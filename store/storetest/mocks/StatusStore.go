@@ -60,6 +60,29 @@ func (_m *StatusStore) GetByIds(userIds []string) ([]*model.Status, error) {
 	return r0, r1
 }
 
+// GetExpiredDND provides a mock function with given fields: expiredAt, limit
+func (_m *StatusStore) GetExpiredDND(expiredAt int64, limit int) ([]*model.Status, error) {
+	ret := _m.Called(expiredAt, limit)
+
+	var r0 []*model.Status
+	if rf, ok := ret.Get(0).(func(int64, int) []*model.Status); ok {
+		r0 = rf(expiredAt, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Status)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, int) error); ok {
+		r1 = rf(expiredAt, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetTotalActiveUsersCount provides a mock function with given fields:
 func (_m *StatusStore) GetTotalActiveUsersCount() (int64, error) {
 	ret := _m.Called()
@@ -0,0 +1,75 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+// Regenerate this file using `make store-mocks`.
+
+package mocks
+
+import (
+	model "github.com/zacmm/zacmm-server/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// StorageUsageStore is an autogenerated mock type for the StorageUsageStore type
+type StorageUsageStore struct {
+	mock.Mock
+}
+
+// Get provides a mock function with given fields: targetType, targetId
+func (_m *StorageUsageStore) Get(targetType string, targetId string) (*model.StorageUsage, error) {
+	ret := _m.Called(targetType, targetId)
+
+	var r0 *model.StorageUsage
+	if rf, ok := ret.Get(0).(func(string, string) *model.StorageUsage); ok {
+		r0 = rf(targetType, targetId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.StorageUsage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(targetType, targetId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAll provides a mock function with given fields: targetType, offset, limit
+func (_m *StorageUsageStore) GetAll(targetType string, offset int, limit int) ([]*model.StorageUsage, error) {
+	ret := _m.Called(targetType, offset, limit)
+
+	var r0 []*model.StorageUsage
+	if rf, ok := ret.Get(0).(func(string, int, int) []*model.StorageUsage); ok {
+		r0 = rf(targetType, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.StorageUsage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int, int) error); ok {
+		r1 = rf(targetType, offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Recompute provides a mock function with given fields:
+func (_m *StorageUsageStore) Recompute() error {
+	ret := _m.Called()
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
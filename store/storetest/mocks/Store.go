@@ -68,6 +68,22 @@ func (_m *Store) Channel() store.ChannelStore {
 	return r0
 }
 
+// ChannelBookmark provides a mock function with given fields:
+func (_m *Store) ChannelBookmark() store.ChannelBookmarkStore {
+	ret := _m.Called()
+
+	var r0 store.ChannelBookmarkStore
+	if rf, ok := ret.Get(0).(func() store.ChannelBookmarkStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.ChannelBookmarkStore)
+		}
+	}
+
+	return r0
+}
+
 // ChannelMemberHistory provides a mock function with given fields:
 func (_m *Store) ChannelMemberHistory() store.ChannelMemberHistoryStore {
 	ret := _m.Called()
@@ -185,11 +201,91 @@ func (_m *Store) Context() context.Context {
 	return r0
 }
 
+// CustomStatus provides a mock function with given fields:
+func (_m *Store) CustomStatus() store.CustomStatusStore {
+	ret := _m.Called()
+
+	var r0 store.CustomStatusStore
+	if rf, ok := ret.Get(0).(func() store.CustomStatusStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.CustomStatusStore)
+		}
+	}
+
+	return r0
+}
+
+// DndSchedule provides a mock function with given fields:
+func (_m *Store) DndSchedule() store.DndScheduleStore {
+	ret := _m.Called()
+
+	var r0 store.DndScheduleStore
+	if rf, ok := ret.Get(0).(func() store.DndScheduleStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.DndScheduleStore)
+		}
+	}
+
+	return r0
+}
+
+// Draft provides a mock function with given fields:
+func (_m *Store) Draft() store.DraftStore {
+	ret := _m.Called()
+
+	var r0 store.DraftStore
+	if rf, ok := ret.Get(0).(func() store.DraftStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.DraftStore)
+		}
+	}
+
+	return r0
+}
+
 // DropAllTables provides a mock function with given fields:
 func (_m *Store) DropAllTables() {
 	_m.Called()
 }
 
+// EmailDigest provides a mock function with given fields:
+func (_m *Store) EmailDigest() store.EmailDigestStore {
+	ret := _m.Called()
+
+	var r0 store.EmailDigestStore
+	if rf, ok := ret.Get(0).(func() store.EmailDigestStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.EmailDigestStore)
+		}
+	}
+
+	return r0
+}
+
+// EmailQueue provides a mock function with given fields:
+func (_m *Store) EmailQueue() store.EmailQueueStore {
+	ret := _m.Called()
+
+	var r0 store.EmailQueueStore
+	if rf, ok := ret.Get(0).(func() store.EmailQueueStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.EmailQueueStore)
+		}
+	}
+
+	return r0
+}
+
 // Emoji provides a mock function with given fields:
 func (_m *Store) Emoji() store.EmojiStore {
 	ret := _m.Called()
@@ -289,6 +385,22 @@ func (_m *Store) Job() store.JobStore {
 	return r0
 }
 
+// KeywordWatch provides a mock function with given fields:
+func (_m *Store) KeywordWatch() store.KeywordWatchStore {
+	ret := _m.Called()
+
+	var r0 store.KeywordWatchStore
+	if rf, ok := ret.Get(0).(func() store.KeywordWatchStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.KeywordWatchStore)
+		}
+	}
+
+	return r0
+}
+
 // License provides a mock function with given fields:
 func (_m *Store) License() store.LicenseStore {
 	ret := _m.Called()
@@ -363,6 +475,22 @@ func (_m *Store) Plugin() store.PluginStore {
 	return r0
 }
 
+// Poll provides a mock function with given fields:
+func (_m *Store) Poll() store.PollStore {
+	ret := _m.Called()
+
+	var r0 store.PollStore
+	if rf, ok := ret.Get(0).(func() store.PollStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.PollStore)
+		}
+	}
+
+	return r0
+}
+
 // Post provides a mock function with given fields:
 func (_m *Store) Post() store.PostStore {
 	ret := _m.Called()
@@ -411,6 +539,22 @@ func (_m *Store) ProductNotices() store.ProductNoticesStore {
 	return r0
 }
 
+// PublicLink provides a mock function with given fields:
+func (_m *Store) PublicLink() store.PublicLinkStore {
+	ret := _m.Called()
+
+	var r0 store.PublicLinkStore
+	if rf, ok := ret.Get(0).(func() store.PublicLinkStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.PublicLinkStore)
+		}
+	}
+
+	return r0
+}
+
 // Reaction provides a mock function with given fields:
 func (_m *Store) Reaction() store.ReactionStore {
 	ret := _m.Called()
@@ -448,6 +592,22 @@ func (_m *Store) Role() store.RoleStore {
 	return r0
 }
 
+// SavedSearch provides a mock function with given fields:
+func (_m *Store) SavedSearch() store.SavedSearchStore {
+	ret := _m.Called()
+
+	var r0 store.SavedSearchStore
+	if rf, ok := ret.Get(0).(func() store.SavedSearchStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.SavedSearchStore)
+		}
+	}
+
+	return r0
+}
+
 // Scheme provides a mock function with given fields:
 func (_m *Store) Scheme() store.SchemeStore {
 	ret := _m.Called()
@@ -501,6 +661,22 @@ func (_m *Store) Status() store.StatusStore {
 	return r0
 }
 
+// StorageUsage provides a mock function with given fields:
+func (_m *Store) StorageUsage() store.StorageUsageStore {
+	ret := _m.Called()
+
+	var r0 store.StorageUsageStore
+	if rf, ok := ret.Get(0).(func() store.StorageUsageStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.StorageUsageStore)
+		}
+	}
+
+	return r0
+}
+
 // System provides a mock function with given fields:
 func (_m *Store) System() store.SystemStore {
 	ret := _m.Called()
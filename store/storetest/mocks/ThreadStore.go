@@ -171,6 +171,29 @@ func (_m *ThreadStore) GetThreadsForUser(userId string, opts model.GetUserThread
 	return r0, r1
 }
 
+// GetUnreadThreadCountsByTeam provides a mock function with given fields: userId
+func (_m *ThreadStore) GetUnreadThreadCountsByTeam(userId string) (map[string]int64, error) {
+	ret := _m.Called(userId)
+
+	var r0 map[string]int64
+	if rf, ok := ret.Get(0).(func(string) map[string]int64); ok {
+		r0 = rf(userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]int64)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // MarkAllAsRead provides a mock function with given fields: userId, timestamp
 func (_m *ThreadStore) MarkAllAsRead(userId string, timestamp int64) error {
 	ret := _m.Called(userId, timestamp)
@@ -51,6 +51,29 @@ func (_m *UploadSessionStore) Get(id string) (*model.UploadSession, error) {
 	return r0, r1
 }
 
+// GetAllPage provides a mock function with given fields: offset, limit
+func (_m *UploadSessionStore) GetAllPage(offset int, limit int) ([]*model.UploadSession, error) {
+	ret := _m.Called(offset, limit)
+
+	var r0 []*model.UploadSession
+	if rf, ok := ret.Get(0).(func(int, int) []*model.UploadSession); ok {
+		r0 = rf(offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.UploadSession)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int, int) error); ok {
+		r1 = rf(offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetForUser provides a mock function with given fields: userId
 func (_m *UploadSessionStore) GetForUser(userId string) ([]*model.UploadSession, error) {
 	ret := _m.Called(userId)
@@ -74,6 +97,29 @@ func (_m *UploadSessionStore) GetForUser(userId string) ([]*model.UploadSession,
 	return r0, r1
 }
 
+// GetUpdatedBefore provides a mock function with given fields: updateAt, limit
+func (_m *UploadSessionStore) GetUpdatedBefore(updateAt int64, limit int) ([]*model.UploadSession, error) {
+	ret := _m.Called(updateAt, limit)
+
+	var r0 []*model.UploadSession
+	if rf, ok := ret.Get(0).(func(int64, int) []*model.UploadSession); ok {
+		r0 = rf(updateAt, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.UploadSession)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, int) error); ok {
+		r1 = rf(updateAt, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Save provides a mock function with given fields: session
 func (_m *UploadSessionStore) Save(session *model.UploadSession) (*model.UploadSession, error) {
 	ret := _m.Called(session)
@@ -0,0 +1,301 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"testing"
+
+	"github.com/zacmm/zacmm-server/model"
+	"github.com/zacmm/zacmm-server/store"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPollStore(t *testing.T, ss store.Store) {
+	t.Run("PollStoreSaveGet", func(t *testing.T) { testPollStoreSaveGet(t, ss) })
+	t.Run("PollStoreUpdate", func(t *testing.T) { testPollStoreUpdate(t, ss) })
+	t.Run("PollStoreGetForPosts", func(t *testing.T) { testPollStoreGetForPosts(t, ss) })
+	t.Run("PollStoreGetPollsToClose", func(t *testing.T) { testPollStoreGetPollsToClose(t, ss) })
+	t.Run("PollStoreSaveVotes", func(t *testing.T) { testPollStoreSaveVotes(t, ss) })
+	t.Run("PollStoreUpdateTally", func(t *testing.T) { testPollStoreUpdateTally(t, ss) })
+}
+
+func testPollStoreSaveGet(t *testing.T, ss store.Store) {
+	poll, err := ss.Poll().Save(&model.Poll{
+		PostId:    model.NewId(),
+		ChannelId: model.NewId(),
+		UserId:    model.NewId(),
+		Question:  "Lunch?",
+		Options:   model.StringArray{"Pizza", "Sushi"},
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, poll.Id)
+	require.NotZero(t, poll.CreateAt)
+	require.Equal(t, poll.CreateAt, poll.UpdateAt)
+
+	deleted, err := ss.Poll().Save(&model.Poll{
+		PostId:    model.NewId(),
+		ChannelId: model.NewId(),
+		UserId:    model.NewId(),
+		Question:  "Dinner?",
+		Options:   model.StringArray{"Pasta", "Curry"},
+		DeleteAt:  model.GetMillis(),
+	})
+	require.NoError(t, err)
+
+	t.Run("getting non-existing poll should fail", func(t *testing.T) {
+		_, err := ss.Poll().Get(model.NewId())
+		require.Error(t, err)
+		require.IsType(t, &store.ErrNotFound{}, err)
+	})
+
+	t.Run("getting deleted poll should fail", func(t *testing.T) {
+		_, err := ss.Poll().Get(deleted.Id)
+		require.Error(t, err)
+		require.IsType(t, &store.ErrNotFound{}, err)
+	})
+
+	t.Run("getting existing poll should succeed", func(t *testing.T) {
+		received, err := ss.Poll().Get(poll.Id)
+		require.NoError(t, err)
+		require.Equal(t, poll, received)
+	})
+}
+
+func testPollStoreUpdate(t *testing.T, ss store.Store) {
+	poll, err := ss.Poll().Save(&model.Poll{
+		PostId:    model.NewId(),
+		ChannelId: model.NewId(),
+		UserId:    model.NewId(),
+		Question:  "Lunch?",
+		Options:   model.StringArray{"Pizza", "Sushi"},
+		CreateAt:  model.GetMillis() - 1000,
+	})
+	require.NoError(t, err)
+	createAt := poll.CreateAt
+
+	poll.ClosedAt = model.GetMillis()
+	updated, err := ss.Poll().Update(poll)
+	require.NoError(t, err)
+	assert.Greater(t, updated.UpdateAt, createAt)
+
+	received, err := ss.Poll().Get(poll.Id)
+	require.NoError(t, err)
+	assert.Equal(t, poll.ClosedAt, received.ClosedAt)
+	assert.Equal(t, createAt, received.CreateAt)
+}
+
+func testPollStoreGetForPosts(t *testing.T, ss store.Store) {
+	postId1 := model.NewId()
+	postId2 := model.NewId()
+
+	poll1, err := ss.Poll().Save(&model.Poll{
+		PostId:    postId1,
+		ChannelId: model.NewId(),
+		UserId:    model.NewId(),
+		Question:  "Lunch?",
+		Options:   model.StringArray{"Pizza", "Sushi"},
+	})
+	require.NoError(t, err)
+
+	_, err = ss.Poll().Save(&model.Poll{
+		PostId:    postId2,
+		ChannelId: model.NewId(),
+		UserId:    model.NewId(),
+		Question:  "Dinner?",
+		Options:   model.StringArray{"Pasta", "Curry"},
+		DeleteAt:  model.GetMillis(),
+	})
+	require.NoError(t, err)
+
+	t.Run("no post ids", func(t *testing.T) {
+		polls, err := ss.Poll().GetForPosts([]string{})
+		require.NoError(t, err)
+		require.Empty(t, polls)
+	})
+
+	t.Run("deleted polls are left out", func(t *testing.T) {
+		polls, err := ss.Poll().GetForPosts([]string{postId1, postId2, model.NewId()})
+		require.NoError(t, err)
+		require.Equal(t, []*model.Poll{poll1}, polls)
+	})
+}
+
+func testPollStoreGetPollsToClose(t *testing.T, ss store.Store) {
+	now := model.GetMillis()
+
+	var dueIds []string
+	for _, closeAt := range []int64{now - 1000, now - 3000, now - 2000} {
+		poll, err := ss.Poll().Save(&model.Poll{
+			PostId:    model.NewId(),
+			ChannelId: model.NewId(),
+			UserId:    model.NewId(),
+			Question:  "Lunch?",
+			Options:   model.StringArray{"Pizza", "Sushi"},
+			CreateAt:  now - 5000,
+			CloseAt:   closeAt,
+		})
+		require.NoError(t, err)
+		dueIds = append(dueIds, poll.Id)
+	}
+
+	notDue, err := ss.Poll().Save(&model.Poll{
+		PostId:    model.NewId(),
+		ChannelId: model.NewId(),
+		UserId:    model.NewId(),
+		Question:  "Dinner?",
+		Options:   model.StringArray{"Pasta", "Curry"},
+		CloseAt:   now + 60000,
+	})
+	require.NoError(t, err)
+
+	alreadyClosed, err := ss.Poll().Save(&model.Poll{
+		PostId:    model.NewId(),
+		ChannelId: model.NewId(),
+		UserId:    model.NewId(),
+		Question:  "Breakfast?",
+		Options:   model.StringArray{"Eggs", "Toast"},
+		CreateAt:  now - 5000,
+		CloseAt:   now - 1000,
+		ClosedAt:  now - 1000,
+	})
+	require.NoError(t, err)
+
+	t.Run("only open polls past their close time are returned", func(t *testing.T) {
+		polls, err := ss.Poll().GetPollsToClose(now, 1000)
+		require.NoError(t, err)
+
+		ids := make([]string, 0, len(polls))
+		for _, poll := range polls {
+			ids = append(ids, poll.Id)
+		}
+		assert.Subset(t, ids, dueIds)
+		assert.NotContains(t, ids, notDue.Id)
+		assert.NotContains(t, ids, alreadyClosed.Id)
+	})
+
+	t.Run("polls are returned by close time", func(t *testing.T) {
+		polls, err := ss.Poll().GetPollsToClose(now, 1000)
+		require.NoError(t, err)
+
+		for i := 1; i < len(polls); i++ {
+			assert.LessOrEqual(t, polls[i-1].CloseAt, polls[i].CloseAt)
+		}
+	})
+
+	t.Run("limit", func(t *testing.T) {
+		polls, err := ss.Poll().GetPollsToClose(now, 1)
+		require.NoError(t, err)
+		require.Len(t, polls, 1)
+	})
+}
+
+func testPollStoreSaveVotes(t *testing.T, ss store.Store) {
+	post, err := ss.Post().Save(&model.Post{
+		ChannelId: model.NewId(),
+		UserId:    model.NewId(),
+		Message:   "Lunch?",
+		Type:      model.POST_POLL,
+	})
+	require.NoError(t, err)
+
+	poll, err := ss.Poll().Save(&model.Poll{
+		PostId:         post.Id,
+		ChannelId:      post.ChannelId,
+		UserId:         post.UserId,
+		Question:       "Lunch?",
+		Options:        model.StringArray{"Pizza", "Sushi", "Tacos"},
+		MultipleChoice: true,
+	})
+	require.NoError(t, err)
+
+	userId1 := model.NewId()
+	userId2 := model.NewId()
+
+	_, err = ss.Poll().SaveVotes(poll.Id, userId1, []*model.PollVote{{OptionIndex: 0}, {OptionIndex: 2}})
+	require.NoError(t, err)
+	updated, err := ss.Poll().SaveVotes(poll.Id, userId2, []*model.PollVote{{OptionIndex: 2}})
+	require.NoError(t, err)
+
+	t.Run("the tally is stored in the post", func(t *testing.T) {
+		results, ok := updated.GetProp(model.POST_PROPS_POLL_RESULTS).(*model.PollResults)
+		require.True(t, ok)
+		assert.Equal(t, []int{1, 0, 2}, results.Counts)
+		assert.Equal(t, 2, results.TotalVoters)
+		assert.Nil(t, results.Voters)
+
+		received, err := ss.Post().GetSingle(post.Id)
+		require.NoError(t, err)
+		assert.Greater(t, received.UpdateAt, post.UpdateAt)
+		results2, ok := received.GetProp(model.POST_PROPS_POLL_RESULTS).(map[string]interface{})
+		require.True(t, ok)
+		assert.Equal(t, []interface{}{float64(1), float64(0), float64(2)}, results2["counts"])
+	})
+
+	t.Run("saving votes replaces previous votes", func(t *testing.T) {
+		updated, err := ss.Poll().SaveVotes(poll.Id, userId1, []*model.PollVote{{OptionIndex: 1}})
+		require.NoError(t, err)
+
+		votes, err := ss.Poll().GetVotes(poll.Id)
+		require.NoError(t, err)
+		require.Len(t, votes, 2)
+
+		results := updated.GetProp(model.POST_PROPS_POLL_RESULTS).(*model.PollResults)
+		assert.Equal(t, []int{0, 1, 1}, results.Counts)
+	})
+
+	t.Run("saving no votes removes votes", func(t *testing.T) {
+		updated, err := ss.Poll().SaveVotes(poll.Id, userId2, []*model.PollVote{})
+		require.NoError(t, err)
+
+		votes, err := ss.Poll().GetVotes(poll.Id)
+		require.NoError(t, err)
+		require.Len(t, votes, 1)
+		require.Equal(t, userId1, votes[0].UserId)
+
+		results := updated.GetProp(model.POST_PROPS_POLL_RESULTS).(*model.PollResults)
+		assert.Equal(t, []int{0, 1, 0}, results.Counts)
+		assert.Equal(t, 1, results.TotalVoters)
+	})
+
+	t.Run("voting on a non-existing poll should fail", func(t *testing.T) {
+		_, err := ss.Poll().SaveVotes(model.NewId(), userId1, []*model.PollVote{{OptionIndex: 0}})
+		require.Error(t, err)
+		require.IsType(t, &store.ErrNotFound{}, err)
+	})
+}
+
+func testPollStoreUpdateTally(t *testing.T, ss store.Store) {
+	post, err := ss.Post().Save(&model.Post{
+		ChannelId: model.NewId(),
+		UserId:    model.NewId(),
+		Message:   "Lunch?",
+		Type:      model.POST_POLL,
+	})
+	require.NoError(t, err)
+
+	poll, err := ss.Poll().Save(&model.Poll{
+		PostId:    post.Id,
+		ChannelId: post.ChannelId,
+		UserId:    post.UserId,
+		Question:  "Lunch?",
+		Options:   model.StringArray{"Pizza", "Sushi"},
+	})
+	require.NoError(t, err)
+
+	_, err = ss.Poll().SaveVotes(poll.Id, model.NewId(), []*model.PollVote{{OptionIndex: 1}})
+	require.NoError(t, err)
+
+	poll.ClosedAt = model.GetMillis()
+	_, err = ss.Poll().Update(poll)
+	require.NoError(t, err)
+
+	updated, err := ss.Poll().UpdateTally(poll.Id)
+	require.NoError(t, err)
+
+	results := updated.GetProp(model.POST_PROPS_POLL_RESULTS).(*model.PollResults)
+	assert.Equal(t, []int{0, 1}, results.Counts)
+	assert.Equal(t, poll.ClosedAt, results.ClosedAt)
+}
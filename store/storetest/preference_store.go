@@ -18,6 +18,7 @@ func TestPreferenceStore(t *testing.T, ss store.Store) {
 	t.Run("PreferenceGet", func(t *testing.T) { testPreferenceGet(t, ss) })
 	t.Run("PreferenceGetCategory", func(t *testing.T) { testPreferenceGetCategory(t, ss) })
 	t.Run("PreferenceGetAll", func(t *testing.T) { testPreferenceGetAll(t, ss) })
+	t.Run("PreferenceGetForUsers", func(t *testing.T) { testPreferenceGetForUsers(t, ss) })
 	t.Run("PreferenceDeleteByUser", func(t *testing.T) { testPreferenceDeleteByUser(t, ss) })
 	t.Run("PreferenceDelete", func(t *testing.T) { testPreferenceDelete(t, ss) })
 	t.Run("PreferenceDeleteCategory", func(t *testing.T) { testPreferenceDeleteCategory(t, ss) })
@@ -194,6 +195,52 @@ func testPreferenceGetAll(t *testing.T, ss store.Store) {
 
 }
 
+func testPreferenceGetForUsers(t *testing.T, ss store.Store) {
+	userId1 := model.NewId()
+	userId2 := model.NewId()
+	category := model.PREFERENCE_CATEGORY_DISPLAY_SETTINGS
+	name := model.NewId()
+
+	preferences := model.Preferences{
+		{
+			UserId:   userId1,
+			Category: category,
+			Name:     name,
+			Value:    "true",
+		},
+		{
+			UserId:   userId2,
+			Category: category,
+			Name:     name,
+			Value:    "false",
+		},
+		// same user/category, different name
+		{
+			UserId:   userId1,
+			Category: category,
+			Name:     model.NewId(),
+		},
+		// same name/category, user not requested
+		{
+			UserId:   model.NewId(),
+			Category: category,
+			Name:     name,
+		},
+	}
+
+	err := ss.Preference().Save(&preferences)
+	require.Nil(t, err)
+
+	result, err := ss.Preference().GetForUsers([]string{userId1, userId2}, category, name)
+	require.Nil(t, err)
+	require.Len(t, result, 2)
+	assert.ElementsMatch(t, model.Preferences{preferences[0], preferences[1]}, result)
+
+	result, err = ss.Preference().GetForUsers([]string{}, category, name)
+	require.Nil(t, err)
+	assert.Empty(t, result)
+}
+
 func testPreferenceDeleteByUser(t *testing.T, ss store.Store) {
 	userId := model.NewId()
 	category := model.PREFERENCE_CATEGORY_DIRECT_CHANNEL_SHOW
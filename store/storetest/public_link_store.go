@@ -0,0 +1,129 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/zacmm/zacmm-server/model"
+	"github.com/zacmm/zacmm-server/store"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublicLinkStore(t *testing.T, ss store.Store) {
+	t.Run("PublicLinkSaveGet", func(t *testing.T) { testPublicLinkSaveGet(t, ss) })
+	t.Run("PublicLinkGetActive", func(t *testing.T) { testPublicLinkGetActive(t, ss) })
+	t.Run("PublicLinkGetForUser", func(t *testing.T) { testPublicLinkGetForUser(t, ss) })
+	t.Run("PublicLinkRevoke", func(t *testing.T) { testPublicLinkRevoke(t, ss) })
+	t.Run("PublicLinkRecordDownload", func(t *testing.T) { testPublicLinkRecordDownload(t, ss) })
+}
+
+func testPublicLinkSaveGet(t *testing.T, ss store.Store) {
+	link, err := ss.PublicLink().Save(&model.PublicLink{FileId: model.NewId(), CreatorId: model.NewId()})
+	require.Nil(t, err)
+	assert.NotEmpty(t, link.Id)
+	assert.NotZero(t, link.CreateAt)
+
+	fetched, err := ss.PublicLink().Get(link.Id)
+	require.Nil(t, err)
+	assert.Equal(t, link, fetched)
+
+	_, err = ss.PublicLink().Save(&model.PublicLink{FileId: model.NewId()})
+	require.NotNil(t, err)
+
+	_, err = ss.PublicLink().Get(model.NewId())
+	var nfErr *store.ErrNotFound
+	assert.True(t, errors.As(err, &nfErr))
+}
+
+func testPublicLinkGetActive(t *testing.T, ss store.Store) {
+	fileId := model.NewId()
+	creatorId := model.NewId()
+	now := model.GetMillis()
+
+	_, err := ss.PublicLink().GetActive(fileId, creatorId, now)
+	var nfErr *store.ErrNotFound
+	require.True(t, errors.As(err, &nfErr))
+
+	permanent, err := ss.PublicLink().Save(&model.PublicLink{FileId: fileId, CreatorId: creatorId, CreateAt: now - 3000})
+	require.Nil(t, err)
+	expiring, err := ss.PublicLink().Save(&model.PublicLink{FileId: fileId, CreatorId: creatorId, CreateAt: now - 2000, ExpireAt: now + 1000})
+	require.Nil(t, err)
+	_, err = ss.PublicLink().Save(&model.PublicLink{FileId: fileId, CreatorId: creatorId, CreateAt: now - 1000, ExpireAt: now - 500})
+	require.Nil(t, err)
+	_, err = ss.PublicLink().Save(&model.PublicLink{FileId: fileId, CreatorId: model.NewId(), CreateAt: now})
+	require.Nil(t, err)
+
+	link, err := ss.PublicLink().GetActive(fileId, creatorId, now)
+	require.Nil(t, err)
+	assert.Equal(t, expiring.Id, link.Id)
+
+	link, err = ss.PublicLink().GetActive(fileId, creatorId, now+2000)
+	require.Nil(t, err)
+	assert.Equal(t, permanent.Id, link.Id)
+
+	require.Nil(t, ss.PublicLink().Revoke(permanent.Id, now))
+	_, err = ss.PublicLink().GetActive(fileId, creatorId, now+2000)
+	require.True(t, errors.As(err, &nfErr))
+}
+
+func testPublicLinkGetForUser(t *testing.T, ss store.Store) {
+	creatorId := model.NewId()
+	now := model.GetMillis()
+
+	var links []*model.PublicLink
+	for i := 0; i < 3; i++ {
+		link, err := ss.PublicLink().Save(&model.PublicLink{FileId: model.NewId(), CreatorId: creatorId, CreateAt: now + int64(i)})
+		require.Nil(t, err)
+		links = append(links, link)
+	}
+	_, err := ss.PublicLink().Save(&model.PublicLink{FileId: model.NewId(), CreatorId: model.NewId()})
+	require.Nil(t, err)
+
+	fetched, err := ss.PublicLink().GetForUser(creatorId, 0, 2)
+	require.Nil(t, err)
+	require.Len(t, fetched, 2)
+	assert.Equal(t, links[2].Id, fetched[0].Id)
+	assert.Equal(t, links[1].Id, fetched[1].Id)
+
+	fetched, err = ss.PublicLink().GetForUser(creatorId, 2, 2)
+	require.Nil(t, err)
+	require.Len(t, fetched, 1)
+	assert.Equal(t, links[0].Id, fetched[0].Id)
+}
+
+func testPublicLinkRevoke(t *testing.T, ss store.Store) {
+	link, err := ss.PublicLink().Save(&model.PublicLink{FileId: model.NewId(), CreatorId: model.NewId()})
+	require.Nil(t, err)
+
+	require.Nil(t, ss.PublicLink().Revoke(link.Id, 1234))
+
+	fetched, err := ss.PublicLink().Get(link.Id)
+	require.Nil(t, err)
+	assert.Equal(t, int64(1234), fetched.RevokeAt)
+
+	// A link can't be revoked twice.
+	err = ss.PublicLink().Revoke(link.Id, 5678)
+	var nfErr *store.ErrNotFound
+	assert.True(t, errors.As(err, &nfErr))
+
+	err = ss.PublicLink().Revoke(model.NewId(), 5678)
+	assert.True(t, errors.As(err, &nfErr))
+}
+
+func testPublicLinkRecordDownload(t *testing.T, ss store.Store) {
+	link, err := ss.PublicLink().Save(&model.PublicLink{FileId: model.NewId(), CreatorId: model.NewId()})
+	require.Nil(t, err)
+
+	require.Nil(t, ss.PublicLink().RecordDownload(link.Id, 1000))
+	require.Nil(t, ss.PublicLink().RecordDownload(link.Id, 2000))
+
+	fetched, err := ss.PublicLink().Get(link.Id)
+	require.Nil(t, err)
+	assert.Equal(t, int64(2), fetched.DownloadCount)
+	assert.Equal(t, int64(2000), fetched.LastDownloadAt)
+}
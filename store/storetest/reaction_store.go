@@ -23,6 +23,7 @@ func TestReactionStore(t *testing.T, ss store.Store) {
 	t.Run("ReactionDeleteAllWithEmojiName", func(t *testing.T) { testReactionDeleteAllWithEmojiName(t, ss) })
 	t.Run("PermanentDeleteBatch", func(t *testing.T) { testReactionStorePermanentDeleteBatch(t, ss) })
 	t.Run("ReactionBulkGetForPosts", func(t *testing.T) { testReactionBulkGetForPosts(t, ss) })
+	t.Run("ReactionGetPostIdsForUsers", func(t *testing.T) { testReactionGetPostIdsForUsers(t, ss) })
 	t.Run("ReactionDeadlock", func(t *testing.T) { testReactionDeadlock(t, ss) })
 }
 
@@ -432,6 +433,59 @@ func testReactionBulkGetForPosts(t *testing.T, ss store.Store) {
 
 }
 
+func testReactionGetPostIdsForUsers(t *testing.T, ss store.Store) {
+	postId := model.NewId()
+	post2Id := model.NewId()
+	post3Id := model.NewId()
+
+	userId := model.NewId()
+	user2Id := model.NewId()
+
+	reactions := []*model.Reaction{
+		{
+			UserId:    userId,
+			PostId:    postId,
+			EmojiName: "smile",
+			CreateAt:  1000,
+		},
+		{
+			UserId:    userId,
+			PostId:    postId,
+			EmojiName: "angry",
+			CreateAt:  1001,
+		},
+		{
+			UserId:    user2Id,
+			PostId:    post2Id,
+			EmojiName: "smile",
+			CreateAt:  2000,
+		},
+		{
+			UserId:    model.NewId(),
+			PostId:    post3Id,
+			EmojiName: "smile",
+			CreateAt:  3000,
+		},
+	}
+
+	for _, reaction := range reactions {
+		_, err := ss.Reaction().Save(reaction)
+		require.Nil(t, err)
+	}
+
+	postIds, err := ss.Reaction().GetPostIdsForUsers([]string{userId, user2Id}, 10)
+	require.Nil(t, err)
+	assert.Equal(t, []string{post2Id, postId}, postIds)
+
+	postIds, err = ss.Reaction().GetPostIdsForUsers([]string{userId, user2Id}, 1)
+	require.Nil(t, err)
+	assert.Equal(t, []string{post2Id}, postIds)
+
+	postIds, err = ss.Reaction().GetPostIdsForUsers([]string{model.NewId()}, 10)
+	require.Nil(t, err)
+	assert.Empty(t, postIds)
+}
+
 // testReactionDeadlock is a best-case attempt to recreate the deadlock scenario.
 // It at least deadlocks 2 times out of 5.
 func testReactionDeadlock(t *testing.T, ss store.Store) {
@@ -0,0 +1,201 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/zacmm/zacmm-server/model"
+	"github.com/zacmm/zacmm-server/store"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSavedSearchStore(t *testing.T, ss store.Store) {
+	t.Run("SavedSearchStoreSaveGet", func(t *testing.T) { testSavedSearchStoreSaveGet(t, ss) })
+	t.Run("SavedSearchStoreUpdate", func(t *testing.T) { testSavedSearchStoreUpdate(t, ss) })
+	t.Run("SavedSearchStoreGetForUser", func(t *testing.T) { testSavedSearchStoreGetForUser(t, ss) })
+	t.Run("SavedSearchStoreGetForNotification", func(t *testing.T) { testSavedSearchStoreGetForNotification(t, ss) })
+	t.Run("SavedSearchStoreDelete", func(t *testing.T) { testSavedSearchStoreDelete(t, ss) })
+	t.Run("SavedSearchStoreUpdateLastCheckedAt", func(t *testing.T) { testSavedSearchStoreUpdateLastCheckedAt(t, ss) })
+}
+
+func testSavedSearchStoreSaveGet(t *testing.T, ss store.Store) {
+	search, err := ss.SavedSearch().Save(&model.SavedSearch{
+		UserId: model.NewId(),
+		TeamId: model.NewId(),
+		Name:   "Incidents",
+		Terms:  "from:alertbot in:prod-incidents error",
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, search.Id)
+	assert.Equal(t, search.CreateAt, search.LastCheckedAt)
+
+	received, err := ss.SavedSearch().Get(search.Id)
+	require.NoError(t, err)
+	assert.Equal(t, search, received)
+
+	_, err = ss.SavedSearch().Get(model.NewId())
+	var nfErr *store.ErrNotFound
+	require.True(t, errors.As(err, &nfErr))
+}
+
+func testSavedSearchStoreUpdate(t *testing.T, ss store.Store) {
+	search, err := ss.SavedSearch().Save(&model.SavedSearch{
+		UserId: model.NewId(),
+		TeamId: model.NewId(),
+		Name:   "Incidents",
+		Terms:  "from:alertbot in:prod-incidents error",
+	})
+	require.NoError(t, err)
+
+	search.Name = "Errors"
+	search.Terms = "error"
+	search.NotifyOnMatch = true
+	_, err = ss.SavedSearch().Update(search)
+	require.NoError(t, err)
+
+	received, err := ss.SavedSearch().Get(search.Id)
+	require.NoError(t, err)
+	assert.Equal(t, "Errors", received.Name)
+	assert.Equal(t, "error", received.Terms)
+	assert.True(t, received.NotifyOnMatch)
+
+	_, err = ss.SavedSearch().Update(&model.SavedSearch{
+		Id:       model.NewId(),
+		UserId:   model.NewId(),
+		TeamId:   model.NewId(),
+		Name:     "Incidents",
+		Terms:    "error",
+		CreateAt: model.GetMillis(),
+	})
+	var nfErr *store.ErrNotFound
+	require.True(t, errors.As(err, &nfErr))
+}
+
+func testSavedSearchStoreGetForUser(t *testing.T, ss store.Store) {
+	userId := model.NewId()
+
+	search1, err := ss.SavedSearch().Save(&model.SavedSearch{
+		UserId: userId,
+		TeamId: model.NewId(),
+		Name:   "Incidents",
+		Terms:  "from:alertbot in:prod-incidents error",
+	})
+	require.NoError(t, err)
+	search2, err := ss.SavedSearch().Save(&model.SavedSearch{
+		UserId:        userId,
+		TeamId:        model.NewId(),
+		Name:          "Incidents",
+		Terms:         "from:alertbot in:prod-incidents error",
+		NotifyOnMatch: true,
+	})
+	require.NoError(t, err)
+	_, err = ss.SavedSearch().Save(&model.SavedSearch{
+		UserId: model.NewId(),
+		TeamId: model.NewId(),
+		Name:   "Incidents",
+		Terms:  "from:alertbot in:prod-incidents error",
+	})
+	require.NoError(t, err)
+
+	searches, err := ss.SavedSearch().GetForUser(userId)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []*model.SavedSearch{search1, search2}, searches)
+
+	searches, err = ss.SavedSearch().GetForUser(model.NewId())
+	require.NoError(t, err)
+	assert.Empty(t, searches)
+}
+
+func testSavedSearchStoreGetForNotification(t *testing.T, ss store.Store) {
+	userId := model.NewId()
+
+	_, err := ss.SavedSearch().Save(&model.SavedSearch{
+		UserId: userId,
+		TeamId: model.NewId(),
+		Name:   "Incidents",
+		Terms:  "from:alertbot in:prod-incidents error",
+	})
+	require.NoError(t, err)
+	search1, err := ss.SavedSearch().Save(&model.SavedSearch{
+		UserId:        userId,
+		TeamId:        model.NewId(),
+		Name:          "Incidents",
+		Terms:         "from:alertbot in:prod-incidents error",
+		NotifyOnMatch: true,
+	})
+	require.NoError(t, err)
+	search2, err := ss.SavedSearch().Save(&model.SavedSearch{
+		UserId:        userId,
+		TeamId:        model.NewId(),
+		Name:          "Incidents",
+		Terms:         "from:alertbot in:prod-incidents error",
+		NotifyOnMatch: true,
+	})
+	require.NoError(t, err)
+
+	var searches []*model.SavedSearch
+	afterId := ""
+	for {
+		page, err := ss.SavedSearch().GetForNotification(afterId, 1)
+		require.NoError(t, err)
+		if len(page) == 0 {
+			break
+		}
+		require.Len(t, page, 1)
+		assert.True(t, page[0].NotifyOnMatch)
+		assert.True(t, page[0].Id > afterId)
+
+		searches = append(searches, page...)
+		afterId = page[0].Id
+	}
+
+	var ids []string
+	for _, search := range searches {
+		if search.UserId == userId {
+			ids = append(ids, search.Id)
+		}
+	}
+	assert.ElementsMatch(t, []string{search1.Id, search2.Id}, ids)
+}
+
+func testSavedSearchStoreDelete(t *testing.T, ss store.Store) {
+	search, err := ss.SavedSearch().Save(&model.SavedSearch{
+		UserId: model.NewId(),
+		TeamId: model.NewId(),
+		Name:   "Incidents",
+		Terms:  "from:alertbot in:prod-incidents error",
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, ss.SavedSearch().Delete(search.Id))
+
+	_, err = ss.SavedSearch().Get(search.Id)
+	var nfErr *store.ErrNotFound
+	require.True(t, errors.As(err, &nfErr))
+
+	err = ss.SavedSearch().Delete(search.Id)
+	require.True(t, errors.As(err, &nfErr))
+}
+
+func testSavedSearchStoreUpdateLastCheckedAt(t *testing.T, ss store.Store) {
+	search, err := ss.SavedSearch().Save(&model.SavedSearch{
+		UserId:        model.NewId(),
+		TeamId:        model.NewId(),
+		Name:          "Incidents",
+		Terms:         "from:alertbot in:prod-incidents error",
+		NotifyOnMatch: true,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, ss.SavedSearch().UpdateLastCheckedAt(search.Id, search.LastCheckedAt+1000))
+
+	received, err := ss.SavedSearch().Get(search.Id)
+	require.NoError(t, err)
+	assert.Equal(t, search.LastCheckedAt+1000, received.LastCheckedAt)
+	assert.Equal(t, search.UpdateAt, received.UpdateAt)
+}
@@ -15,6 +15,7 @@ import (
 func TestStatusStore(t *testing.T, ss store.Store) {
 	t.Run("", func(t *testing.T) { testStatusStore(t, ss) })
 	t.Run("ActiveUserCount", func(t *testing.T) { testActiveUserCount(t, ss) })
+	t.Run("GetExpiredDND", func(t *testing.T) { testGetExpiredDND(t, ss) })
 }
 
 func testStatusStore(t *testing.T, ss store.Store) {
@@ -56,6 +57,48 @@ func testActiveUserCount(t *testing.T, ss store.Store) {
 	require.True(t, count > 0, "expected count > 0, got %d", count)
 }
 
+func testGetExpiredDND(t *testing.T, ss store.Store) {
+	now := model.GetMillis()
+
+	expired := &model.Status{UserId: model.NewId(), Status: model.STATUS_DND, Manual: true, DNDEndTime: now - 2000, PrevStatus: model.STATUS_AWAY}
+	require.Nil(t, ss.Status().SaveOrUpdate(expired))
+
+	expiredLater := &model.Status{UserId: model.NewId(), Status: model.STATUS_DND, Manual: true, DNDEndTime: now - 1000, PrevStatus: model.STATUS_ONLINE}
+	require.Nil(t, ss.Status().SaveOrUpdate(expiredLater))
+
+	notExpired := &model.Status{UserId: model.NewId(), Status: model.STATUS_DND, Manual: true, DNDEndTime: now + 60000}
+	require.Nil(t, ss.Status().SaveOrUpdate(notExpired))
+
+	untimed := &model.Status{UserId: model.NewId(), Status: model.STATUS_DND, Manual: true}
+	require.Nil(t, ss.Status().SaveOrUpdate(untimed))
+
+	notDnd := &model.Status{UserId: model.NewId(), Status: model.STATUS_ONLINE, DNDEndTime: now - 2000}
+	require.Nil(t, ss.Status().SaveOrUpdate(notDnd))
+
+	statuses, err := ss.Status().GetExpiredDND(now, 100)
+	require.Nil(t, err)
+
+	var userIds []string
+	for _, status := range statuses {
+		userIds = append(userIds, status.UserId)
+	}
+	require.Contains(t, userIds, expired.UserId)
+	require.Contains(t, userIds, expiredLater.UserId)
+	require.NotContains(t, userIds, notExpired.UserId)
+	require.NotContains(t, userIds, untimed.UserId)
+	require.NotContains(t, userIds, notDnd.UserId)
+
+	for _, status := range statuses {
+		if status.UserId == expired.UserId {
+			require.Equal(t, model.STATUS_AWAY, status.PrevStatus)
+		}
+	}
+
+	statuses, err = ss.Status().GetExpiredDND(now, 1)
+	require.Nil(t, err)
+	require.Len(t, statuses, 1)
+}
+
 type ByUserId []*model.Status
 
 func (s ByUserId) Len() int           { return len(s) }
@@ -0,0 +1,210 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/zacmm/zacmm-server/model"
+	"github.com/zacmm/zacmm-server/store"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorageUsageStore(t *testing.T, ss store.Store) {
+	t.Run("StorageUsageTrackSaveDelete", func(t *testing.T) { testStorageUsageTrackSaveDelete(t, ss) })
+	t.Run("StorageUsageTrackPermanentDelete", func(t *testing.T) { testStorageUsageTrackPermanentDelete(t, ss) })
+	t.Run("StorageUsageTrackQuarantine", func(t *testing.T) { testStorageUsageTrackQuarantine(t, ss) })
+	t.Run("StorageUsageGetAll", func(t *testing.T) { testStorageUsageGetAll(t, ss) })
+	t.Run("StorageUsageRecompute", func(t *testing.T) { testStorageUsageRecompute(t, ss) })
+}
+
+func saveFileForStorageUsage(t *testing.T, ss store.Store, creatorId, teamId, postId string, size int64) *model.FileInfo {
+	info, err := ss.FileInfo().Save(&model.FileInfo{
+		CreatorId: creatorId,
+		TeamId:    teamId,
+		PostId:    postId,
+		Path:      "file-" + model.NewId() + ".txt",
+		Size:      size,
+	})
+	require.Nil(t, err)
+	return info
+}
+
+func requireStorageUsage(t *testing.T, ss store.Store, targetType, targetId string, usedBytes, fileCount int64) {
+	t.Helper()
+	usage, err := ss.StorageUsage().Get(targetType, targetId)
+	require.Nil(t, err)
+	assert.Equal(t, usedBytes, usage.UsedBytes)
+	assert.Equal(t, fileCount, usage.FileCount)
+}
+
+func testStorageUsageTrackSaveDelete(t *testing.T, ss store.Store) {
+	userId := model.NewId()
+	teamId := model.NewId()
+
+	_, err := ss.StorageUsage().Get(model.STORAGE_USAGE_TARGET_USER, userId)
+	var nfErr *store.ErrNotFound
+	require.True(t, errors.As(err, &nfErr))
+
+	postId := model.NewId()
+	saveFileForStorageUsage(t, ss, userId, teamId, postId, 100)
+	saveFileForStorageUsage(t, ss, userId, teamId, postId, 20)
+	saveFileForStorageUsage(t, ss, userId, "", model.NewId(), 3)
+
+	requireStorageUsage(t, ss, model.STORAGE_USAGE_TARGET_USER, userId, 123, 3)
+	requireStorageUsage(t, ss, model.STORAGE_USAGE_TARGET_TEAM, teamId, 120, 2)
+
+	t.Run("upserting an existing file should not count it twice", func(t *testing.T) {
+		info := saveFileForStorageUsage(t, ss, userId, teamId, "", 1000)
+		info.MiniPreview = &[]byte{1}
+		_, err := ss.FileInfo().Upsert(info)
+		require.Nil(t, err)
+
+		requireStorageUsage(t, ss, model.STORAGE_USAGE_TARGET_USER, userId, 1123, 4)
+
+		require.Nil(t, ss.FileInfo().PermanentDelete(info.Id))
+		requireStorageUsage(t, ss, model.STORAGE_USAGE_TARGET_USER, userId, 123, 3)
+	})
+
+	t.Run("deleting the files of a post should subtract them", func(t *testing.T) {
+		_, err := ss.FileInfo().DeleteForPost(postId)
+		require.Nil(t, err)
+
+		requireStorageUsage(t, ss, model.STORAGE_USAGE_TARGET_USER, userId, 3, 1)
+		requireStorageUsage(t, ss, model.STORAGE_USAGE_TARGET_TEAM, teamId, 0, 0)
+
+		// Deleting them again changes nothing.
+		_, err = ss.FileInfo().DeleteForPost(postId)
+		require.Nil(t, err)
+		requireStorageUsage(t, ss, model.STORAGE_USAGE_TARGET_USER, userId, 3, 1)
+	})
+}
+
+func testStorageUsageTrackPermanentDelete(t *testing.T, ss store.Store) {
+	userId := model.NewId()
+	teamId := model.NewId()
+
+	info := saveFileForStorageUsage(t, ss, userId, teamId, "", 10)
+	saveFileForStorageUsage(t, ss, userId, teamId, "", 20)
+	deletedPostId := model.NewId()
+	saveFileForStorageUsage(t, ss, userId, teamId, deletedPostId, 40)
+	_, err := ss.FileInfo().DeleteForPost(deletedPostId)
+	require.Nil(t, err)
+	requireStorageUsage(t, ss, model.STORAGE_USAGE_TARGET_USER, userId, 30, 2)
+
+	require.Nil(t, ss.FileInfo().PermanentDelete(info.Id))
+	requireStorageUsage(t, ss, model.STORAGE_USAGE_TARGET_USER, userId, 20, 1)
+	requireStorageUsage(t, ss, model.STORAGE_USAGE_TARGET_TEAM, teamId, 20, 1)
+
+	// The file that was already deleted isn't subtracted again.
+	_, err = ss.FileInfo().PermanentDeleteByUser(userId)
+	require.Nil(t, err)
+	requireStorageUsage(t, ss, model.STORAGE_USAGE_TARGET_USER, userId, 0, 0)
+	requireStorageUsage(t, ss, model.STORAGE_USAGE_TARGET_TEAM, teamId, 0, 0)
+
+	t.Run("retention should subtract the files it deletes", func(t *testing.T) {
+		otherUserId := model.NewId()
+		old, err := ss.FileInfo().Save(&model.FileInfo{
+			CreatorId: otherUserId,
+			TeamId:    teamId,
+			Path:      "file-" + model.NewId() + ".txt",
+			Size:      5,
+			CreateAt:  1000,
+		})
+		require.Nil(t, err)
+		saveFileForStorageUsage(t, ss, otherUserId, teamId, "", 7)
+		requireStorageUsage(t, ss, model.STORAGE_USAGE_TARGET_USER, otherUserId, 12, 2)
+
+		_, err = ss.FileInfo().PermanentDeleteBatch(2000, 1000)
+		require.Nil(t, err)
+
+		_, err = ss.FileInfo().Get(old.Id)
+		require.Error(t, err)
+		requireStorageUsage(t, ss, model.STORAGE_USAGE_TARGET_USER, otherUserId, 7, 1)
+		requireStorageUsage(t, ss, model.STORAGE_USAGE_TARGET_TEAM, teamId, 7, 1)
+	})
+}
+
+func testStorageUsageTrackQuarantine(t *testing.T, ss store.Store) {
+	userId := model.NewId()
+	teamId := model.NewId()
+
+	info := saveFileForStorageUsage(t, ss, userId, teamId, "", 50)
+	saveFileForStorageUsage(t, ss, userId, teamId, "", 8)
+
+	require.Nil(t, ss.FileInfo().Quarantine(info.Path, "quarantine/"+info.Path))
+
+	requireStorageUsage(t, ss, model.STORAGE_USAGE_TARGET_USER, userId, 8, 1)
+	requireStorageUsage(t, ss, model.STORAGE_USAGE_TARGET_TEAM, teamId, 8, 1)
+}
+
+func testStorageUsageGetAll(t *testing.T, ss store.Store) {
+	teamIds := []string{model.NewId(), model.NewId(), model.NewId()}
+	// Make sure the teams are larger than those of the other tests.
+	for i, size := range []int64{1<<40 + 2, 1<<40 + 3, 1<<40 + 1} {
+		info := saveFileForStorageUsage(t, ss, model.NewId(), teamIds[i], "", size)
+		defer func() {
+			require.Nil(t, ss.FileInfo().PermanentDelete(info.Id))
+		}()
+	}
+
+	usages, err := ss.StorageUsage().GetAll(model.STORAGE_USAGE_TARGET_TEAM, 0, 2)
+	require.Nil(t, err)
+	require.Len(t, usages, 2)
+	assert.Equal(t, teamIds[1], usages[0].TargetId)
+	assert.Equal(t, teamIds[0], usages[1].TargetId)
+
+	usages, err = ss.StorageUsage().GetAll(model.STORAGE_USAGE_TARGET_TEAM, 2, 1)
+	require.Nil(t, err)
+	require.Len(t, usages, 1)
+	assert.Equal(t, teamIds[2], usages[0].TargetId)
+	assert.Equal(t, model.STORAGE_USAGE_TARGET_TEAM, usages[0].TargetType)
+}
+
+func testStorageUsageRecompute(t *testing.T, ss store.Store) {
+	team := &model.Team{
+		DisplayName: "Name",
+		Name:        "zz" + model.NewId(),
+		Email:       MakeEmail(),
+		Type:        model.TEAM_OPEN,
+	}
+	team, err := ss.Team().Save(team)
+	require.Nil(t, err)
+
+	channel, err := ss.Channel().Save(&model.Channel{
+		TeamId:      team.Id,
+		DisplayName: "Channel",
+		Name:        "zz" + model.NewId() + "b",
+		Type:        model.CHANNEL_OPEN,
+	}, -1)
+	require.Nil(t, err)
+
+	userId := model.NewId()
+	post, err := ss.Post().Save(&model.Post{ChannelId: channel.Id, UserId: userId, Message: "files"})
+	require.Nil(t, err)
+
+	// A file uploaded before the usage was tracked, with no team.
+	saveFileForStorageUsage(t, ss, userId, "", post.Id, 100)
+	saveFileForStorageUsage(t, ss, userId, "", "", 10)
+	deletedPostId := model.NewId()
+	saveFileForStorageUsage(t, ss, userId, "", deletedPostId, 1000)
+	_, err = ss.FileInfo().DeleteForPost(deletedPostId)
+	require.Nil(t, err)
+
+	_, err = ss.StorageUsage().Get(model.STORAGE_USAGE_TARGET_TEAM, team.Id)
+	require.Error(t, err)
+
+	require.Nil(t, ss.StorageUsage().Recompute())
+
+	requireStorageUsage(t, ss, model.STORAGE_USAGE_TARGET_USER, userId, 110, 2)
+	requireStorageUsage(t, ss, model.STORAGE_USAGE_TARGET_TEAM, team.Id, 100, 1)
+
+	files, err := ss.FileInfo().GetForPost(post.Id, true, false, false)
+	require.Nil(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, team.Id, files[0].TeamId)
+}
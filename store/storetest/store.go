@@ -49,6 +49,17 @@ type Store struct {
 	UserTermsOfServiceStore   mocks.UserTermsOfServiceStore
 	LinkMetadataStore         mocks.LinkMetadataStore
 	ProductNoticesStore       mocks.ProductNoticesStore
+	PollStore                 mocks.PollStore
+	DraftStore                mocks.DraftStore
+	ChannelBookmarkStore      mocks.ChannelBookmarkStore
+	CustomStatusStore         mocks.CustomStatusStore
+	EmailQueueStore           mocks.EmailQueueStore
+	EmailDigestStore          mocks.EmailDigestStore
+	DndScheduleStore          mocks.DndScheduleStore
+	KeywordWatchStore         mocks.KeywordWatchStore
+	SavedSearchStore          mocks.SavedSearchStore
+	StorageUsageStore         mocks.StorageUsageStore
+	PublicLinkStore           mocks.PublicLinkStore
 	context                   context.Context
 }
 
@@ -88,19 +99,32 @@ func (s *Store) UserTermsOfService() store.UserTermsOfServiceStore { return &s.U
 func (s *Store) ChannelMemberHistory() store.ChannelMemberHistoryStore {
 	return &s.ChannelMemberHistoryStore
 }
-func (s *Store) Group() store.GroupStore               { return &s.GroupStore }
-func (s *Store) LinkMetadata() store.LinkMetadataStore { return &s.LinkMetadataStore }
-func (s *Store) MarkSystemRanUnitTests()               { /* do nothing */ }
-func (s *Store) Close()                                { /* do nothing */ }
-func (s *Store) LockToMaster()                         { /* do nothing */ }
-func (s *Store) UnlockFromMaster()                     { /* do nothing */ }
-func (s *Store) DropAllTables()                        { /* do nothing */ }
-func (s *Store) GetDbVersion() (string, error)         { return "", nil }
-func (s *Store) RecycleDBConnections(time.Duration)    {}
-func (s *Store) TotalMasterDbConnections() int         { return 1 }
-func (s *Store) TotalReadDbConnections() int           { return 1 }
-func (s *Store) TotalSearchDbConnections() int         { return 1 }
-func (s *Store) GetCurrentSchemaVersion() string       { return "" }
+func (s *Store) Group() store.GroupStore                     { return &s.GroupStore }
+func (s *Store) LinkMetadata() store.LinkMetadataStore       { return &s.LinkMetadataStore }
+func (s *Store) Whitelist() store.WhitelistStore             { return nil }
+func (s *Store) Invite() store.InviteStore                   { return nil }
+func (s *Store) Poll() store.PollStore                       { return &s.PollStore }
+func (s *Store) Draft() store.DraftStore                     { return &s.DraftStore }
+func (s *Store) ChannelBookmark() store.ChannelBookmarkStore { return &s.ChannelBookmarkStore }
+func (s *Store) CustomStatus() store.CustomStatusStore       { return &s.CustomStatusStore }
+func (s *Store) EmailQueue() store.EmailQueueStore           { return &s.EmailQueueStore }
+func (s *Store) EmailDigest() store.EmailDigestStore         { return &s.EmailDigestStore }
+func (s *Store) DndSchedule() store.DndScheduleStore         { return &s.DndScheduleStore }
+func (s *Store) KeywordWatch() store.KeywordWatchStore       { return &s.KeywordWatchStore }
+func (s *Store) SavedSearch() store.SavedSearchStore         { return &s.SavedSearchStore }
+func (s *Store) StorageUsage() store.StorageUsageStore       { return &s.StorageUsageStore }
+func (s *Store) PublicLink() store.PublicLinkStore           { return &s.PublicLinkStore }
+func (s *Store) MarkSystemRanUnitTests()                     { /* do nothing */ }
+func (s *Store) Close()                                      { /* do nothing */ }
+func (s *Store) LockToMaster()                               { /* do nothing */ }
+func (s *Store) UnlockFromMaster()                           { /* do nothing */ }
+func (s *Store) DropAllTables()                              { /* do nothing */ }
+func (s *Store) GetDbVersion() (string, error)               { return "", nil }
+func (s *Store) RecycleDBConnections(time.Duration)          {}
+func (s *Store) TotalMasterDbConnections() int               { return 1 }
+func (s *Store) TotalReadDbConnections() int                 { return 1 }
+func (s *Store) TotalSearchDbConnections() int               { return 1 }
+func (s *Store) GetCurrentSchemaVersion() string             { return "" }
 func (s *Store) CheckIntegrity() <-chan model.IntegrityCheckResult {
 	return make(chan model.IntegrityCheckResult)
 }
@@ -137,5 +161,16 @@ func (s *Store) AssertExpectations(t mock.TestingT) bool {
 		&s.SchemeStore,
 		&s.ThreadStore,
 		&s.ProductNoticesStore,
+		&s.PollStore,
+		&s.DraftStore,
+		&s.ChannelBookmarkStore,
+		&s.CustomStatusStore,
+		&s.EmailQueueStore,
+		&s.EmailDigestStore,
+		&s.DndScheduleStore,
+		&s.KeywordWatchStore,
+		&s.SavedSearchStore,
+		&s.StorageUsageStore,
+		&s.PublicLinkStore,
 	)
 }
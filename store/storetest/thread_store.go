@@ -14,6 +14,7 @@ import (
 
 func TestThreadStore(t *testing.T, ss store.Store, s SqlSupplier) {
 	t.Run("ThreadStorePopulation", func(t *testing.T) { testThreadStorePopulation(t, ss) })
+	t.Run("ThreadStoreGetUnreadThreadCountsByTeam", func(t *testing.T) { testThreadStoreGetUnreadThreadCountsByTeam(t, ss) })
 }
 
 func testThreadStorePopulation(t *testing.T, ss store.Store) {
@@ -310,3 +311,48 @@ func testThreadStorePopulation(t *testing.T, ss store.Store) {
 		}, time.Second, 10*time.Millisecond)
 	})
 }
+
+func testThreadStoreGetUnreadThreadCountsByTeam(t *testing.T, ss store.Store) {
+	userId := model.NewId()
+	teamId := model.NewId()
+
+	channel, err := ss.Channel().Save(&model.Channel{
+		TeamId:      teamId,
+		DisplayName: "Channel",
+		Name:        "channel-" + model.NewId(),
+		Type:        model.CHANNEL_OPEN,
+	}, -1)
+	require.Nil(t, err)
+
+	saveThread := func(lastReplyAt, lastViewed int64, following bool) {
+		thread, err := ss.Thread().Save(&model.Thread{
+			PostId:       model.NewId(),
+			ChannelId:    channel.Id,
+			ReplyCount:   1,
+			LastReplyAt:  lastReplyAt,
+			Participants: model.StringArray{userId},
+		})
+		require.Nil(t, err)
+
+		_, err = ss.Thread().SaveMembership(&model.ThreadMembership{
+			PostId:     thread.PostId,
+			UserId:     userId,
+			Following:  following,
+			LastViewed: lastViewed,
+		})
+		require.Nil(t, err)
+	}
+
+	saveThread(2000, 1000, true)
+	saveThread(3000, 1000, true)
+	saveThread(2000, 3000, true)
+	saveThread(2000, 1000, false)
+
+	counts, err := ss.Thread().GetUnreadThreadCountsByTeam(userId)
+	require.Nil(t, err)
+	require.Equal(t, map[string]int64{teamId: 2}, counts)
+
+	counts, err = ss.Thread().GetUnreadThreadCountsByTeam(model.NewId())
+	require.Nil(t, err)
+	require.Empty(t, counts)
+}
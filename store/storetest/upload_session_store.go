@@ -17,6 +17,8 @@ func TestUploadSessionStore(t *testing.T, ss store.Store) {
 	t.Run("UploadSessionStoreSaveGet", func(t *testing.T) { testUploadSessionStoreSaveGet(t, ss) })
 	t.Run("UploadSessionStoreUpdate", func(t *testing.T) { testUploadSessionStoreUpdate(t, ss) })
 	t.Run("UploadSessionStoreGetForUser", func(t *testing.T) { testUploadSessionStoreGetForUser(t, ss) })
+	t.Run("UploadSessionStoreGetUpdatedBefore", func(t *testing.T) { testUploadSessionStoreGetUpdatedBefore(t, ss) })
+	t.Run("UploadSessionStoreGetAllPage", func(t *testing.T) { testUploadSessionStoreGetAllPage(t, ss) })
 	t.Run("UploadSessionStoreDelete", func(t *testing.T) { testUploadSessionStoreDelete(t, ss) })
 }
 
@@ -180,6 +182,94 @@ func testUploadSessionStoreGetForUser(t *testing.T, ss store.Store) {
 	})
 }
 
+func testUploadSessionStoreGetUpdatedBefore(t *testing.T, ss store.Store) {
+	createAt := model.GetMillis() - 48*60*60*1000
+	var sessions []*model.UploadSession
+	for i := 0; i < 4; i++ {
+		updateAt := createAt + int64(i)
+		if i == 3 {
+			// Created as long ago, but still receiving data.
+			updateAt = model.GetMillis()
+		}
+		us, err := ss.UploadSession().Save(&model.UploadSession{
+			Type:     model.UploadTypePluginBundle,
+			UserId:   model.NewId(),
+			CreateAt: createAt,
+			UpdateAt: updateAt,
+			Filename: "plugin.tar.gz",
+			FileSize: 1024,
+			Path:     "/tmp/plugin.tar.gz",
+		})
+		require.NoError(t, err)
+		sessions = append(sessions, us)
+	}
+	defer func() {
+		for _, us := range sessions {
+			require.NoError(t, ss.UploadSession().Delete(us.Id))
+		}
+	}()
+
+	t.Run("should return no sessions updated since", func(t *testing.T) {
+		us, err := ss.UploadSession().GetUpdatedBefore(createAt, 10)
+		require.NoError(t, err)
+		for _, u := range us {
+			require.Less(t, u.UpdateAt, createAt)
+		}
+	})
+
+	t.Run("should return the least recently updated sessions first", func(t *testing.T) {
+		us, err := ss.UploadSession().GetUpdatedBefore(createAt+2, 10)
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, len(us), 2)
+		require.Equal(t, sessions[0], us[len(us)-2])
+		require.Equal(t, sessions[1], us[len(us)-1])
+	})
+
+	t.Run("should not return sessions created long ago but updated since", func(t *testing.T) {
+		us, err := ss.UploadSession().GetUpdatedBefore(createAt+10, 100)
+		require.NoError(t, err)
+		for _, u := range us {
+			require.NotEqual(t, sessions[3].Id, u.Id)
+		}
+	})
+
+	t.Run("should limit the number of sessions", func(t *testing.T) {
+		us, err := ss.UploadSession().GetUpdatedBefore(createAt+3, 1)
+		require.NoError(t, err)
+		require.Len(t, us, 1)
+	})
+}
+
+func testUploadSessionStoreGetAllPage(t *testing.T, ss store.Store) {
+	var sessions []*model.UploadSession
+	for i := 0; i < 3; i++ {
+		us, err := ss.UploadSession().Save(&model.UploadSession{
+			Type:     model.UploadTypePluginBundle,
+			UserId:   model.NewId(),
+			CreateAt: int64(i + 1),
+			Filename: "plugin.tar.gz",
+			FileSize: 1024,
+			Path:     "/tmp/plugin.tar.gz",
+		})
+		require.NoError(t, err)
+		sessions = append(sessions, us)
+	}
+	defer func() {
+		for _, us := range sessions {
+			require.NoError(t, ss.UploadSession().Delete(us.Id))
+		}
+	}()
+
+	page, err := ss.UploadSession().GetAllPage(0, 2)
+	require.NoError(t, err)
+	require.Equal(t, sessions[:2], page)
+
+	page, err = ss.UploadSession().GetAllPage(2, 2)
+	require.NoError(t, err)
+	require.NotEmpty(t, page)
+	require.Equal(t, sessions[2], page[0])
+}
+
 func testUploadSessionStoreDelete(t *testing.T, ss store.Store) {
 	session := &model.UploadSession{
 		Id:        model.NewId(),
@@ -21,27 +21,38 @@ type TimerLayer struct {
 	AuditStore                store.AuditStore
 	BotStore                  store.BotStore
 	ChannelStore              store.ChannelStore
+	ChannelBookmarkStore      store.ChannelBookmarkStore
 	ChannelMemberHistoryStore store.ChannelMemberHistoryStore
 	ClusterDiscoveryStore     store.ClusterDiscoveryStore
 	CommandStore              store.CommandStore
 	CommandWebhookStore       store.CommandWebhookStore
 	ComplianceStore           store.ComplianceStore
+	CustomStatusStore         store.CustomStatusStore
+	DndScheduleStore          store.DndScheduleStore
+	DraftStore                store.DraftStore
+	EmailDigestStore          store.EmailDigestStore
+	EmailQueueStore           store.EmailQueueStore
 	EmojiStore                store.EmojiStore
 	FileInfoStore             store.FileInfoStore
 	GroupStore                store.GroupStore
 	JobStore                  store.JobStore
+	KeywordWatchStore         store.KeywordWatchStore
 	LicenseStore              store.LicenseStore
 	LinkMetadataStore         store.LinkMetadataStore
 	OAuthStore                store.OAuthStore
 	PluginStore               store.PluginStore
+	PollStore                 store.PollStore
 	PostStore                 store.PostStore
 	PreferenceStore           store.PreferenceStore
 	ProductNoticesStore       store.ProductNoticesStore
+	PublicLinkStore           store.PublicLinkStore
 	ReactionStore             store.ReactionStore
 	RoleStore                 store.RoleStore
+	SavedSearchStore          store.SavedSearchStore
 	SchemeStore               store.SchemeStore
 	SessionStore              store.SessionStore
 	StatusStore               store.StatusStore
+	StorageUsageStore         store.StorageUsageStore
 	SystemStore               store.SystemStore
 	TeamStore                 store.TeamStore
 	TermsOfServiceStore       store.TermsOfServiceStore
@@ -66,6 +77,10 @@ func (s *TimerLayer) Channel() store.ChannelStore {
 	return s.ChannelStore
 }
 
+func (s *TimerLayer) ChannelBookmark() store.ChannelBookmarkStore {
+	return s.ChannelBookmarkStore
+}
+
 func (s *TimerLayer) ChannelMemberHistory() store.ChannelMemberHistoryStore {
 	return s.ChannelMemberHistoryStore
 }
@@ -86,6 +101,26 @@ func (s *TimerLayer) Compliance() store.ComplianceStore {
 	return s.ComplianceStore
 }
 
+func (s *TimerLayer) CustomStatus() store.CustomStatusStore {
+	return s.CustomStatusStore
+}
+
+func (s *TimerLayer) DndSchedule() store.DndScheduleStore {
+	return s.DndScheduleStore
+}
+
+func (s *TimerLayer) Draft() store.DraftStore {
+	return s.DraftStore
+}
+
+func (s *TimerLayer) EmailDigest() store.EmailDigestStore {
+	return s.EmailDigestStore
+}
+
+func (s *TimerLayer) EmailQueue() store.EmailQueueStore {
+	return s.EmailQueueStore
+}
+
 func (s *TimerLayer) Emoji() store.EmojiStore {
 	return s.EmojiStore
 }
@@ -102,6 +137,10 @@ func (s *TimerLayer) Job() store.JobStore {
 	return s.JobStore
 }
 
+func (s *TimerLayer) KeywordWatch() store.KeywordWatchStore {
+	return s.KeywordWatchStore
+}
+
 func (s *TimerLayer) License() store.LicenseStore {
 	return s.LicenseStore
 }
@@ -118,6 +157,10 @@ func (s *TimerLayer) Plugin() store.PluginStore {
 	return s.PluginStore
 }
 
+func (s *TimerLayer) Poll() store.PollStore {
+	return s.PollStore
+}
+
 func (s *TimerLayer) Post() store.PostStore {
 	return s.PostStore
 }
@@ -130,6 +173,10 @@ func (s *TimerLayer) ProductNotices() store.ProductNoticesStore {
 	return s.ProductNoticesStore
 }
 
+func (s *TimerLayer) PublicLink() store.PublicLinkStore {
+	return s.PublicLinkStore
+}
+
 func (s *TimerLayer) Reaction() store.ReactionStore {
 	return s.ReactionStore
 }
@@ -138,6 +185,10 @@ func (s *TimerLayer) Role() store.RoleStore {
 	return s.RoleStore
 }
 
+func (s *TimerLayer) SavedSearch() store.SavedSearchStore {
+	return s.SavedSearchStore
+}
+
 func (s *TimerLayer) Scheme() store.SchemeStore {
 	return s.SchemeStore
 }
@@ -150,6 +201,10 @@ func (s *TimerLayer) Status() store.StatusStore {
 	return s.StatusStore
 }
 
+func (s *TimerLayer) StorageUsage() store.StorageUsageStore {
+	return s.StorageUsageStore
+}
+
 func (s *TimerLayer) System() store.SystemStore {
 	return s.SystemStore
 }
@@ -205,6 +260,11 @@ type TimerLayerChannelStore struct {
 	Root *TimerLayer
 }
 
+type TimerLayerChannelBookmarkStore struct {
+	store.ChannelBookmarkStore
+	Root *TimerLayer
+}
+
 type TimerLayerChannelMemberHistoryStore struct {
 	store.ChannelMemberHistoryStore
 	Root *TimerLayer
@@ -230,6 +290,31 @@ type TimerLayerComplianceStore struct {
 	Root *TimerLayer
 }
 
+type TimerLayerCustomStatusStore struct {
+	store.CustomStatusStore
+	Root *TimerLayer
+}
+
+type TimerLayerDndScheduleStore struct {
+	store.DndScheduleStore
+	Root *TimerLayer
+}
+
+type TimerLayerDraftStore struct {
+	store.DraftStore
+	Root *TimerLayer
+}
+
+type TimerLayerEmailDigestStore struct {
+	store.EmailDigestStore
+	Root *TimerLayer
+}
+
+type TimerLayerEmailQueueStore struct {
+	store.EmailQueueStore
+	Root *TimerLayer
+}
+
 type TimerLayerEmojiStore struct {
 	store.EmojiStore
 	Root *TimerLayer
@@ -250,6 +335,11 @@ type TimerLayerJobStore struct {
 	Root *TimerLayer
 }
 
+type TimerLayerKeywordWatchStore struct {
+	store.KeywordWatchStore
+	Root *TimerLayer
+}
+
 type TimerLayerLicenseStore struct {
 	store.LicenseStore
 	Root *TimerLayer
@@ -270,6 +360,11 @@ type TimerLayerPluginStore struct {
 	Root *TimerLayer
 }
 
+type TimerLayerPollStore struct {
+	store.PollStore
+	Root *TimerLayer
+}
+
 type TimerLayerPostStore struct {
 	store.PostStore
 	Root *TimerLayer
@@ -285,6 +380,11 @@ type TimerLayerProductNoticesStore struct {
 	Root *TimerLayer
 }
 
+type TimerLayerPublicLinkStore struct {
+	store.PublicLinkStore
+	Root *TimerLayer
+}
+
 type TimerLayerReactionStore struct {
 	store.ReactionStore
 	Root *TimerLayer
@@ -295,6 +395,11 @@ type TimerLayerRoleStore struct {
 	Root *TimerLayer
 }
 
+type TimerLayerSavedSearchStore struct {
+	store.SavedSearchStore
+	Root *TimerLayer
+}
+
 type TimerLayerSchemeStore struct {
 	store.SchemeStore
 	Root *TimerLayer
@@ -310,6 +415,11 @@ type TimerLayerStatusStore struct {
 	Root *TimerLayer
 }
 
+type TimerLayerStorageUsageStore struct {
+	store.StorageUsageStore
+	Root *TimerLayer
+}
+
 type TimerLayerSystemStore struct {
 	store.SystemStore
 	Root *TimerLayer
@@ -2096,6 +2206,134 @@ func (s *TimerLayerChannelStore) UserBelongsToChannels(userId string, channelIds
 	return result, err
 }
 
+func (s *TimerLayerChannelBookmarkStore) Delete(bookmarkId string) error {
+	start := timemodule.Now()
+
+	err := s.ChannelBookmarkStore.Delete(bookmarkId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelBookmarkStore.Delete", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerChannelBookmarkStore) Get(bookmarkId string, includeDeleted bool) (*model.ChannelBookmark, error) {
+	start := timemodule.Now()
+
+	result, err := s.ChannelBookmarkStore.Get(bookmarkId, includeDeleted)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelBookmarkStore.Get", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerChannelBookmarkStore) GetAllForExport(limit int, afterId string) ([]*model.ChannelBookmarkForExport, error) {
+	start := timemodule.Now()
+
+	result, err := s.ChannelBookmarkStore.GetAllForExport(limit, afterId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelBookmarkStore.GetAllForExport", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerChannelBookmarkStore) GetBookmarksForChannel(channelId string) ([]*model.ChannelBookmark, error) {
+	start := timemodule.Now()
+
+	result, err := s.ChannelBookmarkStore.GetBookmarksForChannel(channelId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelBookmarkStore.GetBookmarksForChannel", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerChannelBookmarkStore) GetBookmarksForFile(fileId string) ([]*model.ChannelBookmark, error) {
+	start := timemodule.Now()
+
+	result, err := s.ChannelBookmarkStore.GetBookmarksForFile(fileId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelBookmarkStore.GetBookmarksForFile", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerChannelBookmarkStore) Save(bookmark *model.ChannelBookmark) (*model.ChannelBookmark, error) {
+	start := timemodule.Now()
+
+	result, err := s.ChannelBookmarkStore.Save(bookmark)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelBookmarkStore.Save", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerChannelBookmarkStore) Update(bookmark *model.ChannelBookmark) error {
+	start := timemodule.Now()
+
+	err := s.ChannelBookmarkStore.Update(bookmark)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelBookmarkStore.Update", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerChannelBookmarkStore) UpdateSortOrder(bookmarkId string, channelId string, newIndex int64) ([]*model.ChannelBookmark, error) {
+	start := timemodule.Now()
+
+	result, err := s.ChannelBookmarkStore.UpdateSortOrder(bookmarkId, channelId, newIndex)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelBookmarkStore.UpdateSortOrder", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerChannelMemberHistoryStore) GetUsersInChannelDuring(startTime int64, endTime int64, channelId string) ([]*model.ChannelMemberHistoryResult, error) {
 	start := timemodule.Now()
 
@@ -2559,10 +2797,10 @@ func (s *TimerLayerComplianceStore) Update(compliance *model.Compliance) (*model
 	return result, err
 }
 
-func (s *TimerLayerEmojiStore) Delete(emoji *model.Emoji, time int64) error {
+func (s *TimerLayerCustomStatusStore) Delete(userId string) error {
 	start := timemodule.Now()
 
-	err := s.EmojiStore.Delete(emoji, time)
+	err := s.CustomStatusStore.Delete(userId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -2570,15 +2808,15 @@ func (s *TimerLayerEmojiStore) Delete(emoji *model.Emoji, time int64) error {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("EmojiStore.Delete", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("CustomStatusStore.Delete", success, elapsed)
 	}
 	return err
 }
 
-func (s *TimerLayerEmojiStore) Get(id string, allowFromCache bool) (*model.Emoji, error) {
+func (s *TimerLayerCustomStatusStore) Get(userId string) (*model.CustomStatus, error) {
 	start := timemodule.Now()
 
-	result, err := s.EmojiStore.Get(id, allowFromCache)
+	result, err := s.CustomStatusStore.Get(userId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -2586,15 +2824,15 @@ func (s *TimerLayerEmojiStore) Get(id string, allowFromCache bool) (*model.Emoji
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("EmojiStore.Get", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("CustomStatusStore.Get", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerEmojiStore) GetByName(name string, allowFromCache bool) (*model.Emoji, error) {
+func (s *TimerLayerCustomStatusStore) GetByUserIds(userIds []string) ([]*model.CustomStatus, error) {
 	start := timemodule.Now()
 
-	result, err := s.EmojiStore.GetByName(name, allowFromCache)
+	result, err := s.CustomStatusStore.GetByUserIds(userIds)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -2602,15 +2840,15 @@ func (s *TimerLayerEmojiStore) GetByName(name string, allowFromCache bool) (*mod
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("EmojiStore.GetByName", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("CustomStatusStore.GetByUserIds", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerEmojiStore) GetList(offset int, limit int, sort string) ([]*model.Emoji, error) {
+func (s *TimerLayerCustomStatusStore) GetExpired(expiredAt int64, limit int) ([]*model.CustomStatus, error) {
 	start := timemodule.Now()
 
-	result, err := s.EmojiStore.GetList(offset, limit, sort)
+	result, err := s.CustomStatusStore.GetExpired(expiredAt, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -2618,15 +2856,15 @@ func (s *TimerLayerEmojiStore) GetList(offset int, limit int, sort string) ([]*m
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("EmojiStore.GetList", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("CustomStatusStore.GetExpired", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerEmojiStore) GetMultipleByName(names []string) ([]*model.Emoji, error) {
+func (s *TimerLayerCustomStatusStore) Save(customStatus *model.CustomStatus) (*model.CustomStatus, error) {
 	start := timemodule.Now()
 
-	result, err := s.EmojiStore.GetMultipleByName(names)
+	result, err := s.CustomStatusStore.Save(customStatus)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -2634,15 +2872,15 @@ func (s *TimerLayerEmojiStore) GetMultipleByName(names []string) ([]*model.Emoji
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("EmojiStore.GetMultipleByName", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("CustomStatusStore.Save", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerEmojiStore) Save(emoji *model.Emoji) (*model.Emoji, error) {
+func (s *TimerLayerDndScheduleStore) Delete(userId string) error {
 	start := timemodule.Now()
 
-	result, err := s.EmojiStore.Save(emoji)
+	err := s.DndScheduleStore.Delete(userId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -2650,15 +2888,15 @@ func (s *TimerLayerEmojiStore) Save(emoji *model.Emoji) (*model.Emoji, error) {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("EmojiStore.Save", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("DndScheduleStore.Delete", success, elapsed)
 	}
-	return result, err
+	return err
 }
 
-func (s *TimerLayerEmojiStore) Search(name string, prefixOnly bool, limit int) ([]*model.Emoji, error) {
+func (s *TimerLayerDndScheduleStore) Get(userId string) (*model.DndSchedule, error) {
 	start := timemodule.Now()
 
-	result, err := s.EmojiStore.Search(name, prefixOnly, limit)
+	result, err := s.DndScheduleStore.Get(userId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -2666,15 +2904,15 @@ func (s *TimerLayerEmojiStore) Search(name string, prefixOnly bool, limit int) (
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("EmojiStore.Search", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("DndScheduleStore.Get", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerFileInfoStore) AttachToPost(fileId string, postId string, creatorId string) error {
+func (s *TimerLayerDndScheduleStore) GetAll(afterUserId string, limit int) ([]*model.DndSchedule, error) {
 	start := timemodule.Now()
 
-	err := s.FileInfoStore.AttachToPost(fileId, postId, creatorId)
+	result, err := s.DndScheduleStore.GetAll(afterUserId, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -2682,30 +2920,31 @@ func (s *TimerLayerFileInfoStore) AttachToPost(fileId string, postId string, cre
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.AttachToPost", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("DndScheduleStore.GetAll", success, elapsed)
 	}
-	return err
+	return result, err
 }
 
-func (s *TimerLayerFileInfoStore) ClearCaches() {
+func (s *TimerLayerDndScheduleStore) Save(schedule *model.DndSchedule) (*model.DndSchedule, error) {
 	start := timemodule.Now()
 
-	s.FileInfoStore.ClearCaches()
+	result, err := s.DndScheduleStore.Save(schedule)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if true {
+		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.ClearCaches", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("DndScheduleStore.Save", success, elapsed)
 	}
+	return result, err
 }
 
-func (s *TimerLayerFileInfoStore) DeleteForPost(postId string) (string, error) {
+func (s *TimerLayerDraftStore) Delete(userId string, channelId string, rootId string) error {
 	start := timemodule.Now()
 
-	result, err := s.FileInfoStore.DeleteForPost(postId)
+	err := s.DraftStore.Delete(userId, channelId, rootId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -2713,15 +2952,15 @@ func (s *TimerLayerFileInfoStore) DeleteForPost(postId string) (string, error) {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.DeleteForPost", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("DraftStore.Delete", success, elapsed)
 	}
-	return result, err
+	return err
 }
 
-func (s *TimerLayerFileInfoStore) Get(id string) (*model.FileInfo, error) {
+func (s *TimerLayerDraftStore) Get(userId string, channelId string, rootId string) (*model.Draft, error) {
 	start := timemodule.Now()
 
-	result, err := s.FileInfoStore.Get(id)
+	result, err := s.DraftStore.Get(userId, channelId, rootId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -2729,15 +2968,15 @@ func (s *TimerLayerFileInfoStore) Get(id string) (*model.FileInfo, error) {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.Get", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("DraftStore.Get", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerFileInfoStore) GetByPath(path string) (*model.FileInfo, error) {
+func (s *TimerLayerDraftStore) GetDraftsForUser(userId string, teamId string) ([]*model.Draft, error) {
 	start := timemodule.Now()
 
-	result, err := s.FileInfoStore.GetByPath(path)
+	result, err := s.DraftStore.GetDraftsForUser(userId, teamId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -2745,15 +2984,15 @@ func (s *TimerLayerFileInfoStore) GetByPath(path string) (*model.FileInfo, error
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.GetByPath", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("DraftStore.GetDraftsForUser", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerFileInfoStore) GetForPost(postId string, readFromMaster bool, includeDeleted bool, allowFromCache bool) ([]*model.FileInfo, error) {
+func (s *TimerLayerDraftStore) Save(draft *model.Draft) (*model.Draft, error) {
 	start := timemodule.Now()
 
-	result, err := s.FileInfoStore.GetForPost(postId, readFromMaster, includeDeleted, allowFromCache)
+	result, err := s.DraftStore.Save(draft)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -2761,15 +3000,15 @@ func (s *TimerLayerFileInfoStore) GetForPost(postId string, readFromMaster bool,
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.GetForPost", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("DraftStore.Save", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerFileInfoStore) GetForUser(userId string) ([]*model.FileInfo, error) {
+func (s *TimerLayerEmailDigestStore) Delete(ids []string) error {
 	start := timemodule.Now()
 
-	result, err := s.FileInfoStore.GetForUser(userId)
+	err := s.EmailDigestStore.Delete(ids)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -2777,15 +3016,15 @@ func (s *TimerLayerFileInfoStore) GetForUser(userId string) ([]*model.FileInfo,
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.GetForUser", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("EmailDigestStore.Delete", success, elapsed)
 	}
-	return result, err
+	return err
 }
 
-func (s *TimerLayerFileInfoStore) GetWithOptions(page int, perPage int, opt *model.GetFileInfosOptions) ([]*model.FileInfo, error) {
+func (s *TimerLayerEmailDigestStore) DeleteForUser(userId string) error {
 	start := timemodule.Now()
 
-	result, err := s.FileInfoStore.GetWithOptions(page, perPage, opt)
+	err := s.EmailDigestStore.DeleteForUser(userId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -2793,30 +3032,31 @@ func (s *TimerLayerFileInfoStore) GetWithOptions(page int, perPage int, opt *mod
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.GetWithOptions", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("EmailDigestStore.DeleteForUser", success, elapsed)
 	}
-	return result, err
+	return err
 }
 
-func (s *TimerLayerFileInfoStore) InvalidateFileInfosForPostCache(postId string, deleted bool) {
+func (s *TimerLayerEmailDigestStore) GetForUser(userId string) ([]*model.PendingEmailNotification, error) {
 	start := timemodule.Now()
 
-	s.FileInfoStore.InvalidateFileInfosForPostCache(postId, deleted)
+	result, err := s.EmailDigestStore.GetForUser(userId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if true {
+		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.InvalidateFileInfosForPostCache", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("EmailDigestStore.GetForUser", success, elapsed)
 	}
+	return result, err
 }
 
-func (s *TimerLayerFileInfoStore) PermanentDelete(fileId string) error {
+func (s *TimerLayerEmailDigestStore) GetUserIdsWithPending(afterUserId string, limit int) ([]string, error) {
 	start := timemodule.Now()
 
-	err := s.FileInfoStore.PermanentDelete(fileId)
+	result, err := s.EmailDigestStore.GetUserIdsWithPending(afterUserId, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -2824,15 +3064,15 @@ func (s *TimerLayerFileInfoStore) PermanentDelete(fileId string) error {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.PermanentDelete", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("EmailDigestStore.GetUserIdsWithPending", success, elapsed)
 	}
-	return err
+	return result, err
 }
 
-func (s *TimerLayerFileInfoStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+func (s *TimerLayerEmailDigestStore) Save(notification *model.PendingEmailNotification) (*model.PendingEmailNotification, error) {
 	start := timemodule.Now()
 
-	result, err := s.FileInfoStore.PermanentDeleteBatch(endTime, limit)
+	result, err := s.EmailDigestStore.Save(notification)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -2840,15 +3080,15 @@ func (s *TimerLayerFileInfoStore) PermanentDeleteBatch(endTime int64, limit int6
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.PermanentDeleteBatch", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("EmailDigestStore.Save", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerFileInfoStore) PermanentDeleteByUser(userId string) (int64, error) {
+func (s *TimerLayerEmailQueueStore) Get(id string) (*model.QueuedEmail, error) {
 	start := timemodule.Now()
 
-	result, err := s.FileInfoStore.PermanentDeleteByUser(userId)
+	result, err := s.EmailQueueStore.Get(id)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -2856,15 +3096,15 @@ func (s *TimerLayerFileInfoStore) PermanentDeleteByUser(userId string) (int64, e
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.PermanentDeleteByUser", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("EmailQueueStore.Get", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerFileInfoStore) Save(info *model.FileInfo) (*model.FileInfo, error) {
+func (s *TimerLayerEmailQueueStore) GetAll(status string, offset int, limit int) ([]*model.QueuedEmail, error) {
 	start := timemodule.Now()
 
-	result, err := s.FileInfoStore.Save(info)
+	result, err := s.EmailQueueStore.GetAll(status, offset, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -2872,15 +3112,15 @@ func (s *TimerLayerFileInfoStore) Save(info *model.FileInfo) (*model.FileInfo, e
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.Save", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("EmailQueueStore.GetAll", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerFileInfoStore) SetContent(fileId string, content string) error {
+func (s *TimerLayerEmailQueueStore) GetReadyToSend(now int64, limit int) ([]*model.QueuedEmail, error) {
 	start := timemodule.Now()
 
-	err := s.FileInfoStore.SetContent(fileId, content)
+	result, err := s.EmailQueueStore.GetReadyToSend(now, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -2888,15 +3128,15 @@ func (s *TimerLayerFileInfoStore) SetContent(fileId string, content string) erro
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.SetContent", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("EmailQueueStore.GetReadyToSend", success, elapsed)
 	}
-	return err
+	return result, err
 }
 
-func (s *TimerLayerFileInfoStore) Upsert(info *model.FileInfo) (*model.FileInfo, error) {
+func (s *TimerLayerEmailQueueStore) PermanentDeleteSentBefore(before int64, limit int64) (int64, error) {
 	start := timemodule.Now()
 
-	result, err := s.FileInfoStore.Upsert(info)
+	result, err := s.EmailQueueStore.PermanentDeleteSentBefore(before, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -2904,15 +3144,15 @@ func (s *TimerLayerFileInfoStore) Upsert(info *model.FileInfo) (*model.FileInfo,
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.Upsert", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("EmailQueueStore.PermanentDeleteSentBefore", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerGroupStore) AdminRoleGroupsForSyncableMember(userID string, syncableID string, syncableType model.GroupSyncableType) ([]string, error) {
+func (s *TimerLayerEmailQueueStore) Save(email *model.QueuedEmail) (*model.QueuedEmail, error) {
 	start := timemodule.Now()
 
-	result, err := s.GroupStore.AdminRoleGroupsForSyncableMember(userID, syncableID, syncableType)
+	result, err := s.EmailQueueStore.Save(email)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -2920,15 +3160,15 @@ func (s *TimerLayerGroupStore) AdminRoleGroupsForSyncableMember(userID string, s
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.AdminRoleGroupsForSyncableMember", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("EmailQueueStore.Save", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerGroupStore) ChannelMembersMinusGroupMembers(channelID string, groupIDs []string, page int, perPage int) ([]*model.UserWithGroups, error) {
+func (s *TimerLayerEmailQueueStore) Update(email *model.QueuedEmail) (*model.QueuedEmail, error) {
 	start := timemodule.Now()
 
-	result, err := s.GroupStore.ChannelMembersMinusGroupMembers(channelID, groupIDs, page, perPage)
+	result, err := s.EmailQueueStore.Update(email)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -2936,15 +3176,15 @@ func (s *TimerLayerGroupStore) ChannelMembersMinusGroupMembers(channelID string,
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.ChannelMembersMinusGroupMembers", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("EmailQueueStore.Update", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerGroupStore) ChannelMembersToAdd(since int64, channelID *string) ([]*model.UserChannelIDPair, error) {
+func (s *TimerLayerEmojiStore) Delete(emoji *model.Emoji, time int64) error {
 	start := timemodule.Now()
 
-	result, err := s.GroupStore.ChannelMembersToAdd(since, channelID)
+	err := s.EmojiStore.Delete(emoji, time)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -2952,7 +3192,485 @@ func (s *TimerLayerGroupStore) ChannelMembersToAdd(since int64, channelID *strin
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.ChannelMembersToAdd", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("EmojiStore.Delete", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerEmojiStore) Get(id string, allowFromCache bool) (*model.Emoji, error) {
+	start := timemodule.Now()
+
+	result, err := s.EmojiStore.Get(id, allowFromCache)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("EmojiStore.Get", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerEmojiStore) GetByName(name string, allowFromCache bool) (*model.Emoji, error) {
+	start := timemodule.Now()
+
+	result, err := s.EmojiStore.GetByName(name, allowFromCache)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("EmojiStore.GetByName", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerEmojiStore) GetList(offset int, limit int, sort string) ([]*model.Emoji, error) {
+	start := timemodule.Now()
+
+	result, err := s.EmojiStore.GetList(offset, limit, sort)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("EmojiStore.GetList", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerEmojiStore) GetMultipleByName(names []string) ([]*model.Emoji, error) {
+	start := timemodule.Now()
+
+	result, err := s.EmojiStore.GetMultipleByName(names)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("EmojiStore.GetMultipleByName", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerEmojiStore) Save(emoji *model.Emoji) (*model.Emoji, error) {
+	start := timemodule.Now()
+
+	result, err := s.EmojiStore.Save(emoji)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("EmojiStore.Save", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerEmojiStore) Search(name string, prefixOnly bool, limit int) ([]*model.Emoji, error) {
+	start := timemodule.Now()
+
+	result, err := s.EmojiStore.Search(name, prefixOnly, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("EmojiStore.Search", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerFileInfoStore) AttachToPost(fileId string, postId string, creatorId string) error {
+	start := timemodule.Now()
+
+	err := s.FileInfoStore.AttachToPost(fileId, postId, creatorId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.AttachToPost", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerFileInfoStore) ClearCaches() {
+	start := timemodule.Now()
+
+	s.FileInfoStore.ClearCaches()
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if true {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.ClearCaches", success, elapsed)
+	}
+}
+
+func (s *TimerLayerFileInfoStore) CountByContentHash(contentHash string) (int64, error) {
+	start := timemodule.Now()
+
+	result, err := s.FileInfoStore.CountByContentHash(contentHash)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.CountByContentHash", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerFileInfoStore) DeleteForPost(postId string) (string, error) {
+	start := timemodule.Now()
+
+	result, err := s.FileInfoStore.DeleteForPost(postId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.DeleteForPost", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerFileInfoStore) Get(id string) (*model.FileInfo, error) {
+	start := timemodule.Now()
+
+	result, err := s.FileInfoStore.Get(id)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.Get", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerFileInfoStore) GetByPath(path string) (*model.FileInfo, error) {
+	start := timemodule.Now()
+
+	result, err := s.FileInfoStore.GetByPath(path)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.GetByPath", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerFileInfoStore) GetFilesBatchForIndexing(startTime int64, endTime int64, limit int) ([]*model.FileForIndexing, error) {
+	start := timemodule.Now()
+
+	result, err := s.FileInfoStore.GetFilesBatchForIndexing(startTime, endTime, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.GetFilesBatchForIndexing", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerFileInfoStore) GetForPost(postId string, readFromMaster bool, includeDeleted bool, allowFromCache bool) ([]*model.FileInfo, error) {
+	start := timemodule.Now()
+
+	result, err := s.FileInfoStore.GetForPost(postId, readFromMaster, includeDeleted, allowFromCache)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.GetForPost", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerFileInfoStore) GetForUser(userId string) ([]*model.FileInfo, error) {
+	start := timemodule.Now()
+
+	result, err := s.FileInfoStore.GetForUser(userId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.GetForUser", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerFileInfoStore) GetPostIdsForExtensions(extensions []string, limit int) ([]string, error) {
+	start := timemodule.Now()
+
+	result, err := s.FileInfoStore.GetPostIdsForExtensions(extensions, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.GetPostIdsForExtensions", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerFileInfoStore) GetWithOptions(page int, perPage int, opt *model.GetFileInfosOptions) ([]*model.FileInfo, error) {
+	start := timemodule.Now()
+
+	result, err := s.FileInfoStore.GetWithOptions(page, perPage, opt)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.GetWithOptions", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerFileInfoStore) InvalidateFileInfosForPostCache(postId string, deleted bool) {
+	start := timemodule.Now()
+
+	s.FileInfoStore.InvalidateFileInfosForPostCache(postId, deleted)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if true {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.InvalidateFileInfosForPostCache", success, elapsed)
+	}
+}
+
+func (s *TimerLayerFileInfoStore) PermanentDelete(fileId string) error {
+	start := timemodule.Now()
+
+	err := s.FileInfoStore.PermanentDelete(fileId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.PermanentDelete", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerFileInfoStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+	start := timemodule.Now()
+
+	result, err := s.FileInfoStore.PermanentDeleteBatch(endTime, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.PermanentDeleteBatch", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerFileInfoStore) PermanentDeleteByUser(userId string) (int64, error) {
+	start := timemodule.Now()
+
+	result, err := s.FileInfoStore.PermanentDeleteByUser(userId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.PermanentDeleteByUser", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerFileInfoStore) Quarantine(path string, quarantinePath string) error {
+	start := timemodule.Now()
+
+	err := s.FileInfoStore.Quarantine(path, quarantinePath)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.Quarantine", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerFileInfoStore) Save(info *model.FileInfo) (*model.FileInfo, error) {
+	start := timemodule.Now()
+
+	result, err := s.FileInfoStore.Save(info)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.Save", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerFileInfoStore) Search(paramsList []*model.SearchParams, userId string, teamId string, page int, perPage int) (*model.FileInfoList, error) {
+	start := timemodule.Now()
+
+	result, err := s.FileInfoStore.Search(paramsList, userId, teamId, page, perPage)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.Search", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerFileInfoStore) SetContent(fileId string, content string) error {
+	start := timemodule.Now()
+
+	err := s.FileInfoStore.SetContent(fileId, content)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.SetContent", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerFileInfoStore) SetContentHash(path string, contentHash string, blobPath string) error {
+	start := timemodule.Now()
+
+	err := s.FileInfoStore.SetContentHash(path, contentHash, blobPath)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.SetContentHash", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerFileInfoStore) Upsert(info *model.FileInfo) (*model.FileInfo, error) {
+	start := timemodule.Now()
+
+	result, err := s.FileInfoStore.Upsert(info)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.Upsert", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerGroupStore) AdminRoleGroupsForSyncableMember(userID string, syncableID string, syncableType model.GroupSyncableType) ([]string, error) {
+	start := timemodule.Now()
+
+	result, err := s.GroupStore.AdminRoleGroupsForSyncableMember(userID, syncableID, syncableType)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.AdminRoleGroupsForSyncableMember", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerGroupStore) ChannelMembersMinusGroupMembers(channelID string, groupIDs []string, page int, perPage int) ([]*model.UserWithGroups, error) {
+	start := timemodule.Now()
+
+	result, err := s.GroupStore.ChannelMembersMinusGroupMembers(channelID, groupIDs, page, perPage)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.ChannelMembersMinusGroupMembers", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerGroupStore) ChannelMembersToAdd(since int64, channelID *string) ([]*model.UserChannelIDPair, error) {
+	start := timemodule.Now()
+
+	result, err := s.GroupStore.ChannelMembersToAdd(since, channelID)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.ChannelMembersToAdd", success, elapsed)
 	}
 	return result, err
 }
@@ -3656,15 +4374,127 @@ func (s *TimerLayerJobStore) GetAllByStatus(status string) ([]*model.Job, error)
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.GetAllByStatus", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.GetAllByStatus", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerJobStore) GetAllByType(jobType string) ([]*model.Job, error) {
+	start := timemodule.Now()
+
+	result, err := s.JobStore.GetAllByType(jobType)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.GetAllByType", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerJobStore) GetAllByTypePage(jobType string, offset int, limit int) ([]*model.Job, error) {
+	start := timemodule.Now()
+
+	result, err := s.JobStore.GetAllByTypePage(jobType, offset, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.GetAllByTypePage", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerJobStore) GetAllPage(offset int, limit int) ([]*model.Job, error) {
+	start := timemodule.Now()
+
+	result, err := s.JobStore.GetAllPage(offset, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.GetAllPage", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerJobStore) GetCountByStatusAndType(status string, jobType string) (int64, error) {
+	start := timemodule.Now()
+
+	result, err := s.JobStore.GetCountByStatusAndType(status, jobType)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.GetCountByStatusAndType", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerJobStore) GetNewestJobByStatusAndType(status string, jobType string) (*model.Job, error) {
+	start := timemodule.Now()
+
+	result, err := s.JobStore.GetNewestJobByStatusAndType(status, jobType)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.GetNewestJobByStatusAndType", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerJobStore) GetNewestJobByStatusesAndType(statuses []string, jobType string) (*model.Job, error) {
+	start := timemodule.Now()
+
+	result, err := s.JobStore.GetNewestJobByStatusesAndType(statuses, jobType)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.GetNewestJobByStatusesAndType", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerJobStore) Save(job *model.Job) (*model.Job, error) {
+	start := timemodule.Now()
+
+	result, err := s.JobStore.Save(job)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.Save", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerJobStore) GetAllByType(jobType string) ([]*model.Job, error) {
+func (s *TimerLayerJobStore) UpdateOptimistically(job *model.Job, currentStatus string) (bool, error) {
 	start := timemodule.Now()
 
-	result, err := s.JobStore.GetAllByType(jobType)
+	result, err := s.JobStore.UpdateOptimistically(job, currentStatus)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -3672,15 +4502,15 @@ func (s *TimerLayerJobStore) GetAllByType(jobType string) ([]*model.Job, error)
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.GetAllByType", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.UpdateOptimistically", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerJobStore) GetAllByTypePage(jobType string, offset int, limit int) ([]*model.Job, error) {
+func (s *TimerLayerJobStore) UpdateStatus(id string, status string) (*model.Job, error) {
 	start := timemodule.Now()
 
-	result, err := s.JobStore.GetAllByTypePage(jobType, offset, limit)
+	result, err := s.JobStore.UpdateStatus(id, status)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -3688,15 +4518,15 @@ func (s *TimerLayerJobStore) GetAllByTypePage(jobType string, offset int, limit
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.GetAllByTypePage", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.UpdateStatus", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerJobStore) GetAllPage(offset int, limit int) ([]*model.Job, error) {
+func (s *TimerLayerJobStore) UpdateStatusOptimistically(id string, currentStatus string, newStatus string) (bool, error) {
 	start := timemodule.Now()
 
-	result, err := s.JobStore.GetAllPage(offset, limit)
+	result, err := s.JobStore.UpdateStatusOptimistically(id, currentStatus, newStatus)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -3704,15 +4534,15 @@ func (s *TimerLayerJobStore) GetAllPage(offset int, limit int) ([]*model.Job, er
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.GetAllPage", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.UpdateStatusOptimistically", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerJobStore) GetCountByStatusAndType(status string, jobType string) (int64, error) {
+func (s *TimerLayerKeywordWatchStore) Delete(id string) error {
 	start := timemodule.Now()
 
-	result, err := s.JobStore.GetCountByStatusAndType(status, jobType)
+	err := s.KeywordWatchStore.Delete(id)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -3720,15 +4550,15 @@ func (s *TimerLayerJobStore) GetCountByStatusAndType(status string, jobType stri
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.GetCountByStatusAndType", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("KeywordWatchStore.Delete", success, elapsed)
 	}
-	return result, err
+	return err
 }
 
-func (s *TimerLayerJobStore) GetNewestJobByStatusAndType(status string, jobType string) (*model.Job, error) {
+func (s *TimerLayerKeywordWatchStore) Get(id string) (*model.KeywordWatch, error) {
 	start := timemodule.Now()
 
-	result, err := s.JobStore.GetNewestJobByStatusAndType(status, jobType)
+	result, err := s.KeywordWatchStore.Get(id)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -3736,15 +4566,15 @@ func (s *TimerLayerJobStore) GetNewestJobByStatusAndType(status string, jobType
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.GetNewestJobByStatusAndType", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("KeywordWatchStore.Get", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerJobStore) GetNewestJobByStatusesAndType(statuses []string, jobType string) (*model.Job, error) {
+func (s *TimerLayerKeywordWatchStore) GetForTeam(teamId string) ([]*model.KeywordWatch, error) {
 	start := timemodule.Now()
 
-	result, err := s.JobStore.GetNewestJobByStatusesAndType(statuses, jobType)
+	result, err := s.KeywordWatchStore.GetForTeam(teamId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -3752,15 +4582,15 @@ func (s *TimerLayerJobStore) GetNewestJobByStatusesAndType(statuses []string, jo
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.GetNewestJobByStatusesAndType", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("KeywordWatchStore.GetForTeam", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerJobStore) Save(job *model.Job) (*model.Job, error) {
+func (s *TimerLayerKeywordWatchStore) GetForUser(userId string) ([]*model.KeywordWatch, error) {
 	start := timemodule.Now()
 
-	result, err := s.JobStore.Save(job)
+	result, err := s.KeywordWatchStore.GetForUser(userId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -3768,15 +4598,15 @@ func (s *TimerLayerJobStore) Save(job *model.Job) (*model.Job, error) {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.Save", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("KeywordWatchStore.GetForUser", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerJobStore) UpdateOptimistically(job *model.Job, currentStatus string) (bool, error) {
+func (s *TimerLayerKeywordWatchStore) Save(watch *model.KeywordWatch) (*model.KeywordWatch, error) {
 	start := timemodule.Now()
 
-	result, err := s.JobStore.UpdateOptimistically(job, currentStatus)
+	result, err := s.KeywordWatchStore.Save(watch)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -3784,15 +4614,15 @@ func (s *TimerLayerJobStore) UpdateOptimistically(job *model.Job, currentStatus
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.UpdateOptimistically", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("KeywordWatchStore.Save", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerJobStore) UpdateStatus(id string, status string) (*model.Job, error) {
+func (s *TimerLayerKeywordWatchStore) Update(watch *model.KeywordWatch) (*model.KeywordWatch, error) {
 	start := timemodule.Now()
 
-	result, err := s.JobStore.UpdateStatus(id, status)
+	result, err := s.KeywordWatchStore.Update(watch)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -3800,15 +4630,15 @@ func (s *TimerLayerJobStore) UpdateStatus(id string, status string) (*model.Job,
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.UpdateStatus", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("KeywordWatchStore.Update", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerJobStore) UpdateStatusOptimistically(id string, currentStatus string, newStatus string) (bool, error) {
+func (s *TimerLayerKeywordWatchStore) UpdateLastAlertAt(id string, alertAt int64, since int64) (bool, error) {
 	start := timemodule.Now()
 
-	result, err := s.JobStore.UpdateStatusOptimistically(id, currentStatus, newStatus)
+	result, err := s.KeywordWatchStore.UpdateLastAlertAt(id, alertAt, since)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -3816,7 +4646,7 @@ func (s *TimerLayerJobStore) UpdateStatusOptimistically(id string, currentStatus
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.UpdateStatusOptimistically", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("KeywordWatchStore.UpdateLastAlertAt", success, elapsed)
 	}
 	return result, err
 }
@@ -4333,6 +5163,134 @@ func (s *TimerLayerPluginStore) SetWithOptions(pluginId string, key string, valu
 	return result, err
 }
 
+func (s *TimerLayerPollStore) Get(id string) (*model.Poll, error) {
+	start := timemodule.Now()
+
+	result, err := s.PollStore.Get(id)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PollStore.Get", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPollStore) GetForPosts(postIds []string) ([]*model.Poll, error) {
+	start := timemodule.Now()
+
+	result, err := s.PollStore.GetForPosts(postIds)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PollStore.GetForPosts", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPollStore) GetPollsToClose(now int64, limit int) ([]*model.Poll, error) {
+	start := timemodule.Now()
+
+	result, err := s.PollStore.GetPollsToClose(now, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PollStore.GetPollsToClose", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPollStore) GetVotes(pollId string) ([]*model.PollVote, error) {
+	start := timemodule.Now()
+
+	result, err := s.PollStore.GetVotes(pollId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PollStore.GetVotes", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPollStore) Save(poll *model.Poll) (*model.Poll, error) {
+	start := timemodule.Now()
+
+	result, err := s.PollStore.Save(poll)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PollStore.Save", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPollStore) SaveVotes(pollId string, userId string, votes []*model.PollVote) (*model.Post, error) {
+	start := timemodule.Now()
+
+	result, err := s.PollStore.SaveVotes(pollId, userId, votes)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PollStore.SaveVotes", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPollStore) Update(poll *model.Poll) (*model.Poll, error) {
+	start := timemodule.Now()
+
+	result, err := s.PollStore.Update(poll)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PollStore.Update", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPollStore) UpdateTally(pollId string) (*model.Post, error) {
+	start := timemodule.Now()
+
+	result, err := s.PollStore.UpdateTally(pollId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PollStore.UpdateTally", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerPostStore) AnalyticsPostCount(teamId string, mustHaveFile bool, mustHaveHashtag bool) (int64, error) {
 	start := timemodule.Now()
 
@@ -4974,7 +5932,119 @@ func (s *TimerLayerPreferenceStore) Delete(userId string, category string, name
 func (s *TimerLayerPreferenceStore) DeleteCategory(userId string, category string) error {
 	start := timemodule.Now()
 
-	err := s.PreferenceStore.DeleteCategory(userId, category)
+	err := s.PreferenceStore.DeleteCategory(userId, category)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.DeleteCategory", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerPreferenceStore) DeleteCategoryAndName(category string, name string) error {
+	start := timemodule.Now()
+
+	err := s.PreferenceStore.DeleteCategoryAndName(category, name)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.DeleteCategoryAndName", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerPreferenceStore) Get(userId string, category string, name string) (*model.Preference, error) {
+	start := timemodule.Now()
+
+	result, err := s.PreferenceStore.Get(userId, category, name)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.Get", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPreferenceStore) GetAll(userId string) (model.Preferences, error) {
+	start := timemodule.Now()
+
+	result, err := s.PreferenceStore.GetAll(userId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.GetAll", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPreferenceStore) GetCategory(userId string, category string) (model.Preferences, error) {
+	start := timemodule.Now()
+
+	result, err := s.PreferenceStore.GetCategory(userId, category)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.GetCategory", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPreferenceStore) GetForUsers(userIds []string, category string, name string) (model.Preferences, error) {
+	start := timemodule.Now()
+
+	result, err := s.PreferenceStore.GetForUsers(userIds, category, name)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.GetForUsers", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPreferenceStore) PermanentDeleteByUser(userId string) error {
+	start := timemodule.Now()
+
+	err := s.PreferenceStore.PermanentDeleteByUser(userId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.PermanentDeleteByUser", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerPreferenceStore) Save(preferences *model.Preferences) error {
+	start := timemodule.Now()
+
+	err := s.PreferenceStore.Save(preferences)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -4982,15 +6052,15 @@ func (s *TimerLayerPreferenceStore) DeleteCategory(userId string, category strin
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.DeleteCategory", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.Save", success, elapsed)
 	}
 	return err
 }
 
-func (s *TimerLayerPreferenceStore) DeleteCategoryAndName(category string, name string) error {
+func (s *TimerLayerProductNoticesStore) Clear(notices []string) error {
 	start := timemodule.Now()
 
-	err := s.PreferenceStore.DeleteCategoryAndName(category, name)
+	err := s.ProductNoticesStore.Clear(notices)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -4998,15 +6068,15 @@ func (s *TimerLayerPreferenceStore) DeleteCategoryAndName(category string, name
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.DeleteCategoryAndName", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ProductNoticesStore.Clear", success, elapsed)
 	}
 	return err
 }
 
-func (s *TimerLayerPreferenceStore) Get(userId string, category string, name string) (*model.Preference, error) {
+func (s *TimerLayerProductNoticesStore) ClearOldNotices(currentNotices *model.ProductNotices) error {
 	start := timemodule.Now()
 
-	result, err := s.PreferenceStore.Get(userId, category, name)
+	err := s.ProductNoticesStore.ClearOldNotices(currentNotices)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -5014,15 +6084,15 @@ func (s *TimerLayerPreferenceStore) Get(userId string, category string, name str
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.Get", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ProductNoticesStore.ClearOldNotices", success, elapsed)
 	}
-	return result, err
+	return err
 }
 
-func (s *TimerLayerPreferenceStore) GetAll(userId string) (model.Preferences, error) {
+func (s *TimerLayerProductNoticesStore) GetViews(userId string) ([]model.ProductNoticeViewState, error) {
 	start := timemodule.Now()
 
-	result, err := s.PreferenceStore.GetAll(userId)
+	result, err := s.ProductNoticesStore.GetViews(userId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -5030,15 +6100,15 @@ func (s *TimerLayerPreferenceStore) GetAll(userId string) (model.Preferences, er
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.GetAll", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ProductNoticesStore.GetViews", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPreferenceStore) GetCategory(userId string, category string) (model.Preferences, error) {
+func (s *TimerLayerProductNoticesStore) View(userId string, notices []string) error {
 	start := timemodule.Now()
 
-	result, err := s.PreferenceStore.GetCategory(userId, category)
+	err := s.ProductNoticesStore.View(userId, notices)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -5046,15 +6116,15 @@ func (s *TimerLayerPreferenceStore) GetCategory(userId string, category string)
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.GetCategory", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ProductNoticesStore.View", success, elapsed)
 	}
-	return result, err
+	return err
 }
 
-func (s *TimerLayerPreferenceStore) PermanentDeleteByUser(userId string) error {
+func (s *TimerLayerPublicLinkStore) Get(id string) (*model.PublicLink, error) {
 	start := timemodule.Now()
 
-	err := s.PreferenceStore.PermanentDeleteByUser(userId)
+	result, err := s.PublicLinkStore.Get(id)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -5062,15 +6132,15 @@ func (s *TimerLayerPreferenceStore) PermanentDeleteByUser(userId string) error {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.PermanentDeleteByUser", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PublicLinkStore.Get", success, elapsed)
 	}
-	return err
+	return result, err
 }
 
-func (s *TimerLayerPreferenceStore) Save(preferences *model.Preferences) error {
+func (s *TimerLayerPublicLinkStore) GetActive(fileId string, creatorId string, now int64) (*model.PublicLink, error) {
 	start := timemodule.Now()
 
-	err := s.PreferenceStore.Save(preferences)
+	result, err := s.PublicLinkStore.GetActive(fileId, creatorId, now)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -5078,15 +6148,15 @@ func (s *TimerLayerPreferenceStore) Save(preferences *model.Preferences) error {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.Save", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PublicLinkStore.GetActive", success, elapsed)
 	}
-	return err
+	return result, err
 }
 
-func (s *TimerLayerProductNoticesStore) Clear(notices []string) error {
+func (s *TimerLayerPublicLinkStore) GetForUser(userId string, offset int, limit int) ([]*model.PublicLink, error) {
 	start := timemodule.Now()
 
-	err := s.ProductNoticesStore.Clear(notices)
+	result, err := s.PublicLinkStore.GetForUser(userId, offset, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -5094,15 +6164,15 @@ func (s *TimerLayerProductNoticesStore) Clear(notices []string) error {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ProductNoticesStore.Clear", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PublicLinkStore.GetForUser", success, elapsed)
 	}
-	return err
+	return result, err
 }
 
-func (s *TimerLayerProductNoticesStore) ClearOldNotices(currentNotices *model.ProductNotices) error {
+func (s *TimerLayerPublicLinkStore) RecordDownload(id string, downloadAt int64) error {
 	start := timemodule.Now()
 
-	err := s.ProductNoticesStore.ClearOldNotices(currentNotices)
+	err := s.PublicLinkStore.RecordDownload(id, downloadAt)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -5110,15 +6180,15 @@ func (s *TimerLayerProductNoticesStore) ClearOldNotices(currentNotices *model.Pr
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ProductNoticesStore.ClearOldNotices", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PublicLinkStore.RecordDownload", success, elapsed)
 	}
 	return err
 }
 
-func (s *TimerLayerProductNoticesStore) GetViews(userId string) ([]model.ProductNoticeViewState, error) {
+func (s *TimerLayerPublicLinkStore) Revoke(id string, revokeAt int64) error {
 	start := timemodule.Now()
 
-	result, err := s.ProductNoticesStore.GetViews(userId)
+	err := s.PublicLinkStore.Revoke(id, revokeAt)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -5126,15 +6196,15 @@ func (s *TimerLayerProductNoticesStore) GetViews(userId string) ([]model.Product
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ProductNoticesStore.GetViews", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PublicLinkStore.Revoke", success, elapsed)
 	}
-	return result, err
+	return err
 }
 
-func (s *TimerLayerProductNoticesStore) View(userId string, notices []string) error {
+func (s *TimerLayerPublicLinkStore) Save(link *model.PublicLink) (*model.PublicLink, error) {
 	start := timemodule.Now()
 
-	err := s.ProductNoticesStore.View(userId, notices)
+	result, err := s.PublicLinkStore.Save(link)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -5142,9 +6212,9 @@ func (s *TimerLayerProductNoticesStore) View(userId string, notices []string) er
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ProductNoticesStore.View", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PublicLinkStore.Save", success, elapsed)
 	}
-	return err
+	return result, err
 }
 
 func (s *TimerLayerReactionStore) BulkGetForPosts(postIds []string) ([]*model.Reaction, error) {
@@ -5211,6 +6281,22 @@ func (s *TimerLayerReactionStore) GetForPost(postId string, allowFromCache bool)
 	return result, err
 }
 
+func (s *TimerLayerReactionStore) GetPostIdsForUsers(userIds []string, limit int) ([]string, error) {
+	start := timemodule.Now()
+
+	result, err := s.ReactionStore.GetPostIdsForUsers(userIds, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.GetPostIdsForUsers", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerReactionStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
 	start := timemodule.Now()
 
@@ -5403,6 +6489,118 @@ func (s *TimerLayerRoleStore) Save(role *model.Role) (*model.Role, error) {
 	return result, err
 }
 
+func (s *TimerLayerSavedSearchStore) Delete(id string) error {
+	start := timemodule.Now()
+
+	err := s.SavedSearchStore.Delete(id)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SavedSearchStore.Delete", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerSavedSearchStore) Get(id string) (*model.SavedSearch, error) {
+	start := timemodule.Now()
+
+	result, err := s.SavedSearchStore.Get(id)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SavedSearchStore.Get", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerSavedSearchStore) GetForNotification(afterId string, limit int) ([]*model.SavedSearch, error) {
+	start := timemodule.Now()
+
+	result, err := s.SavedSearchStore.GetForNotification(afterId, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SavedSearchStore.GetForNotification", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerSavedSearchStore) GetForUser(userId string) ([]*model.SavedSearch, error) {
+	start := timemodule.Now()
+
+	result, err := s.SavedSearchStore.GetForUser(userId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SavedSearchStore.GetForUser", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerSavedSearchStore) Save(search *model.SavedSearch) (*model.SavedSearch, error) {
+	start := timemodule.Now()
+
+	result, err := s.SavedSearchStore.Save(search)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SavedSearchStore.Save", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerSavedSearchStore) Update(search *model.SavedSearch) (*model.SavedSearch, error) {
+	start := timemodule.Now()
+
+	result, err := s.SavedSearchStore.Update(search)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SavedSearchStore.Update", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerSavedSearchStore) UpdateLastCheckedAt(id string, checkedAt int64) error {
+	start := timemodule.Now()
+
+	err := s.SavedSearchStore.UpdateLastCheckedAt(id, checkedAt)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SavedSearchStore.UpdateLastCheckedAt", success, elapsed)
+	}
+	return err
+}
+
 func (s *TimerLayerSchemeStore) CountByScope(scope string) (int64, error) {
 	start := timemodule.Now()
 
@@ -5818,6 +7016,22 @@ func (s *TimerLayerStatusStore) GetByIds(userIds []string) ([]*model.Status, err
 	return result, err
 }
 
+func (s *TimerLayerStatusStore) GetExpiredDND(expiredAt int64, limit int) ([]*model.Status, error) {
+	start := timemodule.Now()
+
+	result, err := s.StatusStore.GetExpiredDND(expiredAt, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("StatusStore.GetExpiredDND", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerStatusStore) GetTotalActiveUsersCount() (int64, error) {
 	start := timemodule.Now()
 
@@ -5882,6 +7096,54 @@ func (s *TimerLayerStatusStore) UpdateLastActivityAt(userId string, lastActivity
 	return err
 }
 
+func (s *TimerLayerStorageUsageStore) Get(targetType string, targetId string) (*model.StorageUsage, error) {
+	start := timemodule.Now()
+
+	result, err := s.StorageUsageStore.Get(targetType, targetId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("StorageUsageStore.Get", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerStorageUsageStore) GetAll(targetType string, offset int, limit int) ([]*model.StorageUsage, error) {
+	start := timemodule.Now()
+
+	result, err := s.StorageUsageStore.GetAll(targetType, offset, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("StorageUsageStore.GetAll", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerStorageUsageStore) Recompute() error {
+	start := timemodule.Now()
+
+	err := s.StorageUsageStore.Recompute()
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("StorageUsageStore.Recompute", success, elapsed)
+	}
+	return err
+}
+
 func (s *TimerLayerSystemStore) Get() (model.StringMap, error) {
 	start := timemodule.Now()
 
@@ -7032,6 +8294,22 @@ func (s *TimerLayerThreadStore) GetThreadsForUser(userId string, opts model.GetU
 	return result, err
 }
 
+func (s *TimerLayerThreadStore) GetUnreadThreadCountsByTeam(userId string) (map[string]int64, error) {
+	start := timemodule.Now()
+
+	result, err := s.ThreadStore.GetUnreadThreadCountsByTeam(userId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ThreadStore.GetUnreadThreadCountsByTeam", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerThreadStore) MarkAllAsRead(userId string, timestamp int64) error {
 	start := timemodule.Now()
 
@@ -7271,6 +8549,22 @@ func (s *TimerLayerUploadSessionStore) Get(id string) (*model.UploadSession, err
 	return result, err
 }
 
+func (s *TimerLayerUploadSessionStore) GetAllPage(offset int, limit int) ([]*model.UploadSession, error) {
+	start := timemodule.Now()
+
+	result, err := s.UploadSessionStore.GetAllPage(offset, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("UploadSessionStore.GetAllPage", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerUploadSessionStore) GetForUser(userId string) ([]*model.UploadSession, error) {
 	start := timemodule.Now()
 
@@ -7287,6 +8581,22 @@ func (s *TimerLayerUploadSessionStore) GetForUser(userId string) ([]*model.Uploa
 	return result, err
 }
 
+func (s *TimerLayerUploadSessionStore) GetUpdatedBefore(updateAt int64, limit int) ([]*model.UploadSession, error) {
+	start := timemodule.Now()
+
+	result, err := s.UploadSessionStore.GetUpdatedBefore(updateAt, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("UploadSessionStore.GetUpdatedBefore", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerUploadSessionStore) Save(session *model.UploadSession) (*model.UploadSession, error) {
 	start := timemodule.Now()
 
@@ -9090,27 +10400,38 @@ func New(childStore store.Store, metrics einterfaces.MetricsInterface) *TimerLay
 	newStore.AuditStore = &TimerLayerAuditStore{AuditStore: childStore.Audit(), Root: &newStore}
 	newStore.BotStore = &TimerLayerBotStore{BotStore: childStore.Bot(), Root: &newStore}
 	newStore.ChannelStore = &TimerLayerChannelStore{ChannelStore: childStore.Channel(), Root: &newStore}
+	newStore.ChannelBookmarkStore = &TimerLayerChannelBookmarkStore{ChannelBookmarkStore: childStore.ChannelBookmark(), Root: &newStore}
 	newStore.ChannelMemberHistoryStore = &TimerLayerChannelMemberHistoryStore{ChannelMemberHistoryStore: childStore.ChannelMemberHistory(), Root: &newStore}
 	newStore.ClusterDiscoveryStore = &TimerLayerClusterDiscoveryStore{ClusterDiscoveryStore: childStore.ClusterDiscovery(), Root: &newStore}
 	newStore.CommandStore = &TimerLayerCommandStore{CommandStore: childStore.Command(), Root: &newStore}
 	newStore.CommandWebhookStore = &TimerLayerCommandWebhookStore{CommandWebhookStore: childStore.CommandWebhook(), Root: &newStore}
 	newStore.ComplianceStore = &TimerLayerComplianceStore{ComplianceStore: childStore.Compliance(), Root: &newStore}
+	newStore.CustomStatusStore = &TimerLayerCustomStatusStore{CustomStatusStore: childStore.CustomStatus(), Root: &newStore}
+	newStore.DndScheduleStore = &TimerLayerDndScheduleStore{DndScheduleStore: childStore.DndSchedule(), Root: &newStore}
+	newStore.DraftStore = &TimerLayerDraftStore{DraftStore: childStore.Draft(), Root: &newStore}
+	newStore.EmailDigestStore = &TimerLayerEmailDigestStore{EmailDigestStore: childStore.EmailDigest(), Root: &newStore}
+	newStore.EmailQueueStore = &TimerLayerEmailQueueStore{EmailQueueStore: childStore.EmailQueue(), Root: &newStore}
 	newStore.EmojiStore = &TimerLayerEmojiStore{EmojiStore: childStore.Emoji(), Root: &newStore}
 	newStore.FileInfoStore = &TimerLayerFileInfoStore{FileInfoStore: childStore.FileInfo(), Root: &newStore}
 	newStore.GroupStore = &TimerLayerGroupStore{GroupStore: childStore.Group(), Root: &newStore}
 	newStore.JobStore = &TimerLayerJobStore{JobStore: childStore.Job(), Root: &newStore}
+	newStore.KeywordWatchStore = &TimerLayerKeywordWatchStore{KeywordWatchStore: childStore.KeywordWatch(), Root: &newStore}
 	newStore.LicenseStore = &TimerLayerLicenseStore{LicenseStore: childStore.License(), Root: &newStore}
 	newStore.LinkMetadataStore = &TimerLayerLinkMetadataStore{LinkMetadataStore: childStore.LinkMetadata(), Root: &newStore}
 	newStore.OAuthStore = &TimerLayerOAuthStore{OAuthStore: childStore.OAuth(), Root: &newStore}
 	newStore.PluginStore = &TimerLayerPluginStore{PluginStore: childStore.Plugin(), Root: &newStore}
+	newStore.PollStore = &TimerLayerPollStore{PollStore: childStore.Poll(), Root: &newStore}
 	newStore.PostStore = &TimerLayerPostStore{PostStore: childStore.Post(), Root: &newStore}
 	newStore.PreferenceStore = &TimerLayerPreferenceStore{PreferenceStore: childStore.Preference(), Root: &newStore}
 	newStore.ProductNoticesStore = &TimerLayerProductNoticesStore{ProductNoticesStore: childStore.ProductNotices(), Root: &newStore}
+	newStore.PublicLinkStore = &TimerLayerPublicLinkStore{PublicLinkStore: childStore.PublicLink(), Root: &newStore}
 	newStore.ReactionStore = &TimerLayerReactionStore{ReactionStore: childStore.Reaction(), Root: &newStore}
 	newStore.RoleStore = &TimerLayerRoleStore{RoleStore: childStore.Role(), Root: &newStore}
+	newStore.SavedSearchStore = &TimerLayerSavedSearchStore{SavedSearchStore: childStore.SavedSearch(), Root: &newStore}
 	newStore.SchemeStore = &TimerLayerSchemeStore{SchemeStore: childStore.Scheme(), Root: &newStore}
 	newStore.SessionStore = &TimerLayerSessionStore{SessionStore: childStore.Session(), Root: &newStore}
 	newStore.StatusStore = &TimerLayerStatusStore{StatusStore: childStore.Status(), Root: &newStore}
+	newStore.StorageUsageStore = &TimerLayerStorageUsageStore{StorageUsageStore: childStore.StorageUsage(), Root: &newStore}
 	newStore.SystemStore = &TimerLayerSystemStore{SystemStore: childStore.System(), Root: &newStore}
 	newStore.TeamStore = &TimerLayerTeamStore{TeamStore: childStore.Team(), Root: &newStore}
 	newStore.TermsOfServiceStore = &TimerLayerTermsOfServiceStore{TermsOfServiceStore: childStore.TermsOfService(), Root: &newStore}
@@ -0,0 +1,656 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package imgutils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"sort"
+)
+
+// Kinds of metadata reported as removed by StripMetadata.
+const (
+	MetadataExif    = "exif"
+	MetadataGPS     = "gps"
+	MetadataXMP     = "xmp"
+	MetadataIPTC    = "iptc"
+	MetadataComment = "comment"
+	MetadataText    = "text"
+	// MetadataAll is reported when the image was encoded again without any
+	// of its metadata, rather than stripped.
+	MetadataAll = "all"
+)
+
+const (
+	exifTagOrientation = 0x0112
+	exifTagGPSInfo     = 0x8825
+)
+
+var (
+	ErrUnsupportedFormat = errors.New("imgutils: unsupported image format")
+	errMalformed         = errors.New("imgutils: malformed image")
+
+	jpegExifHeader   = []byte("Exif\x00\x00")
+	jpegXMPHeader    = []byte("http://ns.adobe.com/xap/1.0/\x00")
+	jpegXMPExtHeader = []byte("http://ns.adobe.com/xmp/extension/\x00")
+	jpegIPTCHeader   = []byte("Photoshop 3.0\x00")
+	pngSignature     = []byte("\x89PNG\r\n\x1a\n")
+)
+
+// StripMetadata removes the EXIF (including GPS), XMP, IPTC and comment
+// metadata from a JPEG, PNG, WebP or HEIC/AVIF image without re-encoding it.
+// JPEG, PNG and WebP images keep a minimal EXIF block holding only their
+// orientation so that they are still displayed upright; HEIC and AVIF images
+// carry their orientation in properties of the image itself, and have their
+// metadata items overwritten in place instead. It returns the stripped image
+// and the sorted kinds of metadata that were removed, or ErrUnsupportedFormat
+// for any other kind of image.
+func StripMetadata(data []byte) ([]byte, []string, error) {
+	r := &metadataReport{kinds: map[string]bool{}}
+
+	var stripped []byte
+	var err error
+	switch {
+	case len(data) > 2 && data[0] == 0xff && data[1] == 0xd8:
+		stripped, err = stripJPEG(data, r)
+	case bytes.HasPrefix(data, pngSignature):
+		stripped, err = stripPNG(data, r)
+	case len(data) >= 12 && string(data[0:4]) == "RIFF" && string(data[8:12]) == "WEBP":
+		stripped, err = stripWebP(data, r)
+	case isHEIF(data):
+		stripped, err = stripHEIF(data, r)
+	default:
+		return nil, nil, ErrUnsupportedFormat
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return stripped, r.list(), nil
+}
+
+type metadataReport struct {
+	kinds map[string]bool
+}
+
+func (r *metadataReport) add(kind string) {
+	r.kinds[kind] = true
+}
+
+// addExif records removed EXIF data, and returns the orientation it held.
+func (r *metadataReport) addExif(tiff []byte) int {
+	r.add(MetadataExif)
+	orientation, hasGPS := parseExif(tiff)
+	if hasGPS {
+		r.add(MetadataGPS)
+	}
+	return orientation
+}
+
+func (r *metadataReport) list() []string {
+	kinds := make([]string, 0, len(r.kinds))
+	for kind := range r.kinds {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+// parseExif returns the orientation stored in the first IFD of an EXIF TIFF
+// structure, and whether the structure points to GPS information.
+func parseExif(tiff []byte) (orientation int, hasGPS bool) {
+	if len(tiff) < 8 {
+		return 0, false
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[0:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 0, false
+	}
+
+	ifd := int(order.Uint32(tiff[4:8]))
+	if ifd < 8 || ifd+2 > len(tiff) {
+		return 0, false
+	}
+
+	count := int(order.Uint16(tiff[ifd : ifd+2]))
+	for i := 0; i < count; i++ {
+		entry := ifd + 2 + i*12
+		if entry+12 > len(tiff) {
+			break
+		}
+
+		switch order.Uint16(tiff[entry : entry+2]) {
+		case exifTagOrientation:
+			orientation = int(order.Uint16(tiff[entry+8 : entry+10]))
+		case exifTagGPSInfo:
+			hasGPS = true
+		}
+	}
+
+	return orientation, hasGPS
+}
+
+// orientationExif returns an EXIF TIFF structure holding only the given
+// orientation, or nil when the image is upright and needs none.
+func orientationExif(orientation int) []byte {
+	if orientation < 2 || orientation > 8 {
+		return nil
+	}
+
+	tiff := make([]byte, 26)
+	copy(tiff, "MM\x00\x2a")
+	binary.BigEndian.PutUint32(tiff[4:], 8)
+	binary.BigEndian.PutUint16(tiff[8:], 1)
+	binary.BigEndian.PutUint16(tiff[10:], exifTagOrientation)
+	binary.BigEndian.PutUint16(tiff[12:], 3) // SHORT
+	binary.BigEndian.PutUint32(tiff[14:], 1)
+	binary.BigEndian.PutUint16(tiff[18:], uint16(orientation))
+	return tiff
+}
+
+func stripJPEG(data []byte, r *metadataReport) ([]byte, error) {
+	out := make([]byte, 0, len(data))
+	out = append(out, data[0:2]...)
+
+	orientation := 0
+	var exifAt int
+	pos := 2
+	for {
+		if pos+2 > len(data) || data[pos] != 0xff {
+			return nil, errMalformed
+		}
+		marker := data[pos+1]
+		if marker == 0xff {
+			// Fill byte
+			pos++
+			continue
+		}
+
+		// The entropy-coded image data follows the start of scan, and is kept
+		// as is along with whatever follows it.
+		if marker == 0xda || marker == 0xd9 {
+			if exifAt == 0 {
+				exifAt = len(out)
+			}
+			out = append(out, data[pos:]...)
+			break
+		}
+
+		// Markers without a payload
+		if marker == 0x01 || (marker >= 0xd0 && marker <= 0xd7) {
+			out = append(out, data[pos:pos+2]...)
+			pos += 2
+			continue
+		}
+
+		if pos+4 > len(data) {
+			return nil, errMalformed
+		}
+		end := pos + 2 + int(binary.BigEndian.Uint16(data[pos+2:pos+4]))
+		if end < pos+4 || end > len(data) {
+			return nil, errMalformed
+		}
+		payload := data[pos+4 : end]
+
+		keep := true
+		switch {
+		case marker == 0xe1 && bytes.HasPrefix(payload, jpegExifHeader):
+			if o := r.addExif(payload[len(jpegExifHeader):]); o != 0 {
+				orientation = o
+			}
+			keep = false
+		case marker == 0xe1 && (bytes.HasPrefix(payload, jpegXMPHeader) || bytes.HasPrefix(payload, jpegXMPExtHeader)):
+			r.add(MetadataXMP)
+			keep = false
+		case marker == 0xed && bytes.HasPrefix(payload, jpegIPTCHeader):
+			r.add(MetadataIPTC)
+			keep = false
+		case marker == 0xfe:
+			r.add(MetadataComment)
+			keep = false
+		}
+
+		// The EXIF block goes after the JFIF header, which must come first.
+		if exifAt == 0 && marker != 0xe0 {
+			exifAt = len(out)
+		}
+		if keep {
+			out = append(out, data[pos:end]...)
+		}
+		pos = end
+	}
+
+	if tiff := orientationExif(orientation); tiff != nil {
+		segment := make([]byte, 4, 4+len(jpegExifHeader)+len(tiff))
+		segment[0], segment[1] = 0xff, 0xe1
+		binary.BigEndian.PutUint16(segment[2:], uint16(2+len(jpegExifHeader)+len(tiff)))
+		segment = append(segment, jpegExifHeader...)
+		segment = append(segment, tiff...)
+
+		out = append(out[:exifAt], append(segment, out[exifAt:]...)...)
+	}
+
+	return out, nil
+}
+
+func stripPNG(data []byte, r *metadataReport) ([]byte, error) {
+	out := make([]byte, 0, len(data))
+	out = append(out, pngSignature...)
+
+	pos := len(pngSignature)
+	for pos < len(data) {
+		if pos+12 > len(data) {
+			return nil, errMalformed
+		}
+		length := int(binary.BigEndian.Uint32(data[pos : pos+4]))
+		end := pos + 12 + length
+		if length < 0 || end > len(data) || end < pos {
+			return nil, errMalformed
+		}
+		chunkType := string(data[pos+4 : pos+8])
+		chunk := data[pos+8 : pos+8+length]
+
+		switch chunkType {
+		case "eXIf":
+			if tiff := orientationExif(r.addExif(chunk)); tiff != nil {
+				out = appendPNGChunk(out, "eXIf", tiff)
+			}
+		case "iTXt":
+			if bytes.HasPrefix(chunk, []byte("XML:com.adobe.xmp\x00")) {
+				r.add(MetadataXMP)
+			} else {
+				r.add(MetadataText)
+			}
+		case "tEXt", "zTXt":
+			r.add(MetadataText)
+		default:
+			out = append(out, data[pos:end]...)
+		}
+
+		pos = end
+		if chunkType == "IEND" {
+			break
+		}
+	}
+
+	return out, nil
+}
+
+func appendPNGChunk(out []byte, chunkType string, chunk []byte) []byte {
+	var header [8]byte
+	binary.BigEndian.PutUint32(header[0:4], uint32(len(chunk)))
+	copy(header[4:8], chunkType)
+	out = append(out, header[:]...)
+	out = append(out, chunk...)
+
+	crc := crc32.NewIEEE()
+	crc.Write(header[4:8])
+	crc.Write(chunk)
+	var sum [4]byte
+	binary.BigEndian.PutUint32(sum[:], crc.Sum32())
+	return append(out, sum[:]...)
+}
+
+const (
+	webpFlagXMP  = 0x04
+	webpFlagExif = 0x08
+)
+
+func stripWebP(data []byte, r *metadataReport) ([]byte, error) {
+	out := make([]byte, 0, len(data))
+	out = append(out, data[0:12]...)
+
+	orientation := 0
+	vp8x := -1
+	pos := 12
+	for pos < len(data) {
+		if pos+8 > len(data) {
+			return nil, errMalformed
+		}
+		length := int(binary.LittleEndian.Uint32(data[pos+4 : pos+8]))
+		end := pos + 8 + length + length%2
+		if end == len(data)+1 {
+			// Some encoders leave out the padding of the last chunk.
+			end--
+		}
+		if length < 0 || end < pos || pos+8+length > len(data) {
+			return nil, errMalformed
+		}
+		chunk := data[pos+8 : pos+8+length]
+
+		switch string(data[pos : pos+4]) {
+		case "EXIF":
+			orientation = r.addExif(bytes.TrimPrefix(chunk, jpegExifHeader))
+		case "XMP ":
+			r.add(MetadataXMP)
+		case "VP8X":
+			if length < 1 {
+				return nil, errMalformed
+			}
+			vp8x = len(out) + 8
+			out = append(out, data[pos:end]...)
+		default:
+			out = append(out, data[pos:end]...)
+		}
+		pos = end
+	}
+
+	// Only the extended format can hold metadata.
+	if vp8x >= 0 {
+		out[vp8x] &^= webpFlagXMP | webpFlagExif
+
+		if tiff := orientationExif(orientation); tiff != nil {
+			out[vp8x] |= webpFlagExif
+
+			var header [8]byte
+			copy(header[0:4], "EXIF")
+			binary.LittleEndian.PutUint32(header[4:8], uint32(len(tiff)))
+			out = append(out, header[:]...)
+			out = append(out, tiff...)
+		}
+	}
+
+	binary.LittleEndian.PutUint32(out[4:8], uint32(len(out)-8))
+	return out, nil
+}
+
+// isoBox is a box of an ISO base media file, such as a HEIC or AVIF image.
+type isoBox struct {
+	boxType string
+	// start and end are the offsets of the box contents in the file.
+	start, end int
+}
+
+// readISOBoxes returns the boxes found in data[start:end].
+func readISOBoxes(data []byte, start, end int) ([]isoBox, error) {
+	var boxes []isoBox
+	for pos := start; pos < end; {
+		if pos+8 > end {
+			return nil, errMalformed
+		}
+		size := int64(binary.BigEndian.Uint32(data[pos : pos+4]))
+		box := isoBox{boxType: string(data[pos+4 : pos+8]), start: pos + 8}
+		switch size {
+		case 0:
+			size = int64(end - pos)
+		case 1:
+			if pos+16 > end {
+				return nil, errMalformed
+			}
+			size = int64(binary.BigEndian.Uint64(data[pos+8 : pos+16]))
+			box.start += 8
+		}
+		if size < int64(box.start-pos) || size > int64(end-pos) {
+			return nil, errMalformed
+		}
+		box.end = pos + int(size)
+		boxes = append(boxes, box)
+		pos = box.end
+	}
+	return boxes, nil
+}
+
+func findISOBox(boxes []isoBox, boxType string) (isoBox, bool) {
+	for _, box := range boxes {
+		if box.boxType == boxType {
+			return box, true
+		}
+	}
+	return isoBox{}, false
+}
+
+func isHEIF(data []byte) bool {
+	if len(data) < 16 || string(data[4:8]) != "ftyp" {
+		return false
+	}
+
+	end := int(binary.BigEndian.Uint32(data[0:4]))
+	if end < 16 || end > len(data) {
+		return false
+	}
+
+	// The major brand, then the compatible brands after the minor version.
+	for pos := 8; pos+4 <= end; pos += 4 {
+		if pos == 12 {
+			continue
+		}
+		switch string(data[pos : pos+4]) {
+		case "mif1", "msf1", "heic", "heix", "avif":
+			return true
+		}
+	}
+	return false
+}
+
+type isoExtent struct {
+	offset, length int
+}
+
+func stripHEIF(data []byte, r *metadataReport) ([]byte, error) {
+	boxes, err := readISOBoxes(data, 0, len(data))
+	if err != nil {
+		return nil, err
+	}
+
+	meta, ok := findISOBox(boxes, "meta")
+	if !ok || meta.start+4 > meta.end {
+		return data, nil
+	}
+	metaBoxes, err := readISOBoxes(data, meta.start+4, meta.end)
+	if err != nil {
+		return nil, err
+	}
+
+	iinf, ok := findISOBox(metaBoxes, "iinf")
+	if !ok {
+		return data, nil
+	}
+	items, err := readHEIFMetadataItems(data, iinf)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return data, nil
+	}
+
+	iloc, ok := findISOBox(metaBoxes, "iloc")
+	if !ok {
+		return nil, errMalformed
+	}
+	idatStart := -1
+	if idat, ok := findISOBox(metaBoxes, "idat"); ok {
+		idatStart = idat.start
+	}
+	locations, err := readHEIFItemLocations(data, iloc, idatStart)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, len(data))
+	copy(out, data)
+	for id, kind := range items {
+		extents, ok := locations[id]
+		if !ok {
+			continue
+		}
+
+		if kind == MetadataExif {
+			var payload []byte
+			for _, extent := range extents {
+				payload = append(payload, data[extent.offset:extent.offset+extent.length]...)
+			}
+			// The payload starts with the offset of the TIFF header.
+			if len(payload) >= 4 {
+				if tiffAt := 4 + int(binary.BigEndian.Uint32(payload[0:4])); tiffAt >= 4 && tiffAt <= len(payload) {
+					r.addExif(payload[tiffAt:])
+				}
+			}
+			r.add(MetadataExif)
+		} else {
+			r.add(kind)
+		}
+
+		for _, extent := range extents {
+			for i := extent.offset; i < extent.offset+extent.length; i++ {
+				out[i] = 0
+			}
+		}
+	}
+
+	return out, nil
+}
+
+// readHEIFMetadataItems returns the kind of metadata held by each EXIF or XMP
+// item listed in an iinf box, by item id.
+func readHEIFMetadataItems(data []byte, iinf isoBox) (map[uint32]string, error) {
+	if iinf.start+4 > iinf.end {
+		return nil, errMalformed
+	}
+	start := iinf.start + 6
+	if data[iinf.start] != 0 {
+		start = iinf.start + 8
+	}
+	if start > iinf.end {
+		return nil, errMalformed
+	}
+
+	entries, err := readISOBoxes(data, start, iinf.end)
+	if err != nil {
+		return nil, err
+	}
+
+	items := map[uint32]string{}
+	for _, infe := range entries {
+		if infe.boxType != "infe" || infe.start+4 > infe.end {
+			continue
+		}
+
+		// Only versions 2 and later give the item type.
+		version := data[infe.start]
+		pos := infe.start + 4
+		var id uint32
+		switch {
+		case version == 2 && pos+8 <= infe.end:
+			id = uint32(binary.BigEndian.Uint16(data[pos : pos+2]))
+			pos += 4
+		case version == 3 && pos+10 <= infe.end:
+			id = binary.BigEndian.Uint32(data[pos : pos+4])
+			pos += 6
+		default:
+			continue
+		}
+
+		switch string(data[pos : pos+4]) {
+		case "Exif":
+			items[id] = MetadataExif
+		case "mime":
+			// The item name, then its content type, as null-terminated strings.
+			fields := bytes.SplitN(data[pos+4:infe.end], []byte{0}, 3)
+			if len(fields) >= 2 && string(fields[1]) == "application/rdf+xml" {
+				items[id] = MetadataXMP
+			}
+		}
+	}
+
+	return items, nil
+}
+
+// readHEIFItemLocations returns the extents of the items listed in an iloc
+// box that are stored in the file itself or in its idat box, by item id.
+func readHEIFItemLocations(data []byte, iloc isoBox, idatStart int) (map[uint32][]isoExtent, error) {
+	b := &isoReader{data: data[iloc.start:iloc.end]}
+
+	version := b.uint(1)
+	b.uint(3)
+	sizes := b.uint(1)
+	offsetSize, lengthSize := int(sizes>>4), int(sizes&0x0f)
+	sizes = b.uint(1)
+	baseOffsetSize, indexSize := int(sizes>>4), 0
+	if version == 1 || version == 2 {
+		indexSize = int(sizes & 0x0f)
+	}
+
+	itemCount := b.uint(2)
+	if version >= 2 {
+		itemCount = b.uint(4)
+	}
+
+	locations := map[uint32][]isoExtent{}
+	for i := uint64(0); i < itemCount && b.err == nil; i++ {
+		id := b.uint(2)
+		if version >= 2 {
+			id = b.uint(4)
+		}
+		constructionMethod := uint64(0)
+		if version == 1 || version == 2 {
+			constructionMethod = b.uint(2) & 0x0f
+		}
+		b.uint(2) // data reference index
+		baseOffset := b.uint(baseOffsetSize)
+
+		extentCount := b.uint(2)
+		var extents []isoExtent
+		for j := uint64(0); j < extentCount && b.err == nil; j++ {
+			b.uint(indexSize)
+			offset := baseOffset + b.uint(offsetSize)
+			length := b.uint(lengthSize)
+
+			switch constructionMethod {
+			case 0:
+			case 1:
+				if idatStart < 0 {
+					return nil, errMalformed
+				}
+				offset += uint64(idatStart)
+			default:
+				// Items built from other items hold no data of their own.
+				continue
+			}
+
+			if length == 0 || offset > uint64(len(data)) || length > uint64(len(data))-offset {
+				return nil, errMalformed
+			}
+			extents = append(extents, isoExtent{offset: int(offset), length: int(length)})
+		}
+		locations[uint32(id)] = extents
+	}
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	return locations, nil
+}
+
+// isoReader reads big-endian unsigned integers of any size up to 8 bytes,
+// recording an error rather than reading past the end of its data.
+type isoReader struct {
+	data []byte
+	pos  int
+	err  error
+}
+
+func (b *isoReader) uint(size int) uint64 {
+	if b.err != nil {
+		return 0
+	}
+	if b.pos+size > len(b.data) {
+		b.err = errMalformed
+		return 0
+	}
+
+	var v uint64
+	for _, c := range b.data[b.pos : b.pos+size] {
+		v = v<<8 | uint64(c)
+	}
+	b.pos += size
+	return v
+}
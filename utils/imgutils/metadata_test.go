@@ -0,0 +1,270 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package imgutils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/rwcarlsen/goexif/exif"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zacmm/zacmm-server/utils/testutils"
+)
+
+// testExif returns a little-endian EXIF TIFF structure with the given
+// orientation and an empty GPS IFD.
+func testExif(orientation int) []byte {
+	tiff := make([]byte, 44)
+	copy(tiff, "II\x2a\x00")
+	binary.LittleEndian.PutUint32(tiff[4:], 8)
+	binary.LittleEndian.PutUint16(tiff[8:], 2)
+	binary.LittleEndian.PutUint16(tiff[10:], exifTagOrientation)
+	binary.LittleEndian.PutUint16(tiff[12:], 3)
+	binary.LittleEndian.PutUint32(tiff[14:], 1)
+	binary.LittleEndian.PutUint16(tiff[18:], uint16(orientation))
+	binary.LittleEndian.PutUint16(tiff[22:], exifTagGPSInfo)
+	binary.LittleEndian.PutUint16(tiff[24:], 4)
+	binary.LittleEndian.PutUint32(tiff[26:], 1)
+	binary.LittleEndian.PutUint32(tiff[30:], 38)
+	return tiff
+}
+
+func jpegSegment(marker byte, payload []byte) []byte {
+	segment := []byte{0xff, marker, 0, 0}
+	binary.BigEndian.PutUint16(segment[2:], uint16(2+len(payload)))
+	return append(segment, payload...)
+}
+
+func testJPEG(t *testing.T, segments ...[]byte) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, image.NewGray(image.Rect(0, 0, 8, 4)), nil))
+	encoded := buf.Bytes()
+
+	data := append([]byte{}, encoded[:2]...)
+	for _, segment := range segments {
+		data = append(data, segment...)
+	}
+	return append(data, encoded[2:]...)
+}
+
+func jpegOrientation(t *testing.T, data []byte) int {
+	x, err := exif.Decode(bytes.NewReader(data))
+	require.NoError(t, err)
+	tag, err := x.Get(exif.Orientation)
+	require.NoError(t, err)
+	orientation, err := tag.Int(0)
+	require.NoError(t, err)
+	return orientation
+}
+
+func TestStripMetadataJPEG(t *testing.T) {
+	xmp := append(append([]byte{}, jpegXMPHeader...), "<x:xmpmeta/>"...)
+
+	t.Run("should strip metadata and keep the orientation", func(t *testing.T) {
+		data := testJPEG(t,
+			jpegSegment(0xe1, append(append([]byte{}, jpegExifHeader...), testExif(6)...)),
+			jpegSegment(0xe1, xmp),
+			jpegSegment(0xed, append(append([]byte{}, jpegIPTCHeader...), "8BIM"...)),
+			jpegSegment(0xfe, []byte("a comment")),
+		)
+
+		stripped, removed, err := StripMetadata(data)
+		require.NoError(t, err)
+		assert.Equal(t, []string{MetadataComment, MetadataExif, MetadataGPS, MetadataIPTC, MetadataXMP}, removed)
+
+		assert.False(t, bytes.Contains(stripped, []byte("xmpmeta")))
+		assert.False(t, bytes.Contains(stripped, []byte("8BIM")))
+		assert.False(t, bytes.Contains(stripped, []byte("a comment")))
+		assert.Equal(t, 6, jpegOrientation(t, stripped))
+
+		x, err := exif.Decode(bytes.NewReader(stripped))
+		require.NoError(t, err)
+		_, err = x.Get(exif.GPSInfoIFDPointer)
+		assert.Error(t, err)
+
+		_, err = jpeg.Decode(bytes.NewReader(stripped))
+		assert.NoError(t, err)
+	})
+
+	t.Run("should not keep EXIF for an upright image", func(t *testing.T) {
+		data := testJPEG(t, jpegSegment(0xe1, append(append([]byte{}, jpegExifHeader...), testExif(1)...)))
+
+		stripped, removed, err := StripMetadata(data)
+		require.NoError(t, err)
+		assert.Equal(t, []string{MetadataExif, MetadataGPS}, removed)
+		assert.False(t, bytes.Contains(stripped, jpegExifHeader))
+	})
+
+	t.Run("should keep an image without metadata as is", func(t *testing.T) {
+		data := testJPEG(t)
+
+		stripped, removed, err := StripMetadata(data)
+		require.NoError(t, err)
+		assert.Empty(t, removed)
+		assert.Equal(t, data, stripped)
+	})
+
+	t.Run("should keep the orientation of actual photos", func(t *testing.T) {
+		for _, name := range []string{"orientation_test_1.jpeg", "orientation_test_6.jpeg", "orientation_test_8.jpeg"} {
+			data, err := testutils.ReadTestFile(name)
+			require.NoError(t, err)
+
+			stripped, removed, err := StripMetadata(data)
+			require.NoError(t, err, name)
+			assert.Contains(t, removed, MetadataExif, name)
+
+			if orientation := jpegOrientation(t, data); orientation > 1 {
+				assert.Equal(t, orientation, jpegOrientation(t, stripped), name)
+			}
+
+			original, err := jpeg.Decode(bytes.NewReader(data))
+			require.NoError(t, err, name)
+			decoded, err := jpeg.Decode(bytes.NewReader(stripped))
+			require.NoError(t, err, name)
+			assert.Equal(t, original.Bounds(), decoded.Bounds(), name)
+		}
+	})
+
+	t.Run("should return an error for a truncated image", func(t *testing.T) {
+		data := testJPEG(t, jpegSegment(0xfe, []byte("a comment")))
+
+		_, _, err := StripMetadata(data[:10])
+		assert.Error(t, err)
+	})
+}
+
+func TestStripMetadataPNG(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewGray(image.Rect(0, 0, 8, 4))))
+	encoded := buf.Bytes()
+
+	// The IHDR chunk comes first, after the signature.
+	ihdrEnd := len(pngSignature) + 25
+	data := append([]byte{}, encoded[:ihdrEnd]...)
+	data = appendPNGChunk(data, "eXIf", testExif(3))
+	data = appendPNGChunk(data, "tEXt", []byte("Comment\x00a comment"))
+	data = appendPNGChunk(data, "iTXt", []byte("XML:com.adobe.xmp\x00\x00\x00\x00\x00<x:xmpmeta/>"))
+	data = append(data, encoded[ihdrEnd:]...)
+
+	stripped, removed, err := StripMetadata(data)
+	require.NoError(t, err)
+	assert.Equal(t, []string{MetadataExif, MetadataGPS, MetadataText, MetadataXMP}, removed)
+	assert.False(t, bytes.Contains(stripped, []byte("a comment")))
+	assert.False(t, bytes.Contains(stripped, []byte("xmpmeta")))
+
+	exifAt := bytes.Index(stripped, []byte("eXIf"))
+	require.NotEqual(t, -1, exifAt)
+	length := int(binary.BigEndian.Uint32(stripped[exifAt-4 : exifAt]))
+	orientation, hasGPS := parseExif(stripped[exifAt+4 : exifAt+4+length])
+	assert.Equal(t, 3, orientation)
+	assert.False(t, hasGPS)
+
+	_, err = png.Decode(bytes.NewReader(stripped))
+	assert.NoError(t, err)
+}
+
+func riffChunk(fourCC string, payload []byte) []byte {
+	chunk := make([]byte, 8, 8+len(payload)+1)
+	copy(chunk, fourCC)
+	binary.LittleEndian.PutUint32(chunk[4:], uint32(len(payload)))
+	chunk = append(chunk, payload...)
+	if len(payload)%2 == 1 {
+		chunk = append(chunk, 0)
+	}
+	return chunk
+}
+
+func TestStripMetadataWebP(t *testing.T) {
+	vp8x := make([]byte, 10)
+	vp8x[0] = webpFlagExif | webpFlagXMP
+
+	body := []byte("WEBP")
+	body = append(body, riffChunk("VP8X", vp8x)...)
+	body = append(body, riffChunk("VP8L", []byte("image"))...)
+	body = append(body, riffChunk("EXIF", append(append([]byte{}, jpegExifHeader...), testExif(8)...))...)
+	body = append(body, riffChunk("XMP ", []byte("<x:xmpmeta/>"))...)
+	data := append(riffChunk("RIFF", nil), body...)
+	binary.LittleEndian.PutUint32(data[4:], uint32(len(body)))
+
+	stripped, removed, err := StripMetadata(data)
+	require.NoError(t, err)
+	assert.Equal(t, []string{MetadataExif, MetadataGPS, MetadataXMP}, removed)
+	assert.False(t, bytes.Contains(stripped, []byte("xmpmeta")))
+
+	assert.Equal(t, uint32(len(stripped)-8), binary.LittleEndian.Uint32(stripped[4:8]))
+	assert.Equal(t, byte(webpFlagExif), stripped[20])
+	assert.True(t, bytes.Contains(stripped, []byte("image")))
+
+	exifAt := bytes.Index(stripped, []byte("EXIF"))
+	require.NotEqual(t, -1, exifAt)
+	orientation, hasGPS := parseExif(stripped[exifAt+8:])
+	assert.Equal(t, 8, orientation)
+	assert.False(t, hasGPS)
+}
+
+func isoTestBox(boxType string, content ...[]byte) []byte {
+	box := make([]byte, 8)
+	copy(box[4:], boxType)
+	for _, c := range content {
+		box = append(box, c...)
+	}
+	binary.BigEndian.PutUint32(box, uint32(len(box)))
+	return box
+}
+
+func TestStripMetadataHEIF(t *testing.T) {
+	exifPayload := append([]byte{0, 0, 0, 6}, jpegExifHeader...)
+	exifPayload = append(exifPayload, testExif(6)...)
+	xmp := []byte("<x:xmpmeta/>")
+	picture := []byte("image data")
+
+	build := func(mdatStart int) []byte {
+		iinf := isoTestBox("iinf",
+			[]byte{0, 0, 0, 0, 0, 3},
+			isoTestBox("infe", []byte{2, 0, 0, 0, 0, 1, 0, 0}, []byte("hvc1"), []byte("\x00")),
+			isoTestBox("infe", []byte{2, 0, 0, 0, 0, 2, 0, 0}, []byte("Exif"), []byte("\x00")),
+			isoTestBox("infe", []byte{2, 0, 0, 0, 0, 3, 0, 0}, []byte("mime"), []byte("\x00application/rdf+xml\x00")),
+		)
+
+		iloc := []byte{0, 0, 0, 0, 0x44, 0x00, 0, 3}
+		offset := mdatStart
+		for i, length := range []int{len(picture), len(exifPayload), len(xmp)} {
+			item := make([]byte, 14)
+			binary.BigEndian.PutUint16(item[0:], uint16(i+1))
+			binary.BigEndian.PutUint16(item[4:], 1)
+			binary.BigEndian.PutUint32(item[6:], uint32(offset))
+			binary.BigEndian.PutUint32(item[10:], uint32(length))
+			iloc = append(iloc, item...)
+			offset += length
+		}
+
+		data := isoTestBox("ftyp", []byte("heic\x00\x00\x00\x00mif1heic"))
+		data = append(data, isoTestBox("meta", []byte{0, 0, 0, 0}, iinf, isoTestBox("iloc", iloc))...)
+		return append(data, isoTestBox("mdat", picture, exifPayload, xmp)...)
+	}
+	data := build(0)
+	data = build(len(data) - len(picture) - len(exifPayload) - len(xmp))
+
+	stripped, removed, err := StripMetadata(data)
+	require.NoError(t, err)
+	assert.Equal(t, []string{MetadataExif, MetadataGPS, MetadataXMP}, removed)
+	assert.Len(t, stripped, len(data))
+	assert.True(t, bytes.Contains(stripped, picture))
+	assert.False(t, bytes.Contains(stripped, xmp))
+	assert.False(t, bytes.Contains(stripped, exifPayload))
+}
+
+func TestStripMetadataUnsupported(t *testing.T) {
+	data, err := testutils.ReadTestFile("testgif.gif")
+	require.NoError(t, err)
+
+	_, _, err = StripMetadata(data)
+	assert.Equal(t, ErrUnsupportedFormat, err)
+}
@@ -686,6 +686,72 @@ func (c *Context) RequireBotUserId() *Context {
 	return c
 }
 
+func (c *Context) RequirePollId() *Context {
+	if c.Err != nil {
+		return c
+	}
+
+	if !model.IsValidId(c.Params.PollId) {
+		c.SetInvalidUrlParam("poll_id")
+	}
+	return c
+}
+
+func (c *Context) RequireBookmarkId() *Context {
+	if c.Err != nil {
+		return c
+	}
+
+	if !model.IsValidId(c.Params.BookmarkId) {
+		c.SetInvalidUrlParam("bookmark_id")
+	}
+	return c
+}
+
+func (c *Context) RequireEmailId() *Context {
+	if c.Err != nil {
+		return c
+	}
+
+	if !model.IsValidId(c.Params.EmailId) {
+		c.SetInvalidUrlParam("email_id")
+	}
+	return c
+}
+
+func (c *Context) RequireWatchId() *Context {
+	if c.Err != nil {
+		return c
+	}
+
+	if !model.IsValidId(c.Params.WatchId) {
+		c.SetInvalidUrlParam("watch_id")
+	}
+	return c
+}
+
+func (c *Context) RequireSavedSearchId() *Context {
+	if c.Err != nil {
+		return c
+	}
+
+	if !model.IsValidId(c.Params.SavedSearchId) {
+		c.SetInvalidUrlParam("saved_search_id")
+	}
+	return c
+}
+
+func (c *Context) RequirePublicLinkId() *Context {
+	if c.Err != nil {
+		return c
+	}
+
+	if !model.IsValidId(c.Params.PublicLinkId) {
+		c.SetInvalidUrlParam("public_link_id")
+	}
+	return c
+}
+
 func (c *Context) RequireInvoiceId() *Context {
 	if c.Err != nil {
 		return c
@@ -82,6 +82,12 @@ type Params struct {
 	FilterParentTeamPermitted bool
 	CategoryId                string
 	WarnMetricId              string
+	PollId                    string
+	BookmarkId                string
+	EmailId                   string
+	WatchId                   string
+	SavedSearchId             string
+	PublicLinkId              string
 
 	// Cloud
 	InvoiceId string
@@ -345,5 +351,29 @@ func ParamsFromRequest(r *http.Request) *Params {
 		params.WarnMetricId = val
 	}
 
+	if val, ok := props["poll_id"]; ok {
+		params.PollId = val
+	}
+
+	if val, ok := props["bookmark_id"]; ok {
+		params.BookmarkId = val
+	}
+
+	if val, ok := props["email_id"]; ok {
+		params.EmailId = val
+	}
+
+	if val, ok := props["watch_id"]; ok {
+		params.WatchId = val
+	}
+
+	if val, ok := props["saved_search_id"]; ok {
+		params.SavedSearchId = val
+	}
+
+	if val, ok := props["public_link_id"]; ok {
+		params.PublicLinkId = val
+	}
+
 	return params
 }